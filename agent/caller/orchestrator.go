@@ -277,13 +277,16 @@ func (o *Orchestrator) callAgentWithContext(ctx *agentContext.Context, req *Requ
 		)
 	}
 
+	ctx.Logger.A2AStart(req.AgentID)
 	resp, err := agent.Stream(ctx, req.Messages, ctxOpts)
 	if err != nil {
+		ctx.Logger.A2AComplete(req.AgentID, false)
 		if a2aNode != nil {
 			a2aNode.Fail(err)
 		}
 		return NewResult(req.AgentID, nil, fmt.Errorf("agent call failed: %w", err))
 	}
+	ctx.Logger.A2AComplete(req.AgentID, true)
 
 	if a2aNode != nil {
 		a2aNode.Complete(map[string]any{
@@ -523,6 +523,49 @@ func (l *RequestLogger) HookComplete(hookName string) {
 	fmt.Printf("%s    + %s done%s\n", colorGreen, hookName, colorReset)
 }
 
+// A2AStart logs the start of an agent-to-agent call
+func (l *RequestLogger) A2AStart(targetAssistantID string) {
+	if l.noop {
+		return
+	}
+
+	l.mu.RLock()
+	caller := l.currentAssistantID()
+	l.mu.RUnlock()
+
+	elapsed := time.Since(l.startTime).Round(time.Millisecond)
+	kunlog.Trace("[AGENT] %s A2A call: %s -> %s (+%v)", l.shortID, caller, targetAssistantID, elapsed)
+
+	if !config.IsDevelopment() {
+		return
+	}
+
+	fmt.Printf("%s  A2A: %s%s %s[+%v]%s\n", colorCyan, targetAssistantID, colorReset, colorGray, elapsed, colorReset)
+}
+
+// A2AComplete logs the completion of an agent-to-agent call
+func (l *RequestLogger) A2AComplete(targetAssistantID string, success bool) {
+	if l.noop {
+		return
+	}
+
+	if success {
+		kunlog.Trace("[AGENT] %s A2A completed: %s", l.shortID, targetAssistantID)
+	} else {
+		kunlog.Error("[AGENT] %s A2A failed: %s", l.shortID, targetAssistantID)
+	}
+
+	if !config.IsDevelopment() {
+		return
+	}
+
+	if success {
+		fmt.Printf("%s    + %s completed%s\n", colorGreen, targetAssistantID, colorReset)
+	} else {
+		fmt.Printf("%s    x %s failed%s\n", colorRed, targetAssistantID, colorReset)
+	}
+}
+
 // Cleanup logs resource cleanup
 func (l *RequestLogger) Cleanup(resource string) {
 	if l.noop {
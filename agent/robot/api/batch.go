@@ -0,0 +1,179 @@
+package api
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/yaoapp/yao/agent/robot/types"
+)
+
+// ==================== Batch Trigger API ====================
+// A batch runs the same robot once per parameter set; the manager dispatches
+// items lazily within the robot's quota and persists per-item progress.
+
+// TriggerBatch creates a batch of executions for a robot, one per parameter set
+func TriggerBatch(ctx *types.Context, memberID string, req *BatchTriggerRequest) (*BatchResult, error) {
+	if memberID == "" {
+		return nil, fmt.Errorf("member_id is required")
+	}
+	if req == nil {
+		return nil, fmt.Errorf("batch request is required")
+	}
+	if len(req.Items) == 0 {
+		return nil, types.ErrBatchEmpty
+	}
+	if len(req.Items) > types.MaxBatchItems {
+		return nil, fmt.Errorf("%w: %d > %d", types.ErrBatchTooLarge, len(req.Items), types.MaxBatchItems)
+	}
+
+	mgr, err := getManager()
+	if err != nil {
+		return nil, err
+	}
+
+	batch, err := mgr.SubmitBatch(ctx, memberID, req.Items, req.SuppressDelivery)
+	if err != nil {
+		return nil, err
+	}
+	return newBatchResult(batch), nil
+}
+
+// GetBatch returns the batch state and progress
+func GetBatch(ctx *types.Context, batchID string) (*BatchResult, error) {
+	if batchID == "" {
+		return nil, fmt.Errorf("batch_id is required")
+	}
+
+	mgr, err := getManager()
+	if err != nil {
+		return nil, err
+	}
+
+	batch, err := mgr.GetBatch(ctx, batchID)
+	if err != nil {
+		return nil, err
+	}
+	return newBatchResult(batch), nil
+}
+
+// CancelBatch cancels the items of a batch that have not been dispatched yet
+func CancelBatch(ctx *types.Context, batchID string) (*BatchResult, error) {
+	if batchID == "" {
+		return nil, fmt.Errorf("batch_id is required")
+	}
+
+	mgr, err := getManager()
+	if err != nil {
+		return nil, err
+	}
+
+	batch, err := mgr.CancelBatch(ctx, batchID)
+	if err != nil {
+		return nil, err
+	}
+	return newBatchResult(batch), nil
+}
+
+// GetBatchSummaryCSV returns the consolidated per-item results of a batch as CSV
+func GetBatchSummaryCSV(ctx *types.Context, batchID string) ([]byte, error) {
+	if batchID == "" {
+		return nil, fmt.Errorf("batch_id is required")
+	}
+
+	mgr, err := getManager()
+	if err != nil {
+		return nil, err
+	}
+
+	batch, err := mgr.GetBatch(ctx, batchID)
+	if err != nil {
+		return nil, err
+	}
+	return BatchSummaryCSV(batch)
+}
+
+// BatchSummaryCSV renders one row per batch item: index, status, execution_id,
+// error, start_time, end_time, followed by one column per parameter name (sorted)
+func BatchSummaryCSV(batch *types.Batch) ([]byte, error) {
+	if batch == nil {
+		return nil, types.ErrBatchNotFound
+	}
+
+	paramKeys := collectParamKeys(batch.Items)
+	header := append([]string{"index", "status", "execution_id", "error", "start_time", "end_time"}, paramKeys...)
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+
+	for _, item := range batch.Items {
+		row := []string{
+			strconv.Itoa(item.Index),
+			string(item.Status),
+			item.ExecutionID,
+			item.Error,
+			formatBatchTime(item.StartTime),
+			formatBatchTime(item.EndTime),
+		}
+		for _, key := range paramKeys {
+			if v, ok := item.Params[key]; ok && v != nil {
+				row = append(row, fmt.Sprint(v))
+			} else {
+				row = append(row, "")
+			}
+		}
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// newBatchResult converts a batch to the API result
+func newBatchResult(batch *types.Batch) *BatchResult {
+	return &BatchResult{
+		BatchID:          batch.BatchID,
+		MemberID:         batch.MemberID,
+		TeamID:           batch.TeamID,
+		Status:           batch.Status,
+		SuppressDelivery: batch.SuppressDelivery,
+		Progress:         batch.Progress(),
+		Items:            batch.Items,
+		CreatedAt:        batch.CreatedAt,
+		CompletedAt:      batch.CompletedAt,
+	}
+}
+
+// collectParamKeys returns the sorted union of parameter names across items
+func collectParamKeys(items []*types.BatchItem) []string {
+	seen := map[string]bool{}
+	keys := []string{}
+	for _, item := range items {
+		for k := range item.Params {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatBatchTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
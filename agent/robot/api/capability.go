@@ -0,0 +1,25 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/yaoapp/yao/agent/robot/capability"
+	"github.com/yaoapp/yao/agent/robot/types"
+)
+
+// ==================== Capability API ====================
+
+// GetCapabilities returns the robot's effective capability descriptor
+// (allowed agents, MCP tools, processes, delivery channels, parameter schema, quota)
+func GetCapabilities(ctx *types.Context, memberID string) (*types.CapabilityDescriptor, error) {
+	if memberID == "" {
+		return nil, fmt.Errorf("member_id is required")
+	}
+
+	robot, err := GetRobot(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	return capability.Build(ctx, robot), nil
+}
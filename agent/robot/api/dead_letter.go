@@ -0,0 +1,40 @@
+package api
+
+import (
+	robotevents "github.com/yaoapp/yao/agent/robot/events"
+	"github.com/yaoapp/yao/agent/robot/store"
+	"github.com/yaoapp/yao/agent/robot/types"
+)
+
+// DeadLetterList - a page of dead-lettered deliveries
+type DeadLetterList struct {
+	Data     []*store.DeadLetter `json:"data"`
+	Total    int                 `json:"total"`
+	Page     int                 `json:"page"`
+	PageSize int                 `json:"pagesize"`
+}
+
+// ListDeadLetters lists webhook deliveries that failed every attempt, newest first.
+// Payloads are left out, use the letter ID to redeliver.
+func ListDeadLetters(ctx *types.Context, opts *store.DeadLetterListOptions) (*DeadLetterList, error) {
+	if opts == nil {
+		opts = &store.DeadLetterListOptions{}
+	}
+	if opts.Page <= 0 {
+		opts.Page = 1
+	}
+	if opts.PageSize <= 0 {
+		opts.PageSize = 20
+	}
+
+	letters, total, err := robotevents.ListDeadLetters(ctx.Context, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &DeadLetterList{Data: letters, Total: total, Page: opts.Page, PageSize: opts.PageSize}, nil
+}
+
+// RedeliverDeadLetter replays a dead-lettered webhook delivery to its target
+func RedeliverDeadLetter(ctx *types.Context, letterID string) (*types.ChannelResult, error) {
+	return robotevents.Redeliver(ctx.Context, letterID)
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/yaoapp/yao/agent/robot/store"
+	"github.com/yaoapp/yao/agent/robot/types"
+)
+
+// defaultDeliveryMetricsWindow is the lookback used when DeliveryMetricsQuery.Since is unset
+const defaultDeliveryMetricsWindow = 7 * 24 * time.Hour
+
+// DeliveryMetricsQuery - scope of the delivery metrics
+type DeliveryMetricsQuery struct {
+	TeamID   string     `json:"team_id,omitempty"`
+	MemberID string     `json:"member_id,omitempty"`
+	Since    *time.Time `json:"since,omitempty"` // default: 7 days ago
+	Limit    int        `json:"limit,omitempty"` // most recent executions considered (default 1000, max 10000)
+}
+
+// DeliveryMetrics - delivery health aggregated over recent executions
+type DeliveryMetrics struct {
+	Since      time.Time         `json:"since"`
+	Executions int               `json:"executions"` // executions with recorded delivery results
+	Channels   []*ChannelMetrics `json:"channels"`   // one entry per channel type, sorted by type
+}
+
+// ChannelMetrics - outcome counts and latency of one delivery channel type
+type ChannelMetrics struct {
+	Type         types.DeliveryType `json:"type"`
+	Total        int                `json:"total"`
+	Succeeded    int                `json:"succeeded"`
+	Failed       int                `json:"failed"`
+	SuccessRate  float64            `json:"success_rate"`    // 0-1
+	LatencyP50Ms int64              `json:"latency_p50_ms"`  // dispatch to result
+	LatencyP95Ms int64              `json:"latency_p95_ms"`  //
+	LatencyMaxMs int64              `json:"latency_max_ms"`  //
+	Samples      int                `json:"latency_samples"` // results that carried a duration
+}
+
+// GetDeliveryMetrics aggregates the per-channel delivery history recorded on executions
+func GetDeliveryMetrics(ctx *types.Context, query *DeliveryMetricsQuery) (*DeliveryMetrics, error) {
+	if query == nil {
+		query = &DeliveryMetricsQuery{}
+	}
+	since := time.Now().Add(-defaultDeliveryMetricsWindow)
+	if query.Since != nil {
+		since = *query.Since
+	}
+
+	records, err := getExecutionStore().ListDeliveryHistory(ctx.Context, &store.DeliveryHistoryOptions{
+		TeamID:   query.TeamID,
+		MemberID: query.MemberID,
+		Since:    &since,
+		Limit:    query.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load delivery history: %w", err)
+	}
+
+	metrics := aggregateDeliveryMetrics(records)
+	metrics.Since = since
+	return metrics, nil
+}
+
+// aggregateDeliveryMetrics counts outcomes and computes latency percentiles per channel type.
+// Results recorded before latency was tracked count towards the outcomes only.
+func aggregateDeliveryMetrics(records []*store.ExecutionRecord) *DeliveryMetrics {
+	metrics := &DeliveryMetrics{Channels: []*ChannelMetrics{}}
+	channels := map[types.DeliveryType]*ChannelMetrics{}
+	latencies := map[types.DeliveryType][]int64{}
+
+	for _, record := range records {
+		if len(record.DeliveryResults) == 0 {
+			continue
+		}
+		metrics.Executions++
+		for _, result := range record.DeliveryResults {
+			channel, ok := channels[result.Type]
+			if !ok {
+				channel = &ChannelMetrics{Type: result.Type}
+				channels[result.Type] = channel
+				metrics.Channels = append(metrics.Channels, channel)
+			}
+			channel.Total++
+			if result.Success {
+				channel.Succeeded++
+			} else {
+				channel.Failed++
+			}
+			if result.DurationMs > 0 {
+				latencies[result.Type] = append(latencies[result.Type], result.DurationMs)
+			}
+		}
+	}
+
+	for _, channel := range metrics.Channels {
+		if channel.Total > 0 {
+			channel.SuccessRate = float64(channel.Succeeded) / float64(channel.Total)
+		}
+		samples := latencies[channel.Type]
+		if len(samples) == 0 {
+			continue
+		}
+		sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+		channel.Samples = len(samples)
+		channel.LatencyP50Ms = percentile(samples, 50)
+		channel.LatencyP95Ms = percentile(samples, 95)
+		channel.LatencyMaxMs = samples[len(samples)-1]
+	}
+
+	sort.Slice(metrics.Channels, func(i, j int) bool { return metrics.Channels[i].Type < metrics.Channels[j].Type })
+	return metrics
+}
+
+// percentile returns the nearest-rank percentile of sorted samples
+func percentile(sorted []int64, p int) int64 {
+	rank := (p*len(sorted) + 99) / 100 // ceil(p/100 * n)
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
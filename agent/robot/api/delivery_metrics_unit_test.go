@@ -0,0 +1,59 @@
+//go:build unit
+
+package api_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/yao/agent/robot/api"
+	"github.com/yaoapp/yao/agent/robot/store"
+	"github.com/yaoapp/yao/agent/robot/types"
+)
+
+func TestAggregateDeliveryMetrics(t *testing.T) {
+	records := []*store.ExecutionRecord{}
+	// 20 email deliveries taking 10, 20, ... 200ms; the last two failed
+	for i := 1; i <= 20; i++ {
+		records = append(records, &store.ExecutionRecord{
+			ExecutionID: "exec_email",
+			DeliveryResults: []types.ChannelResult{
+				{Type: types.DeliveryEmail, Success: i <= 18, DurationMs: int64(i * 10)},
+			},
+		})
+	}
+	records = append(records,
+		&store.ExecutionRecord{ExecutionID: "exec_webhook", DeliveryResults: []types.ChannelResult{
+			{Type: types.DeliveryWebhook, Success: true, DurationMs: 300},
+			{Type: types.DeliveryWebhook, Success: false}, // recorded before latency tracking
+		}},
+		&store.ExecutionRecord{ExecutionID: "exec_none"},
+	)
+
+	metrics := api.AggregateDeliveryMetrics(records)
+	assert.Equal(t, 21, metrics.Executions)
+	require.Len(t, metrics.Channels, 2)
+
+	email := metrics.Channels[0]
+	assert.Equal(t, types.DeliveryEmail, email.Type)
+	assert.Equal(t, 20, email.Total)
+	assert.Equal(t, 18, email.Succeeded)
+	assert.Equal(t, 2, email.Failed)
+	assert.InDelta(t, 0.9, email.SuccessRate, 0.0001)
+	assert.Equal(t, int64(100), email.LatencyP50Ms)
+	assert.Equal(t, int64(190), email.LatencyP95Ms)
+	assert.Equal(t, int64(200), email.LatencyMaxMs)
+	assert.Equal(t, 20, email.Samples)
+
+	webhook := metrics.Channels[1]
+	assert.Equal(t, types.DeliveryWebhook, webhook.Type)
+	assert.Equal(t, 2, webhook.Total)
+	assert.Equal(t, 1, webhook.Samples)
+	assert.Equal(t, int64(300), webhook.LatencyP50Ms)
+	assert.Equal(t, int64(300), webhook.LatencyP95Ms)
+
+	empty := api.AggregateDeliveryMetrics(nil)
+	assert.Equal(t, 0, empty.Executions)
+	assert.Empty(t, empty.Channels)
+}
@@ -3,7 +3,10 @@ package api
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
+	"time"
+	"unicode/utf8"
 
 	"github.com/yaoapp/yao/agent/robot/store"
 	"github.com/yaoapp/yao/agent/robot/types"
@@ -62,31 +65,93 @@ func ListExecutions(ctx *types.Context, memberID string, query *ExecutionQuery)
 	}
 	query.applyDefaults()
 
-	opts := &store.ListOptions{
-		MemberID: memberID,
-		Page:     query.Page,
-		PageSize: query.PageSize,
-		OrderBy:  "start_time desc",
+	opts := query.listOptions()
+	opts.MemberID = memberID
+	opts.OrderBy = "start_time desc"
+
+	var result *store.ListResult
+	var err error
+	if len(query.Statuses) > 1 {
+		result, err = getExecutionStore().ListByStatuses(context.Background(), query.Statuses, opts)
+	} else {
+		result, err = getExecutionStore().List(context.Background(), opts)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list executions: %w", err)
+	}
+	return newExecutionResult(result, query.Detail), nil
+}
+
+// ListTeamExecutions returns the executions of all robots in a team, newest first
+func ListTeamExecutions(ctx *types.Context, teamID string, query *ExecutionQuery) (*ExecutionResult, error) {
+	if teamID == "" {
+		return nil, fmt.Errorf("team_id is required")
+	}
+
+	if query == nil {
+		query = &ExecutionQuery{}
 	}
+	query.applyDefaults()
 
-	if query.Status != "" {
-		opts.Status = query.Status
+	opts := query.listOptions()
+	opts.OrderBy = "start_time desc"
+	result, err := getExecutionStore().ListExecutionsByTeam(context.Background(), teamID, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list team executions: %w", err)
 	}
-	if len(query.ExcludeStatuses) > 0 {
-		opts.ExcludeStatuses = query.ExcludeStatuses
+	return newExecutionResult(result, query.Detail), nil
+}
+
+// ListChatExecutions returns the executions of one conversation, oldest first
+func ListChatExecutions(ctx *types.Context, chatID string, query *ExecutionQuery) (*ExecutionResult, error) {
+	if chatID == "" {
+		return nil, fmt.Errorf("chat_id is required")
 	}
-	if query.Trigger != "" {
-		opts.TriggerType = query.Trigger
+
+	if query == nil {
+		query = &ExecutionQuery{}
 	}
+	query.applyDefaults()
 
-	result, err := getExecutionStore().List(context.Background(), opts)
+	result, err := getExecutionStore().ListExecutionsByChat(context.Background(), chatID, query.listOptions())
 	if err != nil {
-		return nil, fmt.Errorf("failed to list executions: %w", err)
+		return nil, fmt.Errorf("failed to list chat executions: %w", err)
+	}
+	return newExecutionResult(result, query.Detail), nil
+}
+
+// listOptions maps the query filters and paging onto store options
+func (q *ExecutionQuery) listOptions() *store.ListOptions {
+	opts := &store.ListOptions{
+		Page:     q.Page,
+		PageSize: q.PageSize,
+	}
+	if len(q.Statuses) > 0 {
+		opts.Statuses = q.Statuses
+	} else if q.Status != "" {
+		opts.Status = q.Status
+	}
+	if len(q.ExcludeStatuses) > 0 {
+		opts.ExcludeStatuses = q.ExcludeStatuses
+	}
+	if q.Trigger != "" {
+		opts.TriggerType = q.Trigger
 	}
+	opts.From = q.From
+	opts.To = q.To
+	return opts
+}
 
+// newExecutionResult converts a store page into the API result.
+// Unless detail is set the heavy per-task fields are dropped (see briefExecution).
+func newExecutionResult(result *store.ListResult, detail bool) *ExecutionResult {
 	executions := make([]*types.Execution, 0, len(result.Data))
 	for _, record := range result.Data {
-		executions = append(executions, record.ToExecution())
+		exec := record.ToExecution()
+		if !detail {
+			briefExecution(exec)
+		}
+		executions = append(executions, exec)
 	}
 
 	return &ExecutionResult{
@@ -94,7 +159,27 @@ func ListExecutions(ctx *types.Context, memberID string, query *ExecutionQuery)
 		Total:    result.Total,
 		Page:     result.Page,
 		PageSize: result.PageSize,
-	}, nil
+	}
+}
+
+// briefExecution drops tasks, results and resume_context from a list entry.
+// Progress is derived from the tasks first, so list views keep their progress bar.
+func briefExecution(exec *types.Execution) {
+	if exec.Current == nil && len(exec.Tasks) > 0 {
+		done := 0
+		for _, task := range exec.Tasks {
+			if task.Status == types.TaskCompleted || task.Status == types.TaskFailed || task.Status == types.TaskSkipped {
+				done++
+			}
+		}
+		exec.Current = &types.CurrentState{
+			TaskIndex: len(exec.Tasks),
+			Progress:  fmt.Sprintf("%d/%d", done, len(exec.Tasks)),
+		}
+	}
+	exec.Tasks = nil
+	exec.Results = nil
+	exec.ResumeContext = nil
 }
 
 // ==================== Execution Control API ====================
@@ -111,12 +196,8 @@ func PauseExecution(ctx *types.Context, execID string) error {
 		return err
 	}
 
-	if err := mgr.PauseExecution(ctx, execID); err != nil {
-		return err
-	}
-
-	// Update database status to paused
-	return getExecutionStore().UpdateStatus(context.Background(), execID, types.ExecPaused, "")
+	// The manager persists the paused status
+	return mgr.PauseExecution(ctx, execID)
 }
 
 // ResumeExecution resumes a paused execution
@@ -130,12 +211,7 @@ func ResumeExecution(ctx *types.Context, execID string) error {
 		return err
 	}
 
-	if err := mgr.ResumeExecution(ctx, execID); err != nil {
-		return err
-	}
-
-	// Update database status back to running
-	return getExecutionStore().UpdateStatus(context.Background(), execID, types.ExecRunning, "")
+	return mgr.ResumeExecution(ctx, execID)
 }
 
 // StopExecution stops a running execution
@@ -157,6 +233,100 @@ func StopExecution(ctx *types.Context, execID string) error {
 	return getExecutionStore().UpdateStatus(context.Background(), execID, types.ExecCancelled, "User cancelled")
 }
 
+// ResetExecution forces a stuck execution into a final status (failed or cancelled),
+// clearing its waiting and tracking state. Restrict to the robot owner or an admin.
+func ResetExecution(ctx *types.Context, execID string, status types.ExecStatus, reason string) (*types.Execution, error) {
+	mgr, err := getManager()
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := mgr.ResetExecution(ctx, execID, status, reason)
+	if err != nil {
+		return nil, err
+	}
+	return record.ToExecution(), nil
+}
+
+// RetryExecution runs a failed or cancelled execution again with its goals and task
+// plan, as a new execution. Returns the new execution ID.
+func RetryExecution(ctx *types.Context, execID string) (string, error) {
+	mgr, err := getManager()
+	if err != nil {
+		return "", err
+	}
+	return mgr.RetryExecution(ctx, execID)
+}
+
+// ExecutionCleanupResult - outcome of CleanupExecutions
+type ExecutionCleanupResult struct {
+	OlderThan string `json:"older_than"`
+	DryRun    bool   `json:"dry_run"`
+	Count     int64  `json:"count"` // records deleted, or that would be deleted on a dry run
+}
+
+// CleanupExecutions deletes finished executions (completed, failed, cancelled) that
+// started more than olderThan ago. With dryRun it only counts them.
+func CleanupExecutions(ctx *types.Context, olderThan time.Duration, dryRun bool) (*ExecutionCleanupResult, error) {
+	result := &ExecutionCleanupResult{OlderThan: olderThan.String(), DryRun: dryRun}
+
+	var err error
+	if dryRun {
+		result.Count, err = getExecutionStore().CountOldExecutions(ctx.Context, olderThan)
+	} else {
+		result.Count, err = getExecutionStore().CleanupOldExecutions(ctx.Context, olderThan)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ==================== Execution Notes API ====================
+
+// AddExecutionNote attaches an operator note (e.g. "re-ran because of bad input") to an execution.
+// Notes are kept apart from the robot's own outputs and returned with the execution.
+func AddExecutionNote(ctx *types.Context, execID, author, note string) ([]types.ExecutionNote, error) {
+	if execID == "" {
+		return nil, fmt.Errorf("execution_id is required")
+	}
+	note = strings.TrimSpace(note)
+	if note == "" {
+		return nil, fmt.Errorf("%w: note is required", types.ErrInvalidExecutionNote)
+	}
+	if utf8.RuneCountInString(note) > types.MaxExecutionNoteLength {
+		return nil, fmt.Errorf("%w: note exceeds %d characters", types.ErrInvalidExecutionNote, types.MaxExecutionNoteLength)
+	}
+	if author == "" && ctx != nil {
+		author = ctx.UserID()
+	}
+
+	return getExecutionStore().AddNote(context.Background(), execID, types.ExecutionNote{
+		Author:    author,
+		Note:      note,
+		CreatedAt: time.Now(),
+	})
+}
+
+// GetExecutionPlan returns the editable goals and tasks of a confirming execution
+func GetExecutionPlan(ctx *types.Context, execID string) (*types.ExecutionPlan, error) {
+	mgr, err := getManager()
+	if err != nil {
+		return nil, fmt.Errorf("plan editing not available: %w", err)
+	}
+	return mgr.GetPlan(ctx, execID)
+}
+
+// UpdateExecutionPlan replaces the plan of a confirming execution.
+// plan.Version must match the current plan version (see GetExecutionPlan).
+func UpdateExecutionPlan(ctx *types.Context, execID string, plan *types.ExecutionPlan) (*types.ExecutionPlan, error) {
+	mgr, err := getManager()
+	if err != nil {
+		return nil, fmt.Errorf("plan editing not available: %w", err)
+	}
+	return mgr.UpdatePlan(ctx, execID, plan)
+}
+
 // ==================== Execution Status API ====================
 
 // GetExecutionStatus returns the current status of an execution
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yaoapp/yao/agent/robot/store"
+	"github.com/yaoapp/yao/agent/robot/types"
+)
+
+// ExecutionOutcome - stable result shape of one execution for API consumers,
+// decoupled from the stored phase data
+type ExecutionOutcome struct {
+	ExecutionID string            `json:"execution_id"`
+	MemberID    string            `json:"member_id"`
+	TeamID      string            `json:"team_id,omitempty"`
+	TriggerType types.TriggerType `json:"trigger_type"`
+	Name        string            `json:"name,omitempty"`
+	Status      types.ExecStatus  `json:"status"`
+	Finished    bool              `json:"finished"`        // completed, failed or cancelled: the result will not change
+	Error       string            `json:"error,omitempty"` // execution error or cancel reason
+	Tasks       []*TaskOutcome    `json:"tasks"`           // in plan order
+	Delivery    *DeliveryOutcome  `json:"delivery,omitempty"`
+	Timing      ExecutionTiming   `json:"timing"`
+}
+
+// TaskOutcome - what one planned task produced
+type TaskOutcome struct {
+	TaskID      string           `json:"task_id"`
+	Description string           `json:"description,omitempty"`
+	Status      types.TaskStatus `json:"status"`
+	Output      interface{}      `json:"output,omitempty"`
+	Error       string           `json:"error,omitempty"`
+	DurationMs  int64            `json:"duration_ms,omitempty"`
+}
+
+// DeliveryOutcome - the final report and how its delivery went
+type DeliveryOutcome struct {
+	Summary     string                `json:"summary,omitempty"`
+	Body        string                `json:"body,omitempty"`
+	Attachments int                   `json:"attachments"`
+	Status      types.DeliveryStatus  `json:"status,omitempty"` // pending until the channels report back
+	Channels    []types.ChannelResult `json:"channels,omitempty"`
+}
+
+// ExecutionTiming - wall-clock timing of an execution
+type ExecutionTiming struct {
+	StartTime  *time.Time `json:"start_time,omitempty"`
+	EndTime    *time.Time `json:"end_time,omitempty"`
+	DurationMs int64      `json:"duration_ms"` // up to now while the execution is running
+}
+
+// GetExecutionResult returns the typed result of an execution
+func GetExecutionResult(ctx *types.Context, execID string) (*ExecutionOutcome, error) {
+	if execID == "" {
+		return nil, fmt.Errorf("execution_id is required")
+	}
+
+	record, err := getExecutionStore().Get(context.Background(), execID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get execution: %w", err)
+	}
+	if record == nil {
+		return nil, fmt.Errorf("execution not found: %s", execID)
+	}
+
+	return buildExecutionOutcome(record, time.Now()), nil
+}
+
+// buildExecutionOutcome maps a stored record to its result shape
+func buildExecutionOutcome(record *store.ExecutionRecord, now time.Time) *ExecutionOutcome {
+	outcome := &ExecutionOutcome{
+		ExecutionID: record.ExecutionID,
+		MemberID:    record.MemberID,
+		TeamID:      record.TeamID,
+		TriggerType: record.TriggerType,
+		Name:        record.Name,
+		Status:      record.Status,
+		Finished:    record.Status.IsTerminal(),
+		Error:       record.Error,
+		Tasks:       make([]*TaskOutcome, 0, len(record.Tasks)),
+		Timing:      ExecutionTiming{StartTime: record.StartTime, EndTime: record.EndTime},
+	}
+
+	if outcome.Error == "" && record.Status == types.ExecCancelled {
+		outcome.Error = record.CancelReason
+	}
+
+	if record.StartTime != nil {
+		end := now
+		if record.EndTime != nil {
+			end = *record.EndTime
+		}
+		outcome.Timing.DurationMs = end.Sub(*record.StartTime).Milliseconds()
+	}
+
+	results := make(map[string]*types.TaskResult, len(record.Results))
+	for i := range record.Results {
+		results[record.Results[i].TaskID] = &record.Results[i]
+	}
+	for _, task := range record.Tasks {
+		item := &TaskOutcome{
+			TaskID:      task.ID,
+			Description: task.Description,
+			Status:      task.Status,
+		}
+		if result, ok := results[task.ID]; ok {
+			item.Output = result.Output
+			item.Error = result.Error
+			item.DurationMs = result.Duration
+			// Results are authoritative when the task status was not persisted with them
+			switch {
+			case result.Skipped:
+				item.Status = types.TaskSkipped
+			case result.Success && item.Status != types.TaskCompleted:
+				item.Status = types.TaskCompleted
+			case !result.Success && item.Status == types.TaskPending:
+				item.Status = types.TaskFailed
+			}
+		}
+		outcome.Tasks = append(outcome.Tasks, item)
+	}
+
+	if record.Delivery != nil || len(record.DeliveryResults) > 0 {
+		delivery := &DeliveryOutcome{Status: record.DeliveryStatus, Channels: record.DeliveryResults}
+		if record.Delivery != nil {
+			if content := record.Delivery.Content; content != nil {
+				delivery.Summary = content.Summary
+				delivery.Body = content.Body
+				delivery.Attachments = len(content.Attachments)
+			}
+			if len(delivery.Channels) == 0 {
+				delivery.Channels = record.Delivery.Results
+			}
+		}
+		outcome.Delivery = delivery
+	}
+
+	return outcome
+}
@@ -0,0 +1,125 @@
+//go:build unit
+
+package api_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/yao/agent/robot/api"
+	"github.com/yaoapp/yao/agent/robot/store"
+	"github.com/yaoapp/yao/agent/robot/types"
+)
+
+func TestBuildExecutionOutcomeCompleted(t *testing.T) {
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	end := start.Add(90 * time.Second)
+	record := &store.ExecutionRecord{
+		ExecutionID: "exec_1",
+		MemberID:    "robot_1",
+		TeamID:      "team_1",
+		TriggerType: types.TriggerClock,
+		Name:        "Daily report",
+		Status:      types.ExecCompleted,
+		Tasks: []types.Task{
+			{ID: "task-001", Description: "Collect", Status: types.TaskCompleted},
+			{ID: "task-002", Description: "Escalate", Status: types.TaskPending},
+			{ID: "task-003", Description: "Notify", Status: types.TaskPending},
+		},
+		Results: []types.TaskResult{
+			{TaskID: "task-001", Success: true, Output: map[string]interface{}{"count": 3}, Duration: 1200},
+			{TaskID: "task-002", Success: true, Skipped: true},
+			{TaskID: "task-003", Success: false, Error: "smtp down", Duration: 50},
+		},
+		Delivery: &types.DeliveryResult{
+			Content: &types.DeliveryContent{
+				Summary:     "3 new leads",
+				Body:        "# Report",
+				Attachments: []types.DeliveryAttachment{{Title: "leads.csv"}},
+			},
+		},
+		DeliveryStatus:  types.DeliveryStatusPartial,
+		DeliveryResults: []types.ChannelResult{{Type: types.DeliveryEmail, Success: true}, {Type: types.DeliveryWebhook, Success: false}},
+		StartTime:       &start,
+		EndTime:         &end,
+	}
+
+	outcome := api.BuildExecutionOutcomeAt(record, end.Add(time.Hour))
+	assert.Equal(t, "exec_1", outcome.ExecutionID)
+	assert.True(t, outcome.Finished)
+	assert.Equal(t, int64(90_000), outcome.Timing.DurationMs)
+
+	require.Len(t, outcome.Tasks, 3)
+	assert.Equal(t, types.TaskCompleted, outcome.Tasks[0].Status)
+	assert.Equal(t, map[string]interface{}{"count": 3}, outcome.Tasks[0].Output)
+	assert.Equal(t, int64(1200), outcome.Tasks[0].DurationMs)
+	assert.Equal(t, types.TaskSkipped, outcome.Tasks[1].Status)
+	assert.Equal(t, types.TaskFailed, outcome.Tasks[2].Status)
+	assert.Equal(t, "smtp down", outcome.Tasks[2].Error)
+
+	require.NotNil(t, outcome.Delivery)
+	assert.Equal(t, "3 new leads", outcome.Delivery.Summary)
+	assert.Equal(t, 1, outcome.Delivery.Attachments)
+	assert.Equal(t, types.DeliveryStatusPartial, outcome.Delivery.Status)
+	assert.Len(t, outcome.Delivery.Channels, 2)
+}
+
+func TestBuildExecutionOutcomeRunningAndCancelled(t *testing.T) {
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	record := &store.ExecutionRecord{
+		ExecutionID: "exec_2",
+		Status:      types.ExecRunning,
+		Tasks:       []types.Task{{ID: "task-001", Status: types.TaskRunning}},
+		StartTime:   &start,
+	}
+
+	outcome := api.BuildExecutionOutcomeAt(record, start.Add(5*time.Second))
+	assert.False(t, outcome.Finished)
+	assert.Equal(t, int64(5000), outcome.Timing.DurationMs)
+	assert.Nil(t, outcome.Delivery)
+	require.Len(t, outcome.Tasks, 1)
+	assert.Equal(t, types.TaskRunning, outcome.Tasks[0].Status)
+
+	record.Status = types.ExecCancelled
+	record.CancelReason = "no longer needed"
+	outcome = api.BuildExecutionOutcomeAt(record, start.Add(5*time.Second))
+	assert.True(t, outcome.Finished)
+	assert.Equal(t, "no longer needed", outcome.Error)
+}
+
+func TestBriefExecution(t *testing.T) {
+	t.Run("drops heavy fields and keeps progress", func(t *testing.T) {
+		exec := &types.Execution{
+			ID: "exec_1",
+			Tasks: []types.Task{
+				{ID: "task-001", Status: types.TaskCompleted},
+				{ID: "task-002", Status: types.TaskSkipped},
+				{ID: "task-003", Status: types.TaskPending},
+			},
+			Results:       []types.TaskResult{{TaskID: "task-001", Success: true}},
+			ResumeContext: &types.ResumeContext{},
+		}
+
+		api.ExportBriefExecution(exec)
+
+		assert.Nil(t, exec.Tasks)
+		assert.Nil(t, exec.Results)
+		assert.Nil(t, exec.ResumeContext)
+		require.NotNil(t, exec.Current)
+		assert.Equal(t, "2/3", exec.Current.Progress)
+	})
+
+	t.Run("keeps recorded progress", func(t *testing.T) {
+		exec := &types.Execution{
+			Current: &types.CurrentState{TaskIndex: 1, Progress: "1/4"},
+			Tasks:   []types.Task{{ID: "task-001", Status: types.TaskCompleted}},
+		}
+
+		api.ExportBriefExecution(exec)
+
+		assert.Equal(t, "1/4", exec.Current.Progress)
+		assert.Nil(t, exec.Tasks)
+	})
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"fmt"
+	"time"
+
+	robotevents "github.com/yaoapp/yao/agent/robot/events"
+	"github.com/yaoapp/yao/agent/robot/types"
+	"github.com/yaoapp/yao/event"
+	eventtypes "github.com/yaoapp/yao/event/types"
+)
+
+// executionStreamPoll is how often StreamExecution re-reads the record. Subscriptions
+// drop events when their buffer is full, so a missed terminal event is still noticed.
+const executionStreamPoll = 5 * time.Second
+
+// executionStreamTypes maps robot events to the stream entry types
+var executionStreamTypes = map[string]string{
+	robotevents.PhaseChanged:  "phase",
+	robotevents.TaskCompleted: "task_completed",
+	robotevents.TaskFailed:    "task_failed",
+	robotevents.TaskNeedInput: "need_input",
+	robotevents.ExecWaiting:   "waiting",
+	robotevents.ExecResumed:   "resumed",
+	robotevents.ExecCompleted: "status",
+	robotevents.ExecFailed:    "status",
+	robotevents.ExecCancelled: "status",
+}
+
+// ExecutionStreamEvent - one entry of an execution's live stream
+type ExecutionStreamEvent struct {
+	Type        string           `json:"type"` // status | phase | task_completed | task_failed | need_input | waiting | resumed | done
+	ExecutionID string           `json:"execution_id,omitempty"`
+	Status      types.ExecStatus `json:"status,omitempty"` // set on "status"
+	Data        interface{}      `json:"data,omitempty"`   // the robot event payload
+}
+
+// StreamExecution passes the live events of one execution to send: the current status
+// first, then phase changes, task outcomes and status changes. Once the execution is
+// terminal it sends {"type": "done"} and returns. It also returns when ctx is done or
+// send fails.
+func StreamExecution(ctx *types.Context, execID string, send func(*ExecutionStreamEvent) error) error {
+	if execID == "" {
+		return fmt.Errorf("execution_id is required")
+	}
+
+	// Subscribe before reading the record so no transition falls in between
+	ch := make(chan *eventtypes.Event, 64)
+	subID := event.Subscribe("robot.*", ch, event.Filter(func(ev *eventtypes.Event) bool {
+		return robotevents.PayloadExecutionID(ev.Payload) == execID
+	}))
+	defer event.Unsubscribe(subID)
+
+	done := &ExecutionStreamEvent{Type: "done", ExecutionID: execID}
+	sendStatus := func() (bool, error) {
+		record, err := getExecutionStore().Get(ctx.Context, execID)
+		if err != nil || record == nil {
+			return false, fmt.Errorf("execution not found: %s", execID)
+		}
+		if err := send(&ExecutionStreamEvent{
+			Type:        "status",
+			ExecutionID: execID,
+			Status:      record.Status,
+			Data:        map[string]interface{}{"phase": record.Phase, "error": record.Error},
+		}); err != nil {
+			return false, err
+		}
+		return record.Status.IsTerminal(), nil
+	}
+
+	terminal, err := sendStatus()
+	if err != nil {
+		return err
+	}
+	if terminal {
+		return send(done)
+	}
+
+	ticker := time.NewTicker(executionStreamPoll)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Context.Done():
+			return nil
+
+		case ev, ok := <-ch:
+			if !ok {
+				return nil // event service shut down
+			}
+			entryType, known := executionStreamTypes[ev.Type]
+			if !known {
+				continue
+			}
+			entry := &ExecutionStreamEvent{Type: entryType, ExecutionID: execID, Data: ev.Payload}
+			if payload, ok := ev.Payload.(robotevents.ExecPayload); ok && entryType == "status" {
+				entry.Status = types.ExecStatus(payload.Status)
+			}
+			if err := send(entry); err != nil {
+				return err
+			}
+			if entry.Status.IsTerminal() {
+				return send(done)
+			}
+
+		case <-ticker.C:
+			if finished, err := terminalRecord(ctx, execID); err != nil || !finished {
+				continue
+			}
+			if _, err := sendStatus(); err != nil {
+				return err
+			}
+			return send(done)
+		}
+	}
+}
+
+// terminalRecord reports whether the stored execution has reached a terminal status
+func terminalRecord(ctx *types.Context, execID string) (bool, error) {
+	record, err := getExecutionStore().Get(ctx.Context, execID)
+	if err != nil || record == nil {
+		return false, err
+	}
+	return record.Status.IsTerminal(), nil
+}
@@ -0,0 +1,494 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/yaoapp/yao/agent/robot/executor/standard"
+	"github.com/yaoapp/yao/agent/robot/store"
+	"github.com/yaoapp/yao/agent/robot/types"
+)
+
+// ==================== Explain Types ====================
+
+// ExplainDigest is the compact view of an execution handed to the summarizer:
+// what was asked, what was planned, what needed a human and where results went.
+// Task outputs are only included when short; raw outputs never reach the summarizer.
+type ExplainDigest struct {
+	ExecutionID string            `json:"execution_id"`
+	Name        string            `json:"name,omitempty"`
+	Trigger     types.TriggerType `json:"trigger"`
+	Event       string            `json:"event,omitempty"`
+	Status      types.ExecStatus  `json:"status"`
+	Phase       types.Phase       `json:"phase,omitempty"`
+	Error       string            `json:"error,omitempty"`
+	Request     string            `json:"request,omitempty"`
+	Goals       string            `json:"goals,omitempty"`
+	Tasks       []ExplainTask     `json:"tasks,omitempty"`
+	Questions   []ExplainQuestion `json:"questions,omitempty"`
+	Delivery    *ExplainDelivery  `json:"delivery,omitempty"`
+}
+
+// ExplainTask - one planned task in the digest
+type ExplainTask struct {
+	Number        int              `json:"number"` // 1-based position in the plan
+	Name          string           `json:"name"`
+	Executor      string           `json:"executor,omitempty"`
+	Status        types.TaskStatus `json:"status"`
+	Error         string           `json:"error,omitempty"`
+	Output        string           `json:"output,omitempty"`               // short outputs only
+	OmittedOutput int              `json:"omitted_output_bytes,omitempty"` // size of an output too large to include
+}
+
+// ExplainQuestion - a question a task asked a human, with the reply if any
+type ExplainQuestion struct {
+	Task     int    `json:"task"` // task number
+	TaskName string `json:"task_name"`
+	Question string `json:"question"`
+	Reply    string `json:"reply,omitempty"`
+}
+
+// ExplainDelivery - where the results went
+type ExplainDelivery struct {
+	Success  bool             `json:"success"`
+	Summary  string           `json:"summary,omitempty"`
+	Error    string           `json:"error,omitempty"`
+	Channels []ExplainChannel `json:"channels,omitempty"`
+}
+
+// ExplainChannel - one delivery target
+type ExplainChannel struct {
+	Type       types.DeliveryType `json:"type"`
+	Target     string             `json:"target,omitempty"`
+	Recipients []string           `json:"recipients,omitempty"`
+	Success    bool               `json:"success"`
+	Error      string             `json:"error,omitempty"`
+}
+
+// Digest size limits
+const (
+	explainTextLimit   = 500 // runes kept from request, goals and summaries
+	explainOutputLimit = 300 // bytes; larger task outputs are left out of the digest
+)
+
+// explainPhase resolves the summarizer assistant: per-robot resources.phases.explain,
+// then the global uses.explain setting
+const explainPhase = "explain"
+
+// humanReplyPrefix marks replies injected into a task's messages on resume
+const humanReplyPrefix = "[Human reply] "
+
+// explainSummarizer calls the summarizer assistant with the digest; replaced in tests
+var explainSummarizer = func(ctx *types.Context, agentID, locale string, digest *ExplainDigest) (string, error) {
+	input, err := json.Marshal(map[string]interface{}{
+		"locale":    locale,
+		"execution": digest,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal explain input: %w", err)
+	}
+
+	result, err := standard.NewAgentCaller().CallWithMessages(ctx, agentID, string(input))
+	if err != nil {
+		return "", fmt.Errorf("explain agent (%s) call failed: %w", agentID, err)
+	}
+	return strings.TrimSpace(result.GetText()), nil
+}
+
+// ==================== Explain API Functions ====================
+
+// ExplainExecution returns a plain-language summary of an execution in the given locale.
+// A cached summary is reused while the execution is unchanged (same digest) and forever
+// once it was written for a finished execution. When the summarizer assistant is not
+// configured or fails, a summary is assembled from the record instead; that fallback
+// is not cached, so the next request tries the assistant again.
+func ExplainExecution(ctx *types.Context, execID, locale string) (*types.ExecutionExplanation, error) {
+	if execID == "" {
+		return nil, fmt.Errorf("execution_id is required")
+	}
+	if ctx == nil {
+		ctx = types.NewContext(context.Background(), nil)
+	}
+
+	execStore := getExecutionStore()
+	record, err := execStore.Get(ctx.Context, execID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get execution: %w", err)
+	}
+	if record == nil {
+		return nil, fmt.Errorf("execution not found: %s", execID)
+	}
+
+	locale = normalizeExplainLocale(locale)
+	digest := buildExplainDigest(record)
+	fingerprint := digest.fingerprint()
+
+	if cached := record.Explanations[locale]; cached != nil && (cached.Final || cached.Digest == fingerprint) {
+		return cached, nil
+	}
+
+	explanation := &types.ExecutionExplanation{
+		Locale:      locale,
+		Digest:      fingerprint,
+		GeneratedAt: time.Now(),
+	}
+
+	summary, err := summarizeExecution(ctx, record, locale, digest)
+	if err != nil || summary == "" {
+		if err != nil {
+			log.Warn("explain execution %s: using template summary: %v", execID, err)
+		}
+		explanation.Summary = templateExplanation(digest, locale)
+		explanation.Source = types.ExplanationTemplate
+		return explanation, nil
+	}
+
+	explanation.Summary = summary
+	explanation.Source = types.ExplanationAssistant
+	explanation.Final = record.Status.IsTerminal()
+	if err := execStore.SaveExplanation(ctx.Context, execID, locale, explanation); err != nil {
+		log.Warn("explain execution %s: failed to cache summary: %v", execID, err)
+	}
+	return explanation, nil
+}
+
+// summarizeExecution asks the robot's summarizer assistant for the summary
+func summarizeExecution(ctx *types.Context, record *store.ExecutionRecord, locale string, digest *ExplainDigest) (string, error) {
+	var config *types.Config
+	if robot, err := GetRobot(ctx, record.MemberID); err == nil && robot != nil {
+		config = robot.Config
+	}
+
+	agentID := types.ResolvePhaseAgent(config, explainPhase)
+	if agentID == "" {
+		return "", fmt.Errorf("no explain assistant configured")
+	}
+	return explainSummarizer(ctx, agentID, locale, digest)
+}
+
+// ==================== Digest ====================
+
+// buildExplainDigest condenses an execution record for the summarizer
+func buildExplainDigest(record *store.ExecutionRecord) *ExplainDigest {
+	digest := &ExplainDigest{
+		ExecutionID: record.ExecutionID,
+		Name:        record.Name,
+		Trigger:     record.TriggerType,
+		Status:      record.Status,
+		Phase:       record.Phase,
+		Error:       truncateRunes(record.Error, explainTextLimit),
+	}
+
+	if record.Input != nil {
+		digest.Event = record.Input.EventType
+		var parts []string
+		for _, msg := range record.Input.Messages {
+			if text, ok := msg.Content.(string); ok && strings.TrimSpace(text) != "" {
+				parts = append(parts, strings.TrimSpace(text))
+			}
+		}
+		digest.Request = truncateRunes(strings.Join(parts, "\n"), explainTextLimit)
+	}
+	if record.Goals != nil {
+		digest.Goals = truncateRunes(strings.TrimSpace(record.Goals.Content), explainTextLimit)
+	}
+
+	results := map[string]types.TaskResult{}
+	for _, r := range record.Results {
+		results[r.TaskID] = r
+	}
+
+	for i, task := range record.Tasks {
+		item := ExplainTask{
+			Number:   i + 1,
+			Name:     explainTaskName(task),
+			Executor: task.ExecutorID,
+			Status:   task.Status,
+		}
+
+		result, hasResult := results[task.ID]
+		if hasResult {
+			item.Error = truncateRunes(result.Error, explainTextLimit)
+			if output := explainOutputText(result.Output); len(output) > explainOutputLimit {
+				item.OmittedOutput = len(output)
+			} else {
+				item.Output = output
+			}
+		}
+		digest.Tasks = append(digest.Tasks, item)
+
+		// Questions come from the task results and the current suspension,
+		// replies from the messages injected on resume; they pair up in order
+		var questions, replies []string
+		if hasResult && result.NeedInput && result.InputQuestion != "" {
+			questions = append(questions, result.InputQuestion)
+		}
+		if record.WaitingTaskID == task.ID && record.WaitingQuestion != "" &&
+			(len(questions) == 0 || questions[len(questions)-1] != record.WaitingQuestion) {
+			questions = append(questions, record.WaitingQuestion)
+		}
+		for _, msg := range task.Messages {
+			if text, ok := msg.Content.(string); ok && strings.HasPrefix(text, humanReplyPrefix) {
+				replies = append(replies, strings.TrimPrefix(text, humanReplyPrefix))
+			}
+		}
+		for q, question := range questions {
+			entry := ExplainQuestion{
+				Task:     item.Number,
+				TaskName: item.Name,
+				Question: truncateRunes(question, explainTextLimit),
+			}
+			if q < len(replies) {
+				entry.Reply = truncateRunes(replies[q], explainTextLimit)
+			}
+			digest.Questions = append(digest.Questions, entry)
+		}
+	}
+
+	if d := record.Delivery; d != nil {
+		delivery := &ExplainDelivery{Success: d.Success, Error: d.Error}
+		if d.Content != nil {
+			delivery.Summary = truncateRunes(d.Content.Summary, explainTextLimit)
+		}
+		for _, ch := range d.Results {
+			delivery.Channels = append(delivery.Channels, ExplainChannel{
+				Type:       ch.Type,
+				Target:     ch.Target,
+				Recipients: ch.Recipients,
+				Success:    ch.Success,
+				Error:      ch.Error,
+			})
+		}
+		digest.Delivery = delivery
+	}
+
+	return digest
+}
+
+// fingerprint identifies the execution state a summary was written for
+func (d *ExplainDigest) fingerprint() string {
+	raw, _ := json.Marshal(d)
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:8])
+}
+
+// explainTaskName prefers the human-readable description, then the first message, then the ID
+func explainTaskName(task types.Task) string {
+	if task.Description != "" {
+		return truncateRunes(task.Description, 120)
+	}
+	for _, msg := range task.Messages {
+		if text, ok := msg.Content.(string); ok && text != "" && !strings.HasPrefix(text, humanReplyPrefix) {
+			return truncateRunes(text, 120)
+		}
+	}
+	return task.ID
+}
+
+// explainOutputText renders a task output as text for the size check
+func explainOutputText(output interface{}) string {
+	switch v := output.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(v)
+	default:
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(raw)
+	}
+}
+
+// truncateRunes cuts s to at most limit runes, marking the cut
+func truncateRunes(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:limit]) + "…"
+}
+
+// ==================== Locale & Template ====================
+
+// normalizeExplainLocale lower-cases the locale and defaults to "en"
+func normalizeExplainLocale(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(strings.ReplaceAll(locale, "_", "-")))
+	if locale == "" {
+		return "en"
+	}
+	return locale
+}
+
+// explainLanguage picks the template language for a locale: "zh-cn" -> "zh", unknown -> "en"
+func explainLanguage(locale string) string {
+	lang := strings.SplitN(locale, "-", 2)[0]
+	if _, ok := explainMessages[lang]; ok {
+		return lang
+	}
+	return "en"
+}
+
+// explainMessages are the sentences of the template summary
+var explainMessages = map[string]map[string]string{
+	"en": {
+		"trigger_human":      "You asked the robot: \"%s\".",
+		"trigger_clock":      "The robot ran on its schedule.",
+		"trigger_event":      "The robot was started by the event %s.",
+		"goals":              "It interpreted this as: %s.",
+		"tasks":              "It planned %d tasks: %d completed, %d failed, %d not finished.",
+		"no_tasks":           "It did not plan any tasks.",
+		"question":           "Task %d (%s) needed your input: \"%s\"",
+		"reply":              ", and you replied \"%s\".",
+		"no_reply":           ", which is still waiting for a reply.",
+		"delivered":          "The result was delivered via %s.",
+		"delivery_failed":    "Delivering the result failed: %s.",
+		"status_completed":   "The execution completed.",
+		"status_failed":      "The execution failed: %s.",
+		"status_failed_bare": "The execution failed.",
+		"status_cancelled":   "The execution was cancelled.",
+		"status_running":     "The execution is still in progress.",
+		"status_waiting":     "The execution is waiting for your input.",
+		"status_confirming":  "The plan is waiting for your confirmation.",
+		"list_sep":           ", ",
+	},
+	"zh": {
+		"trigger_human":      "你向机器人提出：“%s”。",
+		"trigger_clock":      "机器人按计划定时运行。",
+		"trigger_event":      "机器人由事件 %s 触发。",
+		"goals":              "它将此理解为：%s。",
+		"tasks":              "它规划了 %d 个任务：%d 个已完成，%d 个失败，%d 个未完成。",
+		"no_tasks":           "它没有规划任何任务。",
+		"question":           "任务 %d（%s）需要你的输入：“%s”",
+		"reply":              "，你的回复是：“%s”。",
+		"no_reply":           "，目前仍在等待回复。",
+		"delivered":          "结果已通过 %s 交付。",
+		"delivery_failed":    "结果交付失败：%s。",
+		"status_completed":   "本次执行已完成。",
+		"status_failed":      "本次执行失败：%s。",
+		"status_failed_bare": "本次执行失败。",
+		"status_cancelled":   "本次执行已取消。",
+		"status_running":     "本次执行仍在进行中。",
+		"status_waiting":     "本次执行正在等待你的输入。",
+		"status_confirming":  "计划正在等待你的确认。",
+		"list_sep":           "、",
+	},
+}
+
+// templateExplanation assembles a summary from the digest without any model call
+func templateExplanation(digest *ExplainDigest, locale string) string {
+	msgs := explainMessages[explainLanguage(locale)]
+	var parts []string
+
+	switch digest.Trigger {
+	case types.TriggerClock:
+		parts = append(parts, msgs["trigger_clock"])
+	case types.TriggerEvent:
+		parts = append(parts, fmt.Sprintf(msgs["trigger_event"], digest.Event))
+	default:
+		if digest.Request != "" {
+			parts = append(parts, fmt.Sprintf(msgs["trigger_human"], firstLine(digest.Request)))
+		}
+	}
+
+	if digest.Goals != "" {
+		parts = append(parts, fmt.Sprintf(msgs["goals"], strings.TrimRight(firstLine(digest.Goals), ".。")))
+	}
+
+	if len(digest.Tasks) == 0 {
+		parts = append(parts, msgs["no_tasks"])
+	} else {
+		completed, failed := 0, 0
+		for _, t := range digest.Tasks {
+			switch t.Status {
+			case types.TaskCompleted:
+				completed++
+			case types.TaskFailed:
+				failed++
+			}
+		}
+		parts = append(parts, fmt.Sprintf(msgs["tasks"], len(digest.Tasks), completed, failed, len(digest.Tasks)-completed-failed))
+	}
+
+	for _, q := range digest.Questions {
+		sentence := fmt.Sprintf(msgs["question"], q.Task, q.TaskName, q.Question)
+		if q.Reply != "" {
+			sentence += fmt.Sprintf(msgs["reply"], q.Reply)
+		} else {
+			sentence += msgs["no_reply"]
+		}
+		parts = append(parts, sentence)
+	}
+
+	if d := digest.Delivery; d != nil {
+		var channels []string
+		for _, ch := range d.Channels {
+			if !ch.Success {
+				continue
+			}
+			target := ch.Target
+			if len(ch.Recipients) > 0 {
+				target = strings.Join(ch.Recipients, msgs["list_sep"])
+			}
+			if target != "" {
+				channels = append(channels, fmt.Sprintf("%s (%s)", ch.Type, target))
+			} else {
+				channels = append(channels, string(ch.Type))
+			}
+		}
+		if len(channels) > 0 {
+			parts = append(parts, fmt.Sprintf(msgs["delivered"], strings.Join(channels, msgs["list_sep"])))
+		}
+		if !d.Success && d.Error != "" {
+			parts = append(parts, fmt.Sprintf(msgs["delivery_failed"], d.Error))
+		}
+	}
+
+	switch digest.Status {
+	case types.ExecCompleted:
+		parts = append(parts, msgs["status_completed"])
+	case types.ExecFailed:
+		if digest.Error != "" {
+			parts = append(parts, fmt.Sprintf(msgs["status_failed"], strings.TrimRight(digest.Error, ".")))
+		} else {
+			parts = append(parts, msgs["status_failed_bare"])
+		}
+	case types.ExecCancelled:
+		parts = append(parts, msgs["status_cancelled"])
+	case types.ExecWaiting:
+		parts = append(parts, msgs["status_waiting"])
+	case types.ExecConfirming:
+		parts = append(parts, msgs["status_confirming"])
+	default:
+		parts = append(parts, msgs["status_running"])
+	}
+
+	sep := " "
+	if explainLanguage(locale) == "zh" {
+		sep = ""
+	}
+	return strings.Join(parts, sep)
+}
+
+// firstLine returns the first markdown line that is not a heading, without list markers
+func firstLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimLeft(line, "-*>"))
+		if i := strings.Index(line, ". "); i > 0 && i <= 3 && strings.Trim(line[:i], "0123456789") == "" {
+			line = line[i+2:]
+		}
+		return line
+	}
+	return strings.TrimSpace(s)
+}
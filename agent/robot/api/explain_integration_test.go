@@ -0,0 +1,124 @@
+//go:build integration
+
+package api_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/yao/agent/robot/api"
+	"github.com/yaoapp/yao/agent/robot/store"
+	"github.com/yaoapp/yao/agent/robot/types"
+	"github.com/yaoapp/yao/unit-test/agent/testprepare"
+)
+
+func TestExplainExecution(t *testing.T) {
+	testprepare.PrepareSandbox(t)
+
+	prevResolver := types.GlobalPhaseAgentResolver
+	types.GlobalPhaseAgentResolver = func(phase types.Phase) string {
+		if phase == "explain" {
+			return "tests.explain"
+		}
+		return ""
+	}
+	defer func() { types.GlobalPhaseAgentResolver = prevResolver }()
+
+	var calls atomic.Int32
+	var fail atomic.Bool
+	restore := api.SetExplainSummarizer(func(ctx *types.Context, agentID, locale string, digest *api.ExplainDigest) (string, error) {
+		if fail.Load() {
+			return "", errors.New("summarizer unavailable")
+		}
+		calls.Add(1)
+		return "summary in " + locale + " for " + string(digest.Status), nil
+	})
+	defer restore()
+
+	s := store.NewExecutionStore()
+	execID := "_test_explain_exec"
+	now := time.Now()
+	record := &store.ExecutionRecord{
+		ExecutionID: execID,
+		MemberID:    "_test_explain_robot",
+		TeamID:      "team_explain_test",
+		TriggerType: types.TriggerHuman,
+		Status:      types.ExecRunning,
+		Phase:       types.PhaseRun,
+		Goals:       &types.Goals{Content: "1. Weekly sales report"},
+		Tasks: []types.Task{
+			{ID: "t1", Description: "Collect sales data", Status: types.TaskCompleted},
+			{ID: "t2", Description: "Write the report", Status: types.TaskRunning},
+		},
+		StartTime: &now,
+	}
+	require.NoError(t, s.Save(context.Background(), record))
+	defer s.Delete(context.Background(), execID)
+
+	ctx := types.NewContext(context.Background(), nil)
+
+	t.Run("generated once while unchanged", func(t *testing.T) {
+		first, err := api.ExplainExecution(ctx, execID, "en-US")
+		require.NoError(t, err)
+		assert.Equal(t, types.ExplanationAssistant, first.Source)
+		assert.Equal(t, "en-us", first.Locale)
+		assert.Equal(t, "summary in en-us for running", first.Summary)
+		assert.False(t, first.Final)
+
+		second, err := api.ExplainExecution(ctx, execID, "en-us")
+		require.NoError(t, err)
+		assert.Equal(t, first.Summary, second.Summary)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("locales are cached separately", func(t *testing.T) {
+		zh, err := api.ExplainExecution(ctx, execID, "zh-CN")
+		require.NoError(t, err)
+		assert.Equal(t, "summary in zh-cn for running", zh.Summary)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("regenerated after the execution changes, then frozen", func(t *testing.T) {
+		require.NoError(t, s.UpdateStatus(context.Background(), execID, types.ExecCompleted, ""))
+
+		done, err := api.ExplainExecution(ctx, execID, "en-us")
+		require.NoError(t, err)
+		assert.Equal(t, "summary in en-us for completed", done.Summary)
+		assert.True(t, done.Final)
+		assert.Equal(t, int32(3), calls.Load())
+
+		// Terminal summaries are immutable even if the record is touched again
+		require.NoError(t, s.UpdateStatus(context.Background(), execID, types.ExecFailed, "late error"))
+		again, err := api.ExplainExecution(ctx, execID, "en-us")
+		require.NoError(t, err)
+		assert.Equal(t, done.Summary, again.Summary)
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("summarizer failure falls back to the template", func(t *testing.T) {
+		fail.Store(true)
+		defer fail.Store(false)
+
+		fallback, err := api.ExplainExecution(ctx, execID, "de-de")
+		require.NoError(t, err)
+		assert.Equal(t, types.ExplanationTemplate, fallback.Source)
+		assert.Contains(t, fallback.Summary, "It planned 2 tasks: 1 completed")
+
+		// The fallback is not cached: the assistant is tried again next time
+		fail.Store(false)
+		retried, err := api.ExplainExecution(ctx, execID, "de-de")
+		require.NoError(t, err)
+		assert.Equal(t, types.ExplanationAssistant, retried.Source)
+	})
+
+	t.Run("unknown execution", func(t *testing.T) {
+		_, err := api.ExplainExecution(ctx, "_test_explain_missing", "en")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+}
@@ -0,0 +1,128 @@
+//go:build unit
+
+package api_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	agentcontext "github.com/yaoapp/yao/agent/context"
+	"github.com/yaoapp/yao/agent/robot/api"
+	"github.com/yaoapp/yao/agent/robot/store"
+	"github.com/yaoapp/yao/agent/robot/types"
+)
+
+func explainRecord() *store.ExecutionRecord {
+	return &store.ExecutionRecord{
+		ExecutionID: "exec_explain",
+		MemberID:    "robot_explain",
+		TriggerType: types.TriggerHuman,
+		Status:      types.ExecCompleted,
+		Input: &types.TriggerInput{
+			Messages: []agentcontext.Message{{Role: "user", Content: "Send me last week's sales report"}},
+		},
+		Goals: &types.Goals{Content: "## Goals\n1. Weekly sales report for the EU region"},
+		Tasks: []types.Task{
+			{ID: "t1", Description: "Collect sales data", ExecutorID: "agent.data", Status: types.TaskCompleted},
+			{ID: "t2", Description: "Build charts", ExecutorID: "agent.chart", Status: types.TaskCompleted},
+			{
+				ID: "t3", Description: "Pick the currency", ExecutorID: "agent.ask", Status: types.TaskCompleted,
+				Messages: []agentcontext.Message{
+					{Role: "user", Content: "Which currency?"},
+					{Role: "user", Content: "[Human reply] EUR"},
+				},
+			},
+			{ID: "t4", Description: "Write the report", ExecutorID: "agent.writer", Status: types.TaskCompleted},
+		},
+		Results: []types.TaskResult{
+			{TaskID: "t1", Success: true, Output: strings.Repeat("row,", 5000)},
+			{TaskID: "t2", Success: true, Output: map[string]interface{}{"charts": 3}},
+			{TaskID: "t3", Success: true, NeedInput: true, InputQuestion: "Should totals be in EUR or USD?"},
+		},
+		Delivery: &types.DeliveryResult{
+			Success: true,
+			Content: &types.DeliveryContent{Summary: "EU sales up 4%", Body: strings.Repeat("long body ", 1000)},
+			Results: []types.ChannelResult{
+				{Type: types.DeliveryEmail, Target: "email", Recipients: []string{"ops@example.com"}, Success: true},
+			},
+		},
+	}
+}
+
+func TestBuildExplainDigest(t *testing.T) {
+	digest := api.BuildExplainDigest(explainRecord())
+
+	assert.Equal(t, "Send me last week's sales report", digest.Request)
+	require.Len(t, digest.Tasks, 4)
+	assert.Equal(t, 1, digest.Tasks[0].Number)
+	assert.Equal(t, "Collect sales data", digest.Tasks[0].Name)
+
+	// Oversized output is left out, only its size is kept
+	assert.Empty(t, digest.Tasks[0].Output)
+	assert.Equal(t, len(strings.Repeat("row,", 5000)), digest.Tasks[0].OmittedOutput)
+	assert.Equal(t, `{"charts":3}`, digest.Tasks[1].Output)
+	assert.Zero(t, digest.Tasks[1].OmittedOutput)
+
+	require.Len(t, digest.Questions, 1)
+	assert.Equal(t, 3, digest.Questions[0].Task)
+	assert.Equal(t, "Should totals be in EUR or USD?", digest.Questions[0].Question)
+	assert.Equal(t, "EUR", digest.Questions[0].Reply)
+
+	require.NotNil(t, digest.Delivery)
+	assert.Equal(t, "EU sales up 4%", digest.Delivery.Summary)
+	assert.Equal(t, []string{"ops@example.com"}, digest.Delivery.Channels[0].Recipients)
+
+	t.Run("pending question from the current suspension", func(t *testing.T) {
+		record := explainRecord()
+		record.Status = types.ExecWaiting
+		record.WaitingTaskID = "t4"
+		record.WaitingQuestion = "Formal or casual tone?"
+
+		digest := api.BuildExplainDigest(record)
+		require.Len(t, digest.Questions, 2)
+		assert.Equal(t, 4, digest.Questions[1].Task)
+		assert.Empty(t, digest.Questions[1].Reply)
+	})
+
+	t.Run("long text is truncated", func(t *testing.T) {
+		record := explainRecord()
+		record.Goals.Content = strings.Repeat("g", 5000)
+		digest := api.BuildExplainDigest(record)
+		assert.Less(t, len(digest.Goals), 1000)
+	})
+}
+
+func TestTemplateExplanation(t *testing.T) {
+	digest := api.BuildExplainDigest(explainRecord())
+
+	t.Run("english", func(t *testing.T) {
+		summary := api.TemplateExplanation(digest, "en-us")
+		assert.Contains(t, summary, `You asked the robot: "Send me last week's sales report".`)
+		assert.Contains(t, summary, "It interpreted this as: Weekly sales report for the EU region.")
+		assert.Contains(t, summary, "It planned 4 tasks: 4 completed, 0 failed, 0 not finished.")
+		assert.Contains(t, summary, `Task 3 (Pick the currency) needed your input: "Should totals be in EUR or USD?", and you replied "EUR".`)
+		assert.Contains(t, summary, "delivered via email (ops@example.com)")
+		assert.True(t, strings.HasSuffix(summary, "The execution completed."))
+	})
+
+	t.Run("locale selects the language", func(t *testing.T) {
+		zh := api.TemplateExplanation(digest, "zh-cn")
+		assert.Contains(t, zh, "它规划了 4 个任务")
+		assert.Contains(t, zh, "本次执行已完成。")
+
+		// Unsupported languages fall back to English
+		assert.Equal(t, api.TemplateExplanation(digest, "en"), api.TemplateExplanation(digest, "fr-fr"))
+	})
+
+	t.Run("failed execution", func(t *testing.T) {
+		record := explainRecord()
+		record.Status = types.ExecFailed
+		record.Error = "mail server unreachable"
+		record.TriggerType = types.TriggerClock
+		summary := api.TemplateExplanation(api.BuildExplainDigest(record), "en")
+		assert.True(t, strings.HasPrefix(summary, "The robot ran on its schedule."))
+		assert.Contains(t, summary, "The execution failed: mail server unreachable.")
+	})
+}
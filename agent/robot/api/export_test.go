@@ -1,6 +1,13 @@
 package api
 
-import "github.com/yaoapp/yao/agent/robot/types"
+import (
+	"context"
+	"time"
+
+	"github.com/yaoapp/yao/agent/robot/store"
+	"github.com/yaoapp/yao/agent/robot/types"
+	storetypes "github.com/yaoapp/yao/agent/store/types"
+)
 
 // ApplyDefaults exposes applyDefaults for external tests.
 func (q *ListQuery) ApplyDefaults() {
@@ -12,7 +19,54 @@ func PaginateRobotsForTest(robots []*types.Robot, query *ListQuery) *ListResult
 	return paginateRobots(robots, query)
 }
 
+// ExportBriefExecution exposes briefExecution for external tests.
+func ExportBriefExecution(exec *types.Execution) {
+	briefExecution(exec)
+}
+
 // ExportLegacyResume exposes legacyResume for external tests.
 func ExportLegacyResume(ctx *types.Context, req *InteractRequest) (*InteractResult, error) {
 	return legacyResume(ctx, req)
 }
+
+// BuildRobotsOverviewAt exposes buildRobotsOverview with a fixed clock for external tests.
+func BuildRobotsOverviewAt(teamID string, now time.Time) (*RobotsOverview, error) {
+	return buildRobotsOverview(context.Background(), teamID, now)
+}
+
+// BuildExplainDigest exposes buildExplainDigest for external tests.
+func BuildExplainDigest(record *store.ExecutionRecord) *ExplainDigest {
+	return buildExplainDigest(record)
+}
+
+// TemplateExplanation exposes templateExplanation for external tests.
+func TemplateExplanation(digest *ExplainDigest, locale string) string {
+	return templateExplanation(digest, locale)
+}
+
+// SetExplainSummarizer replaces the summarizer call for external tests and returns a restore func.
+func SetExplainSummarizer(fn func(ctx *types.Context, agentID, locale string, digest *ExplainDigest) (string, error)) func() {
+	prev := explainSummarizer
+	explainSummarizer = fn
+	return func() { explainSummarizer = prev }
+}
+
+// BuildChatTranscript exposes buildChatTranscript for external tests.
+func BuildChatTranscript(chatID string, records []*store.ExecutionRecord, messages []*storetypes.Message) *ChatTranscript {
+	return buildChatTranscript(chatID, records, messages)
+}
+
+// AggregateDeliveryMetrics exposes aggregateDeliveryMetrics for external tests.
+func AggregateDeliveryMetrics(records []*store.ExecutionRecord) *DeliveryMetrics {
+	return aggregateDeliveryMetrics(records)
+}
+
+// BuildExecutionOutcomeAt exposes buildExecutionOutcome with a fixed clock for external tests.
+func BuildExecutionOutcomeAt(record *store.ExecutionRecord, now time.Time) *ExecutionOutcome {
+	return buildExecutionOutcome(record, now)
+}
+
+// ExportRobotStatusEntry exposes robotStatusEntry for external tests.
+func ExportRobotStatusEntry(robot *types.Robot, snapshot *types.RobotStatusSnapshot) *RobotStatusEntry {
+	return robotStatusEntry(robot, snapshot)
+}
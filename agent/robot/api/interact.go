@@ -16,6 +16,7 @@ type InteractRequest struct {
 	Source      types.InteractSource `json:"source,omitempty"`
 	Message     string               `json:"message"`
 	Action      string               `json:"action,omitempty"`
+	Force       bool                 `json:"force,omitempty"` // with action "cancel": also cancel a running execution
 }
 
 // InteractResult is the response from an interaction.
@@ -63,6 +64,7 @@ func managerInteract(ctx *types.Context, mgr *manager.Manager, memberID string,
 		Source:      req.Source,
 		Message:     req.Message,
 		Action:      req.Action,
+		Force:       req.Force,
 	}
 
 	resp, err := mgr.HandleInteract(ctx, memberID, mgrReq)
@@ -144,6 +146,7 @@ func InteractStream(ctx *types.Context, memberID string, req *InteractRequest, s
 		Source:      req.Source,
 		Message:     req.Message,
 		Action:      req.Action,
+		Force:       req.Force,
 	}
 
 	resp, err := mgr.HandleInteractStream(ctx, memberID, mgrReq, streamFn)
@@ -183,6 +186,7 @@ func InteractStreamRaw(ctx *types.Context, memberID string, req *InteractRequest
 		Source:      req.Source,
 		Message:     req.Message,
 		Action:      req.Action,
+		Force:       req.Force,
 	}
 
 	resp, err := mgr.HandleInteractStreamRaw(ctx, memberID, mgrReq, onMessage)
@@ -200,11 +204,34 @@ func InteractStreamRaw(ctx *types.Context, memberID string, req *InteractRequest
 	}, nil
 }
 
-// CancelExecution cancels a waiting/confirming execution via the manager.
-func CancelExecution(ctx *types.Context, execID string) error {
+// CancelOptions controls CancelExecution, see manager.CancelOptions
+type CancelOptions struct {
+	Cascade     bool   `json:"cascade,omitempty"`      // also cancel not-yet-terminal chained descendants
+	Force       bool   `json:"force,omitempty"`        // also cancel a running execution
+	Reason      string `json:"reason,omitempty"`       // recorded on the execution
+	CancelledBy string `json:"cancelled_by,omitempty"` // defaults to the calling user
+}
+
+// CancelExecution cancels a waiting/confirming execution via the manager; opts.Force also
+// cancels a running one.
+func CancelExecution(ctx *types.Context, execID string, opts CancelOptions) error {
 	mgr, err := getManager()
 	if err != nil {
 		return fmt.Errorf("cancel not available: %w", err)
 	}
-	return mgr.CancelExecution(ctx, execID)
+	return mgr.CancelExecution(ctx, execID, manager.CancelOptions{
+		Cascade:     opts.Cascade,
+		Force:       opts.Force,
+		Reason:      opts.Reason,
+		CancelledBy: opts.CancelledBy,
+	})
+}
+
+// UncancelExecution restores an execution cancelled moments ago (see manager.Config.UncancelWindow)
+func UncancelExecution(ctx *types.Context, execID string) error {
+	mgr, err := getManager()
+	if err != nil {
+		return fmt.Errorf("uncancel not available: %w", err)
+	}
+	return mgr.UncancelExecution(ctx, execID)
 }
@@ -55,7 +55,7 @@ func TestConfirm(t *testing.T) {
 func TestCancelExecution(t *testing.T) {
 	t.Run("no_manager_returns_error", func(t *testing.T) {
 		ctx := types.NewContext(nil, nil)
-		err := api.CancelExecution(ctx, "exec-1")
+		err := api.CancelExecution(ctx, "exec-1", api.CancelOptions{})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "cancel not available")
 	})
@@ -3,8 +3,13 @@ package api
 import (
 	"context"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
+	goustore "github.com/yaoapp/gou/store"
 	robotevents "github.com/yaoapp/yao/agent/robot/events"
 	"github.com/yaoapp/yao/agent/robot/events/integrations"
 	dtadapter "github.com/yaoapp/yao/agent/robot/events/integrations/dingtalk"
@@ -14,6 +19,7 @@ import (
 	weixinadapter "github.com/yaoapp/yao/agent/robot/events/integrations/weixin"
 	"github.com/yaoapp/yao/agent/robot/logger"
 	"github.com/yaoapp/yao/agent/robot/manager"
+	"github.com/yaoapp/yao/agent/robot/store"
 	"github.com/yaoapp/yao/agent/robot/types"
 )
 
@@ -51,9 +57,14 @@ func Start() error {
 		return fmt.Errorf("robot agent system already started")
 	}
 
+	configureExecutionHotStore()
+	configureWebhookPolicy()
+	configureWebhookRetry()
+	configurePayloadLimits()
+
 	// Create new manager if not exists
 	if globalManager == nil {
-		globalManager = manager.New()
+		globalManager = manager.NewWithConfig(managerConfigFromEnv())
 	}
 
 	if err := globalManager.Start(); err != nil {
@@ -76,6 +87,151 @@ func Start() error {
 	return nil
 }
 
+// configureExecutionHotStore serves non-terminal execution state from a KV store when
+// YAO_ROBOT_EXECUTION_STORE names a registered store (e.g. a redis store). The
+// execution table stays the durable history; YAO_ROBOT_EXECUTION_STORE_TTL (default "5s")
+// bounds how long an entry is served, and so how stale a read can be on instances that
+// do not share the KV store.
+func configureExecutionHotStore() {
+	id := os.Getenv("YAO_ROBOT_EXECUTION_STORE")
+	if id == "" {
+		store.SetExecutionHotStore(nil, 0)
+		return
+	}
+
+	kv, err := goustore.Get(id)
+	if err != nil {
+		log.Error("execution hot store %s not available, using database only: %v", id, err)
+		store.SetExecutionHotStore(nil, 0)
+		return
+	}
+
+	var ttl time.Duration
+	if v := os.Getenv("YAO_ROBOT_EXECUTION_STORE_TTL"); v != "" {
+		if ttl, err = time.ParseDuration(v); err != nil {
+			log.Warn("invalid YAO_ROBOT_EXECUTION_STORE_TTL %q, using default: %v", v, err)
+			ttl = 0
+		}
+	}
+	store.SetExecutionHotStore(kv, ttl)
+}
+
+// configureWebhookPolicy loads the webhook SSRF policy from the environment:
+// YAO_ROBOT_WEBHOOK_ALLOW / YAO_ROBOT_WEBHOOK_DENY (comma-separated hosts, IPs or CIDRs)
+// and YAO_ROBOT_WEBHOOK_ALLOW_PRIVATE=true to opt out of the private-range block.
+func configureWebhookPolicy() {
+	split := func(v string) []string {
+		var out []string
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				out = append(out, item)
+			}
+		}
+		return out
+	}
+
+	allowPrivate, _ := strconv.ParseBool(os.Getenv("YAO_ROBOT_WEBHOOK_ALLOW_PRIVATE"))
+	types.SetWebhookPolicy(types.WebhookPolicy{
+		AllowHosts:   split(os.Getenv("YAO_ROBOT_WEBHOOK_ALLOW")),
+		DenyHosts:    split(os.Getenv("YAO_ROBOT_WEBHOOK_DENY")),
+		AllowPrivate: allowPrivate,
+	})
+}
+
+// configureWebhookRetry loads the webhook retry policy from the environment:
+// YAO_ROBOT_WEBHOOK_MAX_ATTEMPTS, YAO_ROBOT_WEBHOOK_RETRY_DELAY (first backoff, e.g. "1s")
+// and YAO_ROBOT_WEBHOOK_RETRY_MAX_DELAY. Unset or invalid values keep the defaults.
+func configureWebhookRetry() {
+	envDuration := func(name string) time.Duration {
+		v := os.Getenv(name)
+		if v == "" {
+			return 0
+		}
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			log.Warn("invalid %s %q, using default", name, v)
+			return 0
+		}
+		return d
+	}
+
+	var retry types.WebhookRetry
+	if v := os.Getenv("YAO_ROBOT_WEBHOOK_MAX_ATTEMPTS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			log.Warn("invalid YAO_ROBOT_WEBHOOK_MAX_ATTEMPTS %q, using default", v)
+		} else {
+			retry.MaxAttempts = n
+		}
+	}
+	retry.BaseDelay = envDuration("YAO_ROBOT_WEBHOOK_RETRY_DELAY")
+	retry.MaxDelay = envDuration("YAO_ROBOT_WEBHOOK_RETRY_MAX_DELAY")
+	types.SetWebhookRetry(retry)
+}
+
+// configurePayloadLimits loads robot payload limits from the environment:
+// YAO_ROBOT_PAYLOAD_MAX_BYTES, YAO_ROBOT_PAYLOAD_MAX_ITEMS, YAO_ROBOT_PAYLOAD_MAX_DEPTH
+// and YAO_ROBOT_PAYLOAD_MAX_KEYS. Unset or invalid values keep the defaults.
+func configurePayloadLimits() {
+	envInt := func(name string) int64 {
+		v := os.Getenv(name)
+		if v == "" {
+			return 0
+		}
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			log.Warn("invalid %s %q, using default", name, v)
+			return 0
+		}
+		return n
+	}
+
+	types.SetPayloadLimits(types.PayloadLimits{
+		MaxBodyBytes:   envInt("YAO_ROBOT_PAYLOAD_MAX_BYTES"),
+		MaxListItems:   int(envInt("YAO_ROBOT_PAYLOAD_MAX_ITEMS")),
+		MaxConfigDepth: int(envInt("YAO_ROBOT_PAYLOAD_MAX_DEPTH")),
+		MaxConfigKeys:  int(envInt("YAO_ROBOT_PAYLOAD_MAX_KEYS")),
+	})
+}
+
+// managerConfigFromEnv returns the default manager config with the autonomous scheduler
+// limits from the environment: YAO_ROBOT_MAX_AUTONOMOUS (concurrent clock-triggered
+// executions across robots, 0 = unlimited) and YAO_ROBOT_AUTONOMOUS_COOLDOWN (e.g. "10m"),
+// plus YAO_ROBOT_UNCANCEL_WINDOW (e.g. "30s", "0" disables un-cancelling).
+func managerConfigFromEnv() *manager.Config {
+	config := manager.DefaultConfig()
+
+	if v := os.Getenv("YAO_ROBOT_MAX_AUTONOMOUS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			log.Warn("invalid YAO_ROBOT_MAX_AUTONOMOUS %q, no limit applied", v)
+		} else {
+			config.MaxAutonomous = n
+		}
+	}
+
+	if v := os.Getenv("YAO_ROBOT_AUTONOMOUS_COOLDOWN"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			log.Warn("invalid YAO_ROBOT_AUTONOMOUS_COOLDOWN %q, no cooldown applied", v)
+		} else {
+			config.AutonomousCooldown = d
+		}
+	}
+
+	if v := os.Getenv("YAO_ROBOT_UNCANCEL_WINDOW"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			log.Warn("invalid YAO_ROBOT_UNCANCEL_WINDOW %q, using %s", v, manager.DefaultUncancelWindow)
+		} else if d <= 0 {
+			config.UncancelWindow = -1
+		} else {
+			config.UncancelWindow = d
+		}
+	}
+	return config
+}
+
 // StartWithConfig starts the robot agent system with custom configuration
 func StartWithConfig(config *manager.Config) error {
 	managerMu.Lock()
@@ -147,6 +303,16 @@ func GetManager() *manager.Manager {
 	return globalManager
 }
 
+// GetSchedulerState returns the autonomous scheduler state: concurrency limit,
+// running autonomous executions, deferred robots and robots in cooldown
+func GetSchedulerState() (*types.SchedulerState, error) {
+	mgr, err := getManager()
+	if err != nil {
+		return nil, err
+	}
+	return mgr.SchedulerState(), nil
+}
+
 // SetManager sets the global manager instance (for testing)
 func SetManager(m *manager.Manager) {
 	managerMu.Lock()
@@ -0,0 +1,206 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/yaoapp/yao/agent/robot/types"
+)
+
+// ==================== Overview API ====================
+
+// overviewLiveStatuses are the non-terminal statuses counted on the overview badges
+var overviewLiveStatuses = []types.ExecStatus{
+	types.ExecWaiting, types.ExecConfirming, types.ExecRunning, types.ExecPending,
+}
+
+// GetRobotsOverview returns badge counts for every robot of a team using grouped
+// execution queries (not one listing per robot). Robot status comes from the
+// manager cache when it is running, otherwise from the database.
+func GetRobotsOverview(ctx *types.Context, teamID string) (*RobotsOverview, error) {
+	if teamID == "" {
+		return nil, fmt.Errorf("team_id is required")
+	}
+	return buildRobotsOverview(ctx.Context, teamID, time.Now())
+}
+
+func buildRobotsOverview(ctx context.Context, teamID string, now time.Time) (*RobotsOverview, error) {
+	robots, err := listTeamRobots(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	s := getExecutionStore()
+	live, err := s.CountByMemberStatus(ctx, teamID, overviewLiveStatuses, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	since := now.Add(-OverviewFailureWindow)
+	failed, err := s.CountByMemberStatus(ctx, teamID, []types.ExecStatus{types.ExecFailed}, &since)
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := s.LatestByMember(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	byMember := make(map[string]*RobotOverview, len(robots))
+	overview := &RobotsOverview{
+		TeamID:      teamID,
+		Robots:      make([]*RobotOverview, 0, len(robots)),
+		GeneratedAt: now,
+	}
+	for _, robot := range robots {
+		item := &RobotOverview{
+			MemberID:    robot.MemberID,
+			DisplayName: robot.DisplayName,
+			Status:      robot.Status,
+		}
+		if last := latest[robot.MemberID]; last != nil {
+			item.LastExecution = &OverviewExecution{
+				ExecutionID: last.ExecutionID,
+				Name:        last.Name,
+				Status:      last.Status,
+				StartTime:   last.StartTime,
+				EndTime:     last.EndTime,
+			}
+		}
+		byMember[robot.MemberID] = item
+		overview.Robots = append(overview.Robots, item)
+	}
+
+	for _, c := range live {
+		item := byMember[c.MemberID]
+		if item == nil {
+			continue
+		}
+		switch c.Status {
+		case types.ExecWaiting:
+			item.Waiting += c.Count
+		case types.ExecConfirming:
+			item.Confirming += c.Count
+		case types.ExecRunning:
+			item.Running += c.Count
+		case types.ExecPending:
+			item.Queued += c.Count
+		}
+	}
+	for _, c := range failed {
+		if item := byMember[c.MemberID]; item != nil {
+			item.RecentFailures += c.Count
+		}
+	}
+
+	for _, item := range overview.Robots {
+		item.NeedsAttention = item.Waiting + item.Confirming
+		item.Active = item.Running + item.Queued
+		if item.Active > 0 && item.Status == types.RobotIdle {
+			item.Status = types.RobotWorking
+		}
+	}
+
+	sort.SliceStable(overview.Robots, func(i, j int) bool {
+		return overview.Robots[i].MemberID < overview.Robots[j].MemberID
+	})
+	return overview, nil
+}
+
+// ==================== Status API ====================
+
+// GetRobotsStatus returns the status of every robot member of a team, working robots
+// first, then by display name. Counts come from the manager's live snapshots; robots
+// the manager has not loaded (or a stopped manager) report their persisted status.
+func GetRobotsStatus(ctx *types.Context, teamID string) ([]*RobotStatusEntry, error) {
+	if teamID == "" {
+		return nil, fmt.Errorf("team_id is required")
+	}
+
+	robots, err := listTeamRobotsFromDB(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	live := map[string]*types.RobotStatusSnapshot{}
+	if mgr, err := getManager(); err == nil {
+		for _, snapshot := range mgr.Snapshots(teamID) {
+			live[snapshot.MemberID] = snapshot
+		}
+	}
+
+	entries := make([]*RobotStatusEntry, 0, len(robots))
+	for _, robot := range robots {
+		entries = append(entries, robotStatusEntry(robot, live[robot.MemberID]))
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		wi, wj := entries[i].Status == types.RobotWorking, entries[j].Status == types.RobotWorking
+		if wi != wj {
+			return wi
+		}
+		return entries[i].DisplayName < entries[j].DisplayName
+	})
+	return entries, nil
+}
+
+// robotStatusEntry merges a robot's persisted row with its live snapshot (nil when not cached)
+func robotStatusEntry(robot *types.Robot, snapshot *types.RobotStatusSnapshot) *RobotStatusEntry {
+	entry := &RobotStatusEntry{
+		MemberID:    robot.MemberID,
+		DisplayName: robot.DisplayName,
+		Status:      robot.Status,
+	}
+	if snapshot == nil {
+		return entry
+	}
+
+	entry.Status = snapshot.Status
+	entry.ActiveCount = snapshot.ActiveCount
+	entry.WaitingCount = snapshot.WaitingCount
+	entry.QueuedCount = snapshot.QueuedCount
+	if entry.ActiveCount > 0 && entry.Status == types.RobotIdle {
+		entry.Status = types.RobotWorking
+	}
+
+	var current *types.ExecBrief
+	for i := range snapshot.ActiveExecs {
+		exec := &snapshot.ActiveExecs[i]
+		if exec.Status != types.ExecRunning {
+			continue
+		}
+		if current == nil || exec.StartTime.After(current.StartTime) {
+			current = exec
+		}
+	}
+	if current != nil {
+		entry.CurrentExecutionName = current.Name
+	}
+	return entry
+}
+
+// listTeamRobots returns every robot of a team: cache snapshot when the manager runs, database otherwise
+func listTeamRobots(teamID string) ([]*types.Robot, error) {
+	if mgr, err := getManager(); err == nil {
+		return mgr.Cache().List(teamID), nil
+	}
+	return listTeamRobotsFromDB(teamID)
+}
+
+// listTeamRobotsFromDB returns every robot member of a team as stored
+func listTeamRobotsFromDB(teamID string) ([]*types.Robot, error) {
+	var robots []*types.Robot
+	for page := 1; ; page++ {
+		result, err := ListRobotsFromDB(&ListQuery{TeamID: teamID, Page: page, PageSize: 100})
+		if err != nil {
+			return nil, err
+		}
+		robots = append(robots, result.Data...)
+		if len(result.Data) == 0 || len(robots) >= result.Total {
+			return robots, nil
+		}
+	}
+}
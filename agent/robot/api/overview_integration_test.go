@@ -0,0 +1,117 @@
+//go:build integration
+
+package api_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/yao/agent/robot/api"
+	"github.com/yaoapp/yao/agent/robot/executor"
+	"github.com/yaoapp/yao/agent/robot/manager"
+	"github.com/yaoapp/yao/agent/robot/store"
+	"github.com/yaoapp/yao/agent/robot/types"
+	"github.com/yaoapp/yao/unit-test/agent/testprepare"
+)
+
+func TestAPIRobotsOverview(t *testing.T) {
+	testprepare.PrepareSandbox(t)
+
+	require.NoError(t, api.StartWithConfig(&manager.Config{
+		TickInterval: time.Hour,
+		Executor:     executor.NewDryRun(),
+	}))
+	defer api.Stop()
+
+	const teamID = "team_overview_test"
+	robots := []string{"robot_overview_a", "robot_overview_b", "robot_overview_empty"}
+	for _, id := range robots {
+		api.GetManager().Cache().Add(&types.Robot{MemberID: id, TeamID: teamID, DisplayName: id, Status: types.RobotIdle})
+	}
+
+	now := time.Now().Truncate(time.Second)
+	s := store.NewExecutionStore()
+	seed := func(id, memberID string, status types.ExecStatus, start time.Time, end *time.Time) {
+		require.NoError(t, s.Save(context.Background(), &store.ExecutionRecord{
+			ExecutionID: id,
+			MemberID:    memberID,
+			TeamID:      teamID,
+			TriggerType: types.TriggerHuman,
+			Status:      status,
+			Phase:       types.PhaseRun,
+			Name:        id,
+			StartTime:   &start,
+			EndTime:     end,
+		}))
+		t.Cleanup(func() { s.Delete(context.Background(), id) })
+	}
+	ago := func(d time.Duration) *time.Time {
+		v := now.Add(-d)
+		return &v
+	}
+
+	// robot a: 2 waiting, 1 confirming, 1 running, 1 failed inside the window, 1 outside
+	seed("exec_overview_a1", "robot_overview_a", types.ExecWaiting, now.Add(-5*time.Hour), nil)
+	seed("exec_overview_a2", "robot_overview_a", types.ExecWaiting, now.Add(-4*time.Hour), nil)
+	seed("exec_overview_a3", "robot_overview_a", types.ExecConfirming, now.Add(-3*time.Hour), nil)
+	seed("exec_overview_a4", "robot_overview_a", types.ExecRunning, now.Add(-time.Minute), nil)
+	seed("exec_overview_a5", "robot_overview_a", types.ExecFailed, now.Add(-24*time.Hour), ago(23*time.Hour))
+	seed("exec_overview_a6", "robot_overview_a", types.ExecFailed, now.Add(-26*time.Hour), ago(25*time.Hour))
+
+	// robot b: 1 queued, 1 completed, 2 recent failures
+	seed("exec_overview_b1", "robot_overview_b", types.ExecPending, now.Add(-2*time.Minute), nil)
+	seed("exec_overview_b2", "robot_overview_b", types.ExecCompleted, now.Add(-10*time.Minute), ago(9*time.Minute))
+	seed("exec_overview_b3", "robot_overview_b", types.ExecFailed, now.Add(-2*time.Hour), ago(time.Hour))
+	seed("exec_overview_b4", "robot_overview_b", types.ExecFailed, now.Add(-90*time.Minute), ago(80*time.Minute))
+
+	// another team's execution must not leak in
+	require.NoError(t, s.Save(context.Background(), &store.ExecutionRecord{
+		ExecutionID: "exec_overview_other", MemberID: "robot_overview_a", TeamID: "team_overview_other",
+		TriggerType: types.TriggerHuman, Status: types.ExecWaiting, StartTime: &now,
+	}))
+	t.Cleanup(func() { s.Delete(context.Background(), "exec_overview_other") })
+
+	overview, err := api.BuildRobotsOverviewAt(teamID, now)
+	require.NoError(t, err)
+	require.Len(t, overview.Robots, 3)
+
+	byID := map[string]*api.RobotOverview{}
+	for _, r := range overview.Robots {
+		byID[r.MemberID] = r
+	}
+
+	a := byID["robot_overview_a"]
+	assert.Equal(t, 2, a.Waiting)
+	assert.Equal(t, 1, a.Confirming)
+	assert.Equal(t, 3, a.NeedsAttention)
+	assert.Equal(t, 1, a.Active)
+	assert.Equal(t, 1, a.RecentFailures, "failure older than 24h is outside the window")
+	assert.Equal(t, types.RobotWorking, a.Status)
+	require.NotNil(t, a.LastExecution)
+	assert.Equal(t, "exec_overview_a4", a.LastExecution.ExecutionID)
+
+	b := byID["robot_overview_b"]
+	assert.Equal(t, 0, b.NeedsAttention)
+	assert.Equal(t, 1, b.Queued)
+	assert.Equal(t, 1, b.Active)
+	assert.Equal(t, 2, b.RecentFailures)
+	require.NotNil(t, b.LastExecution)
+	assert.Equal(t, "exec_overview_b1", b.LastExecution.ExecutionID)
+
+	empty := byID["robot_overview_empty"]
+	assert.Equal(t, 0, empty.NeedsAttention+empty.Active+empty.RecentFailures)
+	assert.Nil(t, empty.LastExecution)
+	assert.Equal(t, types.RobotIdle, empty.Status)
+
+	// Moving the clock forward two hours pushes a5 out of the window
+	later, err := api.BuildRobotsOverviewAt(teamID, now.Add(2*time.Hour))
+	require.NoError(t, err)
+	for _, r := range later.Robots {
+		if r.MemberID == "robot_overview_a" {
+			assert.Equal(t, 0, r.RecentFailures)
+		}
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/yaoapp/yao/agent/robot/store"
 	"github.com/yaoapp/yao/agent/robot/types"
 	"github.com/yaoapp/yao/event"
+	"github.com/yaoapp/yao/openapi/oauth/providers/user"
 )
 
 // ==================== Robot Query API ====================
@@ -185,6 +186,12 @@ func GetRobotStatus(ctx *types.Context, memberID string) (*RobotState, error) {
 	return state, nil
 }
 
+// ValidateDelivery checks a delivery configuration before it is saved on a robot.
+// probe additionally sends a HEAD request to each webhook.
+func ValidateDelivery(ctx *types.Context, prefs *types.DeliveryPreferences, probe bool) []types.DeliveryDiagnostic {
+	return robotevents.ValidateDeliveryPreferences(ctx.Context, prefs, probe)
+}
+
 // ==================== Helper Functions ====================
 
 // loadRobotFromDB loads a robot directly from database
@@ -199,7 +206,7 @@ func loadRobotFromDB(memberID string) (*types.Robot, error) {
 			"id", "member_id", "team_id", "display_name", "bio",
 			"system_prompt", "robot_status", "autonomous_mode",
 			"robot_config", "robot_email", "agents", "mcp_servers",
-			"manager_id", "language_model", "workspace",
+			"manager_id", "language_model", "timezone", "workspace", "cost_limit",
 		},
 		Wheres: []model.QueryWhere{
 			{Column: "member_id", Value: memberID},
@@ -215,6 +222,10 @@ func loadRobotFromDB(memberID string) (*types.Robot, error) {
 		return nil, types.ErrRobotNotFound
 	}
 
+	if err := user.DecryptMemberData(records[0]); err != nil {
+		return nil, fmt.Errorf("failed to load robot: %w", err)
+	}
+
 	return types.NewRobotFromMap(map[string]interface{}(records[0]))
 }
 
@@ -268,7 +279,7 @@ func ListRobotsFromDB(query *ListQuery) (*ListResult, error) {
 			"id", "member_id", "team_id", "display_name", "bio",
 			"system_prompt", "robot_status", "autonomous_mode",
 			"robot_config", "robot_email", "agents", "mcp_servers",
-			"language_model", "workspace",
+			"language_model", "timezone", "workspace", "cost_limit",
 		},
 		Wheres: wheres,
 		Orders: orders,
@@ -294,6 +305,9 @@ func ListRobotsFromDB(query *ListQuery) (*ListResult, error) {
 	switch records := data.(type) {
 	case []maps.MapStr:
 		for _, record := range records {
+			if err := user.DecryptMemberData(record); err != nil {
+				return nil, fmt.Errorf("failed to list robots: %w", err)
+			}
 			robot, err := types.NewRobotFromMap(map[string]interface{}(record))
 			if err != nil {
 				continue // skip invalid records
@@ -302,6 +316,9 @@ func ListRobotsFromDB(query *ListQuery) (*ListResult, error) {
 		}
 	case []map[string]interface{}:
 		for _, record := range records {
+			if err := user.DecryptMemberData(record); err != nil {
+				return nil, fmt.Errorf("failed to list robots: %w", err)
+			}
 			robot, err := types.NewRobotFromMap(record)
 			if err != nil {
 				continue // skip invalid records
@@ -378,6 +395,18 @@ func CreateRobot(ctx *types.Context, req *CreateRobotRequest) (*RobotResponse, e
 	if req.DisplayName == "" {
 		return nil, fmt.Errorf("display_name is required")
 	}
+	if err := checkTimezone(req.Timezone); err != nil {
+		return nil, err
+	}
+	if err := types.CheckRobotPayload(map[string]interface{}{
+		"agents":             req.Agents,
+		"mcp_servers":        req.MCPServers,
+		"authorized_senders": req.AuthorizedSenders,
+		"email_filter_rules": req.EmailFilterRules,
+		"robot_config":       req.RobotConfig,
+	}); err != nil {
+		return nil, err
+	}
 
 	// Generate member_id if not provided
 	if req.MemberID == "" {
@@ -388,6 +417,10 @@ func CreateRobot(ctx *types.Context, req *CreateRobotRequest) (*RobotResponse, e
 		req.MemberID = generatedID
 	}
 
+	if err := robotStore.CheckLanguageModel(context.Background(), req.TeamID, req.LanguageModel); err != nil {
+		return nil, err
+	}
+
 	// Check if robot already exists
 	existing, err := robotStore.Get(context.Background(), req.MemberID)
 	if err != nil {
@@ -428,6 +461,7 @@ func CreateRobot(ctx *types.Context, req *CreateRobotRequest) (*RobotResponse, e
 		DisplayName: req.DisplayName,
 		Bio:         req.Bio,
 		Avatar:      req.Avatar,
+		Timezone:    req.Timezone,
 
 		// Identity & Role
 		SystemPrompt: req.SystemPrompt,
@@ -492,6 +526,15 @@ func UpdateRobot(ctx *types.Context, memberID string, req *UpdateRobotRequest) (
 	if memberID == "" {
 		return nil, fmt.Errorf("member_id is required")
 	}
+	if err := types.CheckRobotPayload(map[string]interface{}{
+		"agents":             req.Agents,
+		"mcp_servers":        req.MCPServers,
+		"authorized_senders": req.AuthorizedSenders,
+		"email_filter_rules": req.EmailFilterRules,
+		"robot_config":       req.RobotConfig,
+	}); err != nil {
+		return nil, err
+	}
 
 	// Get existing record
 	existing, err := robotStore.Get(context.Background(), memberID)
@@ -513,6 +556,12 @@ func UpdateRobot(ctx *types.Context, memberID string, req *UpdateRobotRequest) (
 	if req.Avatar != nil {
 		existing.Avatar = *req.Avatar
 	}
+	if req.Timezone != nil {
+		if err := checkTimezone(*req.Timezone); err != nil {
+			return nil, err
+		}
+		existing.Timezone = *req.Timezone
+	}
 
 	// Identity & Role
 	if req.SystemPrompt != nil {
@@ -558,6 +607,9 @@ func UpdateRobot(ctx *types.Context, memberID string, req *UpdateRobotRequest) (
 		existing.MCPServers = req.MCPServers
 	}
 	if req.LanguageModel != nil {
+		if err := robotStore.CheckLanguageModel(context.Background(), existing.TeamID, *req.LanguageModel); err != nil {
+			return nil, err
+		}
 		existing.LanguageModel = *req.LanguageModel
 	}
 	if req.Workspace != nil {
@@ -604,6 +656,24 @@ func UpdateRobot(ctx *types.Context, memberID string, req *UpdateRobotRequest) (
 	return GetRobotResponse(ctx, memberID)
 }
 
+// RefreshRobot reloads a robot's cached config after its member row was changed outside
+// this package (e.g. by the team member endpoints) and notifies integrations
+func RefreshRobot(ctx *types.Context, memberID, teamID string) {
+	if memberID == "" {
+		return
+	}
+
+	mgr, err := getManager()
+	if err == nil && mgr != nil {
+		_ = mgr.Cache().Refresh(ctx, memberID)
+	}
+
+	event.Push(context.Background(), robotevents.RobotConfigUpdated, robotevents.RobotConfigPayload{
+		MemberID: memberID,
+		TeamID:   teamID,
+	})
+}
+
 // RemoveRobot deletes a robot member
 // Calls store.RobotStore.Delete() and invalidates cache
 func RemoveRobot(ctx *types.Context, memberID string) error {
@@ -675,6 +745,7 @@ func recordToResponse(record *store.RobotRecord) *RobotResponse {
 		DisplayName: record.DisplayName,
 		Bio:         record.Bio,
 		Avatar:      record.Avatar,
+		Timezone:    record.Timezone,
 
 		SystemPrompt: record.SystemPrompt,
 		RoleID:       record.RoleID,
@@ -750,3 +821,14 @@ func memberIDExists(ctx context.Context, memberID string) (bool, error) {
 
 	return len(members) > 0, nil
 }
+
+// checkTimezone rejects timezone names the runtime cannot load; empty means server timezone
+func checkTimezone(tz string) error {
+	if tz == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(tz); err != nil {
+		return fmt.Errorf("invalid timezone: %s", tz)
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ package api_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/yaoapp/yao/agent/robot/api"
@@ -142,3 +143,37 @@ func TestUpdateRobotValidationUnit(t *testing.T) {
 		assert.Contains(t, err.Error(), "member_id is required")
 	})
 }
+
+func TestRobotStatusEntry(t *testing.T) {
+	robot := &types.Robot{MemberID: "robot_1", DisplayName: "Ada", Status: types.RobotPaused}
+
+	t.Run("not cached keeps persisted status", func(t *testing.T) {
+		entry := api.ExportRobotStatusEntry(robot, nil)
+		assert.Equal(t, "robot_1", entry.MemberID)
+		assert.Equal(t, "Ada", entry.DisplayName)
+		assert.Equal(t, types.RobotPaused, entry.Status)
+		assert.Zero(t, entry.ActiveCount)
+		assert.Empty(t, entry.CurrentExecutionName)
+	})
+
+	t.Run("live snapshot wins", func(t *testing.T) {
+		now := time.Now()
+		entry := api.ExportRobotStatusEntry(robot, &types.RobotStatusSnapshot{
+			MemberID:     "robot_1",
+			Status:       types.RobotIdle,
+			ActiveCount:  2,
+			WaitingCount: 1,
+			QueuedCount:  3,
+			ActiveExecs: []types.ExecBrief{
+				{ID: "exec_old", Status: types.ExecRunning, Name: "Morning digest", StartTime: now.Add(-time.Hour)},
+				{ID: "exec_wait", Status: types.ExecWaiting, Name: "Needs input", StartTime: now},
+				{ID: "exec_new", Status: types.ExecRunning, Name: "Weekly report", StartTime: now.Add(-time.Minute)},
+			},
+		})
+		assert.Equal(t, types.RobotWorking, entry.Status, "running executions make an idle robot working")
+		assert.Equal(t, 2, entry.ActiveCount)
+		assert.Equal(t, 1, entry.WaitingCount)
+		assert.Equal(t, 3, entry.QueuedCount)
+		assert.Equal(t, "Weekly report", entry.CurrentExecutionName, "latest running execution")
+	})
+}
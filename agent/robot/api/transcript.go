@@ -0,0 +1,263 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yaoapp/yao/agent/assistant"
+	"github.com/yaoapp/yao/agent/robot/store"
+	"github.com/yaoapp/yao/agent/robot/types"
+	storetypes "github.com/yaoapp/yao/agent/store/types"
+)
+
+// ==================== Transcript Types ====================
+
+// TranscriptKind - who or what produced a transcript entry
+type TranscriptKind string
+
+// Transcript entry kinds
+const (
+	TranscriptHuman  TranscriptKind = "human"  // message from the human
+	TranscriptHost   TranscriptKind = "host"   // Host Agent reply or decision
+	TranscriptStatus TranscriptKind = "status" // execution status change
+)
+
+// TranscriptEntry - one line of a chat transcript
+type TranscriptEntry struct {
+	Kind        TranscriptKind   `json:"kind"`
+	Content     string           `json:"content,omitempty"`
+	ExecutionID string           `json:"execution_id,omitempty"`
+	TaskID      string           `json:"task_id,omitempty"`
+	Scenario    string           `json:"scenario,omitempty"` // host turns: assign, clarify, guide
+	Action      string           `json:"action,omitempty"`   // host decision, e.g. confirm or adjust
+	Status      types.ExecStatus `json:"status,omitempty"`
+	Timestamp   time.Time        `json:"timestamp"`
+}
+
+// ChatTranscript - the ordered human <-> robot conversation of one chat_id
+type ChatTranscript struct {
+	ChatID     string             `json:"chat_id"`
+	MemberID   string             `json:"member_id,omitempty"`
+	Executions []string           `json:"executions"`
+	Entries    []*TranscriptEntry `json:"entries"`
+}
+
+// chatMessages loads the stored conversation of a chat; replaced in tests
+var chatMessages = func(chatID string) ([]*storetypes.Message, error) {
+	chatStore := assistant.GetChatStore()
+	if chatStore == nil {
+		return nil, nil
+	}
+	return chatStore.GetMessages(chatID, storetypes.MessageFilter{})
+}
+
+// ==================== Transcript API Functions ====================
+
+// GetChatTranscript assembles the conversation of a chat across all of its
+// interactions and resumes: human inputs, Host Agent replies and the status
+// changes of the executions started from the chat, in time order.
+func GetChatTranscript(ctx *types.Context, chatID string) (*ChatTranscript, error) {
+	if chatID == "" {
+		return nil, fmt.Errorf("chat_id is required")
+	}
+
+	records, err := getExecutionStore().ListByChat(context.Background(), chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := chatMessages(chatID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chat messages: %w", err)
+	}
+
+	if len(records) == 0 && len(messages) == 0 {
+		return nil, fmt.Errorf("chat not found: %s", chatID)
+	}
+	return buildChatTranscript(chatID, records, messages), nil
+}
+
+// buildChatTranscript merges execution records and stored chat messages.
+// Host turns are taken from the chat store; the records add the status changes and,
+// when the store holds no human turns (e.g. direct resumes), the human inputs.
+func buildChatTranscript(chatID string, records []*store.ExecutionRecord, messages []*storetypes.Message) *ChatTranscript {
+	transcript := &ChatTranscript{
+		ChatID:     chatID,
+		Executions: []string{},
+		Entries:    []*TranscriptEntry{},
+	}
+
+	hostEntries := hostTranscriptEntries(messages)
+	hasHuman := false
+	for _, entry := range hostEntries {
+		if entry.Kind == TranscriptHuman {
+			hasHuman = true
+			break
+		}
+	}
+
+	for _, record := range records {
+		if transcript.MemberID == "" {
+			transcript.MemberID = record.MemberID
+		}
+		transcript.Executions = append(transcript.Executions, record.ExecutionID)
+		transcript.Entries = append(transcript.Entries, recordTranscriptEntries(record, !hasHuman)...)
+	}
+	transcript.Entries = append(transcript.Entries, hostEntries...)
+
+	// Stable: entries sharing a timestamp keep their logical order
+	sort.SliceStable(transcript.Entries, func(i, j int) bool {
+		return transcript.Entries[i].Timestamp.Before(transcript.Entries[j].Timestamp)
+	})
+	return transcript
+}
+
+// hostTranscriptEntries extracts the Host Agent turns from the chat messages.
+// Phase agents share the chat, so only requests whose user message is a HostInput
+// (it carries a scenario) are kept, together with the assistant replies to them.
+func hostTranscriptEntries(messages []*storetypes.Message) []*TranscriptEntry {
+	hostRequests := map[string]string{} // request key -> scenario
+	entries := []*TranscriptEntry{}
+
+	for _, msg := range messages {
+		if msg == nil {
+			continue
+		}
+		key := msg.RequestID
+		if key == "" {
+			key = msg.AssistantID
+		}
+		text := messageText(msg)
+
+		switch msg.Role {
+		case "user":
+			var input types.HostInput
+			if json.Unmarshal([]byte(text), &input) != nil || input.Scenario == "" {
+				continue
+			}
+			hostRequests[key] = input.Scenario
+			for _, m := range input.Messages {
+				if content, ok := m.Content.(string); ok && strings.TrimSpace(content) != "" {
+					entries = append(entries, &TranscriptEntry{
+						Kind:      TranscriptHuman,
+						Content:   content,
+						Scenario:  input.Scenario,
+						Timestamp: msg.CreatedAt,
+					})
+				}
+			}
+
+		case "assistant":
+			scenario, ok := hostRequests[key]
+			if !ok || text == "" {
+				continue
+			}
+			entry := &TranscriptEntry{Kind: TranscriptHost, Content: text, Scenario: scenario, Timestamp: msg.CreatedAt}
+			var output types.HostOutput
+			if json.Unmarshal([]byte(text), &output) == nil && output.Action != "" {
+				entry.Action = string(output.Action)
+				entry.Content = output.Reply
+			}
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// recordTranscriptEntries returns the status changes of one execution and, with
+// withHuman, the human input and the replies injected into its tasks on resume
+func recordTranscriptEntries(record *store.ExecutionRecord, withHuman bool) []*TranscriptEntry {
+	start := transcriptTime(record.StartTime, record.CreatedAt)
+	entries := []*TranscriptEntry{}
+
+	startStatus := types.ExecRunning
+	if record.Status == types.ExecConfirming || record.Status == types.ExecPending {
+		startStatus = record.Status
+	}
+	entries = append(entries, &TranscriptEntry{
+		Kind:        TranscriptStatus,
+		Content:     record.Name,
+		ExecutionID: record.ExecutionID,
+		Status:      startStatus,
+		Timestamp:   start,
+	})
+
+	if withHuman && record.Input != nil {
+		for _, msg := range record.Input.Messages {
+			if text, ok := msg.Content.(string); ok && msg.Role == "user" && strings.TrimSpace(text) != "" {
+				entries = append(entries, &TranscriptEntry{
+					Kind:        TranscriptHuman,
+					Content:     text,
+					ExecutionID: record.ExecutionID,
+					Timestamp:   start,
+				})
+			}
+		}
+	}
+
+	end := transcriptTime(record.EndTime, record.UpdatedAt)
+	if withHuman {
+		// Replies carry no timestamp of their own; they are placed before the final status
+		for _, task := range record.Tasks {
+			for _, msg := range task.Messages {
+				if text, ok := msg.Content.(string); ok && strings.HasPrefix(text, humanReplyPrefix) {
+					entries = append(entries, &TranscriptEntry{
+						Kind:        TranscriptHuman,
+						Content:     strings.TrimPrefix(text, humanReplyPrefix),
+						ExecutionID: record.ExecutionID,
+						TaskID:      task.ID,
+						Timestamp:   end,
+					})
+				}
+			}
+		}
+	}
+
+	switch {
+	case record.Status == types.ExecWaiting:
+		entries = append(entries, &TranscriptEntry{
+			Kind:        TranscriptStatus,
+			Content:     record.WaitingQuestion,
+			ExecutionID: record.ExecutionID,
+			TaskID:      record.WaitingTaskID,
+			Status:      record.Status,
+			Timestamp:   transcriptTime(record.WaitingSince, record.UpdatedAt),
+		})
+	case record.Status.IsTerminal():
+		entries = append(entries, &TranscriptEntry{
+			Kind:        TranscriptStatus,
+			Content:     record.Error,
+			ExecutionID: record.ExecutionID,
+			Status:      record.Status,
+			Timestamp:   end,
+		})
+	}
+	return entries
+}
+
+// transcriptTime returns the first non-nil time
+func transcriptTime(times ...*time.Time) time.Time {
+	for _, t := range times {
+		if t != nil {
+			return *t
+		}
+	}
+	return time.Time{}
+}
+
+// messageText returns the text of a stored chat message
+func messageText(msg *storetypes.Message) string {
+	if msg.Props == nil {
+		return ""
+	}
+	for _, key := range []string{"content", "text"} {
+		if text, ok := msg.Props[key].(string); ok {
+			return strings.TrimSpace(text)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,126 @@
+//go:build unit
+
+package api_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	agentcontext "github.com/yaoapp/yao/agent/context"
+	"github.com/yaoapp/yao/agent/robot/api"
+	"github.com/yaoapp/yao/agent/robot/store"
+	"github.com/yaoapp/yao/agent/robot/types"
+	storetypes "github.com/yaoapp/yao/agent/store/types"
+)
+
+func hostInputMessage(t *testing.T, requestID, scenario, text string, at time.Time) *storetypes.Message {
+	raw, err := json.Marshal(types.HostInput{
+		Scenario: scenario,
+		Messages: []agentcontext.Message{{Role: "user", Content: text}},
+	})
+	require.NoError(t, err)
+	return &storetypes.Message{
+		ChatID:    "chat_transcript",
+		RequestID: requestID,
+		Role:      "user",
+		Type:      "user_input",
+		Props:     map[string]interface{}{"content": string(raw)},
+		CreatedAt: at,
+	}
+}
+
+func assistantMessage(requestID, text string, at time.Time) *storetypes.Message {
+	return &storetypes.Message{
+		ChatID:    "chat_transcript",
+		RequestID: requestID,
+		Role:      "assistant",
+		Type:      "text",
+		Props:     map[string]interface{}{"content": text},
+		CreatedAt: at,
+	}
+}
+
+func TestBuildChatTranscript(t *testing.T) {
+	t0 := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	at := func(min int) time.Time { return t0.Add(time.Duration(min) * time.Minute) }
+	end := at(30)
+	waitingSince := at(40)
+	start1, start2 := at(0), at(35)
+
+	records := []*store.ExecutionRecord{
+		{
+			ExecutionID: "exec_1",
+			MemberID:    "robot_transcript",
+			ChatID:      "chat_transcript",
+			Status:      types.ExecCompleted,
+			Name:        "Weekly report",
+			Input:       &types.TriggerInput{Messages: []agentcontext.Message{{Role: "user", Content: "Send me the weekly report"}}},
+			Tasks: []types.Task{{ID: "t1", Messages: []agentcontext.Message{
+				{Role: "user", Content: "Collect data"},
+				{Role: "user", Content: "[Human reply] EU only"},
+			}}},
+			StartTime: &start1,
+			EndTime:   &end,
+		},
+		{
+			ExecutionID:     "exec_2",
+			MemberID:        "robot_transcript",
+			ChatID:          "chat_transcript",
+			Status:          types.ExecWaiting,
+			WaitingTaskID:   "t1",
+			WaitingQuestion: "Which quarter?",
+			WaitingSince:    &waitingSince,
+			StartTime:       &start2,
+		},
+	}
+
+	t.Run("host turns from the chat store", func(t *testing.T) {
+		messages := []*storetypes.Message{
+			hostInputMessage(t, "req_1", "assign", "Send me the weekly report", at(1)),
+			assistantMessage("req_1", "Sure, EU or global?", at(2)),
+			hostInputMessage(t, "req_2", "assign", "EU only", at(3)),
+			assistantMessage("req_2", `{"action":"confirm","reply":"Starting now"}`, at(4)),
+			// A phase agent sharing the chat is not part of the conversation
+			{ChatID: "chat_transcript", RequestID: "req_p", Role: "user", Props: map[string]interface{}{"content": "plan goals"}, CreatedAt: at(5)},
+			assistantMessage("req_p", "## Goals", at(6)),
+		}
+
+		transcript := api.BuildChatTranscript("chat_transcript", records, messages)
+		assert.Equal(t, "robot_transcript", transcript.MemberID)
+		assert.Equal(t, []string{"exec_1", "exec_2"}, transcript.Executions)
+
+		var lines []string
+		for _, e := range transcript.Entries {
+			lines = append(lines, string(e.Kind)+":"+e.Content+":"+string(e.Status)+":"+e.Action)
+		}
+		assert.Equal(t, []string{
+			"status:Weekly report:running:",
+			"human:Send me the weekly report::",
+			"host:Sure, EU or global?::",
+			"human:EU only::",
+			"host:Starting now::confirm",
+			"status::completed:",
+			"status::running:",
+			"status:Which quarter?:waiting:",
+		}, lines)
+	})
+
+	t.Run("human turns from the records without chat history", func(t *testing.T) {
+		transcript := api.BuildChatTranscript("chat_transcript", records, nil)
+
+		var human []*api.TranscriptEntry
+		for _, e := range transcript.Entries {
+			if e.Kind == api.TranscriptHuman {
+				human = append(human, e)
+			}
+		}
+		require.Len(t, human, 2)
+		assert.Equal(t, "Send me the weekly report", human[0].Content)
+		assert.Equal(t, "EU only", human[1].Content)
+		assert.Equal(t, "t1", human[1].TaskID)
+		assert.True(t, human[1].Timestamp.Equal(end), "replies sit before the final status")
+	})
+}
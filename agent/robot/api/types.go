@@ -97,8 +97,12 @@ type TriggerResult struct {
 // ExecutionQuery - query options for GetExecutions()
 type ExecutionQuery struct {
 	Status          types.ExecStatus   `json:"status,omitempty"`
+	Statuses        []types.ExecStatus `json:"statuses,omitempty"` // any of these; takes priority over Status
 	ExcludeStatuses []types.ExecStatus `json:"exclude_statuses,omitempty"`
 	Trigger         types.TriggerType  `json:"trigger,omitempty"`
+	From            *time.Time         `json:"from,omitempty"`   // started at or after
+	To              *time.Time         `json:"to,omitempty"`     // started at or before
+	Detail          bool               `json:"detail,omitempty"` // keep tasks, results and resume_context
 	Page            int                `json:"page,omitempty"`
 	PageSize        int                `json:"pagesize,omitempty"`
 }
@@ -132,6 +136,7 @@ type CreateRobotRequest struct {
 	DisplayName string `json:"display_name,omitempty"` // Display name
 	Bio         string `json:"bio,omitempty"`          // Robot description
 	Avatar      string `json:"avatar,omitempty"`       // Avatar URL
+	Timezone    string `json:"timezone,omitempty"`     // IANA timezone for schedules and timestamps
 
 	// Identity & Role
 	SystemPrompt string `json:"system_prompt,omitempty"` // System prompt
@@ -168,6 +173,7 @@ type UpdateRobotRequest struct {
 	DisplayName *string `json:"display_name,omitempty"` // Display name
 	Bio         *string `json:"bio,omitempty"`          // Robot description
 	Avatar      *string `json:"avatar,omitempty"`       // Avatar URL
+	Timezone    *string `json:"timezone,omitempty"`     // IANA timezone (""=server timezone)
 
 	// Identity & Role
 	SystemPrompt *string `json:"system_prompt,omitempty"` // System prompt
@@ -212,6 +218,7 @@ type RobotResponse struct {
 	DisplayName string `json:"display_name"`
 	Bio         string `json:"bio,omitempty"`
 	Avatar      string `json:"avatar,omitempty"`
+	Timezone    string `json:"timezone,omitempty"`
 
 	// Identity & Role
 	SystemPrompt string `json:"system_prompt,omitempty"`
@@ -271,3 +278,72 @@ func (q *ExecutionQuery) applyDefaults() {
 		q.PageSize = 100
 	}
 }
+
+// ==================== Batch Types ====================
+
+// BatchTriggerRequest - request for TriggerBatch()
+type BatchTriggerRequest struct {
+	Items            []map[string]interface{} `json:"items"`                       // one parameter set per execution (max types.MaxBatchItems)
+	SuppressDelivery bool                     `json:"suppress_delivery,omitempty"` // skip per-item delivery, notify once on completion
+}
+
+// BatchResult - result of TriggerBatch() / GetBatch() / CancelBatch()
+type BatchResult struct {
+	BatchID          string              `json:"batch_id"`
+	MemberID         string              `json:"member_id"`
+	TeamID           string              `json:"team_id"`
+	Status           types.BatchStatus   `json:"status"`
+	SuppressDelivery bool                `json:"suppress_delivery"`
+	Progress         types.BatchProgress `json:"progress"`
+	Items            []*types.BatchItem  `json:"items,omitempty"`
+	CreatedAt        *time.Time          `json:"created_at,omitempty"`
+	CompletedAt      *time.Time          `json:"completed_at,omitempty"`
+}
+
+// ==================== Overview Types ====================
+
+// OverviewFailureWindow is how far back failed executions count as recent failures
+const OverviewFailureWindow = 24 * time.Hour
+
+// RobotsOverview - result of GetRobotsOverview(), one badge row per robot in the team
+type RobotsOverview struct {
+	TeamID      string           `json:"team_id"`
+	Robots      []*RobotOverview `json:"robots"`
+	GeneratedAt time.Time        `json:"generated_at"`
+}
+
+// RobotOverview - execution badge counts for a single robot
+type RobotOverview struct {
+	MemberID       string             `json:"member_id"`
+	DisplayName    string             `json:"display_name"`
+	Status         types.RobotStatus  `json:"status"`
+	NeedsAttention int                `json:"needs_attention"` // waiting + confirming
+	Waiting        int                `json:"waiting"`
+	Confirming     int                `json:"confirming"`
+	Active         int                `json:"active"` // running + pending (queued)
+	Running        int                `json:"running"`
+	Queued         int                `json:"queued"`
+	RecentFailures int                `json:"recent_failures"` // failed within OverviewFailureWindow
+	LastExecution  *OverviewExecution `json:"last_execution,omitempty"`
+}
+
+// OverviewExecution - the most recent execution shown on a robot badge
+type OverviewExecution struct {
+	ExecutionID string           `json:"execution_id"`
+	Name        string           `json:"name,omitempty"`
+	Status      types.ExecStatus `json:"status"`
+	StartTime   *time.Time       `json:"start_time,omitempty"`
+	EndTime     *time.Time       `json:"end_time,omitempty"`
+}
+
+// RobotStatusEntry - one row of GetRobotsStatus(): the live state of a robot, or its
+// persisted robot_status when the robot is not loaded in the manager cache
+type RobotStatusEntry struct {
+	MemberID             string            `json:"member_id"`
+	DisplayName          string            `json:"display_name"`
+	Status               types.RobotStatus `json:"status"`
+	ActiveCount          int               `json:"active_count"`
+	WaitingCount         int               `json:"waiting_count"`
+	QueuedCount          int               `json:"queued_count"`
+	CurrentExecutionName string            `json:"current_execution_name,omitempty"` // latest running execution
+}
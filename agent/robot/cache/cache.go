@@ -3,6 +3,7 @@ package cache
 import (
 	"sync"
 
+	"github.com/yaoapp/yao/agent/robot/capability"
 	"github.com/yaoapp/yao/agent/robot/types"
 )
 
@@ -57,6 +58,7 @@ func (c *Cache) Add(robot *types.Robot) {
 	defer c.mu.Unlock()
 
 	c.robots[robot.MemberID] = robot
+	capability.Invalidate(robot.MemberID)
 
 	// Update team index
 	if _, exists := c.byTeam[robot.TeamID]; !exists {
@@ -87,6 +89,7 @@ func (c *Cache) Remove(memberID string) {
 	}
 
 	delete(c.robots, memberID)
+	capability.Invalidate(memberID)
 
 	// Remove from team index
 	teamMembers := c.byTeam[robot.TeamID]
@@ -5,9 +5,13 @@ import (
 
 	"github.com/yaoapp/gou/model"
 	"github.com/yaoapp/kun/maps"
+	"github.com/yaoapp/yao/agent/robot/logger"
 	"github.com/yaoapp/yao/agent/robot/types"
+	"github.com/yaoapp/yao/openapi/oauth/providers/user"
 )
 
+var log = logger.New("robot")
+
 // memberModel is the model name for member table
 // Can be changed via SetMemberModel() during system initialization
 var memberModel = "__yao.member"
@@ -29,6 +33,8 @@ var memberFields = []interface{}{
 	"manager_id",
 	"language_model",
 	"workspace",
+	"timezone",
+	"cost_limit",
 }
 
 // SetMemberModel sets the member model name
@@ -76,6 +82,11 @@ func (c *Cache) Load(ctx *types.Context) error {
 
 		// Parse and add each robot
 		for _, record := range data {
+			if err := user.DecryptMemberData(record); err != nil {
+				// Never load a robot with ciphertext in place of its config
+				log.Error("robot %v not loaded: %v", record["member_id"], err)
+				continue
+			}
 			robot, err := types.NewRobotFromMap(map[string]interface{}(record))
 			if err != nil {
 				// Log error but continue loading other robots
@@ -117,5 +128,9 @@ func (c *Cache) LoadByID(ctx *types.Context, memberID string) (*types.Robot, err
 		return nil, types.ErrRobotNotFound
 	}
 
+	if err := user.DecryptMemberData(records[0]); err != nil {
+		return nil, fmt.Errorf("failed to load robot %s: %w", memberID, err)
+	}
+
 	return types.NewRobotFromMap(map[string]interface{}(records[0]))
 }
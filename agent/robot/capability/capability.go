@@ -0,0 +1,230 @@
+package capability
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yaoapp/gou/mcp"
+	"github.com/yaoapp/yao/agent/assistant"
+	"github.com/yaoapp/yao/agent/robot/types"
+)
+
+// describeMCPTimeout bounds listing the tools of one MCP server
+const describeMCPTimeout = 5 * time.Second
+
+// entry is a cached descriptor built for one config version
+type entry struct {
+	version    string
+	descriptor *types.CapabilityDescriptor
+}
+
+var (
+	cache   = map[string]*entry{} // memberID -> entry
+	cacheMu sync.RWMutex
+)
+
+// Build returns the capability descriptor for a robot.
+// The config-derived part is cached per robot config version; live state
+// (quota usage) is filled in on every call. The returned value is a copy.
+// ctx bounds the MCP tool listing; a descriptor built while a server did not
+// answer is not cached, so the next call asks again.
+func Build(ctx context.Context, robot *types.Robot) *types.CapabilityDescriptor {
+	if robot == nil {
+		return nil
+	}
+
+	version := robot.ConfigVersion()
+
+	cacheMu.RLock()
+	cached := cache[robot.MemberID]
+	cacheMu.RUnlock()
+
+	if cached == nil || cached.version != version {
+		descriptor, complete := buildStatic(ctx, robot, version)
+		cached = &entry{version: version, descriptor: descriptor}
+		if complete {
+			cacheMu.Lock()
+			cache[robot.MemberID] = cached
+			cacheMu.Unlock()
+		}
+	}
+
+	d := *cached.descriptor
+	max := robot.MaxQuota()
+	running := robot.RunningCount()
+	remaining := max - running
+	if remaining < 0 {
+		remaining = 0
+	}
+	var quota *types.Quota
+	if robot.Config != nil {
+		quota = robot.Config.Quota
+	}
+	d.Quota = types.QuotaCapability{
+		Max:       max,
+		Running:   running,
+		Remaining: remaining,
+		Queue:     quota.GetQueue(),
+	}
+	return &d
+}
+
+// Invalidate drops the cached descriptor of a robot
+func Invalidate(memberID string) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	delete(cache, memberID)
+}
+
+// Reset drops all cached descriptors
+func Reset() {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	cache = map[string]*entry{}
+}
+
+// Format renders the descriptor as a structured block for agent prompts
+func Format(d *types.CapabilityDescriptor) string {
+	if d == nil {
+		return ""
+	}
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Capabilities\n\n")
+	sb.WriteString("Machine-readable summary of what this robot is allowed to use. ")
+	sb.WriteString("Only plan or call agents, tools and processes listed here.\n\n")
+	sb.WriteString("```json\n")
+	sb.Write(data)
+	sb.WriteString("\n```\n")
+	return sb.String()
+}
+
+// buildStatic assembles the config-derived part of the descriptor. complete is false
+// when an MCP server could not list its tools.
+func buildStatic(ctx context.Context, robot *types.Robot, version string) (d *types.CapabilityDescriptor, complete bool) {
+	d = &types.CapabilityDescriptor{
+		MemberID:    robot.MemberID,
+		Version:     version,
+		CostLimit:   robot.CostLimit,
+		GeneratedAt: time.Now(),
+	}
+	complete = true
+
+	cfg := robot.Config
+	if cfg == nil {
+		return d, complete
+	}
+
+	if cfg.Resources != nil {
+		locale := cfg.GetDefaultLocale()
+		for _, id := range cfg.Resources.Agents {
+			d.Agents = append(d.Agents, describeAgent(id, locale))
+		}
+		for _, m := range cfg.Resources.MCP {
+			c, listed := describeMCP(ctx, m)
+			d.MCP = append(d.MCP, c)
+			complete = complete && listed
+		}
+		for _, p := range cfg.Resources.Processes {
+			d.Processes = append(d.Processes, types.ProcessCapability{
+				Name:        p.Name,
+				Description: p.Description,
+				Args:        p.Args,
+			})
+		}
+	}
+
+	if cfg.Delivery != nil {
+		if cfg.Delivery.Email != nil && cfg.Delivery.Email.Enabled {
+			d.Delivery = append(d.Delivery, types.DeliveryEmail)
+		}
+		if cfg.Delivery.Webhook != nil && cfg.Delivery.Webhook.Enabled {
+			d.Delivery = append(d.Delivery, types.DeliveryWebhook)
+		}
+		if cfg.Delivery.Process != nil && cfg.Delivery.Process.Enabled {
+			d.Delivery = append(d.Delivery, types.DeliveryProcess)
+		}
+		if cfg.Delivery.Slack != nil && cfg.Delivery.Slack.Enabled {
+			d.Delivery = append(d.Delivery, types.DeliverySlack)
+		}
+	}
+
+	if cfg.KB != nil {
+		d.KB = append(d.KB, cfg.KB.Collections...)
+	}
+	if cfg.DB != nil {
+		d.DB = append(d.DB, cfg.DB.Models...)
+	}
+	d.Parameters = cfg.Params
+
+	return d, complete
+}
+
+// describeAgent resolves the assistant name/description, falling back to the ID
+func describeAgent(id, locale string) types.AgentCapability {
+	c := types.AgentCapability{ID: id}
+	ast, err := assistant.Get(id)
+	if err != nil || ast == nil {
+		return c
+	}
+	c.Name = ast.GetName(locale)
+	c.Description = ast.GetDescription(locale)
+	c.Capabilities = ast.Capabilities
+	return c
+}
+
+// describeMCP lists the tools of an MCP server, filtered by the configured allowlist.
+// listed is false when the server failed to list its tools (or did not answer within
+// describeMCPTimeout) and the configured allowlist was used instead.
+func describeMCP(ctx context.Context, cfg types.MCPConfig) (c types.MCPCapability, listed bool) {
+	c = types.MCPCapability{ID: cfg.ID}
+
+	allowed := map[string]bool{}
+	for _, name := range cfg.Tools {
+		allowed[name] = true
+	}
+
+	fallback := func(listed bool) (types.MCPCapability, bool) {
+		for _, name := range cfg.Tools {
+			c.Tools = append(c.Tools, types.ToolCapability{Name: name})
+		}
+		c.AllTools = len(cfg.Tools) == 0
+		return c, listed
+	}
+
+	// An unknown server has nothing to list, only a failed listing is worth retrying
+	client, err := mcp.Select(cfg.ID)
+	if err != nil {
+		return fallback(true)
+	}
+
+	if info := client.Info(); info != nil {
+		c.Name = info.Name
+		c.Description = info.Description
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	listCtx, cancel := context.WithTimeout(ctx, describeMCPTimeout)
+	defer cancel()
+	resp, err := client.ListTools(listCtx, "")
+	if err != nil || resp == nil {
+		return fallback(false)
+	}
+
+	for _, tool := range resp.Tools {
+		if len(allowed) > 0 && !allowed[tool.Name] {
+			continue
+		}
+		c.Tools = append(c.Tools, types.ToolCapability{Name: tool.Name, Description: tool.Description})
+	}
+	return c, true
+}
@@ -0,0 +1,129 @@
+//go:build unit
+
+package capability_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/yao/agent/robot/capability"
+	"github.com/yaoapp/yao/agent/robot/types"
+)
+
+func newCapabilityRobot() *types.Robot {
+	return &types.Robot{
+		MemberID:  "robot_capability",
+		TeamID:    "team_capability",
+		CostLimit: 50,
+		Config: &types.Config{
+			Quota: &types.Quota{Max: 3, Queue: 7},
+			Resources: &types.Resources{
+				Agents: []string{"unknown.writer"},
+				MCP: []types.MCPConfig{
+					{ID: "unknown-mcp", Tools: []string{"search", "fetch"}},
+					{ID: "unknown-all"},
+				},
+				Processes: []types.ProcessConfig{
+					{
+						Name:        "models.crm.customer.Find",
+						Description: "Find a customer",
+						Args:        map[string]interface{}{"type": "array"},
+					},
+				},
+			},
+			Delivery: &types.DeliveryPreferences{
+				Email:   &types.EmailPreference{Enabled: true},
+				Webhook: &types.WebhookPreference{Enabled: false},
+				Process: &types.ProcessPreference{Enabled: true},
+			},
+			KB:     &types.KB{Collections: []string{"kb_sales"}},
+			DB:     &types.DB{Models: []string{"crm.customer"}},
+			Params: map[string]interface{}{"type": "object", "required": []interface{}{"customer_id"}},
+		},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	capability.Reset()
+	robot := newCapabilityRobot()
+
+	d := capability.Build(context.Background(), robot)
+	require.NotNil(t, d)
+	assert.Equal(t, "robot_capability", d.MemberID)
+	assert.Equal(t, robot.ConfigVersion(), d.Version)
+
+	// Unresolvable agents fall back to their ID
+	require.Len(t, d.Agents, 1)
+	assert.Equal(t, "unknown.writer", d.Agents[0].ID)
+
+	// Unreachable MCP servers fall back to the configured tool filter
+	require.Len(t, d.MCP, 2)
+	assert.Equal(t, []types.ToolCapability{{Name: "search"}, {Name: "fetch"}}, d.MCP[0].Tools)
+	assert.False(t, d.MCP[0].AllTools)
+	assert.True(t, d.MCP[1].AllTools)
+
+	require.Len(t, d.Processes, 1)
+	assert.Equal(t, "models.crm.customer.Find", d.Processes[0].Name)
+	assert.Equal(t, map[string]interface{}{"type": "array"}, d.Processes[0].Args)
+
+	assert.Equal(t, []types.DeliveryType{types.DeliveryEmail, types.DeliveryProcess}, d.Delivery)
+	assert.Equal(t, []string{"kb_sales"}, d.KB)
+	assert.Equal(t, []string{"crm.customer"}, d.DB)
+	assert.Equal(t, "object", d.Parameters["type"])
+	assert.Equal(t, 50.0, d.CostLimit)
+	assert.Equal(t, types.QuotaCapability{Max: 3, Running: 0, Remaining: 3, Queue: 7}, d.Quota)
+}
+
+func TestBuildLiveQuota(t *testing.T) {
+	capability.Reset()
+	robot := newCapabilityRobot()
+	first := capability.Build(context.Background(), robot)
+
+	require.True(t, robot.TryAcquireSlot(&types.Execution{ID: "exec_1"}))
+	defer robot.RemoveExecution("exec_1")
+
+	d := capability.Build(context.Background(), robot)
+	assert.Equal(t, first.GeneratedAt, d.GeneratedAt, "static part is served from cache")
+	assert.Equal(t, 1, d.Quota.Running)
+	assert.Equal(t, 2, d.Quota.Remaining)
+}
+
+func TestBuildConfigChange(t *testing.T) {
+	capability.Reset()
+	robot := newCapabilityRobot()
+	before := capability.Build(context.Background(), robot)
+
+	robot.Config.Resources.Agents = append(robot.Config.Resources.Agents, "unknown.analyst")
+	after := capability.Build(context.Background(), robot)
+
+	assert.NotEqual(t, before.Version, after.Version)
+	require.Len(t, after.Agents, 2)
+	assert.Equal(t, "unknown.analyst", after.Agents[1].ID)
+
+	// Cached copies are not affected by later changes
+	assert.Len(t, before.Agents, 1)
+}
+
+func TestInvalidate(t *testing.T) {
+	capability.Reset()
+	robot := newCapabilityRobot()
+	first := capability.Build(context.Background(), robot)
+
+	capability.Invalidate(robot.MemberID)
+	second := capability.Build(context.Background(), robot)
+	assert.Equal(t, first.Version, second.Version)
+	assert.False(t, second.GeneratedAt.Before(first.GeneratedAt))
+	assert.NotSame(t, first, second)
+}
+
+func TestFormat(t *testing.T) {
+	capability.Reset()
+	block := capability.Format(capability.Build(context.Background(), newCapabilityRobot()))
+	assert.True(t, strings.HasPrefix(block, "## Capabilities"))
+	assert.Contains(t, block, "```json")
+	assert.Contains(t, block, `"models.crm.customer.Find"`)
+	assert.Empty(t, capability.Format(nil))
+}
@@ -0,0 +1,13 @@
+package capability_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/yaoapp/yao/unit-test/agent/testprepare"
+)
+
+func TestMain(m *testing.M) {
+	testprepare.MustLoadEnv()
+	os.Exit(m.Run())
+}
@@ -0,0 +1,93 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	robotstore "github.com/yaoapp/yao/agent/robot/store"
+	robottypes "github.com/yaoapp/yao/agent/robot/types"
+)
+
+// Redeliver replays a dead-lettered webhook delivery to its target. The stored payload
+// is sent unchanged, signed with the target's current secret and headers, through the
+// same retry policy as the original delivery. The outcome is recorded on the letter.
+func Redeliver(ctx context.Context, letterID string) (*robottypes.ChannelResult, error) {
+	letters := robotstore.NewDeadLetterStore()
+	letter, err := letters.Get(ctx, letterID)
+	if err != nil {
+		return nil, err
+	}
+	if letter == nil {
+		return nil, fmt.Errorf("dead letter not found: %s", letterID)
+	}
+	if letter.Status == robotstore.DeadLetterRedelivered {
+		return nil, fmt.Errorf("dead letter %s was already redelivered", letterID)
+	}
+
+	target, err := deadLetterTarget(ctx, letter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := defaultHandler.redeliver(ctx, target, letter)
+	if err := letters.RecordAttempt(ctx, letterID, result.Attempts, result.Success, result.Error); err != nil {
+		log.Warn("dead letter %s: redelivery outcome not saved: %v", letterID, err)
+	}
+	return result, nil
+}
+
+// redeliver sends a dead letter's payload once more
+func (h *robotHandler) redeliver(ctx context.Context, target robottypes.WebhookTarget, letter *robotstore.DeadLetter) *robottypes.ChannelResult {
+	now := time.Now()
+	result := &robottypes.ChannelResult{
+		Type:   robottypes.DeliveryWebhook,
+		Target: target.URL,
+		SentAt: &now,
+	}
+
+	// The policy may have changed since the letter was written
+	if err := checkWebhookURL(ctx, target.URL, robottypes.GetWebhookPolicy()); err != nil {
+		result.Error = err.Error()
+		result.Disposition = robottypes.DeliveryFailed
+		return result
+	}
+
+	h.sendWebhook(ctx, target, []byte(letter.Payload), result)
+	result.DurationMs = time.Since(now).Milliseconds()
+	if result.Success {
+		result.Disposition = robottypes.DeliveryDelivered
+	} else {
+		result.Disposition = robottypes.DeliveryDeadLettered
+	}
+	return result
+}
+
+// deadLetterTarget finds the robot's webhook target the letter was addressed to
+func deadLetterTarget(ctx context.Context, letter *robotstore.DeadLetter) (robottypes.WebhookTarget, error) {
+	record, err := robotstore.NewRobotStore().Get(ctx, letter.MemberID)
+	if err != nil {
+		return robottypes.WebhookTarget{}, err
+	}
+	if record == nil {
+		return robottypes.WebhookTarget{}, fmt.Errorf("robot not found: %s", letter.MemberID)
+	}
+	robot, err := record.ToRobot()
+	if err != nil {
+		return robottypes.WebhookTarget{}, err
+	}
+
+	if robot.Config != nil && robot.Config.Delivery != nil && robot.Config.Delivery.Webhook != nil {
+		for _, target := range robot.Config.Delivery.Webhook.Targets {
+			if target.URL == letter.Target {
+				return target, nil
+			}
+		}
+	}
+	return robottypes.WebhookTarget{}, fmt.Errorf("webhook target %s is no longer configured on robot %s", letter.Target, letter.MemberID)
+}
+
+// ListDeadLetters returns the kept deliveries matching opts, newest first
+func ListDeadLetters(ctx context.Context, opts *robotstore.DeadLetterListOptions) ([]*robotstore.DeadLetter, int, error) {
+	return robotstore.NewDeadLetterStore().List(ctx, opts)
+}
@@ -8,8 +8,8 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,7 +18,9 @@ import (
 	"github.com/yaoapp/gou/process"
 	"github.com/yaoapp/gou/text"
 	agentcontext "github.com/yaoapp/yao/agent/context"
+	robotstore "github.com/yaoapp/yao/agent/robot/store"
 	robottypes "github.com/yaoapp/yao/agent/robot/types"
+	robotutils "github.com/yaoapp/yao/agent/robot/utils"
 	"github.com/yaoapp/yao/attachment"
 	eventtypes "github.com/yaoapp/yao/event/types"
 	"github.com/yaoapp/yao/messenger"
@@ -26,7 +28,7 @@ import (
 	"github.com/yaoapp/yao/workspace"
 )
 
-// handleDelivery routes delivery content to configured channels (email, webhook, process).
+// handleDelivery routes delivery content to configured channels (email, webhook, process, slack).
 func (h *robotHandler) handleDelivery(ctx context.Context, ev *eventtypes.Event, resp chan<- eventtypes.Result) {
 	var payload DeliveryPayload
 	if err := ev.Should(&payload); err != nil {
@@ -43,12 +45,14 @@ func (h *robotHandler) handleDelivery(ctx context.Context, ev *eventtypes.Event,
 	prefs := payload.Preferences
 	if content == nil {
 		log.Warn("delivery handler: nil content for execution=%s", payload.ExecutionID)
+		recordDeliveryStatus(ctx, payload.ExecutionID, robottypes.DeliveryStatusSkipped)
 		if ev.IsCall {
 			resp <- eventtypes.Result{Data: "no content"}
 		}
 		return
 	}
 	if prefs == nil {
+		recordDeliveryStatus(ctx, payload.ExecutionID, robottypes.DeliveryStatusSkipped)
 		if ev.IsCall {
 			resp <- eventtypes.Result{Data: "no preferences, skipped"}
 		}
@@ -63,14 +67,21 @@ func (h *robotHandler) handleDelivery(ctx context.Context, ev *eventtypes.Event,
 		MemberID:    payload.MemberID,
 		ExecutionID: payload.ExecutionID,
 		TeamID:      payload.TeamID,
+		Timezone:    payload.Timezone,
 	}
 
+	// Render the body once so every channel receives the same artifact
+	content = h.attachRenderedBody(ctx, content, deliveryCtx)
+	content = h.attachThumbnails(ctx, content, prefs.Thumbnails, deliveryCtx)
+
 	var results []robottypes.ChannelResult
 	var lastErr error
 
 	if prefs.Email != nil && prefs.Email.Enabled {
 		for _, target := range prefs.Email.Targets {
+			started := time.Now()
 			r := h.sendEmail(ctx, content, target, deliveryCtx)
+			r.DurationMs = time.Since(started).Milliseconds()
 			results = append(results, r)
 			if !r.Success && lastErr == nil {
 				lastErr = fmt.Errorf("email delivery failed: %s", r.Error)
@@ -80,7 +91,9 @@ func (h *robotHandler) handleDelivery(ctx context.Context, ev *eventtypes.Event,
 
 	if prefs.Webhook != nil && prefs.Webhook.Enabled {
 		for _, target := range prefs.Webhook.Targets {
+			started := time.Now()
 			r := h.postWebhook(ctx, content, target, deliveryCtx)
+			r.DurationMs = time.Since(started).Milliseconds()
 			results = append(results, r)
 			if !r.Success && lastErr == nil {
 				lastErr = fmt.Errorf("webhook delivery failed: %s", r.Error)
@@ -90,7 +103,9 @@ func (h *robotHandler) handleDelivery(ctx context.Context, ev *eventtypes.Event,
 
 	if prefs.Process != nil && prefs.Process.Enabled {
 		for _, target := range prefs.Process.Targets {
+			started := time.Now()
 			r := h.callProcess(ctx, content, target, deliveryCtx)
+			r.DurationMs = time.Since(started).Milliseconds()
 			results = append(results, r)
 			if !r.Success && lastErr == nil {
 				lastErr = fmt.Errorf("process delivery failed: %s", r.Error)
@@ -98,6 +113,18 @@ func (h *robotHandler) handleDelivery(ctx context.Context, ev *eventtypes.Event,
 		}
 	}
 
+	if prefs.Slack != nil && prefs.Slack.Enabled {
+		for _, target := range prefs.Slack.Targets {
+			started := time.Now()
+			r := h.sendSlack(ctx, content, target, deliveryCtx)
+			r.DurationMs = time.Since(started).Milliseconds()
+			results = append(results, r)
+			if !r.Success && lastErr == nil {
+				lastErr = fmt.Errorf("slack delivery failed: %s", r.Error)
+			}
+		}
+	}
+
 	// Push delivery to integration channels only when the task originated from one
 	if reply := getReplyFunc(); reply != nil && payload.ChatID != "" {
 		channel, chatID := splitChannelChatID(payload.ChatID)
@@ -131,17 +158,47 @@ func (h *robotHandler) handleDelivery(ctx context.Context, ev *eventtypes.Event,
 		log.Error("delivery handler: partial failure execution=%s: %v", payload.ExecutionID, lastErr)
 	}
 
+	status := robottypes.SummarizeDelivery(results)
+	recordDeliveryResults(ctx, payload.ExecutionID, status, results)
+
 	if ev.IsCall {
 		resp <- eventtypes.Result{
 			Data: map[string]interface{}{
-				"execution_id": payload.ExecutionID,
-				"results":      results,
+				"execution_id":    payload.ExecutionID,
+				"results":         results,
+				"delivery_status": status,
 			},
 			Err: lastErr,
 		}
 	}
 }
 
+// recordDeliveryResults writes the delivery outcome and the per-channel results (the
+// delivery history behind DeliveryMetrics) back to the execution record. Logged only on failure.
+func recordDeliveryResults(ctx context.Context, executionID string, status robottypes.DeliveryStatus, results []robottypes.ChannelResult) {
+	if executionID == "" {
+		return
+	}
+	if len(results) == 0 {
+		recordDeliveryStatus(ctx, executionID, status)
+		return
+	}
+	if err := robotstore.NewExecutionStore().UpdateDeliveryResults(ctx, executionID, status, results); err != nil {
+		log.Warn("delivery handler: failed to record delivery results execution=%s: %v", executionID, err)
+	}
+}
+
+// recordDeliveryStatus writes the delivery outcome back to the execution record.
+// Failures are logged only: the channels have already run at this point.
+func recordDeliveryStatus(ctx context.Context, executionID string, status robottypes.DeliveryStatus) {
+	if executionID == "" {
+		return
+	}
+	if err := robotstore.NewExecutionStore().UpdateDeliveryStatus(ctx, executionID, status); err != nil {
+		log.Warn("delivery handler: failed to record delivery_status=%s execution=%s: %v", status, executionID, err)
+	}
+}
+
 // buildDeliveryMessage converts DeliveryContent into a standard assistant Message.
 func buildDeliveryMessage(content *robottypes.DeliveryContent) *agentcontext.Message {
 	if content == nil {
@@ -165,14 +222,17 @@ func buildDeliveryMessage(content *robottypes.DeliveryContent) *agentcontext.Mes
 		if att.File == "" {
 			continue
 		}
-		part := map[string]interface{}{
-			"type": "file",
-			"file": map[string]interface{}{
-				"url":      att.File,
-				"filename": att.Title,
-			},
+		file := map[string]interface{}{
+			"url":      att.File,
+			"filename": att.Title,
 		}
-		parts = append(parts, part)
+		if att.Thumbnail != "" {
+			file["thumbnail"] = att.Thumbnail
+		}
+		parts = append(parts, map[string]interface{}{
+			"type": "file",
+			"file": file,
+		})
 	}
 
 	if len(parts) == 0 {
@@ -207,7 +267,8 @@ func (h *robotHandler) sendEmail(
 	deliveryCtx *robottypes.DeliveryContext,
 ) robottypes.ChannelResult {
 	now := time.Now()
-	targetID := strings.Join(target.To, ",")
+	recipients, invalid := normalizeRecipients(target.To)
+	targetID := strings.Join(recipients, ",")
 	if targetID == "" {
 		targetID = "no-recipients"
 	}
@@ -218,15 +279,30 @@ func (h *robotHandler) sendEmail(
 		SentAt: &now,
 	}
 
+	if len(invalid) > 0 {
+		log.Warn("email delivery: execution=%s skipping invalid recipients: %s", deliveryCtx.ExecutionID, strings.Join(invalid, ", "))
+	}
+	if len(recipients) == 0 {
+		result.Error = "no valid recipients"
+		if len(invalid) > 0 {
+			result.Error += ": " + strings.Join(invalid, ", ")
+		}
+		return result
+	}
+
 	svc := messenger.Instance
 	if svc == nil {
 		result.Error = "messenger service not available"
 		return result
 	}
 
+	if content.RecipientTemplate != "" {
+		return h.sendPersonalizedEmails(ctx, svc, content, target, recipients, deliveryCtx, result)
+	}
+
 	htmlBody, plainBody := buildEmailBody(target.Template, content)
 	msg := &messengerTypes.Message{
-		To:      target.To,
+		To:      recipients,
 		Subject: buildEmailSubject(target.Subject, target.Template, content, deliveryCtx),
 		Body:    plainBody,
 		HTML:    htmlBody,
@@ -245,10 +321,33 @@ func (h *robotHandler) sendEmail(
 	}
 
 	result.Success = true
-	result.Recipients = target.To
+	result.Recipients = recipients
 	return result
 }
 
+// normalizeRecipients normalizes the target addresses and drops duplicates;
+// addresses that cannot be normalized are returned separately as invalid
+func normalizeRecipients(to []string) (recipients []string, invalid []string) {
+	seen := map[string]bool{}
+	for _, addr := range to {
+		if strings.TrimSpace(addr) == "" {
+			continue
+		}
+		email, err := robotutils.NormalizeEmail(addr)
+		if err != nil {
+			invalid = append(invalid, strings.TrimSpace(addr))
+			continue
+		}
+		key := strings.ToLower(email)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		recipients = append(recipients, email)
+	}
+	return recipients, invalid
+}
+
 // ============================================================================
 // Webhook
 // ============================================================================
@@ -266,9 +365,17 @@ func (h *robotHandler) postWebhook(
 		SentAt: &now,
 	}
 
+	if err := checkWebhookURL(ctx, target.URL, robottypes.GetWebhookPolicy()); err != nil {
+		log.Warn("webhook delivery blocked: execution=%s url=%s: %v", deliveryCtx.ExecutionID, redactWebhookURL(target.URL), err)
+		result.Error = err.Error()
+		result.Disposition = robottypes.DeliveryFailed
+		return result
+	}
+
 	payload := map[string]interface{}{
 		"event":        "robot.delivery",
-		"timestamp":    now.Format(time.RFC3339),
+		"timestamp":    now.In(deliveryCtx.Location()).Format(time.RFC3339),
+		"timezone":     deliveryCtx.Location().String(),
 		"execution_id": deliveryCtx.ExecutionID,
 		"member_id":    deliveryCtx.MemberID,
 		"team_id":      deliveryCtx.TeamID,
@@ -282,12 +389,16 @@ func (h *robotHandler) postWebhook(
 	if len(content.Attachments) > 0 {
 		info := make([]map[string]interface{}, 0, len(content.Attachments))
 		for _, att := range content.Attachments {
-			info = append(info, map[string]interface{}{
+			item := map[string]interface{}{
 				"title":       att.Title,
 				"description": att.Description,
 				"task_id":     att.TaskID,
 				"file":        att.File,
-			})
+			}
+			if att.Thumbnail != "" {
+				item["thumbnail"] = att.Thumbnail
+			}
+			info = append(info, item)
 		}
 		payload["attachments"] = info
 	}
@@ -295,51 +406,78 @@ func (h *robotHandler) postWebhook(
 	payloadBytes, err := json.Marshal(payload)
 	if err != nil {
 		result.Error = fmt.Sprintf("failed to marshal payload: %v", err)
+		result.Disposition = robottypes.DeliveryFailed
 		return result
 	}
 
-	method := target.Method
-	if method == "" {
-		method = "POST"
-	}
-
-	req, err := http.NewRequestWithContext(ctx, method, target.URL, bytes.NewReader(payloadBytes))
-	if err != nil {
-		result.Error = fmt.Sprintf("failed to create request: %v", err)
+	h.sendWebhook(ctx, target, payloadBytes, &result)
+	if !result.Success {
+		h.deadLetter(ctx, target, payloadBytes, deliveryCtx, &result)
 		return result
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	for key, value := range target.Headers {
-		req.Header.Set(key, value)
+	result.Disposition = robottypes.DeliveryDelivered
+	return result
+}
+
+// checkWebhookResponse applies the target's expect checks to a 2xx response body
+func checkWebhookResponse(body []byte, expect *robottypes.WebhookExpect) error {
+	if expect.Contains != "" && !strings.Contains(string(body), expect.Contains) {
+		return fmt.Errorf("response does not contain %q", expect.Contains)
+	}
+	if expect.JSONPath == "" {
+		return nil
 	}
 
-	if target.Secret != "" {
-		signature := ComputeHMACSignature(payloadBytes, target.Secret)
-		req.Header.Set("X-Yao-Signature", signature)
-		req.Header.Set("X-Yao-Signature-Algorithm", "HMAC-SHA256")
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return fmt.Errorf("response is not JSON: %v", err)
+	}
+	value, ok := lookupJSONPath(doc, expect.JSONPath)
+	if !ok {
+		return fmt.Errorf("%s not found in response", expect.JSONPath)
+	}
+	if expect.Equals == nil {
+		return nil
 	}
 
-	httpResp, err := h.httpClient.Do(req)
+	// Compare as JSON so 1 and 1.0, or config and response maps, match by value
+	got, _ := json.Marshal(value)
+	want, err := json.Marshal(expect.Equals)
 	if err != nil {
-		result.Error = fmt.Sprintf("request failed: %v", err)
-		return result
+		return fmt.Errorf("invalid expected value: %v", err)
 	}
-	defer httpResp.Body.Close()
-
-	body, _ := io.ReadAll(httpResp.Body)
-
-	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
-		result.Error = fmt.Sprintf("webhook returned status %d: %s", httpResp.StatusCode, string(body))
-		return result
+	var wantValue interface{}
+	_ = json.Unmarshal(want, &wantValue)
+	want, _ = json.Marshal(wantValue)
+	if !bytes.Equal(got, want) {
+		return fmt.Errorf("%s is %s, expected %s", expect.JSONPath, got, want)
 	}
+	return nil
+}
 
-	result.Success = true
-	result.Details = map[string]interface{}{
-		"status_code": httpResp.StatusCode,
-		"response":    string(body),
+// lookupJSONPath walks a dotted path through decoded JSON; numeric segments index arrays
+func lookupJSONPath(doc interface{}, path string) (interface{}, bool) {
+	current := doc
+	for _, key := range strings.Split(path, ".") {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[key]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, false
+			}
+			current = node[index]
+		default:
+			return nil, false
+		}
 	}
-	return result
+	return current, true
 }
 
 // ============================================================================
@@ -359,21 +497,7 @@ func (h *robotHandler) callProcess(
 		SentAt: &now,
 	}
 
-	args := make([]interface{}, 0, 1+len(target.Args))
-	args = append(args, map[string]interface{}{
-		"content": map[string]interface{}{
-			"summary":     content.Summary,
-			"body":        content.Body,
-			"attachments": content.Attachments,
-		},
-		"context": map[string]interface{}{
-			"execution_id": deliveryCtx.ExecutionID,
-			"member_id":    deliveryCtx.MemberID,
-			"team_id":      deliveryCtx.TeamID,
-			"trigger_type": deliveryCtx.TriggerType,
-		},
-	})
-	args = append(args, target.Args...)
+	args := buildProcessArgs(content, target, deliveryCtx)
 
 	proc, err := process.Of(target.Process, args...)
 	if err != nil {
@@ -392,6 +516,91 @@ func (h *robotHandler) callProcess(
 	return result
 }
 
+// buildProcessArgs returns the process arguments: the {content, context} envelope
+// (or the target's ArgsTemplate resolved against it) followed by the target's Args
+func buildProcessArgs(content *robottypes.DeliveryContent, target robottypes.ProcessTarget, deliveryCtx *robottypes.DeliveryContext) []interface{} {
+	envelope := map[string]interface{}{
+		"content": map[string]interface{}{
+			"summary":     content.Summary,
+			"body":        content.Body,
+			"attachments": content.Attachments,
+		},
+		"context": map[string]interface{}{
+			"execution_id": deliveryCtx.ExecutionID,
+			"member_id":    deliveryCtx.MemberID,
+			"team_id":      deliveryCtx.TeamID,
+			"trigger_type": deliveryCtx.TriggerType,
+		},
+	}
+
+	args := make([]interface{}, 0, 1+len(target.ArgsTemplate)+len(target.Args))
+	if len(target.ArgsTemplate) == 0 {
+		args = append(args, envelope)
+	} else {
+		for _, tmpl := range target.ArgsTemplate {
+			args = append(args, bindProcessArg(tmpl, envelope))
+		}
+	}
+	return append(args, target.Args...)
+}
+
+// processArgVar matches a "{{ path }}" placeholder in an args template
+var processArgVar = regexp.MustCompile(`{{\s*([\w.]+)\s*}}`)
+
+// bindProcessArg resolves the placeholders of one args template value
+func bindProcessArg(tmpl interface{}, envelope map[string]interface{}) interface{} {
+	switch v := tmpl.(type) {
+	case string:
+		if m := processArgVar.FindStringSubmatch(v); m != nil && m[0] == strings.TrimSpace(v) {
+			return lookupProcessArg(envelope, m[1])
+		}
+		return processArgVar.ReplaceAllStringFunc(v, func(match string) string {
+			value := lookupProcessArg(envelope, processArgVar.FindStringSubmatch(match)[1])
+			switch val := value.(type) {
+			case nil:
+				return ""
+			case string:
+				return val
+			case map[string]interface{}, []robottypes.DeliveryAttachment:
+				raw, err := json.Marshal(val)
+				if err != nil {
+					return fmt.Sprintf("%v", val)
+				}
+				return string(raw)
+			default:
+				return fmt.Sprintf("%v", val)
+			}
+		})
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			out[key] = bindProcessArg(item, envelope)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = bindProcessArg(item, envelope)
+		}
+		return out
+	default:
+		return tmpl
+	}
+}
+
+// lookupProcessArg walks a dotted path through the envelope; unknown paths resolve to nil
+func lookupProcessArg(envelope map[string]interface{}, path string) interface{} {
+	var current interface{} = envelope
+	for _, key := range strings.Split(path, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = m[key]
+	}
+	return current
+}
+
 // ============================================================================
 // Helpers
 // ============================================================================
@@ -68,8 +68,26 @@ const (
 	ExecFailed    = "robot.exec.failed"
 	ExecCancelled = "robot.exec.cancelled"
 	ExecRecovered = "robot.exec.recovered"
-	Delivery      = "robot.delivery"
-	Message       = "robot.message"
+	PhaseChanged  = "robot.exec.phase"
+
+	ExecUncancelled = "robot.exec.uncancelled"
+	Delivery        = "robot.delivery"
+	Message         = "robot.message"
+)
+
+// Robot execution chain events.
+const (
+	ExecCascadeCancelled = "robot.exec.cascade_cancelled"
+)
+
+// Robot batch trigger events.
+const (
+	BatchCompleted = "robot.batch.completed"
+)
+
+// Robot member lifecycle events.
+const (
+	MemberCreated = "robot.member.created"
 )
 
 // Robot configuration change events (used by integrations Receiver).
@@ -87,6 +105,7 @@ type NeedInputPayload struct {
 	TaskID      string `json:"task_id"`
 	Question    string `json:"question"`
 	ChatID      string `json:"chat_id,omitempty"`
+	Approval    bool   `json:"approval,omitempty"` // waiting for approval of the task, not for an answer
 }
 
 // ExecPayload is a generic execution event payload.
@@ -97,15 +116,38 @@ type ExecPayload struct {
 	Status      string `json:"status,omitempty"`
 	Error       string `json:"error,omitempty"`
 	ChatID      string `json:"chat_id,omitempty"`
+
+	// Set on ExecCancelled only
+	CancelReason string `json:"cancel_reason,omitempty"`
+	CancelledBy  string `json:"cancelled_by,omitempty"`
+}
+
+// CascadePayload is the event payload for ExecCascadeCancelled events.
+// Cancelled lists the descendant executions cancelled along with ExecutionID.
+type CascadePayload struct {
+	ExecutionID string   `json:"execution_id"`
+	MemberID    string   `json:"member_id"`
+	TeamID      string   `json:"team_id"`
+	Cancelled   []string `json:"cancelled"`
 }
 
 // TaskPayload is the event payload for TaskFailed / TaskCompleted events.
 type TaskPayload struct {
+	ExecutionID string      `json:"execution_id"`
+	MemberID    string      `json:"member_id"`
+	TeamID      string      `json:"team_id"`
+	TaskID      string      `json:"task_id"`
+	Error       string      `json:"error,omitempty"`
+	ChatID      string      `json:"chat_id,omitempty"`
+	Output      interface{} `json:"output,omitempty"` // task output, set on TaskCompleted
+}
+
+// PhasePayload is the event payload for PhaseChanged events.
+type PhasePayload struct {
 	ExecutionID string `json:"execution_id"`
 	MemberID    string `json:"member_id"`
 	TeamID      string `json:"team_id"`
-	TaskID      string `json:"task_id"`
-	Error       string `json:"error,omitempty"`
+	Phase       string `json:"phase"`
 	ChatID      string `json:"chat_id,omitempty"`
 }
 
@@ -118,6 +160,18 @@ type DeliveryPayload struct {
 	Content     *robottypes.DeliveryContent     `json:"content,omitempty"`
 	Preferences *robottypes.DeliveryPreferences `json:"preferences,omitempty"`
 	Extra       map[string]any                  `json:"extra,omitempty"`
+	Timezone    string                          `json:"timezone,omitempty"` // robot timezone for rendering times
+}
+
+// BatchPayload is the event payload for BatchCompleted events.
+// When SuppressDelivery is set, this is the only notification for the whole batch.
+type BatchPayload struct {
+	BatchID          string                   `json:"batch_id"`
+	MemberID         string                   `json:"member_id"`
+	TeamID           string                   `json:"team_id"`
+	Status           string                   `json:"status"`
+	SuppressDelivery bool                     `json:"suppress_delivery,omitempty"`
+	Progress         robottypes.BatchProgress `json:"progress"`
 }
 
 // MessagePayload is the event payload for Message events (external channel messages).
@@ -160,6 +214,33 @@ type RobotConfigPayload struct {
 	TeamID   string `json:"team_id"`
 }
 
+// MemberPayload is the event payload for robot.member.* events.
+type MemberPayload struct {
+	TeamID   string `json:"team_id"`
+	MemberID string `json:"member_id"`
+	RoleID   string `json:"role_id,omitempty"`
+}
+
+// PayloadExecutionID returns the execution an event payload belongs to, "" for
+// payloads that are not about a single execution
+func PayloadExecutionID(payload interface{}) string {
+	switch p := payload.(type) {
+	case ExecPayload:
+		return p.ExecutionID
+	case TaskPayload:
+		return p.ExecutionID
+	case PhasePayload:
+		return p.ExecutionID
+	case NeedInputPayload:
+		return p.ExecutionID
+	case DeliveryPayload:
+		return p.ExecutionID
+	case CascadePayload:
+		return p.ExecutionID
+	}
+	return ""
+}
+
 // NormalizeLocale converts various language code formats (IETF BCP 47, etc.)
 // into the lowercase hyphenated form used by agentcontext (e.g. "zh-cn", "en-us").
 //
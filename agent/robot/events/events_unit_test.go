@@ -23,6 +23,7 @@ func TestEventConstants(t *testing.T) {
 		"ExecFailed":    "robot.exec.failed",
 		"ExecCancelled": "robot.exec.cancelled",
 		"ExecRecovered": "robot.exec.recovered",
+		"PhaseChanged":  "robot.exec.phase",
 		"Delivery":      "robot.delivery",
 		"Message":       "robot.message",
 	}
@@ -37,6 +38,7 @@ func TestEventConstants(t *testing.T) {
 		"ExecFailed":    events.ExecFailed,
 		"ExecCancelled": events.ExecCancelled,
 		"ExecRecovered": events.ExecRecovered,
+		"PhaseChanged":  events.PhaseChanged,
 		"Delivery":      events.Delivery,
 		"Message":       events.Message,
 	}
@@ -44,7 +46,7 @@ func TestEventConstants(t *testing.T) {
 	for name, exp := range expected {
 		assert.Equal(t, exp, actual[name], "Event constant %s mismatch", name)
 	}
-	assert.Len(t, actual, 11, "Expected exactly 11 event constants")
+	assert.Len(t, actual, 12, "Expected exactly 12 event constants")
 }
 
 func TestEventConstantNamingConvention(t *testing.T) {
@@ -52,7 +54,7 @@ func TestEventConstantNamingConvention(t *testing.T) {
 		events.TaskNeedInput, events.TaskFailed, events.TaskCompleted,
 		events.ExecWaiting, events.ExecResumed, events.ExecCompleted,
 		events.ExecFailed, events.ExecCancelled, events.ExecRecovered,
-		events.Delivery, events.Message,
+		events.PhaseChanged, events.Delivery, events.Message,
 	}
 
 	for _, e := range allEvents {
@@ -344,3 +346,12 @@ func TestNormalizeLocale(t *testing.T) {
 		})
 	}
 }
+
+func TestPayloadExecutionID(t *testing.T) {
+	assert.Equal(t, "exec-1", events.PayloadExecutionID(events.PhasePayload{ExecutionID: "exec-1", Phase: "run"}))
+	assert.Equal(t, "exec-2", events.PayloadExecutionID(events.TaskPayload{ExecutionID: "exec-2"}))
+	assert.Equal(t, "exec-3", events.PayloadExecutionID(events.ExecPayload{ExecutionID: "exec-3"}))
+	assert.Equal(t, "exec-4", events.PayloadExecutionID(events.NeedInputPayload{ExecutionID: "exec-4"}))
+	assert.Empty(t, events.PayloadExecutionID(events.BatchPayload{BatchID: "batch-1"}))
+	assert.Empty(t, events.PayloadExecutionID(nil))
+}
@@ -3,7 +3,11 @@ package events
 import (
 	"context"
 	"net/http"
+	"sync"
+	"time"
 
+	robotstore "github.com/yaoapp/yao/agent/robot/store"
+	robottypes "github.com/yaoapp/yao/agent/robot/types"
 	eventtypes "github.com/yaoapp/yao/event/types"
 )
 
@@ -12,15 +16,37 @@ type TestHandler struct {
 	h *robotHandler
 }
 
-// NewTestHandler creates a robotHandler for testing.
+// NewTestHandler creates a robotHandler for testing; dead letters are kept in memory.
 func NewTestHandler() *TestHandler {
 	return &TestHandler{
 		h: &robotHandler{
-			httpClient: http.DefaultClient,
+			httpClient:  http.DefaultClient,
+			deadLetters: &memoryDeadLetters{},
 		},
 	}
 }
 
+// memoryDeadLetters records dead letters instead of saving them.
+type memoryDeadLetters struct {
+	mu      sync.Mutex
+	letters []*robotstore.DeadLetter
+}
+
+func (m *memoryDeadLetters) Create(ctx context.Context, letter *robotstore.DeadLetter) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.letters = append(m.letters, letter)
+	return nil
+}
+
+// DeadLetters returns the dead letters the handler has written.
+func (th *TestHandler) DeadLetters() []*robotstore.DeadLetter {
+	m := th.h.deadLetters.(*memoryDeadLetters)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*robotstore.DeadLetter(nil), m.letters...)
+}
+
 // Handle delegates to the internal robotHandler.Handle.
 func (th *TestHandler) Handle(ctx context.Context, ev *eventtypes.Event, resp chan<- eventtypes.Result) {
 	th.h.Handle(ctx, ev, resp)
@@ -30,3 +56,57 @@ func (th *TestHandler) Handle(ctx context.Context, ev *eventtypes.Event, resp ch
 func (th *TestHandler) Shutdown(ctx context.Context) error {
 	return th.h.Shutdown(ctx)
 }
+
+// CheckWebhookURL exposes checkWebhookURL for external tests.
+func CheckWebhookURL(ctx context.Context, rawURL string, policy robottypes.WebhookPolicy) error {
+	return checkWebhookURL(ctx, rawURL, policy)
+}
+
+// BuildProcessArgs exposes buildProcessArgs for external tests.
+func BuildProcessArgs(content *robottypes.DeliveryContent, target robottypes.ProcessTarget, deliveryCtx *robottypes.DeliveryContext) []interface{} {
+	return buildProcessArgs(content, target, deliveryCtx)
+}
+
+// NormalizeRecipients exposes normalizeRecipients for external tests.
+func NormalizeRecipients(to []string) ([]string, []string) {
+	return normalizeRecipients(to)
+}
+
+// CheckWebhookResponse exposes checkWebhookResponse for external tests.
+func CheckWebhookResponse(body []byte, expect *robottypes.WebhookExpect) error {
+	return checkWebhookResponse(body, expect)
+}
+
+// RenderDeliveryBody exposes renderDeliveryBody for external tests; returns filename, content type and data.
+func RenderDeliveryBody(ctx context.Context, content *robottypes.DeliveryContent, executionID string) (string, string, []byte, error) {
+	rendered, err := renderDeliveryBody(ctx, content, executionID)
+	if err != nil || rendered == nil {
+		return "", "", nil, err
+	}
+	return rendered.Filename, rendered.ContentType, rendered.Data, nil
+}
+
+// AttachThumbnails exposes robotHandler.attachThumbnails for external tests.
+func (th *TestHandler) AttachThumbnails(ctx context.Context, content *robottypes.DeliveryContent, pref *robottypes.ThumbnailPreference, deliveryCtx *robottypes.DeliveryContext) *robottypes.DeliveryContent {
+	return th.h.attachThumbnails(ctx, content, pref, deliveryCtx)
+}
+
+// RecipientData exposes recipientData for external tests.
+func RecipientData(email string, member map[string]interface{}, sections map[string]string) map[string]interface{} {
+	return recipientData(email, member, sections)
+}
+
+// PersonalizeContent exposes personalizeContent for external tests.
+func PersonalizeContent(content *robottypes.DeliveryContent, subject string, recipient map[string]interface{}, deliveryCtx *robottypes.DeliveryContext) (*robottypes.DeliveryContent, string) {
+	return personalizeContent(content, subject, recipient, deliveryCtx)
+}
+
+// ParseRetryAfter exposes parseRetryAfter for external tests.
+func ParseRetryAfter(value string, now time.Time) time.Duration {
+	return parseRetryAfter(value, now)
+}
+
+// WebhookBackoff exposes webhookBackoff for external tests.
+func WebhookBackoff(retry robottypes.WebhookRetry, n int, retryAfter time.Duration) time.Duration {
+	return webhookBackoff(retry, n, retryAfter)
+}
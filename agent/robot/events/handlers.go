@@ -5,19 +5,25 @@ import (
 	"net/http"
 	"time"
 
+	robotstore "github.com/yaoapp/yao/agent/robot/store"
 	"github.com/yaoapp/yao/event"
 	eventtypes "github.com/yaoapp/yao/event/types"
 )
 
+// defaultHandler is the registered robot handler, also used to redeliver dead letters
+var defaultHandler = &robotHandler{
+	httpClient:  newWebhookHTTPClient(30 * time.Second),
+	deadLetters: robotstore.NewDeadLetterStore(),
+}
+
 func init() {
-	event.Register("robot", &robotHandler{
-		httpClient: &http.Client{Timeout: 30 * time.Second},
-	})
+	event.Register("robot", defaultHandler)
 }
 
 // robotHandler processes all robot.* events.
 type robotHandler struct {
-	httpClient *http.Client
+	httpClient  *http.Client
+	deadLetters deadLetterSink // nil = failed deliveries are not kept
 }
 
 // Handle dispatches robot events by type.
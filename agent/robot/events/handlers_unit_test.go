@@ -7,7 +7,10 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -17,6 +20,7 @@ import (
 )
 
 func TestRobotHandler_DeliveryWebhook(t *testing.T) {
+	allowLoopbackWebhooks(t)
 	var received map[string]interface{}
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		decoder := json.NewDecoder(r.Body)
@@ -178,6 +182,7 @@ func TestVerifyHMACSignature_TamperedPayload(t *testing.T) {
 }
 
 func TestRobotHandler_WebhookWithSignature(t *testing.T) {
+	allowLoopbackWebhooks(t)
 	var receivedSig string
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		receivedSig = r.Header.Get("X-Yao-Signature")
@@ -219,3 +224,522 @@ func TestRobotHandler_WebhookWithSignature(t *testing.T) {
 	assert.NotEmpty(t, receivedSig, "webhook should receive HMAC signature header")
 	assert.Len(t, receivedSig, 64)
 }
+
+// allowLoopbackWebhooks lets webhook deliveries reach httptest servers on 127.0.0.1
+func allowLoopbackWebhooks(t *testing.T) {
+	t.Helper()
+	prev := robottypes.GetWebhookPolicy()
+	robottypes.SetWebhookPolicy(robottypes.WebhookPolicy{AllowHosts: []string{"127.0.0.1"}})
+	t.Cleanup(func() { robottypes.SetWebhookPolicy(prev) })
+}
+
+// fastWebhookRetry shortens the webhook backoff so retry tests run in milliseconds
+func fastWebhookRetry(t *testing.T, maxAttempts int) {
+	t.Helper()
+	robottypes.SetWebhookRetry(robottypes.WebhookRetry{
+		MaxAttempts: maxAttempts,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    10 * time.Millisecond,
+	})
+	t.Cleanup(func() { robottypes.SetWebhookRetry(robottypes.WebhookRetry{}) })
+}
+
+// deliverWebhook delivers one event to url and returns the webhook's channel result
+func deliverWebhook(t *testing.T, handler *events.TestHandler, url string) robottypes.ChannelResult {
+	t.Helper()
+	return deliverWebhookTarget(t, handler, robottypes.WebhookTarget{URL: url})
+}
+
+// deliverWebhookTarget delivers one event to target and returns the webhook's channel result
+func deliverWebhookTarget(t *testing.T, handler *events.TestHandler, target robottypes.WebhookTarget) robottypes.ChannelResult {
+	t.Helper()
+	ev := &eventtypes.Event{
+		Type:   events.Delivery,
+		ID:     "test-ev-retry",
+		IsCall: true,
+		Payload: events.DeliveryPayload{
+			ExecutionID: "exec-retry",
+			MemberID:    "member-retry",
+			TeamID:      "team-retry",
+			Content:     &robottypes.DeliveryContent{Summary: "retry", Body: "body"},
+			Preferences: &robottypes.DeliveryPreferences{
+				Webhook: &robottypes.WebhookPreference{
+					Enabled: true,
+					Targets: []robottypes.WebhookTarget{target},
+				},
+			},
+		},
+	}
+
+	resp := make(chan eventtypes.Result, 1)
+	handler.Handle(context.Background(), ev, resp)
+	result := <-resp
+	data, ok := result.Data.(map[string]interface{})
+	require.True(t, ok)
+	results, ok := data["results"].([]robottypes.ChannelResult)
+	require.True(t, ok)
+	require.Len(t, results, 1)
+	return results[0]
+}
+
+func TestRobotHandler_WebhookRetriesServerErrors(t *testing.T) {
+	allowLoopbackWebhooks(t)
+	fastWebhookRetry(t, 3)
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	handler := events.NewTestHandler()
+	r := deliverWebhook(t, handler, server.URL)
+	assert.True(t, r.Success, r.Error)
+	assert.Equal(t, 3, r.Attempts)
+	assert.Equal(t, robottypes.DeliveryDelivered, r.Disposition)
+	assert.Empty(t, handler.DeadLetters())
+}
+
+func TestRobotHandler_WebhookTargetRetryLimit(t *testing.T) {
+	allowLoopbackWebhooks(t)
+	fastWebhookRetry(t, 5)
+
+	t.Run("stops at max_retries and keeps the last status", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1)%2 == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		handler := events.NewTestHandler()
+		r := deliverWebhookTarget(t, handler, robottypes.WebhookTarget{URL: server.URL, MaxRetries: 2, RetryBackoff: 1})
+		assert.False(t, r.Success)
+		assert.Equal(t, 3, r.Attempts)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+		details, ok := r.Details.(map[string]interface{})
+		require.True(t, ok)
+		assert.Equal(t, 3, details["attempts"])
+		assert.Equal(t, http.StatusServiceUnavailable, details["status_code"])
+	})
+
+	t.Run("recovers within the limit", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		handler := events.NewTestHandler()
+		r := deliverWebhookTarget(t, handler, robottypes.WebhookTarget{URL: server.URL, MaxRetries: 1})
+		assert.True(t, r.Success, r.Error)
+		details, ok := r.Details.(map[string]interface{})
+		require.True(t, ok)
+		assert.Equal(t, 2, details["attempts"])
+		assert.Equal(t, http.StatusOK, details["status_code"])
+	})
+
+	t.Run("negative max_retries never retries", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		handler := events.NewTestHandler()
+		r := deliverWebhookTarget(t, handler, robottypes.WebhookTarget{URL: server.URL, MaxRetries: -1})
+		assert.False(t, r.Success)
+		assert.Equal(t, 1, r.Attempts)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+}
+
+func TestRobotHandler_DeliverySlack(t *testing.T) {
+	allowLoopbackWebhooks(t)
+	fastWebhookRetry(t, 1)
+
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	ev := &eventtypes.Event{
+		Type:   events.Delivery,
+		ID:     "test-ev-slack",
+		IsCall: true,
+		Payload: events.DeliveryPayload{
+			ExecutionID: "exec-slack",
+			MemberID:    "member-slack",
+			TeamID:      "team-slack",
+			Content: &robottypes.DeliveryContent{
+				Summary: "Weekly sales report",
+				Body:    "*Revenue* is up 12%",
+				Attachments: []robottypes.DeliveryAttachment{
+					{Title: "sales.xlsx", File: "__yao.attachment://sales"},
+				},
+			},
+			Preferences: &robottypes.DeliveryPreferences{
+				Slack: &robottypes.SlackPreference{
+					Enabled: true,
+					Targets: []robottypes.SlackTarget{{
+						WebhookURL:   server.URL,
+						Channel:      "#reports",
+						MentionUsers: []string{"U024BE7LH", "here"},
+					}},
+				},
+			},
+		},
+	}
+
+	resp := make(chan eventtypes.Result, 1)
+	events.NewTestHandler().Handle(context.Background(), ev, resp)
+	result := <-resp
+	require.NoError(t, result.Err)
+
+	data, ok := result.Data.(map[string]interface{})
+	require.True(t, ok)
+	results, ok := data["results"].([]robottypes.ChannelResult)
+	require.True(t, ok)
+	require.Len(t, results, 1)
+	assert.Equal(t, robottypes.DeliverySlack, results[0].Type)
+	assert.Equal(t, "#reports", results[0].Target)
+	assert.True(t, results[0].Success, results[0].Error)
+	assert.Equal(t, robottypes.DeliveryDelivered, results[0].Disposition)
+
+	require.NotNil(t, received)
+	assert.Equal(t, "#reports", received["channel"])
+	assert.Equal(t, "Weekly sales report", received["text"])
+
+	blocks, ok := received["blocks"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, blocks, 3)
+
+	header := blocks[0].(map[string]interface{})
+	assert.Equal(t, "header", header["type"])
+	assert.Equal(t, map[string]interface{}{"type": "plain_text", "text": "Weekly sales report", "emoji": true}, header["text"])
+
+	section := blocks[1].(map[string]interface{})
+	assert.Equal(t, "section", section["type"])
+	assert.Equal(t, map[string]interface{}{"type": "mrkdwn", "text": "<@U024BE7LH> <!here>\n*Revenue* is up 12%"}, section["text"])
+
+	footer := blocks[2].(map[string]interface{})
+	assert.Equal(t, "context", footer["type"])
+	elements := footer["elements"].([]interface{})
+	require.Len(t, elements, 1)
+	assert.Equal(t, "Attachments: sales.xlsx", elements[0].(map[string]interface{})["text"])
+}
+
+func TestRobotHandler_DeliverySlackError(t *testing.T) {
+	allowLoopbackWebhooks(t)
+	fastWebhookRetry(t, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("invalid_blocks"))
+	}))
+	defer server.Close()
+
+	ev := &eventtypes.Event{
+		Type:   events.Delivery,
+		ID:     "test-ev-slack-error",
+		IsCall: true,
+		Payload: events.DeliveryPayload{
+			ExecutionID: "exec-slack-error",
+			Content:     &robottypes.DeliveryContent{Summary: strings.Repeat("s", 200)},
+			Preferences: &robottypes.DeliveryPreferences{
+				Slack: &robottypes.SlackPreference{
+					Enabled: true,
+					Targets: []robottypes.SlackTarget{{WebhookURL: server.URL + "/services/T000/B000/s3cr3t"}},
+				},
+			},
+		},
+	}
+
+	resp := make(chan eventtypes.Result, 1)
+	events.NewTestHandler().Handle(context.Background(), ev, resp)
+	result := <-resp
+	require.Error(t, result.Err)
+	assert.Contains(t, result.Err.Error(), "slack delivery failed")
+
+	results := result.Data.(map[string]interface{})["results"].([]robottypes.ChannelResult)
+	require.Len(t, results, 1)
+	assert.False(t, results[0].Success)
+	assert.Contains(t, results[0].Error, "invalid_blocks")
+	assert.Equal(t, robottypes.DeliveryFailed, results[0].Disposition)
+
+	// The webhook URL is a secret: results carry the host only
+	assert.Equal(t, server.URL+"/…", results[0].Target)
+	assert.NotContains(t, results[0].Error, "s3cr3t")
+}
+
+func TestRobotHandler_WebhookDeadLetter(t *testing.T) {
+	allowLoopbackWebhooks(t)
+	fastWebhookRetry(t, 3)
+
+	t.Run("client errors are not retried", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		handler := events.NewTestHandler()
+		r := deliverWebhook(t, handler, server.URL)
+		assert.False(t, r.Success)
+		assert.Equal(t, 1, r.Attempts)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+		assert.Equal(t, robottypes.DeliveryDeadLettered, r.Disposition)
+		require.Len(t, handler.DeadLetters(), 1)
+	})
+
+	t.Run("server errors exhaust the attempts", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		handler := events.NewTestHandler()
+		r := deliverWebhook(t, handler, server.URL)
+		assert.False(t, r.Success)
+		assert.Equal(t, 3, r.Attempts)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+		assert.Equal(t, robottypes.DeliveryDeadLettered, r.Disposition)
+
+		letters := handler.DeadLetters()
+		require.Len(t, letters, 1)
+		letter := letters[0]
+		assert.Equal(t, "exec-retry", letter.ExecutionID)
+		assert.Equal(t, server.URL, letter.Target)
+		assert.Equal(t, 3, letter.Attempts)
+		assert.Contains(t, letter.LastError, "502")
+		assert.Len(t, letter.PayloadHash, 64)
+		assert.Contains(t, letter.Payload, `"execution_id":"exec-retry"`)
+	})
+
+	t.Run("blocked targets are failed without a letter", func(t *testing.T) {
+		handler := events.NewTestHandler()
+		r := deliverWebhook(t, handler, "http://10.0.0.1/hook")
+		assert.False(t, r.Success)
+		assert.Equal(t, robottypes.DeliveryFailed, r.Disposition)
+		assert.Empty(t, handler.DeadLetters())
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, 5*time.Second, events.ParseRetryAfter("5", now))
+	assert.Equal(t, 90*time.Second, events.ParseRetryAfter(now.Add(90*time.Second).Format(http.TimeFormat), now))
+	assert.Zero(t, events.ParseRetryAfter(now.Add(-time.Minute).Format(http.TimeFormat), now))
+	assert.Zero(t, events.ParseRetryAfter("", now))
+	assert.Zero(t, events.ParseRetryAfter("soon", now))
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	retry := robottypes.WebhookRetry{MaxAttempts: 5, BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+
+	for n, max := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 5: 10 * time.Second} {
+		d := events.WebhookBackoff(retry, n, 0)
+		assert.GreaterOrEqual(t, d, max/2, "attempt %d", n)
+		assert.LessOrEqual(t, d, max, "attempt %d", n)
+	}
+
+	// Retry-After wins when longer, but never beyond MaxDelay
+	assert.Equal(t, 8*time.Second, events.WebhookBackoff(retry, 1, 8*time.Second))
+	assert.Equal(t, 10*time.Second, events.WebhookBackoff(retry, 1, time.Hour))
+}
+
+func TestBuildProcessArgs(t *testing.T) {
+	content := &robottypes.DeliveryContent{
+		Summary:     "Weekly report ready",
+		Body:        "# Report\nSales up 4%",
+		Attachments: []robottypes.DeliveryAttachment{{Title: "chart", File: "__local://f1"}},
+	}
+	dctx := &robottypes.DeliveryContext{ExecutionID: "exec_1", MemberID: "robot_1", TeamID: "team_1", TriggerType: robottypes.TriggerHuman}
+
+	t.Run("default envelope", func(t *testing.T) {
+		args := events.BuildProcessArgs(content, robottypes.ProcessTarget{Process: "scripts.notify", Args: []any{"extra"}}, dctx)
+		require.Len(t, args, 2)
+		envelope, ok := args[0].(map[string]interface{})
+		require.True(t, ok)
+		assert.Equal(t, "Weekly report ready", envelope["content"].(map[string]interface{})["summary"])
+		assert.Equal(t, "exec_1", envelope["context"].(map[string]interface{})["execution_id"])
+		assert.Equal(t, "extra", args[1])
+	})
+
+	t.Run("flat args from template", func(t *testing.T) {
+		args := events.BuildProcessArgs(content, robottypes.ProcessTarget{
+			Process: "scripts.notify",
+			ArgsTemplate: []any{
+				"{{ content.body }}",
+				"[{{context.trigger_type}}] {{ content.summary }} ({{ context.execution_id }})",
+				map[string]interface{}{"files": "{{ content.attachments }}", "team": "{{ context.team_id }}", "fixed": 3},
+				"{{ content.missing }}",
+			},
+			Args: []any{"extra"},
+		}, dctx)
+		require.Len(t, args, 5)
+		assert.Equal(t, "# Report\nSales up 4%", args[0])
+		assert.Equal(t, "[human] Weekly report ready (exec_1)", args[1])
+		opts := args[2].(map[string]interface{})
+		assert.Equal(t, content.Attachments, opts["files"], "whole-value placeholders keep their type")
+		assert.Equal(t, "team_1", opts["team"])
+		assert.Equal(t, 3, opts["fixed"])
+		assert.Nil(t, args[3])
+		assert.Equal(t, "extra", args[4])
+	})
+}
+
+func TestNormalizeRecipients(t *testing.T) {
+	recipients, invalid := events.NormalizeRecipients([]string{
+		" Foo@Example.COM ",
+		"foo@example.com",
+		"bar@example.com",
+		"",
+		"not-an-email",
+		"baz@",
+	})
+	assert.Equal(t, []string{"Foo@example.com", "bar@example.com"}, recipients, "case variants of one address are sent once")
+	assert.Equal(t, []string{"not-an-email", "baz@"}, invalid)
+
+	recipients, invalid = events.NormalizeRecipients(nil)
+	assert.Empty(t, recipients)
+	assert.Empty(t, invalid)
+}
+
+func TestCheckWebhookResponse(t *testing.T) {
+	body := []byte(`{"ok":true,"code":0,"data":{"items":[{"id":"m1"}]},"msg":"accepted"}`)
+
+	tests := []struct {
+		name   string
+		expect robottypes.WebhookExpect
+		errMsg string
+	}{
+		{"path equals bool", robottypes.WebhookExpect{JSONPath: "ok", Equals: true}, ""},
+		{"path equals number", robottypes.WebhookExpect{JSONPath: "code", Equals: 0}, ""},
+		{"array index", robottypes.WebhookExpect{JSONPath: "data.items.0.id", Equals: "m1"}, ""},
+		{"path exists", robottypes.WebhookExpect{JSONPath: "data.items"}, ""},
+		{"substring", robottypes.WebhookExpect{Contains: "accepted"}, ""},
+		{"wrong value", robottypes.WebhookExpect{JSONPath: "code", Equals: 1}, "code is 0, expected 1"},
+		{"missing path", robottypes.WebhookExpect{JSONPath: "data.missing"}, "not found"},
+		{"index out of range", robottypes.WebhookExpect{JSONPath: "data.items.3"}, "not found"},
+		{"missing substring", robottypes.WebhookExpect{Contains: "done"}, "does not contain"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := events.CheckWebhookResponse(body, &tt.expect)
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+
+	t.Run("non-JSON body with path", func(t *testing.T) {
+		err := events.CheckWebhookResponse([]byte("OK"), &robottypes.WebhookExpect{JSONPath: "ok"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not JSON")
+	})
+}
+
+func TestRenderDeliveryBody(t *testing.T) {
+	ctx := context.Background()
+	content := &robottypes.DeliveryContent{Summary: "Weekly <sales>", Body: "# Sales\n\nUp **12%**"}
+
+	t.Run("markdown renders nothing", func(t *testing.T) {
+		name, _, data, err := events.RenderDeliveryBody(ctx, content, "exec_1")
+		require.NoError(t, err)
+		assert.Empty(t, name)
+		assert.Nil(t, data)
+	})
+
+	t.Run("html document", func(t *testing.T) {
+		c := *content
+		c.Format = robottypes.DeliveryFormatHTML
+		name, contentType, data, err := events.RenderDeliveryBody(ctx, &c, "exec_1")
+		require.NoError(t, err)
+		assert.Equal(t, "report-exec_1.html", name)
+		assert.Equal(t, "text/html", contentType)
+		assert.Contains(t, string(data), "<title>Weekly &lt;sales&gt;</title>")
+		assert.Contains(t, string(data), "<strong>12%</strong>")
+	})
+
+	t.Run("pdf falls back to html without a renderer", func(t *testing.T) {
+		events.RegisterPDFRenderer(nil)
+		c := *content
+		c.Format = robottypes.DeliveryFormatPDF
+		name, _, _, err := events.RenderDeliveryBody(ctx, &c, "exec_1")
+		require.NoError(t, err)
+		assert.Equal(t, "report-exec_1.html", name)
+	})
+
+	t.Run("pdf via the registered renderer", func(t *testing.T) {
+		events.RegisterPDFRenderer(func(ctx context.Context, html []byte) ([]byte, error) {
+			return append([]byte("%PDF-"), html[:15]...), nil
+		})
+		defer events.RegisterPDFRenderer(nil)
+
+		c := *content
+		c.Format = robottypes.DeliveryFormatPDF
+		name, contentType, data, err := events.RenderDeliveryBody(ctx, &c, "exec_1")
+		require.NoError(t, err)
+		assert.Equal(t, "report-exec_1.pdf", name)
+		assert.Equal(t, "application/pdf", contentType)
+		assert.Equal(t, "%PDF-<!DOCTYPE html>", string(data))
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		c := *content
+		c.Format = "docx"
+		_, _, _, err := events.RenderDeliveryBody(ctx, &c, "exec_1")
+		assert.Error(t, err)
+	})
+}
+
+func TestAttachThumbnailsSkipsNonImages(t *testing.T) {
+	handler := events.NewTestHandler()
+	deliveryCtx := &robottypes.DeliveryContext{MemberID: "robot_1", ExecutionID: "exec_1"}
+	content := &robottypes.DeliveryContent{
+		Summary: "Report",
+		Attachments: []robottypes.DeliveryAttachment{
+			{Title: "notes.txt", File: "__yao.attachment://abc", ContentType: "text/plain"},
+			{Title: "chart.png", File: "workspace://ws_1/chart.png", ContentType: "image/png"},
+			{Title: "logo.png", File: "https://example.com/logo.png"},
+		},
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		out := handler.AttachThumbnails(context.Background(), content, &robottypes.ThumbnailPreference{}, deliveryCtx)
+		assert.Same(t, content, out)
+	})
+
+	t.Run("nothing to preview", func(t *testing.T) {
+		out := handler.AttachThumbnails(context.Background(), content, &robottypes.ThumbnailPreference{Enabled: true}, deliveryCtx)
+		require.Len(t, out.Attachments, 3)
+		for _, att := range out.Attachments {
+			assert.Empty(t, att.Thumbnail, att.Title)
+		}
+	})
+
+	assert.Equal(t, 256, (&robottypes.ThumbnailPreference{}).GetSize())
+	assert.Equal(t, 128, (&robottypes.ThumbnailPreference{Size: 128}).GetSize())
+}
@@ -0,0 +1,134 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	robotstore "github.com/yaoapp/yao/agent/robot/store"
+	robottypes "github.com/yaoapp/yao/agent/robot/types"
+	messengerTypes "github.com/yaoapp/yao/messenger/types"
+)
+
+// emailSender is the part of the messenger service used to send email
+type emailSender interface {
+	Send(ctx context.Context, channel string, message *messengerTypes.Message) error
+}
+
+// sendPersonalizedEmails sends one message per recipient with content.RecipientTemplate
+// expanded for that recipient. The result succeeds only when every recipient was reached;
+// Recipients lists the ones that were.
+func (h *robotHandler) sendPersonalizedEmails(
+	ctx context.Context,
+	svc emailSender,
+	content *robottypes.DeliveryContent,
+	target robottypes.EmailTarget,
+	recipients []string,
+	deliveryCtx *robottypes.DeliveryContext,
+	result robottypes.ChannelResult,
+) robottypes.ChannelResult {
+	members, err := robotstore.NewRobotStore().TeamMembersByEmail(ctx, deliveryCtx.TeamID, recipients)
+	if err != nil {
+		log.Warn("email delivery: execution=%s member lookup failed, personalizing by address only: %v", deliveryCtx.ExecutionID, err)
+		members = nil
+	}
+
+	attachments := convertAttachments(ctx, content.Attachments)
+	channel := robottypes.DefaultEmailChannel()
+
+	var sent, failures []string
+	for _, to := range recipients {
+		personal, subject := personalizeContent(content, target.Subject, recipientData(to, members[strings.ToLower(to)], content.Sections), deliveryCtx)
+		htmlBody, plainBody := buildEmailBody(target.Template, personal)
+		msg := &messengerTypes.Message{
+			To:      []string{to},
+			Subject: buildEmailSubject(subject, target.Template, personal, deliveryCtx),
+			Body:    plainBody,
+			HTML:    htmlBody,
+			Type:    messengerTypes.MessageTypeEmail,
+		}
+		if len(attachments) > 0 {
+			msg.Attachments = attachments
+		}
+		if err := svc.Send(ctx, channel, msg); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", to, err))
+			continue
+		}
+		sent = append(sent, to)
+	}
+
+	result.Recipients = sent
+	result.Success = len(failures) == 0
+	if len(failures) > 0 {
+		result.Error = fmt.Sprintf("%d of %d personalized emails failed: %s", len(failures), len(recipients), strings.Join(failures, "; "))
+	}
+	return result
+}
+
+// recipientData builds the {{ recipient.* }} values for one address. member is the
+// matching team member row, nil for recipients outside the team.
+func recipientData(email string, member map[string]interface{}, sections map[string]string) map[string]interface{} {
+	name, _, _ := strings.Cut(email, "@")
+	data := map[string]interface{}{
+		"email": email,
+		"name":  name,
+	}
+	for _, field := range []string{"member_id", "user_id", "display_name", "role_id", "timezone"} {
+		if v, ok := member[field].(string); ok && v != "" {
+			data[field] = v
+		}
+	}
+	if name, ok := data["display_name"].(string); ok {
+		data["name"] = name
+	}
+
+	// Most specific section first; "default" catches everyone else
+	memberID, _ := data["member_id"].(string)
+	roleID, _ := data["role_id"].(string)
+	for _, key := range []string{strings.ToLower(email), email, memberID, roleID, "default"} {
+		if section, ok := sections[key]; ok && key != "" {
+			data["section"] = section
+			break
+		}
+	}
+	return data
+}
+
+// personalizeContent returns a copy of content whose body is the recipient template
+// expanded for recipient, and the subject with the same placeholders resolved
+func personalizeContent(content *robottypes.DeliveryContent, subject string, recipient map[string]interface{}, deliveryCtx *robottypes.DeliveryContext) (*robottypes.DeliveryContent, string) {
+	envelope := map[string]interface{}{
+		"recipient": recipient,
+		"content": map[string]interface{}{
+			"summary": content.Summary,
+			"body":    content.Body,
+		},
+		"context": map[string]interface{}{
+			"execution_id": deliveryCtx.ExecutionID,
+			"member_id":    deliveryCtx.MemberID,
+			"team_id":      deliveryCtx.TeamID,
+		},
+	}
+
+	out := *content
+	out.Body = renderTemplateString(content.RecipientTemplate, envelope)
+	out.Summary = renderTemplateString(content.Summary, envelope)
+	out.RecipientTemplate = ""
+	out.Sections = nil
+	return &out, renderTemplateString(subject, envelope)
+}
+
+// renderTemplateString resolves the {{ path }} placeholders of a text template
+func renderTemplateString(tmpl string, envelope map[string]interface{}) string {
+	if tmpl == "" {
+		return ""
+	}
+	switch v := bindProcessArg(tmpl, envelope).(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
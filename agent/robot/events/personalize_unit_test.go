@@ -0,0 +1,73 @@
+//go:build unit
+
+package events_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	events "github.com/yaoapp/yao/agent/robot/events"
+	robottypes "github.com/yaoapp/yao/agent/robot/types"
+)
+
+func TestRecipientData(t *testing.T) {
+	sections := map[string]string{
+		"vip@example.com": "by email",
+		"mem_2":           "by member",
+		"manager":         "by role",
+		"default":         "fallback",
+	}
+
+	t.Run("outside the team uses the address", func(t *testing.T) {
+		data := events.RecipientData("guest@example.com", nil, sections)
+		assert.Equal(t, "guest", data["name"])
+		assert.Equal(t, "guest@example.com", data["email"])
+		assert.Equal(t, "fallback", data["section"])
+		assert.NotContains(t, data, "member_id")
+	})
+
+	t.Run("member fields and display name", func(t *testing.T) {
+		data := events.RecipientData("ann@example.com", map[string]interface{}{
+			"member_id":    "mem_1",
+			"display_name": "Ann",
+			"role_id":      "manager",
+		}, sections)
+		assert.Equal(t, "Ann", data["name"])
+		assert.Equal(t, "mem_1", data["member_id"])
+		assert.Equal(t, "by role", data["section"])
+	})
+
+	t.Run("most specific section wins", func(t *testing.T) {
+		data := events.RecipientData("VIP@example.com", map[string]interface{}{"member_id": "mem_2", "role_id": "manager"}, sections)
+		assert.Equal(t, "by email", data["section"])
+
+		data = events.RecipientData("bob@example.com", map[string]interface{}{"member_id": "mem_2", "role_id": "manager"}, sections)
+		assert.Equal(t, "by member", data["section"])
+	})
+
+	t.Run("no matching section", func(t *testing.T) {
+		data := events.RecipientData("bob@example.com", nil, map[string]string{"manager": "x"})
+		assert.NotContains(t, data, "section")
+	})
+}
+
+func TestPersonalizeContent(t *testing.T) {
+	content := &robottypes.DeliveryContent{
+		Summary:           "Weekly report for {{ recipient.name }}",
+		Body:              "shared body",
+		RecipientTemplate: "Hi {{ recipient.name }},\n\n{{ recipient.section }}\n\n{{ content.body }}",
+		Sections:          map[string]string{"default": "x"},
+	}
+	recipient := events.RecipientData("ann@example.com", map[string]interface{}{"display_name": "Ann"}, map[string]string{"default": "Your numbers are up."})
+
+	out, subject := events.PersonalizeContent(content, "Report {{ context.execution_id }} for {{ recipient.email }}", recipient, &robottypes.DeliveryContext{ExecutionID: "exec_1"})
+	assert.Equal(t, "Hi Ann,\n\nYour numbers are up.\n\nshared body", out.Body)
+	assert.Equal(t, "Weekly report for Ann", out.Summary)
+	assert.Equal(t, "Report exec_1 for ann@example.com", subject)
+	assert.Empty(t, out.RecipientTemplate)
+	assert.Nil(t, out.Sections)
+
+	// The shared content is left untouched for the next recipient
+	assert.Equal(t, "shared body", content.Body)
+	assert.NotEmpty(t, content.RecipientTemplate)
+}
@@ -0,0 +1,160 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"mime/multipart"
+	"net/textproto"
+	"sync"
+
+	"github.com/yaoapp/gou/text"
+	robottypes "github.com/yaoapp/yao/agent/robot/types"
+	"github.com/yaoapp/yao/attachment"
+)
+
+// renderUploader is the attachment manager rendered artifacts are stored in
+const renderUploader = "__yao.attachment"
+
+// PDFRenderer converts a standalone HTML document to PDF.
+// No renderer ships with the robot; deployments register one (e.g. a headless
+// browser) at startup. Without it, pdf deliveries fall back to an HTML file.
+type PDFRenderer func(ctx context.Context, html []byte) ([]byte, error)
+
+var (
+	pdfRenderer   PDFRenderer
+	pdfRendererMu sync.RWMutex
+)
+
+// RegisterPDFRenderer sets the renderer used for DeliveryContent.Format = "pdf"
+func RegisterPDFRenderer(fn PDFRenderer) {
+	pdfRendererMu.Lock()
+	defer pdfRendererMu.Unlock()
+	pdfRenderer = fn
+}
+
+func getPDFRenderer() PDFRenderer {
+	pdfRendererMu.RLock()
+	defer pdfRendererMu.RUnlock()
+	return pdfRenderer
+}
+
+// renderedBody is a delivery body rendered to a file
+type renderedBody struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// attachRenderedBody renders the body in content.Format, uploads it through the
+// attachment manager and returns a copy of content with the artifact appended.
+// Rendering failures are logged and the original content is delivered unchanged.
+func (h *robotHandler) attachRenderedBody(ctx context.Context, content *robottypes.DeliveryContent, deliveryCtx *robottypes.DeliveryContext) *robottypes.DeliveryContent {
+	rendered, err := renderDeliveryBody(ctx, content, deliveryCtx.ExecutionID)
+	if err != nil {
+		log.Warn("delivery handler: render %s failed for execution=%s: %v", content.Format, deliveryCtx.ExecutionID, err)
+		return content
+	}
+	if rendered == nil {
+		return content
+	}
+
+	manager, ok := attachment.Managers[renderUploader]
+	if !ok {
+		log.Warn("delivery handler: %s manager not found, %s not attached", renderUploader, rendered.Filename)
+		return content
+	}
+
+	file, err := manager.Upload(ctx, uploadFileHeader(rendered.Filename, rendered.ContentType, len(rendered.Data)), bytes.NewReader(rendered.Data), attachment.UploadOption{
+		Groups:    []string{"robot", deliveryCtx.MemberID, "delivery"},
+		YaoTeamID: deliveryCtx.TeamID,
+	})
+	if err != nil {
+		log.Warn("delivery handler: upload %s failed for execution=%s: %v", rendered.Filename, deliveryCtx.ExecutionID, err)
+		return content
+	}
+
+	out := *content
+	out.Attachments = append(append([]robottypes.DeliveryAttachment{}, content.Attachments...), robottypes.DeliveryAttachment{
+		Title:       rendered.Filename,
+		Description: content.Summary,
+		File:        fmt.Sprintf("%s://%s", renderUploader, file.ID),
+		Size:        int64(len(rendered.Data)),
+		ContentType: rendered.ContentType,
+	})
+	return &out
+}
+
+// renderDeliveryBody renders the body in content.Format; nil for markdown or an empty body.
+// A pdf request without a registered renderer falls back to HTML.
+func renderDeliveryBody(ctx context.Context, content *robottypes.DeliveryContent, executionID string) (*renderedBody, error) {
+	if content.Format == "" || content.Format == robottypes.DeliveryFormatMarkdown {
+		return nil, nil
+	}
+	if !content.Format.IsValid() {
+		return nil, fmt.Errorf("unsupported format %q", content.Format)
+	}
+
+	markdown := content.Body
+	if markdown == "" {
+		markdown = content.Summary
+	}
+	if markdown == "" {
+		return nil, nil
+	}
+
+	doc, err := buildHTMLDocument(content.Summary, markdown)
+	if err != nil {
+		return nil, err
+	}
+	name := "report"
+	if executionID != "" {
+		name = "report-" + executionID
+	}
+
+	if content.Format == robottypes.DeliveryFormatPDF {
+		if render := getPDFRenderer(); render != nil {
+			pdf, err := render(ctx, doc)
+			if err != nil {
+				return nil, fmt.Errorf("pdf renderer: %w", err)
+			}
+			return &renderedBody{Filename: name + ".pdf", ContentType: "application/pdf", Data: pdf}, nil
+		}
+		log.Warn("delivery handler: no pdf renderer registered, attaching html for execution=%s", executionID)
+	}
+	return &renderedBody{Filename: name + ".html", ContentType: "text/html", Data: doc}, nil
+}
+
+// buildHTMLDocument wraps the markdown body rendered to HTML in a standalone page
+func buildHTMLDocument(title, markdown string) ([]byte, error) {
+	body, err := text.MarkdownToHTML(markdown)
+	if err != nil {
+		return nil, fmt.Errorf("markdown to html: %w", err)
+	}
+	if title == "" {
+		title = "Report"
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&buf, "<title>%s</title>\n", html.EscapeString(title))
+	buf.WriteString("</head>\n<body>\n")
+	buf.WriteString(body)
+	buf.WriteString("\n</body>\n</html>\n")
+	return buf.Bytes(), nil
+}
+
+// uploadFileHeader builds the header for uploading generated content through an attachment manager
+func uploadFileHeader(filename, contentType string, size int) *attachment.FileHeader {
+	hdr := make(textproto.MIMEHeader)
+	hdr.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, filename))
+	hdr.Set("Content-Type", contentType)
+	return &attachment.FileHeader{
+		FileHeader: &multipart.FileHeader{
+			Filename: filename,
+			Header:   hdr,
+			Size:     int64(size),
+		},
+	}
+}
@@ -0,0 +1,151 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	robottypes "github.com/yaoapp/yao/agent/robot/types"
+)
+
+// Block Kit text limits; longer text is cut with an ellipsis
+const (
+	slackHeaderMaxChars  = 150
+	slackSectionMaxChars = 3000
+)
+
+// sendSlack posts the content to a Slack incoming webhook as a Block Kit message:
+// the summary as a header, mentions and body as a markdown section, attachment titles
+// as a context line. Network errors and 5xx answers are retried like webhooks.
+func (h *robotHandler) sendSlack(
+	ctx context.Context,
+	content *robottypes.DeliveryContent,
+	target robottypes.SlackTarget,
+	deliveryCtx *robottypes.DeliveryContext,
+) robottypes.ChannelResult {
+	now := time.Now()
+	result := robottypes.ChannelResult{
+		Type:   robottypes.DeliverySlack,
+		Target: redactWebhookURL(target.WebhookURL), // the URL path is the webhook's secret
+		SentAt: &now,
+	}
+	if target.Channel != "" {
+		result.Target = target.Channel
+	}
+
+	if err := checkWebhookURL(ctx, target.WebhookURL, robottypes.GetWebhookPolicy()); err != nil {
+		log.Warn("slack delivery blocked: execution=%s: %v", deliveryCtx.ExecutionID, err)
+		result.Error = err.Error()
+		result.Disposition = robottypes.DeliveryFailed
+		return result
+	}
+
+	payloadBytes, err := json.Marshal(buildSlackMessage(content, target))
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to marshal slack message: %v", err)
+		result.Disposition = robottypes.DeliveryFailed
+		return result
+	}
+
+	h.sendWebhook(ctx, robottypes.WebhookTarget{URL: target.WebhookURL}, payloadBytes, &result)
+	if !result.Success {
+		result.Disposition = robottypes.DeliveryFailed
+		return result
+	}
+	result.Disposition = robottypes.DeliveryDelivered
+	return result
+}
+
+// buildSlackMessage renders the delivery content as a Block Kit message
+func buildSlackMessage(content *robottypes.DeliveryContent, target robottypes.SlackTarget) map[string]interface{} {
+	var blocks []map[string]interface{}
+
+	if summary := strings.TrimSpace(content.Summary); summary != "" {
+		blocks = append(blocks, map[string]interface{}{
+			"type": "header",
+			"text": map[string]interface{}{
+				"type":  "plain_text",
+				"text":  truncateSlackText(summary, slackHeaderMaxChars),
+				"emoji": true,
+			},
+		})
+	}
+
+	var section []string
+	if mentions := slackMentions(target.MentionUsers); mentions != "" {
+		section = append(section, mentions)
+	}
+	if body := strings.TrimSpace(content.Body); body != "" {
+		section = append(section, body)
+	}
+	if len(section) > 0 {
+		blocks = append(blocks, map[string]interface{}{
+			"type": "section",
+			"text": map[string]interface{}{
+				"type": "mrkdwn",
+				"text": truncateSlackText(strings.Join(section, "\n"), slackSectionMaxChars),
+			},
+		})
+	}
+
+	var titles []string
+	for _, att := range content.Attachments {
+		if att.Title != "" {
+			titles = append(titles, att.Title)
+		}
+	}
+	if len(titles) > 0 {
+		blocks = append(blocks, map[string]interface{}{
+			"type": "context",
+			"elements": []map[string]interface{}{{
+				"type": "mrkdwn",
+				"text": truncateSlackText("Attachments: "+strings.Join(titles, ", "), slackSectionMaxChars),
+			}},
+		})
+	}
+
+	// text is the notification and accessibility fallback for the blocks
+	fallback := strings.TrimSpace(content.Summary)
+	if fallback == "" {
+		fallback = strings.TrimSpace(content.Body)
+	}
+	msg := map[string]interface{}{
+		"text":   truncateSlackText(fallback, slackSectionMaxChars),
+		"blocks": blocks,
+	}
+	if target.Channel != "" {
+		msg["channel"] = target.Channel
+	}
+	return msg
+}
+
+// slackMentions formats user IDs as Slack mentions; here, channel and everyone
+// become the matching broadcast, values already in <...> form are kept
+func slackMentions(users []string) string {
+	mentions := make([]string, 0, len(users))
+	for _, user := range users {
+		user = strings.TrimPrefix(strings.TrimSpace(user), "@")
+		switch {
+		case user == "":
+			continue
+		case strings.HasPrefix(user, "<") && strings.HasSuffix(user, ">"):
+			mentions = append(mentions, user)
+		case user == "here" || user == "channel" || user == "everyone":
+			mentions = append(mentions, "<!"+user+">")
+		default:
+			mentions = append(mentions, "<@"+user+">")
+		}
+	}
+	return strings.Join(mentions, " ")
+}
+
+// truncateSlackText cuts text to max characters (runes), ending with an ellipsis
+func truncateSlackText(text string, max int) string {
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+	return string(runes[:max-1]) + "…"
+}
@@ -0,0 +1,85 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	robottypes "github.com/yaoapp/yao/agent/robot/types"
+	"github.com/yaoapp/yao/attachment"
+)
+
+// thumbnailTypes are the image types attachment.CompressImage can re-encode
+var thumbnailTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+}
+
+// attachThumbnails generates a small preview for every image attachment stored in an
+// attachment manager and records its wrapper in DeliveryAttachment.Thumbnail.
+// Non-image, workspace and URL attachments are left as they are; a failed preview
+// never blocks the delivery itself.
+func (h *robotHandler) attachThumbnails(ctx context.Context, content *robottypes.DeliveryContent, pref *robottypes.ThumbnailPreference, deliveryCtx *robottypes.DeliveryContext) *robottypes.DeliveryContent {
+	if pref == nil || !pref.Enabled || len(content.Attachments) == 0 {
+		return content
+	}
+
+	out := *content
+	out.Attachments = append([]robottypes.DeliveryAttachment{}, content.Attachments...)
+	for i := range out.Attachments {
+		att := &out.Attachments[i]
+		if att.Thumbnail != "" {
+			continue
+		}
+		thumb, err := makeThumbnail(ctx, *att, pref.GetSize(), deliveryCtx)
+		if err != nil {
+			log.Warn("delivery handler: thumbnail for %q skipped, execution=%s: %v", att.Title, deliveryCtx.ExecutionID, err)
+			continue
+		}
+		att.Thumbnail = thumb
+	}
+	return &out
+}
+
+// makeThumbnail reads an image attachment, scales it down to size and uploads the
+// preview next to the original. Returns "" without error for non-image attachments.
+func makeThumbnail(ctx context.Context, att robottypes.DeliveryAttachment, size int, deliveryCtx *robottypes.DeliveryContext) (string, error) {
+	if att.ContentType != "" && !thumbnailTypes[att.ContentType] {
+		return "", nil
+	}
+	uploader, fileID, isWrapper := attachment.Parse(att.File)
+	if !isWrapper {
+		return "", nil
+	}
+	manager, ok := attachment.Managers[uploader]
+	if !ok {
+		return "", fmt.Errorf("manager not found: %s", uploader)
+	}
+
+	info, err := manager.Info(ctx, fileID)
+	if err != nil {
+		return "", fmt.Errorf("file info: %w", err)
+	}
+	if !thumbnailTypes[info.ContentType] {
+		return "", nil
+	}
+
+	data, err := manager.Read(ctx, fileID)
+	if err != nil {
+		return "", fmt.Errorf("read: %w", err)
+	}
+	preview, err := attachment.CompressImage(bytes.NewReader(data), info.ContentType, size)
+	if err != nil {
+		return "", err
+	}
+
+	filename := "thumb-" + info.Filename
+	file, err := manager.Upload(ctx, uploadFileHeader(filename, info.ContentType, len(preview)), bytes.NewReader(preview), attachment.UploadOption{
+		Groups:    []string{"robot", deliveryCtx.MemberID, "delivery"},
+		YaoTeamID: deliveryCtx.TeamID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("upload: %w", err)
+	}
+	return fmt.Sprintf("%s://%s", uploader, file.ID), nil
+}
@@ -0,0 +1,212 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/yaoapp/gou/process"
+	robottypes "github.com/yaoapp/yao/agent/robot/types"
+)
+
+// probeTimeout bounds the optional reachability request to a webhook
+const probeTimeout = 5 * time.Second
+
+// webhookMethods are the HTTP methods a webhook target may use
+var webhookMethods = map[string]bool{
+	http.MethodPost:   true,
+	http.MethodPut:    true,
+	http.MethodPatch:  true,
+	http.MethodGet:    true,
+	http.MethodDelete: true,
+}
+
+// ValidateDeliveryPreferences checks every delivery target without running an execution:
+// email addresses, webhook and Slack URLs (shape, DNS and the webhook policy) and process names.
+// With probe set, each webhook also gets a HEAD request to confirm it answers at all;
+// any HTTP response counts as reachable. Returns one diagnostic per target.
+func ValidateDeliveryPreferences(ctx context.Context, prefs *robottypes.DeliveryPreferences, probe bool) []robottypes.DeliveryDiagnostic {
+	diagnostics := []robottypes.DeliveryDiagnostic{}
+	if prefs == nil {
+		return diagnostics
+	}
+
+	if prefs.Email != nil {
+		for i, target := range prefs.Email.Targets {
+			d := validateEmailTarget(target)
+			d.Index = i
+			if !prefs.Email.Enabled {
+				d.Warnings = append(d.Warnings, "email delivery is disabled")
+			}
+			diagnostics = append(diagnostics, d.done())
+		}
+	}
+
+	if prefs.Webhook != nil {
+		for i, target := range prefs.Webhook.Targets {
+			d := validateWebhookTarget(ctx, target, probe)
+			d.Index = i
+			if !prefs.Webhook.Enabled {
+				d.Warnings = append(d.Warnings, "webhook delivery is disabled")
+			}
+			diagnostics = append(diagnostics, d.done())
+		}
+	}
+
+	if prefs.Process != nil {
+		for i, target := range prefs.Process.Targets {
+			d := validateProcessTarget(target)
+			d.Index = i
+			if !prefs.Process.Enabled {
+				d.Warnings = append(d.Warnings, "process delivery is disabled")
+			}
+			diagnostics = append(diagnostics, d.done())
+		}
+	}
+
+	if prefs.Slack != nil {
+		for i, target := range prefs.Slack.Targets {
+			d := validateSlackTarget(ctx, target)
+			d.Index = i
+			if !prefs.Slack.Enabled {
+				d.Warnings = append(d.Warnings, "slack delivery is disabled")
+			}
+			diagnostics = append(diagnostics, d.done())
+		}
+	}
+
+	return diagnostics
+}
+
+// diagnostic accumulates the findings for one target
+type diagnostic struct {
+	robottypes.DeliveryDiagnostic
+}
+
+func (d *diagnostic) fail(format string, args ...interface{}) {
+	d.Errors = append(d.Errors, fmt.Sprintf(format, args...))
+}
+
+func (d *diagnostic) done() robottypes.DeliveryDiagnostic {
+	d.Valid = len(d.Errors) == 0
+	return d.DeliveryDiagnostic
+}
+
+func validateEmailTarget(target robottypes.EmailTarget) *diagnostic {
+	d := &diagnostic{robottypes.DeliveryDiagnostic{
+		Type:   robottypes.DeliveryEmail,
+		Target: strings.Join(target.To, ", "),
+	}}
+
+	recipients, invalid := normalizeRecipients(target.To)
+	for _, addr := range invalid {
+		d.fail("invalid email address: %q", addr)
+	}
+	if len(recipients) == 0 && len(invalid) == 0 {
+		d.fail("no recipients")
+	}
+	if kept := len(recipients) + len(invalid); kept < countNonEmpty(target.To) {
+		d.Warnings = append(d.Warnings, "duplicate recipients are sent once")
+	}
+	return d
+}
+
+func validateWebhookTarget(ctx context.Context, target robottypes.WebhookTarget, probe bool) *diagnostic {
+	d := &diagnostic{robottypes.DeliveryDiagnostic{
+		Type:   robottypes.DeliveryWebhook,
+		Target: target.URL,
+	}}
+
+	if strings.TrimSpace(target.URL) == "" {
+		d.fail("url is required")
+		return d
+	}
+	if target.Method != "" && !webhookMethods[strings.ToUpper(target.Method)] {
+		d.fail("unsupported method %q", target.Method)
+	}
+	if target.Expect != nil && target.Expect.Equals != nil && target.Expect.JSONPath == "" {
+		d.fail("expect.equals needs expect.json_path")
+	}
+	if target.RetryBackoff < 0 {
+		d.fail("retry_backoff must not be negative")
+	}
+	if target.Secret == "" {
+		d.Warnings = append(d.Warnings, "no secret set, payloads are not signed")
+	}
+
+	if err := checkWebhookURL(ctx, target.URL, robottypes.GetWebhookPolicy()); err != nil {
+		d.fail("%v", err)
+		return d
+	}
+
+	if probe {
+		probeCtx, cancel := context.WithTimeout(ctx, probeTimeout)
+		defer cancel()
+		req, err := http.NewRequestWithContext(probeCtx, http.MethodHead, target.URL, nil)
+		if err != nil {
+			d.fail("failed to create probe request: %v", err)
+			return d
+		}
+		resp, err := newWebhookHTTPClient(probeTimeout).Do(req)
+		if err != nil {
+			d.fail("unreachable: %v", err)
+			return d
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 500 {
+			d.Warnings = append(d.Warnings, fmt.Sprintf("probe answered %d", resp.StatusCode))
+		}
+	}
+	return d
+}
+
+func validateProcessTarget(target robottypes.ProcessTarget) *diagnostic {
+	d := &diagnostic{robottypes.DeliveryDiagnostic{
+		Type:   robottypes.DeliveryProcess,
+		Target: target.Process,
+	}}
+
+	if strings.TrimSpace(target.Process) == "" {
+		d.fail("process is required")
+		return d
+	}
+	// process.Of only resolves the handler, nothing is executed
+	if _, err := process.Of(target.Process); err != nil {
+		d.fail("process not found: %v", err)
+	}
+	return d
+}
+
+func validateSlackTarget(ctx context.Context, target robottypes.SlackTarget) *diagnostic {
+	d := &diagnostic{robottypes.DeliveryDiagnostic{
+		Type:   robottypes.DeliverySlack,
+		Target: redactWebhookURL(target.WebhookURL),
+	}}
+
+	if strings.TrimSpace(target.WebhookURL) == "" {
+		d.fail("webhook_url is required")
+		return d
+	}
+	if target.Channel != "" {
+		d.Target = target.Channel
+	}
+	if target.Channel != "" && !strings.HasPrefix(target.Channel, "#") && !strings.HasPrefix(target.Channel, "@") {
+		d.Warnings = append(d.Warnings, "channel is usually #name or @user")
+	}
+	if err := checkWebhookURL(ctx, target.WebhookURL, robottypes.GetWebhookPolicy()); err != nil {
+		d.fail("%v", err)
+	}
+	return d
+}
+
+func countNonEmpty(values []string) int {
+	n := 0
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			n++
+		}
+	}
+	return n
+}
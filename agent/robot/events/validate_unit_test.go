@@ -0,0 +1,64 @@
+//go:build unit
+
+package events_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	events "github.com/yaoapp/yao/agent/robot/events"
+	robottypes "github.com/yaoapp/yao/agent/robot/types"
+)
+
+func TestValidateDeliveryPreferences(t *testing.T) {
+	allowLoopbackWebhooks(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	prefs := &robottypes.DeliveryPreferences{
+		Email: &robottypes.EmailPreference{
+			Enabled: true,
+			Targets: []robottypes.EmailTarget{
+				{To: []string{"ops@example.com"}},
+				{To: []string{"not-an-email", "a@example.com"}},
+			},
+		},
+		Webhook: &robottypes.WebhookPreference{
+			Enabled: true,
+			Targets: []robottypes.WebhookTarget{
+				{URL: server.URL, Secret: "s"},
+				{URL: "ftp://example.com/hook", Method: "TRACE"},
+			},
+		},
+		Process: &robottypes.ProcessPreference{
+			Enabled: false,
+			Targets: []robottypes.ProcessTarget{{Process: ""}},
+		},
+	}
+
+	diags := events.ValidateDeliveryPreferences(context.Background(), prefs, true)
+	require.Len(t, diags, 5)
+
+	assert.True(t, diags[0].Valid)
+	assert.Equal(t, robottypes.DeliveryEmail, diags[0].Type)
+
+	assert.False(t, diags[1].Valid)
+	assert.Equal(t, 1, diags[1].Index)
+	assert.Contains(t, diags[1].Errors[0], "not-an-email")
+
+	assert.True(t, diags[2].Valid, diags[2].Errors)
+	assert.Empty(t, diags[2].Warnings)
+
+	assert.False(t, diags[3].Valid)
+	assert.Len(t, diags[3].Errors, 2, "bad method and blocked scheme")
+
+	assert.False(t, diags[4].Valid)
+	assert.Equal(t, robottypes.DeliveryProcess, diags[4].Type)
+	assert.Contains(t, diags[4].Warnings, "process delivery is disabled")
+}
@@ -0,0 +1,162 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	robottypes "github.com/yaoapp/yao/agent/robot/types"
+)
+
+// checkWebhookURL validates a webhook URL against the webhook policy before dispatch.
+// The host is resolved and every address must pass; the dialer re-checks the address
+// actually connected to, so DNS rebinding cannot bypass this check.
+func checkWebhookURL(ctx context.Context, rawURL string, policy robottypes.WebhookPolicy) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		// Report the parse failure without quoting the URL, which may hold a secret
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("%w: invalid url: %v", robottypes.ErrWebhookTargetBlocked, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%w: unsupported scheme %q", robottypes.ErrWebhookTargetBlocked, u.Scheme)
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if host == "" {
+		return fmt.Errorf("%w: missing host", robottypes.ErrWebhookTargetBlocked)
+	}
+
+	if matchWebhookHost(host, nil, policy.DenyHosts) {
+		return fmt.Errorf("%w: host %s is denied", robottypes.ErrWebhookTargetBlocked, host)
+	}
+
+	var ips []net.IP
+	if ip := net.ParseIP(host); ip != nil {
+		ips = []net.IP{ip}
+	} else {
+		addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			return fmt.Errorf("%w: cannot resolve %s: %v", robottypes.ErrWebhookTargetBlocked, host, err)
+		}
+		for _, a := range addrs {
+			ips = append(ips, a.IP)
+		}
+	}
+
+	for _, ip := range ips {
+		if err := checkWebhookIP(host, ip, policy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkWebhookIP applies the policy to one resolved address of host
+func checkWebhookIP(host string, ip net.IP, policy robottypes.WebhookPolicy) error {
+	if len(policy.AllowHosts) > 0 && !matchWebhookHost(host, ip, policy.AllowHosts) {
+		return fmt.Errorf("%w: host %s is not in the allow list", robottypes.ErrWebhookTargetBlocked, host)
+	}
+
+	return checkWebhookAddr(ip, policy)
+}
+
+// checkWebhookAddr applies the address rules that are re-checked at dial time.
+// An internal address is only reachable when private ranges are allowed or the
+// address itself (IP or CIDR, not a hostname) is in the allow list.
+func checkWebhookAddr(ip net.IP, policy robottypes.WebhookPolicy) error {
+	if matchWebhookHost("", ip, policy.DenyHosts) {
+		return fmt.Errorf("%w: address %s is denied", robottypes.ErrWebhookTargetBlocked, ip)
+	}
+	if !policy.AllowPrivate && isInternalIP(ip) && !matchWebhookHost("", ip, policy.AllowHosts) {
+		return fmt.Errorf("%w: internal address %s", robottypes.ErrWebhookTargetBlocked, ip)
+	}
+	return nil
+}
+
+// matchWebhookHost reports whether host or ip matches any entry (hostname, *.suffix, IP or CIDR)
+func matchWebhookHost(host string, ip net.IP, entries []string) bool {
+	for _, entry := range entries {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+
+		if _, cidr, err := net.ParseCIDR(entry); err == nil {
+			if ip != nil && cidr.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if entryIP := net.ParseIP(entry); entryIP != nil {
+			if ip != nil && entryIP.Equal(ip) {
+				return true
+			}
+			continue
+		}
+
+		if host == "" {
+			continue
+		}
+		if strings.HasPrefix(entry, "*.") {
+			if strings.HasSuffix(host, entry[1:]) {
+				return true
+			}
+			continue
+		}
+		if host == entry {
+			return true
+		}
+	}
+	return false
+}
+
+// isInternalIP reports loopback, private, link-local, unspecified and multicast addresses
+func isInternalIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() ||
+		ip.IsUnspecified() || ip.IsMulticast()
+}
+
+// newWebhookHTTPClient returns an HTTP client that enforces the webhook policy on every
+// connection (including redirects), using the policy in effect at dial time
+func newWebhookHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil {
+				return fmt.Errorf("%w: unresolved address %s", robottypes.ErrWebhookTargetBlocked, address)
+			}
+			return checkWebhookAddr(ip, robottypes.GetWebhookPolicy())
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	transport.Proxy = nil // a proxy would hide the real target from the dial check
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 5 {
+				return fmt.Errorf("stopped after %d redirects", len(via))
+			}
+			return checkWebhookURL(req.Context(), req.URL.String(), robottypes.GetWebhookPolicy())
+		},
+	}
+}
@@ -0,0 +1,110 @@
+//go:build unit
+
+package events_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	events "github.com/yaoapp/yao/agent/robot/events"
+	robottypes "github.com/yaoapp/yao/agent/robot/types"
+	eventtypes "github.com/yaoapp/yao/event/types"
+)
+
+func TestCheckWebhookURL(t *testing.T) {
+	ctx := context.Background()
+	none := robottypes.WebhookPolicy{}
+
+	t.Run("blocks internal ranges by default", func(t *testing.T) {
+		for _, u := range []string{
+			"http://169.254.169.254/latest/meta-data/",
+			"http://127.0.0.1:8080/hook",
+			"http://10.0.0.5/hook",
+			"http://192.168.1.1/hook",
+			"http://[::1]/hook",
+			"http://0.0.0.0/hook",
+			"http://localhost/hook",
+		} {
+			err := events.CheckWebhookURL(ctx, u, none)
+			assert.ErrorIs(t, err, robottypes.ErrWebhookTargetBlocked, u)
+		}
+	})
+
+	t.Run("allows public addresses", func(t *testing.T) {
+		assert.NoError(t, events.CheckWebhookURL(ctx, "https://93.184.216.34/hook", none))
+	})
+
+	t.Run("rejects non-http schemes", func(t *testing.T) {
+		err := events.CheckWebhookURL(ctx, "file:///etc/passwd", none)
+		assert.ErrorIs(t, err, robottypes.ErrWebhookTargetBlocked)
+		assert.Contains(t, err.Error(), "scheme")
+	})
+
+	t.Run("private opt-out", func(t *testing.T) {
+		policy := robottypes.WebhookPolicy{AllowPrivate: true}
+		assert.NoError(t, events.CheckWebhookURL(ctx, "http://10.0.0.5/hook", policy))
+	})
+
+	t.Run("allow list by CIDR reaches an internal service", func(t *testing.T) {
+		policy := robottypes.WebhookPolicy{AllowHosts: []string{"10.1.0.0/16"}}
+		assert.NoError(t, events.CheckWebhookURL(ctx, "http://10.1.2.3/hook", policy))
+		assert.Error(t, events.CheckWebhookURL(ctx, "http://10.2.0.1/hook", policy))
+		assert.Error(t, events.CheckWebhookURL(ctx, "https://93.184.216.34/hook", policy), "not in allow list")
+	})
+
+	t.Run("allow list by hostname does not unlock internal addresses", func(t *testing.T) {
+		policy := robottypes.WebhookPolicy{AllowHosts: []string{"localhost"}}
+		assert.Error(t, events.CheckWebhookURL(ctx, "http://localhost/hook", policy))
+	})
+
+	t.Run("deny list wins", func(t *testing.T) {
+		policy := robottypes.WebhookPolicy{AllowPrivate: true, DenyHosts: []string{"169.254.0.0/16", "*.internal.example"}}
+		assert.Error(t, events.CheckWebhookURL(ctx, "http://169.254.169.254/", policy))
+		assert.Error(t, events.CheckWebhookURL(ctx, "http://api.internal.example/hook", policy))
+		assert.NoError(t, events.CheckWebhookURL(ctx, "http://10.0.0.5/hook", policy))
+	})
+}
+
+func TestRobotHandler_WebhookBlockedTarget(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	prev := robottypes.GetWebhookPolicy()
+	robottypes.SetWebhookPolicy(robottypes.WebhookPolicy{})
+	defer robottypes.SetWebhookPolicy(prev)
+
+	handler := events.NewTestHandler()
+	ev := &eventtypes.Event{
+		Type:   events.Delivery,
+		ID:     "test-ev-blocked",
+		IsCall: true,
+		Payload: events.DeliveryPayload{
+			ExecutionID: "exec-blocked",
+			MemberID:    "member-blocked",
+			TeamID:      "team-blocked",
+			Content:     &robottypes.DeliveryContent{Summary: "s", Body: "b"},
+			Preferences: &robottypes.DeliveryPreferences{
+				Webhook: &robottypes.WebhookPreference{
+					Enabled: true,
+					Targets: []robottypes.WebhookTarget{{URL: server.URL}},
+				},
+			},
+		},
+	}
+
+	resp := make(chan eventtypes.Result, 1)
+	handler.Handle(context.Background(), ev, resp)
+
+	result := <-resp
+	require.Error(t, result.Err)
+	assert.Contains(t, result.Err.Error(), "webhook target not allowed")
+	assert.False(t, called, "blocked target must not be contacted")
+}
@@ -0,0 +1,227 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	robotstore "github.com/yaoapp/yao/agent/robot/store"
+	robottypes "github.com/yaoapp/yao/agent/robot/types"
+	robotutils "github.com/yaoapp/yao/agent/robot/utils"
+)
+
+// deadLetterSink persists webhook deliveries that failed every attempt
+type deadLetterSink interface {
+	Create(ctx context.Context, letter *robotstore.DeadLetter) error
+}
+
+// webhookAttempt is the outcome of one webhook request
+type webhookAttempt struct {
+	statusCode int
+	body       []byte
+	retryAfter time.Duration // from the Retry-After header, 0 if absent
+	err        error
+	retryable  bool // network error or 5xx
+}
+
+// sendWebhook posts payloadBytes to the target, retrying network errors and 5xx
+// responses with exponential backoff. 4xx responses and failed response checks are
+// final: the receiver answered, asking again will not change its mind.
+func (h *robotHandler) sendWebhook(ctx context.Context, target robottypes.WebhookTarget, payloadBytes []byte, result *robottypes.ChannelResult) {
+	retry := target.Retry(robottypes.GetWebhookRetry())
+
+	var attempt webhookAttempt
+	for n := 1; ; n++ {
+		result.Attempts = n
+		attempt = h.attemptWebhook(ctx, target, payloadBytes)
+		if attempt.err == nil || !attempt.retryable || n >= retry.MaxAttempts {
+			break
+		}
+
+		delay := webhookBackoff(retry, n, attempt.retryAfter)
+		log.Warn("webhook delivery attempt %d/%d failed: url=%s: %v (retrying in %s)",
+			n, retry.MaxAttempts, redactWebhookURL(target.URL), attempt.err, delay)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			result.Error = fmt.Sprintf("%v (retry cancelled: %v)", attempt.err, ctx.Err())
+			result.Details = map[string]interface{}{"attempts": n, "status_code": attempt.statusCode}
+			return
+		case <-timer.C:
+		}
+	}
+
+	details := map[string]interface{}{"attempts": result.Attempts}
+	result.Details = details
+	if attempt.statusCode != 0 {
+		details["status_code"] = attempt.statusCode
+		details["response"] = string(attempt.body)
+		if attempt.err == nil && target.Expect != nil {
+			validation := map[string]interface{}{"passed": true}
+			if err := checkWebhookResponse(attempt.body, target.Expect); err != nil {
+				validation["passed"] = false
+				validation["error"] = err.Error()
+				details["validation"] = validation
+				result.Error = fmt.Sprintf("webhook response validation failed: %v", err)
+				return
+			}
+			details["validation"] = validation
+		}
+	}
+
+	if attempt.err != nil {
+		result.Error = attempt.err.Error()
+		return
+	}
+	result.Success = true
+}
+
+// attemptWebhook makes one signed request to the target
+func (h *robotHandler) attemptWebhook(ctx context.Context, target robottypes.WebhookTarget, payloadBytes []byte) webhookAttempt {
+	method := target.Method
+	if method == "" {
+		method = "POST"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target.URL, bytes.NewReader(payloadBytes))
+	if err != nil {
+		return webhookAttempt{err: fmt.Errorf("failed to create request: %v", err)}
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range target.Headers {
+		req.Header.Set(key, value)
+	}
+
+	if target.Secret != "" {
+		signature := ComputeHMACSignature(payloadBytes, target.Secret)
+		req.Header.Set("X-Yao-Signature", signature)
+		req.Header.Set("X-Yao-Signature-Algorithm", "HMAC-SHA256")
+	}
+
+	httpResp, err := h.httpClient.Do(req)
+	if err != nil {
+		// A cancelled delivery is not worth retrying, anything else on the wire is
+		retryable := !errors.Is(err, context.Canceled) && ctx.Err() == nil
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			// The client quotes the full URL, secret path included
+			err = fmt.Errorf("%s %s: %v", urlErr.Op, redactWebhookURL(urlErr.URL), urlErr.Err)
+		}
+		return webhookAttempt{err: fmt.Errorf("request failed: %v", err), retryable: retryable}
+	}
+	defer httpResp.Body.Close()
+
+	body, _ := io.ReadAll(httpResp.Body)
+	attempt := webhookAttempt{statusCode: httpResp.StatusCode, body: body}
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		attempt.err = fmt.Errorf("webhook returned status %d: %s", httpResp.StatusCode, string(body))
+		attempt.retryable = httpResp.StatusCode >= 500
+		attempt.retryAfter = parseRetryAfter(httpResp.Header.Get("Retry-After"), time.Now())
+	}
+	return attempt
+}
+
+// redactWebhookURL keeps the scheme and host of a webhook URL for results and logs and
+// drops path and query, which hold the secret of incoming webhooks such as Slack's:
+// "https://hooks.slack.com/services/T0/B0/x" becomes "https://hooks.slack.com/…"
+func redactWebhookURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "[invalid url]"
+	}
+	redacted := u.Scheme + "://" + u.Host
+	if (u.Path != "" && u.Path != "/") || u.RawQuery != "" {
+		redacted += "/…"
+	}
+	return redacted
+}
+
+// webhookBackoff is the wait after the n-th failed attempt: BaseDelay doubled per
+// retry with jitter, or the receiver's Retry-After if longer, never above MaxDelay
+func webhookBackoff(retry robottypes.WebhookRetry, n int, retryAfter time.Duration) time.Duration {
+	delay := retry.BaseDelay
+	for i := 1; i < n && delay < retry.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > retry.MaxDelay {
+		delay = retry.MaxDelay
+	}
+
+	// Spread retries over [delay/2, delay] so receivers recovering from an outage are not hit in step
+	if half := int64(delay / 2); half > 0 {
+		delay = time.Duration(half + rand.Int63n(half+1))
+	}
+
+	if retryAfter > delay {
+		delay = retryAfter
+	}
+	if delay > retry.MaxDelay {
+		delay = retry.MaxDelay
+	}
+	return delay
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs <= 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
+
+// deadLetter keeps a webhook delivery that failed every attempt so it can be redelivered
+// later. The payload is stored as sent; secrets and headers are read from the target on
+// redelivery.
+func (h *robotHandler) deadLetter(ctx context.Context, target robottypes.WebhookTarget, payloadBytes []byte, deliveryCtx *robottypes.DeliveryContext, result *robottypes.ChannelResult) {
+	result.Disposition = robottypes.DeliveryFailed
+	if h.deadLetters == nil {
+		return
+	}
+
+	hash := sha256.Sum256(payloadBytes)
+	letter := &robotstore.DeadLetter{
+		LetterID:    robotutils.NewID(),
+		ExecutionID: deliveryCtx.ExecutionID,
+		MemberID:    deliveryCtx.MemberID,
+		TeamID:      deliveryCtx.TeamID,
+		Channel:     robottypes.DeliveryWebhook,
+		Target:      target.URL,
+		Payload:     string(payloadBytes),
+		PayloadHash: hex.EncodeToString(hash[:]),
+		LastError:   result.Error,
+		Attempts:    result.Attempts,
+		Status:      robotstore.DeadLetterPending,
+	}
+	if err := h.deadLetters.Create(ctx, letter); err != nil {
+		log.Warn("webhook dead letter not saved: execution=%s url=%s: %v", deliveryCtx.ExecutionID, redactWebhookURL(target.URL), err)
+		return
+	}
+
+	result.Disposition = robottypes.DeliveryDeadLettered
+	if details, ok := result.Details.(map[string]interface{}); ok {
+		details["dead_letter_id"] = letter.LetterID
+	}
+}
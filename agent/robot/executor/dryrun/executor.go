@@ -7,6 +7,7 @@ import (
 
 	"github.com/yaoapp/yao/agent/robot/executor/types"
 	robottypes "github.com/yaoapp/yao/agent/robot/types"
+	"github.com/yaoapp/yao/agent/robot/utils"
 )
 
 // Executor implements a dry-run executor that simulates execution
@@ -64,9 +65,10 @@ func (e *Executor) ExecuteWithControl(ctx *robottypes.Context, robot *robottypes
 		startPhaseIndex = 1 // Skip P0
 	}
 
-	// Use provided execID or generate new one
+	// Use provided execID or generate new one. IDs must be unique: TryAcquireSlot
+	// treats a tracked ID as the same execution and would not count a second slot.
 	if execID == "" {
-		execID = fmt.Sprintf("dryrun_%d", time.Now().UnixNano())
+		execID = utils.NewIDWithPrefix("dryrun_")
 	}
 
 	// Create execution record
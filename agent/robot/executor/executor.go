@@ -53,6 +53,7 @@ type (
 	Config        = types.Config
 	DryRunConfig  = types.DryRunConfig
 	SandboxConfig = types.SandboxConfig
+	RetryPolicy   = types.RetryPolicy
 	Mode          = types.Mode
 	Setting       = types.Setting
 )
@@ -0,0 +1,82 @@
+//go:build unit
+
+package executor_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/yao/agent/robot/executor"
+	"github.com/yaoapp/yao/agent/robot/types"
+)
+
+// TestExecutorQuotaUnderContention fires many simultaneous executions at one robot and
+// checks the robot never tracks more than Quota.Max at once
+func TestExecutorQuotaUnderContention(t *testing.T) {
+	const (
+		maxQuota   = 3
+		goroutines = 64
+		rounds     = 5
+	)
+
+	robot := &types.Robot{
+		MemberID: "robot_quota_stress",
+		TeamID:   "team_quota_stress",
+		Config:   &types.Config{Quota: &types.Quota{Max: maxQuota}},
+	}
+
+	var running, peak atomic.Int32
+	var overQuota atomic.Bool
+	exec := executor.NewDryRunWithCallbacks(2*time.Millisecond,
+		func() {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			if robot.RunningCount() > maxQuota {
+				overQuota.Store(true)
+			}
+		},
+		func() { running.Add(-1) },
+	)
+	ctx := types.NewContext(context.Background(), nil)
+
+	var completed, rejected atomic.Int32
+	for round := 0; round < rounds; round++ {
+		start := make(chan struct{})
+		var wg sync.WaitGroup
+		for i := 0; i < goroutines; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start
+				_, err := exec.Execute(ctx, robot, types.TriggerClock, nil)
+				switch {
+				case err == nil:
+					completed.Add(1)
+				case errors.Is(err, types.ErrQuotaExceeded):
+					rejected.Add(1)
+				default:
+					t.Errorf("unexpected error: %v", err)
+				}
+			}()
+		}
+		close(start)
+		wg.Wait()
+	}
+
+	assert.False(t, overQuota.Load(), "robot tracked more executions than its quota")
+	assert.LessOrEqual(t, int(peak.Load()), maxQuota)
+	assert.Equal(t, int32(goroutines*rounds), completed.Load()+rejected.Load())
+	assert.Positive(t, completed.Load())
+	assert.Positive(t, rejected.Load(), "the quota should have turned executions away")
+	assert.Zero(t, robot.RunningCount(), "every slot is released")
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/yaoapp/yao/agent/robot/executor/types"
 	robottypes "github.com/yaoapp/yao/agent/robot/types"
+	"github.com/yaoapp/yao/agent/robot/utils"
 )
 
 // Executor implements a sandboxed executor placeholder.
@@ -77,9 +78,10 @@ func (e *Executor) ExecuteWithControl(ctx *robottypes.Context, robot *robottypes
 		startPhaseIndex = 1
 	}
 
-	// Use provided execID or generate new one
+	// Use provided execID or generate new one. IDs must be unique: TryAcquireSlot
+	// treats a tracked ID as the same execution and would not count a second slot.
 	if execID == "" {
-		execID = fmt.Sprintf("sandbox_%d", time.Now().UnixNano())
+		execID = utils.NewIDWithPrefix("sandbox_")
 	}
 
 	// Create execution record
@@ -62,6 +62,10 @@ type AgentCaller struct {
 	log *execLogger
 }
 
+// agentCallObserver receives the messages of every Call. Always nil in production:
+// only tests set it, through SetAgentCallObserver in export_test.go.
+var agentCallObserver func(assistantID string, messages []agentcontext.Message)
+
 // NewAgentCaller creates a new AgentCaller with default settings (single-call mode)
 func NewAgentCaller() *AgentCaller {
 	return &AgentCaller{
@@ -202,6 +206,10 @@ func (c *AgentCaller) Call(ctx *robottypes.Context, assistantID string, messages
 		Mode:      c.Mode,
 	}
 
+	if agentCallObserver != nil {
+		agentCallObserver(assistantID, messages)
+	}
+
 	agentCtx := c.buildAgentContext(ctx, assistantID)
 	defer func() {
 		kunlog.Trace("[robot-agent] releasing context: assistantID=%s chatID=%s", assistantID, c.ChatID)
@@ -0,0 +1,103 @@
+//go:build integration
+
+package standard_test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	agentcontext "github.com/yaoapp/yao/agent/context"
+	"github.com/yaoapp/yao/agent/robot/executor/standard"
+	robottypes "github.com/yaoapp/yao/agent/robot/types"
+	"github.com/yaoapp/yao/unit-test/agent/testprepare"
+)
+
+// captureAgentCalls records agent inputs by assistant ID
+func captureAgentCalls(t *testing.T) func(assistantID string) []agentcontext.Message {
+	var mu sync.Mutex
+	calls := map[string][]agentcontext.Message{}
+	standard.SetAgentCallObserver(func(assistantID string, messages []agentcontext.Message) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls[assistantID] = append([]agentcontext.Message{}, messages...)
+	})
+	t.Cleanup(func() { standard.SetAgentCallObserver(nil) })
+
+	return func(assistantID string) []agentcontext.Message {
+		mu.Lock()
+		defer mu.Unlock()
+		return calls[assistantID]
+	}
+}
+
+func TestCapabilityDescriptorInjection(t *testing.T) {
+	identity := testprepare.PrepareSandbox(t)
+	ctx := testCtx(identity)
+
+	t.Run("tasks_planner_receives_descriptor", func(t *testing.T) {
+		captured := captureAgentCalls(t)
+
+		robot := newTestRobot(t, identity)
+		robot.Config.Resources.Processes = []robottypes.ProcessConfig{
+			{Name: "utils.now.Timestamp", Description: "Current timestamp"},
+		}
+		exec := createTasksExecution(robot, robottypes.TriggerClock)
+		exec.Goals = &robottypes.Goals{Content: "## Goals\n\n1. [High] Summarize sales"}
+
+		// The mock agent does not return tasks; only the planner input matters here
+		_ = standard.New().RunTasks(ctx, exec, nil)
+
+		messages := captured("tests.robot-tasks")
+		require.NotEmpty(t, messages)
+		content, _ := messages[len(messages)-1].Content.(string)
+		assert.Contains(t, content, "## Capabilities")
+		assert.Contains(t, content, `"experts.text-writer"`)
+		assert.Contains(t, content, `"utils.now.Timestamp"`)
+	})
+
+	t.Run("task_agent_system_context_has_descriptor", func(t *testing.T) {
+		captured := captureAgentCalls(t)
+
+		robot := newTestRobot(t, identity)
+		runner := standard.NewRunner(ctx, robot, standard.DefaultRunConfig(), "", "test-capability")
+		task := &robottypes.Task{
+			ID:           "task-cap",
+			ExecutorType: robottypes.ExecutorAssistant,
+			ExecutorID:   "experts.text-writer",
+			Messages:     []agentcontext.Message{{Role: agentcontext.RoleUser, Content: "Say hello"}},
+			Status:       robottypes.TaskPending,
+		}
+		runner.ExecuteTask(task, &standard.RunnerContext{})
+
+		messages := captured("experts.text-writer")
+		require.NotEmpty(t, messages)
+		assert.Equal(t, agentcontext.RoleSystem, messages[0].Role)
+		system, _ := messages[0].Content.(string)
+		assert.True(t, strings.HasPrefix(system, "## Capabilities"))
+	})
+
+	t.Run("descriptor_follows_config_change", func(t *testing.T) {
+		captured := captureAgentCalls(t)
+
+		robot := newTestRobot(t, identity)
+		exec := createTasksExecution(robot, robottypes.TriggerClock)
+		exec.Goals = &robottypes.Goals{Content: "## Goals\n\n1. [High] Summarize sales"}
+		_ = standard.New().RunTasks(ctx, exec, nil)
+
+		robot.Config.Resources.Agents = []string{"experts.data-analyst"}
+		exec = createTasksExecution(robot, robottypes.TriggerClock)
+		exec.Goals = &robottypes.Goals{Content: "## Goals\n\n1. [High] Summarize sales"}
+		_ = standard.New().RunTasks(ctx, exec, nil)
+
+		messages := captured("tests.robot-tasks")
+		require.NotEmpty(t, messages)
+		content, _ := messages[len(messages)-1].Content.(string)
+		require.Contains(t, content, "## Capabilities")
+		block := content[strings.Index(content, "## Capabilities"):]
+		assert.NotContains(t, block, `"experts.text-writer"`)
+		assert.Contains(t, block, `"experts.data-analyst"`)
+	})
+}
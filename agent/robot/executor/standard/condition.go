@@ -0,0 +1,411 @@
+package standard
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	robottypes "github.com/yaoapp/yao/agent/robot/types"
+)
+
+// EvaluateCondition evaluates a task condition against the results of earlier tasks.
+//
+// The syntax is deliberately small and side-effect free:
+//
+//	task-001.success && task-001.output.count > 0
+//	!task-002.output.anomalies || task-002.output.severity == "high"
+//
+// A path starts with a task ID and walks into its result: success, skipped, error
+// and output (maps, list indexes, and JSON text are traversed). Missing fields are
+// null. Operators: == != > >= < <= && || ! and parentheses; literals are numbers,
+// quoted strings, true, false and null. A bare value is true when it is not null,
+// false, zero or empty. An empty condition is always true.
+func EvaluateCondition(expr string, results []robottypes.TaskResult) (bool, error) {
+	if strings.TrimSpace(expr) == "" {
+		return true, nil
+	}
+	tokens, err := tokenizeCondition(expr)
+	if err != nil {
+		return false, err
+	}
+
+	p := &conditionParser{tokens: tokens, results: results}
+	value, err := p.parseOr()
+	if err != nil {
+		return false, err
+	}
+	if p.pos < len(p.tokens) {
+		return false, fmt.Errorf("unexpected %q at position %d", p.tokens[p.pos].text, p.tokens[p.pos].offset)
+	}
+	return truthy(value), nil
+}
+
+type conditionTokenKind int
+
+const (
+	tokenPath conditionTokenKind = iota
+	tokenNumber
+	tokenString
+	tokenOp
+)
+
+type conditionToken struct {
+	kind   conditionTokenKind
+	text   string
+	offset int
+}
+
+// tokenizeCondition splits a condition into paths, literals and operators
+func tokenizeCondition(expr string) ([]conditionToken, error) {
+	tokens := []conditionToken{}
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+
+		case c == '"' || c == '\'':
+			end := i + 1
+			var sb strings.Builder
+			for end < len(expr) && expr[end] != c {
+				if expr[end] == '\\' && end+1 < len(expr) {
+					end++
+				}
+				sb.WriteByte(expr[end])
+				end++
+			}
+			if end >= len(expr) {
+				return nil, fmt.Errorf("unterminated string at position %d", i)
+			}
+			tokens = append(tokens, conditionToken{kind: tokenString, text: sb.String(), offset: i})
+			i = end + 1
+
+		case isDigit(c) || (c == '-' && i+1 < len(expr) && isDigit(expr[i+1])):
+			end := i + 1
+			for end < len(expr) && (isDigit(expr[end]) || expr[end] == '.') {
+				end++
+			}
+			tokens = append(tokens, conditionToken{kind: tokenNumber, text: expr[i:end], offset: i})
+			i = end
+
+		case isPathStart(c):
+			end := i + 1
+			for end < len(expr) && isPathChar(expr[end]) {
+				end++
+			}
+			tokens = append(tokens, conditionToken{kind: tokenPath, text: expr[i:end], offset: i})
+			i = end
+
+		default:
+			op := ""
+			for _, candidate := range []string{"==", "!=", ">=", "<=", "&&", "||", ">", "<", "!", "(", ")"} {
+				if strings.HasPrefix(expr[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+			}
+			tokens = append(tokens, conditionToken{kind: tokenOp, text: op, offset: i})
+			i += len(op)
+		}
+	}
+	return tokens, nil
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func isPathStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isPathChar(c byte) bool { return isPathStart(c) || isDigit(c) || c == '-' || c == '.' }
+
+// conditionParser is a recursive descent parser that evaluates while it parses:
+// or := and ("||" and)*; and := unary ("&&" unary)*; unary := "!" unary | cmp;
+// cmp := operand (op operand)?; operand := literal | path | "(" or ")"
+type conditionParser struct {
+	tokens  []conditionToken
+	pos     int
+	results []robottypes.TaskResult
+}
+
+func (p *conditionParser) peekOp(ops ...string) string {
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != tokenOp {
+		return ""
+	}
+	for _, op := range ops {
+		if p.tokens[p.pos].text == op {
+			return op
+		}
+	}
+	return ""
+}
+
+func (p *conditionParser) parseOr() (interface{}, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekOp("||") != "" {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = truthy(left) || truthy(right)
+	}
+	return left, nil
+}
+
+func (p *conditionParser) parseAnd() (interface{}, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekOp("&&") != "" {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = truthy(left) && truthy(right)
+	}
+	return left, nil
+}
+
+func (p *conditionParser) parseUnary() (interface{}, error) {
+	if p.peekOp("!") != "" {
+		p.pos++
+		value, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return !truthy(value), nil
+	}
+	return p.parseComparison()
+}
+
+func (p *conditionParser) parseComparison() (interface{}, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	op := p.peekOp("==", "!=", ">=", "<=", ">", "<")
+	if op == "" {
+		return left, nil
+	}
+	p.pos++
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	return compareValues(left, right, op)
+}
+
+func (p *conditionParser) parseOperand() (interface{}, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("unexpected end of condition")
+	}
+	tok := p.tokens[p.pos]
+	p.pos++
+
+	switch tok.kind {
+	case tokenNumber:
+		n, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", tok.text, tok.offset)
+		}
+		return n, nil
+	case tokenString:
+		return tok.text, nil
+	case tokenPath:
+		switch tok.text {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null", "nil":
+			return nil, nil
+		}
+		return p.resolvePath(tok)
+	}
+
+	if tok.text == "(" {
+		value, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peekOp(")") == "" {
+			return nil, fmt.Errorf("missing ')' for '(' at position %d", tok.offset)
+		}
+		p.pos++
+		return value, nil
+	}
+	return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.offset)
+}
+
+// resolvePath looks up "<task_id>.<field>..." in the results of earlier tasks
+func (p *conditionParser) resolvePath(tok conditionToken) (interface{}, error) {
+	segments := strings.Split(tok.text, ".")
+	var result *robottypes.TaskResult
+	for i := range p.results {
+		if p.results[i].TaskID == segments[0] {
+			result = &p.results[i]
+			break
+		}
+	}
+	if result == nil {
+		return nil, fmt.Errorf("unknown task %q at position %d (only earlier tasks can be referenced)", segments[0], tok.offset)
+	}
+
+	var value interface{} = map[string]interface{}{
+		"success": result.Success,
+		"skipped": result.Skipped,
+		"error":   result.Error,
+		"output":  result.Output,
+	}
+	for _, segment := range segments[1:] {
+		if segment == "" {
+			return nil, fmt.Errorf("invalid path %q at position %d", tok.text, tok.offset)
+		}
+		value = lookupField(value, segment)
+	}
+	return value, nil
+}
+
+// lookupField returns value[key] for maps, value[index] for lists and decodes JSON text
+// on the way; anything missing is nil
+func lookupField(value interface{}, key string) interface{} {
+	if s, ok := value.(string); ok {
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(s), &decoded); err != nil {
+			return nil
+		}
+		value = decoded
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v[key]
+	case []interface{}:
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 || index >= len(v) {
+			return nil
+		}
+		return v[index]
+	case nil:
+		return nil
+	}
+
+	// Typed maps and slices (e.g. outputs built in Go code)
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil
+		}
+		field := rv.MapIndex(reflect.ValueOf(key).Convert(rv.Type().Key()))
+		if !field.IsValid() {
+			return nil
+		}
+		return field.Interface()
+	case reflect.Slice, reflect.Array:
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 || index >= rv.Len() {
+			return nil
+		}
+		return rv.Index(index).Interface()
+	}
+	return nil
+}
+
+// compareValues applies a comparison operator; numbers compare numerically, strings lexically
+func compareValues(left, right interface{}, op string) (bool, error) {
+	if l, ok := toNumber(left); ok {
+		if r, ok := toNumber(right); ok {
+			switch op {
+			case "==":
+				return l == r, nil
+			case "!=":
+				return l != r, nil
+			case ">":
+				return l > r, nil
+			case ">=":
+				return l >= r, nil
+			case "<":
+				return l < r, nil
+			case "<=":
+				return l <= r, nil
+			}
+		}
+	}
+
+	if l, ok := left.(string); ok {
+		if r, ok := right.(string); ok {
+			switch op {
+			case ">":
+				return l > r, nil
+			case ">=":
+				return l >= r, nil
+			case "<":
+				return l < r, nil
+			case "<=":
+				return l <= r, nil
+			}
+		}
+	}
+
+	switch op {
+	case "==":
+		return reflect.DeepEqual(left, right), nil
+	case "!=":
+		return !reflect.DeepEqual(left, right), nil
+	}
+	return false, fmt.Errorf("cannot compare %v %s %v", left, op, right)
+}
+
+func toNumber(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// truthy reports whether a value counts as true in a condition
+func truthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	}
+	if n, ok := toNumber(value); ok {
+		return n != 0
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array:
+		return rv.Len() > 0
+	case reflect.Ptr, reflect.Interface:
+		return !rv.IsNil()
+	}
+	return true
+}
@@ -0,0 +1,71 @@
+//go:build unit
+
+package standard_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/yao/agent/robot/executor/standard"
+	"github.com/yaoapp/yao/agent/robot/types"
+)
+
+func TestEvaluateConditionUnit(t *testing.T) {
+	results := []types.TaskResult{
+		{TaskID: "task-001", Success: true, Output: map[string]interface{}{
+			"count":     float64(3),
+			"anomalies": []interface{}{},
+			"severity":  "low",
+			"items":     []interface{}{map[string]interface{}{"name": "cpu"}},
+		}},
+		{TaskID: "task-002", Success: false, Error: "timeout"},
+		{TaskID: "task-003", Success: true, Output: `{"found": true, "total": 12}`},
+		{TaskID: "task-004", Success: true, Skipped: true},
+	}
+
+	cases := []struct {
+		expr string
+		want bool
+	}{
+		{"", true},
+		{"task-001.success", true},
+		{"task-002.success", false},
+		{"!task-002.success", true},
+		{"task-001.output.count > 0", true},
+		{"task-001.output.count >= 3 && task-001.output.count < 4", true},
+		{"task-001.output.count == 3", true},
+		{"task-001.output.anomalies", false},
+		{"!task-001.output.anomalies || task-001.output.severity == 'high'", true},
+		{`task-001.output.severity != "low"`, false},
+		{"task-001.output.items.0.name == 'cpu'", true},
+		{"task-001.output.items.5.name", false},
+		{"task-001.output.missing == null", true},
+		{"task-002.error == 'timeout'", true},
+		{"task-003.output.found && task-003.output.total > 10", true},
+		{"task-004.skipped", true},
+		{"(task-002.success || task-001.success) && !(task-004.success == false)", true},
+		{"task-001.output.count > -1", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.expr, func(t *testing.T) {
+			got, err := standard.EvaluateCondition(tc.expr, results)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	invalid := []string{
+		"task-009.success",          // unknown task
+		"task-001.success &&",       // dangling operator
+		"(task-001.success",         // unbalanced
+		"task-001.output.count ~ 1", // unknown operator
+		"'unterminated",
+		"task-001.output.severity > 1 > 2",
+	}
+	for _, expr := range invalid {
+		t.Run("invalid "+expr, func(t *testing.T) {
+			_, err := standard.EvaluateCondition(expr, results)
+			assert.Error(t, err)
+		})
+	}
+}
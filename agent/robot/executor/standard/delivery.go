@@ -108,6 +108,14 @@ func (e *Executor) RunDelivery(ctx *robottypes.Context, exec *robottypes.Executi
 // pushDeliveryEvent pushes a delivery event to the event bus.
 // Registered handlers (see events/handlers.go) route to email/webhook/process channels.
 func (e *Executor) pushDeliveryEvent(ctx *robottypes.Context, exec *robottypes.Execution, robot *robottypes.Robot) error {
+	// Batch items may opt out of per-item delivery; the batch completion event replaces it
+	if exec.Input != nil && exec.Input.Data != nil {
+		if suppress, ok := exec.Input.Data[robottypes.BatchDataKeySuppressDelivery].(bool); ok && suppress {
+			kunlog.Trace("delivery suppressed for batch item: execution=%s batch=%v", exec.ID, exec.Input.Data[robottypes.BatchDataKeyID])
+			return nil
+		}
+	}
+
 	prefs := buildDeliveryPreferences(robot)
 
 	chatID := exec.ChatID
@@ -123,6 +131,14 @@ func (e *Executor) pushDeliveryEvent(ctx *robottypes.Context, exec *robottypes.E
 		}
 	}
 
+	// Pending until the delivery handler reports the channel outcome
+	exec.DeliveryStatus = robottypes.DeliveryStatusPending
+	if !e.config.SkipPersistence && e.store != nil {
+		if err := e.store.UpdateDeliveryStatus(ctx.Context, exec.ID, exec.DeliveryStatus); err != nil {
+			kunlog.Warn("delivery status update failed: execution=%s error=%v", exec.ID, err)
+		}
+	}
+
 	eventCtx := ctx.Context
 	if ctx.Auth != nil {
 		eventCtx = event.WithAuth(eventCtx, &process.AuthorizedInfo{
@@ -140,6 +156,7 @@ func (e *Executor) pushDeliveryEvent(ctx *robottypes.Context, exec *robottypes.E
 		Content:     exec.Delivery.Content,
 		Preferences: prefs,
 		Extra:       extra,
+		Timezone:    robot.Location().String(),
 	})
 	if err != nil {
 		kunlog.Error("delivery event push failed: execution=%s error=%v", exec.ID, err)
@@ -178,6 +195,18 @@ func parseDeliveryContent(data map[string]interface{}) *robottypes.DeliveryConte
 		}
 	}
 
+	if tmpl, ok := contentData["recipient_template"].(string); ok {
+		content.RecipientTemplate = tmpl
+	}
+	if sections, ok := contentData["sections"].(map[string]interface{}); ok {
+		content.Sections = make(map[string]string, len(sections))
+		for key, value := range sections {
+			if s, ok := value.(string); ok {
+				content.Sections[key] = s
+			}
+		}
+	}
+
 	if content.Summary == "" && content.Body == "" {
 		return nil
 	}
@@ -298,6 +327,12 @@ func buildDeliveryPreferences(robot *robottypes.Robot) *robottypes.DeliveryPrefe
 		}
 	}
 
+	if robot.Config != nil && robot.Config.Delivery != nil && robot.Config.Delivery.Slack != nil {
+		if robot.Config.Delivery.Slack.Enabled && len(robot.Config.Delivery.Slack.Targets) > 0 {
+			prefs.Slack = robot.Config.Delivery.Slack
+		}
+	}
+
 	return prefs
 }
 
@@ -350,7 +385,7 @@ func (f *InputFormatter) FormatDeliveryInput(exec *robottypes.Execution, robot *
 	sb.WriteString("## Execution Context\n\n")
 	sb.WriteString(fmt.Sprintf("- **Trigger**: %s\n", exec.TriggerType))
 	sb.WriteString(fmt.Sprintf("- **Status**: %s\n", exec.Status))
-	sb.WriteString(fmt.Sprintf("- **Start Time**: %s\n", exec.StartTime.Format("2006-01-02 15:04:05")))
+	sb.WriteString(fmt.Sprintf("- **Start Time**: %s\n", deliveryTime(exec.StartTime, robot)))
 	if exec.EndTime != nil {
 		duration := exec.EndTime.Sub(exec.StartTime)
 		sb.WriteString(fmt.Sprintf("- **Duration**: %s\n", duration.String()))
@@ -472,7 +507,7 @@ func (f *InputFormatter) FormatDeliveryInputWithManifest(
 	if locale != "" {
 		sb.WriteString(fmt.Sprintf("- **Language**: %s\n", locale))
 	}
-	sb.WriteString(fmt.Sprintf("- **Start Time**: %s\n", exec.StartTime.Format("2006-01-02 15:04:05")))
+	sb.WriteString(fmt.Sprintf("- **Start Time**: %s\n", deliveryTime(exec.StartTime, robot)))
 	if exec.EndTime != nil {
 		duration := exec.EndTime.Sub(exec.StartTime)
 		sb.WriteString(fmt.Sprintf("- **Duration**: %s\n", duration.String()))
@@ -537,3 +572,12 @@ func (f *InputFormatter) FormatDeliveryInputWithManifest(
 
 	return sb.String()
 }
+
+// deliveryTime renders t in the robot's timezone for delivery content
+func deliveryTime(t time.Time, robot *robottypes.Robot) string {
+	loc := time.Local
+	if robot != nil {
+		loc = robot.Location()
+	}
+	return t.In(loc).Format("2006-01-02 15:04:05 MST")
+}
@@ -1,6 +1,7 @@
 package standard
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"sync/atomic"
@@ -29,6 +30,9 @@ type Executor struct {
 	currentCount atomic.Int32
 	onStart      func()
 	onEnd        func()
+
+	// runPhase hook, nil = runPhaseLogic (replaced by tests)
+	phaseFn func(ctx *robottypes.Context, exec *robottypes.Execution, phase robottypes.Phase, data interface{}) error
 }
 
 // New creates a new standard executor
@@ -138,9 +142,7 @@ func (e *Executor) ExecuteWithControl(ctx *robottypes.Context, robot *robottypes
 					"error":        err,
 				}).Warn("Failed to persist pre-confirmed goals: %v", err)
 			}
-			if goalName := extractGoalName(exec.Goals); goalName != "" {
-				e.updateUIFields(ctx, exec, goalName, "")
-			}
+			e.applyGoalName(ctx, exec)
 		}
 
 	}
@@ -159,9 +161,8 @@ func (e *Executor) ExecuteWithControl(ctx *robottypes.Context, robot *robottypes
 		if exec.Status == robottypes.ExecWaiting {
 			return
 		}
-		robot.RemoveExecution(exec.ID)
-		// Update robot status to idle if no more running executions
-		if robot.RunningCount() == 0 && !e.config.SkipPersistence && e.robotStore != nil {
+		// Update robot status to idle if no more running executions; a paused robot stays paused
+		if robot.ReleaseSlot(exec.ID) == 0 && robot.Status != robottypes.RobotPaused && !e.config.SkipPersistence && e.robotStore != nil {
 			if err := e.robotStore.UpdateStatus(ctx.Context, robot.MemberID, robottypes.RobotIdle); err != nil {
 				kunlog.With(kunlog.F{
 					"member_id": robot.MemberID,
@@ -227,16 +228,34 @@ func (e *Executor) ExecuteWithControl(ctx *robottypes.Context, robot *robottypes
 		return exec, nil
 	}
 
+	// The team may have narrowed the allowed models since the robot was configured
+	if !e.config.SkipPersistence && e.robotStore != nil {
+		if err := e.robotStore.CheckLanguageModel(ctx.Context, robot.TeamID, robot.LanguageModel); err != nil {
+			exec.Status = robottypes.ExecFailed
+			exec.Error = err.Error()
+			kunlog.With(kunlog.F{
+				"execution_id":   exec.ID,
+				"member_id":      exec.MemberID,
+				"language_model": robot.LanguageModel,
+			}).Warn("Execution rejected: %v", err)
+			if e.store != nil {
+				_ = e.store.UpdateStatus(ctx.Context, exec.ID, robottypes.ExecFailed, err.Error())
+			}
+			return exec, nil
+		}
+	}
+
 	// Determine locale for UI messages
 	locale := getEffectiveLocale(robot, exec.Input)
 
 	// Execute phases (PhaseHost is not part of the normal pipeline — it is only for Interact)
+	run := newRunTimeout(robot)
 	phases := robottypes.AllPhases[startPhaseIndex:]
 	for _, phase := range phases {
 		if phase == robottypes.PhaseHost {
 			continue
 		}
-		if err := e.runPhase(ctx, exec, phase, data, control); err != nil {
+		if err := e.runPhase(ctx, exec, phase, data, control, run); err != nil {
 			// Check if execution was suspended (needs human input)
 			if err == robottypes.ErrExecutionSuspended {
 				kunlog.With(kunlog.F{
@@ -247,26 +266,10 @@ func (e *Executor) ExecuteWithControl(ctx *robottypes.Context, robot *robottypes
 				return exec, robottypes.ErrExecutionSuspended
 			}
 
-			// Check if execution was cancelled
-			if err == robottypes.ErrExecutionCancelled {
-				exec.Status = robottypes.ExecCancelled
-				exec.Error = "execution cancelled by user"
-				now := time.Now()
-				exec.EndTime = &now
-
-				// Update UI field for cancellation with i18n
-				e.updateUIFields(ctx, exec, "", getLocalizedMessage(locale, "cancelled"))
-
-				kunlog.With(kunlog.F{
-					"execution_id": exec.ID,
-					"member_id":    exec.MemberID,
-					"phase":        string(phase),
-				}).Info("Execution cancelled by user")
-
-				// Persist cancelled status
-				if !e.config.SkipPersistence && e.store != nil {
-					_ = e.store.UpdateStatus(ctx.Context, exec.ID, robottypes.ExecCancelled, "execution cancelled by user")
-				}
+			// Check if execution was cancelled. A stop from outside cancels the context,
+			// which can surface as any error from the phase (e.g. an aborted LLM call).
+			if err == robottypes.ErrExecutionCancelled || ctx.Context.Err() != nil {
+				e.finishCancelled(ctx, exec, phase, locale)
 				return exec, nil
 			}
 
@@ -290,10 +293,17 @@ func (e *Executor) ExecuteWithControl(ctx *robottypes.Context, robot *robottypes
 			if !e.config.SkipPersistence && e.store != nil {
 				_ = e.store.UpdateStatus(ctx.Context, exec.ID, robottypes.ExecFailed, err.Error())
 			}
+			e.pushExecEnded(ctx, exec, robotevents.ExecFailed)
 			return exec, nil
 		}
 	}
 
+	// Cancelled after the last phase started: the canceller's status wins
+	if ctx.Context.Err() != nil {
+		e.finishCancelled(ctx, exec, exec.Phase, locale)
+		return exec, nil
+	}
+
 	// Mark completed
 	exec.Status = robottypes.ExecCompleted
 	now := time.Now()
@@ -319,19 +329,56 @@ func (e *Executor) ExecuteWithControl(ctx *robottypes.Context, robot *robottypes
 		}
 	}
 
-	event.Push(ctx.Context, robotevents.ExecCompleted, robotevents.ExecPayload{
+	e.pushExecEnded(ctx, exec, robotevents.ExecCompleted)
+
+	return exec, nil
+}
+
+// pushExecEnded announces a finished run (ExecCompleted or ExecFailed) with its final status
+func (e *Executor) pushExecEnded(ctx *robottypes.Context, exec *robottypes.Execution, eventType string) {
+	event.Push(ctx.Context, eventType, robotevents.ExecPayload{
 		ExecutionID: exec.ID,
 		MemberID:    exec.MemberID,
 		TeamID:      exec.TeamID,
-		Status:      string(robottypes.ExecCompleted),
+		Status:      string(exec.Status),
+		Error:       exec.Error,
 		ChatID:      exec.ChatID,
 	})
+}
 
-	return exec, nil
+// finishCancelled marks a cancelled run. When the record was already cancelled by
+// CancelExecution or a reset, its status and reason are kept as written.
+func (e *Executor) finishCancelled(ctx *robottypes.Context, exec *robottypes.Execution, phase robottypes.Phase, locale string) {
+	exec.Status = robottypes.ExecCancelled
+	exec.Error = "execution cancelled by user"
+	now := time.Now()
+	exec.EndTime = &now
+
+	// Update UI field for cancellation with i18n
+	e.updateUIFields(ctx, exec, "", getLocalizedMessage(locale, "cancelled"))
+
+	kunlog.With(kunlog.F{
+		"execution_id": exec.ID,
+		"member_id":    exec.MemberID,
+		"phase":        string(phase),
+	}).Info("Execution cancelled by user")
+
+	if e.config.SkipPersistence || e.store == nil {
+		return
+	}
+	// ctx is already cancelled here, so the store calls run detached from it
+	if record, err := e.store.Get(context.Background(), exec.ID); err == nil && record != nil && record.Status.IsTerminal() {
+		exec.Status = record.Status
+		if record.Error != "" {
+			exec.Error = record.Error
+		}
+		return
+	}
+	_ = e.store.UpdateStatus(context.Background(), exec.ID, robottypes.ExecCancelled, "execution cancelled by user")
 }
 
-// runPhase executes a single phase
-func (e *Executor) runPhase(ctx *robottypes.Context, exec *robottypes.Execution, phase robottypes.Phase, data interface{}, control robottypes.ExecutionControl) error {
+// runPhase executes a single phase within the robot's phase timeout and the run's deadline
+func (e *Executor) runPhase(ctx *robottypes.Context, exec *robottypes.Execution, phase robottypes.Phase, data interface{}, control robottypes.ExecutionControl, run runTimeout) error {
 	// Check if context is cancelled before starting this phase
 	select {
 	case <-ctx.Context.Done():
@@ -365,27 +412,64 @@ func (e *Executor) runPhase(ctx *robottypes.Context, exec *robottypes.Execution,
 		}
 	}
 
+	event.Push(ctx.Context, robotevents.PhaseChanged, robotevents.PhasePayload{
+		ExecutionID: exec.ID,
+		MemberID:    exec.MemberID,
+		TeamID:      exec.TeamID,
+		Phase:       string(phase),
+		ChatID:      exec.ChatID,
+	})
+
 	if e.config.OnPhaseStart != nil {
 		e.config.OnPhaseStart(phase)
 	}
 
 	phaseStart := time.Now()
+	bound := boundPhase(ctx, exec.GetRobot(), phase, run, e.config.PhaseTimeout)
+	defer bound.cancel()
+	attemptPhase := func() error {
+		return bound.wait(func() error { return e.phaseLogic(bound.ctx, exec, phase, data) })
+	}
 
-	// Execute phase-specific logic
-	var err error
-	switch phase {
-	case robottypes.PhaseInspiration:
-		err = e.RunInspiration(ctx, exec, data)
-	case robottypes.PhaseGoals:
-		err = e.RunGoals(ctx, exec, data)
-	case robottypes.PhaseTasks:
-		err = e.RunTasks(ctx, exec, data)
-	case robottypes.PhaseRun:
-		err = e.RunExecution(ctx, exec, data)
-	case robottypes.PhaseDelivery:
-		err = e.RunDelivery(ctx, exec, data)
-	case robottypes.PhaseLearning:
-		err = e.RunLearning(ctx, exec, data)
+	// Execute phase-specific logic, retrying transient failures per the phase's policy
+	policy := e.config.RetryPolicyFor(phase)
+	err := attemptPhase()
+	for attempt := 1; err != nil && attempt <= policy.MaxRetries && isRetryableError(err) && bound.ctx.Err() == nil; attempt++ {
+		delay := policy.Delay(attempt)
+		kunlog.With(kunlog.F{
+			"execution_id": exec.ID,
+			"member_id":    exec.MemberID,
+			"phase":        string(phase),
+			"attempt":      attempt,
+			"max_retries":  policy.MaxRetries,
+			"error":        err.Error(),
+		}).Warn("Phase %s failed, retry %d/%d in %s: %v", phase, attempt, policy.MaxRetries, delay, err)
+
+		select {
+		case <-ctx.Context.Done():
+			return robottypes.ErrExecutionCancelled
+		case <-bound.ctx.Done():
+		case <-time.After(delay):
+		}
+		if bound.ctx.Err() != nil {
+			break // timed out while waiting to retry
+		}
+		if control != nil {
+			if err := control.WaitIfPaused(); err != nil {
+				return err
+			}
+		}
+		if phase == robottypes.PhaseRun {
+			prepareRunRetry(exec)
+		}
+		err = attemptPhase()
+	}
+
+	// A phase ended by its deadline fails with the limit it ran into
+	if err != nil {
+		if timeoutErr := bound.timeoutError(ctx); timeoutErr != nil {
+			err = timeoutErr
+		}
 	}
 
 	if err != nil {
@@ -436,6 +520,34 @@ func (e *Executor) runPhase(ctx *robottypes.Context, exec *robottypes.Execution,
 	return nil
 }
 
+// phaseLogic runs one attempt of a phase
+func (e *Executor) phaseLogic(ctx *robottypes.Context, exec *robottypes.Execution, phase robottypes.Phase, data interface{}) error {
+	if e.phaseFn != nil {
+		return e.phaseFn(ctx, exec, phase, data)
+	}
+	return e.runPhaseLogic(ctx, exec, phase, data)
+}
+
+// runPhaseLogic runs the phase-specific logic once.
+// A retried Run phase continues at the task that failed (see prepareRunRetry).
+func (e *Executor) runPhaseLogic(ctx *robottypes.Context, exec *robottypes.Execution, phase robottypes.Phase, data interface{}) error {
+	switch phase {
+	case robottypes.PhaseInspiration:
+		return e.RunInspiration(ctx, exec, data)
+	case robottypes.PhaseGoals:
+		return e.RunGoals(ctx, exec, data)
+	case robottypes.PhaseTasks:
+		return e.RunTasks(ctx, exec, data)
+	case robottypes.PhaseRun:
+		return e.RunExecution(ctx, exec, data)
+	case robottypes.PhaseDelivery:
+		return e.RunDelivery(ctx, exec, data)
+	case robottypes.PhaseLearning:
+		return e.RunLearning(ctx, exec, data)
+	}
+	return nil
+}
+
 // getPhaseData extracts the output data for a specific phase from execution
 func (e *Executor) getPhaseData(exec *robottypes.Execution, phase robottypes.Phase) interface{} {
 	switch phase {
@@ -550,6 +662,7 @@ var uiMessages = map[string]map[string]string{
 		"cancelled":           "Cancelled",
 		"failed_prefix":       "Failed at ",
 		"task_prefix":         "Task",
+		"approval_question":   "Approval required before running %s. Confirm to proceed, or skip this task.",
 		// Phase names for failure messages
 		"phase_inspiration": "inspiration",
 		"phase_goals":       "goals",
@@ -574,6 +687,7 @@ var uiMessages = map[string]map[string]string{
 		"cancelled":           "已取消",
 		"failed_prefix":       "失败于",
 		"task_prefix":         "任务",
+		"approval_question":   "执行 %s 前需要您的批准。确认后继续，或跳过此任务。",
 		// Phase names for failure messages
 		"phase_inspiration": "灵感阶段",
 		"phase_goals":       "目标阶段",
@@ -738,6 +852,11 @@ func stripMarkdownFormatting(s string) string {
 // Suspend transitions the execution to waiting status, persists state, and returns
 // ErrExecutionSuspended so the caller stops further phase processing.
 func (e *Executor) Suspend(ctx *robottypes.Context, exec *robottypes.Execution, taskIndex int, question string) error {
+	return e.suspend(ctx, exec, taskIndex, question, "")
+}
+
+// suspend implements Suspend; approvalTaskID is set when the task waits for approval
+func (e *Executor) suspend(ctx *robottypes.Context, exec *robottypes.Execution, taskIndex int, question string, approvalTaskID string) error {
 	now := time.Now()
 	taskID := ""
 	if taskIndex >= 0 && taskIndex < len(exec.Tasks) {
@@ -752,6 +871,7 @@ func (e *Executor) Suspend(ctx *robottypes.Context, exec *robottypes.Execution,
 	exec.ResumeContext = &robottypes.ResumeContext{
 		TaskIndex:       taskIndex,
 		PreviousResults: exec.Results,
+		ApprovalTaskID:  approvalTaskID,
 	}
 
 	if !e.config.SkipPersistence && e.store != nil {
@@ -779,6 +899,7 @@ func (e *Executor) Suspend(ctx *robottypes.Context, exec *robottypes.Execution,
 		"member_id":    exec.MemberID,
 		"task_id":      taskID,
 		"question":     question,
+		"approval":     approvalTaskID != "",
 	}).Info("Execution suspended, waiting for human input")
 
 	// Fire event (best-effort, errors are ignored)
@@ -789,11 +910,85 @@ func (e *Executor) Suspend(ctx *robottypes.Context, exec *robottypes.Execution,
 		TaskID:      taskID,
 		Question:    question,
 		ChatID:      exec.ChatID,
+		Approval:    approvalTaskID != "",
 	})
 
 	return robottypes.ErrExecutionSuspended
 }
 
+// ResumeFromTask resumes a suspended execution from taskIndex instead of the suspended
+// task, e.g. to redo earlier tasks after their input data was fixed. taskIndex may
+// point at any task up to the suspended one, except a skipped task. Results from
+// taskIndex on are dropped and those tasks set back to pending; the rewound state is
+// persisted before the normal Resume path runs with reply.
+func (e *Executor) ResumeFromTask(ctx *robottypes.Context, execID string, taskIndex int, reply string) error {
+	if ctx == nil {
+		return fmt.Errorf("context is required for resume")
+	}
+	if execID == "" {
+		return fmt.Errorf("execID cannot be empty")
+	}
+	if e.store == nil || e.config.SkipPersistence {
+		return fmt.Errorf("store is required for resume")
+	}
+
+	record, err := e.store.Get(ctx.Context, execID)
+	if err != nil {
+		return fmt.Errorf("failed to load execution: %w", err)
+	}
+	if record == nil {
+		return fmt.Errorf("execution not found: %s", execID)
+	}
+	if record.Status != robottypes.ExecWaiting {
+		return fmt.Errorf("execution %s is not in waiting status (current: %s)", execID, record.Status)
+	}
+
+	exec := record.ToExecution()
+	resumeCtx := exec.ResumeContext
+	if resumeCtx == nil {
+		resumeCtx = &robottypes.ResumeContext{TaskIndex: len(exec.Tasks)}
+	}
+	last := resumeCtx.TaskIndex
+	if last >= len(exec.Tasks) {
+		last = len(exec.Tasks) - 1
+	}
+	if taskIndex < 0 || taskIndex > last {
+		return fmt.Errorf("invalid task index %d: execution %s can resume from task 0 to %d", taskIndex, execID, last)
+	}
+	if exec.Tasks[taskIndex].Status == robottypes.TaskSkipped {
+		return fmt.Errorf("invalid task index %d: task %s was skipped", taskIndex, exec.Tasks[taskIndex].ID)
+	}
+
+	// Rewind: the tasks from taskIndex on run again
+	if len(resumeCtx.PreviousResults) > taskIndex {
+		resumeCtx.PreviousResults = resumeCtx.PreviousResults[:taskIndex]
+	}
+	resumeCtx.TaskIndex = taskIndex
+	resumeCtx.ApprovalTaskID = ""
+	for i := taskIndex; i < len(exec.Tasks); i++ {
+		exec.Tasks[i].Status = robottypes.TaskPending
+		exec.Tasks[i].StartTime = nil
+		exec.Tasks[i].EndTime = nil
+	}
+	exec.ResumeContext = resumeCtx
+
+	if err := e.store.UpdateTasks(ctx.Context, exec.ID, exec.Tasks, nil); err != nil {
+		return fmt.Errorf("failed to persist rewound tasks: %w", err)
+	}
+	if err := e.store.UpdateSuspendState(ctx.Context, exec.ID, exec.Tasks[taskIndex].ID, exec.WaitingQuestion, resumeCtx); err != nil {
+		return fmt.Errorf("failed to persist rewound resume state: %w", err)
+	}
+
+	kunlog.With(kunlog.F{
+		"execution_id": exec.ID,
+		"member_id":    exec.MemberID,
+		"from_task":    taskIndex,
+		"suspended_at": last,
+	}).Info("Execution rewound to task %d", taskIndex)
+
+	return e.Resume(ctx, execID, reply)
+}
+
 // Resume resumes a suspended execution with human-provided input.
 // Loads execution from DB, restores state, injects reply, and continues from the suspended task.
 func (e *Executor) Resume(ctx *robottypes.Context, execID string, reply string) error {
@@ -851,8 +1046,7 @@ func (e *Executor) Resume(ctx *robottypes.Context, execID string, reply string)
 		if exec.Status == robottypes.ExecWaiting {
 			return // re-suspended, keep tracking
 		}
-		robot.RemoveExecution(exec.ID)
-		if robot.RunningCount() == 0 && !e.config.SkipPersistence && e.robotStore != nil {
+		if robot.ReleaseSlot(exec.ID) == 0 && robot.Status != robottypes.RobotPaused && !e.config.SkipPersistence && e.robotStore != nil {
 			if err := e.robotStore.UpdateStatus(ctx.Context, robot.MemberID, robottypes.RobotIdle); err != nil {
 				kunlog.With(kunlog.F{
 					"member_id": robot.MemberID,
@@ -882,6 +1076,20 @@ func (e *Executor) Resume(ctx *robottypes.Context, execID string, reply string)
 		reply = "" // Don't inject __skip__ as a message
 	}
 
+	// Pending approval: only ReplyApprove lets the gated task run; anything else is
+	// recorded on the task and the execution keeps waiting (re-suspended by RunExecution)
+	if exec.ResumeContext != nil && exec.ResumeContext.ApprovalTaskID != "" {
+		ti := exec.ResumeContext.TaskIndex
+		if reply == robottypes.ReplyApprove && ti >= 0 && ti < len(exec.Tasks) && exec.Tasks[ti].ID == exec.ResumeContext.ApprovalTaskID {
+			now := time.Now()
+			exec.Tasks[ti].ApprovedAt = &now
+			reply = "" // Don't inject the approval marker as a message
+		}
+		exec.ResumeContext.ApprovalTaskID = ""
+	} else if reply == robottypes.ReplyApprove {
+		reply = ""
+	}
+
 	// Inject reply into the waiting task's messages so the re-executed task gets context
 	if exec.ResumeContext != nil {
 		ti := exec.ResumeContext.TaskIndex
@@ -921,16 +1129,28 @@ func (e *Executor) Resume(ctx *robottypes.Context, execID string, reply string)
 		ChatID:      exec.ChatID,
 	})
 
-	// Continue P3 (Run) from where it was suspended
-	if err := e.RunExecution(ctx, exec, nil); err != nil {
+	// Continue P3 (Run) from where it was suspended; the resumed phases get a fresh run budget
+	run := newRunTimeout(robot)
+	bound := boundPhase(ctx, robot, robottypes.PhaseRun, run, e.config.PhaseTimeout)
+	err = bound.wait(func() error { return e.RunExecution(bound.ctx, exec, nil) })
+	if timeoutErr := bound.timeoutError(ctx); err != nil && timeoutErr != nil {
+		err = timeoutErr
+	}
+	bound.cancel()
+	if err != nil {
 		if err == robottypes.ErrExecutionSuspended {
 			return err
 		}
+		if err == robottypes.ErrExecutionCancelled || ctx.Context.Err() != nil {
+			e.finishCancelled(ctx, exec, robottypes.PhaseRun, getEffectiveLocale(robot, exec.Input))
+			return robottypes.ErrExecutionCancelled
+		}
 		exec.Status = robottypes.ExecFailed
 		exec.Error = err.Error()
 		if !e.config.SkipPersistence && e.store != nil {
 			_ = e.store.UpdateStatus(ctx.Context, exec.ID, robottypes.ExecFailed, err.Error())
 		}
+		e.pushExecEnded(ctx, exec, robotevents.ExecFailed)
 		return err
 	}
 
@@ -940,10 +1160,14 @@ func (e *Executor) Resume(ctx *robottypes.Context, execID string, reply string)
 	// Continue with P4 (Delivery) and P5 (Learning)
 	locale := getEffectiveLocale(robot, exec.Input)
 	for _, phase := range []robottypes.Phase{robottypes.PhaseDelivery, robottypes.PhaseLearning} {
-		if err := e.runPhase(ctx, exec, phase, nil, nil); err != nil {
+		if err := e.runPhase(ctx, exec, phase, nil, nil, run); err != nil {
 			if err == robottypes.ErrExecutionSuspended {
 				return err
 			}
+			if err == robottypes.ErrExecutionCancelled || ctx.Context.Err() != nil {
+				e.finishCancelled(ctx, exec, phase, locale)
+				return robottypes.ErrExecutionCancelled
+			}
 			exec.Status = robottypes.ExecFailed
 			exec.Error = err.Error()
 			failedPrefix := getLocalizedMessage(locale, "failed_prefix")
@@ -952,9 +1176,14 @@ func (e *Executor) Resume(ctx *robottypes.Context, execID string, reply string)
 			if !e.config.SkipPersistence && e.store != nil {
 				_ = e.store.UpdateStatus(ctx.Context, exec.ID, robottypes.ExecFailed, err.Error())
 			}
+			e.pushExecEnded(ctx, exec, robotevents.ExecFailed)
 			return fmt.Errorf("resume phase %s failed: %w", phase, err)
 		}
 	}
+	if ctx.Context.Err() != nil {
+		e.finishCancelled(ctx, exec, exec.Phase, locale)
+		return robottypes.ErrExecutionCancelled
+	}
 
 	// Mark completed
 	exec.Status = robottypes.ExecCompleted
@@ -964,6 +1193,7 @@ func (e *Executor) Resume(ctx *robottypes.Context, execID string, reply string)
 	if !e.config.SkipPersistence && e.store != nil {
 		_ = e.store.UpdateStatus(ctx.Context, exec.ID, robottypes.ExecCompleted, "")
 	}
+	e.pushExecEnded(ctx, exec, robotevents.ExecCompleted)
 
 	return nil
 }
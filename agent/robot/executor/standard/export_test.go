@@ -1,6 +1,9 @@
 package standard
 
 import (
+	"context"
+
+	mcpTypes "github.com/yaoapp/gou/mcp/types"
 	agentcontext "github.com/yaoapp/yao/agent/context"
 	robottypes "github.com/yaoapp/yao/agent/robot/types"
 )
@@ -27,9 +30,56 @@ var (
 	HasAgentRulesFn         = (*Validator).hasAgentRules
 	GetSemanticRulesFn      = (*Validator).getSemanticRules
 	GenerateFeedbackReplyFn = (*Validator).generateFeedbackReply
+	IsRetryableErrorFn      = isRetryableError
+	ApplyGoalNameFn         = (*Executor).applyGoalName
 )
 
 type ExportedCallResult = CallResult
 type ExportedManifestFile = ManifestFile
 type ExportedCompletionResponse = agentcontext.CompletionResponse
 type ExportedValidationResult = robottypes.ValidationResult
+
+// SetAgentCallObserver installs a hook receiving the messages of every agent call
+func SetAgentCallObserver(fn func(assistantID string, messages []agentcontext.Message)) {
+	agentCallObserver = fn
+}
+
+// RunBoundPhase runs fn under the context a phase of robot gets and returns the
+// timeout error the phase would fail with, nil if it stayed within its limits
+func RunBoundPhase(ctx *robottypes.Context, robot *robottypes.Robot, phase robottypes.Phase, fn func(phaseCtx *robottypes.Context)) error {
+	bound := boundPhase(ctx, robot, phase, newRunTimeout(robot), 0)
+	defer bound.cancel()
+	fn(bound.ctx)
+	return bound.timeoutError(ctx)
+}
+
+// RunPhaseLogic runs the built-in logic of phase, for phase funcs delegating to it
+func RunPhaseLogic(e *Executor, ctx *robottypes.Context, exec *robottypes.Execution, phase robottypes.Phase, data interface{}) error {
+	return e.runPhaseLogic(ctx, exec, phase, data)
+}
+
+// SetPhaseFunc replaces the phase logic of e with fn
+func SetPhaseFunc(e *Executor, fn func(ctx *robottypes.Context, exec *robottypes.Execution, phase robottypes.Phase, data interface{}) error) {
+	e.phaseFn = fn
+}
+
+// MCPCallFunc answers the tool calls of the mock MCP client installed by SetMCPCall
+type MCPCallFunc func(ctx context.Context, server, tool string, arguments interface{}) (*mcpTypes.CallToolResponse, error)
+
+type mockMCPClient struct {
+	server string
+	call   MCPCallFunc
+}
+
+func (c *mockMCPClient) CallTool(ctx context.Context, name string, arguments interface{}, extraArgs ...interface{}) (*mcpTypes.CallToolResponse, error) {
+	return c.call(ctx, c.server, name, arguments)
+}
+
+// SetMCPCall makes every MCP server resolve to a mock client calling fn and returns a restore func
+func SetMCPCall(fn MCPCallFunc) func() {
+	prev := selectMCPClient
+	selectMCPClient = func(id string) (mcpToolCaller, error) {
+		return &mockMCPClient{server: id, call: fn}, nil
+	}
+	return func() { selectMCPClient = prev }
+}
@@ -102,6 +102,7 @@ func (e *Executor) RunGoals(ctx *robottypes.Context, exec *robottypes.Execution,
 		exec.Goals = &robottypes.Goals{
 			Content: content,
 		}
+		e.applyGoalName(ctx, exec)
 		return nil
 	}
 
@@ -123,12 +124,16 @@ func (e *Executor) RunGoals(ctx *robottypes.Context, exec *robottypes.Execution,
 		return fmt.Errorf("goals agent (%s) returned empty content", agentID)
 	}
 
-	// Update Name from goals content (extract first line as execution title)
+	e.applyGoalName(ctx, exec)
+	return nil
+}
+
+// applyGoalName replaces the trigger-based placeholder name (e.g. "Scheduled execution"
+// for clock triggers) with a title taken from the finalized goals
+func (e *Executor) applyGoalName(ctx *robottypes.Context, exec *robottypes.Execution) {
 	if goalName := extractGoalName(exec.Goals); goalName != "" {
 		e.updateUIFields(ctx, exec, goalName, "")
 	}
-
-	return nil
 }
 
 // ParseDelivery converts map to DeliveryTarget struct
@@ -185,7 +190,7 @@ func ParseDelivery(data map[string]interface{}) *robottypes.DeliveryTarget {
 func IsValidDeliveryType(t robottypes.DeliveryType) bool {
 	switch t {
 	case robottypes.DeliveryEmail, robottypes.DeliveryWebhook,
-		robottypes.DeliveryProcess, robottypes.DeliveryNotify:
+		robottypes.DeliveryProcess, robottypes.DeliverySlack, robottypes.DeliveryNotify:
 		return true
 	default:
 		return false
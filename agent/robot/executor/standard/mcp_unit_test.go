@@ -0,0 +1,123 @@
+//go:build unit
+
+package standard_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mcpTypes "github.com/yaoapp/gou/mcp/types"
+	agentcontext "github.com/yaoapp/yao/agent/context"
+	"github.com/yaoapp/yao/agent/robot/executor/standard"
+	"github.com/yaoapp/yao/agent/robot/types"
+)
+
+func mcpRunner(executor *types.ExecutorConfig, servers ...types.MCPConfig) *standard.Runner {
+	robot := &types.Robot{MemberID: "robot_mcp", Config: &types.Config{
+		Resources: &types.Resources{MCP: servers},
+		Executor:  executor,
+	}}
+	ctx := types.NewContext(context.Background(), nil)
+	return standard.NewRunner(ctx, robot, standard.DefaultRunConfig(), "", "exec_mcp")
+}
+
+func textResponse(text string) *mcpTypes.CallToolResponse {
+	return &mcpTypes.CallToolResponse{Content: []mcpTypes.ToolContent{{Type: mcpTypes.ToolContentTypeText, Text: text}}}
+}
+
+func TestRunnerExecuteMCPTask(t *testing.T) {
+	type call struct {
+		server, tool string
+		args         interface{}
+	}
+	var calls []call
+	restore := standard.SetMCPCall(func(ctx context.Context, server, tool string, args interface{}) (*mcpTypes.CallToolResponse, error) {
+		calls = append(calls, call{server, tool, args})
+		switch tool {
+		case "fail":
+			return nil, errors.New("connection refused")
+		case "reject":
+			return &mcpTypes.CallToolResponse{IsError: true, Content: []mcpTypes.ToolContent{{Type: mcpTypes.ToolContentTypeText, Text: "bad query"}}}, nil
+		case "slow":
+			<-ctx.Done()
+			return nil, ctx.Err()
+		case "json":
+			return textResponse(`{"total": 3}`), nil
+		}
+		return textResponse("42 results"), nil
+	})
+	defer restore()
+
+	runner := mcpRunner(&types.ExecutorConfig{ToolTimeout: "20ms"},
+		types.MCPConfig{ID: "search.web"},
+		types.MCPConfig{ID: "search", Tools: []string{"json"}},
+	)
+
+	t.Run("executor_id resolves against configured servers", func(t *testing.T) {
+		calls = nil
+		task := &types.Task{ID: "t1", ExecutorType: types.ExecutorMCP, ExecutorID: "search.web.query",
+			Args: []any{map[string]interface{}{"q": "yao"}}}
+		result := runner.ExecuteTask(task, &standard.RunnerContext{})
+		require.True(t, result.Success, result.Error)
+		assert.Equal(t, "42 results", result.Output)
+		require.Len(t, calls, 1)
+		assert.Equal(t, "search.web", calls[0].server, "longest configured server ID wins")
+		assert.Equal(t, "query", calls[0].tool)
+		assert.Equal(t, map[string]interface{}{"q": "yao"}, calls[0].args)
+	})
+
+	t.Run("JSON text output is decoded", func(t *testing.T) {
+		task := &types.Task{ID: "t2", ExecutorType: types.ExecutorMCP, MCPServer: "search", MCPTool: "json"}
+		result := runner.ExecuteTask(task, &standard.RunnerContext{})
+		require.True(t, result.Success, result.Error)
+		assert.Equal(t, map[string]interface{}{"total": float64(3)}, result.Output)
+	})
+
+	t.Run("message arguments carry previous results", func(t *testing.T) {
+		calls = nil
+		task := &types.Task{ID: "t3", ExecutorType: types.ExecutorMCP, MCPServer: "search.web", MCPTool: "query",
+			Messages: []agentcontext.Message{{Role: agentcontext.RoleUser, Content: "Find related docs"}}}
+		taskCtx := &standard.RunnerContext{PreviousResults: []types.TaskResult{{TaskID: "t0", Success: true, Output: "earlier finding"}}}
+		result := runner.ExecuteTask(task, taskCtx)
+		require.True(t, result.Success, result.Error)
+		require.Len(t, calls, 1)
+		input, _ := calls[0].args.(map[string]interface{})["input"].(string)
+		assert.Contains(t, input, "earlier finding")
+		assert.Contains(t, input, "Find related docs")
+	})
+
+	t.Run("JSON message is the argument object", func(t *testing.T) {
+		calls = nil
+		task := &types.Task{ID: "t4", ExecutorType: types.ExecutorMCP, MCPServer: "search.web", MCPTool: "query",
+			Messages: []agentcontext.Message{{Role: agentcontext.RoleUser, Content: `{"q": "robots"}`}}}
+		result := runner.ExecuteTask(task, &standard.RunnerContext{})
+		require.True(t, result.Success, result.Error)
+		assert.Equal(t, map[string]interface{}{"q": "robots"}, calls[0].args)
+	})
+
+	failures := []struct {
+		name  string
+		task  *types.Task
+		error string
+	}{
+		{"server not configured", &types.Task{ID: "f1", ExecutorType: types.ExecutorMCP, MCPServer: "mail", MCPTool: "send"}, "not configured"},
+		{"tool not allowed", &types.Task{ID: "f2", ExecutorType: types.ExecutorMCP, MCPServer: "search", MCPTool: "query"}, "not allowed"},
+		{"no server or tool", &types.Task{ID: "f3", ExecutorType: types.ExecutorMCP, ExecutorID: "unknown"}, "requires mcp_server"},
+		{"client error", &types.Task{ID: "f4", ExecutorType: types.ExecutorMCP, MCPServer: "search.web", MCPTool: "fail"}, "connection refused"},
+		{"tool error", &types.Task{ID: "f5", ExecutorType: types.ExecutorMCP, MCPServer: "search.web", MCPTool: "reject"}, "bad query"},
+		{"timeout", &types.Task{ID: "f6", ExecutorType: types.ExecutorMCP, MCPServer: "search.web", MCPTool: "slow"}, "timed out after 20ms"},
+	}
+	for _, tc := range failures {
+		t.Run(tc.name, func(t *testing.T) {
+			start := time.Now()
+			result := runner.ExecuteTask(tc.task, &standard.RunnerContext{})
+			assert.False(t, result.Success)
+			assert.Contains(t, result.Error, tc.error)
+			assert.Less(t, time.Since(start), 5*time.Second)
+		})
+	}
+}
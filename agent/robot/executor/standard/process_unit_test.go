@@ -0,0 +1,73 @@
+//go:build unit
+
+package standard_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/yao/agent/robot/executor/standard"
+	"github.com/yaoapp/yao/agent/robot/types"
+)
+
+func processRunner(resources *types.Resources) *standard.Runner {
+	robot := &types.Robot{MemberID: "robot_process", Config: &types.Config{Resources: resources}}
+	ctx := types.NewContext(context.Background(), nil)
+	return standard.NewRunner(ctx, robot, standard.DefaultRunConfig(), "", "exec_process")
+}
+
+func TestRunnerExecuteProcessTask(t *testing.T) {
+	process.Register("robotunit.report.echo", func(p *process.Process) interface{} { return p.Args })
+	process.Register("robotunit.report.panic", func(p *process.Process) interface{} { panic("boom") })
+	process.Register("robotunit.admin.drop", func(p *process.Process) interface{} { return "dropped" })
+
+	runner := processRunner(&types.Resources{ProcessNamespaces: []string{"robotunit.report"}})
+	previous := &standard.RunnerContext{PreviousResults: []types.TaskResult{
+		{TaskID: "task-001", Success: true, Output: map[string]interface{}{"id": float64(7), "name": "Q3"}},
+	}}
+
+	t.Run("arguments resolve earlier results", func(t *testing.T) {
+		task := &types.Task{ID: "task-002", ExecutorType: types.ExecutorProcess, ExecutorID: "robotunit.report.echo",
+			Args: []any{"{{results.task-001.output.id}}", "report {{ results.task-001.output.name }}", map[string]interface{}{"source": "{{results.task-001.output}}"}}}
+		result := runner.ExecuteTask(task, previous)
+		require.True(t, result.Success, result.Error)
+		assert.Equal(t, []interface{}{
+			float64(7),
+			"report Q3",
+			map[string]interface{}{"source": map[string]interface{}{"id": float64(7), "name": "Q3"}},
+		}, result.Output)
+	})
+
+	t.Run("unknown task reference fails the task", func(t *testing.T) {
+		task := &types.Task{ID: "task-003", ExecutorType: types.ExecutorProcess, ExecutorID: "robotunit.report.echo",
+			Args: []any{"{{results.task-999.output}}"}}
+		result := runner.ExecuteTask(task, previous)
+		assert.False(t, result.Success)
+		assert.Contains(t, result.Error, "task-999")
+	})
+
+	t.Run("panic fails the task", func(t *testing.T) {
+		task := &types.Task{ID: "task-004", ExecutorType: types.ExecutorProcess, ExecutorID: "robotunit.report.panic"}
+		result := runner.ExecuteTask(task, previous)
+		assert.False(t, result.Success)
+		assert.Contains(t, result.Error, "panicked: boom")
+	})
+
+	t.Run("process outside the allowed namespaces is refused", func(t *testing.T) {
+		task := &types.Task{ID: "task-005", ExecutorType: types.ExecutorProcess, ExecutorID: "robotunit.admin.drop"}
+		result := runner.ExecuteTask(task, previous)
+		assert.False(t, result.Success)
+		assert.Contains(t, result.Error, "not allowed")
+
+		listed := processRunner(&types.Resources{Processes: []types.ProcessConfig{{Name: "robotunit.admin.drop"}}})
+		result = listed.ExecuteTask(task, previous)
+		require.True(t, result.Success, result.Error)
+		assert.Equal(t, "dropped", result.Output)
+
+		result = processRunner(nil).ExecuteTask(task, previous)
+		assert.False(t, result.Success)
+	})
+}
@@ -0,0 +1,65 @@
+package standard
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	robottypes "github.com/yaoapp/yao/agent/robot/types"
+)
+
+// transientErrorMarkers are fragments of error messages returned by LLM providers
+// and the network stack for failures that usually succeed on a later attempt
+var transientErrorMarkers = []string{
+	"timeout",
+	"timed out",
+	"deadline exceeded",
+	"connection reset",
+	"connection refused",
+	"broken pipe",
+	"unexpected eof",
+	"temporarily unavailable",
+	"service unavailable",
+	"bad gateway",
+	"gateway timeout",
+	"rate limit",
+	"too many requests",
+	"overloaded",
+	"status 429",
+	"status 500",
+	"status 502",
+	"status 503",
+	"status 504",
+}
+
+// isRetryableError reports whether a failed phase may be retried. Only transient
+// LLM and network failures qualify; validation errors, missing configuration,
+// cancellation and suspension are final.
+func isRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, robottypes.ErrExecutionSuspended) ||
+		errors.Is(err, robottypes.ErrExecutionCancelled) ||
+		errors.Is(err, robottypes.ErrPhaseAgentNotFound) ||
+		errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, marker := range transientErrorMarkers {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,183 @@
+//go:build unit
+
+package standard_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mcpTypes "github.com/yaoapp/gou/mcp/types"
+	"github.com/yaoapp/yao/agent/robot/executor/standard"
+	executortypes "github.com/yaoapp/yao/agent/robot/executor/types"
+	"github.com/yaoapp/yao/agent/robot/types"
+)
+
+func TestIsRetryableErrorUnit(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{"nil", nil, false},
+		{"suspended", types.ErrExecutionSuspended, false},
+		{"cancelled", fmt.Errorf("phase run: %w", types.ErrExecutionCancelled), false},
+		{"context canceled", context.Canceled, false},
+		{"missing agent", types.ErrPhaseAgentNotFound, false},
+		{"validation", errors.New("no tasks to execute"), false},
+		{"invalid json", errors.New("failed to parse goals: invalid character '}'"), false},
+		{"deadline", fmt.Errorf("call agent: %w", context.DeadlineExceeded), true},
+		{"net error", &net.OpError{Op: "dial", Err: errors.New("refused")}, true},
+		{"rate limit", errors.New("LLM error: Rate limit reached, please retry"), true},
+		{"upstream 503", errors.New("request failed with status 503"), true},
+		{"connection reset", errors.New("read tcp: connection reset by peer"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.retryable, standard.IsRetryableErrorFn(tt.err))
+		})
+	}
+}
+
+func TestRetryPolicyUnit(t *testing.T) {
+	config := executortypes.Config{
+		PhaseRetry: executortypes.RetryPolicy{MaxRetries: 2, Backoff: time.Second},
+		PhaseRetries: map[types.Phase]executortypes.RetryPolicy{
+			types.PhaseDelivery: {MaxRetries: 5},
+		},
+	}
+
+	assert.Equal(t, 2, config.RetryPolicyFor(types.PhaseGoals).MaxRetries)
+	assert.Equal(t, 5, config.RetryPolicyFor(types.PhaseDelivery).MaxRetries)
+	assert.Equal(t, 0, (&executortypes.Config{}).RetryPolicyFor(types.PhaseRun).MaxRetries, "no retries by default")
+
+	policy := config.PhaseRetry
+	assert.Equal(t, time.Second, policy.Delay(1))
+	assert.Equal(t, 2*time.Second, policy.Delay(2))
+	assert.Equal(t, 4*time.Second, policy.Delay(3))
+	assert.Equal(t, time.Hour, policy.Delay(100))
+	assert.Equal(t, time.Duration(0), config.RetryPolicyFor(types.PhaseDelivery).Delay(1))
+}
+
+func TestPhaseRetryExecutionUnit(t *testing.T) {
+	newExecutor := func(maxRetries int) *standard.Executor {
+		return standard.NewWithConfig(executortypes.Config{
+			SkipPersistence: true,
+			PhaseRetry:      executortypes.RetryPolicy{MaxRetries: maxRetries, Backoff: time.Millisecond},
+		})
+	}
+	newRobot := func() *types.Robot {
+		return &types.Robot{MemberID: "robot_retry", TeamID: "team_retry", Config: &types.Config{}}
+	}
+	ctx := types.NewContext(context.Background(), nil)
+
+	t.Run("phase failing twice then succeeding completes the execution", func(t *testing.T) {
+		e := newExecutor(2)
+		attempts := 0
+		standard.SetPhaseFunc(e, func(ctx *types.Context, exec *types.Execution, phase types.Phase, data interface{}) error {
+			if phase != types.PhaseGoals {
+				return nil
+			}
+			attempts++
+			if attempts <= 2 {
+				return errors.New("LLM error: service unavailable")
+			}
+			return nil
+		})
+
+		exec, err := e.Execute(ctx, newRobot(), types.TriggerHuman, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, types.ExecCompleted, exec.Status)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("retries run out", func(t *testing.T) {
+		e := newExecutor(1)
+		attempts := 0
+		standard.SetPhaseFunc(e, func(ctx *types.Context, exec *types.Execution, phase types.Phase, data interface{}) error {
+			if phase != types.PhaseGoals {
+				return nil
+			}
+			attempts++
+			return errors.New("LLM error: service unavailable")
+		})
+
+		exec, err := e.Execute(ctx, newRobot(), types.TriggerHuman, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, types.ExecFailed, exec.Status)
+		assert.Equal(t, 2, attempts, "first attempt and one retry")
+	})
+
+	t.Run("suspension is not retried", func(t *testing.T) {
+		e := newExecutor(3)
+		attempts := 0
+		standard.SetPhaseFunc(e, func(ctx *types.Context, exec *types.Execution, phase types.Phase, data interface{}) error {
+			if phase != types.PhaseGoals {
+				return nil
+			}
+			attempts++
+			return types.ErrExecutionSuspended
+		})
+
+		_, err := e.Execute(ctx, newRobot(), types.TriggerHuman, nil)
+		assert.ErrorIs(t, err, types.ErrExecutionSuspended)
+		assert.Equal(t, 1, attempts)
+	})
+}
+
+func TestRunPhaseRetryContinuesAtFailedTaskUnit(t *testing.T) {
+	calls := map[string]int{}
+	restore := standard.SetMCPCall(func(ctx context.Context, server, tool string, args interface{}) (*mcpTypes.CallToolResponse, error) {
+		calls[tool]++
+		if tool == "flaky" && calls[tool] == 1 {
+			return nil, errors.New("read tcp: connection reset by peer")
+		}
+		return textResponse(tool + " done"), nil
+	})
+	defer restore()
+
+	e := standard.NewWithConfig(executortypes.Config{
+		SkipPersistence: true,
+		PhaseRetries: map[types.Phase]executortypes.RetryPolicy{
+			types.PhaseRun: {MaxRetries: 2, Backoff: time.Millisecond},
+		},
+	})
+	standard.SetPhaseFunc(e, func(ctx *types.Context, exec *types.Execution, phase types.Phase, data interface{}) error {
+		switch phase {
+		case types.PhaseTasks:
+			exec.Tasks = []types.Task{
+				{ID: "t1", ExecutorType: types.ExecutorMCP, MCPServer: "mail", MCPTool: "send", Status: types.TaskPending},
+				{ID: "t2", ExecutorType: types.ExecutorMCP, MCPServer: "mail", MCPTool: "flaky", Status: types.TaskPending},
+				{ID: "t3", ExecutorType: types.ExecutorMCP, MCPServer: "mail", MCPTool: "notify", Status: types.TaskPending},
+			}
+		case types.PhaseRun:
+			return standard.RunPhaseLogic(e, ctx, exec, phase, data)
+		}
+		return nil
+	})
+
+	robot := &types.Robot{MemberID: "robot_run_retry", TeamID: "team_retry", Config: &types.Config{
+		Resources: &types.Resources{MCP: []types.MCPConfig{{ID: "mail"}}},
+	}}
+	exec, err := e.Execute(types.NewContext(context.Background(), nil), robot, types.TriggerHuman, nil)
+	require.NoError(t, err)
+	assert.Equal(t, types.ExecCompleted, exec.Status)
+
+	assert.Equal(t, 1, calls["send"], "a task completed before the failure is not run again")
+	assert.Equal(t, 2, calls["flaky"], "the failed task is retried")
+	assert.Equal(t, 1, calls["notify"])
+
+	require.Len(t, exec.Results, 3, "results of failed attempts are not kept")
+	for i, result := range exec.Results {
+		assert.Equal(t, exec.Tasks[i].ID, result.TaskID)
+		assert.True(t, result.Success, result.Error)
+	}
+	for _, task := range exec.Tasks {
+		assert.Equal(t, types.TaskCompleted, task.Status)
+	}
+}
@@ -0,0 +1,91 @@
+package standard
+
+import (
+	"encoding/json"
+	"strings"
+
+	kunlog "github.com/yaoapp/kun/log"
+	robottypes "github.com/yaoapp/yao/agent/robot/types"
+)
+
+// TaskClassifier picks one of the candidate agents for a task; "" when undecided
+type TaskClassifier func(task *robottypes.Task, candidates []string) string
+
+// RouteTaskAgents assigns an agent to every assistant task the planner left without
+// an executor_id. Routing rules are tried first, then the classifier, and a robot
+// with a single agent uses it. Tasks with an explicit executor_id are never changed.
+func RouteTaskAgents(tasks []robottypes.Task, robot *robottypes.Robot, classify TaskClassifier) {
+	if robot == nil || robot.Config == nil || robot.Config.Resources == nil || robot.Config.Resources.Routing == nil {
+		return
+	}
+	resources := robot.Config.Resources
+
+	for i := range tasks {
+		task := &tasks[i]
+		if task.ExecutorType != robottypes.ExecutorAssistant || task.ExecutorID != "" {
+			continue
+		}
+
+		source := "rule"
+		agent := resources.Routing.Match(task, resources.Agents)
+		if agent == "" && classify != nil && len(resources.Agents) > 1 {
+			source = "classifier"
+			agent = classify(task, resources.Agents)
+		}
+		if agent == "" && len(resources.Agents) == 1 {
+			source = "only agent"
+			agent = resources.Agents[0]
+		}
+		if agent == "" {
+			continue
+		}
+
+		task.ExecutorID = agent
+		kunlog.Trace("[routing] task %s -> %s (%s)", task.ID, agent, source)
+	}
+}
+
+// classifyTask asks the routing classifier agent which candidate fits the task best.
+// The agent answers {"agent": "<id>"}; anything outside the candidates is ignored.
+func (e *Executor) classifyTask(ctx *robottypes.Context, exec *robottypes.Execution, robot *robottypes.Robot) TaskClassifier {
+	agentID := robot.Config.Resources.Routing.Agent
+	if agentID == "" {
+		return nil
+	}
+
+	return func(task *robottypes.Task, candidates []string) string {
+		input, err := json.Marshal(map[string]interface{}{
+			"task": map[string]interface{}{
+				"id":              task.ID,
+				"description":     task.Description,
+				"tags":            task.Tags,
+				"expected_output": task.ExpectedOutput,
+			},
+			"candidates": candidates,
+		})
+		if err != nil {
+			return ""
+		}
+
+		caller := NewAgentCaller()
+		caller.log = newExecLogger(robot, exec.ID)
+		result, err := caller.CallWithMessages(ctx, agentID, string(input))
+		if err != nil {
+			kunlog.Warn("[routing] classifier (%s) failed for task %s: %v", agentID, task.ID, err)
+			return ""
+		}
+		data, err := result.GetJSON()
+		if err != nil {
+			kunlog.Warn("[routing] classifier (%s) returned invalid JSON for task %s: %v", agentID, task.ID, err)
+			return ""
+		}
+
+		picked, _ := data["agent"].(string)
+		for _, candidate := range candidates {
+			if strings.EqualFold(candidate, strings.TrimSpace(picked)) {
+				return candidate
+			}
+		}
+		return ""
+	}
+}
@@ -0,0 +1,62 @@
+//go:build unit
+
+package standard_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/yao/agent/robot/executor/standard"
+	"github.com/yaoapp/yao/agent/robot/types"
+)
+
+func TestRouteTaskAgentsUnit(t *testing.T) {
+	robot := &types.Robot{
+		MemberID: "test-routing",
+		Config: &types.Config{
+			Resources: &types.Resources{
+				Agents: []string{"experts.finance", "experts.writer"},
+				Routing: &types.AgentRouting{
+					Rules: []types.RoutingRule{{Agent: "experts.finance", Tags: []string{"finance"}}},
+				},
+			},
+		},
+	}
+
+	tasks := []types.Task{
+		{ID: "t1", ExecutorType: types.ExecutorAssistant, Tags: []string{"finance"}},
+		{ID: "t2", ExecutorType: types.ExecutorAssistant, Description: "Write it up"},
+		{ID: "t3", ExecutorType: types.ExecutorAssistant, ExecutorID: "experts.writer", Tags: []string{"finance"}},
+		{ID: "t4", ExecutorType: types.ExecutorMCP, MCPServer: "search"},
+	}
+
+	var classified []string
+	standard.RouteTaskAgents(tasks, robot, func(task *types.Task, candidates []string) string {
+		classified = append(classified, task.ID)
+		assert.Equal(t, robot.Config.Resources.Agents, candidates)
+		return "experts.writer"
+	})
+
+	assert.Equal(t, "experts.finance", tasks[0].ExecutorID, "rule match")
+	assert.Equal(t, "experts.writer", tasks[1].ExecutorID, "classifier")
+	assert.Equal(t, "experts.writer", tasks[2].ExecutorID, "explicit executor_id is kept")
+	assert.Empty(t, tasks[3].ExecutorID, "only agent tasks are routed")
+	assert.Equal(t, []string{"t2"}, classified)
+
+	t.Run("single agent", func(t *testing.T) {
+		single := &types.Robot{Config: &types.Config{Resources: &types.Resources{
+			Agents:  []string{"experts.writer"},
+			Routing: &types.AgentRouting{},
+		}}}
+		tasks := []types.Task{{ID: "t1", ExecutorType: types.ExecutorAssistant}}
+		standard.RouteTaskAgents(tasks, single, nil)
+		assert.Equal(t, "experts.writer", tasks[0].ExecutorID)
+	})
+
+	t.Run("routing not configured", func(t *testing.T) {
+		plain := &types.Robot{Config: &types.Config{Resources: &types.Resources{Agents: []string{"experts.writer"}}}}
+		tasks := []types.Task{{ID: "t1", ExecutorType: types.ExecutorAssistant}}
+		standard.RouteTaskAgents(tasks, plain, nil)
+		assert.Empty(t, tasks[0].ExecutorID)
+	})
+}
@@ -98,6 +98,30 @@ func (e *Executor) RunExecution(ctx *robottypes.Context, exec *robottypes.Execut
 			Progress:  fmt.Sprintf("%d/%d tasks", i+1, len(exec.Tasks)),
 		}
 
+		// Conditional tasks are skipped before approval: nothing runs, nothing to approve
+		if task.Condition != "" {
+			run, err := EvaluateCondition(task.Condition, exec.Results)
+			if err != nil || !run {
+				result := e.skipTask(ctx, exec, task, err)
+				runner.writeTaskOutput(task, result, "")
+				if !result.Success && !config.ContinueOnFailure {
+					for j := i + 1; j < len(exec.Tasks); j++ {
+						exec.Tasks[j].Status = robottypes.TaskSkipped
+					}
+					e.updateTasksState(ctx, exec)
+					return fmt.Errorf("task %s failed: %s", task.ID, result.Error)
+				}
+				continue
+			}
+		}
+
+		// Sensitive tasks wait for explicit human approval, even in autonomous mode
+		if task.ApprovedAt == nil && robot.Config.RequiresApproval(task) {
+			question := fmt.Sprintf(getLocalizedMessage(runner.locale, "approval_question"),
+				formatTaskProgressName(task, i, len(exec.Tasks), runner.locale))
+			return e.suspend(ctx, exec, i, question, task.ID)
+		}
+
 		// Update UI field with current task description (i18n)
 		taskName := formatTaskProgressName(task, i, len(exec.Tasks), runner.locale)
 		e.updateUIFields(ctx, exec, "", taskName)
@@ -133,6 +157,7 @@ func (e *Executor) RunExecution(ctx *robottypes.Context, exec *robottypes.Execut
 				TeamID:      exec.TeamID,
 				TaskID:      task.ID,
 				ChatID:      exec.ChatID,
+				Output:      result.Output,
 			})
 		} else {
 			task.Status = robottypes.TaskFailed
@@ -174,6 +199,62 @@ func (e *Executor) RunExecution(ctx *robottypes.Context, exec *robottypes.Execut
 	return nil
 }
 
+// prepareRunRetry sets up a retry of the Run phase to continue at the task that failed,
+// through the resume context RunExecution already honours: tasks that completed keep
+// their results and never run again, and results do not pile up across attempts.
+// The failed task is the last recorded result when it did not succeed; a failure that
+// left no result (a task cut off by a deadline) continues at the next unrecorded task.
+func prepareRunRetry(exec *robottypes.Execution) {
+	index := len(exec.Results)
+	if index > 0 && !exec.Results[index-1].Success {
+		index--
+	}
+	if index > len(exec.Tasks) {
+		index = len(exec.Tasks)
+	}
+
+	previous := make([]robottypes.TaskResult, index)
+	copy(previous, exec.Results[:index])
+	exec.ResumeContext = &robottypes.ResumeContext{TaskIndex: index, PreviousResults: previous}
+
+	for i := index; i < len(exec.Tasks); i++ {
+		exec.Tasks[i].Status = robottypes.TaskPending
+		exec.Tasks[i].StartTime = nil
+		exec.Tasks[i].EndTime = nil
+	}
+}
+
+// skipTask records a task whose condition did not hold. A condition that cannot be
+// evaluated fails the task instead, so a broken plan is visible rather than silently shorter.
+func (e *Executor) skipTask(ctx *robottypes.Context, exec *robottypes.Execution, task *robottypes.Task, condErr error) *robottypes.TaskResult {
+	now := time.Now()
+	task.StartTime = &now
+	task.EndTime = &now
+
+	result := &robottypes.TaskResult{TaskID: task.ID}
+	if condErr != nil {
+		task.Status = robottypes.TaskFailed
+		result.Error = fmt.Sprintf("invalid condition %q: %s", task.Condition, condErr.Error())
+		event.Push(ctx.Context, robotevents.TaskFailed, robotevents.TaskPayload{
+			ExecutionID: exec.ID,
+			MemberID:    exec.MemberID,
+			TeamID:      exec.TeamID,
+			TaskID:      task.ID,
+			Error:       result.Error,
+			ChatID:      exec.ChatID,
+		})
+	} else {
+		task.Status = robottypes.TaskSkipped
+		result.Success = true
+		result.Skipped = true
+	}
+
+	// Results stay aligned with tasks so later conditions and resume see every task
+	exec.Results = append(exec.Results, *result)
+	e.updateTasksState(ctx, exec)
+	return result
+}
+
 // formatTaskProgressName formats a progress name for the current task (used for UI with i18n)
 func formatTaskProgressName(task *robottypes.Task, index int, total int, locale string) string {
 	taskPrefix := getLocalizedMessage(locale, "task_prefix")
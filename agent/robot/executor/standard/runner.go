@@ -1,16 +1,22 @@
 package standard
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/yaoapp/gou/mcp"
+	mcpTypes "github.com/yaoapp/gou/mcp/types"
 	"github.com/yaoapp/gou/process"
 	kunlog "github.com/yaoapp/kun/log"
 	agentcontext "github.com/yaoapp/yao/agent/context"
 	"github.com/yaoapp/yao/agent/llm"
+	"github.com/yaoapp/yao/agent/robot/capability"
 	robottypes "github.com/yaoapp/yao/agent/robot/types"
 	taiworkspace "github.com/yaoapp/yao/tai/workspace"
 )
@@ -194,7 +200,14 @@ func (r *Runner) executeAssistantTask(task *robottypes.Task, taskCtx *RunnerCont
 
 	r.log.logTaskInput(task, input, caller.Connector)
 
-	result, err := caller.CallWithMessages(r.ctx, task.ExecutorID, input)
+	// Capability descriptor goes into the system context of every task agent
+	var result *CallResult
+	var err error
+	if block := capability.Format(capability.Build(r.ctx, r.robot)); block != "" {
+		result, err = caller.CallWithSystemAndUser(r.ctx, task.ExecutorID, block, input)
+	} else {
+		result, err = caller.CallWithMessages(r.ctx, task.ExecutorID, input)
+	}
 	if err != nil {
 		return nil, nil, fmt.Errorf("assistant call failed: %w", err)
 	}
@@ -248,50 +261,186 @@ func (r *Runner) extractOutput(result *CallResult) interface{} {
 	return nil
 }
 
-// ExecuteMCPTask executes a task using an MCP tool
-// Requires task.MCPServer and task.MCPTool fields to be set
-// executor_id is the combined form: "mcp_server.mcp_tool" (e.g., "ark.image.text2img.generate")
+// mcpToolCaller is the part of an MCP client a task needs
+type mcpToolCaller interface {
+	CallTool(ctx context.Context, name string, arguments interface{}, extraArgs ...interface{}) (*mcpTypes.CallToolResponse, error)
+}
+
+// selectMCPClient returns the MCP client registered under id (replaced in tests)
+var selectMCPClient = func(id string) (mcpToolCaller, error) {
+	return mcp.Select(id)
+}
+
+// ExecuteMCPTask executes a task using an MCP tool of one of the robot's mcp_servers.
+// The server and tool come from task.MCPServer and task.MCPTool, or from executor_id in
+// the combined form "mcp_server.mcp_tool" (e.g., "ark.image.text2img.generate").
+// The call is bounded by executor.tool_timeout; a tool that reports an error fails the task.
 func (r *Runner) ExecuteMCPTask(task *robottypes.Task, taskCtx *RunnerContext) (interface{}, error) {
-	// Validate MCP-specific fields
-	if task.MCPServer == "" || task.MCPTool == "" {
-		return nil, fmt.Errorf("MCP task requires mcp_server and mcp_tool fields (executor_id: %s)", task.ExecutorID)
+	server, tool, err := r.resolveMCPTool(task)
+	if err != nil {
+		return nil, err
 	}
 
-	// Get MCP client
-	client, err := mcp.Select(task.MCPServer)
+	client, err := selectMCPClient(server)
+	if err != nil {
+		return nil, fmt.Errorf("MCP server not found: %s: %w", server, err)
+	}
+
+	args := r.buildMCPArguments(task, taskCtx)
+
+	var executorConfig *robottypes.ExecutorConfig
+	if r.robot.Config != nil {
+		executorConfig = r.robot.Config.Executor
+	}
+	timeout := executorConfig.GetToolTimeout()
+	callCtx, cancel := context.WithTimeout(r.ctx.Context, timeout)
+	defer cancel()
+
+	kunlog.Trace("[robot-runner] ExecuteMCPTask: task=%s tool=%s.%s args=%d", task.ID, server, tool, len(args))
+
+	resp, err := client.CallTool(callCtx, tool, args)
 	if err != nil {
-		return nil, fmt.Errorf("MCP server not found: %s: %w", task.MCPServer, err)
+		if errors.Is(callCtx.Err(), context.DeadlineExceeded) && r.ctx.Context.Err() == nil {
+			return nil, fmt.Errorf("MCP tool call timed out after %s (%s.%s)", timeout, server, tool)
+		}
+		return nil, fmt.Errorf("MCP tool call failed (%s.%s): %w", server, tool, err)
+	}
+	if resp == nil {
+		return nil, fmt.Errorf("MCP tool returned no result (%s.%s)", server, tool)
 	}
 
-	// Build arguments map from task.Args
-	args := make(map[string]interface{})
+	output := mcpToolOutput(resp)
+	if resp.IsError {
+		return nil, fmt.Errorf("MCP tool returned an error (%s.%s): %v", server, tool, output)
+	}
+	return output, nil
+}
+
+// resolveMCPTool finds the server and tool of an MCP task among the robot's configured
+// MCP servers. A server whose tool list is set only allows those tools.
+func (r *Runner) resolveMCPTool(task *robottypes.Task) (string, string, error) {
+	var configured []robottypes.MCPConfig
+	if r.robot.Config != nil && r.robot.Config.Resources != nil {
+		configured = r.robot.Config.Resources.MCP
+	}
+
+	server, tool := task.MCPServer, task.MCPTool
+	if server == "" || tool == "" {
+		// Server IDs contain dots too: take the longest configured ID the executor_id starts with
+		for _, cfg := range configured {
+			if strings.HasPrefix(task.ExecutorID, cfg.ID+".") && len(cfg.ID) > len(server) {
+				server = cfg.ID
+				tool = strings.TrimPrefix(task.ExecutorID, cfg.ID+".")
+			}
+		}
+	}
+	if server == "" || tool == "" {
+		return "", "", fmt.Errorf("MCP task requires mcp_server and mcp_tool fields (executor_id: %s)", task.ExecutorID)
+	}
+
+	for _, cfg := range configured {
+		if cfg.ID != server {
+			continue
+		}
+		if len(cfg.Tools) == 0 {
+			return server, tool, nil
+		}
+		for _, allowed := range cfg.Tools {
+			if allowed == tool {
+				return server, tool, nil
+			}
+		}
+		return "", "", fmt.Errorf("MCP tool %s is not allowed on server %s for this robot", tool, server)
+	}
+	return "", "", fmt.Errorf("MCP server %s is not configured for this robot", server)
+}
+
+// buildMCPArguments derives the tool arguments of a task. Planned arguments (task.Args)
+// are used as given: a map is the argument object, anything else becomes {"input": value}.
+// Without planned arguments the task messages are used: a JSON object as the arguments,
+// other text as {"input": text} with the previous results prepended, as assistant tasks get them.
+func (r *Runner) buildMCPArguments(task *robottypes.Task, taskCtx *RunnerContext) map[string]interface{} {
 	if len(task.Args) > 0 {
-		// First argument should be a map of tool arguments
 		if argsMap, ok := task.Args[0].(map[string]interface{}); ok {
-			args = argsMap
-		} else {
-			// If not a map, try to convert single argument
-			args["input"] = task.Args[0]
+			return argsMap
 		}
+		return map[string]interface{}{"input": task.Args[0]}
 	}
 
-	// Call MCP tool
-	result, err := client.CallTool(r.ctx.Context, task.MCPTool, args)
-	if err != nil {
-		return nil, fmt.Errorf("MCP tool call failed (%s.%s): %w", task.MCPServer, task.MCPTool, err)
+	instructions := strings.TrimSpace(r.FormatMessagesAsText(task.Messages))
+	if instructions == "" {
+		return map[string]interface{}{}
+	}
+	var args map[string]interface{}
+	if err := json.Unmarshal([]byte(instructions), &args); err == nil && args != nil {
+		return args
 	}
 
-	return result, nil
+	if taskCtx == nil {
+		taskCtx = &RunnerContext{}
+	}
+	input := strings.TrimSpace(r.FormatMessagesAsText(r.BuildAssistantMessages(task, taskCtx)))
+	return map[string]interface{}{"input": input}
+}
+
+// mcpToolOutput turns a tool response into a task output: the text of a single text
+// content (decoded when it is JSON), the joined text of several, or the raw contents
+func mcpToolOutput(resp *mcpTypes.CallToolResponse) interface{} {
+	var texts []string
+	for _, content := range resp.Content {
+		if content.Type == mcpTypes.ToolContentTypeText {
+			texts = append(texts, content.Text)
+		}
+	}
+	if len(texts) == 0 || len(texts) != len(resp.Content) {
+		return resp.Content
+	}
+
+	text := strings.Join(texts, "\n")
+	var decoded interface{}
+	if len(texts) == 1 && json.Unmarshal([]byte(text), &decoded) == nil {
+		return decoded
+	}
+	return text
 }
 
 // ExecuteProcessTask executes a task using a Yao process
-// ExecutorID is the process name (e.g., "models.user.Find", "scripts.myScript.Run")
-func (r *Runner) ExecuteProcessTask(task *robottypes.Task, taskCtx *RunnerContext) (interface{}, error) {
+// ExecutorID is the process name (e.g., "models.user.Find", "scripts.myScript.Run"); only
+// processes the robot's resources allow can be called. String arguments may reference
+// earlier results, e.g. "{{results.task-001.output.id}}" (see resolveArgTemplates).
+// A panicking process fails the task instead of the executor.
+func (r *Runner) ExecuteProcessTask(task *robottypes.Task, taskCtx *RunnerContext) (output interface{}, err error) {
+	var resources *robottypes.Resources
+	if r.robot.Config != nil {
+		resources = r.robot.Config.Resources
+	}
+	if !resources.AllowsProcess(task.ExecutorID) {
+		return nil, fmt.Errorf("process %s is not allowed for this robot", task.ExecutorID)
+	}
+
+	var previous []robottypes.TaskResult
+	if taskCtx != nil {
+		previous = taskCtx.PreviousResults
+	}
+	args := make([]interface{}, len(task.Args))
+	for i, arg := range task.Args {
+		if args[i], err = resolveArgTemplates(arg, previous); err != nil {
+			return nil, fmt.Errorf("process argument %d: %w", i, err)
+		}
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			output, err = nil, fmt.Errorf("process %s panicked: %v", task.ExecutorID, rec)
+		}
+	}()
+
 	// Create process with task arguments
-	proc, err := process.Of(task.ExecutorID, task.Args...)
+	proc, err := process.Of(task.ExecutorID, args...)
 	if err != nil {
 		return nil, fmt.Errorf("process creation failed: %w", err)
 	}
+	defer proc.Release()
 
 	// Set context for timeout and cancellation
 	proc.Context = r.ctx.Context
@@ -300,12 +449,83 @@ func (r *Runner) ExecuteProcessTask(task *robottypes.Task, taskCtx *RunnerContex
 	if err := proc.Execute(); err != nil {
 		return nil, fmt.Errorf("process execution failed: %w", err)
 	}
-	defer proc.Release()
 
 	// Return the result
 	return proc.Value(), nil
 }
 
+// argTemplate matches a reference to an earlier result, e.g. {{results.task-001.output}}
+var argTemplate = regexp.MustCompile(`\{\{\s*results\.([A-Za-z0-9_.\-]+)\s*\}\}`)
+
+// resolveArgTemplates replaces result references in string arguments, walking into maps
+// and lists. The path after "results." is a condition path (see EvaluateCondition). A
+// string that is a single reference takes the referenced value as is; references inside
+// longer text are replaced by the value as text (JSON for maps and lists).
+func resolveArgTemplates(arg interface{}, results []robottypes.TaskResult) (interface{}, error) {
+	switch v := arg.(type) {
+	case string:
+		if m := argTemplate.FindStringSubmatch(v); m != nil && m[0] == strings.TrimSpace(v) {
+			return resolveResultPath(m[1], results)
+		}
+		var resolveErr error
+		resolved := argTemplate.ReplaceAllStringFunc(v, func(ref string) string {
+			value, err := resolveResultPath(argTemplate.FindStringSubmatch(ref)[1], results)
+			if err != nil {
+				resolveErr = err
+				return ref
+			}
+			return formatTemplateValue(value)
+		})
+		return resolved, resolveErr
+
+	case map[string]interface{}:
+		resolved := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			value, err := resolveArgTemplates(item, results)
+			if err != nil {
+				return nil, err
+			}
+			resolved[key] = value
+		}
+		return resolved, nil
+
+	case []interface{}:
+		resolved := make([]interface{}, len(v))
+		for i, item := range v {
+			value, err := resolveArgTemplates(item, results)
+			if err != nil {
+				return nil, err
+			}
+			resolved[i] = value
+		}
+		return resolved, nil
+	}
+	return arg, nil
+}
+
+// resolveResultPath looks up "<task_id>.<field>..." in the results of earlier tasks
+func resolveResultPath(path string, results []robottypes.TaskResult) (interface{}, error) {
+	p := &conditionParser{results: results}
+	return p.resolvePath(conditionToken{kind: tokenPath, text: path})
+}
+
+// formatTemplateValue renders a referenced value inside text
+func formatTemplateValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]interface{}, []interface{}:
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(raw)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
 // BuildAssistantMessages builds messages for an assistant task
 func (r *Runner) BuildAssistantMessages(task *robottypes.Task, taskCtx *RunnerContext) []agentcontext.Message {
 	messages := make([]agentcontext.Message, 0)
@@ -355,36 +575,132 @@ func (r *Runner) FormatMessagesAsText(messages []agentcontext.Message) string {
 	return result
 }
 
-// FormatPreviousResultsAsContext formats previous task results as context
+// FormatPreviousResultsAsContext formats previous task results as context.
+// The robot's executor.previous_results strategy decides how much of each result is
+// kept; the default (full) includes every output verbatim.
 func (r *Runner) FormatPreviousResultsAsContext(results []robottypes.TaskResult) string {
 	if len(results) == 0 {
 		return ""
 	}
 
+	var executorConfig *robottypes.ExecutorConfig
+	if r.robot != nil && r.robot.Config != nil {
+		executorConfig = r.robot.Config.Executor
+	}
+	cfg := executorConfig.GetPreviousResults()
+
+	blocks := make([]string, len(results))
+	omitted := 0
+	switch cfg.Strategy {
+	case robottypes.ResultsSummary:
+		for i := range results {
+			blocks[i] = formatResultSummary(&results[i])
+		}
+
+	case robottypes.ResultsLastN:
+		omitted = len(results) - cfg.LastN
+		if omitted < 0 {
+			omitted = 0
+		}
+		for i := omitted; i < len(results); i++ {
+			blocks[i] = formatResultFull(&results[i])
+		}
+
+	case robottypes.ResultsTokenBudget:
+		// Newest results are the most relevant: spend the budget from the end,
+		// summarizing a result that no longer fits and dropping everything older
+		// once not even the summary fits.
+		budget := cfg.TokenBudget
+		for i := len(results) - 1; i >= 0; i-- {
+			full := formatResultFull(&results[i])
+			if tokens := estimateTokens(full); tokens <= budget {
+				blocks[i] = full
+				budget -= tokens
+				continue
+			}
+			summary := formatResultSummary(&results[i])
+			if tokens := estimateTokens(summary); tokens <= budget {
+				blocks[i] = summary
+				budget -= tokens
+				continue
+			}
+			omitted = i + 1
+			break
+		}
+
+	default:
+		for i := range results {
+			blocks[i] = formatResultFull(&results[i])
+		}
+	}
+
 	var sb strings.Builder
 	sb.WriteString("## Previous Task Results\n\n")
 	sb.WriteString("The following tasks have been completed. Use their results as needed:\n\n")
+	if omitted > 0 {
+		sb.WriteString(fmt.Sprintf("_%d earlier task result(s) omitted to fit the context._\n\n", omitted))
+	}
+	for _, block := range blocks {
+		if block != "" {
+			sb.WriteString(block)
+		}
+	}
 
-	for _, result := range results {
-		sb.WriteString(fmt.Sprintf("### Task: %s\n", result.TaskID))
-		if result.Success {
-			sb.WriteString("- Status: ✓ Success\n")
+	contextLen := sb.Len()
+	kunlog.Trace("[robot-runner] FormatPreviousResultsAsContext: strategy=%s results=%d omitted=%d totalLen=%d", cfg.Strategy, len(results), omitted, contextLen)
+	return sb.String()
+}
+
+// resultSummaryLen is the output preview length of a summarized result
+const resultSummaryLen = 300
+
+// formatResultFull renders a task result with its complete output
+func formatResultFull(result *robottypes.TaskResult) string {
+	var sb strings.Builder
+	sb.WriteString(resultHeader(result))
+	if result.Output != nil {
+		outputJSON, err := json.MarshalIndent(result.Output, "", "  ")
+		if err == nil {
+			sb.WriteString(fmt.Sprintf("- Output:\n```json\n%s\n```\n", string(outputJSON)))
 		} else {
-			sb.WriteString("- Status: ✗ Failed\n")
+			sb.WriteString(fmt.Sprintf("- Output: %v\n", result.Output))
 		}
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
 
-		if result.Output != nil {
-			outputJSON, err := json.MarshalIndent(result.Output, "", "  ")
-			if err == nil {
-				sb.WriteString(fmt.Sprintf("- Output:\n```json\n%s\n```\n", string(outputJSON)))
+// formatResultSummary renders a task result with its status and a short output preview
+func formatResultSummary(result *robottypes.TaskResult) string {
+	var sb strings.Builder
+	sb.WriteString(resultHeader(result))
+	if !result.Success && result.Error != "" {
+		sb.WriteString(fmt.Sprintf("- Error: %s\n", truncateSummary(result.Error, resultSummaryLen)))
+	}
+	if result.Output != nil {
+		text, ok := result.Output.(string)
+		if !ok {
+			if raw, err := json.Marshal(result.Output); err == nil {
+				text = string(raw)
 			} else {
-				sb.WriteString(fmt.Sprintf("- Output: %v\n", result.Output))
+				text = fmt.Sprintf("%v", result.Output)
 			}
 		}
-		sb.WriteString("\n")
+		sb.WriteString(fmt.Sprintf("- Output (preview): %s\n", truncateSummary(strings.TrimSpace(text), resultSummaryLen)))
 	}
-
-	contextLen := sb.Len()
-	kunlog.Trace("[robot-runner] FormatPreviousResultsAsContext: results=%d totalLen=%d", len(results), contextLen)
+	sb.WriteString("\n")
 	return sb.String()
 }
+
+func resultHeader(result *robottypes.TaskResult) string {
+	status := "- Status: ✓ Success\n"
+	if !result.Success {
+		status = "- Status: ✗ Failed\n"
+	}
+	return fmt.Sprintf("### Task: %s\n", result.TaskID) + status
+}
+
+// estimateTokens approximates the token count of s (about four characters per token)
+func estimateTokens(s string) int {
+	return (utf8.RuneCountInString(s) + 3) / 4
+}
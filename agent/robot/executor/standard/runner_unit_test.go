@@ -0,0 +1,65 @@
+//go:build unit
+
+package standard_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/yao/agent/robot/executor/standard"
+	"github.com/yaoapp/yao/agent/robot/types"
+)
+
+func resultsRunner(cfg *types.PreviousResultsConfig) *standard.Runner {
+	robot := &types.Robot{MemberID: "robot_results", Config: &types.Config{
+		Executor: &types.ExecutorConfig{PreviousResults: cfg},
+	}}
+	return standard.NewRunner(nil, robot, standard.DefaultRunConfig(), "", "exec_results")
+}
+
+func TestFormatPreviousResultsStrategies(t *testing.T) {
+	long := strings.Repeat("word ", 400)
+	results := []types.TaskResult{
+		{TaskID: "task-001", Success: true, Output: long},
+		{TaskID: "task-002", Success: false, Error: "boom", Output: "partial"},
+		{TaskID: "task-003", Success: true, Output: map[string]interface{}{"count": 42}},
+	}
+
+	t.Run("full by default", func(t *testing.T) {
+		text := resultsRunner(nil).FormatPreviousResultsAsContext(results)
+		assert.Contains(t, text, long)
+		assert.Contains(t, text, `"count": 42`)
+		assert.NotContains(t, text, "omitted")
+	})
+
+	t.Run("summary", func(t *testing.T) {
+		text := resultsRunner(&types.PreviousResultsConfig{Strategy: types.ResultsSummary}).FormatPreviousResultsAsContext(results)
+		assert.NotContains(t, text, long)
+		assert.Contains(t, text, "- Output (preview): word word")
+		assert.Contains(t, text, "- Error: boom")
+		assert.Contains(t, text, `{"count":42}`)
+	})
+
+	t.Run("last_n", func(t *testing.T) {
+		text := resultsRunner(&types.PreviousResultsConfig{Strategy: types.ResultsLastN, LastN: 2}).FormatPreviousResultsAsContext(results)
+		assert.NotContains(t, text, "task-001")
+		assert.Contains(t, text, "task-002")
+		assert.Contains(t, text, "task-003")
+		assert.Contains(t, text, "1 earlier task result(s) omitted")
+	})
+
+	t.Run("token_budget summarizes then drops the oldest", func(t *testing.T) {
+		text := resultsRunner(&types.PreviousResultsConfig{Strategy: types.ResultsTokenBudget, TokenBudget: 120}).FormatPreviousResultsAsContext(results)
+		assert.Contains(t, text, `"count": 42`, "newest result kept verbatim")
+		assert.Contains(t, text, "task-002")
+		assert.NotContains(t, text, long)
+		assert.Contains(t, text, "1 earlier task result(s) omitted")
+	})
+
+	t.Run("token_budget keeps everything that fits", func(t *testing.T) {
+		text := resultsRunner(&types.PreviousResultsConfig{Strategy: types.ResultsTokenBudget, TokenBudget: 100000}).FormatPreviousResultsAsContext(results)
+		assert.Contains(t, text, long)
+		assert.NotContains(t, text, "omitted")
+	})
+}
@@ -74,6 +74,40 @@ func TestSuspendExecution(t *testing.T) {
 	})
 }
 
+func TestApprovalGate(t *testing.T) {
+	_ = testprepare.PrepareSandbox(t)
+
+	t.Run("gated_task_suspends_before_running", func(t *testing.T) {
+		robot := &robottypes.Robot{
+			MemberID: "test-robot-approval",
+			TeamID:   "test-team-1",
+			Config:   &robottypes.Config{ApprovalRequiredFor: []string{"payment"}},
+		}
+		exec := &robottypes.Execution{
+			ID: "exec-approval-001", MemberID: robot.MemberID, TeamID: robot.TeamID,
+			Status: robottypes.ExecRunning, Phase: robottypes.PhaseRun,
+			Tasks: []robottypes.Task{
+				{ID: "task-pay", Description: "Pay the invoice", ExecutorType: robottypes.ExecutorProcess,
+					ExecutorID: "scripts.billing.Pay", Tags: []string{"payment"}, Status: robottypes.TaskPending},
+			},
+		}
+		exec.SetRobot(robot)
+
+		e := standard.NewWithConfig(types.Config{SkipPersistence: true})
+		err := e.RunExecution(robottypes.NewContext(context.Background(), nil), exec, nil)
+
+		assert.ErrorIs(t, err, robottypes.ErrExecutionSuspended)
+		assert.Equal(t, robottypes.ExecWaiting, exec.Status)
+		assert.Equal(t, "task-pay", exec.WaitingTaskID)
+		assert.Contains(t, exec.WaitingQuestion, "Pay the invoice")
+		require.NotNil(t, exec.ResumeContext)
+		assert.Equal(t, "task-pay", exec.ResumeContext.ApprovalTaskID)
+		assert.Equal(t, robottypes.TaskWaitingInput, exec.Tasks[0].Status)
+		assert.Nil(t, exec.Tasks[0].StartTime, "the gated task never started")
+		assert.Empty(t, exec.Results)
+	})
+}
+
 // ============================================================================
 // ResumeContext data structure tests
 // ============================================================================
@@ -221,6 +255,31 @@ func TestResume(t *testing.T) {
 		assert.Equal(t, robottypes.ExecCompleted, loaded.Status)
 	})
 
+	t.Run("R7_Resume_without_approval_keeps_gated_task_waiting", func(t *testing.T) {
+		identity := testprepare.PrepareSandbox(t)
+		ctx := testCtx(identity)
+		robot := newResumeTestRobot(t, identity)
+		robot.Config.ApprovalRequiredFor = []string{"assistant"}
+		exec := newSuspendedResumeExecution(robot)
+		exec.ResumeContext.ApprovalTaskID = "task-001"
+
+		execStore := store.NewExecutionStore()
+		require.NoError(t, execStore.Save(ctx.Context, store.FromExecution(exec)))
+		require.NoError(t, store.NewRobotStore().Save(ctx.Context, store.FromRobot(robot)))
+
+		e := standard.New()
+		err := e.Resume(ctx, exec.ID, "not sure yet")
+		assert.ErrorIs(t, err, robottypes.ErrExecutionSuspended)
+
+		loaded, err := execStore.Get(ctx.Context, exec.ID)
+		require.NoError(t, err)
+		assert.Equal(t, robottypes.ExecWaiting, loaded.Status)
+		require.NotNil(t, loaded.ResumeContext)
+		assert.Equal(t, "task-001", loaded.ResumeContext.ApprovalTaskID)
+		assert.Nil(t, loaded.Tasks[0].ApprovedAt)
+		assert.Empty(t, loaded.Results, "the gated task did not run")
+	})
+
 	t.Run("R8_Resume_counter_returns_to_zero_after_completion", func(t *testing.T) {
 		identity := testprepare.PrepareSandbox(t)
 		ctx := testCtx(identity)
@@ -250,6 +309,84 @@ func TestResume(t *testing.T) {
 	})
 }
 
+func TestResumeFromTask(t *testing.T) {
+	cleanupResumeTestData(t)
+	defer cleanupResumeTestData(t)
+
+	// suspendedAtSecondTask has task-001 done and task-002 waiting for input
+	suspendedAtSecondTask := func(robot *robottypes.Robot) *robottypes.Execution {
+		exec := newSuspendedResumeExecution(robot)
+		second := exec.Tasks[0]
+		second.ID, second.Order = "task-002", 1
+		exec.Tasks[0].Status = robottypes.TaskCompleted
+		exec.Tasks = append(exec.Tasks, second)
+		exec.WaitingTaskID = "task-002"
+		exec.ResumeContext = &robottypes.ResumeContext{TaskIndex: 1, PreviousResults: []robottypes.TaskResult{
+			{TaskID: "task-001", Success: true, Output: "stale"},
+		}}
+		return exec
+	}
+
+	t.Run("rejects_out_of_range_and_skipped_tasks", func(t *testing.T) {
+		identity := testprepare.PrepareSandbox(t)
+		ctx := testCtx(identity)
+		robot := newResumeTestRobot(t, identity)
+		exec := suspendedAtSecondTask(robot)
+		exec.Tasks[0].Status = robottypes.TaskSkipped
+
+		execStore := store.NewExecutionStore()
+		require.NoError(t, execStore.Save(ctx.Context, store.FromExecution(exec)))
+		require.NoError(t, store.NewRobotStore().Save(ctx.Context, store.FromRobot(robot)))
+
+		e := standard.New()
+		for _, index := range []int{-1, 2} {
+			err := e.ResumeFromTask(ctx, exec.ID, index, "")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "invalid task index")
+		}
+		err := e.ResumeFromTask(ctx, exec.ID, 0, "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "was skipped")
+
+		loaded, err := execStore.Get(ctx.Context, exec.ID)
+		require.NoError(t, err)
+		assert.Equal(t, robottypes.ExecWaiting, loaded.Status, "a rejected rewind changes nothing")
+		assert.Equal(t, 1, loaded.ResumeContext.TaskIndex)
+	})
+
+	t.Run("rejects_executions_not_waiting", func(t *testing.T) {
+		identity := testprepare.PrepareSandbox(t)
+		ctx := testCtx(identity)
+		robot := newResumeTestRobot(t, identity)
+		exec := newResumeTestExecution(robot)
+		require.NoError(t, store.NewExecutionStore().Save(ctx.Context, store.FromExecution(exec)))
+
+		err := standard.New().ResumeFromTask(ctx, exec.ID, 0, "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not in waiting status")
+	})
+
+	t.Run("reruns_from_an_earlier_task", func(t *testing.T) {
+		identity := testprepare.PrepareSandbox(t)
+		ctx := testCtx(identity)
+		robot := newResumeTestRobot(t, identity)
+		exec := suspendedAtSecondTask(robot)
+
+		execStore := store.NewExecutionStore()
+		require.NoError(t, execStore.Save(ctx.Context, store.FromExecution(exec)))
+		require.NoError(t, store.NewRobotStore().Save(ctx.Context, store.FromRobot(robot)))
+
+		err := standard.New().ResumeFromTask(ctx, exec.ID, 0, "Upstream data was fixed")
+		require.NoError(t, err)
+
+		loaded, err := execStore.Get(ctx.Context, exec.ID)
+		require.NoError(t, err)
+		assert.Equal(t, robottypes.ExecCompleted, loaded.Status)
+		require.Len(t, loaded.Results, 2)
+		assert.NotEqual(t, "stale", loaded.Results[0].Output, "task-001 ran again")
+	})
+}
+
 // ============================================================================
 // Resume Helpers
 // ============================================================================
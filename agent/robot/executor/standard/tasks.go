@@ -6,6 +6,7 @@ import (
 
 	kunlog "github.com/yaoapp/kun/log"
 	agentcontext "github.com/yaoapp/yao/agent/context"
+	"github.com/yaoapp/yao/agent/robot/capability"
 	robottypes "github.com/yaoapp/yao/agent/robot/types"
 )
 
@@ -53,6 +54,16 @@ func (e *Executor) RunTasks(ctx *robottypes.Context, exec *robottypes.Execution,
 		return fmt.Errorf("tasks agent (%s) received empty input for task planning", agentID)
 	}
 
+	// Structured capability block so the planner does not have to guess what is allowed
+	if block := capability.Format(capability.Build(ctx, robot)); block != "" {
+		userContent += "\n" + block
+	}
+	routing := robot.Config != nil && robot.Config.Resources != nil && robot.Config.Resources.Routing != nil
+	if routing {
+		userContent += "\n## Agent Routing\n\nFor agent tasks, executor_id may be left empty; " +
+			"the agent is then picked from the robot's agents by the task description and tags.\n"
+	}
+
 	// Call agent
 	caller := NewAgentCaller()
 	caller.log = newExecLogger(robot, exec.ID)
@@ -84,6 +95,11 @@ func (e *Executor) RunTasks(ctx *robottypes.Context, exec *robottypes.Execution,
 	// Normalize executor IDs and types against available resources
 	NormalizeTaskExecutors(tasks, robot)
 
+	// Pick agents for tasks planned without one
+	if routing {
+		RouteTaskAgents(tasks, robot, e.classifyTask(ctx, exec, robot))
+	}
+
 	// Validate tasks
 	if err := ValidateTasks(tasks); err != nil {
 		return fmt.Errorf("tasks validation failed: %w", err)
@@ -144,10 +160,10 @@ func ParseTask(data map[string]interface{}, index int) (*robottypes.Task, error)
 		return nil, fmt.Errorf("missing executor_type")
 	}
 
-	// Required: executor_id
+	// Required: executor_id (agent tasks may omit it and be routed, see RouteTaskAgents)
 	if execID, ok := data["executor_id"].(string); ok && execID != "" {
 		task.ExecutorID = execID
-	} else {
+	} else if task.ExecutorType != robottypes.ExecutorAssistant {
 		return nil, fmt.Errorf("missing executor_id")
 	}
 
@@ -213,6 +229,21 @@ func ParseTask(data map[string]interface{}, index int) (*robottypes.Task, error)
 		}
 	}
 
+	// Optional: condition (evaluated against earlier results in P3)
+	if condition, ok := data["condition"].(string); ok {
+		task.Condition = strings.TrimSpace(condition)
+	}
+
+	// Optional: tags (matched by approval_required_for)
+	if tags, ok := data["tags"].([]interface{}); ok {
+		task.Tags = make([]string, 0, len(tags))
+		for _, tag := range tags {
+			if s, ok := tag.(string); ok && s != "" {
+				task.Tags = append(task.Tags, s)
+			}
+		}
+	}
+
 	return task, nil
 }
 
@@ -194,6 +194,22 @@ func TestParseTasks(t *testing.T) {
 		assert.Contains(t, err.Error(), "missing executor_type")
 	})
 
+	t.Run("allows_agent_task_without_executor_id", func(t *testing.T) {
+		data := []interface{}{
+			map[string]interface{}{"executor_type": "agent", "description": "Summarize content"},
+		}
+		tasks, err := standard.ParseTasks(data)
+		require.NoError(t, err)
+		assert.Empty(t, tasks[0].ExecutorID, "left for routing")
+
+		data = []interface{}{
+			map[string]interface{}{"executor_type": "process", "description": "Export"},
+		}
+		_, err = standard.ParseTasks(data)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "missing executor_id")
+	})
+
 	t.Run("handles_different_executor_types", func(t *testing.T) {
 		data := []interface{}{
 			map[string]interface{}{"executor_type": "agent", "executor_id": "a", "description": "d"},
@@ -0,0 +1,122 @@
+package standard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	kunlog "github.com/yaoapp/kun/log"
+	robottypes "github.com/yaoapp/yao/agent/robot/types"
+)
+
+// runTimeout is the overall time limit of one run: the initial run of an execution,
+// or the phases a Resume continues
+type runTimeout struct {
+	limit    time.Duration
+	deadline time.Time // zero = no limit
+}
+
+// newRunTimeout starts the overall timer of a run from the robot's config
+func newRunTimeout(robot *robottypes.Robot) runTimeout {
+	var config *robottypes.Config
+	if robot != nil {
+		config = robot.Config
+	}
+	run := runTimeout{limit: config.GetOverallTimeout()}
+	if run.limit > 0 {
+		run.deadline = time.Now().Add(run.limit)
+	}
+	return run
+}
+
+// phaseBound is the context one phase runs under. It ends at the robot's phase
+// timeout or the run deadline, whichever comes first.
+type phaseBound struct {
+	ctx    *robottypes.Context
+	cancel context.CancelFunc
+	phase  robottypes.Phase
+	start  time.Time
+	limit  time.Duration // phase timeout (0 = none)
+	run    runTimeout
+}
+
+// boundPhase derives the context of phase from ctx. limit is the executor's phase
+// timeout (0 = none), the robot's own phase timeout applies when shorter.
+// The caller must call cancel.
+func boundPhase(ctx *robottypes.Context, robot *robottypes.Robot, phase robottypes.Phase, run runTimeout, limit time.Duration) *phaseBound {
+	bound := &phaseBound{phase: phase, start: time.Now(), run: run, limit: limit}
+	if robot != nil {
+		if robotLimit := robot.Config.GetPhaseTimeout(); robotLimit > 0 && (bound.limit <= 0 || robotLimit < bound.limit) {
+			bound.limit = robotLimit
+		}
+	}
+
+	deadline := run.deadline
+	if bound.limit > 0 {
+		if end := bound.start.Add(bound.limit); deadline.IsZero() || end.Before(deadline) {
+			deadline = end
+		}
+	}
+
+	phaseCtx := *ctx
+	if deadline.IsZero() {
+		phaseCtx.Context, bound.cancel = context.WithCancel(ctx.Context)
+	} else {
+		phaseCtx.Context, bound.cancel = context.WithDeadline(ctx.Context, deadline)
+	}
+	bound.ctx = &phaseCtx
+	return bound
+}
+
+// phaseStopGrace is how long a phase may take to return once its context ended
+// before wait logs it as slow to stop
+const phaseStopGrace = 5 * time.Second
+
+// wait runs fn and returns its error, or the context error once the phase's context
+// ends. fn runs on b.ctx, so the deadline cancels it; wait still waits for fn to
+// return, so a late phase never touches the execution while it is retried or
+// finalized. A phase slow to honour its context is logged, never left running.
+func (b *phaseBound) wait(fn func() error) error {
+	done := make(chan error, 1)
+	go func() { done <- fn() }()
+	select {
+	case err := <-done:
+		return err
+	case <-b.ctx.Done():
+	}
+
+	select {
+	case <-done:
+	case <-time.After(phaseStopGrace):
+		kunlog.Warn("phase %s still running %s after its context ended, waiting for it to stop", b.phase, phaseStopGrace)
+		<-done
+	}
+	return b.ctx.Err()
+}
+
+// timeoutError describes the limit the phase ran into, with the elapsed time so the
+// persisted error explains the failure. It returns nil while the phase is within its
+// limits, and when parent ended first: that is a cancellation, not a timeout.
+func (b *phaseBound) timeoutError(parent *robottypes.Context) error {
+	if parent.Context.Err() != nil || !errors.Is(b.ctx.Err(), context.DeadlineExceeded) {
+		return nil
+	}
+
+	now := time.Now()
+	if !b.run.deadline.IsZero() && !now.Before(b.run.deadline) {
+		elapsed := b.run.limit + now.Sub(b.run.deadline)
+		return fmt.Errorf("%w: execution exceeded %s timeout in phase %s (elapsed %s)",
+			robottypes.ErrExecutionTimeout, seconds(b.run.limit), b.phase, seconds(elapsed))
+	}
+	return fmt.Errorf("%w: phase %s exceeded %s timeout (elapsed %s)",
+		robottypes.ErrExecutionTimeout, b.phase, seconds(b.limit), seconds(now.Sub(b.start)))
+}
+
+// seconds formats d as whole seconds, e.g. "300s"; limits under a second keep their milliseconds
+func seconds(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return fmt.Sprintf("%ds", int64(d.Round(time.Second)/time.Second))
+}
@@ -0,0 +1,124 @@
+//go:build unit
+
+package standard_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/yao/agent/robot/executor/standard"
+	executortypes "github.com/yaoapp/yao/agent/robot/executor/types"
+	"github.com/yaoapp/yao/agent/robot/types"
+)
+
+func robotWithTimeout(timeout *types.Timeout) *types.Robot {
+	return &types.Robot{MemberID: "robot_timeout", Config: &types.Config{Timeout: timeout}}
+}
+
+func TestPhaseTimeoutUnit(t *testing.T) {
+	wait := func(phaseCtx *types.Context) { <-phaseCtx.Done() }
+
+	t.Run("phase timeout", func(t *testing.T) {
+		ctx := types.NewContext(context.Background(), nil)
+		err := standard.RunBoundPhase(ctx, robotWithTimeout(&types.Timeout{Phase: 1}), types.PhaseTasks, wait)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, types.ErrExecutionTimeout))
+		assert.Contains(t, err.Error(), "phase tasks exceeded 1s timeout (elapsed 1s)")
+	})
+
+	t.Run("overall timeout wins when shorter", func(t *testing.T) {
+		ctx := types.NewContext(context.Background(), nil)
+		err := standard.RunBoundPhase(ctx, robotWithTimeout(&types.Timeout{Overall: 1, Phase: 60}), types.PhaseRun, wait)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, types.ErrExecutionTimeout))
+		assert.Contains(t, err.Error(), "execution exceeded 1s timeout in phase run")
+	})
+
+	t.Run("zero means no timeout", func(t *testing.T) {
+		ctx := types.NewContext(context.Background(), nil)
+		err := standard.RunBoundPhase(ctx, robotWithTimeout(&types.Timeout{}), types.PhaseTasks, func(phaseCtx *types.Context) {
+			_, hasDeadline := phaseCtx.Deadline()
+			assert.False(t, hasDeadline)
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("default overall timeout", func(t *testing.T) {
+		ctx := types.NewContext(context.Background(), nil)
+		err := standard.RunBoundPhase(ctx, robotWithTimeout(nil), types.PhaseTasks, func(phaseCtx *types.Context) {
+			deadline, hasDeadline := phaseCtx.Deadline()
+			assert.True(t, hasDeadline)
+			assert.WithinDuration(t, time.Now().Add(30*time.Minute), deadline, time.Minute)
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("cancellation is not a timeout", func(t *testing.T) {
+		parent, cancel := context.WithCancel(context.Background())
+		ctx := types.NewContext(parent, nil)
+		err := standard.RunBoundPhase(ctx, robotWithTimeout(&types.Timeout{Phase: 60}), types.PhaseTasks, func(phaseCtx *types.Context) {
+			cancel()
+			<-phaseCtx.Done()
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestExecutorPhaseTimeoutUnit(t *testing.T) {
+	robot := func() *types.Robot {
+		return &types.Robot{MemberID: "robot_phase_timeout", TeamID: "team_phase_timeout", Config: &types.Config{}}
+	}
+
+	t.Run("phase cancelled at its deadline", func(t *testing.T) {
+		e := standard.NewWithConfig(executortypes.Config{SkipPersistence: true, PhaseTimeout: 100 * time.Millisecond})
+		standard.SetPhaseFunc(e, func(ctx *types.Context, exec *types.Execution, phase types.Phase, data interface{}) error {
+			if phase == types.PhaseTasks {
+				select {
+				case <-ctx.Done(): // slow agent call, stopped by the phase deadline
+				case <-time.After(time.Second):
+				}
+			}
+			return nil
+		})
+
+		r := robot()
+		start := time.Now()
+		exec, err := e.Execute(types.NewContext(context.Background(), nil), r, types.TriggerHuman, nil)
+		require.NoError(t, err)
+		require.NotNil(t, exec)
+
+		assert.Less(t, time.Since(start), time.Second, "the phase is stopped at its deadline")
+		assert.Equal(t, types.ExecFailed, exec.Status)
+		assert.Equal(t, types.PhaseTasks, exec.Phase)
+		assert.Contains(t, exec.Error, "phase tasks exceeded 100ms timeout")
+		assert.Zero(t, r.RunningCount(), "the execution slot is released")
+	})
+
+	t.Run("phase ignoring its context is waited for", func(t *testing.T) {
+		e := standard.NewWithConfig(executortypes.Config{SkipPersistence: true, PhaseTimeout: 50 * time.Millisecond})
+		var returned atomic.Bool
+		standard.SetPhaseFunc(e, func(ctx *types.Context, exec *types.Execution, phase types.Phase, data interface{}) error {
+			if phase == types.PhaseTasks {
+				time.Sleep(300 * time.Millisecond) // hung call: ignores its context
+				exec.Current = &types.CurrentState{Progress: "late write"}
+				returned.Store(true)
+			}
+			return nil
+		})
+
+		r := robot()
+		exec, err := e.Execute(types.NewContext(context.Background(), nil), r, types.TriggerHuman, nil)
+		require.NoError(t, err)
+		require.NotNil(t, exec)
+
+		assert.True(t, returned.Load(), "the execution is finalized only after the phase returned")
+		assert.Equal(t, types.ExecFailed, exec.Status)
+		assert.Contains(t, exec.Error, "phase tasks exceeded 50ms timeout")
+		assert.Zero(t, r.RunningCount())
+	})
+}
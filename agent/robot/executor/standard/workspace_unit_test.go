@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	agentcontext "github.com/yaoapp/yao/agent/context"
 	"github.com/yaoapp/yao/agent/robot/executor/standard"
+	executortypes "github.com/yaoapp/yao/agent/robot/executor/types"
 	"github.com/yaoapp/yao/agent/robot/types"
 )
 
@@ -585,3 +586,21 @@ func TestValidatorDetectNeedMoreInfoUnit(t *testing.T) {
 		assert.False(t, needInput, "standalone detectNeedMoreInfo checks Next hook, not Content")
 	})
 }
+
+func TestApplyGoalNameUnit(t *testing.T) {
+	e := standard.NewWithConfig(executortypes.Config{SkipPersistence: true})
+	ctx := types.NewContext(nil, nil)
+
+	t.Run("clock execution takes its name from the goals", func(t *testing.T) {
+		exec := &types.Execution{ID: "exec_name", TriggerType: types.TriggerClock, Name: "Scheduled execution"}
+		exec.Goals = &types.Goals{Content: "## Goals\n\nReview overnight support tickets"}
+		standard.ApplyGoalNameFn(e, ctx, exec)
+		assert.Equal(t, "Review overnight support tickets", exec.Name)
+	})
+
+	t.Run("keeps the placeholder without goals", func(t *testing.T) {
+		exec := &types.Execution{ID: "exec_name", TriggerType: types.TriggerEvent, Name: "Event triggered"}
+		standard.ApplyGoalNameFn(e, ctx, exec)
+		assert.Equal(t, "Event triggered", exec.Name)
+	})
+}
@@ -74,6 +74,45 @@ type Config struct {
 
 	// OnPhaseEnd callback when a phase ends
 	OnPhaseEnd func(phase robottypes.Phase)
+
+	// PhaseRetry is the retry policy applied to every phase (zero value: no retries)
+	PhaseRetry RetryPolicy
+
+	// PhaseRetries overrides PhaseRetry for individual phases
+	PhaseRetries map[robottypes.Phase]RetryPolicy
+
+	// PhaseTimeout bounds every phase this executor runs (0 = only the robot's timeout.phase).
+	// When the robot sets a phase timeout as well, the shorter one applies.
+	PhaseTimeout time.Duration
+}
+
+// RetryPolicy controls how a phase that failed with a transient error is retried
+type RetryPolicy struct {
+	MaxRetries int           `json:"max_retries"` // retries after the first attempt (0 = no retry)
+	Backoff    time.Duration `json:"backoff"`     // delay before the first retry, doubled for each further retry
+}
+
+// RetryPolicyFor returns the retry policy of a phase
+func (c *Config) RetryPolicyFor(phase robottypes.Phase) RetryPolicy {
+	if policy, ok := c.PhaseRetries[phase]; ok {
+		return policy
+	}
+	return c.PhaseRetry
+}
+
+// Delay returns the wait before retry number attempt (1-based)
+func (p RetryPolicy) Delay(attempt int) time.Duration {
+	if p.Backoff <= 0 || attempt < 1 {
+		return 0
+	}
+	delay := p.Backoff
+	for i := 1; i < attempt && delay < time.Hour; i++ {
+		delay *= 2
+	}
+	if delay > time.Hour {
+		delay = time.Hour
+	}
+	return delay
 }
 
 // DryRunConfig holds dry-run specific configuration
@@ -0,0 +1,103 @@
+// Package ingress admits inbound email to robots. The mail gateway hands each message
+// over together with the robot it was addressed to; the robot member's authorized_senders
+// and email_filter_rules decide whether it may trigger the robot, and accepted mail
+// runs the robot as an event trigger.
+package ingress
+
+import (
+	"fmt"
+
+	"github.com/yaoapp/yao/agent/robot/api"
+	"github.com/yaoapp/yao/agent/robot/store"
+	"github.com/yaoapp/yao/agent/robot/types"
+)
+
+// EventEmailReceived is the event type of an email trigger
+const EventEmailReceived = "email.received"
+
+// robotStore loads the robot member the email is addressed to
+var robotStore = store.NewRobotStore()
+
+// InboundEmail is a message received by the mail gateway
+type InboundEmail struct {
+	From      string `json:"from"`                 // From header, "Name <a@b.com>" or "a@b.com"
+	To        string `json:"to,omitempty"`         // address the message was sent to
+	Subject   string `json:"subject,omitempty"`    // subject line
+	Body      string `json:"body,omitempty"`       // plain text body
+	MessageID string `json:"message_id,omitempty"` // Message-ID header, for replies and dedup
+}
+
+// Decision is the outcome of EvaluateInboundEmail
+type Decision struct {
+	Accepted bool     `json:"accepted"`
+	Sender   string   `json:"sender,omitempty"`  // sender address, lower-cased
+	Reasons  []string `json:"reasons,omitempty"` // every check the email failed
+}
+
+// Result is the outcome of Ingest
+type Result struct {
+	Decision
+	ExecutionID string `json:"execution_id,omitempty"`
+	Message     string `json:"message,omitempty"`
+}
+
+// Ingest evaluates email against the rules of robot memberID and, when accepted, triggers
+// the robot with an email.received event. A rejected email is not an error: the result
+// carries the reasons. Errors are reserved for a missing robot or a failing store.
+func Ingest(ctx *types.Context, memberID string, email *InboundEmail) (*Result, error) {
+	if memberID == "" {
+		return nil, fmt.Errorf("member_id is required")
+	}
+	if email == nil {
+		return nil, fmt.Errorf("email is required")
+	}
+
+	record, err := robotStore.Get(ctx.Context, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, fmt.Errorf("%w: %s", types.ErrRobotNotFound, memberID)
+	}
+
+	result := &Result{Decision: *EvaluateInboundEmail(record, email)}
+	if !result.Accepted {
+		return result, nil
+	}
+
+	triggered, err := api.HandleEvent(ctx, memberID, &api.TriggerRequest{
+		Type:      types.TriggerEvent,
+		Source:    types.EventEmail,
+		EventType: EventEmailReceived,
+		Data:      eventData(result.Sender, email),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !triggered.Accepted {
+		// The email passed the rules but the robot refused the trigger (paused, event trigger disabled)
+		result.Accepted = false
+		result.Reasons = append(result.Reasons, triggered.Message)
+		return result, nil
+	}
+
+	result.ExecutionID = triggered.ExecutionID
+	result.Message = triggered.Message
+	return result, nil
+}
+
+// eventData is the trigger input of an accepted email
+func eventData(sender string, email *InboundEmail) map[string]interface{} {
+	data := map[string]interface{}{
+		"from":    sender,
+		"subject": email.Subject,
+		"body":    email.Body,
+	}
+	if email.To != "" {
+		data["to"] = email.To
+	}
+	if email.MessageID != "" {
+		data["message_id"] = email.MessageID
+	}
+	return data
+}
@@ -0,0 +1,139 @@
+//go:build unit
+
+package ingress_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/yao/agent/robot/ingress"
+	"github.com/yaoapp/yao/agent/robot/store"
+)
+
+func TestEvaluateInboundEmailSenders(t *testing.T) {
+	record := &store.RobotRecord{
+		Status:            "active",
+		AuthorizedSenders: `["boss@example.com", "*@acme.com"]`,
+	}
+
+	tests := []struct {
+		name   string
+		from   string
+		accept bool
+	}{
+		{"exact", "boss@example.com", true},
+		{"exact_case_insensitive", "Big Boss <BOSS@Example.com>", true},
+		{"domain_wildcard", "alice@acme.com", true},
+		{"subdomain_not_covered", "alice@mail.acme.com", false},
+		{"unlisted", "someone@example.com", false},
+		{"lookalike_domain", "eve@evilacme.com", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := ingress.EvaluateInboundEmail(record, &ingress.InboundEmail{From: tt.from})
+			assert.Equal(t, tt.accept, decision.Accepted, decision.Reasons)
+		})
+	}
+
+	t.Run("no_authorized_senders", func(t *testing.T) {
+		decision := ingress.EvaluateInboundEmail(&store.RobotRecord{Status: "active"}, &ingress.InboundEmail{From: "boss@example.com"})
+		assert.False(t, decision.Accepted)
+		assert.Contains(t, decision.Reasons, "robot has no authorized senders")
+	})
+
+	t.Run("invalid_sender", func(t *testing.T) {
+		decision := ingress.EvaluateInboundEmail(record, &ingress.InboundEmail{From: "not an address"})
+		assert.False(t, decision.Accepted)
+		require.Len(t, decision.Reasons, 1)
+		assert.Contains(t, decision.Reasons[0], "invalid sender")
+	})
+
+	t.Run("inactive_robot", func(t *testing.T) {
+		inactive := *record
+		inactive.Status = "suspended"
+		decision := ingress.EvaluateInboundEmail(&inactive, &ingress.InboundEmail{From: "boss@example.com"})
+		assert.False(t, decision.Accepted)
+		assert.Contains(t, decision.Reasons, "robot member is suspended")
+	})
+}
+
+func TestEvaluateInboundEmailFilterRules(t *testing.T) {
+	record := &store.RobotRecord{
+		Status:            "active",
+		AuthorizedSenders: []interface{}{"*@acme.com"},
+		EmailFilterRules: []interface{}{
+			"allow subject:(?i)^\\[report\\]",
+			map[string]interface{}{"action": "allow", "field": "body", "pattern": "#robot"},
+			"deny body:(?i)unsubscribe",
+		},
+	}
+
+	t.Run("allowed_by_subject", func(t *testing.T) {
+		decision := ingress.EvaluateInboundEmail(record, &ingress.InboundEmail{From: "a@acme.com", Subject: "[Report] weekly"})
+		assert.True(t, decision.Accepted, decision.Reasons)
+		assert.Equal(t, "a@acme.com", decision.Sender)
+	})
+
+	t.Run("allowed_by_body", func(t *testing.T) {
+		decision := ingress.EvaluateInboundEmail(record, &ingress.InboundEmail{From: "a@acme.com", Subject: "hi", Body: "please run #robot"})
+		assert.True(t, decision.Accepted, decision.Reasons)
+	})
+
+	t.Run("no_allow_rule_matched", func(t *testing.T) {
+		decision := ingress.EvaluateInboundEmail(record, &ingress.InboundEmail{From: "a@acme.com", Subject: "hi"})
+		assert.False(t, decision.Accepted)
+		assert.Equal(t, []string{"no allow filter rule matched"}, decision.Reasons)
+	})
+
+	t.Run("deny_wins", func(t *testing.T) {
+		decision := ingress.EvaluateInboundEmail(record, &ingress.InboundEmail{From: "a@acme.com", Subject: "[Report] x", Body: "Unsubscribe here"})
+		assert.False(t, decision.Accepted)
+		require.Len(t, decision.Reasons, 1)
+		assert.Contains(t, decision.Reasons[0], "denied by filter rule 3")
+	})
+
+	t.Run("every_failure_reported", func(t *testing.T) {
+		decision := ingress.EvaluateInboundEmail(record, &ingress.InboundEmail{From: "x@other.com", Body: "unsubscribe"})
+		assert.False(t, decision.Accepted)
+		assert.Len(t, decision.Reasons, 3)
+	})
+
+	t.Run("plain_address_pattern", func(t *testing.T) {
+		legacy := &store.RobotRecord{
+			AuthorizedSenders: `["*@example.com"]`,
+			EmailFilterRules:  `[".*@example\\.com$"]`,
+		}
+		decision := ingress.EvaluateInboundEmail(legacy, &ingress.InboundEmail{From: "admin@example.com"})
+		assert.True(t, decision.Accepted, decision.Reasons)
+	})
+
+	t.Run("invalid_rule_rejects", func(t *testing.T) {
+		broken := &store.RobotRecord{
+			AuthorizedSenders: `["*@acme.com"]`,
+			EmailFilterRules:  `["deny subject:(unclosed"]`,
+		}
+		decision := ingress.EvaluateInboundEmail(broken, &ingress.InboundEmail{From: "a@acme.com"})
+		assert.False(t, decision.Accepted)
+		require.Len(t, decision.Reasons, 1)
+		assert.Contains(t, decision.Reasons[0], "invalid filter rule 1")
+	})
+}
+
+func TestParseFilterRules(t *testing.T) {
+	rules, errs := ingress.ParseFilterRules([]interface{}{
+		"deny from:@spam\\.com$",
+		"Allow body: hello",
+		map[string]interface{}{"action": "block", "pattern": "x"},
+		map[string]interface{}{"field": "cc", "pattern": "x"},
+		42,
+	})
+	require.Len(t, rules, 2)
+	assert.Equal(t, ingress.RuleDeny, rules[0].Action)
+	assert.Equal(t, ingress.FieldFrom, rules[0].Field)
+	assert.Equal(t, "@spam\\.com$", rules[0].Pattern)
+	assert.Equal(t, ingress.RuleAllow, rules[1].Action)
+	assert.Equal(t, ingress.FieldBody, rules[1].Field)
+	assert.Equal(t, "hello", rules[1].Pattern)
+	assert.Len(t, errs, 3)
+}
@@ -0,0 +1,209 @@
+package ingress
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+
+	"github.com/yaoapp/yao/agent/robot/store"
+	"github.com/yaoapp/yao/agent/robot/utils"
+)
+
+// Filter rule actions
+const (
+	RuleAllow = "allow"
+	RuleDeny  = "deny"
+)
+
+// Filter rule fields
+const (
+	FieldFrom    = "from"
+	FieldSubject = "subject"
+	FieldBody    = "body"
+)
+
+// FilterRule is one parsed entry of email_filter_rules
+type FilterRule struct {
+	Action  string // allow | deny
+	Field   string // from | subject | body
+	Pattern string
+	re      *regexp.Regexp
+}
+
+// EvaluateInboundEmail decides whether email may trigger the robot described by record.
+// The sender must be listed in authorized_senders, either exactly or by a domain wildcard
+// ("*@acme.com"); an empty list accepts nobody. email_filter_rules are then applied: any
+// matching deny rule rejects the email and, when allow rules exist, at least one must match.
+// Every failed check is reported in Decision.Reasons.
+func EvaluateInboundEmail(record *store.RobotRecord, email *InboundEmail) *Decision {
+	decision := &Decision{}
+	if record == nil || email == nil {
+		decision.Reasons = append(decision.Reasons, "robot and email are required")
+		return decision
+	}
+
+	sender, err := parseSender(email.From)
+	if err != nil {
+		decision.Reasons = append(decision.Reasons, fmt.Sprintf("invalid sender %q: %v", email.From, err))
+	} else {
+		decision.Sender = sender
+	}
+
+	if record.Status != "" && record.Status != "active" {
+		decision.Reasons = append(decision.Reasons, fmt.Sprintf("robot member is %s", record.Status))
+	}
+
+	if decision.Sender != "" {
+		senders := toStrings(record.AuthorizedSenders)
+		switch {
+		case len(senders) == 0:
+			decision.Reasons = append(decision.Reasons, "robot has no authorized senders")
+		case !senderAuthorized(decision.Sender, senders):
+			decision.Reasons = append(decision.Reasons, fmt.Sprintf("sender %s is not an authorized sender", decision.Sender))
+		}
+	}
+
+	rules, errs := ParseFilterRules(record.EmailFilterRules)
+	decision.Reasons = append(decision.Reasons, errs...)
+
+	fields := map[string]string{FieldFrom: decision.Sender, FieldSubject: email.Subject, FieldBody: email.Body}
+	hasAllow, allowed := false, false
+	for i, rule := range rules {
+		matched := rule.re.MatchString(fields[rule.Field])
+		if rule.Action == RuleDeny {
+			if matched {
+				decision.Reasons = append(decision.Reasons, fmt.Sprintf("denied by filter rule %d (%s %s: %s)", i+1, rule.Action, rule.Field, rule.Pattern))
+			}
+			continue
+		}
+		hasAllow = true
+		allowed = allowed || matched
+	}
+	if hasAllow && !allowed {
+		decision.Reasons = append(decision.Reasons, "no allow filter rule matched")
+	}
+
+	decision.Accepted = len(decision.Reasons) == 0
+	return decision
+}
+
+// ParseFilterRules reads email_filter_rules. Each entry is either an object
+// {"action": "allow|deny", "field": "from|subject|body", "pattern": "<regex>"} or a string
+// "[allow|deny] [from|subject|body:]<regex>". Action defaults to allow and field to from,
+// so plain address patterns such as ".*@example\.com$" keep their meaning.
+// Entries that cannot be parsed are returned as reasons: a broken rule rejects mail
+// rather than silently letting it through.
+func ParseFilterRules(v interface{}) ([]FilterRule, []string) {
+	entries := jsonList(v)
+	rules := make([]FilterRule, 0, len(entries))
+	var errs []string
+	for i, entry := range entries {
+		rule, err := parseFilterRule(entry)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("invalid filter rule %d: %v", i+1, err))
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	return rules, errs
+}
+
+// parseFilterRule parses one rule in string or object form
+func parseFilterRule(entry interface{}) (FilterRule, error) {
+	rule := FilterRule{Action: RuleAllow, Field: FieldFrom}
+	switch value := entry.(type) {
+	case string:
+		rest := strings.TrimSpace(value)
+		for _, action := range []string{RuleAllow, RuleDeny} {
+			if strings.HasPrefix(strings.ToLower(rest), action+" ") {
+				rule.Action = action
+				rest = strings.TrimSpace(rest[len(action):])
+				break
+			}
+		}
+		for _, field := range []string{FieldFrom, FieldSubject, FieldBody} {
+			if strings.HasPrefix(strings.ToLower(rest), field+":") {
+				rule.Field = field
+				rest = strings.TrimSpace(rest[len(field)+1:])
+				break
+			}
+		}
+		rule.Pattern = rest
+	case map[string]interface{}:
+		if action := strings.ToLower(utils.GetString(value, "action")); action != "" {
+			rule.Action = action
+		}
+		if field := strings.ToLower(utils.GetString(value, "field")); field != "" {
+			rule.Field = field
+		}
+		rule.Pattern = utils.GetString(value, "pattern")
+	default:
+		return rule, fmt.Errorf("expected a string or an object, got %T", entry)
+	}
+
+	if rule.Action != RuleAllow && rule.Action != RuleDeny {
+		return rule, fmt.Errorf("unknown action %q", rule.Action)
+	}
+	if rule.Field != FieldFrom && rule.Field != FieldSubject && rule.Field != FieldBody {
+		return rule, fmt.Errorf("unknown field %q", rule.Field)
+	}
+	if rule.Pattern == "" {
+		return rule, fmt.Errorf("pattern is required")
+	}
+	re, err := regexp.Compile(rule.Pattern)
+	if err != nil {
+		return rule, err
+	}
+	rule.re = re
+	return rule, nil
+}
+
+// parseSender extracts the lower-cased address from a From header ("Name <a@b.com>" or "a@b.com")
+func parseSender(from string) (string, error) {
+	addr, err := mail.ParseAddress(strings.TrimSpace(from))
+	if err != nil {
+		return "", err
+	}
+	return strings.ToLower(addr.Address), nil
+}
+
+// senderAuthorized reports whether sender matches an entry of authorized_senders,
+// exactly or by a "*@domain" wildcard
+func senderAuthorized(sender string, senders []string) bool {
+	for _, entry := range senders {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == sender {
+			return true
+		}
+		if domain, ok := strings.CutPrefix(entry, "*@"); ok && domain != "" && strings.HasSuffix(sender, "@"+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonList reads a JSON array column, stored as text or already decoded
+func jsonList(v interface{}) []interface{} {
+	if values, ok := v.([]string); ok {
+		items := make([]interface{}, len(values))
+		for i, value := range values {
+			items[i] = value
+		}
+		return items
+	}
+	items, _ := utils.ToJSONValue(v).([]interface{})
+	return items
+}
+
+// toStrings reads a JSON array column as strings, skipping non-string entries
+func toStrings(v interface{}) []string {
+	items := jsonList(v)
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok && strings.TrimSpace(s) != "" {
+			values = append(values, s)
+		}
+	}
+	return values
+}
@@ -0,0 +1,486 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/yaoapp/yao/agent/robot/events"
+	"github.com/yaoapp/yao/agent/robot/pool"
+	"github.com/yaoapp/yao/agent/robot/store"
+	"github.com/yaoapp/yao/agent/robot/types"
+	"github.com/yaoapp/yao/agent/robot/utils"
+	"github.com/yaoapp/yao/event"
+)
+
+// ==================== Batch Trigger ====================
+// A batch runs the same robot once per parameter set. Items are not pushed into
+// the pool up front: the manager dispatches them one at a time whenever the
+// robot has a free quota slot (on submit, on every execution completion and on
+// every tick), round-robin across batches so one large batch cannot starve others.
+
+// SubmitBatch creates and persists a batch, then starts dispatching its items
+func (m *Manager) SubmitBatch(ctx *types.Context, memberID string, params []map[string]interface{}, suppressDelivery bool) (*types.Batch, error) {
+	m.mu.RLock()
+	if !m.started {
+		m.mu.RUnlock()
+		return nil, fmt.Errorf("manager not started")
+	}
+	m.mu.RUnlock()
+
+	if len(params) == 0 {
+		return nil, types.ErrBatchEmpty
+	}
+	if len(params) > types.MaxBatchItems {
+		return nil, fmt.Errorf("%w: %d > %d", types.ErrBatchTooLarge, len(params), types.MaxBatchItems)
+	}
+
+	robot, lazyLoaded, err := m.getOrLoadRobot(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if lazyLoaded {
+		m.scheduleCleanup(robot)
+	}
+
+	if robot.Status == types.RobotPaused {
+		return nil, types.ErrRobotPaused
+	}
+	if robot.Config != nil && robot.Config.Triggers != nil {
+		if !robot.Config.Triggers.IsEnabled(types.TriggerEvent) {
+			return nil, types.ErrTriggerDisabled
+		}
+	}
+
+	now := time.Now()
+	batch := &types.Batch{
+		BatchID:          utils.NewID(),
+		MemberID:         memberID,
+		TeamID:           robot.TeamID,
+		Status:           types.BatchPending,
+		SuppressDelivery: suppressDelivery,
+		Items:            make([]*types.BatchItem, len(params)),
+		CreatedBy:        ctx.UserID(),
+		CreatedAt:        &now,
+	}
+	for i, p := range params {
+		batch.Items[i] = &types.BatchItem{Index: i, Params: p, Status: types.BatchItemQueued}
+	}
+
+	if err := m.batchStore.Save(ctx.Context, batch); err != nil {
+		return nil, err
+	}
+
+	m.batchMu.Lock()
+	m.batches[batch.BatchID] = batch
+	m.batchOrder = append(m.batchOrder, batch.BatchID)
+	m.batchMu.Unlock()
+
+	m.feedBatches()
+
+	return m.GetBatch(ctx, batch.BatchID)
+}
+
+// GetBatch returns a snapshot of a batch, from memory if active, otherwise from the store
+func (m *Manager) GetBatch(ctx *types.Context, batchID string) (*types.Batch, error) {
+	m.batchMu.Lock()
+	if b, ok := m.batches[batchID]; ok {
+		snapshot := copyBatch(b)
+		m.batchMu.Unlock()
+		return snapshot, nil
+	}
+	m.batchMu.Unlock()
+
+	batch, err := m.batchStore.Get(ctx.Context, batchID)
+	if err != nil {
+		return nil, err
+	}
+	if batch == nil {
+		return nil, types.ErrBatchNotFound
+	}
+	return batch, nil
+}
+
+// CancelBatch cancels every queued item of a batch. Items already running finish normally.
+// Returns the updated batch snapshot.
+func (m *Manager) CancelBatch(ctx *types.Context, batchID string) (*types.Batch, error) {
+	m.batchMu.Lock()
+	b, ok := m.batches[batchID]
+	if !ok {
+		m.batchMu.Unlock()
+		batch, err := m.batchStore.Get(ctx.Context, batchID)
+		if err != nil {
+			return nil, err
+		}
+		if batch == nil {
+			return nil, types.ErrBatchNotFound
+		}
+		// Not active: already finished, nothing left to cancel
+		return batch, nil
+	}
+
+	b.CancelRemaining()
+	m.finishBatchIfDone(b)
+	snapshot := copyBatch(b)
+	m.batchMu.Unlock()
+
+	if err := m.batchStore.Save(ctx.Context, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// batchDispatch is a batch item reserved under batchMu and submitted outside it
+type batchDispatch struct {
+	batchID   string
+	memberID  string
+	item      *types.BatchItem // only touched under batchMu
+	execID    string
+	startTime time.Time
+	input     *types.TriggerInput
+}
+
+// feedBatches dispatches queued batch items while their robots have free slots.
+// Items are reserved under batchMu and submitted outside it: loading a robot reads the
+// store and submitting goes through the pool, neither of which may hold up batch reads
+// and execution completions.
+func (m *Manager) feedBatches() {
+	for {
+		reserved := m.reserveBatchItems()
+		if len(reserved) == 0 {
+			return
+		}
+
+		progressed := false
+		outcomes := make([]error, len(reserved))
+		for i, d := range reserved {
+			outcomes[i] = m.submitBatchItem(d)
+			if outcomes[i] == nil {
+				progressed = true
+			}
+		}
+
+		for _, b := range m.settleBatchItems(reserved, outcomes) {
+			m.saveBatch(b)
+		}
+		if !progressed {
+			return
+		}
+	}
+}
+
+// reserveBatchItems takes the next queued item of every active batch, round-robin, and
+// marks it running under a fresh execution ID before anything is submitted, so a
+// concurrent feed or a recovery never dispatches the same item twice
+func (m *Manager) reserveBatchItems() []batchDispatch {
+	m.batchMu.Lock()
+	defer m.batchMu.Unlock()
+
+	var reserved []batchDispatch
+	for _, id := range m.batchOrder {
+		b := m.batches[id]
+		if b == nil || b.Status == types.BatchCancelled || b.Status == types.BatchCompleted {
+			continue
+		}
+		item := b.NextQueued()
+		if item == nil {
+			continue
+		}
+
+		now := time.Now()
+		execID := pool.GenerateExecID()
+		item.Status = types.BatchItemRunning
+		item.ExecutionID = execID
+		item.StartTime = &now
+		m.batchByExec[execID] = b.BatchID
+		reserved = append(reserved, batchDispatch{
+			batchID:   b.BatchID,
+			memberID:  b.MemberID,
+			item:      item,
+			execID:    execID,
+			startTime: now,
+			input:     buildBatchItemInput(b, item),
+		})
+	}
+	return reserved
+}
+
+// submitBatchItem submits a reserved item if the robot has a free slot. Must not be
+// called with batchMu held.
+func (m *Manager) submitBatchItem(d batchDispatch) error {
+	robot := m.cache.Get(d.memberID)
+	if robot == nil {
+		loaded, lazyLoaded, err := m.getOrLoadRobot(types.NewContext(m.ctx, nil), d.memberID)
+		if err != nil {
+			return err
+		}
+		if lazyLoaded {
+			m.scheduleCleanup(loaded)
+		}
+		robot = loaded
+	}
+	if robot.Status == types.RobotPaused {
+		return types.ErrRobotPaused
+	}
+
+	preExec := &types.Execution{
+		ID:          d.execID,
+		MemberID:    robot.MemberID,
+		TeamID:      robot.TeamID,
+		TriggerType: types.TriggerEvent,
+		Status:      types.ExecPending,
+		StartTime:   d.startTime,
+	}
+	if !robot.TryAcquireSlot(preExec) {
+		return types.ErrRobotBusy
+	}
+
+	ctrlExec := m.execController.Track(d.execID, robot.MemberID, robot.TeamID)
+	execCtx := types.NewContext(ctrlExec.Context(), m.buildRobotAuth(robot))
+	if _, err := m.pool.SubmitWithID(execCtx, robot, types.TriggerEvent, d.input, d.execID, ctrlExec); err != nil {
+		robot.RemoveExecution(d.execID)
+		m.execController.Untrack(d.execID)
+		return err
+	}
+	return nil
+}
+
+// settleBatchItems records the outcome of submitted items and returns snapshots of the
+// batches that changed. An item that could not start yet goes back to the queue, or is
+// cancelled if its batch was cancelled meanwhile; an item whose robot is gone fails.
+func (m *Manager) settleBatchItems(reserved []batchDispatch, outcomes []error) []*types.Batch {
+	m.batchMu.Lock()
+	defer m.batchMu.Unlock()
+
+	var dirty []*types.Batch
+	for i, d := range reserved {
+		b := m.batches[d.batchID]
+		if b == nil || d.item.ExecutionID != d.execID {
+			continue // finished meanwhile, the item already settled
+		}
+
+		err := outcomes[i]
+		now := time.Now()
+		switch {
+		case err == nil:
+			if b.Status == types.BatchPending {
+				b.Status = types.BatchRunning
+			}
+		case errors.Is(err, types.ErrRobotNotFound):
+			delete(m.batchByExec, d.execID)
+			d.item.Status = types.BatchItemFailed
+			d.item.ExecutionID = ""
+			d.item.Error = err.Error()
+			d.item.EndTime = &now
+		case b.Status == types.BatchCancelled:
+			delete(m.batchByExec, d.execID)
+			d.item.Status = types.BatchItemCancelled
+			d.item.ExecutionID = ""
+			d.item.EndTime = &now
+		default:
+			// Paused, busy, over its daily limit or the pool queue is full: back to the
+			// queue for a later feed, as it was before the reservation
+			delete(m.batchByExec, d.execID)
+			d.item.Status = types.BatchItemQueued
+			d.item.ExecutionID = ""
+			d.item.StartTime = nil
+			continue
+		}
+		m.finishBatchIfDone(b)
+		dirty = appendUnique(dirty, b)
+	}
+
+	snapshots := make([]*types.Batch, 0, len(dirty))
+	for _, b := range dirty {
+		snapshots = append(snapshots, copyBatch(b))
+	}
+	return snapshots
+}
+
+// onBatchExecutionComplete records the outcome of a batch item execution and
+// dispatches the next queued items. No-op for executions that are not batch items.
+func (m *Manager) onBatchExecutionComplete(execID string, status types.ExecStatus) {
+	m.batchMu.Lock()
+	batchID, ok := m.batchByExec[execID]
+	if !ok {
+		m.batchMu.Unlock()
+		return
+	}
+	delete(m.batchByExec, execID)
+
+	b := m.batches[batchID]
+	if b == nil {
+		m.batchMu.Unlock()
+		return
+	}
+	if item := b.FindItemByExecution(execID); item != nil {
+		now := time.Now()
+		item.Status = types.BatchItemStatusFromExec(status)
+		item.EndTime = &now
+	}
+	m.finishBatchIfDone(b)
+	snapshot := copyBatch(b)
+	m.batchMu.Unlock()
+
+	m.saveBatch(snapshot)
+	m.feedBatches()
+}
+
+// reconcileBatches settles running items whose executions are no longer tracked
+// in memory (e.g. suspended executions that were resumed and finished elsewhere)
+func (m *Manager) reconcileBatches(ctx context.Context) {
+	execStore := store.NewExecutionStore()
+
+	m.batchMu.Lock()
+	var pending []string
+	for execID := range m.batchByExec {
+		if m.execController.Get(execID) == nil {
+			pending = append(pending, execID)
+		}
+	}
+	m.batchMu.Unlock()
+
+	for _, execID := range pending {
+		record, err := execStore.Get(ctx, execID)
+		if err != nil || record == nil {
+			continue
+		}
+		switch record.Status {
+		case types.ExecCompleted, types.ExecFailed, types.ExecCancelled:
+			m.onBatchExecutionComplete(execID, record.Status)
+		}
+	}
+}
+
+// recoverBatches reloads active batches after a restart. Running items whose
+// execution was never persisted are queued again; the others are settled from
+// the execution record (recoverExecutions has already failed interrupted runs).
+func (m *Manager) recoverBatches(ctx context.Context) {
+	batches, err := m.batchStore.ListActive(ctx)
+	if err != nil {
+		log.Printf("[batch] failed to list active batches: %v", err)
+		return
+	}
+
+	execStore := store.NewExecutionStore()
+
+	m.batchMu.Lock()
+	defer m.batchMu.Unlock()
+
+	for _, b := range batches {
+		for _, item := range b.Items {
+			if item.Status != types.BatchItemRunning {
+				continue
+			}
+			record, err := execStore.Get(ctx, item.ExecutionID)
+			if err != nil {
+				log.Printf("[batch] failed to load execution %s of batch %s: %v", item.ExecutionID, b.BatchID, err)
+				continue
+			}
+			switch {
+			case record == nil:
+				item.Status = types.BatchItemQueued
+				item.ExecutionID = ""
+				item.StartTime = nil
+			case record.Status == types.ExecCompleted || record.Status == types.ExecFailed || record.Status == types.ExecCancelled:
+				item.Status = types.BatchItemStatusFromExec(record.Status)
+				item.Error = record.Error
+				item.EndTime = record.EndTime
+			default:
+				// waiting / confirming: keep tracking until it settles
+				m.batchByExec[item.ExecutionID] = b.BatchID
+			}
+		}
+
+		m.batches[b.BatchID] = b
+		m.batchOrder = append(m.batchOrder, b.BatchID)
+		m.finishBatchIfDone(b)
+		if err := m.batchStore.Save(ctx, b); err != nil {
+			log.Printf("[batch] failed to save recovered batch %s: %v", b.BatchID, err)
+		}
+	}
+}
+
+// finishBatchIfDone marks a batch completed once every item is terminal, drops it
+// from the active set and pushes the batch completion notification.
+// Caller must hold batchMu.
+func (m *Manager) finishBatchIfDone(b *types.Batch) {
+	if !b.IsFinished() {
+		return
+	}
+
+	now := time.Now()
+	if b.Status != types.BatchCancelled {
+		b.Status = types.BatchCompleted
+	}
+	b.CompletedAt = &now
+
+	delete(m.batches, b.BatchID)
+	for i, id := range m.batchOrder {
+		if id == b.BatchID {
+			m.batchOrder = append(m.batchOrder[:i], m.batchOrder[i+1:]...)
+			break
+		}
+	}
+
+	progress := b.Progress()
+	payload := events.BatchPayload{
+		BatchID:          b.BatchID,
+		MemberID:         b.MemberID,
+		TeamID:           b.TeamID,
+		Status:           string(b.Status),
+		SuppressDelivery: b.SuppressDelivery,
+		Progress:         progress,
+	}
+	go func() {
+		_, _ = event.Push(context.Background(), events.BatchCompleted, payload)
+	}()
+}
+
+// saveBatch persists a batch snapshot, logging failures
+func (m *Manager) saveBatch(b *types.Batch) {
+	if err := m.batchStore.Save(m.ctx, b); err != nil {
+		log.Printf("[batch] failed to save batch %s: %v", b.BatchID, err)
+	}
+}
+
+// buildBatchItemInput builds the event trigger input of a batch item.
+// The item parameters become the event data, tagged with the batch identity.
+func buildBatchItemInput(b *types.Batch, item *types.BatchItem) *types.TriggerInput {
+	data := make(map[string]interface{}, len(item.Params)+3)
+	for k, v := range item.Params {
+		data[k] = v
+	}
+	data[types.BatchDataKeyID] = b.BatchID
+	data[types.BatchDataKeyIndex] = item.Index
+	if b.SuppressDelivery {
+		data[types.BatchDataKeySuppressDelivery] = true
+	}
+	return &types.TriggerInput{
+		Source:    types.BatchEventSource,
+		EventType: "batch.item",
+		Data:      data,
+	}
+}
+
+// copyBatch returns a deep copy of the batch state (params are shared, they never change)
+func copyBatch(b *types.Batch) *types.Batch {
+	cp := *b
+	cp.Items = make([]*types.BatchItem, len(b.Items))
+	for i, item := range b.Items {
+		it := *item
+		cp.Items[i] = &it
+	}
+	return &cp
+}
+
+func appendUnique(list []*types.Batch, b *types.Batch) []*types.Batch {
+	for _, x := range list {
+		if x == b {
+			return list
+		}
+	}
+	return append(list, b)
+}
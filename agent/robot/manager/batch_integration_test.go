@@ -0,0 +1,191 @@
+//go:build integration
+
+package manager_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/yao/agent/robot/api"
+	"github.com/yaoapp/yao/agent/robot/executor"
+	"github.com/yaoapp/yao/agent/robot/manager"
+	"github.com/yaoapp/yao/agent/robot/pool"
+	"github.com/yaoapp/yao/agent/robot/store"
+	"github.com/yaoapp/yao/agent/robot/types"
+	"github.com/yaoapp/yao/unit-test/agent/testprepare"
+)
+
+// batchStubExecutor wraps the dry-run executor and fails items whose params carry "fail": true
+type batchStubExecutor struct {
+	*executor.DryRunExecutor
+}
+
+func (e *batchStubExecutor) ExecuteWithControl(ctx *types.Context, robot *types.Robot, trigger types.TriggerType, data interface{}, execID string, control types.ExecutionControl) (*types.Execution, error) {
+	if input, ok := data.(*types.TriggerInput); ok && input.Data != nil {
+		if fail, _ := input.Data["fail"].(bool); fail {
+			exec := &types.Execution{ID: execID, MemberID: robot.MemberID, TeamID: robot.TeamID, TriggerType: trigger}
+			if !robot.TryAcquireSlot(exec) {
+				return nil, types.ErrQuotaExceeded
+			}
+			defer robot.RemoveExecution(execID)
+			exec.Status = types.ExecFailed
+			exec.Error = "stub failure"
+			return exec, nil
+		}
+	}
+	return e.DryRunExecutor.ExecuteWithControl(ctx, robot, trigger, data, execID, control)
+}
+
+func newBatchTestManager(t *testing.T, delay time.Duration, quota int) (*manager.Manager, *types.Robot) {
+	m := manager.NewWithConfig(&manager.Config{
+		TickInterval: time.Hour,
+		PoolConfig:   &pool.Config{WorkerSize: 4, QueueSize: 20},
+		Executor:     &batchStubExecutor{DryRunExecutor: executor.NewDryRunWithDelay(delay)},
+	})
+	require.NoError(t, m.Start())
+
+	robot := &types.Robot{
+		MemberID:       "robot_batch_" + strings.ReplaceAll(t.Name(), "/", "_"),
+		TeamID:         "team_batch_test",
+		Status:         types.RobotIdle,
+		AutonomousMode: false,
+		Config:         &types.Config{Quota: &types.Quota{Max: quota}},
+	}
+	m.Cache().Add(robot)
+	return m, robot
+}
+
+func waitBatch(t *testing.T, m *manager.Manager, batchID string, timeout time.Duration) *types.Batch {
+	ctx := types.NewContext(context.Background(), nil)
+	deadline := time.Now().Add(timeout)
+	for {
+		b, err := m.GetBatch(ctx, batchID)
+		require.NoError(t, err)
+		if b.IsFinished() || time.Now().After(deadline) {
+			return b
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestManagerBatch(t *testing.T) {
+	testprepare.PrepareSandbox(t)
+
+	t.Run("runs items within quota and accounts progress", func(t *testing.T) {
+		m, robot := newBatchTestManager(t, 100*time.Millisecond, 1)
+		defer m.Stop()
+
+		ctx := types.NewContext(context.Background(), nil)
+		batch, err := m.SubmitBatch(ctx, robot.MemberID, []map[string]interface{}{
+			{"customer_id": "c1"},
+			{"customer_id": "c2", "fail": true},
+			{"customer_id": "c3"},
+		}, false)
+		require.NoError(t, err)
+		defer store.NewBatchStore().Delete(context.Background(), batch.BatchID)
+
+		// Quota 1: only one item is dispatched at a time, the rest wait in the batch
+		p := batch.Progress()
+		assert.Equal(t, 3, p.Total)
+		assert.Equal(t, 1, p.Running)
+		assert.Equal(t, 2, p.Queued)
+		assert.LessOrEqual(t, robot.RunningCount(), 1)
+
+		final := waitBatch(t, m, batch.BatchID, 10*time.Second)
+		p = final.Progress()
+		assert.Equal(t, types.BatchCompleted, final.Status)
+		assert.Equal(t, 2, p.Completed)
+		assert.Equal(t, 1, p.Failed)
+		assert.Equal(t, 0, p.Queued+p.Running)
+		assert.NotNil(t, final.CompletedAt)
+
+		// State is persisted
+		saved, err := store.NewBatchStore().Get(context.Background(), batch.BatchID)
+		require.NoError(t, err)
+		require.NotNil(t, saved)
+		assert.Equal(t, types.BatchCompleted, saved.Status)
+		assert.Equal(t, p, saved.Progress())
+
+		// Summary: header + one row per item
+		csv, err := api.BatchSummaryCSV(final)
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(csv)), "\n")
+		require.Len(t, lines, 4)
+		assert.Equal(t, "index,status,execution_id,error,start_time,end_time,customer_id,fail", lines[0])
+		assert.True(t, strings.HasPrefix(lines[2], "1,failed,"))
+	})
+
+	t.Run("cancel leaves running item and cancels the tail", func(t *testing.T) {
+		m, robot := newBatchTestManager(t, 300*time.Millisecond, 1)
+		defer m.Stop()
+
+		ctx := types.NewContext(context.Background(), nil)
+		items := make([]map[string]interface{}, 5)
+		for i := range items {
+			items[i] = map[string]interface{}{"row": i}
+		}
+		batch, err := m.SubmitBatch(ctx, robot.MemberID, items, true)
+		require.NoError(t, err)
+		defer store.NewBatchStore().Delete(context.Background(), batch.BatchID)
+
+		cancelled, err := m.CancelBatch(ctx, batch.BatchID)
+		require.NoError(t, err)
+		assert.Equal(t, types.BatchCancelled, cancelled.Status)
+		assert.Equal(t, 4, cancelled.Progress().Cancelled)
+		assert.Equal(t, 1, cancelled.Progress().Running)
+
+		final := waitBatch(t, m, batch.BatchID, 10*time.Second)
+		p := final.Progress()
+		assert.Equal(t, types.BatchCancelled, final.Status)
+		assert.Equal(t, 1, p.Completed)
+		assert.Equal(t, 4, p.Cancelled)
+		assert.True(t, final.SuppressDelivery)
+	})
+
+	t.Run("items of a removed robot fail instead of waiting forever", func(t *testing.T) {
+		m, robot := newBatchTestManager(t, 200*time.Millisecond, 1)
+		defer m.Stop()
+
+		ctx := types.NewContext(context.Background(), nil)
+		batch, err := m.SubmitBatch(ctx, robot.MemberID, []map[string]interface{}{{"row": 0}, {"row": 1}, {"row": 2}}, true)
+		require.NoError(t, err)
+		defer store.NewBatchStore().Delete(context.Background(), batch.BatchID)
+
+		// The robot only lives in the cache: once removed it cannot be loaded again
+		m.Cache().Remove(robot.MemberID)
+
+		final := waitBatch(t, m, batch.BatchID, 10*time.Second)
+		p := final.Progress()
+		assert.Equal(t, types.BatchCompleted, final.Status)
+		assert.Equal(t, 1, p.Completed)
+		assert.Equal(t, 2, p.Failed)
+		for _, item := range final.Items[1:] {
+			assert.Contains(t, item.Error, types.ErrRobotNotFound.Error())
+			assert.Empty(t, item.ExecutionID, "the reserved execution never ran")
+		}
+	})
+
+	t.Run("rejects empty and oversized batches", func(t *testing.T) {
+		m, robot := newBatchTestManager(t, 0, 1)
+		defer m.Stop()
+
+		ctx := types.NewContext(context.Background(), nil)
+		_, err := m.SubmitBatch(ctx, robot.MemberID, nil, false)
+		assert.ErrorIs(t, err, types.ErrBatchEmpty)
+
+		_, err = m.SubmitBatch(ctx, robot.MemberID, make([]map[string]interface{}, types.MaxBatchItems+1), false)
+		assert.ErrorIs(t, err, types.ErrBatchTooLarge)
+	})
+
+	t.Run("unknown batch", func(t *testing.T) {
+		m, _ := newBatchTestManager(t, 0, 1)
+		defer m.Stop()
+
+		_, err := m.GetBatch(types.NewContext(context.Background(), nil), "batch_does_not_exist")
+		assert.ErrorIs(t, err, types.ErrBatchNotFound)
+	})
+}
@@ -0,0 +1,149 @@
+//go:build integration
+
+package manager_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/yao/agent/robot/manager"
+	"github.com/yaoapp/yao/agent/robot/store"
+	"github.com/yaoapp/yao/agent/robot/types"
+	"github.com/yaoapp/yao/unit-test/agent/testprepare"
+)
+
+const cascadeTestPrefix = "_test_cascade_"
+
+func saveChainedExec(t *testing.T, s *store.ExecutionStore, id, parentID string, status types.ExecStatus) {
+	t.Helper()
+	now := time.Now()
+	require.NoError(t, s.Save(context.Background(), &store.ExecutionRecord{
+		ExecutionID:       cascadeTestPrefix + id,
+		MemberID:          cascadeTestPrefix + "member",
+		TeamID:            "team_cascade",
+		TriggerType:       types.TriggerHuman,
+		Status:            status,
+		Phase:             types.PhaseRun,
+		ParentExecutionID: parentID,
+		StartTime:         &now,
+	}))
+	t.Cleanup(func() { s.Delete(context.Background(), cascadeTestPrefix+id) })
+}
+
+func execStatus(t *testing.T, s *store.ExecutionStore, id string) types.ExecStatus {
+	t.Helper()
+	record, err := s.Get(context.Background(), cascadeTestPrefix+id)
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	return record.Status
+}
+
+func TestCancelExecutionCascade(t *testing.T) {
+	testprepare.PrepareSandbox(t)
+
+	m := manager.New()
+	require.NoError(t, m.Start())
+	defer m.Stop()
+
+	s := store.NewExecutionStore()
+	ctx := types.NewContext(context.Background(), nil)
+	p := cascadeTestPrefix
+
+	t.Run("without cascade children keep running", func(t *testing.T) {
+		saveChainedExec(t, s, "solo_root", "", types.ExecWaiting)
+		saveChainedExec(t, s, "solo_child", p+"solo_root", types.ExecRunning)
+
+		require.NoError(t, m.CancelExecution(ctx, p+"solo_root", manager.CancelOptions{}))
+		assert.Equal(t, types.ExecCancelled, execStatus(t, s, "solo_root"))
+		assert.Equal(t, types.ExecRunning, execStatus(t, s, "solo_child"))
+	})
+
+	t.Run("reason and canceller are recorded", func(t *testing.T) {
+		saveChainedExec(t, s, "reason_root", "", types.ExecConfirming)
+		saveChainedExec(t, s, "reason_child", p+"reason_root", types.ExecWaiting)
+
+		require.NoError(t, m.CancelExecution(ctx, p+"reason_root", manager.CancelOptions{Cascade: true, Reason: "wrong target list", CancelledBy: "user-42"}))
+
+		root, err := s.Get(context.Background(), p+"reason_root")
+		require.NoError(t, err)
+		assert.Equal(t, "wrong target list", root.CancelReason)
+		assert.Equal(t, "user-42", root.CancelledBy)
+		assert.Equal(t, "wrong target list", root.ToExecution().CancelReason)
+
+		child, err := s.Get(context.Background(), p+"reason_child")
+		require.NoError(t, err)
+		assert.Equal(t, "cancelled with parent execution "+p+"reason_root", child.CancelReason)
+		assert.Equal(t, "user-42", child.CancelledBy)
+	})
+
+	t.Run("defaults without reason", func(t *testing.T) {
+		saveChainedExec(t, s, "default_root", "", types.ExecWaiting)
+
+		require.NoError(t, m.CancelExecution(ctx, p+"default_root", manager.CancelOptions{}))
+
+		record, err := s.Get(context.Background(), p+"default_root")
+		require.NoError(t, err)
+		assert.Equal(t, "cancelled by user", record.CancelReason)
+		assert.Equal(t, "system", record.CancelledBy)
+	})
+
+	t.Run("cascade cancels descendants transitively", func(t *testing.T) {
+		saveChainedExec(t, s, "root", "", types.ExecWaiting)
+		saveChainedExec(t, s, "child_running", p+"root", types.ExecRunning)
+		saveChainedExec(t, s, "child_done", p+"root", types.ExecCompleted)
+		saveChainedExec(t, s, "grandchild", p+"child_running", types.ExecConfirming)
+		saveChainedExec(t, s, "great_grandchild", p+"child_done", types.ExecPending)
+
+		require.NoError(t, m.CancelExecution(ctx, p+"root", manager.CancelOptions{Cascade: true}))
+		assert.Equal(t, types.ExecCancelled, execStatus(t, s, "root"))
+		assert.Equal(t, types.ExecCancelled, execStatus(t, s, "child_running"))
+		assert.Equal(t, types.ExecCompleted, execStatus(t, s, "child_done"))
+		assert.Equal(t, types.ExecCancelled, execStatus(t, s, "grandchild"))
+		assert.Equal(t, types.ExecCancelled, execStatus(t, s, "great_grandchild"))
+	})
+
+	t.Run("cascade from a finished parent", func(t *testing.T) {
+		saveChainedExec(t, s, "done_root", "", types.ExecCompleted)
+		saveChainedExec(t, s, "continued", p+"done_root", types.ExecRunning)
+
+		require.NoError(t, m.CancelExecution(ctx, p+"done_root", manager.CancelOptions{Cascade: true}))
+		assert.Equal(t, types.ExecCompleted, execStatus(t, s, "done_root"))
+		assert.Equal(t, types.ExecCancelled, execStatus(t, s, "continued"))
+	})
+
+	t.Run("cycles terminate", func(t *testing.T) {
+		saveChainedExec(t, s, "cycle_a", p+"cycle_b", types.ExecWaiting)
+		saveChainedExec(t, s, "cycle_b", p+"cycle_a", types.ExecRunning)
+
+		done := make(chan error, 1)
+		go func() { done <- m.CancelExecution(ctx, p+"cycle_a", manager.CancelOptions{Cascade: true}) }()
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("cascade cancel did not terminate on a cycle")
+		}
+		assert.Equal(t, types.ExecCancelled, execStatus(t, s, "cycle_a"))
+		assert.Equal(t, types.ExecCancelled, execStatus(t, s, "cycle_b"))
+	})
+
+	t.Run("running parent requires force", func(t *testing.T) {
+		saveChainedExec(t, s, "busy_root", "", types.ExecRunning)
+		err := m.CancelExecution(ctx, p+"busy_root", manager.CancelOptions{Cascade: true})
+		assert.ErrorIs(t, err, types.ErrCancelRequiresForce)
+		assert.Equal(t, types.ExecRunning, execStatus(t, s, "busy_root"))
+	})
+
+	t.Run("force cancels a running parent and its chain", func(t *testing.T) {
+		saveChainedExec(t, s, "forced_root", "", types.ExecRunning)
+		saveChainedExec(t, s, "forced_child", p+"forced_root", types.ExecWaiting)
+
+		require.NoError(t, m.CancelExecution(ctx, p+"forced_root", manager.CancelOptions{Cascade: true, Force: true}))
+
+		assert.Equal(t, types.ExecCancelled, execStatus(t, s, "forced_root"))
+		assert.Equal(t, types.ExecCancelled, execStatus(t, s, "forced_child"))
+	})
+}
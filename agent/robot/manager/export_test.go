@@ -1,6 +1,7 @@
 package manager
 
 import (
+	agentcontext "github.com/yaoapp/yao/agent/context"
 	"github.com/yaoapp/yao/agent/robot/executor/standard"
 	"github.com/yaoapp/yao/agent/robot/store"
 	"github.com/yaoapp/yao/agent/robot/types"
@@ -25,3 +26,24 @@ func ExportProcessHostAction(m *Manager, ctx *types.Context, robot *types.Robot,
 func ExportParseHostAgentResult(m *Manager, result *standard.CallResult) (*types.HostOutput, error) {
 	return m.parseHostAgentResult(result)
 }
+
+// HostStreamCaller exposes hostStreamCaller for external tests.
+type HostStreamCaller = hostStreamCaller
+
+// SetHostStreamCaller replaces the raw Host Agent stream caller; the returned func restores it.
+func SetHostStreamCaller(caller HostStreamCaller) func() {
+	previous := newHostStreamCaller
+	newHostStreamCaller = func(string, *types.Robot) hostStreamCaller { return caller }
+	return func() { newHostStreamCaller = previous }
+}
+
+func ExportCallHostAgentStreamRaw(m *Manager, ctx *types.Context, agentID string, input *types.HostInput, chatID string, robot *types.Robot, onMessage agentcontext.OnMessageFunc) (*types.HostOutput, error) {
+	return m.callHostAgentStreamRaw(ctx, agentID, input, chatID, robot, onMessage)
+}
+
+// HostStreamBuffer exposes hostStreamBuffer for external tests.
+type HostStreamBuffer = hostStreamBuffer
+
+func ExportNewHostStreamBuffer(onMessage agentcontext.OnMessageFunc, maxChunks int) *HostStreamBuffer {
+	return newHostStreamBuffer(onMessage, maxChunks)
+}
@@ -1,9 +1,11 @@
 package manager
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"strings"
+	"sync"
 	"time"
 
 	"github.com/yaoapp/kun/log"
@@ -31,6 +33,7 @@ type InteractRequest struct {
 	Source      types.InteractSource `json:"source,omitempty"`
 	Message     string               `json:"message"`
 	Action      string               `json:"action,omitempty"`
+	Force       bool                 `json:"force,omitempty"` // with action "cancel": also cancel a running execution
 }
 
 // InteractResponse is the result of an interaction.
@@ -43,8 +46,26 @@ type InteractResponse struct {
 	WaitForMore bool   `json:"wait_for_more,omitempty"`
 }
 
+// CancelOptions controls CancelExecution. The zero value cancels a waiting or
+// confirming execution on behalf of the calling user.
+type CancelOptions struct {
+	// Cascade also cancels the not-yet-terminal descendant executions (chained via
+	// parent_execution_id); an already finished parent is allowed in that case so
+	// its chained continuations can be stopped.
+	Cascade bool
+	// Force also cancels a running execution.
+	Force bool
+	// Reason is stored on the record and carried by ExecCancelled; defaults to "cancelled by user".
+	Reason string
+	// CancelledBy is stored next to Reason; defaults to the calling user (or "system").
+	CancelledBy string
+}
+
 // CancelExecution cancels a waiting/confirming execution.
-func (m *Manager) CancelExecution(ctx *types.Context, execID string) error {
+// With opts.Force, a running execution is cancelled too: the record is marked cancelled
+// first, then its tracked context is cancelled so the executor stops at the next
+// phase boundary (or as soon as the in-flight call returns) without rewriting the status.
+func (m *Manager) CancelExecution(ctx *types.Context, execID string, opts CancelOptions) error {
 	m.mu.RLock()
 	if !m.started {
 		m.mu.RUnlock()
@@ -61,25 +82,119 @@ func (m *Manager) CancelExecution(ctx *types.Context, execID string) error {
 		return fmt.Errorf("execution not found: %s", execID)
 	}
 
-	if record.Status != types.ExecWaiting && record.Status != types.ExecConfirming {
-		return fmt.Errorf("execution %s is in status %s, only waiting/confirming can be cancelled", execID, record.Status)
+	reason := opts.Reason
+	if reason == "" {
+		reason = "cancelled by user"
+	}
+	cancelledBy := opts.CancelledBy
+	if cancelledBy == "" {
+		cancelledBy = ctx.UserID()
+	}
+	if cancelledBy == "" {
+		cancelledBy = "system"
+	}
+
+	if !opts.Cascade || !record.Status.IsTerminal() {
+		switch {
+		case record.Status == types.ExecWaiting || record.Status == types.ExecConfirming:
+		case record.Status == types.ExecRunning && opts.Force:
+			log.Warn("[cancel] force-cancelling running execution %s (by %s)", execID, cancelledBy)
+		case record.Status == types.ExecRunning:
+			return fmt.Errorf("%w: execution %s is running, set force to cancel it", types.ErrCancelRequiresForce, execID)
+		default:
+			return fmt.Errorf("execution %s is in status %s, only waiting/confirming (or running with force) can be cancelled", execID, record.Status)
+		}
+		if err := m.cancelExecutionRecord(ctx, execStore, record, reason, cancelledBy); err != nil {
+			return err
+		}
+	}
+
+	if !opts.Cascade {
+		return nil
+	}
+
+	cancelled, err := m.cancelDescendants(ctx, execStore, record, cancelledBy)
+	if len(cancelled) > 0 {
+		event.Push(ctx.Context, robotevents.ExecCascadeCancelled, robotevents.CascadePayload{
+			ExecutionID: execID,
+			MemberID:    record.MemberID,
+			TeamID:      record.TeamID,
+			Cancelled:   cancelled,
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("cascade cancel of %s incomplete: %w", execID, err)
+	}
+	return nil
+}
+
+// cancelDescendants walks the chain below root breadth-first and cancels every
+// execution that is not terminal yet. Visited IDs guard against cycles.
+// Returns the IDs that were cancelled.
+func (m *Manager) cancelDescendants(ctx *types.Context, execStore *store.ExecutionStore, root *store.ExecutionRecord, cancelledBy string) ([]string, error) {
+	visited := map[string]bool{root.ExecutionID: true}
+	queue := []string{root.ExecutionID}
+	cancelled := []string{}
+	reason := fmt.Sprintf("cancelled with parent execution %s", root.ExecutionID)
+
+	for len(queue) > 0 {
+		parentID := queue[0]
+		queue = queue[1:]
+
+		children, err := execStore.ListChildren(ctx.Context, parentID)
+		if err != nil {
+			return cancelled, err
+		}
+
+		for _, child := range children {
+			if visited[child.ExecutionID] {
+				log.Warn("[cascade] cycle detected at execution %s (parent %s), skipping", child.ExecutionID, parentID)
+				continue
+			}
+			visited[child.ExecutionID] = true
+			queue = append(queue, child.ExecutionID)
+
+			if child.Status.IsTerminal() {
+				continue
+			}
+
+			// Running executions are stopped through their controller first
+			if m.execController.Get(child.ExecutionID) != nil {
+				_ = m.execController.Stop(child.ExecutionID)
+			}
+			if err := m.cancelExecutionRecord(ctx, execStore, child, reason, cancelledBy); err != nil {
+				return cancelled, err
+			}
+			cancelled = append(cancelled, child.ExecutionID)
+		}
 	}
 
-	if err := execStore.UpdateStatus(ctx.Context, execID, types.ExecCancelled, "cancelled by user"); err != nil {
+	return cancelled, nil
+}
+
+// cancelExecutionRecord marks an execution cancelled, releases its slot and emits ExecCancelled
+func (m *Manager) cancelExecutionRecord(ctx *types.Context, execStore *store.ExecutionStore, record *store.ExecutionRecord, reason, cancelledBy string) error {
+	if err := execStore.MarkCancelled(ctx.Context, record.ExecutionID, reason, cancelledBy); err != nil {
 		return fmt.Errorf("failed to cancel execution: %w", err)
 	}
 
-	m.execController.Untrack(execID)
+	m.execController.Untrack(record.ExecutionID)
+	var tracked *types.Execution
 	if robot := m.cache.Get(record.MemberID); robot != nil {
-		robot.RemoveExecution(execID)
+		tracked = robot.GetExecution(record.ExecutionID)
+		robot.RemoveExecution(record.ExecutionID)
 	}
+	m.rememberCancel(record, tracked)
 
 	event.Push(ctx.Context, robotevents.ExecCancelled, robotevents.ExecPayload{
-		ExecutionID: execID,
+		ExecutionID: record.ExecutionID,
 		MemberID:    record.MemberID,
 		TeamID:      record.TeamID,
 		Status:      string(types.ExecCancelled),
 		ChatID:      record.ChatID,
+
+		CancelReason: reason,
+		CancelledBy:  cancelledBy,
 	})
 
 	return nil
@@ -125,10 +240,18 @@ func (m *Manager) HandleInteract(ctx *types.Context, memberID string, req *Inter
 		return nil, fmt.Errorf("execution not found: %s", req.ExecutionID)
 	}
 
+	// An explicit cancel does not need the Host Agent
+	if req.Action == string(types.HostActionCancel) {
+		return m.interactCancel(ctx, record, req)
+	}
+
 	switch record.Status {
 	case types.ExecConfirming:
 		return m.handleConfirmingInteraction(ctx, robot, record, req, execStore)
 	case types.ExecWaiting:
+		if req.Action == "confirm" && awaitingApproval(record) {
+			return m.approveTask(ctx, record)
+		}
 		return m.handleWaitingInteraction(ctx, robot, record, req, execStore)
 	case types.ExecRunning:
 		if record.WaitingTaskID == "" {
@@ -144,6 +267,20 @@ func (m *Manager) HandleInteract(ctx *types.Context, memberID string, req *Inter
 	}
 }
 
+// interactCancel handles action "cancel": the execution is cancelled directly, with the
+// message as the reason. Running executions are only cancelled when req.Force is set.
+func (m *Manager) interactCancel(ctx *types.Context, record *store.ExecutionRecord, req *InteractRequest) (*InteractResponse, error) {
+	if err := m.CancelExecution(ctx, record.ExecutionID, CancelOptions{Force: req.Force, Reason: req.Message}); err != nil {
+		return nil, err
+	}
+	return &InteractResponse{
+		ExecutionID: record.ExecutionID,
+		Status:      "cancelled",
+		Message:     "Execution cancelled",
+		ChatID:      record.ChatID,
+	}, nil
+}
+
 // handleNewInteraction creates a confirming execution and calls Host Agent with "assign" scenario.
 func (m *Manager) handleNewInteraction(ctx *types.Context, robot *types.Robot, req *InteractRequest, execStore *store.ExecutionStore) (*InteractResponse, error) {
 	exec, chatID, err := m.createConfirmingExecution(ctx, robot, req, execStore)
@@ -277,6 +414,7 @@ func (m *Manager) buildHostContext(robot *types.Robot, record *store.ExecutionRe
 	if record.WaitingQuestion != "" {
 		hostCtx.AgentReply = record.WaitingQuestion
 	}
+	hostCtx.PendingApproval = awaitingApproval(record)
 	return hostCtx
 }
 
@@ -294,7 +432,11 @@ func (m *Manager) buildRobotStatusSnapshot(robot *types.Robot) *types.RobotStatu
 		ActiveExecs:  robot.ListExecutionBriefs(),
 	}
 	if m.pool != nil {
-		snapshot.QueuedCount = m.pool.QueueSize()
+		snapshot.QueuedCount = m.pool.QueuedFor(robot.MemberID)
+		if remaining, ok := m.pool.DailyRemaining(robot); ok {
+			snapshot.DailyLimit = robot.Config.GetMaxDailyExecutions()
+			snapshot.DailyRemaining = &remaining
+		}
 	}
 	return snapshot
 }
@@ -379,6 +521,14 @@ func (m *Manager) processHostAction(ctx *types.Context, robot *types.Robot, reco
 
 	switch output.Action {
 	case types.HostActionConfirm:
+		if awaitingApproval(record) {
+			approved, err := m.approveTask(ctx, record)
+			if err != nil {
+				return nil, err
+			}
+			approved.Reply = output.Reply
+			return approved, nil
+		}
 		if err := m.advanceExecution(ctx, robot, record, execStore); err != nil {
 			return nil, fmt.Errorf("failed to advance execution: %w", err)
 		}
@@ -419,7 +569,7 @@ func (m *Manager) processHostAction(ctx *types.Context, robot *types.Robot, reco
 		resp.Message = "Execution resumed with additional context"
 
 	case types.HostActionCancel:
-		if err := m.CancelExecution(ctx, record.ExecutionID); err != nil {
+		if err := m.CancelExecution(ctx, record.ExecutionID, CancelOptions{}); err != nil {
 			return nil, fmt.Errorf("failed to cancel execution: %w", err)
 		}
 		resp.Status = "cancelled"
@@ -479,6 +629,8 @@ func (m *Manager) adjustExecution(ctx *types.Context, record *store.ExecutionRec
 		}
 	}
 
+	// A Host Agent adjustment invalidates structured edits based on the previous plan
+	record.PlanVersion++
 	return execStore.Save(ctx.Context, record)
 }
 
@@ -546,6 +698,32 @@ func (m *Manager) resumeWithContext(ctx *types.Context, record *store.ExecutionR
 	return m.executeResume(ctx, record.ExecutionID, reply)
 }
 
+// awaitingApproval reports whether a waiting execution is held by an approval gate
+func awaitingApproval(record *store.ExecutionRecord) bool {
+	return record.Status == types.ExecWaiting && record.ResumeContext != nil && record.ResumeContext.ApprovalTaskID != ""
+}
+
+// approveTask grants the pending approval and resumes the execution with the gated task
+func (m *Manager) approveTask(ctx *types.Context, record *store.ExecutionRecord) (*InteractResponse, error) {
+	resp := &InteractResponse{
+		ExecutionID: record.ExecutionID,
+		Status:      "approved",
+		Message:     fmt.Sprintf("Task %s approved, execution resumed", record.ResumeContext.ApprovalTaskID),
+		ChatID:      record.ChatID,
+	}
+
+	err := m.executeResume(ctx, record.ExecutionID, types.ReplyApprove)
+	if err == types.ErrExecutionSuspended {
+		resp.Status = "waiting"
+		resp.Message = "Task approved; execution suspended again"
+		return resp, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resume approved execution: %w", err)
+	}
+	return resp, nil
+}
+
 // directAssign is the fallback when Host Agent is unavailable: directly start execution.
 func (m *Manager) directAssign(ctx *types.Context, robot *types.Robot, record *store.ExecutionRecord, req *InteractRequest, execStore *store.ExecutionStore) (*InteractResponse, error) {
 	if err := m.advanceExecution(ctx, robot, record, execStore); err != nil {
@@ -616,10 +794,18 @@ func (m *Manager) HandleInteractStream(ctx *types.Context, memberID string, req
 		return nil, fmt.Errorf("execution not found: %s", req.ExecutionID)
 	}
 
+	// An explicit cancel does not need the Host Agent
+	if req.Action == string(types.HostActionCancel) {
+		return m.interactCancel(ctx, record, req)
+	}
+
 	switch record.Status {
 	case types.ExecConfirming:
 		return m.handleConfirmingInteractionStream(ctx, robot, record, req, execStore, streamFn)
 	case types.ExecWaiting:
+		if req.Action == "confirm" && awaitingApproval(record) {
+			return m.approveTask(ctx, record)
+		}
 		return m.handleWaitingInteractionStream(ctx, robot, record, req, execStore, streamFn)
 	case types.ExecRunning:
 		if record.WaitingTaskID == "" {
@@ -784,10 +970,18 @@ func (m *Manager) HandleInteractStreamRaw(ctx *types.Context, memberID string, r
 		return nil, fmt.Errorf("execution not found: %s", req.ExecutionID)
 	}
 
+	// An explicit cancel does not need the Host Agent
+	if req.Action == string(types.HostActionCancel) {
+		return m.interactCancel(ctx, record, req)
+	}
+
 	switch record.Status {
 	case types.ExecConfirming:
 		return m.handleConfirmingInteractionStreamRaw(ctx, robot, record, req, execStore, onMessage)
 	case types.ExecWaiting:
+		if req.Action == "confirm" && awaitingApproval(record) {
+			return m.approveTask(ctx, record)
+		}
 		return m.handleWaitingInteractionStreamRaw(ctx, robot, record, req, execStore, onMessage)
 	case types.ExecRunning:
 		if record.WaitingTaskID == "" {
@@ -810,6 +1004,14 @@ func (m *Manager) handleNewInteractionStreamRaw(ctx *types.Context, robot *types
 	}
 
 	hostOutput, err := m.callHostAgentForScenarioStreamRaw(ctx, robot, "assign", req.Message, nil, chatID, onMessage)
+	if errors.Is(err, types.ErrInteractCancelled) {
+		// Nobody is waiting for this assignment: close it rather than leave it confirming
+		detached := types.NewContext(context.WithoutCancel(ctx.Context), ctx.Auth)
+		if cancelErr := m.CancelExecution(detached, exec.ExecutionID, CancelOptions{Reason: "client disconnected"}); cancelErr != nil {
+			log.Warn("Failed to cancel abandoned confirming execution %s: %v", exec.ExecutionID, cancelErr)
+		}
+		return nil, err
+	}
 	if err != nil {
 		log.Warn("Host Agent call failed, using direct assign: %v", err)
 		return m.directAssign(ctx, robot, exec, req, execStore)
@@ -827,6 +1029,9 @@ func (m *Manager) handleNewInteractionStreamRaw(ctx *types.Context, robot *types
 func (m *Manager) handleConfirmingInteractionStreamRaw(ctx *types.Context, robot *types.Robot, record *store.ExecutionRecord, req *InteractRequest, execStore *store.ExecutionStore, onMessage agentcontext.OnMessageFunc) (*InteractResponse, error) {
 	hostCtx := m.buildHostContext(robot, record, nil)
 	hostOutput, err := m.callHostAgentForScenarioStreamRaw(ctx, robot, "assign", req.Message, hostCtx, record.ChatID, onMessage)
+	if errors.Is(err, types.ErrInteractCancelled) {
+		return nil, err
+	}
 	if err != nil {
 		log.Warn("Host Agent call failed during confirming: %v", err)
 		return &InteractResponse{
@@ -850,6 +1055,9 @@ func (m *Manager) handleWaitingInteractionStreamRaw(ctx *types.Context, robot *t
 	hostCtx := m.buildHostContext(robot, record, waitingTask)
 
 	hostOutput, err := m.callHostAgentForScenarioStreamRaw(ctx, robot, "clarify", req.Message, hostCtx, record.ChatID, onMessage)
+	if errors.Is(err, types.ErrInteractCancelled) {
+		return nil, err
+	}
 	if err != nil {
 		log.Warn("Host Agent call failed during clarify, falling back to direct resume: %v", err)
 		return m.directResume(ctx, record, req)
@@ -867,6 +1075,9 @@ func (m *Manager) handleWaitingInteractionStreamRaw(ctx *types.Context, robot *t
 func (m *Manager) handleRunningInteractionStreamRaw(ctx *types.Context, robot *types.Robot, record *store.ExecutionRecord, req *InteractRequest, execStore *store.ExecutionStore, onMessage agentcontext.OnMessageFunc) (*InteractResponse, error) {
 	hostCtx := m.buildHostContext(robot, record, nil)
 	hostOutput, err := m.callHostAgentForScenarioStreamRaw(ctx, robot, "guide", req.Message, hostCtx, record.ChatID, onMessage)
+	if errors.Is(err, types.ErrInteractCancelled) {
+		return nil, err
+	}
 	if err != nil {
 		return &InteractResponse{
 			ExecutionID: record.ExecutionID,
@@ -900,91 +1111,87 @@ func (m *Manager) callHostAgentForScenarioStreamRaw(ctx *types.Context, robot *t
 	}, chatID, robot, onMessage)
 }
 
+// hostStreamCaller is the part of the agent caller used for raw Host Agent streams
+type hostStreamCaller interface {
+	CallWithMessagesStreamRaw(ctx *types.Context, assistantID string, userContent string, onMessage agentcontext.OnMessageFunc) (*standard.CallResult, error)
+}
+
+// newHostStreamCaller builds the caller of a raw Host Agent stream (replaced in tests)
+var newHostStreamCaller = func(chatID string, robot *types.Robot) hostStreamCaller {
+	caller := standard.NewConversationCaller(chatID)
+	caller.Workspace = robot.Workspace
+	return caller
+}
+
 // callHostAgentStreamRaw calls the Host Agent with CUI raw message streaming.
 // It buffers text chunks that look like JSON output (starting with "{" or "```json")
 // so the frontend never sees raw decision JSON. If the final result is a decision,
 // the buffered chunks are discarded and a clean reply is sent instead. If the
 // result is a normal conversation turn, buffered chunks are flushed through.
+// At most Config.MaxBufferedChunks are held; see hostStreamBuffer.
+//
+// When ctx is done before the call returns (the SSE client disconnected), the call is
+// abandoned: buffered chunks are dropped, later chunks go nowhere, and
+// types.ErrInteractCancelled is returned.
 func (m *Manager) callHostAgentStreamRaw(ctx *types.Context, agentID string, input *types.HostInput, chatID string, robot *types.Robot, onMessage agentcontext.OnMessageFunc) (*types.HostOutput, error) {
 	inputJSON, err := json.Marshal(input)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal host input: %w", err)
 	}
 
-	var (
-		bufferedChunks  []*message.Message
-		buffering       bool
-		accumulatedText string
-		lastTextMsgID   string
-	)
-
-	wrappedOnMessage := func(msg *message.Message) int {
-		if msg == nil {
-			return onMessage(msg)
-		}
-
-		// Only intercept text type messages with delta content
-		if msg.Type != message.TypeText || !msg.Delta {
-			return onMessage(msg)
-		}
-
-		if msg.MessageID != "" {
-			lastTextMsgID = msg.MessageID
-		}
+	buffer := newHostStreamBuffer(onMessage, m.config.MaxBufferedChunks)
 
-		// Extract the text content from this chunk
-		chunkText := ""
-		if msg.Props != nil {
-			if c, ok := msg.Props["content"].(string); ok {
-				chunkText = c
-			}
-		}
-		accumulatedText += chunkText
-
-		// Decide whether to buffer: check accumulated text so far
-		trimmed := strings.TrimSpace(accumulatedText)
-		if !buffering && len(trimmed) > 0 {
-			if trimmed[0] == '{' || strings.HasPrefix(trimmed, "```") {
-				buffering = true
-			}
-		}
-
-		if buffering {
-			bufferedChunks = append(bufferedChunks, msg)
+	// The caller streams from its own goroutine; once cancelled, nothing reaches the client
+	var mu sync.Mutex
+	cancelled := false
+	relay := func(msg *message.Message) int {
+		mu.Lock()
+		defer mu.Unlock()
+		if cancelled {
 			return 0
 		}
-
-		return onMessage(msg)
-	}
-
-	caller := standard.NewConversationCaller(chatID)
-	caller.Workspace = robot.Workspace
-	result, err := caller.CallWithMessagesStreamRaw(ctx, agentID, string(inputJSON), wrappedOnMessage)
-	if err != nil {
-		return nil, fmt.Errorf("host agent (%s) call failed: %w", agentID, err)
+		return buffer.OnMessage(msg)
+	}
+	abandon := func() error {
+		mu.Lock()
+		cancelled = true
+		buffer.Discard()
+		mu.Unlock()
+		log.Info("Host Agent (%s) stream abandoned: client disconnected (chat=%s)", agentID, chatID)
+		return types.ErrInteractCancelled
+	}
+
+	type callOutcome struct {
+		result *standard.CallResult
+		err    error
+	}
+	done := make(chan callOutcome, 1)
+	caller := newHostStreamCaller(chatID, robot)
+	go func() {
+		result, err := caller.CallWithMessagesStreamRaw(ctx, agentID, string(inputJSON), relay)
+		done <- callOutcome{result: result, err: err}
+	}()
+
+	var outcome callOutcome
+	select {
+	case outcome = <-done:
+	case <-ctx.Done():
+		return nil, abandon()
+	}
+	if outcome.err != nil {
+		if ctx.Err() != nil {
+			return nil, abandon()
+		}
+		return nil, fmt.Errorf("host agent (%s) call failed: %w", agentID, outcome.err)
 	}
+	result := outcome.result
 
 	output, err := m.parseHostAgentResult(result)
 	if err != nil {
 		return nil, err
 	}
 
-	if output.Action != "" && lastTextMsgID != "" {
-		// Decision detected — discard buffered JSON chunks, send reply text
-		onMessage(&message.Message{
-			Type:      message.TypeText,
-			MessageID: lastTextMsgID,
-			Props:     map[string]interface{}{"content": output.Reply},
-			Delta:     false,
-		})
-	} else if len(bufferedChunks) > 0 {
-		// Not a decision — flush all buffered chunks to the frontend
-		for _, chunk := range bufferedChunks {
-			if onMessage(chunk) != 0 {
-				break
-			}
-		}
-	}
+	buffer.Finish(output.Action != "", output.Reply)
 
 	return output, nil
 }
@@ -211,3 +211,30 @@ func TestParseHostAgentResult(t *testing.T) {
 		assert.True(t, output.WaitForMore)
 	})
 }
+
+func TestManagerSnapshots(t *testing.T) {
+	m := manager.New()
+
+	busy := &types.Robot{MemberID: "robot_busy", TeamID: "team_a", Status: types.RobotWorking}
+	busy.AddExecution(&types.Execution{ID: "exec_1", Status: types.ExecRunning, Name: "Digest"})
+	busy.AddExecution(&types.Execution{ID: "exec_2", Status: types.ExecWaiting})
+	m.Cache().Add(busy)
+	m.Cache().Add(&types.Robot{MemberID: "robot_idle", TeamID: "team_a", Status: types.RobotIdle})
+	m.Cache().Add(&types.Robot{MemberID: "robot_other", TeamID: "team_b", Status: types.RobotIdle})
+
+	snapshots := manager.New().Snapshots("team_a")
+	assert.Empty(t, snapshots, "no robots cached")
+
+	snapshots = m.Snapshots("team_a")
+	require.Len(t, snapshots, 2)
+	byID := map[string]*types.RobotStatusSnapshot{}
+	for _, snapshot := range snapshots {
+		byID[snapshot.MemberID] = snapshot
+	}
+	require.Contains(t, byID, "robot_busy")
+	assert.Equal(t, 1, byID["robot_busy"].ActiveCount)
+	assert.Equal(t, 1, byID["robot_busy"].WaitingCount)
+	assert.Zero(t, byID["robot_busy"].QueuedCount, "queued counts the robot's own jobs, not the queue size")
+	assert.Len(t, byID["robot_busy"].ActiveExecs, 2)
+	assert.Contains(t, byID, "robot_idle")
+}
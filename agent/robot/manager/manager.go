@@ -6,10 +6,12 @@ import (
 	"sync"
 	"time"
 
+	"github.com/yaoapp/kun/log"
 	"github.com/yaoapp/yao/agent/robot/cache"
 	"github.com/yaoapp/yao/agent/robot/events"
 	"github.com/yaoapp/yao/agent/robot/executor"
 	"github.com/yaoapp/yao/agent/robot/pool"
+	"github.com/yaoapp/yao/agent/robot/store"
 	"github.com/yaoapp/yao/agent/robot/trigger"
 	"github.com/yaoapp/yao/agent/robot/types"
 	"github.com/yaoapp/yao/event"
@@ -26,13 +28,27 @@ type Config struct {
 	TickInterval time.Duration  // how often to check clock triggers (default: 1 minute)
 	PoolConfig   *pool.Config   // worker pool configuration
 	Executor     types.Executor // optional: custom executor (default: real executor)
+
+	// Autonomous scheduling
+	MaxAutonomous      int           // max concurrent autonomous (clock) executions across robots (0 = unlimited)
+	AutonomousCooldown time.Duration // min rest between a robot's autonomous runs, from the end of the last one
+
+	// UncancelWindow is how long a cancelled waiting/confirming execution can be
+	// restored with UncancelExecution (default: 30s, negative disables)
+	UncancelWindow time.Duration
+
+	// MaxBufferedChunks caps the Host Agent text chunks held back while the reply
+	// looks like decision JSON (default: 2048); past it they are streamed through
+	MaxBufferedChunks int
 }
 
 // DefaultConfig returns default manager configuration
 func DefaultConfig() *Config {
 	return &Config{
-		TickInterval: DefaultTickInterval,
-		PoolConfig:   pool.DefaultConfig(),
+		TickInterval:      DefaultTickInterval,
+		PoolConfig:        pool.DefaultConfig(),
+		UncancelWindow:    DefaultUncancelWindow,
+		MaxBufferedChunks: DefaultMaxBufferedChunks,
 	}
 }
 
@@ -47,6 +63,27 @@ type Manager struct {
 	// Execution control for pause/resume/stop
 	execController *trigger.ExecutionController
 
+	// Concurrency limit and cooldown for clock triggers
+	scheduler *scheduler
+
+	// Persists next_run_at / cooldown_until for the database-side due query
+	robotStore *store.RobotStore
+
+	// Robot-wide pauses: memberID -> executions paused by PauseRobot
+	robotPauses map[string][]string
+	pauseMu     sync.Mutex
+
+	// Cancellations that UncancelExecution can still undo: execID -> prior state
+	recentlyCancelled map[string]*cancelledExec
+	cancelMu          sync.Mutex
+
+	// Batch triggers: active batches (dispatch order) and execID -> batchID index
+	batchStore  *store.BatchStore
+	batches     map[string]*types.Batch
+	batchOrder  []string
+	batchByExec map[string]string
+	batchMu     sync.Mutex
+
 	// Ticker for clock trigger checking
 	ticker     *time.Ticker
 	tickerDone chan struct{}
@@ -75,6 +112,12 @@ func NewWithConfig(config *Config) *Manager {
 	if config.TickInterval <= 0 {
 		config.TickInterval = DefaultTickInterval
 	}
+	if config.UncancelWindow == 0 {
+		config.UncancelWindow = DefaultUncancelWindow
+	}
+	if config.MaxBufferedChunks <= 0 {
+		config.MaxBufferedChunks = DefaultMaxBufferedChunks
+	}
 
 	// Create components
 	c := cache.New()
@@ -92,6 +135,10 @@ func NewWithConfig(config *Config) *Manager {
 	// Wire up pool with executor
 	p.SetExecutor(e)
 
+	// Count max_daily_executions from the execution store, shared by every instance
+	executions := store.NewExecutionStore()
+	p.SetDailyUsage(executions.ExecutionIDsSince)
+
 	// Create shared executor instances for each mode
 	// These are reused across all executions to maintain accurate counters
 	dryRunExecutor := executor.NewDryRun()
@@ -111,11 +158,18 @@ func NewWithConfig(config *Config) *Manager {
 	})
 
 	return &Manager{
-		config:         config,
-		cache:          c,
-		pool:           p,
-		executor:       e,
-		execController: ec,
+		config:            config,
+		cache:             c,
+		pool:              p,
+		executor:          e,
+		execController:    ec,
+		scheduler:         newScheduler(config.MaxAutonomous, config.AutonomousCooldown),
+		robotStore:        store.NewRobotStore(),
+		robotPauses:       map[string][]string{},
+		recentlyCancelled: map[string]*cancelledExec{},
+		batchStore:        store.NewBatchStore(),
+		batches:           map[string]*types.Batch{},
+		batchByExec:       map[string]string{},
 	}
 }
 
@@ -143,14 +197,29 @@ func (m *Manager) Start() error {
 	// Recover non-terminal executions from previous server lifecycle
 	pendingNotifications := m.recoverExecutions(m.ctx)
 
+	// Reload unfinished batches so their remaining items keep being dispatched
+	m.recoverBatches(m.ctx)
+
 	// Set completion callback to clean up ExecutionController when execution finishes
 	m.pool.SetOnComplete(func(execID, memberID string, status types.ExecStatus) {
 		// Remove from ExecutionController (cleans up in-memory tracking)
 		m.execController.Untrack(execID)
+		// Free the autonomous slot and start the robot's cooldown
+		finishedAt := time.Now()
+		if m.scheduler.finish(execID, finishedAt) && m.scheduler.cooldown > 0 {
+			m.recordCooldown(m.ctx, memberID, finishedAt.Add(m.scheduler.cooldown))
+		}
 		// Remove from robot's in-memory execution list
 		if robot := m.cache.Get(memberID); robot != nil {
 			robot.RemoveExecution(execID)
 		}
+		// Record batch item outcome and dispatch the next queued items
+		m.onBatchExecutionComplete(execID, status)
+	})
+
+	// A suspended execution waits for a human, so it no longer counts against the autonomous limit
+	m.pool.SetOnSuspend(func(execID, memberID string) {
+		m.scheduler.release(execID)
 	})
 
 	// Start worker pool
@@ -169,6 +238,8 @@ func (m *Manager) Start() error {
 
 	m.started = true
 
+	go m.feedBatches()
+
 	if len(pendingNotifications) > 0 {
 		go func() {
 			for _, n := range pendingNotifications {
@@ -231,8 +302,9 @@ func (m *Manager) tickerLoop() {
 // Tick processes a clock tick
 // 1. Get all cached robots
 // 2. For each robot with clock trigger enabled
-// 3. Check if should execute based on clock config
-// 4. Submit to pool with robot's own identity
+// 3. Check if should execute based on clock config (or was deferred last tick)
+// 4. Skip robots in cooldown, defer robots over the concurrency limit
+// 5. Submit to pool with robot's own identity, oldest activity first
 func (m *Manager) Tick(parentCtx context.Context, now time.Time) error {
 	m.mu.RLock()
 	if !m.started {
@@ -243,6 +315,8 @@ func (m *Manager) Tick(parentCtx context.Context, now time.Time) error {
 
 	// Get autonomous robots for clock trigger check
 	robots := m.cache.ListAutonomous()
+	sortByActivity(robots)
+	deferred := m.scheduler.beginTick(now)
 
 	for _, robot := range robots {
 		// Skip if robot is not active
@@ -263,8 +337,18 @@ func (m *Manager) Tick(parentCtx context.Context, now time.Time) error {
 			continue
 		}
 
-		// Check if should trigger based on clock config
-		if !m.shouldTrigger(robot, now) {
+		// Check if should trigger based on clock config; deferred robots stay due
+		deferredSince, wasDeferred := deferred[robot.MemberID]
+		if !wasDeferred && !m.shouldTrigger(robot, now) {
+			continue
+		}
+
+		if m.scheduler.coolingDown(robot.MemberID, now) {
+			continue
+		}
+
+		// A robot with no free quota slot should not hold or wait for an autonomous slot
+		if !robot.CanRun() {
 			continue
 		}
 
@@ -277,6 +361,15 @@ func (m *Manager) Tick(parentCtx context.Context, now time.Time) error {
 		// Pre-generate execution ID
 		execID := pool.GenerateExecID()
 
+		// Take an autonomous slot; over the limit the robot waits for the next tick
+		if !m.scheduler.reserve(execID, robot.MemberID) {
+			if !wasDeferred {
+				deferredSince = now
+			}
+			m.scheduler.deferRobot(robot.MemberID, deferredSince)
+			continue
+		}
+
 		// Pre-acquire execution slot to prevent daemon-mode race condition:
 		// Without this, CanRun() stays true between Tick and worker dequeue,
 		// causing duplicate submissions on every tick interval.
@@ -289,6 +382,7 @@ func (m *Manager) Tick(parentCtx context.Context, now time.Time) error {
 			StartTime:   now,
 		}
 		if !robot.TryAcquireSlot(preExec) {
+			m.scheduler.release(execID)
 			continue
 		}
 
@@ -300,23 +394,49 @@ func (m *Manager) Tick(parentCtx context.Context, now time.Time) error {
 		execCtx := types.NewContext(ctrlExec.Context(), robotAuth)
 
 		// Create clock context for P0 inspiration
-		clockCtx := types.NewClockContext(now, robot.Config.Clock.TZ)
+		clockCtx := types.NewClockContext(now, robot.Location().String())
 
 		// Submit to pool with the cancellable context and execution control
 		_, err := m.pool.SubmitWithID(execCtx, robot, types.TriggerClock, clockCtx, execID, ctrlExec)
 		if err != nil {
 			robot.RemoveExecution(execID)
 			m.execController.Untrack(execID)
+			m.scheduler.release(execID)
 			continue
 		}
 
-		// Update robot's last run time
+		// Update robot's last run time and when the clock is due next
 		robot.LastRun = now
+		robot.NextRun = robot.Config.Clock.NextRunAfter(now, robot.Location())
+		m.recordNextRun(parentCtx, robot)
 	}
 
+	// Settle batch items finished outside the pool, then dispatch queued items
+	m.reconcileBatches(parentCtx)
+	m.feedBatches()
+
 	return nil
 }
 
+// recordNextRun persists robot.NextRun; a zero time (daemon mode) stores NULL = due now
+func (m *Manager) recordNextRun(ctx context.Context, robot *types.Robot) {
+	var at *time.Time
+	if !robot.NextRun.IsZero() {
+		next := robot.NextRun
+		at = &next
+	}
+	if err := m.robotStore.UpdateNextRun(ctx, robot.MemberID, at); err != nil {
+		log.With(log.F{"member_id": robot.MemberID, "error": err}).Warn("Failed to persist next run time")
+	}
+}
+
+// recordCooldown persists the end of a robot's rest period after an autonomous run
+func (m *Manager) recordCooldown(ctx context.Context, memberID string, until time.Time) {
+	if err := m.robotStore.UpdateCooldown(ctx, memberID, &until); err != nil {
+		log.With(log.F{"member_id": memberID, "error": err}).Warn("Failed to persist cooldown")
+	}
+}
+
 // buildRobotAuth creates AuthorizedInfo for a robot's own identity
 // Used when robot executes autonomously (clock trigger)
 func (m *Manager) buildRobotAuth(robot *types.Robot) *oauthtypes.AuthorizedInfo {
@@ -335,9 +455,8 @@ func (m *Manager) shouldTrigger(robot *types.Robot, now time.Time) bool {
 		return false
 	}
 
-	// Get time in robot's timezone
-	loc := clock.GetLocation()
-	localNow := now.In(loc)
+	// Get time in robot's timezone (clock tz > robot timezone > server zone)
+	localNow := now.In(robot.Location())
 
 	switch clock.Mode {
 	case types.ClockTimes:
@@ -403,24 +522,7 @@ func (m *Manager) shouldTriggerDaemon(robot *types.Robot, clock *types.Clock, no
 
 // matchesDay checks if current day matches the configured days
 func (m *Manager) matchesDay(clock *types.Clock, now time.Time) bool {
-	// Empty days or ["*"] means all days
-	if len(clock.Days) == 0 {
-		return true
-	}
-
-	for _, day := range clock.Days {
-		if day == "*" {
-			return true
-		}
-		// Match day name (Mon, Tue, Wed, Thu, Fri, Sat, Sun)
-		// or full name (Monday, Tuesday, etc.)
-		weekday := now.Weekday().String()
-		shortDay := weekday[:3] // Mon, Tue, etc.
-		if day == weekday || day == shortDay {
-			return true
-		}
-	}
-	return false
+	return clock.MatchesDay(now)
 }
 
 // TriggerManual manually triggers a robot execution (for testing or API calls)
@@ -635,7 +737,9 @@ func (m *Manager) HandleEvent(ctx *types.Context, req *types.EventRequest) (*typ
 
 // ==================== Execution Control ====================
 
-// PauseExecution pauses a running execution
+// PauseExecution holds a running execution without cancelling it: the phase loop
+// blocks in WaitIfPaused before its next phase, and the paused status is persisted
+// so the record reflects the hold until ResumeExecution.
 func (m *Manager) PauseExecution(ctx *types.Context, execID string) error {
 	// Get execution info before pausing
 	exec := m.execController.Get(execID)
@@ -648,6 +752,15 @@ func (m *Manager) PauseExecution(ctx *types.Context, execID string) error {
 		return err
 	}
 
+	// Persist the paused status; undo the pause if the record can't follow
+	if err := store.NewExecutionStore().UpdateStatus(ctx.Context, execID, types.ExecPaused, ""); err != nil {
+		if resumeErr := m.execController.Resume(execID); resumeErr != nil {
+			log.With(log.F{"execution_id": execID, "error": resumeErr}).Warn("Failed to undo pause")
+		}
+		return fmt.Errorf("failed to persist paused status: %w", err)
+	}
+	exec.UpdateStatus(types.ExecPaused)
+
 	// Remove from robot's in-memory execution list (paused doesn't count as running)
 	if robot := m.cache.Get(exec.MemberID); robot != nil {
 		robot.RemoveExecution(execID)
@@ -656,7 +769,8 @@ func (m *Manager) PauseExecution(ctx *types.Context, execID string) error {
 	return nil
 }
 
-// ResumeExecution resumes a paused execution
+// ResumeExecution lifts a PauseExecution: the phase loop continues and the record
+// is set back to running
 func (m *Manager) ResumeExecution(ctx *types.Context, execID string) error {
 	// Get execution info before resuming
 	exec := m.execController.Get(execID)
@@ -668,6 +782,12 @@ func (m *Manager) ResumeExecution(ctx *types.Context, execID string) error {
 	if err := m.execController.Resume(execID); err != nil {
 		return err
 	}
+	exec.UpdateStatus(types.ExecRunning)
+
+	// The execution is already running again; a stale record only affects listings
+	if err := store.NewExecutionStore().UpdateStatus(ctx.Context, execID, types.ExecRunning, ""); err != nil {
+		log.With(log.F{"execution_id": execID, "error": err}).Warn("Failed to persist running status")
+	}
 
 	// Add back to robot's in-memory execution list
 	if robot := m.cache.Get(exec.MemberID); robot != nil {
@@ -849,3 +969,14 @@ func (m *Manager) Queued() int {
 func (m *Manager) CachedRobots() int {
 	return m.cache.Count()
 }
+
+// Snapshots returns the live status of every cached robot of a team, one snapshot per
+// robot. Robots not loaded in the cache are left out.
+func (m *Manager) Snapshots(teamID string) []*types.RobotStatusSnapshot {
+	robots := m.cache.List(teamID)
+	snapshots := make([]*types.RobotStatusSnapshot, 0, len(robots))
+	for _, robot := range robots {
+		snapshots = append(snapshots, m.buildRobotStatusSnapshot(robot))
+	}
+	return snapshots
+}
@@ -0,0 +1,115 @@
+package manager
+
+import (
+	"fmt"
+
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/agent/robot/types"
+)
+
+// PauseRobot puts a robot on hold for maintenance: every running execution is paused
+// through its ExecutionControl and new submissions are refused until ResumeRobot.
+// The paused status is persisted to robot_status, so cache reloads and restarts keep
+// the robot on hold. Unlike offline, nothing is cancelled; unlike PauseExecution, it
+// covers the whole robot. Executions paused individually beforehand stay paused on resume.
+func (m *Manager) PauseRobot(ctx *types.Context, memberID string) error {
+	m.mu.RLock()
+	if !m.started {
+		m.mu.RUnlock()
+		return fmt.Errorf("manager not started")
+	}
+	m.mu.RUnlock()
+
+	if memberID == "" {
+		return fmt.Errorf("member_id is required")
+	}
+
+	// Persist first: new submissions are refused from here on, and a reload reads paused
+	if err := m.robotStore.UpdateStatus(ctx.Context, memberID, types.RobotPaused); err != nil {
+		return err
+	}
+	if robot := m.cache.Get(memberID); robot != nil {
+		robot.Status = types.RobotPaused
+	}
+
+	var paused []string
+	for _, exec := range m.execController.ListByMember(memberID) {
+		if exec.IsPaused() || exec.IsCancelled() {
+			continue
+		}
+		if err := m.PauseExecution(ctx, exec.ID); err != nil {
+			log.With(log.F{"member_id": memberID, "execution_id": exec.ID, "error": err}).Warn("Failed to pause execution for robot pause")
+			continue
+		}
+		paused = append(paused, exec.ID)
+	}
+
+	m.pauseMu.Lock()
+	m.robotPauses[memberID] = append(m.robotPauses[memberID], paused...)
+	m.pauseMu.Unlock()
+
+	log.With(log.F{"member_id": memberID, "executions": len(paused)}).Info("Robot paused")
+	return nil
+}
+
+// ResumeRobot lifts a PauseRobot: the robot accepts submissions again and the
+// executions paused by PauseRobot continue where they stopped.
+func (m *Manager) ResumeRobot(ctx *types.Context, memberID string) error {
+	m.mu.RLock()
+	if !m.started {
+		m.mu.RUnlock()
+		return fmt.Errorf("manager not started")
+	}
+	m.mu.RUnlock()
+
+	if memberID == "" {
+		return fmt.Errorf("member_id is required")
+	}
+
+	if err := m.robotStore.UpdateStatus(ctx.Context, memberID, types.RobotIdle); err != nil {
+		return err
+	}
+
+	m.pauseMu.Lock()
+	paused := m.robotPauses[memberID]
+	delete(m.robotPauses, memberID)
+	m.pauseMu.Unlock()
+
+	robot := m.cache.Get(memberID)
+	if robot != nil {
+		robot.Status = types.RobotIdle
+	}
+
+	resumed := 0
+	for _, execID := range paused {
+		// Gone meanwhile: stopped, reset or ended by a restart
+		if exec := m.execController.Get(execID); exec == nil || !exec.IsPaused() {
+			continue
+		}
+		if err := m.ResumeExecution(ctx, execID); err != nil {
+			log.With(log.F{"member_id": memberID, "execution_id": execID, "error": err}).Warn("Failed to resume execution for robot resume")
+			continue
+		}
+		resumed++
+	}
+
+	// Executions continue running: the robot is working again, not idle
+	if resumed > 0 {
+		if robot != nil {
+			robot.Status = types.RobotWorking
+		}
+		if err := m.robotStore.UpdateStatus(ctx.Context, memberID, types.RobotWorking); err != nil {
+			log.With(log.F{"member_id": memberID, "error": err}).Warn("Failed to update robot status to working")
+		}
+	}
+
+	log.With(log.F{"member_id": memberID, "executions": resumed}).Info("Robot resumed")
+	return nil
+}
+
+// RobotPausedExecutions returns the executions held by PauseRobot for memberID
+func (m *Manager) RobotPausedExecutions(memberID string) []string {
+	m.pauseMu.Lock()
+	defer m.pauseMu.Unlock()
+	return append([]string{}, m.robotPauses[memberID]...)
+}
@@ -0,0 +1,112 @@
+//go:build integration
+
+package manager_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/yao/agent/robot/executor"
+	"github.com/yaoapp/yao/agent/robot/manager"
+	"github.com/yaoapp/yao/agent/robot/pool"
+	"github.com/yaoapp/yao/agent/robot/types"
+	"github.com/yaoapp/yao/unit-test/agent/testprepare"
+)
+
+func TestManagerPauseRobot(t *testing.T) {
+	testprepare.PrepareSandbox(t)
+
+	exec := &blockingExecutor{DryRunExecutor: executor.NewDryRun(), release: make(chan struct{})}
+	m := manager.NewWithConfig(&manager.Config{
+		TickInterval: time.Hour,
+		PoolConfig:   &pool.Config{WorkerSize: 4, QueueSize: 10},
+		Executor:     exec,
+	})
+	require.NoError(t, m.Start())
+	defer m.Stop()
+	defer close(exec.release)
+
+	robot := &types.Robot{
+		MemberID: "_test_pause_robot",
+		TeamID:   "team_pause_test",
+		Status:   types.RobotIdle,
+		Config:   &types.Config{Quota: &types.Quota{Max: 3}},
+	}
+	m.Cache().Add(robot)
+	ctx := types.NewContext(nil, nil)
+
+	first, err := m.TriggerManual(ctx, robot.MemberID, types.TriggerHuman, nil)
+	require.NoError(t, err)
+	second, err := m.TriggerManual(ctx, robot.MemberID, types.TriggerHuman, nil)
+	require.NoError(t, err)
+
+	// An execution paused on its own before the robot pause is left alone on resume
+	require.NoError(t, m.PauseExecution(ctx, second))
+
+	require.NoError(t, m.PauseRobot(ctx, robot.MemberID))
+	assert.Equal(t, types.RobotPaused, robot.Status)
+	assert.Equal(t, []string{first}, m.RobotPausedExecutions(robot.MemberID))
+
+	status, err := m.GetExecutionStatus(first)
+	require.NoError(t, err)
+	assert.True(t, status.IsPaused())
+
+	_, err = m.TriggerManual(ctx, robot.MemberID, types.TriggerHuman, nil)
+	assert.ErrorIs(t, err, types.ErrRobotPaused)
+
+	require.NoError(t, m.ResumeRobot(ctx, robot.MemberID))
+	assert.NotEqual(t, types.RobotPaused, robot.Status)
+	assert.Empty(t, m.RobotPausedExecutions(robot.MemberID))
+
+	status, err = m.GetExecutionStatus(first)
+	require.NoError(t, err)
+	assert.False(t, status.IsPaused())
+	status, err = m.GetExecutionStatus(second)
+	require.NoError(t, err)
+	assert.True(t, status.IsPaused(), "individually paused execution stays paused")
+
+	_, err = m.TriggerManual(ctx, robot.MemberID, types.TriggerHuman, nil)
+	assert.NoError(t, err)
+}
+
+func TestManagerPauseExecution(t *testing.T) {
+	testprepare.PrepareSandbox(t)
+
+	exec := &blockingExecutor{DryRunExecutor: executor.NewDryRun(), release: make(chan struct{})}
+	m := manager.NewWithConfig(&manager.Config{
+		TickInterval: time.Hour,
+		PoolConfig:   &pool.Config{WorkerSize: 2, QueueSize: 10},
+		Executor:     exec,
+	})
+	require.NoError(t, m.Start())
+	defer m.Stop()
+	defer close(exec.release)
+
+	robot := &types.Robot{
+		MemberID: "_test_pause_execution",
+		TeamID:   "team_pause_test",
+		Status:   types.RobotIdle,
+		Config:   &types.Config{Quota: &types.Quota{Max: 2}},
+	}
+	m.Cache().Add(robot)
+	ctx := types.NewContext(nil, nil)
+
+	execID, err := m.TriggerManual(ctx, robot.MemberID, types.TriggerHuman, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, m.PauseExecution(ctx, execID))
+	status, err := m.GetExecutionStatus(execID)
+	require.NoError(t, err)
+	assert.True(t, status.IsPaused())
+	assert.Equal(t, types.ExecPaused, status.Status)
+	assert.Error(t, m.PauseExecution(ctx, execID), "pausing twice fails")
+
+	require.NoError(t, m.ResumeExecution(ctx, execID))
+	assert.False(t, status.IsPaused())
+	assert.Equal(t, types.ExecRunning, status.Status)
+	assert.Error(t, m.ResumeExecution(ctx, execID), "resuming a running execution fails")
+
+	assert.Error(t, m.PauseExecution(ctx, "unknown_exec"))
+}
@@ -0,0 +1,177 @@
+package manager
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yaoapp/kun/log"
+	agentcontext "github.com/yaoapp/yao/agent/context"
+	"github.com/yaoapp/yao/agent/robot/executor/standard"
+	"github.com/yaoapp/yao/agent/robot/store"
+	"github.com/yaoapp/yao/agent/robot/types"
+	"github.com/yaoapp/yao/agent/robot/utils"
+)
+
+// GetPlan returns the goals and tasks of a confirming execution in editable form.
+func (m *Manager) GetPlan(ctx *types.Context, execID string) (*types.ExecutionPlan, error) {
+	record, err := m.loadConfirmingRecord(ctx, execID)
+	if err != nil {
+		return nil, err
+	}
+	return planFromRecord(record), nil
+}
+
+// UpdatePlan replaces the plan of a confirming execution with a structured edit.
+//
+// The edit is a full replace (nothing has run while confirming). plan.Version must be
+// the version the edit was based on; a concurrent edit or Host Agent adjustment in
+// between yields types.ErrPlanVersionConflict. Goals may be omitted to keep the current
+// ones. On confirm the executor finds goals and tasks populated and skips regenerating
+// them, so the edited plan runs verbatim.
+func (m *Manager) UpdatePlan(ctx *types.Context, execID string, plan *types.ExecutionPlan) (*types.ExecutionPlan, error) {
+	if plan == nil {
+		return nil, fmt.Errorf("%w: plan is required", types.ErrInvalidPlan)
+	}
+
+	record, err := m.loadConfirmingRecord(ctx, execID)
+	if err != nil {
+		return nil, err
+	}
+
+	robot, _, err := m.getOrLoadRobot(ctx, record.MemberID)
+	if err != nil {
+		return nil, fmt.Errorf("robot not found: %w", err)
+	}
+
+	goals := plan.Goals
+	if goals == nil || goals.Content == "" {
+		goals = record.Goals
+	}
+	if goals == nil || goals.Content == "" {
+		return nil, fmt.Errorf("%w: goals are required", types.ErrInvalidPlan)
+	}
+
+	tasks := normalizePlanTasks(record.Tasks, plan.Tasks)
+	if err := validatePlanTasks(tasks, robot); err != nil {
+		return nil, err
+	}
+
+	execStore := store.NewExecutionStore()
+	version, err := execStore.UpdatePlan(ctx.Context, execID, plan.Version, goals, tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	// Edits are kept on the execution, attributed to whoever made them
+	edit := types.PlanEdit{
+		Editor:   ctx.UserID(),
+		Version:  version,
+		Tasks:    len(tasks),
+		EditedAt: time.Now(),
+	}
+	if err := execStore.AddPlanEdit(ctx.Context, execID, edit); err != nil {
+		log.Warn("[plan] failed to record plan edit on execution %s: %v", execID, err)
+	}
+
+	return &types.ExecutionPlan{
+		ExecutionID: execID,
+		Version:     version,
+		Goals:       goals,
+		Tasks:       tasks,
+	}, nil
+}
+
+// loadConfirmingRecord loads an execution and checks its plan is still editable
+func (m *Manager) loadConfirmingRecord(ctx *types.Context, execID string) (*store.ExecutionRecord, error) {
+	m.mu.RLock()
+	if !m.started {
+		m.mu.RUnlock()
+		return nil, fmt.Errorf("manager not started")
+	}
+	m.mu.RUnlock()
+
+	if execID == "" {
+		return nil, fmt.Errorf("execution_id is required")
+	}
+
+	record, err := store.NewExecutionStore().Get(ctx.Context, execID)
+	if err != nil || record == nil {
+		return nil, fmt.Errorf("execution not found: %s", execID)
+	}
+	if record.Status != types.ExecConfirming {
+		return nil, fmt.Errorf("%w: execution %s is %s", types.ErrPlanNotEditable, execID, record.Status)
+	}
+	return record, nil
+}
+
+func planFromRecord(record *store.ExecutionRecord) *types.ExecutionPlan {
+	tasks := record.Tasks
+	if tasks == nil {
+		tasks = []types.Task{}
+	}
+	return &types.ExecutionPlan{
+		ExecutionID: record.ExecutionID,
+		Version:     record.PlanVersion,
+		Goals:       record.Goals,
+		Tasks:       tasks,
+	}
+}
+
+// normalizePlanTasks resets runtime fields of edited tasks: order follows list position,
+// every task starts pending, new tasks get an ID and are marked as human-sourced.
+func normalizePlanTasks(current, edited []types.Task) []types.Task {
+	sources := make(map[string]types.TaskSource, len(current))
+	for _, t := range current {
+		sources[t.ID] = t.Source
+	}
+
+	tasks := make([]types.Task, len(edited))
+	for i, t := range edited {
+		if t.ID == "" {
+			t.ID = fmt.Sprintf("edited-%s", utils.NewID()[:8])
+		}
+		if src, ok := sources[t.ID]; ok && t.Source == "" {
+			t.Source = src
+		}
+		if t.Source == "" {
+			t.Source = types.TaskSourceHuman
+		}
+		if t.ExecutorType == "" {
+			t.ExecutorType = types.ExecutorAssistant
+		}
+		if t.Description != "" && len(t.Messages) == 0 {
+			t.Messages = []agentcontext.Message{{Role: "user", Content: t.Description}}
+		}
+		t.Order = i
+		t.Status = types.TaskPending
+		t.StartTime = nil
+		t.EndTime = nil
+		tasks[i] = t
+	}
+	return tasks
+}
+
+// validatePlanTasks checks an edited task list against the robot's configured resources.
+// Unlike planner output, unknown executors are rejected rather than reported as warnings.
+func validatePlanTasks(tasks []types.Task, robot *types.Robot) error {
+	if err := standard.ValidateTasks(tasks); err != nil {
+		return fmt.Errorf("%w: %s", types.ErrInvalidPlan, err.Error())
+	}
+	for i := range tasks {
+		task := &tasks[i]
+		if !standard.IsValidExecutorType(task.ExecutorType) {
+			return fmt.Errorf("%w: task %s: unknown executor type '%s'", types.ErrInvalidPlan, task.ID, task.ExecutorType)
+		}
+		if err := standard.ValidateMCPTask(task); err != nil {
+			return fmt.Errorf("%w: %s", types.ErrInvalidPlan, err.Error())
+		}
+		executorID := task.ExecutorID
+		if task.ExecutorType == types.ExecutorMCP {
+			executorID = task.MCPServer
+		}
+		if !standard.ValidateExecutorExists(executorID, task.ExecutorType, robot) {
+			return fmt.Errorf("%w: task %s: %s '%s' is not available to this robot", types.ErrInvalidPlan, task.ID, task.ExecutorType, executorID)
+		}
+	}
+	return nil
+}
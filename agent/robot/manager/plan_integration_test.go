@@ -0,0 +1,193 @@
+//go:build integration
+
+package manager_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	agentcontext "github.com/yaoapp/yao/agent/context"
+	"github.com/yaoapp/yao/agent/robot/executor"
+	"github.com/yaoapp/yao/agent/robot/manager"
+	"github.com/yaoapp/yao/agent/robot/pool"
+	"github.com/yaoapp/yao/agent/robot/store"
+	"github.com/yaoapp/yao/agent/robot/types"
+	"github.com/yaoapp/yao/unit-test/agent/testprepare"
+)
+
+const planTestPrefix = "_test_plan_"
+
+// planCaptureExecutor records the tasks found in the store when an execution starts,
+// i.e. what the standard executor would load and run after confirm
+type planCaptureExecutor struct {
+	*executor.DryRunExecutor
+	mu    sync.Mutex
+	tasks map[string][]types.Task
+}
+
+func (e *planCaptureExecutor) ExecuteWithControl(ctx *types.Context, robot *types.Robot, trigger types.TriggerType, data interface{}, execID string, control types.ExecutionControl) (*types.Execution, error) {
+	record, err := store.NewExecutionStore().Get(ctx.Context, execID)
+	if err == nil && record != nil {
+		e.mu.Lock()
+		e.tasks[execID] = record.Tasks
+		e.mu.Unlock()
+	}
+	return &types.Execution{ID: execID, MemberID: robot.MemberID, TeamID: robot.TeamID, Status: types.ExecCompleted}, nil
+}
+
+func (e *planCaptureExecutor) captured(execID string) ([]types.Task, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	tasks, ok := e.tasks[execID]
+	return tasks, ok
+}
+
+func saveConfirmingExec(t *testing.T, s *store.ExecutionStore, id, memberID string) {
+	t.Helper()
+	now := time.Now()
+	require.NoError(t, s.Save(context.Background(), &store.ExecutionRecord{
+		ExecutionID: planTestPrefix + id,
+		MemberID:    memberID,
+		TeamID:      "team_plan_test",
+		TriggerType: types.TriggerHuman,
+		Status:      types.ExecConfirming,
+		Phase:       types.PhaseGoals,
+		Goals:       &types.Goals{Content: "## Goals\n1. Weekly sales report"},
+		Tasks: []types.Task{
+			planTask("task-1", "agent.alpha", "Collect sales data"),
+			planTask("task-2", "agent.alpha", "Draft the report"),
+		},
+		StartTime: &now,
+	}))
+	t.Cleanup(func() { s.Delete(context.Background(), planTestPrefix+id) })
+}
+
+func planTask(id, agentID, text string) types.Task {
+	return types.Task{
+		ID:           id,
+		ExecutorType: types.ExecutorAssistant,
+		ExecutorID:   agentID,
+		Messages:     []agentcontext.Message{{Role: "user", Content: text}},
+		Source:       types.TaskSourceAuto,
+		Status:       types.TaskPending,
+	}
+}
+
+func TestManagerPlanEditing(t *testing.T) {
+	testprepare.PrepareSandbox(t)
+
+	exec := &planCaptureExecutor{DryRunExecutor: executor.NewDryRun(), tasks: map[string][]types.Task{}}
+	m := manager.NewWithConfig(&manager.Config{
+		TickInterval: time.Hour,
+		PoolConfig:   &pool.Config{WorkerSize: 2, QueueSize: 10},
+		Executor:     exec,
+	})
+	require.NoError(t, m.Start())
+	defer m.Stop()
+
+	robot := &types.Robot{
+		MemberID: planTestPrefix + "robot",
+		TeamID:   "team_plan_test",
+		Status:   types.RobotIdle,
+		Config: &types.Config{
+			Quota:     &types.Quota{Max: 2},
+			Resources: &types.Resources{Agents: []string{"agent.alpha", "agent.beta"}},
+		},
+	}
+	m.Cache().Add(robot)
+
+	s := store.NewExecutionStore()
+	ctx := types.NewContext(context.Background(), nil)
+	p := planTestPrefix
+
+	t.Run("edit then confirm runs exactly the edited tasks", func(t *testing.T) {
+		saveConfirmingExec(t, s, "confirm", robot.MemberID)
+
+		plan, err := m.GetPlan(ctx, p+"confirm")
+		require.NoError(t, err)
+		assert.Equal(t, 0, plan.Version)
+		require.Len(t, plan.Tasks, 2)
+
+		// Remove task-1, edit task-2, add a new one in front
+		edited := plan.Tasks[1]
+		edited.ExecutorID = "agent.beta"
+		updated, err := m.UpdatePlan(ctx, p+"confirm", &types.ExecutionPlan{
+			Version: plan.Version,
+			Tasks: []types.Task{
+				{ExecutorID: "agent.alpha", Description: "Check last week's numbers"},
+				edited,
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, updated.Version)
+		require.Len(t, updated.Tasks, 2)
+		assert.Equal(t, types.TaskSourceHuman, updated.Tasks[0].Source)
+		assert.Equal(t, types.TaskSourceAuto, updated.Tasks[1].Source)
+
+		record, err := s.Get(context.Background(), p+"confirm")
+		require.NoError(t, err)
+		require.NotEmpty(t, record.PlanEdits, "edit is recorded on the execution")
+		last := record.PlanEdits[len(record.PlanEdits)-1]
+		assert.Equal(t, 1, last.Version)
+		assert.Equal(t, 2, last.Tasks)
+		assert.Empty(t, record.Notes, "plan edits are not operator notes")
+
+		_, err = manager.ExportProcessHostAction(m, ctx, robot, record, &types.HostOutput{Action: types.HostActionConfirm}, s)
+		require.NoError(t, err)
+
+		var ran []types.Task
+		require.Eventually(t, func() bool {
+			var ok bool
+			ran, ok = exec.captured(p + "confirm")
+			return ok
+		}, 5*time.Second, 20*time.Millisecond)
+
+		require.Len(t, ran, 2)
+		for i := range ran {
+			assert.Equal(t, updated.Tasks[i].ID, ran[i].ID)
+			assert.Equal(t, updated.Tasks[i].ExecutorID, ran[i].ExecutorID)
+			assert.Equal(t, i, ran[i].Order)
+		}
+		assert.Equal(t, "agent.beta", ran[1].ExecutorID)
+	})
+
+	t.Run("unknown agent is rejected", func(t *testing.T) {
+		saveConfirmingExec(t, s, "unknown_agent", robot.MemberID)
+
+		_, err := m.UpdatePlan(ctx, p+"unknown_agent", &types.ExecutionPlan{
+			Tasks: []types.Task{planTask("task-1", "agent.nope", "Do something")},
+		})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, types.ErrInvalidPlan))
+
+		plan, err := m.GetPlan(ctx, p+"unknown_agent")
+		require.NoError(t, err)
+		assert.Equal(t, 0, plan.Version, "rejected edits leave the plan untouched")
+		assert.Equal(t, "agent.alpha", plan.Tasks[0].ExecutorID)
+	})
+
+	t.Run("stale version conflicts", func(t *testing.T) {
+		saveConfirmingExec(t, s, "stale", robot.MemberID)
+		tasks := []types.Task{planTask("task-1", "agent.alpha", "Only task")}
+
+		_, err := m.UpdatePlan(ctx, p+"stale", &types.ExecutionPlan{Version: 0, Tasks: tasks})
+		require.NoError(t, err)
+
+		_, err = m.UpdatePlan(ctx, p+"stale", &types.ExecutionPlan{Version: 0, Tasks: tasks})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, types.ErrPlanVersionConflict))
+	})
+
+	t.Run("only confirming executions are editable", func(t *testing.T) {
+		saveConfirmingExec(t, s, "running", robot.MemberID)
+		require.NoError(t, s.UpdateStatus(context.Background(), p+"running", types.ExecRunning, ""))
+
+		_, err := m.GetPlan(ctx, p+"running")
+		assert.True(t, errors.Is(err, types.ErrPlanNotEditable))
+	})
+}
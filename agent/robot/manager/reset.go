@@ -0,0 +1,113 @@
+package manager
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yaoapp/kun/log"
+	robotevents "github.com/yaoapp/yao/agent/robot/events"
+	"github.com/yaoapp/yao/agent/robot/store"
+	"github.com/yaoapp/yao/agent/robot/types"
+	"github.com/yaoapp/yao/event"
+)
+
+// resetTargets are the statuses a stuck execution may be forced into.
+// Only final states are allowed: forcing "running" or "waiting" would leave a
+// record no worker owns, which is exactly the state a reset is meant to clear.
+var resetTargets = map[types.ExecStatus]bool{
+	types.ExecFailed:    true,
+	types.ExecCancelled: true,
+}
+
+// ResetExecution forces a wedged execution (never progressing, stuck confirming or
+// waiting) into a final status. It stops the execution if it is still tracked,
+// releases its in-memory slots, clears the waiting and resume state, and records
+// who reset it and why as an execution note. Callers must restrict it to the
+// robot's owner or an admin.
+func (m *Manager) ResetExecution(ctx *types.Context, execID string, status types.ExecStatus, reason string) (*store.ExecutionRecord, error) {
+	m.mu.RLock()
+	if !m.started {
+		m.mu.RUnlock()
+		return nil, fmt.Errorf("manager not started")
+	}
+	m.mu.RUnlock()
+
+	if execID == "" {
+		return nil, fmt.Errorf("execution_id is required")
+	}
+	if !resetTargets[status] {
+		return nil, fmt.Errorf("%w: cannot reset to %q, use failed or cancelled", types.ErrInvalidReset, status)
+	}
+
+	execStore := store.NewExecutionStore()
+	record, err := execStore.Get(ctx.Context, execID)
+	if err != nil || record == nil {
+		return nil, fmt.Errorf("execution not found: %s", execID)
+	}
+	if record.Status.IsTerminal() {
+		return nil, fmt.Errorf("%w: execution %s already ended with status %s", types.ErrInvalidReset, execID, record.Status)
+	}
+
+	operator := ctx.UserID()
+	if operator == "" {
+		operator = "system"
+	}
+	reason = strings.TrimSpace(reason)
+	message := fmt.Sprintf("reset by %s from %s", operator, record.Status)
+	if reason != "" {
+		message += ": " + reason
+	}
+
+	log.With(log.F{
+		"execution_id": execID,
+		"member_id":    record.MemberID,
+		"team_id":      record.TeamID,
+		"from_status":  string(record.Status),
+		"to_status":    string(status),
+		"phase":        string(record.Phase),
+		"operator":     operator,
+		"reason":       reason,
+	}).Warn("[reset] forcing execution %s from %s to %s (operator=%s)", execID, record.Status, status, operator)
+
+	// Stop a still-tracked run so its worker does not keep writing to the record
+	if m.execController.Get(execID) != nil {
+		if err := m.execController.Stop(execID); err != nil {
+			log.Warn("[reset] execution %s: stop failed: %v", execID, err)
+		}
+	}
+	m.execController.Untrack(execID)
+	m.scheduler.release(execID)
+	if robot := m.cache.Get(record.MemberID); robot != nil {
+		robot.RemoveExecution(execID)
+	}
+
+	if err := execStore.ResetState(ctx.Context, execID, status, message); err != nil {
+		return nil, err
+	}
+
+	if _, err := execStore.AddNote(ctx.Context, execID, types.ExecutionNote{
+		Author:    operator,
+		Note:      strings.TrimSpace(fmt.Sprintf("Execution reset from %s to %s. %s", record.Status, status, reason)),
+		CreatedAt: time.Now(),
+	}); err != nil {
+		log.Warn("[reset] execution %s: failed to record audit note: %v", execID, err)
+	}
+
+	m.onBatchExecutionComplete(execID, status)
+
+	eventType := robotevents.ExecFailed
+	if status == types.ExecCancelled {
+		eventType = robotevents.ExecCancelled
+	}
+	event.Push(ctx.Context, eventType, robotevents.ExecPayload{
+		ExecutionID: execID,
+		MemberID:    record.MemberID,
+		TeamID:      record.TeamID,
+		Status:      string(status),
+		Error:       message,
+		ChatID:      record.ChatID,
+	})
+
+	return execStore.Get(ctx.Context, execID)
+}
@@ -0,0 +1,78 @@
+//go:build integration
+
+package manager_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/yao/agent/robot/manager"
+	"github.com/yaoapp/yao/agent/robot/store"
+	"github.com/yaoapp/yao/agent/robot/types"
+	oauthtypes "github.com/yaoapp/yao/openapi/oauth/types"
+	"github.com/yaoapp/yao/unit-test/agent/testprepare"
+)
+
+func TestResetExecution(t *testing.T) {
+	testprepare.PrepareSandbox(t)
+
+	m := manager.New()
+	require.NoError(t, m.Start())
+	defer m.Stop()
+
+	s := store.NewExecutionStore()
+	ctx := types.NewContext(context.Background(), &oauthtypes.AuthorizedInfo{UserID: "user_operator"})
+
+	t.Run("stuck waiting execution is cancelled and cleared", func(t *testing.T) {
+		saveChainedExec(t, s, "reset_waiting", "", types.ExecWaiting)
+		require.NoError(t, s.UpdateSuspendState(context.Background(), cascadeTestPrefix+"reset_waiting", "task-1", "Which region?",
+			&types.ResumeContext{TaskIndex: 1}))
+
+		record, err := m.ResetExecution(ctx, cascadeTestPrefix+"reset_waiting", types.ExecCancelled, "robot wedged on a dead question")
+		require.NoError(t, err)
+		assert.Equal(t, types.ExecCancelled, record.Status)
+		assert.Empty(t, record.WaitingTaskID)
+		assert.Empty(t, record.WaitingQuestion)
+		assert.Nil(t, record.WaitingSince)
+		assert.Nil(t, record.ResumeContext)
+		assert.NotNil(t, record.EndTime)
+		assert.Contains(t, record.Error, "reset by user_operator from waiting")
+
+		require.Len(t, record.Notes, 1)
+		assert.Equal(t, "user_operator", record.Notes[0].Author)
+		assert.Contains(t, record.Notes[0].Note, "robot wedged on a dead question")
+	})
+
+	t.Run("stuck confirming execution can be failed", func(t *testing.T) {
+		saveChainedExec(t, s, "reset_confirming", "", types.ExecConfirming)
+
+		record, err := m.ResetExecution(ctx, cascadeTestPrefix+"reset_confirming", types.ExecFailed, "")
+		require.NoError(t, err)
+		assert.Equal(t, types.ExecFailed, record.Status)
+	})
+
+	t.Run("non-final target status is rejected", func(t *testing.T) {
+		saveChainedExec(t, s, "reset_bad_target", "", types.ExecRunning)
+
+		for _, status := range []types.ExecStatus{types.ExecRunning, types.ExecWaiting, types.ExecCompleted, "bogus"} {
+			_, err := m.ResetExecution(ctx, cascadeTestPrefix+"reset_bad_target", status, "")
+			assert.ErrorIs(t, err, types.ErrInvalidReset, "status %s", status)
+		}
+		assert.Equal(t, types.ExecRunning, execStatus(t, s, "reset_bad_target"))
+	})
+
+	t.Run("finished execution is not reset", func(t *testing.T) {
+		saveChainedExec(t, s, "reset_done", "", types.ExecCompleted)
+
+		_, err := m.ResetExecution(ctx, cascadeTestPrefix+"reset_done", types.ExecFailed, "")
+		assert.ErrorIs(t, err, types.ErrInvalidReset)
+		assert.Equal(t, types.ExecCompleted, execStatus(t, s, "reset_done"))
+	})
+
+	t.Run("unknown execution", func(t *testing.T) {
+		_, err := m.ResetExecution(ctx, cascadeTestPrefix+"reset_missing", types.ExecFailed, "")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,131 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/agent/robot/pool"
+	"github.com/yaoapp/yao/agent/robot/store"
+	"github.com/yaoapp/yao/agent/robot/types"
+)
+
+// retryableStatuses are the outcomes an execution can be retried from. A completed run
+// has nothing left to retry, and an unfinished one still owns its record.
+var retryableStatuses = map[types.ExecStatus]bool{
+	types.ExecFailed:    true,
+	types.ExecCancelled: true,
+}
+
+// RetryExecution runs a failed or cancelled execution again as a new human-triggered
+// execution. The new record keeps the original input, goals and task plan (the goals
+// and tasks phases reuse them instead of planning again) and points back to the
+// original through parent_execution_id. Returns the new execution ID.
+func (m *Manager) RetryExecution(ctx *types.Context, execID string) (string, error) {
+	m.mu.RLock()
+	if !m.started {
+		m.mu.RUnlock()
+		return "", fmt.Errorf("manager not started")
+	}
+	m.mu.RUnlock()
+
+	if execID == "" {
+		return "", fmt.Errorf("execution_id is required")
+	}
+
+	execStore := store.NewExecutionStore()
+	record, err := execStore.Get(ctx.Context, execID)
+	if err != nil || record == nil {
+		return "", fmt.Errorf("execution not found: %s", execID)
+	}
+	if !retryableStatuses[record.Status] {
+		return "", fmt.Errorf("%w: execution %s is %s, only failed or cancelled executions can be retried", types.ErrRetryNotAllowed, execID, record.Status)
+	}
+
+	robot, lazyLoaded, err := m.getOrLoadRobot(ctx, record.MemberID)
+	if err != nil {
+		return "", err
+	}
+	if robot.Status == types.RobotPaused {
+		return "", types.ErrRobotPaused
+	}
+	if robot.Config != nil && robot.Config.Triggers != nil && !robot.Config.Triggers.IsEnabled(types.TriggerHuman) {
+		return "", types.ErrTriggerDisabled
+	}
+
+	// The executor picks the saved goals and tasks up from the record with the new ID
+	newID := pool.GenerateExecID()
+	retry := retryRecord(record, newID)
+	if err := execStore.Save(ctx.Context, retry); err != nil {
+		if lazyLoaded {
+			m.cache.Remove(record.MemberID)
+		}
+		return "", fmt.Errorf("failed to save retry of execution %s: %w", execID, err)
+	}
+
+	ctrlExec := m.execController.Track(newID, record.MemberID, robot.TeamID)
+	execCtx := types.NewContext(ctrlExec.Context(), ctx.Auth)
+	execCtx.Locale = ctx.Locale
+
+	var data interface{}
+	if retry.Input != nil {
+		data = retry.Input
+	}
+	if _, err := m.pool.SubmitWithID(execCtx, robot, types.TriggerHuman, data, newID, ctrlExec); err != nil {
+		m.execController.Untrack(newID)
+		if lazyLoaded {
+			m.cache.Remove(record.MemberID)
+		}
+		if delErr := execStore.Delete(context.Background(), newID); delErr != nil {
+			log.Warn("[retry] execution %s: failed to remove unsubmitted retry %s: %v", execID, newID, delErr)
+		}
+		return "", err
+	}
+
+	if lazyLoaded {
+		m.scheduleCleanup(robot)
+	}
+
+	log.With(log.F{
+		"execution_id": newID,
+		"retry_of":     execID,
+		"member_id":    record.MemberID,
+		"from_status":  string(record.Status),
+		"from_phase":   string(record.Phase),
+	}).Info("[retry] execution %s retried as %s", execID, newID)
+	return newID, nil
+}
+
+// retryRecord clones the parts of record a retry starts from: the input, goals and task
+// plan. The tasks' runtime state (status, timing, approval) is reset so each runs again.
+func retryRecord(record *store.ExecutionRecord, newID string) *store.ExecutionRecord {
+	var tasks []types.Task
+	if len(record.Tasks) > 0 {
+		tasks = make([]types.Task, len(record.Tasks))
+		for i, task := range record.Tasks {
+			task.Status = types.TaskPending
+			task.StartTime = nil
+			task.EndTime = nil
+			task.ApprovedAt = nil
+			tasks[i] = task
+		}
+	}
+
+	now := time.Now()
+	return &store.ExecutionRecord{
+		ExecutionID:       newID,
+		MemberID:          record.MemberID,
+		TeamID:            record.TeamID,
+		TriggerType:       types.TriggerHuman,
+		Status:            types.ExecPending,
+		Phase:             types.PhaseGoals,
+		Name:              record.Name,
+		Input:             record.Input,
+		Goals:             record.Goals,
+		Tasks:             tasks,
+		ChatID:            fmt.Sprintf("robot_%s_%s", record.MemberID, newID),
+		ParentExecutionID: record.ExecutionID,
+		StartTime:         &now,
+	}
+}
@@ -0,0 +1,128 @@
+//go:build integration
+
+package manager_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/yao/agent/robot/executor"
+	"github.com/yaoapp/yao/agent/robot/manager"
+	"github.com/yaoapp/yao/agent/robot/pool"
+	"github.com/yaoapp/yao/agent/robot/store"
+	"github.com/yaoapp/yao/agent/robot/types"
+	"github.com/yaoapp/yao/unit-test/agent/testprepare"
+)
+
+func TestRetryExecution(t *testing.T) {
+	testprepare.PrepareSandbox(t)
+
+	exec := &blockingExecutor{DryRunExecutor: executor.NewDryRun(), release: make(chan struct{})}
+	m := manager.NewWithConfig(&manager.Config{
+		TickInterval: time.Hour,
+		PoolConfig:   &pool.Config{WorkerSize: 2, QueueSize: 10},
+		Executor:     exec,
+	})
+	require.NoError(t, m.Start())
+	defer m.Stop()
+	defer close(exec.release)
+
+	robot := &types.Robot{
+		MemberID: "_test_retry_robot",
+		TeamID:   "team_retry_test",
+		Status:   types.RobotIdle,
+		Config:   &types.Config{Quota: &types.Quota{Max: 2}},
+	}
+	m.Cache().Add(robot)
+
+	s := store.NewExecutionStore()
+	ctx := types.NewContext(context.Background(), nil)
+
+	save := func(id string, status types.ExecStatus) {
+		t.Helper()
+		now := time.Now()
+		require.NoError(t, s.Save(context.Background(), &store.ExecutionRecord{
+			ExecutionID: id,
+			MemberID:    robot.MemberID,
+			TeamID:      robot.TeamID,
+			TriggerType: types.TriggerClock,
+			Status:      status,
+			Phase:       types.PhaseRun,
+			Error:       "task-2 failed",
+			Input:       &types.TriggerInput{Action: types.ActionTaskAdd},
+			Goals:       &types.Goals{Content: "## Goals\n1. Send the weekly report"},
+			Tasks: []types.Task{
+				{ID: "task-1", ExecutorType: types.ExecutorAssistant, ExecutorID: "writer", Status: types.TaskCompleted, StartTime: &now, EndTime: &now},
+				{ID: "task-2", ExecutorType: types.ExecutorAssistant, ExecutorID: "mailer", Status: types.TaskFailed, StartTime: &now},
+			},
+			StartTime: &now,
+		}))
+		t.Cleanup(func() { s.Delete(context.Background(), id) })
+	}
+
+	t.Run("clone inherits goals and tasks", func(t *testing.T) {
+		save("_test_retry_failed", types.ExecFailed)
+
+		newID, err := m.RetryExecution(ctx, "_test_retry_failed")
+		require.NoError(t, err)
+		require.NotEmpty(t, newID)
+		assert.NotEqual(t, "_test_retry_failed", newID)
+		t.Cleanup(func() { s.Delete(context.Background(), newID) })
+
+		clone, err := s.Get(context.Background(), newID)
+		require.NoError(t, err)
+		require.NotNil(t, clone)
+		assert.Equal(t, types.ExecPending, clone.Status)
+		assert.Equal(t, types.TriggerHuman, clone.TriggerType)
+		assert.Equal(t, "_test_retry_failed", clone.ParentExecutionID)
+		assert.Empty(t, clone.Error)
+		require.NotNil(t, clone.Goals)
+		assert.Equal(t, "## Goals\n1. Send the weekly report", clone.Goals.Content)
+		require.Len(t, clone.Tasks, 2)
+		for _, task := range clone.Tasks {
+			assert.Equal(t, types.TaskPending, task.Status)
+			assert.Nil(t, task.StartTime)
+		}
+		require.NotNil(t, clone.Input)
+		assert.Equal(t, types.ActionTaskAdd, clone.Input.Action)
+
+		// The original is left as it was
+		original, err := s.Get(context.Background(), "_test_retry_failed")
+		require.NoError(t, err)
+		assert.Equal(t, types.ExecFailed, original.Status)
+		assert.Equal(t, types.TaskCompleted, original.Tasks[0].Status)
+
+		status, err := m.GetExecutionStatus(newID)
+		require.NoError(t, err)
+		assert.NotNil(t, status, "retry is tracked for pause/resume/stop")
+	})
+
+	t.Run("cancelled executions can be retried", func(t *testing.T) {
+		save("_test_retry_cancelled", types.ExecCancelled)
+
+		newID, err := m.RetryExecution(ctx, "_test_retry_cancelled")
+		require.NoError(t, err)
+		t.Cleanup(func() { s.Delete(context.Background(), newID) })
+	})
+
+	t.Run("completed and unfinished executions are refused", func(t *testing.T) {
+		for _, status := range []types.ExecStatus{types.ExecCompleted, types.ExecRunning, types.ExecWaiting} {
+			id := "_test_retry_" + string(status)
+			save(id, status)
+
+			_, err := m.RetryExecution(ctx, id)
+			assert.True(t, errors.Is(err, types.ErrRetryNotAllowed), "status %s", status)
+		}
+	})
+
+	t.Run("unknown execution", func(t *testing.T) {
+		_, err := m.RetryExecution(ctx, "_test_retry_missing")
+		assert.Error(t, err)
+		_, err = m.RetryExecution(ctx, "")
+		assert.Error(t, err)
+	})
+}
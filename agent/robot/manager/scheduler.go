@@ -0,0 +1,161 @@
+package manager
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/yaoapp/yao/agent/robot/types"
+)
+
+// scheduler bounds autonomous (clock) triggers: how many autonomous executions may
+// run at once across all robots, and how long a robot rests after an autonomous run.
+// Robots that are due while the limit is reached are deferred and retried first on
+// the next tick, so a times-mode robot does not lose its slot by missing its minute.
+type scheduler struct {
+	mu            sync.Mutex
+	maxConcurrent int                  // 0 = unlimited
+	cooldown      time.Duration        // 0 = no cooldown
+	running       map[string]string    // execID -> memberID
+	lastFinished  map[string]time.Time // memberID -> end of the last autonomous run
+	deferred      map[string]time.Time // memberID -> first tick it was held back
+	lastTick      time.Time
+}
+
+func newScheduler(maxConcurrent int, cooldown time.Duration) *scheduler {
+	if maxConcurrent < 0 {
+		maxConcurrent = 0
+	}
+	if cooldown < 0 {
+		cooldown = 0
+	}
+	return &scheduler{
+		maxConcurrent: maxConcurrent,
+		cooldown:      cooldown,
+		running:       map[string]string{},
+		lastFinished:  map[string]time.Time{},
+		deferred:      map[string]time.Time{},
+	}
+}
+
+// beginTick starts a scheduling round and returns the robots deferred by the previous one.
+// Deferred entries not renewed during this round (robot paused, removed, admitted) are dropped.
+func (s *scheduler) beginTick(now time.Time) map[string]time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev := s.deferred
+	s.deferred = map[string]time.Time{}
+	s.lastTick = now
+	return prev
+}
+
+// coolingDown reports whether memberID is still resting after its last autonomous run
+func (s *scheduler) coolingDown(memberID string, now time.Time) bool {
+	if s.cooldown <= 0 {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	finished, ok := s.lastFinished[memberID]
+	return ok && now.Sub(finished) < s.cooldown
+}
+
+// reserve takes a concurrency slot for execID; false when the limit is reached
+func (s *scheduler) reserve(execID, memberID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.maxConcurrent > 0 && len(s.running) >= s.maxConcurrent {
+		return false
+	}
+	s.running[execID] = memberID
+	return true
+}
+
+// release returns a slot reserved for an execution that was never submitted
+func (s *scheduler) release(execID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.running, execID)
+}
+
+// finish releases the slot of a completed autonomous execution and starts the robot's cooldown.
+// Executions not started by the scheduler are ignored and reported as false.
+func (s *scheduler) finish(execID string, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	memberID, ok := s.running[execID]
+	if !ok {
+		return false
+	}
+	delete(s.running, execID)
+	s.lastFinished[memberID] = now
+	return true
+}
+
+// deferRobot records a robot that was due but held back by the concurrency limit
+func (s *scheduler) deferRobot(memberID string, since time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deferred[memberID] = since
+}
+
+// state returns a monitoring snapshot
+func (s *scheduler) state(now time.Time) *types.SchedulerState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := &types.SchedulerState{
+		MaxConcurrent: s.maxConcurrent,
+		Running:       len(s.running),
+	}
+	if s.cooldown > 0 {
+		state.Cooldown = s.cooldown.String()
+	}
+	if !s.lastTick.IsZero() {
+		tick := s.lastTick
+		state.LastTick = &tick
+	}
+
+	for _, memberID := range s.running {
+		state.RunningRobots = append(state.RunningRobots, memberID)
+	}
+	sort.Strings(state.RunningRobots)
+
+	for memberID, since := range s.deferred {
+		state.Deferred = append(state.Deferred, types.SchedulerDeferred{MemberID: memberID, Since: since})
+	}
+	sort.Slice(state.Deferred, func(i, j int) bool {
+		if !state.Deferred[i].Since.Equal(state.Deferred[j].Since) {
+			return state.Deferred[i].Since.Before(state.Deferred[j].Since)
+		}
+		return state.Deferred[i].MemberID < state.Deferred[j].MemberID
+	})
+
+	if s.cooldown > 0 {
+		for memberID, finished := range s.lastFinished {
+			if until := finished.Add(s.cooldown); until.After(now) {
+				state.CoolingDown = append(state.CoolingDown, types.SchedulerCooldown{MemberID: memberID, Until: until})
+			}
+		}
+		sort.Slice(state.CoolingDown, func(i, j int) bool {
+			return state.CoolingDown[i].Until.Before(state.CoolingDown[j].Until)
+		})
+	}
+	return state
+}
+
+// sortByActivity orders robots oldest-activity-first so the longest-waiting robots
+// get the limited slots; ties are broken by member ID for a stable order
+func sortByActivity(robots []*types.Robot) {
+	sort.SliceStable(robots, func(i, j int) bool {
+		if !robots[i].LastRun.Equal(robots[j].LastRun) {
+			return robots[i].LastRun.Before(robots[j].LastRun)
+		}
+		return robots[i].MemberID < robots[j].MemberID
+	})
+}
+
+// SchedulerState returns the autonomous scheduler's current state for monitoring
+func (m *Manager) SchedulerState() *types.SchedulerState {
+	return m.scheduler.state(time.Now())
+}
@@ -0,0 +1,101 @@
+//go:build integration
+
+package manager_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/yao/agent/robot/executor"
+	"github.com/yaoapp/yao/agent/robot/manager"
+	"github.com/yaoapp/yao/agent/robot/pool"
+	"github.com/yaoapp/yao/agent/robot/types"
+	"github.com/yaoapp/yao/unit-test/agent/testprepare"
+)
+
+// blockingExecutor holds every execution until release is closed
+type blockingExecutor struct {
+	*executor.DryRunExecutor
+	release chan struct{}
+}
+
+func (e *blockingExecutor) ExecuteWithControl(ctx *types.Context, robot *types.Robot, trigger types.TriggerType, data interface{}, execID string, control types.ExecutionControl) (*types.Execution, error) {
+	<-e.release
+	return &types.Execution{ID: execID, MemberID: robot.MemberID, TeamID: robot.TeamID, Status: types.ExecCompleted}, nil
+}
+
+func autonomousRobot(id string, lastRun time.Time) *types.Robot {
+	return &types.Robot{
+		MemberID:       id,
+		TeamID:         "team_scheduler_test",
+		AutonomousMode: true,
+		Status:         types.RobotIdle,
+		LastRun:        lastRun,
+		Config: &types.Config{
+			Triggers: &types.Triggers{},
+			Clock:    &types.Clock{Mode: types.ClockInterval, Every: "1m"},
+			Quota:    &types.Quota{Max: 1},
+		},
+	}
+}
+
+func TestManagerAutonomousScheduler(t *testing.T) {
+	testprepare.PrepareSandbox(t)
+
+	exec := &blockingExecutor{DryRunExecutor: executor.NewDryRun(), release: make(chan struct{})}
+	m := manager.NewWithConfig(&manager.Config{
+		TickInterval:       time.Hour,
+		PoolConfig:         &pool.Config{WorkerSize: 10, QueueSize: 20},
+		Executor:           exec,
+		MaxAutonomous:      2,
+		AutonomousCooldown: time.Hour,
+	})
+	require.NoError(t, m.Start())
+	defer m.Stop()
+
+	// Five robots due at once; robot_0 has waited longest
+	now := time.Now()
+	for i := 0; i < 5; i++ {
+		m.Cache().Add(autonomousRobot(fmt.Sprintf("_test_sched_robot_%d", i), now.Add(-time.Duration(10-i)*time.Hour)))
+	}
+
+	require.NoError(t, m.Tick(context.Background(), now))
+
+	state := m.SchedulerState()
+	assert.Equal(t, 2, state.MaxConcurrent)
+	assert.Equal(t, "1h0m0s", state.Cooldown)
+	assert.Equal(t, 2, state.Running, "only max_concurrent autonomous executions start")
+	assert.Equal(t, []string{"_test_sched_robot_0", "_test_sched_robot_1"}, state.RunningRobots, "oldest activity first")
+	require.Len(t, state.Deferred, 3)
+	assert.Equal(t, "_test_sched_robot_2", state.Deferred[0].MemberID)
+	require.NotNil(t, state.LastTick)
+
+	// Still full: deferred robots keep waiting and keep their original since
+	require.NoError(t, m.Tick(context.Background(), now.Add(time.Minute)))
+	state = m.SchedulerState()
+	assert.Equal(t, 2, state.Running)
+	require.Len(t, state.Deferred, 3)
+	assert.True(t, state.Deferred[0].Since.Equal(now))
+
+	// Finish the running pair; both start their cooldown
+	close(exec.release)
+	require.Eventually(t, func() bool {
+		return m.SchedulerState().Running == 0
+	}, 5*time.Second, 20*time.Millisecond)
+	state = m.SchedulerState()
+	require.Len(t, state.CoolingDown, 2)
+
+	// Next tick: robot_0/1 are due again but cooling down, so two deferred robots start
+	later := time.Now().Add(30 * time.Minute)
+	require.NoError(t, m.Tick(context.Background(), later))
+	state = m.SchedulerState()
+	require.Len(t, state.Deferred, 1)
+	assert.Equal(t, "_test_sched_robot_4", state.Deferred[0].MemberID)
+	assert.True(t, m.Cache().Get("_test_sched_robot_0").LastRun.Equal(now))
+	assert.True(t, m.Cache().Get("_test_sched_robot_2").LastRun.Equal(later))
+	assert.True(t, m.Cache().Get("_test_sched_robot_3").LastRun.Equal(later))
+}
@@ -0,0 +1,116 @@
+package manager
+
+import (
+	"strings"
+
+	"github.com/yaoapp/kun/log"
+	agentcontext "github.com/yaoapp/yao/agent/context"
+	"github.com/yaoapp/yao/agent/output/message"
+)
+
+// DefaultMaxBufferedChunks caps how many text chunks the Host Agent stream holds back
+// while it looks like decision JSON
+const DefaultMaxBufferedChunks = 2048
+
+// hostStreamBuffer holds back Host Agent text deltas that look like JSON output
+// (starting with "{" or "```") so the frontend never sees raw decision JSON.
+// Past maxChunks the stream is no longer treated as a decision candidate: the
+// held chunks are flushed and the rest passes straight through.
+type hostStreamBuffer struct {
+	onMessage agentcontext.OnMessageFunc
+	maxChunks int
+
+	chunks        []*message.Message
+	buffering     bool
+	decided       bool   // the opening of the text settled whether to buffer
+	head          string // leading text, accumulated until decided
+	overflowed    bool
+	lastTextMsgID string
+}
+
+func newHostStreamBuffer(onMessage agentcontext.OnMessageFunc, maxChunks int) *hostStreamBuffer {
+	if maxChunks <= 0 {
+		maxChunks = DefaultMaxBufferedChunks
+	}
+	return &hostStreamBuffer{onMessage: onMessage, maxChunks: maxChunks}
+}
+
+// Discard drops the held chunks without sending them, for a stream nobody reads anymore
+func (b *hostStreamBuffer) Discard() {
+	b.chunks = nil
+	b.buffering = false
+}
+
+// OnMessage is the stream callback handed to the agent caller
+func (b *hostStreamBuffer) OnMessage(msg *message.Message) int {
+	// Only intercept text type messages with delta content
+	if msg == nil || msg.Type != message.TypeText || !msg.Delta {
+		return b.onMessage(msg)
+	}
+
+	if msg.MessageID != "" {
+		b.lastTextMsgID = msg.MessageID
+	}
+
+	if !b.decided {
+		if msg.Props != nil {
+			if c, ok := msg.Props["content"].(string); ok {
+				b.head += c
+			}
+		}
+		trimmed := strings.TrimSpace(b.head)
+		switch {
+		case trimmed == "":
+		case trimmed[0] == '{' || strings.HasPrefix(trimmed, "```"):
+			b.buffering, b.decided = true, true
+		case trimmed[0] != '`' || len(trimmed) >= 3:
+			b.decided = true
+		}
+		if b.decided {
+			b.head = ""
+		}
+	}
+
+	if !b.buffering {
+		return b.onMessage(msg)
+	}
+
+	b.chunks = append(b.chunks, msg)
+	if len(b.chunks) <= b.maxChunks {
+		return 0
+	}
+
+	// Too long to be a decision (or the stream is stuck): stop holding it back
+	log.Warn("host agent stream: more than %d buffered chunks, flushing and passing through", b.maxChunks)
+	b.buffering = false
+	b.overflowed = true
+	return b.flush()
+}
+
+// Finish settles the held chunks once the final output is known. A decision replaces
+// the streamed text with the clean reply; anything else is flushed as it came.
+func (b *hostStreamBuffer) Finish(isDecision bool, reply string) {
+	if isDecision && b.lastTextMsgID != "" {
+		b.chunks = nil
+		b.onMessage(&message.Message{
+			Type:      message.TypeText,
+			MessageID: b.lastTextMsgID,
+			Props:     map[string]interface{}{"content": reply},
+			Delta:     false,
+		})
+		return
+	}
+	b.flush()
+}
+
+// flush sends the held chunks in order, stopping early when the consumer asks to
+func (b *hostStreamBuffer) flush() int {
+	chunks := b.chunks
+	b.chunks = nil
+	for _, chunk := range chunks {
+		if code := b.onMessage(chunk); code != 0 {
+			return code
+		}
+	}
+	return 0
+}
@@ -0,0 +1,189 @@
+//go:build unit
+
+package manager_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	agentcontext "github.com/yaoapp/yao/agent/context"
+	"github.com/yaoapp/yao/agent/output/message"
+	"github.com/yaoapp/yao/agent/robot/executor/standard"
+	"github.com/yaoapp/yao/agent/robot/manager"
+	"github.com/yaoapp/yao/agent/robot/types"
+)
+
+func textDelta(content string) *message.Message {
+	return &message.Message{
+		Type:      message.TypeText,
+		MessageID: "msg-1",
+		Delta:     true,
+		Props:     map[string]interface{}{"content": content},
+	}
+}
+
+type streamRecorder struct {
+	messages []*message.Message
+}
+
+func (r *streamRecorder) onMessage(msg *message.Message) int {
+	r.messages = append(r.messages, msg)
+	return 0
+}
+
+func (r *streamRecorder) text() string {
+	var sb strings.Builder
+	for _, msg := range r.messages {
+		if c, ok := msg.Props["content"].(string); ok {
+			sb.WriteString(c)
+		}
+	}
+	return sb.String()
+}
+
+func TestHostStreamBufferPassesPlainText(t *testing.T) {
+	rec := &streamRecorder{}
+	buf := manager.ExportNewHostStreamBuffer(rec.onMessage, 10)
+
+	buf.OnMessage(textDelta("Sure, "))
+	buf.OnMessage(textDelta("{not json} here"))
+	assert.Len(t, rec.messages, 2)
+
+	buf.Finish(false, "")
+	assert.Equal(t, "Sure, {not json} here", rec.text())
+}
+
+func TestHostStreamBufferHoldsDecisionJSON(t *testing.T) {
+	rec := &streamRecorder{}
+	buf := manager.ExportNewHostStreamBuffer(rec.onMessage, 10)
+
+	// The fence arrives split across chunks
+	buf.OnMessage(textDelta("`"))
+	buf.OnMessage(textDelta("``json\n{\"action\":"))
+	buf.OnMessage(textDelta("\"confirm\"}\n```"))
+	assert.Empty(t, rec.messages)
+
+	buf.Finish(true, "Done.")
+	if assert.Len(t, rec.messages, 1) {
+		assert.False(t, rec.messages[0].Delta)
+		assert.Equal(t, "Done.", rec.messages[0].Props["content"])
+		assert.Equal(t, "msg-1", rec.messages[0].MessageID)
+	}
+}
+
+func TestHostStreamBufferFlushesNonDecisionJSON(t *testing.T) {
+	rec := &streamRecorder{}
+	buf := manager.ExportNewHostStreamBuffer(rec.onMessage, 10)
+
+	buf.OnMessage(textDelta("{\"a\":"))
+	buf.OnMessage(textDelta("1}"))
+	assert.Empty(t, rec.messages)
+
+	buf.Finish(false, "")
+	assert.Equal(t, "{\"a\":1}", rec.text())
+}
+
+func TestHostStreamBufferCapsLargeStream(t *testing.T) {
+	const limit = 100
+	rec := &streamRecorder{}
+	buf := manager.ExportNewHostStreamBuffer(rec.onMessage, limit)
+
+	buf.OnMessage(textDelta("{"))
+	for i := 1; i < limit; i++ {
+		buf.OnMessage(textDelta(`"x",`))
+	}
+	assert.Empty(t, rec.messages, "chunks up to the cap are held back")
+
+	// One past the cap flushes everything held so far, in order
+	buf.OnMessage(textDelta(`"overflow",`))
+	assert.Len(t, rec.messages, limit+1)
+	assert.True(t, strings.HasPrefix(rec.text(), `{"x",`))
+
+	// Later chunks stream straight through
+	for i := 0; i < 10_000; i++ {
+		buf.OnMessage(textDelta(`"y",`))
+	}
+	assert.Len(t, rec.messages, limit+1+10_000)
+
+	buf.Finish(false, "")
+	assert.Len(t, rec.messages, limit+1+10_000, "nothing left to flush")
+}
+
+func TestHostStreamBufferStopsFlushWhenConsumerAborts(t *testing.T) {
+	calls := 0
+	buf := manager.ExportNewHostStreamBuffer(func(msg *message.Message) int {
+		calls++
+		return 1
+	}, 2)
+
+	buf.OnMessage(textDelta("{"))
+	buf.OnMessage(textDelta("1"))
+	assert.Equal(t, 1, buf.OnMessage(textDelta("2")))
+	assert.Equal(t, 1, calls)
+}
+
+// blockingStreamCaller streams the start of a decision, then blocks until its context is done
+type blockingStreamCaller struct {
+	started chan struct{}
+	stopped chan struct{}
+}
+
+func (c *blockingStreamCaller) CallWithMessagesStreamRaw(ctx *types.Context, assistantID string, userContent string, onMessage agentcontext.OnMessageFunc) (*standard.CallResult, error) {
+	defer close(c.stopped)
+	onMessage(textDelta(`{"action": "confirm",`))
+	close(c.started)
+	<-ctx.Done()
+	onMessage(textDelta(` "reply": "too late"}`))
+	return nil, ctx.Err()
+}
+
+// replyStreamCaller streams a plain reply and returns it
+type replyStreamCaller struct{}
+
+func (replyStreamCaller) CallWithMessagesStreamRaw(ctx *types.Context, assistantID string, userContent string, onMessage agentcontext.OnMessageFunc) (*standard.CallResult, error) {
+	onMessage(textDelta("Which report do you need?"))
+	return &standard.CallResult{Content: "Which report do you need?"}, nil
+}
+
+func TestCallHostAgentStreamRawCancellation(t *testing.T) {
+	m := manager.New()
+	robot := &types.Robot{MemberID: "robot-stream"}
+	input := &types.HostInput{Scenario: "assign"}
+
+	t.Run("client disconnect abandons the stream", func(t *testing.T) {
+		caller := &blockingStreamCaller{started: make(chan struct{}), stopped: make(chan struct{})}
+		defer manager.SetHostStreamCaller(caller)()
+
+		parent, cancel := context.WithCancel(context.Background())
+		go func() {
+			<-caller.started
+			cancel()
+		}()
+
+		rec := &streamRecorder{}
+		output, err := manager.ExportCallHostAgentStreamRaw(m, types.NewContext(parent, nil), "host", input, "chat-1", robot, rec.onMessage)
+		require.ErrorIs(t, err, types.ErrInteractCancelled)
+		assert.Nil(t, output)
+
+		select {
+		case <-caller.stopped:
+		case <-time.After(time.Second):
+			t.Fatal("caller was not released by the cancellation")
+		}
+		assert.Empty(t, rec.messages, "buffered and late chunks must be discarded")
+	})
+
+	t.Run("completed stream passes through", func(t *testing.T) {
+		defer manager.SetHostStreamCaller(replyStreamCaller{})()
+
+		rec := &streamRecorder{}
+		output, err := manager.ExportCallHostAgentStreamRaw(m, types.NewContext(context.Background(), nil), "host", input, "chat-2", robot, rec.onMessage)
+		require.NoError(t, err)
+		assert.Equal(t, "Which report do you need?", output.Reply)
+		assert.Len(t, rec.messages, 1)
+	})
+}
@@ -0,0 +1,100 @@
+package manager
+
+import (
+	"fmt"
+	"time"
+
+	robotevents "github.com/yaoapp/yao/agent/robot/events"
+	"github.com/yaoapp/yao/agent/robot/store"
+	"github.com/yaoapp/yao/agent/robot/types"
+	"github.com/yaoapp/yao/event"
+)
+
+// DefaultUncancelWindow is how long a cancelled execution can be restored by default
+const DefaultUncancelWindow = 30 * time.Second
+
+// cancelledExec remembers what a cancellation undid, for UncancelExecution
+type cancelledExec struct {
+	memberID    string
+	priorStatus types.ExecStatus
+	tracked     *types.Execution // robot slot entry released by the cancel, if any
+	at          time.Time
+}
+
+// rememberCancel records a cancellation that can still be undone. Only executions
+// parked without a worker (waiting/confirming) qualify: a stopped run cannot be resumed.
+func (m *Manager) rememberCancel(record *store.ExecutionRecord, tracked *types.Execution) {
+	if m.config.UncancelWindow <= 0 {
+		return
+	}
+	if record.Status != types.ExecWaiting && record.Status != types.ExecConfirming {
+		return
+	}
+
+	m.cancelMu.Lock()
+	defer m.cancelMu.Unlock()
+	m.pruneCancelledLocked(time.Now())
+	m.recentlyCancelled[record.ExecutionID] = &cancelledExec{
+		memberID:    record.MemberID,
+		priorStatus: record.Status,
+		tracked:     tracked,
+		at:          time.Now(),
+	}
+}
+
+// pruneCancelledLocked drops cancellations whose grace window has passed; cancelMu must be held
+func (m *Manager) pruneCancelledLocked(now time.Time) {
+	for execID, c := range m.recentlyCancelled {
+		if now.Sub(c.at) > m.config.UncancelWindow {
+			delete(m.recentlyCancelled, execID)
+		}
+	}
+}
+
+// UncancelExecution restores an execution cancelled within the last UncancelWindow to
+// the status it had before (waiting or confirming). After the window, or for runs
+// that were stopped while executing, the cancellation is permanent.
+func (m *Manager) UncancelExecution(ctx *types.Context, execID string) error {
+	m.mu.RLock()
+	if !m.started {
+		m.mu.RUnlock()
+		return fmt.Errorf("manager not started")
+	}
+	m.mu.RUnlock()
+
+	m.cancelMu.Lock()
+	m.pruneCancelledLocked(time.Now())
+	c, ok := m.recentlyCancelled[execID]
+	delete(m.recentlyCancelled, execID)
+	m.cancelMu.Unlock()
+	if !ok {
+		return fmt.Errorf("execution %s cannot be restored: not cancelled within the last %s", execID, m.config.UncancelWindow)
+	}
+
+	execStore := store.NewExecutionStore()
+	restored, err := execStore.RestoreCancelled(ctx.Context, execID, c.priorStatus)
+	if err != nil {
+		return err
+	}
+	if !restored {
+		return fmt.Errorf("execution %s is no longer cancelled", execID)
+	}
+
+	if c.tracked != nil {
+		if robot := m.cache.Get(c.memberID); robot != nil {
+			robot.AddExecution(c.tracked)
+		}
+	}
+
+	record, err := execStore.Get(ctx.Context, execID)
+	if err == nil && record != nil {
+		event.Push(ctx.Context, robotevents.ExecUncancelled, robotevents.ExecPayload{
+			ExecutionID: execID,
+			MemberID:    record.MemberID,
+			TeamID:      record.TeamID,
+			Status:      string(c.priorStatus),
+			ChatID:      record.ChatID,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+//go:build integration
+
+package manager_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/yao/agent/robot/manager"
+	"github.com/yaoapp/yao/agent/robot/store"
+	"github.com/yaoapp/yao/agent/robot/types"
+	"github.com/yaoapp/yao/unit-test/agent/testprepare"
+)
+
+func TestUncancelExecution(t *testing.T) {
+	testprepare.PrepareSandbox(t)
+
+	config := manager.DefaultConfig()
+	config.UncancelWindow = 200 * time.Millisecond
+	m := manager.NewWithConfig(config)
+	require.NoError(t, m.Start())
+	defer m.Stop()
+
+	s := store.NewExecutionStore()
+	ctx := types.NewContext(context.Background(), nil)
+	p := cascadeTestPrefix
+
+	t.Run("restores the prior status within the window", func(t *testing.T) {
+		saveChainedExec(t, s, "undo_confirming", "", types.ExecConfirming)
+
+		require.NoError(t, m.CancelExecution(ctx, p+"undo_confirming", manager.CancelOptions{Reason: "oops", CancelledBy: "user-1"}))
+		require.NoError(t, m.UncancelExecution(ctx, p+"undo_confirming"))
+
+		record, err := s.Get(context.Background(), p+"undo_confirming")
+		require.NoError(t, err)
+		assert.Equal(t, types.ExecConfirming, record.Status)
+		assert.Empty(t, record.CancelReason)
+		assert.Empty(t, record.CancelledBy)
+		assert.Nil(t, record.EndTime)
+	})
+
+	t.Run("permanent after the window", func(t *testing.T) {
+		saveChainedExec(t, s, "undo_late", "", types.ExecWaiting)
+
+		require.NoError(t, m.CancelExecution(ctx, p+"undo_late", manager.CancelOptions{}))
+		time.Sleep(300 * time.Millisecond)
+
+		err := m.UncancelExecution(ctx, p+"undo_late")
+		assert.Error(t, err)
+		assert.Equal(t, types.ExecCancelled, execStatus(t, s, "undo_late"))
+	})
+
+	t.Run("only once", func(t *testing.T) {
+		saveChainedExec(t, s, "undo_twice", "", types.ExecWaiting)
+
+		require.NoError(t, m.CancelExecution(ctx, p+"undo_twice", manager.CancelOptions{}))
+		require.NoError(t, m.UncancelExecution(ctx, p+"undo_twice"))
+		assert.Error(t, m.UncancelExecution(ctx, p+"undo_twice"))
+		assert.Equal(t, types.ExecWaiting, execStatus(t, s, "undo_twice"))
+	})
+}
@@ -0,0 +1,141 @@
+package pool
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/yaoapp/yao/agent/robot/types"
+)
+
+// DailyUsageFunc returns the IDs of the robot's executions recorded since the start of
+// its day. The manager wires it to the execution store, so the daily cap holds across
+// restarts and across instances sharing the store.
+type DailyUsageFunc func(ctx context.Context, memberID string, since time.Time) ([]string, error)
+
+// dailyCounter counts executions per robot and calendar day (in the robot's timezone)
+// for Config.MaxDailyExecutions. Today's usage is the union of the executions recorded
+// in the store and the ones this instance accepted that may not be recorded yet (still
+// queued, or run by an executor that does not persist). Without a usage func only the
+// local submissions count, and they start over when the process restarts.
+type dailyCounter struct {
+	mu     sync.Mutex
+	usage  DailyUsageFunc
+	counts map[string]dailyCount // memberID -> today's local submissions
+}
+
+type dailyCount struct {
+	day string              // YYYY-MM-DD in the robot's timezone
+	ids map[string]struct{} // execution IDs accepted today by this instance
+}
+
+func newDailyCounter() *dailyCounter {
+	return &dailyCounter{counts: make(map[string]dailyCount)}
+}
+
+// dayKey returns the robot-local calendar day of now
+func dayKey(robot *types.Robot, now time.Time) string {
+	return now.In(robot.Location()).Format("2006-01-02")
+}
+
+// dayStart returns the robot-local midnight starting the day of now
+func dayStart(robot *types.Robot, now time.Time) time.Time {
+	local := now.In(robot.Location())
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+}
+
+// nextReset returns the robot-local midnight following now
+func nextReset(robot *types.Robot, now time.Time) time.Time {
+	local := now.In(robot.Location())
+	return time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, local.Location())
+}
+
+// take counts execution execID for today; false when the daily limit is used up
+func (d *dailyCounter) take(ctx context.Context, robot *types.Robot, execID string, now time.Time) bool {
+	limit := robot.Config.GetMaxDailyExecutions()
+	if limit <= 0 {
+		return true
+	}
+
+	// Query the store before locking, the counter is shared by every robot
+	recorded := d.recorded(ctx, robot, now)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	count := d.today(robot, now)
+	if _, ok := count.ids[execID]; ok {
+		return true // already counted, e.g. a recovered execution submitted again
+	}
+	if union(count.ids, recorded) >= limit {
+		return false
+	}
+	count.ids[execID] = struct{}{}
+	d.counts[robot.MemberID] = count
+	return true
+}
+
+// refund gives back an execution counted by take that never made it into the queue
+func (d *dailyCounter) refund(robot *types.Robot, execID string, now time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	count, ok := d.counts[robot.MemberID]
+	if !ok || count.day != dayKey(robot, now) {
+		return
+	}
+	delete(count.ids, execID)
+}
+
+// used returns today's count for the robot
+func (d *dailyCounter) used(ctx context.Context, robot *types.Robot, now time.Time) int {
+	recorded := d.recorded(ctx, robot, now)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return union(d.today(robot, now).ids, recorded)
+}
+
+// today returns the robot's local submissions for the day of now, starting over on a new day.
+// Callers hold d.mu.
+func (d *dailyCounter) today(robot *types.Robot, now time.Time) dailyCount {
+	day := dayKey(robot, now)
+	count := d.counts[robot.MemberID]
+	if count.day != day {
+		count = dailyCount{day: day, ids: map[string]struct{}{}}
+		d.counts[robot.MemberID] = count
+	}
+	return count
+}
+
+// recorded returns the robot's executions recorded in the store today. A store failure
+// falls back to the local count rather than blocking every submission.
+func (d *dailyCounter) recorded(ctx context.Context, robot *types.Robot, now time.Time) []string {
+	if d.usage == nil {
+		return nil
+	}
+	ids, err := d.usage(ctx, robot.MemberID, dayStart(robot, now))
+	if err != nil {
+		log.Warn("daily execution count for robot %s falls back to this instance: %v", robot.MemberID, err)
+		return nil
+	}
+	return ids
+}
+
+// union returns the number of distinct IDs in local and recorded
+func union(local map[string]struct{}, recorded []string) int {
+	n := len(local)
+	seen := make(map[string]struct{}, len(recorded))
+	for _, id := range recorded {
+		if _, ok := local[id]; ok {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		n++
+	}
+	return n
+}
@@ -1,9 +1,11 @@
 package pool
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/yaoapp/yao/agent/robot/types"
 	"github.com/yaoapp/yao/agent/robot/utils"
@@ -36,6 +38,10 @@ type ExecutorFactory func(mode types.ExecutorMode) types.Executor
 // Parameters: execID, memberID, status
 type OnCompleteCallback func(execID, memberID string, status types.ExecStatus)
 
+// OnSuspendCallback is called when an execution suspends to wait for human input
+// Parameters: execID, memberID
+type OnSuspendCallback func(execID, memberID string)
+
 // Pool implements types.Pool interface
 // Manages a pool of workers that execute robot jobs from a priority queue
 type Pool struct {
@@ -44,9 +50,11 @@ type Pool struct {
 	executor        types.Executor     // default executor for running jobs
 	executorFactory ExecutorFactory    // optional: factory for mode-specific executors
 	onComplete      OnCompleteCallback // optional: callback when execution completes
+	onSuspend       OnSuspendCallback  // optional: callback when execution suspends
 	workers         []*Worker          // worker goroutines
 	running         atomic.Int32       // number of currently running jobs
 	wg              sync.WaitGroup     // wait group for graceful shutdown
+	daily           *dailyCounter      // per-robot daily submissions (max_daily_executions)
 	started         bool               // whether pool has been started
 	mu              sync.RWMutex       // protects started flag
 }
@@ -76,6 +84,7 @@ func NewWithConfig(config *Config) *Pool {
 	return &Pool{
 		size:  workerSize,
 		queue: NewPriorityQueue(queueSize),
+		daily: newDailyCounter(),
 	}
 }
 
@@ -97,6 +106,19 @@ func (p *Pool) SetOnComplete(callback OnCompleteCallback) {
 	p.onComplete = callback
 }
 
+// SetOnSuspend sets the callback for execution suspension
+// Called when an execution releases its worker to wait for input
+func (p *Pool) SetOnSuspend(callback OnSuspendCallback) {
+	p.onSuspend = callback
+}
+
+// SetDailyUsage sets the source of the executions a robot already ran today, counted
+// against max_daily_executions together with this pool's own submissions.
+// Must be called before Start()
+func (p *Pool) SetDailyUsage(usage DailyUsageFunc) {
+	p.daily.usage = usage
+}
+
 // GetExecutor returns the appropriate executor for the given mode
 // If factory is set and mode is specified, uses factory; otherwise uses default
 func (p *Pool) GetExecutor(mode types.ExecutorMode) types.Executor {
@@ -195,6 +217,28 @@ func (p *Pool) submitWithIDAndMode(ctx *types.Context, robot *types.Robot, trigg
 		return "", fmt.Errorf("robot cannot be nil")
 	}
 
+	// A paused robot takes no new work until it is resumed
+	if robot.Status == types.RobotPaused {
+		return "", types.ErrRobotPaused
+	}
+
+	// Reject policy: fail fast instead of waiting for a slot. Jobs whose slot was
+	// pre-acquired by the caller (Tick, batches) already hold one and always pass.
+	if robot.OverflowPolicy() == types.OverflowReject && robot.GetExecution(execID) == nil && !robot.CanRunTrigger(trigger) {
+		return "", types.ErrQuotaExceeded
+	}
+
+	// Cost rail: max_daily_executions per robot-local day
+	now := time.Now()
+	dailyCtx := context.Background()
+	if ctx != nil && ctx.Context != nil {
+		dailyCtx = ctx.Context
+	}
+	if !p.daily.take(dailyCtx, robot, execID, now) {
+		return "", fmt.Errorf("%w (%d per day, resets at %s)", types.ErrDailyLimitReached,
+			robot.Config.GetMaxDailyExecutions(), nextReset(robot, now).Format(time.RFC3339))
+	}
+
 	// Create queue item with the provided ID and control
 	item := &QueueItem{
 		Robot:        robot,
@@ -208,12 +252,27 @@ func (p *Pool) submitWithIDAndMode(ctx *types.Context, robot *types.Robot, trigg
 
 	// Try to add to queue
 	if !p.queue.Enqueue(item) {
+		p.daily.refund(robot, execID, now)
 		return "", fmt.Errorf("queue full (max %d items)", p.queue.maxSize)
 	}
 
 	return execID, nil
 }
 
+// DailyRemaining returns how many more executions the robot may start today.
+// ok is false when the robot has no max_daily_executions.
+func (p *Pool) DailyRemaining(robot *types.Robot) (remaining int, ok bool) {
+	limit := robot.Config.GetMaxDailyExecutions()
+	if limit <= 0 {
+		return 0, false
+	}
+	remaining = limit - p.daily.used(context.Background(), robot, time.Now())
+	if remaining < 0 {
+		remaining = 0
+	}
+	return remaining, true
+}
+
 // Running returns number of currently running jobs
 func (p *Pool) Running() int {
 	return int(p.running.Load())
@@ -224,6 +283,11 @@ func (p *Pool) Queued() int {
 	return p.queue.Size()
 }
 
+// QueuedFor returns the number of queued jobs of one robot
+func (p *Pool) QueuedFor(memberID string) int {
+	return p.queue.RobotQueuedCount(memberID)
+}
+
 // incrementRunning increments the running counter
 func (p *Pool) incrementRunning() {
 	p.running.Add(1)
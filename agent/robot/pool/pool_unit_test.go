@@ -362,3 +362,120 @@ func TestPoolWithoutExecutor(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "executor not set")
 }
+
+// TestQuotaOverflowPolicy tests the queue, reject and overflow policies at submission
+func TestQuotaOverflowPolicy(t *testing.T) {
+	exec := executor.NewDryRunWithDelay(300 * time.Millisecond)
+	p := pool.NewWithConfig(&pool.Config{WorkerSize: 10, QueueSize: 100})
+	p.SetExecutor(exec)
+	p.Start()
+	defer p.Stop()
+
+	ctx := createTestContext()
+
+	t.Run("reject fails fast when full", func(t *testing.T) {
+		robot := createTestRobot("robot_reject", "team_1", 1, 10, 5)
+		robot.Config.Quota.OverflowPolicy = types.OverflowReject
+
+		_, err := p.Submit(ctx, robot, types.TriggerHuman, nil)
+		assert.NoError(t, err)
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, 1, robot.RunningCount())
+
+		_, err = p.Submit(ctx, robot, types.TriggerHuman, nil)
+		assert.ErrorIs(t, err, types.ErrQuotaExceeded)
+	})
+
+	t.Run("overflow lets human work borrow a slot", func(t *testing.T) {
+		robot := createTestRobot("robot_overflow", "team_1", 1, 10, 5)
+		robot.Config.Quota.OverflowPolicy = types.OverflowBorrow
+
+		for _, trigger := range []types.TriggerType{types.TriggerClock, types.TriggerClock, types.TriggerHuman} {
+			_, err := p.Submit(ctx, robot, trigger, nil)
+			assert.NoError(t, err)
+			time.Sleep(20 * time.Millisecond)
+		}
+		time.Sleep(50 * time.Millisecond)
+
+		assert.Equal(t, 2, robot.RunningCount(), "clock work queues, human work borrows")
+	})
+}
+
+// TestMaxDailyExecutions tests the per-robot daily cap at submission
+func TestMaxDailyExecutions(t *testing.T) {
+	exec := executor.NewDryRunWithDelay(10 * time.Millisecond)
+	p := pool.NewWithConfig(&pool.Config{WorkerSize: 10, QueueSize: 100})
+	p.SetExecutor(exec)
+	p.Start()
+	defer p.Stop()
+
+	ctx := createTestContext()
+
+	t.Run("rejects once the cap is used up", func(t *testing.T) {
+		robot := createTestRobot("robot_daily", "team_1", 5, 10, 5)
+		robot.Config.MaxDailyExecutions = 2
+
+		remaining, ok := p.DailyRemaining(robot)
+		assert.True(t, ok)
+		assert.Equal(t, 2, remaining)
+
+		for i := 0; i < 2; i++ {
+			_, err := p.Submit(ctx, robot, types.TriggerHuman, nil)
+			assert.NoError(t, err)
+		}
+
+		_, err := p.Submit(ctx, robot, types.TriggerClock, nil)
+		assert.ErrorIs(t, err, types.ErrDailyLimitReached)
+		assert.Contains(t, err.Error(), "resets at")
+
+		remaining, _ = p.DailyRemaining(robot)
+		assert.Equal(t, 0, remaining)
+	})
+
+	t.Run("unlimited by default", func(t *testing.T) {
+		robot := createTestRobot("robot_daily_unlimited", "team_1", 5, 10, 5)
+
+		_, ok := p.DailyRemaining(robot)
+		assert.False(t, ok)
+		for i := 0; i < 3; i++ {
+			_, err := p.Submit(ctx, robot, types.TriggerHuman, nil)
+			assert.NoError(t, err)
+		}
+	})
+}
+
+// TestMaxDailyExecutionsRecorded tests that executions recorded in the store, by an
+// earlier process or another instance, count against the daily cap
+func TestMaxDailyExecutionsRecorded(t *testing.T) {
+	recorded := []string{"exec_before_restart", "exec_other_instance"}
+	var since time.Time
+
+	p := pool.NewWithConfig(&pool.Config{WorkerSize: 2, QueueSize: 10})
+	p.SetExecutor(executor.NewDryRunWithDelay(10 * time.Millisecond))
+	p.SetDailyUsage(func(ctx context.Context, memberID string, from time.Time) ([]string, error) {
+		since = from
+		return recorded, nil
+	})
+	p.Start()
+	defer p.Stop()
+
+	ctx := createTestContext()
+	robot := createTestRobot("robot_daily_recorded", "team_1", 5, 10, 5)
+	robot.Config.MaxDailyExecutions = 3
+
+	remaining, ok := p.DailyRemaining(robot)
+	assert.True(t, ok)
+	assert.Equal(t, 1, remaining)
+	assert.Equal(t, 0, since.Hour(), "the count starts at the robot-local midnight")
+
+	execID, err := p.Submit(ctx, robot, types.TriggerHuman, nil)
+	assert.NoError(t, err)
+
+	// Once this execution is recorded too it is still counted once
+	recorded = append(recorded, execID)
+	remaining, _ = p.DailyRemaining(robot)
+	assert.Equal(t, 0, remaining)
+
+	_, err = p.Submit(ctx, robot, types.TriggerHuman, nil)
+	assert.ErrorIs(t, err, types.ErrDailyLimitReached)
+}
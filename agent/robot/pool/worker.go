@@ -69,7 +69,7 @@ func (w *Worker) execute(item *QueueItem) {
 	// Pre-check if robot can run (non-atomic, just for early rejection).
 	// Skip for jobs whose slot was pre-acquired by Tick — they already hold
 	// a reserved slot and will pass TryAcquireSlot idempotently.
-	if item.Robot.GetExecution(item.ExecID) == nil && !item.Robot.CanRun() {
+	if item.Robot.GetExecution(item.ExecID) == nil && !item.Robot.CanRunTrigger(item.Trigger) {
 		w.requeue(item, "quota pre-check failed")
 		return
 	}
@@ -101,6 +101,9 @@ func (w *Worker) execute(item *QueueItem) {
 				log.Info("Worker %d: Execution %s suspended for robot %s (waiting for input)",
 					w.id, execution.ID, item.Robot.MemberID)
 			}
+			if w.pool.onSuspend != nil {
+				w.pool.onSuspend(item.ExecID, item.Robot.MemberID)
+			}
 			return
 		}
 
@@ -2,22 +2,39 @@ package robot
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
 
 	"github.com/yaoapp/gou/process"
 	"github.com/yaoapp/kun/exception"
 	"github.com/yaoapp/yao/agent/assistant"
 	"github.com/yaoapp/yao/agent/robot/api"
+	"github.com/yaoapp/yao/agent/robot/store"
 	"github.com/yaoapp/yao/agent/robot/types"
 )
 
 func init() {
 	process.RegisterGroup("robot", map[string]process.Handler{
-		"get":             processGet,
-		"list":            processList,
-		"status":          processStatus,
-		"executions":      processExecutions,
-		"execution":       processExecution,
-		"updateChatTitle": processUpdateChatTitle,
+		"get":                   processGet,
+		"list":                  processList,
+		"status":                processStatus,
+		"executions":            processExecutions,
+		"execution":             processExecution,
+		"team.executions":       processTeamExecutions,
+		"chat.executions":       processChatExecutions,
+		"updateChatTitle":       processUpdateChatTitle,
+		"scheduler":             processScheduler,
+		"chat.transcript":       processChatTranscript,
+		"delivery.validate":     processDeliveryValidate,
+		"delivery.metrics":      processDeliveryMetrics,
+		"delivery.dead_letters": processDeliveryDeadLetters,
+		"delivery.redeliver":    processDeliveryRedeliver,
+		"execution.result":      processExecutionResult,
+		"execution.cleanup":     processExecutionCleanup,
+		"execution.retry":       processExecutionRetry,
+		"execution.list":        processExecutions,
 	})
 }
 
@@ -78,35 +95,149 @@ func processStatus(p *process.Process) interface{} {
 	return result
 }
 
-// processExecutions handles robot.Executions(memberID, filter?).
+// processExecutions handles robot.Executions(memberID, filter?), also registered as robot.execution.list.
 // args[0]: memberID string; args[1]: optional filter map
 func processExecutions(p *process.Process) interface{} {
 	p.ValidateArgNums(1)
 	memberID := p.ArgsString(0)
 	ctx := types.NewContext(context.Background(), nil)
-	filter := &api.ExecutionQuery{}
-	if p.NumOfArgs() > 1 {
-		raw := p.ArgsMap(1)
-		if v, ok := raw["page"]; ok {
-			filter.Page = toInt(v)
-		}
-		if v, ok := raw["pagesize"]; ok {
-			filter.PageSize = toInt(v)
-		}
-		if v, ok := raw["status"]; ok {
-			filter.Status = types.ExecStatus(toString(v))
-		}
-		if v, ok := raw["trigger"]; ok {
-			filter.Trigger = types.TriggerType(toString(v))
+	result, err := api.ListExecutions(ctx, memberID, executionFilter(p, 1))
+	if err != nil {
+		exception.New(err.Error(), 500).Throw()
+	}
+	return result
+}
+
+// processExecutionResult handles robot.execution.result(execID).
+// args[0]: execution ID string; returns api.ExecutionOutcome
+func processExecutionResult(p *process.Process) interface{} {
+	p.ValidateArgNums(1)
+	execID := p.ArgsString(0)
+	ctx := types.NewContext(context.Background(), nil)
+	result, err := api.GetExecutionResult(ctx, execID)
+	if err != nil {
+		exception.New(err.Error(), 500).Throw()
+	}
+	return result
+}
+
+// processExecutionCleanup handles robot.execution.cleanup(olderThan, dryRun?).
+// args[0]: age as a duration such as "720h"; args[1]: optional bool, count without deleting.
+// Meant to be scheduled with a cron task; returns api.ExecutionCleanupResult
+func processExecutionCleanup(p *process.Process) interface{} {
+	p.ValidateArgNums(1)
+	olderThan, err := time.ParseDuration(p.ArgsString(0))
+	if err != nil || olderThan <= 0 {
+		exception.New("invalid olderThan %q: use a positive duration like \"720h\"", 400, p.ArgsString(0)).Throw()
+	}
+	dryRun := p.NumOfArgs() > 1 && p.ArgsBool(1)
+
+	ctx := types.NewContext(context.Background(), nil)
+	result, err := api.CleanupExecutions(ctx, olderThan, dryRun)
+	if err != nil {
+		exception.New(err.Error(), 500).Throw()
+	}
+	return result
+}
+
+// processExecutionRetry handles robot.execution.retry(execID).
+// args[0]: ID of a failed or cancelled execution; returns {"execution_id": new ID}
+func processExecutionRetry(p *process.Process) interface{} {
+	p.ValidateArgNums(1)
+	execID := p.ArgsString(0)
+	ctx := types.NewContext(context.Background(), nil)
+	newID, err := api.RetryExecution(ctx, execID)
+	if err != nil {
+		code := 500
+		if errors.Is(err, types.ErrRetryNotAllowed) {
+			code = 409
+		} else if strings.HasPrefix(err.Error(), "execution not found") {
+			code = 404
 		}
+		exception.New(err.Error(), code).Throw()
+	}
+	return map[string]interface{}{"execution_id": newID}
+}
+
+// processTeamExecutions handles robot.Team.Executions(teamID, filter?).
+// args[0]: teamID string; args[1]: optional filter map
+func processTeamExecutions(p *process.Process) interface{} {
+	p.ValidateArgNums(1)
+	teamID := p.ArgsString(0)
+	ctx := types.NewContext(context.Background(), nil)
+	result, err := api.ListTeamExecutions(ctx, teamID, executionFilter(p, 1))
+	if err != nil {
+		exception.New(err.Error(), 500).Throw()
 	}
-	result, err := api.ListExecutions(ctx, memberID, filter)
+	return result
+}
+
+// processChatExecutions handles robot.Chat.Executions(chatID, filter?).
+// args[0]: chatID string; args[1]: optional filter map
+func processChatExecutions(p *process.Process) interface{} {
+	p.ValidateArgNums(1)
+	chatID := p.ArgsString(0)
+	ctx := types.NewContext(context.Background(), nil)
+	result, err := api.ListChatExecutions(ctx, chatID, executionFilter(p, 1))
 	if err != nil {
 		exception.New(err.Error(), 500).Throw()
 	}
 	return result
 }
 
+// executionFilter reads the optional execution filter map at args[index]:
+// page, pagesize, status (string or list of strings), trigger, from/to as RFC3339 times,
+// and detail (bool) to keep tasks, results and resume_context on each entry
+func executionFilter(p *process.Process, index int) *api.ExecutionQuery {
+	filter := &api.ExecutionQuery{}
+	if p.NumOfArgs() <= index {
+		return filter
+	}
+	raw := p.ArgsMap(index)
+	if v, ok := raw["page"]; ok {
+		filter.Page = toInt(v)
+	}
+	if v, ok := raw["pagesize"]; ok {
+		filter.PageSize = toInt(v)
+	}
+	if v, ok := raw["status"]; ok {
+		switch statuses := v.(type) {
+		case []interface{}:
+			for _, s := range statuses {
+				filter.Statuses = append(filter.Statuses, types.ExecStatus(toString(s)))
+			}
+		case []string:
+			for _, s := range statuses {
+				filter.Statuses = append(filter.Statuses, types.ExecStatus(s))
+			}
+		default:
+			filter.Status = types.ExecStatus(toString(v))
+		}
+	}
+	if v, ok := raw["trigger"]; ok {
+		filter.Trigger = types.TriggerType(toString(v))
+	}
+	if v, ok := raw["detail"].(bool); ok {
+		filter.Detail = v
+	}
+	filter.From = filterTime(raw, "from")
+	filter.To = filterTime(raw, "to")
+	return filter
+}
+
+// filterTime parses an optional RFC3339 time from a filter map
+func filterTime(raw map[string]interface{}, key string) *time.Time {
+	value := toString(raw[key])
+	if value == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		exception.New("invalid %s %q: use an RFC3339 time", 400, key, value).Throw()
+	}
+	return &t
+}
+
 // processExecution handles robot.Execution(memberID, executionID).
 // args[0]: memberID string; args[1]: executionID string
 func processExecution(p *process.Process) interface{} {
@@ -122,6 +253,127 @@ func processExecution(p *process.Process) interface{} {
 	return result
 }
 
+// processScheduler handles robot.Scheduler().
+// Returns the autonomous scheduler state for monitoring.
+func processScheduler(p *process.Process) interface{} {
+	result, err := api.GetSchedulerState()
+	if err != nil {
+		exception.New(err.Error(), 500).Throw()
+	}
+	return result
+}
+
+// processChatTranscript handles robot.Chat.Transcript(chatID).
+// args[0]: chatID string
+func processChatTranscript(p *process.Process) interface{} {
+	p.ValidateArgNums(1)
+	chatID := p.ArgsString(0)
+	ctx := types.NewContext(context.Background(), nil)
+	result, err := api.GetChatTranscript(ctx, chatID)
+	if err != nil {
+		exception.New(err.Error(), 500).Throw()
+	}
+	return result
+}
+
+// processDeliveryValidate handles robot.Delivery.Validate(prefs, probe?).
+// args[0]: delivery preferences map (same shape as robot_config.delivery)
+// args[1]: optional bool, send a HEAD request to each webhook
+// Returns one diagnostic per target.
+func processDeliveryValidate(p *process.Process) interface{} {
+	p.ValidateArgNums(1)
+
+	raw, err := json.Marshal(p.Args[0])
+	if err != nil {
+		exception.New("invalid delivery preferences: %s", 400, err.Error()).Throw()
+	}
+	prefs := &types.DeliveryPreferences{}
+	if err := json.Unmarshal(raw, prefs); err != nil {
+		exception.New("invalid delivery preferences: %s", 400, err.Error()).Throw()
+	}
+
+	probe := false
+	if p.NumOfArgs() > 1 {
+		probe, _ = p.Args[1].(bool)
+	}
+
+	ctx := types.NewContext(context.Background(), nil)
+	return api.ValidateDelivery(ctx, prefs, probe)
+}
+
+// processDeliveryMetrics handles robot.delivery.metrics(filter?).
+// args[0]: optional filter map with team_id, member_id, since (RFC3339 time or a
+// lookback duration such as "24h"; default 7 days) and limit
+func processDeliveryMetrics(p *process.Process) interface{} {
+	p.ValidateArgNums(0)
+	query := &api.DeliveryMetricsQuery{}
+	if p.NumOfArgs() > 0 {
+		raw := p.ArgsMap(0)
+		query.TeamID = toString(raw["team_id"])
+		query.MemberID = toString(raw["member_id"])
+		query.Limit = toInt(raw["limit"])
+		if since := toString(raw["since"]); since != "" {
+			if t, err := time.Parse(time.RFC3339, since); err == nil {
+				query.Since = &t
+			} else if d, err := time.ParseDuration(since); err == nil && d > 0 {
+				t := time.Now().Add(-d)
+				query.Since = &t
+			} else {
+				exception.New("invalid since %q: use an RFC3339 time or a duration like \"24h\"", 400, since).Throw()
+			}
+		}
+	}
+
+	ctx := types.NewContext(context.Background(), nil)
+	result, err := api.GetDeliveryMetrics(ctx, query)
+	if err != nil {
+		exception.New(err.Error(), 500).Throw()
+	}
+	return result
+}
+
+// processDeliveryDeadLetters handles robot.delivery.dead_letters(filter?).
+// args[0]: optional filter map with member_id, team_id, status (pending | redelivered), page and pagesize
+func processDeliveryDeadLetters(p *process.Process) interface{} {
+	p.ValidateArgNums(0)
+	opts := &store.DeadLetterListOptions{}
+	if p.NumOfArgs() > 0 {
+		raw := p.ArgsMap(0)
+		opts.MemberID = toString(raw["member_id"])
+		opts.TeamID = toString(raw["team_id"])
+		opts.Status = toString(raw["status"])
+		opts.Page = toInt(raw["page"])
+		opts.PageSize = toInt(raw["pagesize"])
+	}
+
+	ctx := types.NewContext(context.Background(), nil)
+	result, err := api.ListDeadLetters(ctx, opts)
+	if err != nil {
+		exception.New(err.Error(), 500).Throw()
+	}
+	return result
+}
+
+// processDeliveryRedeliver handles robot.delivery.redeliver(letterID).
+// args[0]: dead letter ID; returns the channel result of the redelivery
+func processDeliveryRedeliver(p *process.Process) interface{} {
+	p.ValidateArgNums(1)
+	letterID := p.ArgsString(0)
+	ctx := types.NewContext(context.Background(), nil)
+	result, err := api.RedeliverDeadLetter(ctx, letterID)
+	if err != nil {
+		code := 500
+		switch {
+		case strings.Contains(err.Error(), "not found"):
+			code = 404
+		case strings.Contains(err.Error(), "already redelivered"), strings.Contains(err.Error(), "no longer configured"):
+			code = 409
+		}
+		exception.New(err.Error(), code).Throw()
+	}
+	return result
+}
+
 // processUpdateChatTitle handles robot.UpdateChatTitle(chatID, title).
 // args[0]: chatID string; args[1]: title string
 func processUpdateChatTitle(p *process.Process) interface{} {
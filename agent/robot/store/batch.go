@@ -0,0 +1,230 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/xun/capsule"
+	"github.com/yaoapp/yao/agent/robot/types"
+)
+
+// BatchStore - persistent storage for robot batch triggers
+// Maps to __yao.agent.batch model
+type BatchStore struct {
+	modelID string
+}
+
+// NewBatchStore creates a new batch store instance
+func NewBatchStore() *BatchStore {
+	return &BatchStore{
+		modelID: "__yao.agent.batch",
+	}
+}
+
+// Save creates or updates a batch record (including all item states)
+func (s *BatchStore) Save(ctx context.Context, batch *types.Batch) error {
+	mod := model.Select(s.modelID)
+	if mod == nil {
+		return fmt.Errorf("model %s not found", s.modelID)
+	}
+
+	data := s.batchToMap(batch)
+
+	existing, err := s.Get(ctx, batch.BatchID)
+	if err == nil && existing != nil {
+		_, err = mod.UpdateWhere(
+			model.QueryParam{
+				Wheres: []model.QueryWhere{
+					{Column: "batch_id", Value: batch.BatchID},
+				},
+			},
+			data,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update batch record: %w", err)
+		}
+		return nil
+	}
+
+	_, err = mod.Create(data)
+	if err != nil {
+		return fmt.Errorf("failed to create batch record: %w", err)
+	}
+	return nil
+}
+
+// Get retrieves a batch by batch_id, returns nil if not found
+func (s *BatchStore) Get(ctx context.Context, batchID string) (*types.Batch, error) {
+	mod := model.Select(s.modelID)
+	if mod == nil {
+		return nil, fmt.Errorf("model %s not found", s.modelID)
+	}
+
+	rows, err := mod.Get(model.QueryParam{
+		Wheres: []model.QueryWhere{
+			{Column: "batch_id", Value: batchID},
+		},
+		Limit: 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch record: %w", err)
+	}
+
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	return s.mapToBatch(rows[0])
+}
+
+// ListActive returns batches that still have items to dispatch or track,
+// oldest first. Used by the manager to resume feeding after a restart.
+func (s *BatchStore) ListActive(ctx context.Context) ([]*types.Batch, error) {
+	mod := model.Select(s.modelID)
+	if mod == nil {
+		return nil, fmt.Errorf("model %s not found", s.modelID)
+	}
+
+	statuses := []interface{}{
+		string(types.BatchPending),
+		string(types.BatchRunning),
+		string(types.BatchCancelled),
+	}
+
+	rows, err := capsule.Query().
+		Table(mod.MetaData.Table.Name).
+		WhereIn("status", statuses).
+		OrderBy("id", "asc").
+		Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active batches: %w", err)
+	}
+
+	batches := make([]*types.Batch, 0, len(rows))
+	for _, row := range rows {
+		batch, err := s.mapToBatch(map[string]interface{}(row))
+		if err != nil {
+			continue
+		}
+		if batch.IsActive() {
+			batches = append(batches, batch)
+		}
+	}
+	return batches, nil
+}
+
+// Delete removes a batch record
+func (s *BatchStore) Delete(ctx context.Context, batchID string) error {
+	mod := model.Select(s.modelID)
+	if mod == nil {
+		return fmt.Errorf("model %s not found", s.modelID)
+	}
+
+	_, err := mod.DeleteWhere(model.QueryParam{
+		Wheres: []model.QueryWhere{
+			{Column: "batch_id", Value: batchID},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete batch record: %w", err)
+	}
+	return nil
+}
+
+// batchToMap converts a batch to a model row
+func (s *BatchStore) batchToMap(batch *types.Batch) map[string]interface{} {
+	data := map[string]interface{}{
+		"batch_id":          batch.BatchID,
+		"member_id":         batch.MemberID,
+		"team_id":           batch.TeamID,
+		"status":            string(batch.Status),
+		"suppress_delivery": batch.SuppressDelivery,
+		"total":             len(batch.Items),
+		"items":             batch.Items,
+	}
+	if batch.CreatedBy != "" {
+		data["created_by"] = batch.CreatedBy
+	}
+	if batch.CompletedAt != nil {
+		data["completed_at"] = *batch.CompletedAt
+	}
+	return data
+}
+
+// mapToBatch converts a model row to a batch
+func (s *BatchStore) mapToBatch(row map[string]interface{}) (*types.Batch, error) {
+	batch := &types.Batch{}
+
+	if v, ok := row["batch_id"].(string); ok {
+		batch.BatchID = v
+	}
+	if v, ok := row["member_id"].(string); ok {
+		batch.MemberID = v
+	}
+	if v, ok := row["team_id"].(string); ok {
+		batch.TeamID = v
+	}
+	if v, ok := row["status"].(string); ok {
+		batch.Status = types.BatchStatus(v)
+	}
+	if v, ok := row["created_by"].(string); ok {
+		batch.CreatedBy = v
+	}
+
+	switch v := row["suppress_delivery"].(type) {
+	case bool:
+		batch.SuppressDelivery = v
+	case int64:
+		batch.SuppressDelivery = v != 0
+	case int:
+		batch.SuppressDelivery = v != 0
+	case float64:
+		batch.SuppressDelivery = v != 0
+	}
+
+	if v := row["items"]; v != nil {
+		items, err := s.parseItems(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse batch items: %w", err)
+		}
+		batch.Items = items
+	}
+
+	batch.CreatedAt = parseBatchTime(row["created_at"])
+	batch.UpdatedAt = parseBatchTime(row["updated_at"])
+	batch.CompletedAt = parseBatchTime(row["completed_at"])
+
+	return batch, nil
+}
+
+func (s *BatchStore) parseItems(v interface{}) ([]*types.BatchItem, error) {
+	var data []byte
+	switch raw := v.(type) {
+	case []byte:
+		data = raw
+	case string:
+		data = []byte(raw)
+	default:
+		var err error
+		data, err = json.Marshal(raw)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var items []*types.BatchItem
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func parseBatchTime(v interface{}) *time.Time {
+	if v == nil {
+		return nil
+	}
+	return (&ExecutionStore{}).parseTime(v)
+}
@@ -0,0 +1,235 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/yao/agent/robot/types"
+)
+
+// DeadLetter status values
+const (
+	DeadLetterPending     = "pending"     // not redelivered yet, or every redelivery failed
+	DeadLetterRedelivered = "redelivered" // a redelivery succeeded
+)
+
+// DeadLetter - a delivery that failed every attempt, kept so it can be redelivered
+type DeadLetter struct {
+	LetterID      string             `json:"letter_id"`
+	ExecutionID   string             `json:"execution_id"`
+	MemberID      string             `json:"member_id"`
+	TeamID        string             `json:"team_id,omitempty"`
+	Channel       types.DeliveryType `json:"channel"`
+	Target        string             `json:"target"`            // webhook URL
+	Payload       string             `json:"payload,omitempty"` // request body as sent
+	PayloadHash   string             `json:"payload_hash"`      // SHA-256 of Payload (hex)
+	LastError     string             `json:"last_error,omitempty"`
+	Attempts      int                `json:"attempts"`
+	Status        string             `json:"status"`
+	RedeliveredAt *time.Time         `json:"redelivered_at,omitempty"`
+	CreatedAt     *time.Time         `json:"created_at,omitempty"`
+	UpdatedAt     *time.Time         `json:"updated_at,omitempty"`
+}
+
+// DeadLetterListOptions - filters for DeadLetterStore.List
+type DeadLetterListOptions struct {
+	MemberID string
+	TeamID   string
+	Status   string // pending | redelivered, empty for both
+	Page     int
+	PageSize int
+}
+
+// DeadLetterStore - persistent storage for failed robot deliveries
+// Maps to __yao.agent.dead_letter model
+type DeadLetterStore struct {
+	modelID string
+}
+
+// NewDeadLetterStore creates a new dead letter store instance
+func NewDeadLetterStore() *DeadLetterStore {
+	return &DeadLetterStore{
+		modelID: "__yao.agent.dead_letter",
+	}
+}
+
+// Create stores a new dead letter
+func (s *DeadLetterStore) Create(ctx context.Context, letter *DeadLetter) error {
+	mod := model.Select(s.modelID)
+	if mod == nil {
+		return fmt.Errorf("model %s not found", s.modelID)
+	}
+
+	if letter.Status == "" {
+		letter.Status = DeadLetterPending
+	}
+	data := map[string]interface{}{
+		"letter_id":    letter.LetterID,
+		"execution_id": letter.ExecutionID,
+		"member_id":    letter.MemberID,
+		"team_id":      letter.TeamID,
+		"channel":      string(letter.Channel),
+		"target":       letter.Target,
+		"payload":      letter.Payload,
+		"payload_hash": letter.PayloadHash,
+		"last_error":   letter.LastError,
+		"attempts":     letter.Attempts,
+		"status":       letter.Status,
+	}
+	if _, err := mod.Create(data); err != nil {
+		return fmt.Errorf("failed to create dead letter: %w", err)
+	}
+	return nil
+}
+
+// Get retrieves a dead letter by letter_id, returns nil if not found
+func (s *DeadLetterStore) Get(ctx context.Context, letterID string) (*DeadLetter, error) {
+	mod := model.Select(s.modelID)
+	if mod == nil {
+		return nil, fmt.Errorf("model %s not found", s.modelID)
+	}
+
+	rows, err := mod.Get(model.QueryParam{
+		Wheres: []model.QueryWhere{
+			{Column: "letter_id", Value: letterID},
+		},
+		Limit: 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dead letter: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return s.mapToLetter(rows[0]), nil
+}
+
+// List returns dead letters newest first, without their payloads, and the total count
+func (s *DeadLetterStore) List(ctx context.Context, opts *DeadLetterListOptions) ([]*DeadLetter, int, error) {
+	mod := model.Select(s.modelID)
+	if mod == nil {
+		return nil, 0, fmt.Errorf("model %s not found", s.modelID)
+	}
+	if opts == nil {
+		opts = &DeadLetterListOptions{}
+	}
+
+	param := model.QueryParam{
+		Select: []interface{}{
+			"letter_id", "execution_id", "member_id", "team_id", "channel", "target", "payload_hash",
+			"last_error", "attempts", "status", "redelivered_at", "created_at", "updated_at",
+		},
+		Orders: []model.QueryOrder{{Column: "id", Option: "desc"}},
+	}
+	if opts.MemberID != "" {
+		param.Wheres = append(param.Wheres, model.QueryWhere{Column: "member_id", Value: opts.MemberID})
+	}
+	if opts.TeamID != "" {
+		param.Wheres = append(param.Wheres, model.QueryWhere{Column: "team_id", Value: opts.TeamID})
+	}
+	if opts.Status != "" {
+		param.Wheres = append(param.Wheres, model.QueryWhere{Column: "status", Value: opts.Status})
+	}
+
+	page, pageSize := opts.Page, opts.PageSize
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+
+	res, err := mod.Paginate(param, page, pageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+
+	letters := make([]*DeadLetter, 0)
+	for _, row := range toRows(res["data"]) {
+		letters = append(letters, s.mapToLetter(row))
+	}
+	return letters, rowInt(res["total"]), nil
+}
+
+// RecordAttempt adds a redelivery's attempts to a dead letter. On success the letter is
+// marked redelivered, otherwise lastError replaces the stored error.
+func (s *DeadLetterStore) RecordAttempt(ctx context.Context, letterID string, attempts int, success bool, lastError string) error {
+	mod := model.Select(s.modelID)
+	if mod == nil {
+		return fmt.Errorf("model %s not found", s.modelID)
+	}
+
+	letter, err := s.Get(ctx, letterID)
+	if err != nil {
+		return err
+	}
+	if letter == nil {
+		return fmt.Errorf("dead letter not found: %s", letterID)
+	}
+
+	data := map[string]interface{}{"attempts": letter.Attempts + attempts}
+	if success {
+		data["status"] = DeadLetterRedelivered
+		data["redelivered_at"] = time.Now()
+	} else {
+		data["last_error"] = lastError
+	}
+	_, err = mod.UpdateWhere(model.QueryParam{
+		Wheres: []model.QueryWhere{
+			{Column: "letter_id", Value: letterID},
+		},
+	}, data)
+	if err != nil {
+		return fmt.Errorf("failed to update dead letter: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a dead letter
+func (s *DeadLetterStore) Delete(ctx context.Context, letterID string) error {
+	mod := model.Select(s.modelID)
+	if mod == nil {
+		return fmt.Errorf("model %s not found", s.modelID)
+	}
+
+	_, err := mod.DeleteWhere(model.QueryParam{
+		Wheres: []model.QueryWhere{
+			{Column: "letter_id", Value: letterID},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete dead letter: %w", err)
+	}
+	return nil
+}
+
+// mapToLetter converts a model row to a dead letter
+func (s *DeadLetterStore) mapToLetter(row map[string]interface{}) *DeadLetter {
+	letter := &DeadLetter{}
+	letter.LetterID, _ = row["letter_id"].(string)
+	letter.ExecutionID, _ = row["execution_id"].(string)
+	letter.MemberID, _ = row["member_id"].(string)
+	letter.TeamID, _ = row["team_id"].(string)
+	if v, ok := row["channel"].(string); ok {
+		letter.Channel = types.DeliveryType(v)
+	}
+	letter.Target, _ = row["target"].(string)
+	letter.PayloadHash, _ = row["payload_hash"].(string)
+	letter.LastError, _ = row["last_error"].(string)
+	letter.Status, _ = row["status"].(string)
+	letter.Attempts = rowInt(row["attempts"])
+
+	switch v := row["payload"].(type) {
+	case string:
+		letter.Payload = v
+	case []byte:
+		letter.Payload = string(v)
+	}
+
+	letter.RedeliveredAt = parseBatchTime(row["redelivered_at"])
+	letter.CreatedAt = parseBatchTime(row["created_at"])
+	letter.UpdatedAt = parseBatchTime(row["updated_at"])
+	return letter
+}
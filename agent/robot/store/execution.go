@@ -4,10 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/yaoapp/gou/model"
 	"github.com/yaoapp/xun/capsule"
+	"github.com/yaoapp/xun/dbal/query"
 	"github.com/yaoapp/yao/agent/robot/types"
 )
 
@@ -41,6 +43,11 @@ type ExecutionRecord struct {
 	Delivery    *types.DeliveryResult    `json:"delivery,omitempty"`
 	Learning    []types.LearningEntry    `json:"learning,omitempty"`
 
+	// Delivery outcome, set by UpdateDeliveryStatus (pending until the channels report back)
+	DeliveryStatus types.DeliveryStatus `json:"delivery_status,omitempty"`
+	// Per-channel outcome reported by the delivery handler, set by UpdateDeliveryResults
+	DeliveryResults []types.ChannelResult `json:"delivery_results,omitempty"`
+
 	// V2: Conversation and suspend-resume fields
 	ChatID          string               `json:"chat_id,omitempty"`
 	WaitingTaskID   string               `json:"waiting_task_id,omitempty"`
@@ -48,6 +55,28 @@ type ExecutionRecord struct {
 	WaitingSince    *time.Time           `json:"waiting_since,omitempty"`
 	ResumeContext   *types.ResumeContext `json:"resume_context,omitempty"`
 
+	// Chaining: execution this one was continued from (empty for root executions)
+	ParentExecutionID string `json:"parent_execution_id,omitempty"`
+
+	// Operator annotations, appended with AddNote
+	Notes []types.ExecutionNote `json:"notes,omitempty"`
+
+	// Cancellation details, written by MarkCancelled
+	CancelReason string `json:"cancel_reason,omitempty"`
+	CancelledBy  string `json:"cancelled_by,omitempty"`
+
+	// Optimistic concurrency for plan edits, bumped by UpdatePlan
+	PlanVersion int `json:"plan_version,omitempty"`
+
+	// Cached plain-language summaries keyed by locale, written with SaveExplanation
+	Explanations map[string]*types.ExecutionExplanation `json:"explanations,omitempty"`
+
+	// Human plan edits, appended with AddPlanEdit
+	PlanEdits []types.PlanEdit `json:"plan_edits,omitempty"`
+
+	// Optimistic concurrency for notes, plan edits and explanations, bumped by every annotation write
+	AnnotationsVersion int `json:"annotations_version,omitempty"`
+
 	// Timestamps
 	StartTime *time.Time `json:"start_time,omitempty"`
 	EndTime   *time.Time `json:"end_time,omitempty"`
@@ -65,10 +94,13 @@ type CurrentState struct {
 type ListOptions struct {
 	MemberID        string             `json:"member_id,omitempty"`
 	TeamID          string             `json:"team_id,omitempty"`
+	ChatID          string             `json:"chat_id,omitempty"`
 	Status          types.ExecStatus   `json:"status,omitempty"`
 	Statuses        []types.ExecStatus `json:"statuses,omitempty"`         // Multi-status IN query; takes priority over Status when non-empty
 	ExcludeStatuses []types.ExecStatus `json:"exclude_statuses,omitempty"` // Exclude these statuses (ne)
 	TriggerType     types.TriggerType  `json:"trigger_type,omitempty"`
+	From            *time.Time         `json:"from,omitempty"` // start_time >= From
+	To              *time.Time         `json:"to,omitempty"`   // start_time <= To
 	Page            int                `json:"page,omitempty"`
 	PageSize        int                `json:"pagesize,omitempty"`
 	OrderBy         string             `json:"order_by,omitempty"`
@@ -85,6 +117,7 @@ type ListResult struct {
 // ExecutionStore - persistent storage for robot execution records
 type ExecutionStore struct {
 	modelID string
+	hot     *hotLayer // optional KV layer; falls back to the process-wide one (see SetExecutionHotStore)
 }
 
 // NewExecutionStore creates a new execution store instance
@@ -96,6 +129,8 @@ func NewExecutionStore() *ExecutionStore {
 
 // Save creates or updates an execution record
 func (s *ExecutionStore) Save(ctx context.Context, record *ExecutionRecord) error {
+	defer s.hotLayer().invalidate(record.ExecutionID)
+
 	mod := model.Select(s.modelID)
 	if mod == nil {
 		return fmt.Errorf("model %s not found", s.modelID)
@@ -104,7 +139,7 @@ func (s *ExecutionStore) Save(ctx context.Context, record *ExecutionRecord) erro
 	data := s.recordToMap(record)
 
 	// Check if record exists by execution_id
-	existing, err := s.Get(ctx, record.ExecutionID)
+	existing, err := s.getFromDB(record.ExecutionID)
 	if err == nil && existing != nil {
 		// Update existing record
 		_, err = mod.UpdateWhere(
@@ -130,7 +165,24 @@ func (s *ExecutionStore) Save(ctx context.Context, record *ExecutionRecord) erro
 }
 
 // Get retrieves an execution record by execution_id
+// Non-terminal records are served from the hot store when one is configured.
 func (s *ExecutionStore) Get(ctx context.Context, executionID string) (*ExecutionRecord, error) {
+	hot := s.hotLayer()
+	if record := hot.get(executionID); record != nil {
+		return record, nil
+	}
+
+	gen := hot.mark()
+	record, err := s.getFromDB(executionID)
+	if err != nil || record == nil {
+		return record, err
+	}
+	hot.put(record, gen)
+	return record, nil
+}
+
+// getFromDB reads an execution record from the database, bypassing the hot store
+func (s *ExecutionStore) getFromDB(executionID string) (*ExecutionRecord, error) {
 	mod := model.Select(s.modelID)
 	if mod == nil {
 		return nil, fmt.Errorf("model %s not found", s.modelID)
@@ -153,6 +205,61 @@ func (s *ExecutionStore) Get(ctx context.Context, executionID string) (*Executio
 	return s.mapToRecord(rows[0])
 }
 
+// ListChildren returns the executions directly chained from the given parent execution
+func (s *ExecutionStore) ListChildren(ctx context.Context, parentExecutionID string) ([]*ExecutionRecord, error) {
+	mod := model.Select(s.modelID)
+	if mod == nil {
+		return nil, fmt.Errorf("model %s not found", s.modelID)
+	}
+
+	rows, err := mod.Get(model.QueryParam{
+		Wheres: []model.QueryWhere{
+			{Column: "parent_execution_id", Value: parentExecutionID},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list child executions: %w", err)
+	}
+
+	records := make([]*ExecutionRecord, 0, len(rows))
+	for _, row := range rows {
+		record, err := s.mapToRecord(row)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// ListByChat returns every execution of a conversation (chat_id), oldest first
+func (s *ExecutionStore) ListByChat(ctx context.Context, chatID string) ([]*ExecutionRecord, error) {
+	mod := model.Select(s.modelID)
+	if mod == nil {
+		return nil, fmt.Errorf("model %s not found", s.modelID)
+	}
+
+	rows, err := mod.Get(model.QueryParam{
+		Wheres: []model.QueryWhere{
+			{Column: "chat_id", Value: chatID},
+		},
+		Orders: []model.QueryOrder{{Column: "created_at", Option: "asc"}, {Column: "id", Option: "asc"}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chat executions: %w", err)
+	}
+
+	records := make([]*ExecutionRecord, 0, len(rows))
+	for _, row := range rows {
+		record, err := s.mapToRecord(row)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
 // List retrieves execution records with pagination using mod.Paginate
 func (s *ExecutionStore) List(ctx context.Context, opts *ListOptions) (*ListResult, error) {
 	mod := model.Select(s.modelID)
@@ -173,6 +280,9 @@ func (s *ExecutionStore) List(ctx context.Context, opts *ListOptions) (*ListResu
 		if opts.TeamID != "" {
 			wheres = append(wheres, model.QueryWhere{Column: "team_id", Value: opts.TeamID})
 		}
+		if opts.ChatID != "" {
+			wheres = append(wheres, model.QueryWhere{Column: "chat_id", Value: opts.ChatID})
+		}
 		if len(opts.Statuses) > 0 {
 			// Backward compat: use the first status for simple equality filter.
 			// For multi-status IN queries, use ListByStatuses() instead.
@@ -186,6 +296,12 @@ func (s *ExecutionStore) List(ctx context.Context, opts *ListOptions) (*ListResu
 		if opts.TriggerType != "" {
 			wheres = append(wheres, model.QueryWhere{Column: "trigger_type", Value: string(opts.TriggerType)})
 		}
+		if opts.From != nil {
+			wheres = append(wheres, model.QueryWhere{Column: "start_time", OP: ">=", Value: *opts.From})
+		}
+		if opts.To != nil {
+			wheres = append(wheres, model.QueryWhere{Column: "start_time", OP: "<=", Value: *opts.To})
+		}
 
 		if opts.Page > 0 {
 			page = opts.Page
@@ -276,9 +392,7 @@ func (s *ExecutionStore) ListByStatuses(ctx context.Context, statuses []types.Ex
 
 	// Count query
 	countQB := qb.Table(tableName).WhereIn("status", statusStrs)
-	if opts != nil && opts.MemberID != "" {
-		countQB = countQB.Where("member_id", opts.MemberID)
-	}
+	countQB = scopeStatusQuery(countQB, opts)
 	total, err := countQB.Count()
 	if err != nil {
 		return nil, fmt.Errorf("failed to count executions by statuses: %w", err)
@@ -286,9 +400,7 @@ func (s *ExecutionStore) ListByStatuses(ctx context.Context, statuses []types.Ex
 
 	// Data query
 	dataQB := qb.Table(tableName).WhereIn("status", statusStrs)
-	if opts != nil && opts.MemberID != "" {
-		dataQB = dataQB.Where("member_id", opts.MemberID)
-	}
+	dataQB = scopeStatusQuery(dataQB, opts)
 	rows, err := dataQB.OrderBy("start_time", "desc").Limit(pageSize).Offset(offset).Get()
 	if err != nil {
 		return nil, fmt.Errorf("failed to list executions by statuses: %w", err)
@@ -312,8 +424,102 @@ func (s *ExecutionStore) ListByStatuses(ctx context.Context, statuses []types.Ex
 	}, nil
 }
 
+// scopeStatusQuery applies the member, team, chat, trigger and time range filters of opts to a status query
+func scopeStatusQuery(qb query.Query, opts *ListOptions) query.Query {
+	if opts == nil {
+		return qb
+	}
+	if opts.MemberID != "" {
+		qb = qb.Where("member_id", opts.MemberID)
+	}
+	if opts.TeamID != "" {
+		qb = qb.Where("team_id", opts.TeamID)
+	}
+	if opts.ChatID != "" {
+		qb = qb.Where("chat_id", opts.ChatID)
+	}
+	if opts.TriggerType != "" {
+		qb = qb.Where("trigger_type", string(opts.TriggerType))
+	}
+	if opts.From != nil {
+		qb = qb.Where("start_time", ">=", *opts.From)
+	}
+	if opts.To != nil {
+		qb = qb.Where("start_time", "<=", *opts.To)
+	}
+	return qb
+}
+
+// ExecutionIDsSince returns the IDs of the robot's executions started at or after since,
+// recorded by any instance. The pool counts them against max_daily_executions.
+func (s *ExecutionStore) ExecutionIDsSince(ctx context.Context, memberID string, since time.Time) ([]string, error) {
+	mod := model.Select(s.modelID)
+	if mod == nil {
+		return nil, fmt.Errorf("model %s not found", s.modelID)
+	}
+
+	rows, err := capsule.Query().Table(mod.MetaData.Table.Name).
+		Select("execution_id").
+		Where("member_id", memberID).
+		Where("start_time", ">=", since).
+		Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list executions since %s: %w", since.Format(time.RFC3339), err)
+	}
+
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if id, ok := row["execution_id"].(string); ok && id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// ListExecutionsByTeam lists the executions of every robot in a team.
+// opts supplies paging and the status/trigger filters; more than one entry in
+// opts.Statuses selects any of them.
+func (s *ExecutionStore) ListExecutionsByTeam(ctx context.Context, teamID string, opts *ListOptions) (*ListResult, error) {
+	if teamID == "" {
+		return nil, fmt.Errorf("team_id is required")
+	}
+	scoped := ListOptions{}
+	if opts != nil {
+		scoped = *opts
+	}
+	scoped.TeamID = teamID
+	return s.listScoped(ctx, &scoped)
+}
+
+// ListExecutionsByChat lists the executions started from one conversation, oldest
+// first unless opts.OrderBy says otherwise. Paging and filters work as in ListExecutionsByTeam.
+func (s *ExecutionStore) ListExecutionsByChat(ctx context.Context, chatID string, opts *ListOptions) (*ListResult, error) {
+	if chatID == "" {
+		return nil, fmt.Errorf("chat_id is required")
+	}
+	scoped := ListOptions{}
+	if opts != nil {
+		scoped = *opts
+	}
+	scoped.ChatID = chatID
+	if scoped.OrderBy == "" {
+		scoped.OrderBy = "start_time asc"
+	}
+	return s.listScoped(ctx, &scoped)
+}
+
+// listScoped routes multi-status filters to ListByStatuses, everything else to List
+func (s *ExecutionStore) listScoped(ctx context.Context, opts *ListOptions) (*ListResult, error) {
+	if len(opts.Statuses) > 1 {
+		return s.ListByStatuses(ctx, opts.Statuses, opts)
+	}
+	return s.List(ctx, opts)
+}
+
 // UpdatePhase updates the current phase and its data
 func (s *ExecutionStore) UpdatePhase(ctx context.Context, executionID string, phase types.Phase, data interface{}) error {
+	defer s.hotLayer().invalidate(executionID)
+
 	mod := model.Select(s.modelID)
 	if mod == nil {
 		return fmt.Errorf("model %s not found", s.modelID)
@@ -368,6 +574,8 @@ func (s *ExecutionStore) UpdatePhase(ctx context.Context, executionID string, ph
 
 // UpdateStatus updates the execution status
 func (s *ExecutionStore) UpdateStatus(ctx context.Context, executionID string, status types.ExecStatus, errorMsg string) error {
+	defer s.hotLayer().invalidate(executionID)
+
 	mod := model.Select(s.modelID)
 	if mod == nil {
 		return fmt.Errorf("model %s not found", s.modelID)
@@ -402,8 +610,72 @@ func (s *ExecutionStore) UpdateStatus(ctx context.Context, executionID string, s
 	return nil
 }
 
+// MarkCancelled sets an execution to cancelled and records why and by whom.
+// The reason is also written to error so existing readers keep showing it.
+func (s *ExecutionStore) MarkCancelled(ctx context.Context, executionID, reason, cancelledBy string) error {
+	defer s.hotLayer().invalidate(executionID)
+
+	mod := model.Select(s.modelID)
+	if mod == nil {
+		return fmt.Errorf("model %s not found", s.modelID)
+	}
+
+	_, err := mod.UpdateWhere(
+		model.QueryParam{
+			Wheres: []model.QueryWhere{
+				{Column: "execution_id", Value: executionID},
+			},
+		},
+		map[string]interface{}{
+			"status":        string(types.ExecCancelled),
+			"error":         reason,
+			"cancel_reason": reason,
+			"cancelled_by":  cancelledBy,
+			"end_time":      time.Now(),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark cancelled: %w", err)
+	}
+
+	return nil
+}
+
+// RestoreCancelled puts a cancelled execution back into status and clears the
+// cancellation details. Returns false when the execution is no longer cancelled.
+func (s *ExecutionStore) RestoreCancelled(ctx context.Context, executionID string, status types.ExecStatus) (bool, error) {
+	defer s.hotLayer().invalidate(executionID)
+
+	mod := model.Select(s.modelID)
+	if mod == nil {
+		return false, fmt.Errorf("model %s not found", s.modelID)
+	}
+
+	affected, err := mod.UpdateWhere(
+		model.QueryParam{
+			Wheres: []model.QueryWhere{
+				{Column: "execution_id", Value: executionID},
+				{Column: "status", Value: string(types.ExecCancelled)},
+			},
+		},
+		map[string]interface{}{
+			"status":        string(status),
+			"error":         nil,
+			"cancel_reason": nil,
+			"cancelled_by":  nil,
+			"end_time":      nil,
+		},
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to restore execution: %w", err)
+	}
+	return affected > 0, nil
+}
+
 // UpdateCurrent updates the current executing state
 func (s *ExecutionStore) UpdateCurrent(ctx context.Context, executionID string, current *CurrentState) error {
+	defer s.hotLayer().invalidate(executionID)
+
 	mod := model.Select(s.modelID)
 	if mod == nil {
 		return fmt.Errorf("model %s not found", s.modelID)
@@ -431,6 +703,8 @@ func (s *ExecutionStore) UpdateCurrent(ctx context.Context, executionID string,
 // UpdateTasks updates the tasks array with current status
 // This should be called after each task completes to persist status changes
 func (s *ExecutionStore) UpdateTasks(ctx context.Context, executionID string, tasks []types.Task, current *CurrentState) error {
+	defer s.hotLayer().invalidate(executionID)
+
 	mod := model.Select(s.modelID)
 	if mod == nil {
 		return fmt.Errorf("model %s not found", s.modelID)
@@ -459,6 +733,8 @@ func (s *ExecutionStore) UpdateTasks(ctx context.Context, executionID string, ta
 // UpdateUIFields updates the UI display fields (name and current_task_name)
 // These fields are updated by executor at each phase for frontend display
 func (s *ExecutionStore) UpdateUIFields(ctx context.Context, executionID string, name string, currentTaskName string) error {
+	defer s.hotLayer().invalidate(executionID)
+
 	mod := model.Select(s.modelID)
 	if mod == nil {
 		return fmt.Errorf("model %s not found", s.modelID)
@@ -494,6 +770,8 @@ func (s *ExecutionStore) UpdateUIFields(ctx context.Context, executionID string,
 // UpdateSuspendState atomically transitions an execution to waiting status
 // with all suspend-related fields in a single DB write.
 func (s *ExecutionStore) UpdateSuspendState(ctx context.Context, executionID string, waitingTaskID string, question string, resumeCtx *types.ResumeContext) error {
+	defer s.hotLayer().invalidate(executionID)
+
 	mod := model.Select(s.modelID)
 	if mod == nil {
 		return fmt.Errorf("model %s not found", s.modelID)
@@ -526,6 +804,8 @@ func (s *ExecutionStore) UpdateSuspendState(ctx context.Context, executionID str
 
 // UpdateResumeState clears waiting fields and transitions execution back to running.
 func (s *ExecutionStore) UpdateResumeState(ctx context.Context, executionID string) error {
+	defer s.hotLayer().invalidate(executionID)
+
 	mod := model.Select(s.modelID)
 	if mod == nil {
 		return fmt.Errorf("model %s not found", s.modelID)
@@ -553,8 +833,299 @@ func (s *ExecutionStore) UpdateResumeState(ctx context.Context, executionID stri
 	return nil
 }
 
+// ResetState forces an execution into status and clears its waiting, resume and
+// current-task state in one write. reason is stored as the execution error.
+func (s *ExecutionStore) ResetState(ctx context.Context, executionID string, status types.ExecStatus, reason string) error {
+	defer s.hotLayer().invalidate(executionID)
+
+	mod := model.Select(s.modelID)
+	if mod == nil {
+		return fmt.Errorf("model %s not found", s.modelID)
+	}
+
+	updateData := map[string]interface{}{
+		"status":           string(status),
+		"error":            reason,
+		"waiting_task_id":  "",
+		"waiting_question": "",
+		"waiting_since":    nil,
+		"resume_context":   nil,
+		"current":          nil,
+	}
+	if status.IsTerminal() {
+		updateData["end_time"] = time.Now()
+	}
+
+	_, err := mod.UpdateWhere(
+		model.QueryParam{
+			Wheres: []model.QueryWhere{
+				{Column: "execution_id", Value: executionID},
+			},
+		},
+		updateData,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to reset execution: %w", err)
+	}
+	return nil
+}
+
+// UpdateDeliveryStatus records the outcome of the delivery channels for an execution
+func (s *ExecutionStore) UpdateDeliveryStatus(ctx context.Context, executionID string, status types.DeliveryStatus) error {
+	defer s.hotLayer().invalidate(executionID)
+
+	mod := model.Select(s.modelID)
+	if mod == nil {
+		return fmt.Errorf("model %s not found", s.modelID)
+	}
+
+	_, err := mod.UpdateWhere(
+		model.QueryParam{
+			Wheres: []model.QueryWhere{
+				{Column: "execution_id", Value: executionID},
+			},
+		},
+		map[string]interface{}{"delivery_status": string(status)},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update delivery status: %w", err)
+	}
+	return nil
+}
+
+// UpdateDeliveryResults records the delivery outcome together with the per-channel results
+func (s *ExecutionStore) UpdateDeliveryResults(ctx context.Context, executionID string, status types.DeliveryStatus, results []types.ChannelResult) error {
+	defer s.hotLayer().invalidate(executionID)
+
+	mod := model.Select(s.modelID)
+	if mod == nil {
+		return fmt.Errorf("model %s not found", s.modelID)
+	}
+
+	_, err := mod.UpdateWhere(
+		model.QueryParam{
+			Wheres: []model.QueryWhere{
+				{Column: "execution_id", Value: executionID},
+			},
+		},
+		map[string]interface{}{
+			"delivery_status":  string(status),
+			"delivery_results": results,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update delivery results: %w", err)
+	}
+	return nil
+}
+
+// DeliveryHistoryOptions - filters for ListDeliveryHistory
+type DeliveryHistoryOptions struct {
+	TeamID   string     `json:"team_id,omitempty"`
+	MemberID string     `json:"member_id,omitempty"`
+	Since    *time.Time `json:"since,omitempty"` // only executions created at or after
+	Limit    int        `json:"limit,omitempty"` // most recent first; default 1000, max 10000
+}
+
+// ListDeliveryHistory returns the most recent executions that recorded per-channel delivery
+// results. Only identifying columns and delivery_results are loaded.
+func (s *ExecutionStore) ListDeliveryHistory(ctx context.Context, opts *DeliveryHistoryOptions) ([]*ExecutionRecord, error) {
+	mod := model.Select(s.modelID)
+	if mod == nil {
+		return nil, fmt.Errorf("model %s not found", s.modelID)
+	}
+	if opts == nil {
+		opts = &DeliveryHistoryOptions{}
+	}
+
+	wheres := []model.QueryWhere{{Column: "delivery_results", OP: "notnull"}}
+	if opts.TeamID != "" {
+		wheres = append(wheres, model.QueryWhere{Column: "team_id", Value: opts.TeamID})
+	}
+	if opts.MemberID != "" {
+		wheres = append(wheres, model.QueryWhere{Column: "member_id", Value: opts.MemberID})
+	}
+	if opts.Since != nil {
+		wheres = append(wheres, model.QueryWhere{Column: "created_at", OP: ">=", Value: *opts.Since})
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 1000
+	}
+	if limit > 10000 {
+		limit = 10000
+	}
+
+	rows, err := mod.Get(model.QueryParam{
+		Select: []interface{}{"execution_id", "member_id", "team_id", "delivery_status", "delivery_results", "created_at"},
+		Wheres: wheres,
+		Orders: []model.QueryOrder{{Column: "created_at", Option: "desc"}},
+		Limit:  limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list delivery history: %w", err)
+	}
+
+	records := make([]*ExecutionRecord, 0, len(rows))
+	for _, row := range rows {
+		record, err := s.mapToRecord(row)
+		if err != nil {
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// AddNote appends an operator note to an execution and returns the updated note list.
+// The append is a conditional update on annotations_version, so concurrent notes are
+// not lost, whichever instance writes them.
+func (s *ExecutionStore) AddNote(ctx context.Context, executionID string, note types.ExecutionNote) ([]types.ExecutionNote, error) {
+	var notes []types.ExecutionNote
+	err := s.updateAnnotations(executionID, func(record *ExecutionRecord) map[string]interface{} {
+		notes = append(record.Notes, note)
+		return map[string]interface{}{"notes": notes}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add execution note: %w", err)
+	}
+	return notes, nil
+}
+
+// AddPlanEdit appends a plan edit to the execution's edit history, with the same
+// concurrency guarantee as AddNote
+func (s *ExecutionStore) AddPlanEdit(ctx context.Context, executionID string, edit types.PlanEdit) error {
+	err := s.updateAnnotations(executionID, func(record *ExecutionRecord) map[string]interface{} {
+		return map[string]interface{}{"plan_edits": append(record.PlanEdits, edit)}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record plan edit: %w", err)
+	}
+	return nil
+}
+
+// UpdatePlan replaces the goals and tasks of a confirming execution, provided its plan
+// version still equals expectedVersion, and returns the new version.
+// The version check and the write are a single conditional UPDATE, so of two concurrent
+// edits based on the same version exactly one succeeds.
+func (s *ExecutionStore) UpdatePlan(ctx context.Context, executionID string, expectedVersion int, goals *types.Goals, tasks []types.Task) (int, error) {
+	defer s.hotLayer().invalidate(executionID)
+
+	mod := model.Select(s.modelID)
+	if mod == nil {
+		return 0, fmt.Errorf("model %s not found", s.modelID)
+	}
+
+	version := expectedVersion + 1
+	affected, err := mod.UpdateWhere(
+		model.QueryParam{
+			Wheres: []model.QueryWhere{
+				{Column: "execution_id", Value: executionID},
+				{Column: "status", Value: string(types.ExecConfirming)},
+				{Column: "plan_version", Value: expectedVersion},
+			},
+		},
+		map[string]interface{}{
+			"goals":        goals,
+			"tasks":        tasks,
+			"plan_version": version,
+		},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update plan: %w", err)
+	}
+	if affected > 0 {
+		return version, nil
+	}
+
+	// Nothing matched: tell a missing record, a state change and a stale version apart
+	record, err := s.getFromDB(executionID)
+	if err != nil {
+		return 0, err
+	}
+	if record == nil {
+		return 0, fmt.Errorf("execution not found: %s", executionID)
+	}
+	if record.Status != types.ExecConfirming {
+		return 0, fmt.Errorf("%w: execution %s is %s", types.ErrPlanNotEditable, executionID, record.Status)
+	}
+	return 0, fmt.Errorf("%w: expected version %d, current is %d", types.ErrPlanVersionConflict, expectedVersion, record.PlanVersion)
+}
+
+// SaveExplanation stores the summary for one locale, keeping the other locales.
+// A final summary already stored for the locale is never replaced.
+func (s *ExecutionStore) SaveExplanation(ctx context.Context, executionID, locale string, explanation *types.ExecutionExplanation) error {
+	err := s.updateAnnotations(executionID, func(record *ExecutionRecord) map[string]interface{} {
+		if existing := record.Explanations[locale]; existing != nil && existing.Final {
+			return nil
+		}
+		explanations := record.Explanations
+		if explanations == nil {
+			explanations = map[string]*types.ExecutionExplanation{}
+		}
+		explanations[locale] = explanation
+		return map[string]interface{}{"explanations": explanations}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save execution explanation: %w", err)
+	}
+	return nil
+}
+
+// annotationAttempts bounds the retries of updateAnnotations under contention
+const annotationAttempts = 5
+
+// updateAnnotations runs a read-modify-write of the annotation columns (notes, plan_edits,
+// explanations).
+// update derives the columns to write from the current record, nil to write nothing. The write
+// only applies while annotations_version still matches the record read, so a concurrent update
+// from this or another instance makes it start over from the fresh record instead of being lost.
+func (s *ExecutionStore) updateAnnotations(executionID string, update func(record *ExecutionRecord) map[string]interface{}) error {
+	defer s.hotLayer().invalidate(executionID)
+
+	mod := model.Select(s.modelID)
+	if mod == nil {
+		return fmt.Errorf("model %s not found", s.modelID)
+	}
+
+	for attempt := 0; attempt < annotationAttempts; attempt++ {
+		record, err := s.getFromDB(executionID)
+		if err != nil {
+			return err
+		}
+		if record == nil {
+			return fmt.Errorf("execution not found: %s", executionID)
+		}
+
+		data := update(record)
+		if data == nil {
+			return nil
+		}
+		data["annotations_version"] = record.AnnotationsVersion + 1
+		affected, err := mod.UpdateWhere(
+			model.QueryParam{
+				Wheres: []model.QueryWhere{
+					{Column: "execution_id", Value: executionID},
+					{Column: "annotations_version", Value: record.AnnotationsVersion},
+				},
+			},
+			data,
+		)
+		if err != nil {
+			return err
+		}
+		if affected > 0 {
+			return nil
+		}
+	}
+	return fmt.Errorf("execution %s: annotations changed concurrently %d times, giving up", executionID, annotationAttempts)
+}
+
 // Delete removes an execution record by execution_id
 func (s *ExecutionStore) Delete(ctx context.Context, executionID string) error {
+	defer s.hotLayer().invalidate(executionID)
+
 	mod := model.Select(s.modelID)
 	if mod == nil {
 		return fmt.Errorf("model %s not found", s.modelID)
@@ -572,6 +1143,59 @@ func (s *ExecutionStore) Delete(ctx context.Context, executionID string) error {
 	return nil
 }
 
+// cleanupStatuses are the statuses CleanupOldExecutions may delete. Waiting, confirming
+// and paused executions can still be resumed, so they are kept however old they are.
+var cleanupStatuses = []types.ExecStatus{types.ExecCompleted, types.ExecFailed, types.ExecCancelled}
+
+// CleanupOldExecutions hard-deletes completed, failed and cancelled executions whose
+// start_time is more than olderThan ago and returns how many were deleted.
+// The delete is a single conditional statement, so concurrent calls are safe; terminal
+// records are never held by the hot layer, so there is nothing to invalidate.
+func (s *ExecutionStore) CleanupOldExecutions(ctx context.Context, olderThan time.Duration) (int64, error) {
+	qb, err := s.oldExecutionsQuery(olderThan)
+	if err != nil {
+		return 0, err
+	}
+	deleted, err := qb.Delete()
+	if err != nil {
+		return 0, fmt.Errorf("failed to clean up execution records: %w", err)
+	}
+	return deleted, nil
+}
+
+// CountOldExecutions counts the executions CleanupOldExecutions would delete
+func (s *ExecutionStore) CountOldExecutions(ctx context.Context, olderThan time.Duration) (int64, error) {
+	qb, err := s.oldExecutionsQuery(olderThan)
+	if err != nil {
+		return 0, err
+	}
+	count, err := qb.Count()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count old execution records: %w", err)
+	}
+	return count, nil
+}
+
+// oldExecutionsQuery selects the terminal executions started before now - olderThan.
+// Uses WhereIn on the query builder for the same reason as ListByStatuses.
+func (s *ExecutionStore) oldExecutionsQuery(olderThan time.Duration) (query.Query, error) {
+	if olderThan <= 0 {
+		return nil, fmt.Errorf("olderThan must be positive, got %s", olderThan)
+	}
+	mod := model.Select(s.modelID)
+	if mod == nil {
+		return nil, fmt.Errorf("model %s not found", s.modelID)
+	}
+
+	statuses := make([]interface{}, len(cleanupStatuses))
+	for i, status := range cleanupStatuses {
+		statuses[i] = string(status)
+	}
+	return capsule.Query().Table(mod.MetaData.Table.Name).
+		WhereIn("status", statuses).
+		Where("start_time", "<", time.Now().Add(-olderThan)), nil
+}
+
 // recordToMap converts ExecutionRecord to map for model operations
 func (s *ExecutionStore) recordToMap(record *ExecutionRecord) map[string]interface{} {
 	data := map[string]interface{}{
@@ -616,10 +1240,19 @@ func (s *ExecutionStore) recordToMap(record *ExecutionRecord) map[string]interfa
 	if record.Learning != nil {
 		data["learning"] = record.Learning
 	}
+	if record.DeliveryStatus != "" {
+		data["delivery_status"] = string(record.DeliveryStatus)
+	}
+	if record.DeliveryResults != nil {
+		data["delivery_results"] = record.DeliveryResults
+	}
 	// V2 fields
 	if record.ChatID != "" {
 		data["chat_id"] = record.ChatID
 	}
+	if record.ParentExecutionID != "" {
+		data["parent_execution_id"] = record.ParentExecutionID
+	}
 	if record.WaitingTaskID != "" {
 		data["waiting_task_id"] = record.WaitingTaskID
 	}
@@ -632,6 +1265,23 @@ func (s *ExecutionStore) recordToMap(record *ExecutionRecord) map[string]interfa
 	if record.ResumeContext != nil {
 		data["resume_context"] = record.ResumeContext
 	}
+	// Notes are only written when present so a Save from the executor never drops them
+	if len(record.Notes) > 0 {
+		data["notes"] = record.Notes
+	}
+	// Same for the plan version: executor saves carry 0 and must not reset it
+	if record.PlanVersion > 0 {
+		data["plan_version"] = record.PlanVersion
+	}
+	if len(record.Explanations) > 0 {
+		data["explanations"] = record.Explanations
+	}
+	if record.CancelReason != "" {
+		data["cancel_reason"] = record.CancelReason
+	}
+	if record.CancelledBy != "" {
+		data["cancelled_by"] = record.CancelledBy
+	}
 
 	if record.StartTime != nil {
 		data["start_time"] = *record.StartTime
@@ -711,11 +1361,20 @@ func (s *ExecutionStore) mapToRecord(row map[string]interface{}) (*ExecutionReco
 	if v := row["learning"]; v != nil {
 		record.Learning = s.parseLearningEntries(v)
 	}
+	if v, ok := row["delivery_status"].(string); ok {
+		record.DeliveryStatus = types.DeliveryStatus(v)
+	}
+	if v := row["delivery_results"]; v != nil {
+		record.DeliveryResults = s.parseChannelResults(v)
+	}
 
 	// V2 fields
 	if v, ok := row["chat_id"].(string); ok {
 		record.ChatID = v
 	}
+	if v, ok := row["parent_execution_id"].(string); ok {
+		record.ParentExecutionID = v
+	}
 	if v, ok := row["waiting_task_id"].(string); ok {
 		record.WaitingTaskID = v
 	}
@@ -728,6 +1387,27 @@ func (s *ExecutionStore) mapToRecord(row map[string]interface{}) (*ExecutionReco
 	if v := row["resume_context"]; v != nil {
 		record.ResumeContext = s.parseResumeContext(v)
 	}
+	if v := row["notes"]; v != nil {
+		record.Notes = s.parseNotes(v)
+	}
+	if v := row["plan_version"]; v != nil {
+		record.PlanVersion = rowInt(v)
+	}
+	if v := row["explanations"]; v != nil {
+		record.Explanations = s.parseExplanations(v)
+	}
+	if v := row["plan_edits"]; v != nil {
+		record.PlanEdits = s.parsePlanEdits(v)
+	}
+	if v := row["annotations_version"]; v != nil {
+		record.AnnotationsVersion = rowInt(v)
+	}
+	if v, ok := row["cancel_reason"].(string); ok {
+		record.CancelReason = v
+	}
+	if v, ok := row["cancelled_by"].(string); ok {
+		record.CancelledBy = v
+	}
 
 	// Timestamps
 	if v := row["start_time"]; v != nil {
@@ -832,6 +1512,18 @@ func (s *ExecutionStore) parseDeliveryResult(v interface{}) *types.DeliveryResul
 	return &result
 }
 
+func (s *ExecutionStore) parseChannelResults(v interface{}) []types.ChannelResult {
+	data, err := s.toJSON(v)
+	if err != nil {
+		return nil
+	}
+	var results []types.ChannelResult
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil
+	}
+	return results
+}
+
 func (s *ExecutionStore) parseLearningEntries(v interface{}) []types.LearningEntry {
 	data, err := s.toJSON(v)
 	if err != nil {
@@ -856,6 +1548,42 @@ func (s *ExecutionStore) parseResumeContext(v interface{}) *types.ResumeContext
 	return &ctx
 }
 
+func (s *ExecutionStore) parseNotes(v interface{}) []types.ExecutionNote {
+	data, err := s.toJSON(v)
+	if err != nil {
+		return nil
+	}
+	var notes []types.ExecutionNote
+	if err := json.Unmarshal(data, &notes); err != nil {
+		return nil
+	}
+	return notes
+}
+
+func (s *ExecutionStore) parsePlanEdits(v interface{}) []types.PlanEdit {
+	data, err := s.toJSON(v)
+	if err != nil {
+		return nil
+	}
+	var edits []types.PlanEdit
+	if err := json.Unmarshal(data, &edits); err != nil {
+		return nil
+	}
+	return edits
+}
+
+func (s *ExecutionStore) parseExplanations(v interface{}) map[string]*types.ExecutionExplanation {
+	data, err := s.toJSON(v)
+	if err != nil {
+		return nil
+	}
+	var explanations map[string]*types.ExecutionExplanation
+	if err := json.Unmarshal(data, &explanations); err != nil {
+		return nil
+	}
+	return explanations
+}
+
 func (s *ExecutionStore) toJSON(v interface{}) ([]byte, error) {
 	switch data := v.(type) {
 	case []byte:
@@ -936,6 +1664,145 @@ func (s *ExecutionStore) parseTime(v interface{}) *time.Time {
 	return nil
 }
 
+// ==================== Team Overview ====================
+
+// MemberStatusCount is one group of a per-robot execution count
+type MemberStatusCount struct {
+	MemberID string           `json:"member_id"`
+	Status   types.ExecStatus `json:"status"`
+	Count    int              `json:"count"`
+}
+
+// CountByMemberStatus counts a team's executions grouped by (member_id, status) in a single query.
+// When endedAfter is set only executions that ended at or after it are counted (use it for terminal statuses).
+func (s *ExecutionStore) CountByMemberStatus(ctx context.Context, teamID string, statuses []types.ExecStatus, endedAfter *time.Time) ([]MemberStatusCount, error) {
+	if teamID == "" || len(statuses) == 0 {
+		return []MemberStatusCount{}, nil
+	}
+
+	mod := model.Select(s.modelID)
+	if mod == nil {
+		return nil, fmt.Errorf("model %s not found", s.modelID)
+	}
+
+	statusStrs := make([]interface{}, len(statuses))
+	for i, st := range statuses {
+		statusStrs[i] = string(st)
+	}
+
+	qb := capsule.Query().
+		Table(mod.MetaData.Table.Name).
+		Select("member_id", "status").
+		SelectRaw("COUNT(*) as cnt").
+		Where("team_id", teamID).
+		WhereIn("status", statusStrs)
+	if endedAfter != nil {
+		qb = qb.Where("end_time", ">=", *endedAfter)
+	}
+
+	rows, err := qb.GroupBy("member_id", "status").Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to count executions by member: %w", err)
+	}
+
+	counts := make([]MemberStatusCount, 0, len(rows))
+	for _, row := range rows {
+		memberID, _ := row["member_id"].(string)
+		status, _ := row["status"].(string)
+		counts = append(counts, MemberStatusCount{
+			MemberID: memberID,
+			Status:   types.ExecStatus(status),
+			Count:    rowInt(row["cnt"]),
+		})
+	}
+	return counts, nil
+}
+
+// LatestByMember returns the most recent execution (by start_time) of every robot in a team,
+// keyed by member_id. Uses one grouped query plus one lookup query.
+func (s *ExecutionStore) LatestByMember(ctx context.Context, teamID string) (map[string]*ExecutionRecord, error) {
+	latest := map[string]*ExecutionRecord{}
+	if teamID == "" {
+		return latest, nil
+	}
+
+	mod := model.Select(s.modelID)
+	if mod == nil {
+		return nil, fmt.Errorf("model %s not found", s.modelID)
+	}
+	tableName := mod.MetaData.Table.Name
+
+	groups, err := capsule.Query().
+		Table(tableName).
+		Select("member_id").
+		SelectRaw("MAX(start_time) as last_start").
+		Where("team_id", teamID).
+		WhereNotNull("start_time").
+		GroupBy("member_id").
+		Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to group latest executions: %w", err)
+	}
+	if len(groups) == 0 {
+		return latest, nil
+	}
+
+	memberIDs := make([]interface{}, 0, len(groups))
+	starts := make([]interface{}, 0, len(groups))
+	lastStart := make(map[string]*time.Time, len(groups))
+	for _, g := range groups {
+		memberID, _ := g["member_id"].(string)
+		memberIDs = append(memberIDs, memberID)
+		starts = append(starts, g["last_start"])
+		lastStart[memberID] = s.parseTime(g["last_start"])
+	}
+
+	rows, err := capsule.Query().
+		Table(tableName).
+		Select("execution_id", "member_id", "team_id", "trigger_type", "status", "phase", "name", "start_time", "end_time").
+		Where("team_id", teamID).
+		WhereIn("member_id", memberIDs).
+		WhereIn("start_time", starts).
+		Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load latest executions: %w", err)
+	}
+
+	for _, row := range rows {
+		record, err := s.mapToRecord(map[string]interface{}(row))
+		if err != nil {
+			continue
+		}
+		want := lastStart[record.MemberID]
+		if want == nil || record.StartTime == nil || !record.StartTime.Equal(*want) {
+			continue
+		}
+		if _, exists := latest[record.MemberID]; !exists {
+			latest[record.MemberID] = record
+		}
+	}
+	return latest, nil
+}
+
+// rowInt reads an integer aggregate column across drivers
+func rowInt(v interface{}) int {
+	switch n := v.(type) {
+	case int:
+		return n
+	case int64:
+		return int(n)
+	case float64:
+		return int(n)
+	case []byte:
+		i, _ := strconv.Atoi(string(n))
+		return i
+	case string:
+		i, _ := strconv.Atoi(n)
+		return i
+	}
+	return 0
+}
+
 // ==================== Results & Activities ====================
 
 // ResultListOptions - options for listing execution results (deliveries)
@@ -1304,11 +2171,17 @@ func FromExecution(exec *types.Execution) *ExecutionRecord {
 		Results:         exec.Results,
 		Delivery:        exec.Delivery,
 		Learning:        exec.Learning,
+		DeliveryStatus:  exec.DeliveryStatus,
 		ChatID:          exec.ChatID,
 		WaitingTaskID:   exec.WaitingTaskID,
 		WaitingQuestion: exec.WaitingQuestion,
 		WaitingSince:    exec.WaitingSince,
 		ResumeContext:   exec.ResumeContext,
+
+		ParentExecutionID: exec.ParentExecutionID,
+		Notes:             exec.Notes,
+		CancelReason:      exec.CancelReason,
+		CancelledBy:       exec.CancelledBy,
 	}
 
 	// Convert timestamps
@@ -1349,11 +2222,18 @@ func (r *ExecutionRecord) ToExecution() *types.Execution {
 		Results:         r.Results,
 		Delivery:        r.Delivery,
 		Learning:        r.Learning,
+		DeliveryStatus:  r.DeliveryStatus,
 		ChatID:          r.ChatID,
 		WaitingTaskID:   r.WaitingTaskID,
 		WaitingQuestion: r.WaitingQuestion,
 		WaitingSince:    r.WaitingSince,
 		ResumeContext:   r.ResumeContext,
+
+		ParentExecutionID: r.ParentExecutionID,
+		Notes:             r.Notes,
+		PlanEdits:         r.PlanEdits,
+		CancelReason:      r.CancelReason,
+		CancelledBy:       r.CancelledBy,
 	}
 
 	// Convert timestamps
@@ -0,0 +1,215 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/agent/robot/types"
+)
+
+// ExecutionBackend is the storage contract behind the interact and status-poll hot paths.
+// ExecutionStore is the default (SQL) implementation; when a hot store is configured it
+// also acts as the layered implementation described below.
+//
+// Consistency rules for the layered mode:
+//   - SQL is always written first and is the source of truth; the KV entry is dropped after every write
+//   - only non-terminal records (pending/running/waiting/confirming) are served from KV
+//   - terminal records are always read from SQL and never cached
+//   - List queries always hit SQL
+//   - a read that raced with a write does not repopulate KV with the pre-write record
+//   - a failed KV operation disables KV reads for a cooldown (at least the TTL after a failed
+//     invalidation), so a stale entry is never served; reads fall back to SQL transparently
+//   - an entry is served for at most the TTL after it was read from SQL, whatever the KV's own
+//     expiry. Instances sharing SQL but not the KV (e.g. one lru store each) do not see each
+//     other's invalidations, so the TTL bounds how stale their status polls can be.
+//
+// Optimistic concurrency (plan_version, see UpdatePlan) lives in the SQL layer; the
+// conditional UPDATE is the arbiter and cached records are never used for the check.
+type ExecutionBackend interface {
+	Get(ctx context.Context, executionID string) (*ExecutionRecord, error)
+	Save(ctx context.Context, record *ExecutionRecord) error
+	UpdateStatus(ctx context.Context, executionID string, status types.ExecStatus, errorMsg string) error
+	UpdatePhase(ctx context.Context, executionID string, phase types.Phase, data interface{}) error
+	UpdateTasks(ctx context.Context, executionID string, tasks []types.Task, current *CurrentState) error
+	UpdateSuspendState(ctx context.Context, executionID string, waitingTaskID string, question string, resumeCtx *types.ResumeContext) error
+	UpdateResumeState(ctx context.Context, executionID string) error
+	List(ctx context.Context, opts *ListOptions) (*ListResult, error)
+}
+
+var _ ExecutionBackend = (*ExecutionStore)(nil)
+
+// HotStore is the KV subset used for hot execution state.
+// gou store.Store (lru, redis, mongo, badger) satisfies it.
+type HotStore interface {
+	Get(key string) (interface{}, bool)
+	Set(key string, value interface{}, ttl time.Duration) error
+	Del(key string) error
+}
+
+const (
+	hotStoreKeyPrefix  = "robot:execution:"
+	hotStoreDefaultTTL = 5 * time.Second
+	hotStoreCooldown   = 30 * time.Second
+)
+
+// defaultHotLayer is shared by every ExecutionStore created without an explicit layer
+var defaultHotLayer atomic.Pointer[hotLayer]
+
+// SetExecutionHotStore configures the KV layer used by execution stores for non-terminal records.
+// Pass nil to serve everything from SQL (the default). ttl <= 0 uses a 5 second TTL.
+func SetExecutionHotStore(kv HotStore, ttl time.Duration) {
+	if kv == nil {
+		defaultHotLayer.Store(nil)
+		return
+	}
+	defaultHotLayer.Store(newHotLayer(kv, ttl))
+}
+
+// NewExecutionStoreWithHotStore creates an execution store with its own KV layer,
+// independent of the process-wide one set by SetExecutionHotStore
+func NewExecutionStoreWithHotStore(kv HotStore, ttl time.Duration) *ExecutionStore {
+	s := NewExecutionStore()
+	if kv != nil {
+		s.hot = newHotLayer(kv, ttl)
+	}
+	return s
+}
+
+// hotEntry is the cached form of a record, stamped with the time it was read from SQL
+type hotEntry struct {
+	CachedAt int64            `json:"cached_at"` // unix nano
+	Record   *ExecutionRecord `json:"record"`
+}
+
+// hotLayer caches non-terminal execution records in a KV store
+type hotLayer struct {
+	kv          HotStore
+	ttl         time.Duration
+	bypassUntil atomic.Int64  // unix nano; reads skip KV until then
+	generation  atomic.Uint64 // bumped on every invalidation
+	mu          sync.Mutex    // orders put against invalidate
+}
+
+func newHotLayer(kv HotStore, ttl time.Duration) *hotLayer {
+	if ttl <= 0 {
+		ttl = hotStoreDefaultTTL
+	}
+	return &hotLayer{kv: kv, ttl: ttl}
+}
+
+// hotLayer returns the layer this store reads through, nil when KV is disabled
+func (s *ExecutionStore) hotLayer() *hotLayer {
+	if s.hot != nil {
+		return s.hot
+	}
+	return defaultHotLayer.Load()
+}
+
+// get returns the cached record, nil on miss, error, bypass, expiry or terminal state
+func (h *hotLayer) get(executionID string) (record *ExecutionRecord) {
+	if h == nil || executionID == "" || h.bypassed() {
+		return nil
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			h.trip("get", executionID, r)
+			record = nil
+		}
+	}()
+
+	v, ok := h.kv.Get(hotStoreKeyPrefix + executionID)
+	if !ok || v == nil {
+		return nil
+	}
+
+	var raw []byte
+	switch val := v.(type) {
+	case string:
+		raw = []byte(val)
+	case []byte:
+		raw = val
+	default:
+		return nil
+	}
+
+	var entry hotEntry
+	if err := json.Unmarshal(raw, &entry); err != nil || entry.Record == nil || entry.Record.Status.IsTerminal() {
+		return nil
+	}
+	if time.Since(time.Unix(0, entry.CachedAt)) > h.ttl {
+		return nil
+	}
+	return entry.Record
+}
+
+// mark returns the current write generation, taken before a SQL read that may be cached
+func (h *hotLayer) mark() uint64 {
+	if h == nil {
+		return 0
+	}
+	return h.generation.Load()
+}
+
+// put caches a non-terminal record read at generation gen; terminal records are never cached,
+// and neither is a record whose read overlapped a write
+func (h *hotLayer) put(record *ExecutionRecord, gen uint64) {
+	if h == nil || record == nil || record.Status.IsTerminal() || h.bypassed() {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.generation.Load() != gen {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			h.trip("set", record.ExecutionID, r)
+		}
+	}()
+
+	raw, err := json.Marshal(hotEntry{CachedAt: time.Now().UnixNano(), Record: record})
+	if err != nil {
+		return
+	}
+	if err := h.kv.Set(hotStoreKeyPrefix+record.ExecutionID, string(raw), h.ttl); err != nil {
+		h.trip("set", record.ExecutionID, err)
+	}
+}
+
+// invalidate drops the cached record after a write
+func (h *hotLayer) invalidate(executionID string) {
+	if h == nil || executionID == "" {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	defer func() {
+		if r := recover(); r != nil {
+			h.trip("del", executionID, r)
+		}
+	}()
+
+	h.generation.Add(1)
+	if err := h.kv.Del(hotStoreKeyPrefix + executionID); err != nil {
+		h.trip("del", executionID, err)
+	}
+}
+
+// trip disables KV reads for a cooldown period. A failed delete may leave a stale entry
+// behind, so reads stay on SQL until it has expired.
+func (h *hotLayer) trip(op, executionID string, cause interface{}) {
+	cooldown := hotStoreCooldown
+	if op == "del" && h.ttl > cooldown {
+		cooldown = h.ttl
+	}
+	h.bypassUntil.Store(time.Now().Add(cooldown).UnixNano())
+	log.Warn("robot execution hot store %s failed for %s, serving from database for %s: %v", op, executionID, cooldown, cause)
+}
+
+func (h *hotLayer) bypassed() bool {
+	return time.Now().UnixNano() < h.bypassUntil.Load()
+}
@@ -0,0 +1,252 @@
+//go:build integration
+
+package store_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/yao/agent/robot/store"
+	"github.com/yaoapp/yao/agent/robot/types"
+	"github.com/yaoapp/yao/unit-test/agent/testprepare"
+)
+
+// memoryHotStore is an in-process HotStore with failure injection
+type memoryHotStore struct {
+	mu       sync.Mutex
+	data     map[string]interface{}
+	gets     int
+	hits     int
+	failSet  bool
+	failDel  bool
+	panicGet bool
+}
+
+func newMemoryHotStore() *memoryHotStore {
+	return &memoryHotStore{data: map[string]interface{}{}}
+}
+
+func (m *memoryHotStore) Get(key string) (interface{}, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.panicGet {
+		panic("connection refused")
+	}
+	m.gets++
+	v, ok := m.data[key]
+	if ok {
+		m.hits++
+	}
+	return v, ok
+}
+
+func (m *memoryHotStore) Set(key string, value interface{}, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.failSet {
+		return fmt.Errorf("connection refused")
+	}
+	m.data[key] = value
+	return nil
+}
+
+func (m *memoryHotStore) Del(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.failDel {
+		return fmt.Errorf("connection refused")
+	}
+	delete(m.data, key)
+	return nil
+}
+
+func (m *memoryHotStore) size() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.data)
+}
+
+func (m *memoryHotStore) hitCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.hits
+}
+
+func saveHotTestExecution(t *testing.T, s *store.ExecutionStore, id string, status types.ExecStatus, teamID string) {
+	t.Helper()
+	now := time.Now()
+	require.NoError(t, s.Save(context.Background(), &store.ExecutionRecord{
+		ExecutionID: id,
+		MemberID:    "member_test_hot",
+		TeamID:      teamID,
+		TriggerType: types.TriggerHuman,
+		Status:      status,
+		Phase:       types.PhaseRun,
+		StartTime:   &now,
+	}))
+}
+
+// setRawStatus changes a row behind the store's back, to detect reads served from KV
+func setRawStatus(t *testing.T, id string, status types.ExecStatus) {
+	t.Helper()
+	_, err := model.Select("__yao.agent.execution").UpdateWhere(model.QueryParam{
+		Wheres: []model.QueryWhere{{Column: "execution_id", Value: id}},
+	}, map[string]interface{}{"status": string(status)})
+	require.NoError(t, err)
+}
+
+func TestExecutionStoreHotLayer(t *testing.T) {
+	identity := testprepare.PrepareSandbox(t)
+	cleanupTestExecutions(t)
+	defer cleanupTestExecutions(t)
+
+	ctx := context.Background()
+
+	t.Run("serves non-terminal reads from KV", func(t *testing.T) {
+		kv := newMemoryHotStore()
+		s := store.NewExecutionStoreWithHotStore(kv, time.Minute)
+		saveHotTestExecution(t, s, "exec_test_hot_001", types.ExecRunning, identity.AlphaTeamID)
+
+		first, err := s.Get(ctx, "exec_test_hot_001")
+		require.NoError(t, err)
+		assert.Equal(t, types.ExecRunning, first.Status)
+		assert.Equal(t, 1, kv.size())
+
+		// A change made outside the store is not visible: the read came from KV
+		setRawStatus(t, "exec_test_hot_001", types.ExecPending)
+		second, err := s.Get(ctx, "exec_test_hot_001")
+		require.NoError(t, err)
+		assert.Equal(t, types.ExecRunning, second.Status)
+		assert.Equal(t, 1, kv.hitCount())
+	})
+
+	t.Run("terminal records always come from SQL", func(t *testing.T) {
+		kv := newMemoryHotStore()
+		s := store.NewExecutionStoreWithHotStore(kv, time.Minute)
+		saveHotTestExecution(t, s, "exec_test_hot_002", types.ExecRunning, identity.AlphaTeamID)
+
+		_, err := s.Get(ctx, "exec_test_hot_002")
+		require.NoError(t, err)
+		require.NoError(t, s.UpdateStatus(ctx, "exec_test_hot_002", types.ExecCompleted, ""))
+		assert.Equal(t, 0, kv.size())
+
+		record, err := s.Get(ctx, "exec_test_hot_002")
+		require.NoError(t, err)
+		assert.Equal(t, types.ExecCompleted, record.Status)
+		assert.Equal(t, 0, kv.size(), "terminal records are never cached")
+	})
+
+	t.Run("no stale waiting state after resume", func(t *testing.T) {
+		kv := newMemoryHotStore()
+		s := store.NewExecutionStoreWithHotStore(kv, time.Minute)
+		saveHotTestExecution(t, s, "exec_test_hot_003", types.ExecRunning, identity.AlphaTeamID)
+
+		require.NoError(t, s.UpdateSuspendState(ctx, "exec_test_hot_003", "task-1", "Which region?", nil))
+		waiting, err := s.Get(ctx, "exec_test_hot_003")
+		require.NoError(t, err)
+		require.Equal(t, types.ExecWaiting, waiting.Status)
+		assert.Equal(t, "task-1", waiting.WaitingTaskID)
+
+		require.NoError(t, s.UpdateResumeState(ctx, "exec_test_hot_003"))
+		resumed, err := s.Get(ctx, "exec_test_hot_003")
+		require.NoError(t, err)
+		assert.Equal(t, types.ExecRunning, resumed.Status)
+		assert.Empty(t, resumed.WaitingTaskID)
+		assert.Empty(t, resumed.WaitingQuestion)
+	})
+
+	t.Run("failed invalidation falls back to SQL", func(t *testing.T) {
+		kv := newMemoryHotStore()
+		s := store.NewExecutionStoreWithHotStore(kv, time.Minute)
+		saveHotTestExecution(t, s, "exec_test_hot_004", types.ExecRunning, identity.AlphaTeamID)
+		require.NoError(t, s.UpdateSuspendState(ctx, "exec_test_hot_004", "task-1", "Approve?", nil))
+
+		_, err := s.Get(ctx, "exec_test_hot_004")
+		require.NoError(t, err)
+		require.Equal(t, 1, kv.size())
+
+		// KV goes away: the stale waiting entry stays behind but must not be served
+		kv.failDel = true
+		require.NoError(t, s.UpdateResumeState(ctx, "exec_test_hot_004"))
+		assert.Equal(t, 1, kv.size())
+
+		record, err := s.Get(ctx, "exec_test_hot_004")
+		require.NoError(t, err)
+		assert.Equal(t, types.ExecRunning, record.Status)
+	})
+
+	t.Run("unavailable KV is transparent", func(t *testing.T) {
+		kv := newMemoryHotStore()
+		kv.panicGet = true
+		kv.failSet = true
+		s := store.NewExecutionStoreWithHotStore(kv, time.Minute)
+		saveHotTestExecution(t, s, "exec_test_hot_005", types.ExecWaiting, identity.AlphaTeamID)
+
+		record, err := s.Get(ctx, "exec_test_hot_005")
+		require.NoError(t, err)
+		require.NotNil(t, record)
+		assert.Equal(t, types.ExecWaiting, record.Status)
+	})
+
+	t.Run("entries are served for the TTL only", func(t *testing.T) {
+		kv := newMemoryHotStore()
+		s := store.NewExecutionStoreWithHotStore(kv, 50*time.Millisecond)
+		saveHotTestExecution(t, s, "exec_test_hot_007", types.ExecRunning, identity.AlphaTeamID)
+
+		_, err := s.Get(ctx, "exec_test_hot_007")
+		require.NoError(t, err)
+		require.Equal(t, 1, kv.size())
+
+		// Another instance changes the row; this instance's KV never hears of it
+		setRawStatus(t, "exec_test_hot_007", types.ExecWaiting)
+		time.Sleep(100 * time.Millisecond)
+
+		record, err := s.Get(ctx, "exec_test_hot_007")
+		require.NoError(t, err)
+		assert.Equal(t, types.ExecWaiting, record.Status, "an expired entry is read again from SQL")
+	})
+
+	t.Run("process-wide hot store", func(t *testing.T) {
+		kv := newMemoryHotStore()
+		store.SetExecutionHotStore(kv, time.Minute)
+		defer store.SetExecutionHotStore(nil, 0)
+
+		s := store.NewExecutionStore()
+		saveHotTestExecution(t, s, "exec_test_hot_006", types.ExecRunning, identity.AlphaTeamID)
+		_, err := s.Get(ctx, "exec_test_hot_006")
+		require.NoError(t, err)
+		assert.Equal(t, 1, kv.size())
+	})
+}
+
+// BenchmarkExecutionStoreStatusPoll compares status-poll reads with and without the KV layer
+func BenchmarkExecutionStoreStatusPoll(b *testing.B) {
+	// Convert testing.B to testing.T for the sandbox helpers
+	t := &testing.T{}
+	identity := testprepare.PrepareSandbox(t)
+	cleanupTestExecutions(t)
+	defer cleanupTestExecutions(t)
+
+	ctx := context.Background()
+	sqlOnly := store.NewExecutionStore()
+	saveHotTestExecution(t, sqlOnly, "exec_test_hot_bench", types.ExecWaiting, identity.AlphaTeamID)
+
+	for name, s := range map[string]*store.ExecutionStore{
+		"sql":     sqlOnly,
+		"layered": store.NewExecutionStoreWithHotStore(newMemoryHotStore(), time.Minute),
+	} {
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := s.Get(ctx, "exec_test_hot_bench"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
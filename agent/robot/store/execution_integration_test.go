@@ -5,6 +5,7 @@ package store_test
 import (
 	"context"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -1096,6 +1097,222 @@ func TestExecutionStoreListActivities(t *testing.T) {
 
 // --- helpers ---
 
+// TestExecutionStoreAddNote tests operator annotations on execution records
+func TestExecutionStoreAddNote(t *testing.T) {
+	identity := testprepare.PrepareSandbox(t)
+	cleanupTestExecutions(t)
+	defer cleanupTestExecutions(t)
+
+	s := store.NewExecutionStore()
+	ctx := context.Background()
+
+	startTime := time.Now()
+	record := &store.ExecutionRecord{
+		ExecutionID: "exec_test_note_001",
+		MemberID:    "member_test_note",
+		TeamID:      identity.AlphaTeamID,
+		TriggerType: types.TriggerHuman,
+		Status:      types.ExecCompleted,
+		Phase:       types.PhaseLearning,
+		StartTime:   &startTime,
+	}
+	require.NoError(t, s.Save(ctx, record))
+
+	t.Run("appends notes in order", func(t *testing.T) {
+		notes, err := s.AddNote(ctx, "exec_test_note_001", types.ExecutionNote{Author: "user_1", Note: "re-ran because of bad input", CreatedAt: time.Now()})
+		require.NoError(t, err)
+		assert.Len(t, notes, 1)
+
+		notes, err = s.AddNote(ctx, "exec_test_note_001", types.ExecutionNote{Author: "user_2", Note: "confirmed with customer", CreatedAt: time.Now()})
+		require.NoError(t, err)
+		require.Len(t, notes, 2)
+
+		saved, err := s.Get(ctx, "exec_test_note_001")
+		require.NoError(t, err)
+		require.Len(t, saved.Notes, 2)
+		assert.Equal(t, "user_1", saved.Notes[0].Author)
+		assert.Equal(t, "confirmed with customer", saved.Notes[1].Note)
+		assert.Len(t, saved.ToExecution().Notes, 2)
+	})
+
+	t.Run("executor saves keep notes", func(t *testing.T) {
+		record.Name = "Renamed"
+		require.NoError(t, s.Save(ctx, record))
+
+		saved, err := s.Get(ctx, "exec_test_note_001")
+		require.NoError(t, err)
+		assert.Equal(t, "Renamed", saved.Name)
+		assert.Len(t, saved.Notes, 2)
+	})
+
+	t.Run("concurrent annotations from separate stores are all kept", func(t *testing.T) {
+		// Each writer can lose at most once to every other writer, within annotationAttempts
+		stores := []*store.ExecutionStore{store.NewExecutionStore(), store.NewExecutionStore()}
+		var wg sync.WaitGroup
+		errs := make(chan error, 4)
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				_, err := stores[i%2].AddNote(ctx, "exec_test_note_001", types.ExecutionNote{Author: "user_c", Note: "concurrent", CreatedAt: time.Now()})
+				errs <- err
+			}(i)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- stores[1].SaveExplanation(ctx, "exec_test_note_001", "en-us", &types.ExecutionExplanation{Summary: "done", Locale: "en-us"})
+		}()
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		saved, err := s.Get(ctx, "exec_test_note_001")
+		require.NoError(t, err)
+		assert.Len(t, saved.Notes, 5)
+		require.NotNil(t, saved.Explanations["en-us"])
+		assert.Equal(t, "done", saved.Explanations["en-us"].Summary)
+	})
+
+	t.Run("unknown execution", func(t *testing.T) {
+		_, err := s.AddNote(ctx, "exec_test_note_missing", types.ExecutionNote{Note: "x"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+}
+
+func TestExecutionStoreListByTeamAndChat(t *testing.T) {
+	testprepare.PrepareSandbox(t)
+	cleanupTestExecutions(t)
+	defer cleanupTestExecutions(t)
+
+	s := store.NewExecutionStore()
+	ctx := context.Background()
+
+	base := time.Now().Add(-time.Hour)
+	seed := []struct {
+		id, member, chat string
+		status           types.ExecStatus
+	}{
+		{"exec_test_scope_001", "member_scope_a", "chat_scope_1", types.ExecCompleted},
+		{"exec_test_scope_002", "member_scope_a", "chat_scope_1", types.ExecFailed},
+		{"exec_test_scope_003", "member_scope_b", "chat_scope_1", types.ExecRunning},
+		{"exec_test_scope_004", "member_scope_b", "chat_scope_2", types.ExecCompleted},
+	}
+	for i, r := range seed {
+		start := base.Add(time.Duration(i) * time.Minute)
+		require.NoError(t, s.Save(ctx, &store.ExecutionRecord{
+			ExecutionID: r.id,
+			MemberID:    r.member,
+			TeamID:      "team_test_scope",
+			ChatID:      r.chat,
+			TriggerType: types.TriggerHuman,
+			Status:      r.status,
+			Phase:       types.PhaseRun,
+			StartTime:   &start,
+		}))
+	}
+
+	ids := func(result *store.ListResult) []string {
+		out := []string{}
+		for _, r := range result.Data {
+			out = append(out, r.ExecutionID)
+		}
+		return out
+	}
+
+	t.Run("team pages across robots", func(t *testing.T) {
+		result, err := s.ListExecutionsByTeam(ctx, "team_test_scope", &store.ListOptions{PageSize: 3, OrderBy: "start_time desc"})
+		require.NoError(t, err)
+		assert.Equal(t, 4, result.Total)
+		assert.Equal(t, []string{"exec_test_scope_004", "exec_test_scope_003", "exec_test_scope_002"}, ids(result))
+
+		result, err = s.ListExecutionsByTeam(ctx, "team_test_scope", &store.ListOptions{Page: 2, PageSize: 3, OrderBy: "start_time desc"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"exec_test_scope_001"}, ids(result))
+	})
+
+	t.Run("team with status filters", func(t *testing.T) {
+		result, err := s.ListExecutionsByTeam(ctx, "team_test_scope", &store.ListOptions{Status: types.ExecCompleted})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"exec_test_scope_001", "exec_test_scope_004"}, ids(result))
+
+		result, err = s.ListExecutionsByTeam(ctx, "team_test_scope", &store.ListOptions{Statuses: []types.ExecStatus{types.ExecFailed, types.ExecRunning}})
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Total)
+		assert.ElementsMatch(t, []string{"exec_test_scope_002", "exec_test_scope_003"}, ids(result))
+	})
+
+	t.Run("chat lists oldest first", func(t *testing.T) {
+		result, err := s.ListExecutionsByChat(ctx, "chat_scope_1", nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"exec_test_scope_001", "exec_test_scope_002", "exec_test_scope_003"}, ids(result))
+
+		result, err = s.ListExecutionsByChat(ctx, "chat_scope_1", &store.ListOptions{ExcludeStatuses: []types.ExecStatus{types.ExecFailed}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"exec_test_scope_001", "exec_test_scope_003"}, ids(result))
+	})
+
+	t.Run("time range filters on start_time", func(t *testing.T) {
+		from := base.Add(30 * time.Second)
+		to := base.Add(2*time.Minute + 30*time.Second)
+		result, err := s.ListExecutionsByTeam(ctx, "team_test_scope", &store.ListOptions{From: &from, To: &to, OrderBy: "start_time asc"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"exec_test_scope_002", "exec_test_scope_003"}, ids(result))
+
+		result, err = s.ListExecutionsByTeam(ctx, "team_test_scope", &store.ListOptions{
+			From:     &from,
+			Statuses: []types.ExecStatus{types.ExecCompleted, types.ExecRunning},
+		})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"exec_test_scope_003", "exec_test_scope_004"}, ids(result))
+	})
+
+	t.Run("scope is required", func(t *testing.T) {
+		_, err := s.ListExecutionsByTeam(ctx, "", nil)
+		assert.Error(t, err)
+		_, err = s.ListExecutionsByChat(ctx, "", nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestExecutionStoreUpdateDeliveryStatus(t *testing.T) {
+	identity := testprepare.PrepareSandbox(t)
+	cleanupTestExecutions(t)
+	defer cleanupTestExecutions(t)
+
+	s := store.NewExecutionStore()
+	ctx := context.Background()
+
+	startTime := time.Now()
+	record := &store.ExecutionRecord{
+		ExecutionID: "exec_test_delivery_status_001",
+		MemberID:    "member_test_delivery_status",
+		TeamID:      identity.AlphaTeamID,
+		TriggerType: types.TriggerClock,
+		Status:      types.ExecRunning,
+		Phase:       types.PhaseDelivery,
+		StartTime:   &startTime,
+	}
+	require.NoError(t, s.Save(ctx, record))
+
+	saved, err := s.Get(ctx, record.ExecutionID)
+	require.NoError(t, err)
+	assert.Empty(t, saved.DeliveryStatus)
+
+	require.NoError(t, s.UpdateDeliveryStatus(ctx, record.ExecutionID, types.DeliveryStatusPending))
+	require.NoError(t, s.UpdateStatus(ctx, record.ExecutionID, types.ExecCompleted, ""))
+	require.NoError(t, s.UpdateDeliveryStatus(ctx, record.ExecutionID, types.DeliveryStatusPartial))
+
+	saved, err = s.Get(ctx, record.ExecutionID)
+	require.NoError(t, err)
+	assert.Equal(t, types.ExecCompleted, saved.Status)
+	assert.Equal(t, types.DeliveryStatusPartial, saved.DeliveryStatus)
+	assert.Equal(t, types.DeliveryStatusPartial, saved.ToExecution().DeliveryStatus)
+}
+
 func cleanupTestExecutions(t *testing.T) {
 	t.Helper()
 	mod := model.Select("__yao.agent.execution")
@@ -1346,3 +1563,80 @@ func setupTestActivitiesData(t *testing.T, s *store.ExecutionStore, ctx context.
 		require.NoError(t, err)
 	}
 }
+
+// TestExecutionStoreCleanupOldExecutions tests deleting old finished executions
+func TestExecutionStoreCleanupOldExecutions(t *testing.T) {
+	identity := testprepare.PrepareSandbox(t)
+	cleanupTestExecutions(t)
+	defer cleanupTestExecutions(t)
+
+	s := store.NewExecutionStore()
+	ctx := context.Background()
+
+	old := time.Now().Add(-90 * 24 * time.Hour)
+	recent := time.Now().Add(-time.Hour)
+	records := map[string]struct {
+		status types.ExecStatus
+		start  time.Time
+	}{
+		"exec_test_cleanup_completed": {types.ExecCompleted, old},
+		"exec_test_cleanup_failed":    {types.ExecFailed, old},
+		"exec_test_cleanup_cancelled": {types.ExecCancelled, old},
+		"exec_test_cleanup_waiting":   {types.ExecWaiting, old},
+		"exec_test_cleanup_running":   {types.ExecRunning, old},
+		"exec_test_cleanup_recent":    {types.ExecCompleted, recent},
+	}
+	for id, r := range records {
+		start := r.start
+		require.NoError(t, s.Save(ctx, &store.ExecutionRecord{
+			ExecutionID: id,
+			MemberID:    "member_test_cleanup",
+			TeamID:      identity.AlphaTeamID,
+			TriggerType: types.TriggerClock,
+			Status:      r.status,
+			Phase:       types.PhaseInspiration,
+			StartTime:   &start,
+		}))
+	}
+
+	t.Run("rejects_non_positive_age", func(t *testing.T) {
+		_, err := s.CleanupOldExecutions(ctx, 0)
+		assert.Error(t, err)
+	})
+
+	t.Run("dry_run_counts_without_deleting", func(t *testing.T) {
+		count, err := s.CountOldExecutions(ctx, 30*24*time.Hour)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, count, int64(3))
+
+		saved, err := s.Get(ctx, "exec_test_cleanup_completed")
+		require.NoError(t, err)
+		assert.NotNil(t, saved, "dry run must not delete")
+	})
+
+	t.Run("deletes_only_old_finished_executions", func(t *testing.T) {
+		deleted, err := s.CleanupOldExecutions(ctx, 30*24*time.Hour)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, deleted, int64(3))
+
+		for _, id := range []string{"exec_test_cleanup_completed", "exec_test_cleanup_failed", "exec_test_cleanup_cancelled"} {
+			saved, err := s.Get(ctx, id)
+			require.NoError(t, err)
+			assert.Nil(t, saved, "%s should be deleted", id)
+		}
+		for _, id := range []string{"exec_test_cleanup_waiting", "exec_test_cleanup_running", "exec_test_cleanup_recent"} {
+			saved, err := s.Get(ctx, id)
+			require.NoError(t, err)
+			assert.NotNil(t, saved, "%s should be kept", id)
+		}
+	})
+
+	t.Run("waiting_executions_survive_any_age", func(t *testing.T) {
+		_, err := s.CleanupOldExecutions(ctx, time.Nanosecond)
+		require.NoError(t, err)
+
+		saved, err := s.Get(ctx, "exec_test_cleanup_waiting")
+		require.NoError(t, err)
+		assert.NotNil(t, saved, "suspended executions are never cleaned up")
+	})
+}
@@ -3,12 +3,14 @@ package store
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/yaoapp/gou/model"
 	"github.com/yaoapp/kun/maps"
 	"github.com/yaoapp/yao/agent/robot/types"
 	"github.com/yaoapp/yao/agent/robot/utils"
+	"github.com/yaoapp/yao/openapi/oauth/providers/user"
 )
 
 // RobotRecord - persistent storage for robot member
@@ -43,6 +45,7 @@ type RobotRecord struct {
 	MCPServers    interface{} `json:"mcp_servers,omitempty"`    // MCP servers (JSON array)
 	LanguageModel string      `json:"language_model,omitempty"` // Language model name
 	Workspace     string      `json:"workspace,omitempty"`      // Workspace ID bound to this robot
+	Timezone      string      `json:"timezone,omitempty"`       // IANA timezone for schedules and rendered times
 
 	// Limits
 	CostLimit float64 `json:"cost_limit,omitempty"` // Monthly cost limit USD
@@ -119,6 +122,7 @@ var robotFields = []interface{}{
 	"mcp_servers",
 	"language_model",
 	"workspace",
+	"timezone",
 
 	// Limits
 	"cost_limit",
@@ -149,6 +153,9 @@ func (s *RobotStore) Save(ctx context.Context, record *RobotRecord) error {
 	record.MemberType = "robot"
 
 	data := s.recordToMap(record)
+	if err := user.EncryptMemberData(data); err != nil {
+		return err
+	}
 
 	// Check if record exists by member_id
 	existing, err := s.Get(ctx, record.MemberID)
@@ -325,6 +332,9 @@ func (s *RobotStore) UpdateConfig(ctx context.Context, memberID string, config i
 	data := map[string]interface{}{
 		"robot_config": config,
 	}
+	if err := user.EncryptMemberData(data); err != nil {
+		return err
+	}
 
 	_, err := mod.UpdateWhere(
 		model.QueryParam{
@@ -369,6 +379,44 @@ func (s *RobotStore) UpdateStatus(ctx context.Context, memberID string, status t
 	return nil
 }
 
+// UpdateNextRun records when the robot's clock trigger is next due; nil means due now.
+// next_run_at and cooldown_until back the member provider's due-robot query.
+func (s *RobotStore) UpdateNextRun(ctx context.Context, memberID string, at *time.Time) error {
+	return s.updateScheduleColumn(memberID, "next_run_at", at)
+}
+
+// UpdateCooldown records until when the robot rests after an autonomous run; nil clears it
+func (s *RobotStore) UpdateCooldown(ctx context.Context, memberID string, until *time.Time) error {
+	return s.updateScheduleColumn(memberID, "cooldown_until", until)
+}
+
+func (s *RobotStore) updateScheduleColumn(memberID, column string, at *time.Time) error {
+	mod := model.Select(s.modelID)
+	if mod == nil {
+		return fmt.Errorf("model %s not found", s.modelID)
+	}
+
+	data := map[string]interface{}{column: nil}
+	if at != nil {
+		data[column] = *at
+	}
+
+	_, err := mod.UpdateWhere(
+		model.QueryParam{
+			Wheres: []model.QueryWhere{
+				{Column: "member_id", Value: memberID},
+				{Column: "member_type", Value: "robot"},
+			},
+		},
+		data,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update robot %s: %w", column, err)
+	}
+
+	return nil
+}
+
 // recordToMap converts RobotRecord to map for model operations
 func (s *RobotStore) recordToMap(record *RobotRecord) map[string]interface{} {
 	data := map[string]interface{}{
@@ -442,6 +490,9 @@ func (s *RobotStore) recordToMap(record *RobotRecord) map[string]interface{} {
 	if record.Workspace != "" {
 		data["workspace"] = record.Workspace
 	}
+	if record.Timezone != "" {
+		data["timezone"] = record.Timezone
+	}
 
 	// Limits
 	if record.CostLimit > 0 {
@@ -476,6 +527,10 @@ func (s *RobotStore) recordToMap(record *RobotRecord) map[string]interface{} {
 
 // mapToRecord converts a model row to RobotRecord
 func (s *RobotStore) mapToRecord(row map[string]interface{}) (*RobotRecord, error) {
+	if err := user.DecryptMemberData(row); err != nil {
+		return nil, err
+	}
+
 	record := &RobotRecord{}
 
 	// Basic fields
@@ -557,6 +612,9 @@ func (s *RobotStore) mapToRecord(row map[string]interface{}) (*RobotRecord, erro
 	if v, ok := row["workspace"].(string); ok {
 		record.Workspace = v
 	}
+	if v, ok := row["timezone"].(string); ok {
+		record.Timezone = v
+	}
 
 	// Limits
 	if v := row["cost_limit"]; v != nil {
@@ -608,6 +666,7 @@ func (r *RobotRecord) ToRobot() (*types.Robot, error) {
 		RobotEmail:     r.RobotEmail,
 		LanguageModel:  r.LanguageModel,
 		Workspace:      r.Workspace,
+		Timezone:       r.Timezone,
 	}
 
 	// Parse robot_status
@@ -692,6 +751,7 @@ func FromRobot(robot *types.Robot) *RobotRecord {
 		RobotEmail:     robot.RobotEmail,
 		LanguageModel:  robot.LanguageModel,
 		Workspace:      robot.Workspace,
+		Timezone:       robot.Timezone,
 		MemberType:     "robot",
 		Status:         "active",
 	}
@@ -702,3 +762,79 @@ func FromRobot(robot *types.Robot) *RobotRecord {
 
 	return record
 }
+
+// teamModelID is the team model holding team settings
+const teamModelID = "__yao.team"
+
+// CheckLanguageModel verifies languageModel against the team's allowed_language_models
+// setting. An empty model, an unknown team or an empty allowlist pass.
+func (s *RobotStore) CheckLanguageModel(ctx context.Context, teamID, languageModel string) error {
+	if languageModel == "" || teamID == "" {
+		return nil
+	}
+
+	mod := model.Select(teamModelID)
+	if mod == nil {
+		return nil
+	}
+	rows, err := mod.Get(model.QueryParam{
+		Select: []interface{}{"settings"},
+		Wheres: []model.QueryWhere{
+			{Column: "team_id", Value: teamID},
+		},
+		Limit: 1,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get team settings: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return user.CheckLanguageModel(user.AllowedLanguageModels(rows[0]["settings"]), languageModel)
+}
+
+// TeamMembersByEmail returns the active members of a team whose email is in emails,
+// keyed by lower-cased email. Emails are matched after decryption, so encrypted
+// member columns are supported.
+func (s *RobotStore) TeamMembersByEmail(ctx context.Context, teamID string, emails []string) (map[string]map[string]interface{}, error) {
+	found := map[string]map[string]interface{}{}
+	if teamID == "" || len(emails) == 0 {
+		return found, nil
+	}
+
+	wanted := make(map[string]bool, len(emails))
+	for _, email := range emails {
+		wanted[strings.ToLower(strings.TrimSpace(email))] = true
+	}
+
+	mod := model.Select(s.modelID)
+	if mod == nil {
+		return nil, fmt.Errorf("model %s not found", s.modelID)
+	}
+	rows, err := mod.Get(model.QueryParam{
+		Select: []interface{}{"member_id", "member_type", "user_id", "display_name", "email", "robot_email", "role_id", "timezone"},
+		Wheres: []model.QueryWhere{
+			{Column: "team_id", Value: teamID},
+			{Column: "status", Value: "active"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team members: %w", err)
+	}
+
+	for _, row := range rows {
+		if err := user.DecryptMemberData(row); err != nil {
+			return nil, fmt.Errorf("failed to decrypt member: %w", err)
+		}
+		for _, column := range []string{"email", "robot_email"} {
+			email, _ := row[column].(string)
+			email = strings.ToLower(strings.TrimSpace(email))
+			if email != "" && wanted[email] {
+				if _, exists := found[email]; !exists {
+					found[email] = row
+				}
+			}
+		}
+	}
+	return found, nil
+}
@@ -63,10 +63,14 @@ func (c *ExecutionController) Track(execID, memberID, teamID string) *Controlled
 	return exec
 }
 
-// Untrack stops tracking an execution
+// Untrack stops tracking an execution and cancels its context, so a run that is
+// still in flight sees ctx.Done() at its next check
 func (c *ExecutionController) Untrack(execID string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	if exec, ok := c.executions[execID]; ok && exec.cancel != nil {
+		exec.cancel()
+	}
 	delete(c.executions, execID)
 }
 
@@ -86,6 +86,15 @@ func TestExecutionControllerUntrack(t *testing.T) {
 		assert.Nil(t, ctrl.Get("exec_001"))
 	})
 
+	t.Run("untrack cancels the execution context", func(t *testing.T) {
+		ctrl := trigger.NewExecutionController()
+		exec := ctrl.Track("exec_001", "robot_001", "team_001")
+
+		ctrl.Untrack("exec_001")
+
+		assert.True(t, exec.IsCancelled())
+	})
+
 	t.Run("untrack non-existent does not panic", func(t *testing.T) {
 		ctrl := trigger.NewExecutionController()
 
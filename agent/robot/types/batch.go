@@ -0,0 +1,173 @@
+package types
+
+import "time"
+
+// MaxBatchItems is the upper bound on parameter sets accepted by a single batch trigger
+const MaxBatchItems = 1000
+
+// Batch trigger data keys, injected into TriggerInput.Data of every batch item
+const (
+	BatchDataKeyID               = "batch_id"
+	BatchDataKeyIndex            = "batch_index"
+	BatchDataKeySuppressDelivery = "suppress_delivery"
+)
+
+// BatchEventSource is the TriggerInput.Source used for batch item executions
+const BatchEventSource EventSource = "batch"
+
+// BatchStatus - lifecycle status of a batch
+type BatchStatus string
+
+// BatchStatus constants define the batch status values
+const (
+	BatchPending   BatchStatus = "pending"   // created, no item dispatched yet
+	BatchRunning   BatchStatus = "running"   // at least one item dispatched
+	BatchCompleted BatchStatus = "completed" // every item reached a terminal state
+	BatchCancelled BatchStatus = "cancelled" // remaining items were cancelled
+)
+
+// BatchItemStatus - status of a single item within a batch
+type BatchItemStatus string
+
+// BatchItemStatus constants define the batch item status values
+const (
+	BatchItemQueued    BatchItemStatus = "queued"
+	BatchItemRunning   BatchItemStatus = "running"
+	BatchItemCompleted BatchItemStatus = "completed"
+	BatchItemFailed    BatchItemStatus = "failed"
+	BatchItemCancelled BatchItemStatus = "cancelled"
+)
+
+// IsTerminal returns true if the item will not change state anymore
+func (s BatchItemStatus) IsTerminal() bool {
+	return s == BatchItemCompleted || s == BatchItemFailed || s == BatchItemCancelled
+}
+
+// Batch - a set of executions of the same robot, one per parameter set.
+// Items are dispatched lazily by the manager as the robot's quota allows.
+type Batch struct {
+	BatchID          string       `json:"batch_id"`
+	MemberID         string       `json:"member_id"`
+	TeamID           string       `json:"team_id"`
+	Status           BatchStatus  `json:"status"`
+	SuppressDelivery bool         `json:"suppress_delivery"` // skip per-item delivery, notify once on completion
+	Items            []*BatchItem `json:"items"`
+	CreatedBy        string       `json:"created_by,omitempty"`
+	CreatedAt        *time.Time   `json:"created_at,omitempty"`
+	UpdatedAt        *time.Time   `json:"updated_at,omitempty"`
+	CompletedAt      *time.Time   `json:"completed_at,omitempty"`
+}
+
+// BatchItem - one parameter set of a batch and the execution it produced
+type BatchItem struct {
+	Index       int                    `json:"index"`
+	Params      map[string]interface{} `json:"params,omitempty"`
+	Status      BatchItemStatus        `json:"status"`
+	ExecutionID string                 `json:"execution_id,omitempty"`
+	Error       string                 `json:"error,omitempty"`
+	StartTime   *time.Time             `json:"start_time,omitempty"`
+	EndTime     *time.Time             `json:"end_time,omitempty"`
+}
+
+// BatchProgress - per-status item counts of a batch
+type BatchProgress struct {
+	Total     int `json:"total"`
+	Queued    int `json:"queued"`
+	Running   int `json:"running"`
+	Completed int `json:"completed"`
+	Failed    int `json:"failed"`
+	Cancelled int `json:"cancelled"`
+}
+
+// Done returns the number of items in a terminal state
+func (p BatchProgress) Done() int {
+	return p.Completed + p.Failed + p.Cancelled
+}
+
+// Progress counts the batch items by status
+func (b *Batch) Progress() BatchProgress {
+	p := BatchProgress{Total: len(b.Items)}
+	for _, item := range b.Items {
+		switch item.Status {
+		case BatchItemQueued:
+			p.Queued++
+		case BatchItemRunning:
+			p.Running++
+		case BatchItemCompleted:
+			p.Completed++
+		case BatchItemFailed:
+			p.Failed++
+		case BatchItemCancelled:
+			p.Cancelled++
+		}
+	}
+	return p
+}
+
+// NextQueued returns the first item still waiting to be dispatched, or nil
+func (b *Batch) NextQueued() *BatchItem {
+	for _, item := range b.Items {
+		if item.Status == BatchItemQueued {
+			return item
+		}
+	}
+	return nil
+}
+
+// FindItemByExecution returns the item bound to the given execution ID, or nil
+func (b *Batch) FindItemByExecution(execID string) *BatchItem {
+	if execID == "" {
+		return nil
+	}
+	for _, item := range b.Items {
+		if item.ExecutionID == execID {
+			return item
+		}
+	}
+	return nil
+}
+
+// CancelRemaining marks every queued item as cancelled and returns how many were cancelled.
+// Running items are left untouched and finish normally.
+func (b *Batch) CancelRemaining() int {
+	now := time.Now()
+	n := 0
+	for _, item := range b.Items {
+		if item.Status == BatchItemQueued {
+			item.Status = BatchItemCancelled
+			item.EndTime = &now
+			n++
+		}
+	}
+	if n > 0 || b.Status == BatchPending {
+		b.Status = BatchCancelled
+	}
+	return n
+}
+
+// IsFinished returns true if every item reached a terminal state
+func (b *Batch) IsFinished() bool {
+	for _, item := range b.Items {
+		if !item.Status.IsTerminal() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsActive returns true if the batch may still dispatch or track items
+func (b *Batch) IsActive() bool {
+	return b.Status == BatchPending || b.Status == BatchRunning || (b.Status == BatchCancelled && !b.IsFinished())
+}
+
+// BatchItemStatusFromExec maps a terminal execution status to a batch item status
+func BatchItemStatusFromExec(status ExecStatus) BatchItemStatus {
+	switch status {
+	case ExecCompleted:
+		return BatchItemCompleted
+	case ExecCancelled:
+		return BatchItemCancelled
+	default:
+		return BatchItemFailed
+	}
+}
@@ -0,0 +1,83 @@
+//go:build unit
+
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/yao/agent/robot/types"
+)
+
+func newTestBatch(statuses ...types.BatchItemStatus) *types.Batch {
+	b := &types.Batch{BatchID: "batch_1", Status: types.BatchRunning}
+	for i, s := range statuses {
+		b.Items = append(b.Items, &types.BatchItem{Index: i, Status: s})
+	}
+	return b
+}
+
+func TestBatchProgress(t *testing.T) {
+	b := newTestBatch(
+		types.BatchItemQueued, types.BatchItemQueued, types.BatchItemRunning,
+		types.BatchItemCompleted, types.BatchItemFailed, types.BatchItemCancelled,
+	)
+
+	p := b.Progress()
+	assert.Equal(t, 6, p.Total)
+	assert.Equal(t, 2, p.Queued)
+	assert.Equal(t, 1, p.Running)
+	assert.Equal(t, 1, p.Completed)
+	assert.Equal(t, 1, p.Failed)
+	assert.Equal(t, 1, p.Cancelled)
+	assert.Equal(t, 3, p.Done())
+}
+
+func TestBatchNextQueued(t *testing.T) {
+	b := newTestBatch(types.BatchItemCompleted, types.BatchItemRunning, types.BatchItemQueued)
+	next := b.NextQueued()
+	assert.NotNil(t, next)
+	assert.Equal(t, 2, next.Index)
+
+	next.Status = types.BatchItemRunning
+	assert.Nil(t, b.NextQueued())
+}
+
+func TestBatchCancelRemaining(t *testing.T) {
+	t.Run("cancels only queued items", func(t *testing.T) {
+		b := newTestBatch(types.BatchItemCompleted, types.BatchItemRunning, types.BatchItemQueued, types.BatchItemQueued)
+		assert.Equal(t, 2, b.CancelRemaining())
+		assert.Equal(t, types.BatchCancelled, b.Status)
+		assert.Equal(t, types.BatchItemRunning, b.Items[1].Status)
+		assert.NotNil(t, b.Items[3].EndTime)
+
+		// Running item still pending: batch stays active until it settles
+		assert.False(t, b.IsFinished())
+		assert.True(t, b.IsActive())
+
+		b.Items[1].Status = types.BatchItemCompleted
+		assert.True(t, b.IsFinished())
+		assert.False(t, b.IsActive())
+	})
+
+	t.Run("nothing left to cancel keeps status", func(t *testing.T) {
+		b := newTestBatch(types.BatchItemCompleted, types.BatchItemRunning)
+		assert.Equal(t, 0, b.CancelRemaining())
+		assert.Equal(t, types.BatchRunning, b.Status)
+	})
+}
+
+func TestBatchFindItemByExecution(t *testing.T) {
+	b := newTestBatch(types.BatchItemRunning, types.BatchItemQueued)
+	b.Items[0].ExecutionID = "exec_1"
+
+	assert.Equal(t, 0, b.FindItemByExecution("exec_1").Index)
+	assert.Nil(t, b.FindItemByExecution("exec_2"))
+	assert.Nil(t, b.FindItemByExecution(""))
+}
+
+func TestBatchItemStatusFromExec(t *testing.T) {
+	assert.Equal(t, types.BatchItemCompleted, types.BatchItemStatusFromExec(types.ExecCompleted))
+	assert.Equal(t, types.BatchItemCancelled, types.BatchItemStatusFromExec(types.ExecCancelled))
+	assert.Equal(t, types.BatchItemFailed, types.BatchItemStatusFromExec(types.ExecFailed))
+}
@@ -0,0 +1,78 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// CapabilityDescriptor - machine-readable summary of what a robot can do.
+// Built from robot config (cached per config version) plus live state
+// (quota usage), injected into the Tasks planner and task agent contexts.
+type CapabilityDescriptor struct {
+	MemberID    string                 `json:"member_id"`
+	Version     string                 `json:"version"` // config version the descriptor was built from
+	Agents      []AgentCapability      `json:"agents,omitempty"`
+	MCP         []MCPCapability        `json:"mcp,omitempty"`
+	Processes   []ProcessCapability    `json:"processes,omitempty"`
+	Delivery    []DeliveryType         `json:"delivery,omitempty"`   // enabled delivery channels
+	Parameters  map[string]interface{} `json:"parameters,omitempty"` // trigger parameter schema
+	KB          []string               `json:"kb,omitempty"`
+	DB          []string               `json:"db,omitempty"`
+	Quota       QuotaCapability        `json:"quota"`
+	CostLimit   float64                `json:"cost_limit,omitempty"` // monthly USD limit, 0 = unlimited
+	GeneratedAt time.Time              `json:"generated_at"`
+}
+
+// AgentCapability - an assistant the robot may delegate tasks to
+type AgentCapability struct {
+	ID           string `json:"id"`
+	Name         string `json:"name,omitempty"`
+	Description  string `json:"description,omitempty"`
+	Capabilities string `json:"capabilities,omitempty"`
+}
+
+// MCPCapability - an MCP server and the tools the robot may call on it
+type MCPCapability struct {
+	ID          string           `json:"id"`
+	Name        string           `json:"name,omitempty"`
+	Description string           `json:"description,omitempty"`
+	Tools       []ToolCapability `json:"tools,omitempty"`
+	AllTools    bool             `json:"all_tools,omitempty"` // no tool filter configured and tool list unavailable
+}
+
+// ToolCapability - a single MCP tool
+type ToolCapability struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// ProcessCapability - a Yao process with its argument schema
+type ProcessCapability struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Args        map[string]interface{} `json:"args,omitempty"`
+}
+
+// QuotaCapability - live concurrency quota
+type QuotaCapability struct {
+	Max       int `json:"max"`
+	Running   int `json:"running"`
+	Remaining int `json:"remaining"`
+	Queue     int `json:"queue"`
+}
+
+// ConfigVersion returns a stable fingerprint of the robot fields that shape
+// its capabilities. Any config change yields a new version.
+func (r *Robot) ConfigVersion() string {
+	if r == nil {
+		return ""
+	}
+	data, _ := json.Marshal(struct {
+		Config    *Config `json:"config"`
+		CostLimit float64 `json:"cost_limit"`
+	}{r.Config, r.CostLimit})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
@@ -171,3 +171,36 @@ func TestClockContextFields(t *testing.T) {
 	assert.True(t, ctx.IsYearEnd)
 	assert.Equal(t, "UTC", ctx.TZ)
 }
+
+func TestClockNextRunAfter(t *testing.T) {
+	// 2024-01-15 is a Monday
+	monday := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
+
+	t.Run("interval adds every", func(t *testing.T) {
+		clock := &types.Clock{Mode: types.ClockInterval, Every: "45m"}
+		assert.Equal(t, monday.Add(45*time.Minute), clock.NextRunAfter(monday, time.UTC))
+	})
+
+	t.Run("times picks the next slot today", func(t *testing.T) {
+		clock := &types.Clock{Mode: types.ClockTimes, Times: []string{"17:00", "09:00"}}
+		assert.Equal(t, time.Date(2024, 1, 15, 17, 0, 0, 0, time.UTC), clock.NextRunAfter(monday, time.UTC))
+	})
+
+	t.Run("times rolls over to the next allowed day", func(t *testing.T) {
+		clock := &types.Clock{Mode: types.ClockTimes, Times: []string{"09:00"}, Days: []string{"Wed"}}
+		assert.Equal(t, time.Date(2024, 1, 17, 9, 0, 0, 0, time.UTC), clock.NextRunAfter(monday, time.UTC))
+	})
+
+	t.Run("times evaluated in the robot timezone", func(t *testing.T) {
+		tokyo, err := time.LoadLocation("Asia/Tokyo")
+		assert.NoError(t, err)
+		clock := &types.Clock{Mode: types.ClockTimes, Times: []string{"09:00"}}
+		// 14:30 UTC is 23:30 in Tokyo, so the next 09:00 is tomorrow morning Tokyo time
+		assert.True(t, time.Date(2024, 1, 16, 9, 0, 0, 0, tokyo).Equal(clock.NextRunAfter(monday, tokyo)))
+	})
+
+	t.Run("daemon and invalid configs are due now", func(t *testing.T) {
+		assert.True(t, (&types.Clock{Mode: types.ClockDaemon}).NextRunAfter(monday, time.UTC).IsZero())
+		assert.True(t, (&types.Clock{Mode: types.ClockInterval, Every: "soon"}).NextRunAfter(monday, time.UTC).IsZero())
+	})
+}
@@ -2,6 +2,7 @@ package types
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 )
 
@@ -20,6 +21,86 @@ type Config struct {
 	Executor      *ExecutorConfig      `json:"executor,omitempty"`       // executor mode settings
 	DefaultLocale string               `json:"default_locale,omitempty"` // default language for clock/event triggers ("en", "zh")
 	Integrations  *Integrations        `json:"integrations,omitempty"`   // external channel integrations (telegram, etc.)
+
+	Params map[string]interface{} `json:"params,omitempty"` // JSON schema of human/batch trigger parameters
+
+	// ApprovalRequiredFor lists task types that need explicit human approval before they run,
+	// even in autonomous mode. Entries match a task's tags, executor type or executor ID;
+	// a trailing "*" matches by prefix (e.g. "process.payments.*").
+	ApprovalRequiredFor []string `json:"approval_required_for,omitempty"`
+
+	// MaxDailyExecutions caps how many executions the robot starts per day, counted
+	// from midnight in the robot's timezone (0 = unlimited). Unlike Quota it limits
+	// volume, not concurrency.
+	MaxDailyExecutions int `json:"max_daily_executions,omitempty"`
+
+	// Timeout fails executions that run too long, e.g. on an assistant that never
+	// returns. Without it the default overall limit applies.
+	Timeout *Timeout `json:"timeout,omitempty"`
+}
+
+// GetOverallTimeout returns the execution time limit: timeout.overall when a timeout
+// is configured, executor.max_duration otherwise (default: 30m). 0 means no limit.
+func (c *Config) GetOverallTimeout() time.Duration {
+	if c == nil {
+		return (*ExecutorConfig)(nil).GetMaxDuration()
+	}
+	if c.Timeout == nil {
+		return c.Executor.GetMaxDuration()
+	}
+	if c.Timeout.Overall <= 0 {
+		return 0
+	}
+	return time.Duration(c.Timeout.Overall) * time.Second
+}
+
+// GetPhaseTimeout returns the time limit of each phase (0 = no limit)
+func (c *Config) GetPhaseTimeout() time.Duration {
+	if c == nil || c.Timeout == nil || c.Timeout.Phase <= 0 {
+		return 0
+	}
+	return time.Duration(c.Timeout.Phase) * time.Second
+}
+
+// GetMaxDailyExecutions returns the daily execution cap (0 = unlimited)
+func (c *Config) GetMaxDailyExecutions() int {
+	if c == nil || c.MaxDailyExecutions < 0 {
+		return 0
+	}
+	return c.MaxDailyExecutions
+}
+
+// RequiresApproval reports whether task must be approved by a human before it runs
+func (c *Config) RequiresApproval(task *Task) bool {
+	if c == nil || task == nil || len(c.ApprovalRequiredFor) == 0 {
+		return false
+	}
+
+	candidates := append([]string{string(task.ExecutorType), task.ExecutorID}, task.Tags...)
+	if task.MCPServer != "" && task.MCPTool != "" {
+		candidates = append(candidates, task.MCPServer+"."+task.MCPTool)
+	}
+
+	for _, pattern := range c.ApprovalRequiredFor {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern == "" {
+			continue
+		}
+		for _, candidate := range candidates {
+			candidate = strings.ToLower(candidate)
+			if candidate == "" {
+				continue
+			}
+			if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+				if strings.HasPrefix(candidate, prefix) {
+					return true
+				}
+			} else if candidate == pattern {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // Integrations holds configuration for external platform integrations.
@@ -64,8 +145,35 @@ type DiscordConfig struct {
 
 // ExecutorConfig - executor settings
 type ExecutorConfig struct {
-	Mode        ExecutorMode `json:"mode,omitempty"`         // standard | dryrun | sandbox
-	MaxDuration string       `json:"max_duration,omitempty"` // max execution time (e.g., "30m")
+	Mode            ExecutorMode           `json:"mode,omitempty"`             // standard | dryrun | sandbox
+	MaxDuration     string                 `json:"max_duration,omitempty"`     // max execution time (e.g., "30m")
+	ToolTimeout     string                 `json:"tool_timeout,omitempty"`     // max time of one MCP tool call in P3 (e.g., "2m")
+	PreviousResults *PreviousResultsConfig `json:"previous_results,omitempty"` // how earlier results reach later tasks
+}
+
+// PreviousResultsConfig - context assembly for previous task results in P3
+type PreviousResultsConfig struct {
+	Strategy    ResultsStrategy `json:"strategy,omitempty"`     // full | summary | last_n | token_budget (default: full)
+	LastN       int             `json:"last_n,omitempty"`       // results kept verbatim by last_n (default: 3)
+	TokenBudget int             `json:"token_budget,omitempty"` // estimated tokens allowed by token_budget (default: 8000)
+}
+
+// GetPreviousResults returns the previous results settings (default: full)
+func (e *ExecutorConfig) GetPreviousResults() PreviousResultsConfig {
+	cfg := PreviousResultsConfig{Strategy: ResultsFull, LastN: 3, TokenBudget: 8000}
+	if e == nil || e.PreviousResults == nil {
+		return cfg
+	}
+	if s := e.PreviousResults.Strategy; s != "" && s.IsValid() {
+		cfg.Strategy = s
+	}
+	if e.PreviousResults.LastN > 0 {
+		cfg.LastN = e.PreviousResults.LastN
+	}
+	if e.PreviousResults.TokenBudget > 0 {
+		cfg.TokenBudget = e.PreviousResults.TokenBudget
+	}
+	return cfg
 }
 
 // GetMode returns the executor mode (default: standard)
@@ -88,6 +196,18 @@ func (e *ExecutorConfig) GetMaxDuration() time.Duration {
 	return d
 }
 
+// GetToolTimeout returns the time limit of one MCP tool call (default: 5m)
+func (e *ExecutorConfig) GetToolTimeout() time.Duration {
+	if e == nil || e.ToolTimeout == "" {
+		return 5 * time.Minute
+	}
+	d, err := time.ParseDuration(e.ToolTimeout)
+	if err != nil || d <= 0 {
+		return 5 * time.Minute
+	}
+	return d
+}
+
 // Validate validates the config
 func (c *Config) Validate() error {
 	if c.Identity == nil || c.Identity.Role == "" {
@@ -191,6 +311,63 @@ func (c *Clock) GetLocation() *time.Location {
 	return loc
 }
 
+// MatchesDay reports whether t falls on one of the configured days (empty or "*" = every day).
+// Days match by full or short weekday name (Monday, Mon).
+func (c *Clock) MatchesDay(t time.Time) bool {
+	if len(c.Days) == 0 {
+		return true
+	}
+	weekday := t.Weekday().String()
+	for _, day := range c.Days {
+		if day == "*" || day == weekday || day == weekday[:3] {
+			return true
+		}
+	}
+	return false
+}
+
+// NextRunAfter returns the first time after t at which the clock is due, evaluated in loc.
+// Zero means "due whenever a slot is free": daemon mode or a config that cannot be scheduled.
+func (c *Clock) NextRunAfter(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.Local
+	}
+	switch c.Mode {
+	case ClockInterval:
+		every, err := time.ParseDuration(c.Every)
+		if err != nil || every <= 0 {
+			return time.Time{}
+		}
+		return t.Add(every)
+
+	case ClockTimes:
+		local := t.In(loc)
+		var next time.Time
+		// A week ahead covers every day filter
+		for offset := 0; offset <= 7; offset++ {
+			day := time.Date(local.Year(), local.Month(), local.Day()+offset, 0, 0, 0, 0, loc)
+			if !c.MatchesDay(day) {
+				continue
+			}
+			for _, hhmm := range c.Times {
+				at, err := time.Parse("15:04", hhmm)
+				if err != nil {
+					continue
+				}
+				candidate := time.Date(day.Year(), day.Month(), day.Day(), at.Hour(), at.Minute(), 0, 0, loc)
+				if candidate.After(t) && (next.IsZero() || candidate.Before(next)) {
+					next = candidate
+				}
+			}
+			if !next.IsZero() {
+				return next
+			}
+		}
+		return next
+	}
+	return time.Time{}
+}
+
 // Identity - who is this robot
 type Identity struct {
 	Role   string   `json:"role"`
@@ -198,11 +375,19 @@ type Identity struct {
 	Rules  []string `json:"rules,omitempty"`
 }
 
+// Timeout - execution time limits in seconds (0 = no limit)
+type Timeout struct {
+	Overall int `json:"overall"`         // whole execution; phases run after a resume get a fresh budget
+	Phase   int `json:"phase,omitempty"` // each phase, retries included
+}
+
 // Quota - concurrency limits
 type Quota struct {
-	Max      int `json:"max"`      // max running (default: 2)
-	Queue    int `json:"queue"`    // queue size (default: 10)
-	Priority int `json:"priority"` // 1-10 (default: 5)
+	Max            int            `json:"max"`                       // max running (default: 2)
+	Queue          int            `json:"queue"`                     // queue size (default: 10)
+	Priority       int            `json:"priority"`                  // 1-10 (default: 5)
+	OverflowPolicy OverflowPolicy `json:"overflow_policy,omitempty"` // queue | reject | overflow (default: queue)
+	Overflow       int            `json:"overflow,omitempty"`        // extra slots under the overflow policy (default: 1)
 }
 
 // GetMax returns max with default
@@ -221,6 +406,28 @@ func (q *Quota) GetQueue() int {
 	return q.Queue
 }
 
+// GetOverflowPolicy returns the overflow policy (default: queue)
+func (q *Quota) GetOverflowPolicy() OverflowPolicy {
+	if q == nil || q.OverflowPolicy == "" || !q.OverflowPolicy.IsValid() {
+		return OverflowQueue
+	}
+	return q.OverflowPolicy
+}
+
+// GetMaxFor returns the running limit that applies to an execution of the given trigger.
+// Under the overflow policy human-triggered work, the highest priority, may borrow
+// quota.overflow slots on top of max; everything else is bound by max.
+func (q *Quota) GetMaxFor(trigger TriggerType) int {
+	limit := q.GetMax()
+	if q.GetOverflowPolicy() != OverflowBorrow || trigger != TriggerHuman {
+		return limit
+	}
+	if q.Overflow <= 0 {
+		return limit + 1
+	}
+	return limit + q.Overflow
+}
+
 // GetPriority returns priority with default
 func (q *Quota) GetPriority() int {
 	if q == nil || q.Priority <= 0 {
@@ -256,6 +463,80 @@ type Resources struct {
 	Phases map[Phase]string `json:"phases,omitempty"` // phase -> agent ID
 	Agents []string         `json:"agents,omitempty"`
 	MCP    []MCPConfig      `json:"mcp,omitempty"`
+
+	Processes []ProcessConfig `json:"processes,omitempty"` // Yao processes tasks may call
+
+	// ProcessNamespaces allows every process under a namespace, e.g. "models.crm" allows
+	// "models.crm.customer.Find". Process tasks outside Processes and these are refused.
+	ProcessNamespaces []string `json:"process_namespaces,omitempty"`
+
+	Routing *AgentRouting `json:"routing,omitempty"` // picks agents for tasks planned without one
+}
+
+// AgentRouting - selects the agent of a task from Agents by its content.
+// Only tasks planned without an executor_id are routed; an explicit ID is kept.
+type AgentRouting struct {
+	Rules []RoutingRule `json:"rules,omitempty"` // evaluated in order, first match wins
+	Agent string        `json:"agent,omitempty"` // classifier agent consulted when no rule matches
+}
+
+// RoutingRule - routes tasks carrying any of Tags or mentioning any of Keywords to Agent
+type RoutingRule struct {
+	Agent    string   `json:"agent"`
+	Tags     []string `json:"tags,omitempty"`
+	Keywords []string `json:"keywords,omitempty"` // matched case-insensitively against description and messages
+}
+
+// Match returns the agent of the first rule matching the task, restricted to the allowed agents
+func (r *AgentRouting) Match(task *Task, allowed []string) string {
+	if r == nil || task == nil {
+		return ""
+	}
+
+	var text string
+	for _, rule := range r.Rules {
+		if !containsFold(allowed, rule.Agent) {
+			continue
+		}
+		for _, tag := range rule.Tags {
+			if containsFold(task.Tags, tag) {
+				return rule.Agent
+			}
+		}
+		if len(rule.Keywords) == 0 {
+			continue
+		}
+		if text == "" {
+			text = strings.ToLower(taskText(task))
+		}
+		for _, keyword := range rule.Keywords {
+			if keyword != "" && strings.Contains(text, strings.ToLower(keyword)) {
+				return rule.Agent
+			}
+		}
+	}
+	return ""
+}
+
+// taskText returns the description and text messages of a task
+func taskText(task *Task) string {
+	parts := []string{task.Description}
+	for _, msg := range task.Messages {
+		if content, ok := msg.Content.(string); ok {
+			parts = append(parts, content)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// containsFold reports whether list holds value, ignoring case
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
 }
 
 // GlobalPhaseAgentResolver is called by GetPhaseAgent when no per-robot override
@@ -277,6 +558,27 @@ func (r *Resources) GetPhaseAgent(phase Phase) string {
 	return ""
 }
 
+// AllowsProcess reports whether tasks may call the Yao process name: it is listed in
+// Processes or lies under one of ProcessNamespaces. Names compare case-insensitively.
+func (r *Resources) AllowsProcess(name string) bool {
+	if r == nil || name == "" {
+		return false
+	}
+	name = strings.ToLower(name)
+	for _, p := range r.Processes {
+		if strings.ToLower(p.Name) == name {
+			return true
+		}
+	}
+	for _, ns := range r.ProcessNamespaces {
+		ns = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(ns), "."))
+		if ns != "" && strings.HasPrefix(name, ns+".") {
+			return true
+		}
+	}
+	return false
+}
+
 // ResolvePhaseAgent resolves the agent ID for a phase from robot config.
 // It delegates to Resources.GetPhaseAgent which handles the full priority chain:
 // per-robot Resources.Phases > GlobalPhaseAgentResolver (Uses config) > empty.
@@ -307,6 +609,13 @@ type MCPConfig struct {
 	Tools []string `json:"tools,omitempty"` // empty = all
 }
 
+// ProcessConfig - Yao process a task may call, with its argument schema
+type ProcessConfig struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Args        map[string]interface{} `json:"args,omitempty"` // JSON schema of the process arguments
+}
+
 // WeixinConfig holds WeChat iLink Bot integration settings.
 type WeixinConfig struct {
 	Enabled    bool   `json:"enabled"`
@@ -1,6 +1,9 @@
 package types
 
-import "sync"
+import (
+	"sync"
+	"time"
+)
 
 // Global configuration for robot agent
 // These values can be set during agent initialization
@@ -33,3 +36,127 @@ func SetDefaultEmailChannel(channel string) {
 	defer configMu.Unlock()
 	defaultEmailChannel = channel
 }
+
+// WebhookPolicy controls which hosts webhook delivery may reach (SSRF protection).
+// Entries are hostnames ("hooks.example.com", "*.example.com"), IPs or CIDRs ("10.1.0.0/16").
+//   - DenyHosts always wins
+//   - when AllowHosts is non-empty, only matching targets are allowed
+//   - loopback, private, link-local (incl. 169.254.169.254) and unspecified addresses are
+//     blocked unless AllowPrivate is set or the address (IP or CIDR) is listed in AllowHosts
+type WebhookPolicy struct {
+	AllowHosts   []string `json:"allow_hosts,omitempty"`
+	DenyHosts    []string `json:"deny_hosts,omitempty"`
+	AllowPrivate bool     `json:"allow_private,omitempty"`
+}
+
+// webhookPolicy - current webhook target policy, private ranges blocked by default
+var webhookPolicy = WebhookPolicy{}
+
+// GetWebhookPolicy returns a copy of the webhook target policy
+func GetWebhookPolicy() WebhookPolicy {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return WebhookPolicy{
+		AllowHosts:   append([]string(nil), webhookPolicy.AllowHosts...),
+		DenyHosts:    append([]string(nil), webhookPolicy.DenyHosts...),
+		AllowPrivate: webhookPolicy.AllowPrivate,
+	}
+}
+
+// SetWebhookPolicy replaces the webhook target policy
+func SetWebhookPolicy(policy WebhookPolicy) {
+	configMu.Lock()
+	defer configMu.Unlock()
+	webhookPolicy = policy
+}
+
+// PayloadLimits bounds robot create/update payloads so a single request cannot store
+// a huge or pathologically nested configuration. Zero fields use the defaults below.
+type PayloadLimits struct {
+	MaxBodyBytes   int64 `json:"max_body_bytes,omitempty"`   // request body size
+	MaxListItems   int   `json:"max_list_items,omitempty"`   // agents, mcp_servers, authorized_senders, email_filter_rules
+	MaxConfigDepth int   `json:"max_config_depth,omitempty"` // nesting depth of robot_config
+	MaxConfigKeys  int   `json:"max_config_keys,omitempty"`  // total object keys in robot_config
+}
+
+// Default payload limits
+const (
+	DefaultPayloadMaxBodyBytes   int64 = 1 << 20 // 1MB
+	DefaultPayloadMaxListItems         = 500
+	DefaultPayloadMaxConfigDepth       = 32
+	DefaultPayloadMaxConfigKeys        = 5000
+)
+
+// payloadLimits - current robot payload limits, zero means default
+var payloadLimits = PayloadLimits{}
+
+// GetPayloadLimits returns the robot payload limits with defaults applied
+func GetPayloadLimits() PayloadLimits {
+	configMu.RLock()
+	limits := payloadLimits
+	configMu.RUnlock()
+
+	if limits.MaxBodyBytes <= 0 {
+		limits.MaxBodyBytes = DefaultPayloadMaxBodyBytes
+	}
+	if limits.MaxListItems <= 0 {
+		limits.MaxListItems = DefaultPayloadMaxListItems
+	}
+	if limits.MaxConfigDepth <= 0 {
+		limits.MaxConfigDepth = DefaultPayloadMaxConfigDepth
+	}
+	if limits.MaxConfigKeys <= 0 {
+		limits.MaxConfigKeys = DefaultPayloadMaxConfigKeys
+	}
+	return limits
+}
+
+// SetPayloadLimits replaces the robot payload limits
+func SetPayloadLimits(limits PayloadLimits) {
+	configMu.Lock()
+	defer configMu.Unlock()
+	payloadLimits = limits
+}
+
+// WebhookRetry controls how a webhook delivery that failed with a network error or a
+// 5xx response is retried. Zero fields use the defaults below.
+type WebhookRetry struct {
+	MaxAttempts int           `json:"max_attempts,omitempty"` // requests per delivery, including the first
+	BaseDelay   time.Duration `json:"base_delay,omitempty"`   // wait before the first retry, doubled for each further retry
+	MaxDelay    time.Duration `json:"max_delay,omitempty"`    // cap on a single wait, Retry-After included
+}
+
+// Default webhook retry policy
+const (
+	DefaultWebhookMaxAttempts = 3
+	DefaultWebhookBaseDelay   = time.Second
+	DefaultWebhookMaxDelay    = 30 * time.Second
+)
+
+// webhookRetry - current webhook retry policy, zero means default
+var webhookRetry = WebhookRetry{}
+
+// GetWebhookRetry returns the webhook retry policy with defaults applied
+func GetWebhookRetry() WebhookRetry {
+	configMu.RLock()
+	retry := webhookRetry
+	configMu.RUnlock()
+
+	if retry.MaxAttempts <= 0 {
+		retry.MaxAttempts = DefaultWebhookMaxAttempts
+	}
+	if retry.BaseDelay <= 0 {
+		retry.BaseDelay = DefaultWebhookBaseDelay
+	}
+	if retry.MaxDelay <= 0 {
+		retry.MaxDelay = DefaultWebhookMaxDelay
+	}
+	return retry
+}
+
+// SetWebhookRetry replaces the webhook retry policy
+func SetWebhookRetry(retry WebhookRetry) {
+	configMu.Lock()
+	defer configMu.Unlock()
+	webhookRetry = retry
+}
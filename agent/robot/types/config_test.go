@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	agentcontext "github.com/yaoapp/yao/agent/context"
 	"github.com/yaoapp/yao/agent/robot/types"
 )
 
@@ -215,6 +216,23 @@ func TestQuotaDefaults(t *testing.T) {
 		assert.Equal(t, 20, quota.GetQueue())
 		assert.Equal(t, 8, quota.GetPriority())
 	})
+
+	t.Run("overflow policy", func(t *testing.T) {
+		var quota *types.Quota
+		assert.Equal(t, types.OverflowQueue, quota.GetOverflowPolicy())
+		assert.Equal(t, 2, quota.GetMaxFor(types.TriggerHuman))
+
+		quota = &types.Quota{Max: 2, OverflowPolicy: "bogus"}
+		assert.Equal(t, types.OverflowQueue, quota.GetOverflowPolicy())
+
+		quota = &types.Quota{Max: 2, OverflowPolicy: types.OverflowBorrow}
+		assert.Equal(t, 3, quota.GetMaxFor(types.TriggerHuman), "one extra slot by default")
+		assert.Equal(t, 2, quota.GetMaxFor(types.TriggerClock), "only human work borrows")
+
+		quota.Overflow = 3
+		assert.Equal(t, 5, quota.GetMaxFor(types.TriggerHuman))
+		assert.Equal(t, 2, quota.GetMaxFor(types.TriggerEvent))
+	})
 }
 
 func TestResourcesGetPhaseAgent(t *testing.T) {
@@ -390,3 +408,80 @@ func TestExecutorConfigGetMaxDuration(t *testing.T) {
 		}
 	})
 }
+
+func TestConfigRequiresApproval(t *testing.T) {
+	config := &types.Config{ApprovalRequiredFor: []string{"payment", "mcp", "process.billing.*", "gmail.send"}}
+
+	tests := []struct {
+		name string
+		task *types.Task
+		want bool
+	}{
+		{"tag", &types.Task{ExecutorType: types.ExecutorAssistant, ExecutorID: "agent.writer", Tags: []string{"Payment"}}, true},
+		{"executor type", &types.Task{ExecutorType: types.ExecutorMCP, ExecutorID: "search.web"}, true},
+		{"executor id prefix", &types.Task{ExecutorType: types.ExecutorProcess, ExecutorID: "process.billing.charge"}, true},
+		{"mcp server and tool", &types.Task{ExecutorType: types.ExecutorAssistant, MCPServer: "gmail", MCPTool: "send"}, true},
+		{"no match", &types.Task{ExecutorType: types.ExecutorAssistant, ExecutorID: "agent.writer", Tags: []string{"report"}}, false},
+		{"prefix is not exact", &types.Task{ExecutorType: types.ExecutorProcess, ExecutorID: "process.billingx"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, config.RequiresApproval(tt.task))
+		})
+	}
+
+	var nilConfig *types.Config
+	assert.False(t, nilConfig.RequiresApproval(&types.Task{Tags: []string{"payment"}}))
+	assert.False(t, (&types.Config{}).RequiresApproval(&types.Task{Tags: []string{"payment"}}))
+}
+
+func TestAgentRoutingMatch(t *testing.T) {
+	routing := &types.AgentRouting{
+		Rules: []types.RoutingRule{
+			{Agent: "experts.finance", Tags: []string{"finance"}},
+			{Agent: "experts.writer", Keywords: []string{"report", "summary"}},
+			{Agent: "experts.unlisted", Keywords: []string{"sales"}},
+		},
+	}
+	allowed := []string{"experts.finance", "experts.writer"}
+
+	task := &types.Task{Tags: []string{"Finance"}, Description: "Write the weekly report"}
+	assert.Equal(t, "experts.finance", routing.Match(task, allowed), "rules are evaluated in order")
+
+	task = &types.Task{Messages: []agentcontext.Message{{Role: "user", Content: "Draft a SUMMARY of the call"}}}
+	assert.Equal(t, "experts.writer", routing.Match(task, allowed))
+
+	task = &types.Task{Description: "Collect sales figures"}
+	assert.Empty(t, routing.Match(task, allowed), "agents outside the allowed list are never picked")
+
+	assert.Empty(t, (*types.AgentRouting)(nil).Match(task, allowed))
+}
+
+func TestConfigTimeouts(t *testing.T) {
+	t.Run("nil config - default overall, no phase limit", func(t *testing.T) {
+		var config *types.Config
+		assert.Equal(t, 30*time.Minute, config.GetOverallTimeout())
+		assert.Equal(t, time.Duration(0), config.GetPhaseTimeout())
+	})
+
+	t.Run("no timeout - falls back to executor max_duration", func(t *testing.T) {
+		config := &types.Config{Executor: &types.ExecutorConfig{MaxDuration: "1h"}}
+		assert.Equal(t, time.Hour, config.GetOverallTimeout())
+		assert.Equal(t, time.Duration(0), config.GetPhaseTimeout())
+	})
+
+	t.Run("custom seconds", func(t *testing.T) {
+		config := &types.Config{Timeout: &types.Timeout{Overall: 600, Phase: 300}}
+		assert.Equal(t, 10*time.Minute, config.GetOverallTimeout())
+		assert.Equal(t, 5*time.Minute, config.GetPhaseTimeout())
+	})
+
+	t.Run("zero means no timeout", func(t *testing.T) {
+		config := &types.Config{
+			Timeout:  &types.Timeout{},
+			Executor: &types.ExecutorConfig{MaxDuration: "1h"},
+		}
+		assert.Equal(t, time.Duration(0), config.GetOverallTimeout())
+		assert.Equal(t, time.Duration(0), config.GetPhaseTimeout())
+	})
+}
@@ -61,6 +61,11 @@ const (
 	ExecWaiting    ExecStatus = "waiting"    // V2: suspended, waiting for human input
 )
 
+// IsTerminal returns true if the execution will not change state anymore
+func (s ExecStatus) IsTerminal() bool {
+	return s == ExecCompleted || s == ExecFailed || s == ExecCancelled
+}
+
 // RobotStatus - matches __yao.member.robot_status
 type RobotStatus string
 
@@ -124,9 +129,72 @@ const (
 	DeliveryEmail   DeliveryType = "email"   // Send via yao/messenger
 	DeliveryWebhook DeliveryType = "webhook" // POST to external URL
 	DeliveryProcess DeliveryType = "process" // Call Yao Process
+	DeliverySlack   DeliveryType = "slack"   // POST Block Kit message to a Slack incoming webhook
 	DeliveryNotify  DeliveryType = "notify"  // In-app notification (future, auto by subscriptions)
 )
 
+// DeliveryStatus - outcome of delivering an execution's results to its channels
+type DeliveryStatus string
+
+// DeliveryStatus constants
+const (
+	DeliveryStatusPending DeliveryStatus = "pending" // delivery event pushed, channels not done yet
+	DeliveryStatusSuccess DeliveryStatus = "success" // every target succeeded
+	DeliveryStatusPartial DeliveryStatus = "partial" // some targets failed
+	DeliveryStatusFailed  DeliveryStatus = "failed"  // every target failed
+	DeliveryStatusSkipped DeliveryStatus = "skipped" // nothing to deliver or no targets configured
+)
+
+// DeliveryDisposition - final outcome of delivering to one target
+type DeliveryDisposition string
+
+// DeliveryDisposition constants
+const (
+	DeliveryDelivered    DeliveryDisposition = "delivered"     // the target accepted the delivery
+	DeliveryFailed       DeliveryDisposition = "failed"        // gave up without a dead letter (blocked target, unbuildable payload)
+	DeliveryDeadLettered DeliveryDisposition = "dead_lettered" // every attempt failed, kept for redelivery
+)
+
+// DeliveryFormat - rendered artifact attached to the delivery content
+type DeliveryFormat string
+
+// DeliveryFormat constants
+const (
+	DeliveryFormatMarkdown DeliveryFormat = "markdown" // body only, no artifact (default)
+	DeliveryFormatHTML     DeliveryFormat = "html"     // body rendered to a standalone HTML file
+	DeliveryFormatPDF      DeliveryFormat = "pdf"      // body rendered to PDF via the registered renderer
+)
+
+// IsValid reports whether f is a known delivery format; empty means markdown
+func (f DeliveryFormat) IsValid() bool {
+	switch f {
+	case "", DeliveryFormatMarkdown, DeliveryFormatHTML, DeliveryFormatPDF:
+		return true
+	}
+	return false
+}
+
+// SummarizeDelivery reduces per-target results to a DeliveryStatus
+func SummarizeDelivery(results []ChannelResult) DeliveryStatus {
+	if len(results) == 0 {
+		return DeliveryStatusSkipped
+	}
+	succeeded := 0
+	for _, r := range results {
+		if r.Success {
+			succeeded++
+		}
+	}
+	switch succeeded {
+	case len(results):
+		return DeliveryStatusSuccess
+	case 0:
+		return DeliveryStatusFailed
+	default:
+		return DeliveryStatusPartial
+	}
+}
+
 // DedupResult - deduplication result
 type DedupResult string
 
@@ -144,6 +212,7 @@ type EventSource string
 const (
 	EventWebhook  EventSource = "webhook"  // HTTP webhook
 	EventDatabase EventSource = "database" // DB change trigger
+	EventEmail    EventSource = "email"    // inbound email via the mail gateway
 )
 
 // LearningType - learning entry type
@@ -215,6 +284,27 @@ const (
 	ExecutorSandbox ExecutorMode = "sandbox"
 )
 
+// OverflowPolicy - what happens to a submission when the robot's quota is full
+type OverflowPolicy string
+
+// OverflowPolicy constants
+const (
+	OverflowQueue  OverflowPolicy = "queue"    // wait in the pool queue until a slot frees up (default)
+	OverflowReject OverflowPolicy = "reject"   // fail the submission with ErrQuotaExceeded
+	OverflowBorrow OverflowPolicy = "overflow" // human-triggered work may exceed max by quota.overflow slots
+)
+
+// ResultsStrategy - how previous task results are folded into a later task's context
+type ResultsStrategy string
+
+// ResultsStrategy constants
+const (
+	ResultsFull        ResultsStrategy = "full"         // every previous result verbatim (default)
+	ResultsSummary     ResultsStrategy = "summary"      // status and a short preview of each output
+	ResultsLastN       ResultsStrategy = "last_n"       // the most recent N results verbatim, older ones listed by status only
+	ResultsTokenBudget ResultsStrategy = "token_budget" // newest results first until the budget is spent; older ones summarized, then dropped
+)
+
 // HostAction defines structured instructions from Host Agent to Manager
 type HostAction string
 
@@ -249,6 +339,24 @@ func (m ExecutorMode) IsValid() bool {
 	return false
 }
 
+// IsValid checks if the overflow policy is valid
+func (p OverflowPolicy) IsValid() bool {
+	switch p {
+	case OverflowQueue, OverflowReject, OverflowBorrow, "":
+		return true
+	}
+	return false
+}
+
+// IsValid checks if the results strategy is valid
+func (s ResultsStrategy) IsValid() bool {
+	switch s {
+	case ResultsFull, ResultsSummary, ResultsLastN, ResultsTokenBudget, "":
+		return true
+	}
+	return false
+}
+
 // GetDefault returns the default executor mode if empty
 func (m ExecutorMode) GetDefault() ExecutorMode {
 	if m == "" {
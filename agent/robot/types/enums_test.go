@@ -184,3 +184,13 @@ func TestExecutorModeGetDefault(t *testing.T) {
 		})
 	}
 }
+
+func TestSummarizeDelivery(t *testing.T) {
+	ok := types.ChannelResult{Type: types.DeliveryEmail, Success: true}
+	failed := types.ChannelResult{Type: types.DeliveryWebhook, Error: "timeout"}
+
+	assert.Equal(t, types.DeliveryStatusSkipped, types.SummarizeDelivery(nil))
+	assert.Equal(t, types.DeliveryStatusSuccess, types.SummarizeDelivery([]types.ChannelResult{ok, ok}))
+	assert.Equal(t, types.DeliveryStatusPartial, types.SummarizeDelivery([]types.ChannelResult{ok, failed}))
+	assert.Equal(t, types.DeliveryStatusFailed, types.SummarizeDelivery([]types.ChannelResult{failed}))
+}
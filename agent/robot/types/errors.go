@@ -26,12 +26,19 @@ var ErrRobotBusy = errors.New("robot has reached max concurrent executions")
 // ErrQuotaExceeded indicates robot quota was exceeded (atomic check failed)
 var ErrQuotaExceeded = errors.New("robot quota exceeded")
 
+// ErrDailyLimitReached indicates the robot used up its max_daily_executions for today
+var ErrDailyLimitReached = errors.New("robot reached its daily execution limit")
+
 // ErrTriggerDisabled indicates trigger type is disabled for this robot
 var ErrTriggerDisabled = errors.New("trigger type is disabled for this robot")
 
 // ErrExecutionCancelled indicates execution was cancelled
 var ErrExecutionCancelled = errors.New("execution was cancelled")
 
+// ErrInteractCancelled indicates the client of a streaming interaction went away
+// before the Host Agent finished; nothing is left to answer
+var ErrInteractCancelled = errors.New("interaction cancelled by client")
+
 // ErrExecutionTimeout indicates execution timed out
 var ErrExecutionTimeout = errors.New("execution timed out")
 
@@ -51,3 +58,42 @@ var ErrDeliveryFailed = errors.New("delivery failed")
 // suspended to wait for human input. The executor should persist state and
 // release its worker goroutine. NOT a failure — resumable via Resume().
 var ErrExecutionSuspended = errors.New("execution suspended: waiting for human input")
+
+// ErrBatchEmpty indicates a batch trigger was submitted without items
+var ErrBatchEmpty = errors.New("batch must contain at least one item")
+
+// ErrBatchTooLarge indicates a batch trigger exceeds MaxBatchItems
+var ErrBatchTooLarge = errors.New("batch exceeds the maximum number of items")
+
+// ErrBatchNotFound indicates batch not found
+var ErrBatchNotFound = errors.New("batch not found")
+
+// ErrInvalidExecutionNote indicates an empty or oversized execution note
+var ErrInvalidExecutionNote = errors.New("invalid execution note")
+
+// ErrInvalidReset indicates an execution reset to an unsupported status or of an execution that already ended
+var ErrInvalidReset = errors.New("invalid execution reset")
+
+// ErrCancelRequiresForce indicates a cancel of a running execution without force
+var ErrCancelRequiresForce = errors.New("cancelling a running execution requires force")
+
+// ErrPlanNotEditable indicates a plan edit on an execution that is not confirming
+var ErrPlanNotEditable = errors.New("plan can only be edited while the execution is confirming")
+
+// ErrPlanVersionConflict indicates a plan edit based on a stale plan version
+var ErrPlanVersionConflict = errors.New("plan version conflict")
+
+// ErrInvalidPlan indicates an edited plan that fails validation
+var ErrInvalidPlan = errors.New("invalid plan")
+
+// ErrPayloadTooLarge indicates a robot payload over the configured body size
+var ErrPayloadTooLarge = errors.New("payload too large")
+
+// ErrPayloadLimit indicates a robot payload that breaks a structural limit (list length, nesting, key count)
+var ErrPayloadLimit = errors.New("payload limit exceeded")
+
+// ErrWebhookTargetBlocked indicates a webhook URL rejected by the webhook host policy
+var ErrWebhookTargetBlocked = errors.New("webhook target not allowed")
+
+// ErrRetryNotAllowed indicates a retry of an execution that did not fail or get cancelled
+var ErrRetryNotAllowed = errors.New("execution cannot be retried")
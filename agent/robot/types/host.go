@@ -13,12 +13,15 @@ type HostInput struct {
 // Note: Goals is *Goals (struct with Content field), serialized as {"content":"..."}.
 // Host Agent prompts must expect this struct format rather than a plain string.
 type HostContext struct {
-	RobotStatus *RobotStatusSnapshot   `json:"robot_status,omitempty"`
-	Goals       *Goals                 `json:"goals,omitempty"`
-	Tasks       []Task                 `json:"tasks,omitempty"`
-	CurrentTask *Task                  `json:"current_task,omitempty"`
-	AgentReply  string                 `json:"agent_reply,omitempty"`
-	History     []agentcontext.Message `json:"history,omitempty"`
+	RobotStatus *RobotStatusSnapshot `json:"robot_status,omitempty"`
+	Goals       *Goals               `json:"goals,omitempty"`
+	Tasks       []Task               `json:"tasks,omitempty"`
+	CurrentTask *Task                `json:"current_task,omitempty"`
+	AgentReply  string               `json:"agent_reply,omitempty"`
+	// PendingApproval: the current task waits for approval (approval_required_for);
+	// "confirm" approves it, "skip" rejects it
+	PendingApproval bool                   `json:"pending_approval,omitempty"`
+	History         []agentcontext.Message `json:"history,omitempty"`
 }
 
 // HostOutput is the structured output from Host Agent
@@ -28,6 +28,13 @@ type Manager interface {
 }
 
 // Executor - executes robot phases
+//
+// Quota invariant: a robot never tracks more executions than its quota allows.
+// Implementations reserve a slot with Robot.TryAcquireSlot (an atomic check-and-add
+// on the robot's execution map) before running and release it when done, and give
+// every execution a unique ID, since an ID that is already tracked reuses its slot.
+// Only Resume re-adds a suspended execution without the check: its slot was
+// counted when it first ran.
 type Executor interface {
 	// ExecuteWithControl runs execution with pre-generated ID and execution control (used by pool)
 	// control: optional, allows pause/resume functionality
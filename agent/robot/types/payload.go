@@ -0,0 +1,82 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// payloadListFields are the robot member columns holding unbounded lists
+var payloadListFields = []string{"agents", "mcp_servers", "authorized_senders", "email_filter_rules"}
+
+// CheckRobotPayload validates robot member column values against the payload limits.
+// It checks the list columns and the shape of robot_config; other keys are ignored.
+// The returned error wraps ErrPayloadLimit and names the violated limit.
+func CheckRobotPayload(data map[string]interface{}) error {
+	if data == nil {
+		return nil
+	}
+	limits := GetPayloadLimits()
+
+	for _, field := range payloadListFields {
+		if n := listLen(data[field]); n > limits.MaxListItems {
+			return fmt.Errorf("%w: %s has %d items, max_list_items is %d", ErrPayloadLimit, field, n, limits.MaxListItems)
+		}
+	}
+
+	if cfg, ok := data["robot_config"]; ok && cfg != nil {
+		keys := 0
+		if err := walkConfig(cfg, 1, &keys, limits); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listLen returns the length of a slice value, 0 for anything else
+func listLen(v interface{}) int {
+	switch list := v.(type) {
+	case nil:
+		return 0
+	case []string:
+		return len(list)
+	case []interface{}:
+		return len(list)
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		return rv.Len()
+	}
+	return 0
+}
+
+// walkConfig checks nesting depth and total key count, stopping at the first violation
+func walkConfig(v interface{}, depth int, keys *int, limits PayloadLimits) error {
+	switch node := v.(type) {
+	case map[string]interface{}:
+		if depth > limits.MaxConfigDepth {
+			return fmt.Errorf("%w: robot_config nesting exceeds max_config_depth %d", ErrPayloadLimit, limits.MaxConfigDepth)
+		}
+		*keys += len(node)
+		if *keys > limits.MaxConfigKeys {
+			return fmt.Errorf("%w: robot_config has more than max_config_keys %d keys", ErrPayloadLimit, limits.MaxConfigKeys)
+		}
+		for _, child := range node {
+			if err := walkConfig(child, depth+1, keys, limits); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		if depth > limits.MaxConfigDepth {
+			return fmt.Errorf("%w: robot_config nesting exceeds max_config_depth %d", ErrPayloadLimit, limits.MaxConfigDepth)
+		}
+		if len(node) > limits.MaxConfigKeys {
+			return fmt.Errorf("%w: robot_config list has more than max_config_keys %d items", ErrPayloadLimit, limits.MaxConfigKeys)
+		}
+		for _, child := range node {
+			if err := walkConfig(child, depth+1, keys, limits); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+//go:build unit
+
+package types_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/yao/agent/robot/types"
+)
+
+func nestedConfig(depth int) map[string]interface{} {
+	cfg := map[string]interface{}{"leaf": true}
+	for i := 1; i < depth; i++ {
+		cfg = map[string]interface{}{"next": cfg}
+	}
+	return cfg
+}
+
+func TestCheckRobotPayload(t *testing.T) {
+	types.SetPayloadLimits(types.PayloadLimits{})
+
+	t.Run("valid payload passes", func(t *testing.T) {
+		err := types.CheckRobotPayload(map[string]interface{}{
+			"agents":       []string{"agent.alpha", "agent.beta"},
+			"mcp_servers":  []interface{}{"mcp.search"},
+			"robot_config": map[string]interface{}{"quota": map[string]interface{}{"max": 2}},
+			"display_name": "Report Bot",
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, types.CheckRobotPayload(nil))
+	})
+
+	t.Run("10000 agents", func(t *testing.T) {
+		agents := make([]interface{}, 10000)
+		for i := range agents {
+			agents[i] = "agent.x"
+		}
+		err := types.CheckRobotPayload(map[string]interface{}{"agents": agents})
+		assert.True(t, errors.Is(err, types.ErrPayloadLimit))
+		assert.Contains(t, err.Error(), "max_list_items")
+		assert.Contains(t, err.Error(), "agents")
+	})
+
+	t.Run("100 levels deep", func(t *testing.T) {
+		err := types.CheckRobotPayload(map[string]interface{}{"robot_config": nestedConfig(100)})
+		assert.True(t, errors.Is(err, types.ErrPayloadLimit))
+		assert.Contains(t, err.Error(), "max_config_depth")
+
+		assert.NoError(t, types.CheckRobotPayload(map[string]interface{}{"robot_config": nestedConfig(types.DefaultPayloadMaxConfigDepth)}))
+	})
+
+	t.Run("too many keys", func(t *testing.T) {
+		types.SetPayloadLimits(types.PayloadLimits{MaxConfigKeys: 10})
+		defer types.SetPayloadLimits(types.PayloadLimits{})
+
+		cfg := map[string]interface{}{}
+		for _, k := range []string{"a", "b", "c", "d", "e", "f"} {
+			cfg[k] = map[string]interface{}{"x": 1}
+		}
+		err := types.CheckRobotPayload(map[string]interface{}{"robot_config": cfg})
+		assert.True(t, errors.Is(err, types.ErrPayloadLimit))
+		assert.Contains(t, err.Error(), "max_config_keys")
+	})
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
@@ -24,6 +25,8 @@ type Robot struct {
 	RobotEmail     string      `json:"robot_email"`    // Deprecated: Robot's email address for sending emails
 	LanguageModel  string      `json:"language_model"` // LLM connector override (from __yao.member.language_model)
 	Workspace      string      `json:"workspace"`      // Workspace ID bound to this robot (nullable in DB)
+	CostLimit      float64     `json:"cost_limit"`     // Monthly cost limit USD (0 = unlimited)
+	Timezone       string      `json:"timezone"`       // IANA timezone (from __yao.member.timezone); empty = server zone
 
 	// Manager info (from __yao.member)
 	ManagerID    string `json:"manager_id"`    // Direct manager user_id (who manages this robot)
@@ -45,12 +48,28 @@ type Robot struct {
 // CanRun checks if robot can accept new execution
 // Note: This is a read-only check. For atomic check-and-acquire, use TryAcquireSlot()
 func (r *Robot) CanRun() bool {
+	return r.CanRunTrigger("")
+}
+
+// CanRunTrigger checks if robot can accept a new execution of the given trigger,
+// counting the overflow slots the quota lends to high-priority work
+func (r *Robot) CanRunTrigger(trigger TriggerType) bool {
 	r.execMu.RLock()
 	defer r.execMu.RUnlock()
+	return len(r.executions) < r.quota().GetMaxFor(trigger)
+}
+
+// OverflowPolicy returns how submissions are handled while the quota is full
+func (r *Robot) OverflowPolicy() OverflowPolicy {
+	return r.quota().GetOverflowPolicy()
+}
+
+// quota returns the robot's quota config; nil means defaults
+func (r *Robot) quota() *Quota {
 	if r.Config == nil {
-		return len(r.executions) < 2 // default max
+		return nil
 	}
-	return len(r.executions) < r.Config.Quota.GetMax()
+	return r.Config.Quota
 }
 
 // TryAcquireSlot atomically checks if robot can run and reserves a slot.
@@ -71,12 +90,7 @@ func (r *Robot) TryAcquireSlot(exec *Execution) bool {
 		}
 	}
 
-	maxQuota := 2 // default
-	if r.Config != nil {
-		maxQuota = r.Config.Quota.GetMax()
-	}
-
-	if len(r.executions) >= maxQuota {
+	if len(r.executions) >= r.quota().GetMaxFor(exec.TriggerType) {
 		return false
 	}
 
@@ -105,6 +119,20 @@ func (r *Robot) AddExecution(exec *Execution) {
 	r.executions[exec.ID] = exec
 }
 
+// Location returns the timezone the robot schedules and renders times in:
+// the clock's tz when set, else the robot's timezone, else the server zone
+func (r *Robot) Location() *time.Location {
+	if r.Config != nil && r.Config.Clock != nil && r.Config.Clock.TZ != "" {
+		return r.Config.Clock.GetLocation()
+	}
+	if r.Timezone != "" {
+		if loc, err := time.LoadLocation(r.Timezone); err == nil {
+			return loc
+		}
+	}
+	return time.Local
+}
+
 // RemoveExecution removes an execution from tracking
 func (r *Robot) RemoveExecution(execID string) {
 	r.execMu.Lock()
@@ -112,6 +140,15 @@ func (r *Robot) RemoveExecution(execID string) {
 	delete(r.executions, execID)
 }
 
+// ReleaseSlot removes an execution from tracking and returns how many remain,
+// under one lock so the count cannot include a slot acquired in between
+func (r *Robot) ReleaseSlot(execID string) int {
+	r.execMu.Lock()
+	defer r.execMu.Unlock()
+	delete(r.executions, execID)
+	return len(r.executions)
+}
+
 // GetExecution returns an execution by ID
 func (r *Robot) GetExecution(execID string) *Execution {
 	r.execMu.RLock()
@@ -219,6 +256,9 @@ type Execution struct {
 	Delivery    *DeliveryResult    `json:"delivery,omitempty"`
 	Learning    []LearningEntry    `json:"learning,omitempty"`
 
+	// Outcome of the delivery channels, written by the delivery handler after P4
+	DeliveryStatus DeliveryStatus `json:"delivery_status,omitempty"`
+
 	// V2: Conversation and suspend-resume fields
 	ChatID          string         `json:"chat_id,omitempty"`          // Unique conversation ID for Host Agent
 	WaitingTaskID   string         `json:"waiting_task_id,omitempty"`  // Task ID that is waiting for input
@@ -226,6 +266,19 @@ type Execution struct {
 	WaitingSince    *time.Time     `json:"waiting_since,omitempty"`    // When execution was suspended
 	ResumeContext   *ResumeContext `json:"resume_context,omitempty"`   // State for resuming suspended execution
 
+	// Chaining: execution this one was continued from (empty for root executions)
+	ParentExecutionID string `json:"parent_execution_id,omitempty"`
+
+	// Operator annotations (not produced by the robot)
+	Notes []ExecutionNote `json:"notes,omitempty"`
+
+	// Human edits of the plan while confirming, oldest first
+	PlanEdits []PlanEdit `json:"plan_edits,omitempty"`
+
+	// Cancellation details, set when the execution is cancelled through CancelExecution
+	CancelReason string `json:"cancel_reason,omitempty"`
+	CancelledBy  string `json:"cancelled_by,omitempty"` // user ID, or "system"
+
 	// Runtime (internal, not serialized)
 	ctx    context.Context    `json:"-"`
 	cancel context.CancelFunc `json:"-"`
@@ -234,8 +287,57 @@ type Execution struct {
 
 // ResumeContext holds the state needed to resume a suspended execution
 type ResumeContext struct {
-	TaskIndex       int          `json:"task_index"`       // Index of the task to resume from
-	PreviousResults []TaskResult `json:"previous_results"` // Results from tasks completed before suspend
+	TaskIndex       int          `json:"task_index"`                 // Index of the task to resume from
+	PreviousResults []TaskResult `json:"previous_results"`           // Results from tasks completed before suspend
+	ApprovalTaskID  string       `json:"approval_task_id,omitempty"` // Set when suspended to approve this task (not to answer a question)
+}
+
+// ReplyApprove is the resume reply that grants a pending task approval.
+// Any other reply keeps the execution waiting for approval; "__skip__" skips the task.
+const ReplyApprove = "__approve__"
+
+// MaxExecutionNoteLength caps the size of a single operator note
+const MaxExecutionNoteLength = 4000
+
+// ExecutionNote is an operator annotation attached to an execution for later review
+type ExecutionNote struct {
+	Author    string    `json:"author"` // user ID of the operator
+	Note      string    `json:"note"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PlanEdit records one human edit of a confirming execution's plan
+type PlanEdit struct {
+	Editor   string    `json:"editor"`  // user ID of whoever edited the plan
+	Version  int       `json:"version"` // plan version the edit produced
+	Tasks    int       `json:"tasks"`   // number of tasks in the edited plan
+	EditedAt time.Time `json:"edited_at"`
+}
+
+// ExecutionExplanation is a plain-language summary of what an execution did and why,
+// cached on the execution per locale
+type ExecutionExplanation struct {
+	Summary     string    `json:"summary"`
+	Locale      string    `json:"locale"`
+	Source      string    `json:"source"` // assistant | template
+	Digest      string    `json:"digest"` // fingerprint of the execution state the summary describes
+	Final       bool      `json:"final"`  // written after the execution ended; never regenerated
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// Explanation sources
+const (
+	ExplanationAssistant = "assistant" // written by the summarizer assistant
+	ExplanationTemplate  = "template"  // assembled from the execution record, used when the assistant is unavailable
+)
+
+// ExecutionPlan is the editable goals and task list of a confirming execution.
+// Version is bumped on every plan change and must be echoed back on update.
+type ExecutionPlan struct {
+	ExecutionID string `json:"execution_id"`
+	Version     int    `json:"version"`
+	Goals       *Goals `json:"goals,omitempty"`
+	Tasks       []Task `json:"tasks"`
 }
 
 // ExecBrief is a lightweight summary of an execution for status snapshots
@@ -252,14 +354,39 @@ type ExecBrief struct {
 
 // RobotStatusSnapshot provides real-time robot status for the Host Agent
 type RobotStatusSnapshot struct {
-	MemberID     string      `json:"member_id,omitempty"`    // Robot member ID
-	Status       RobotStatus `json:"status,omitempty"`       // Current robot status (idle/working)
-	ActiveCount  int         `json:"active_count"`           // Currently running executions
-	WaitingCount int         `json:"waiting_count"`          // Executions waiting for input
-	QueuedCount  int         `json:"queued_count"`           // Executions in queue (not yet started)
-	MaxQuota     int         `json:"max_quota"`              // Maximum concurrent executions
-	ActiveExecs  []ExecBrief `json:"active_execs,omitempty"` // Currently running execution summaries
-	RecentExecs  []ExecBrief `json:"recent_execs,omitempty"` // Recently completed execution summaries
+	MemberID       string      `json:"member_id,omitempty"`       // Robot member ID
+	Status         RobotStatus `json:"status,omitempty"`          // Current robot status (idle/working)
+	ActiveCount    int         `json:"active_count"`              // Currently running executions
+	WaitingCount   int         `json:"waiting_count"`             // Executions waiting for input
+	QueuedCount    int         `json:"queued_count"`              // Executions in queue (not yet started)
+	MaxQuota       int         `json:"max_quota"`                 // Maximum concurrent executions
+	DailyLimit     int         `json:"daily_limit,omitempty"`     // max_daily_executions (0 = unlimited)
+	DailyRemaining *int        `json:"daily_remaining,omitempty"` // Executions left today (nil when unlimited)
+	ActiveExecs    []ExecBrief `json:"active_execs,omitempty"`    // Currently running execution summaries
+	RecentExecs    []ExecBrief `json:"recent_execs,omitempty"`    // Recently completed execution summaries
+}
+
+// SchedulerState - autonomous scheduler snapshot for monitoring
+type SchedulerState struct {
+	MaxConcurrent int                 `json:"max_concurrent"`           // Max concurrent autonomous executions (0 = unlimited)
+	Cooldown      string              `json:"cooldown,omitempty"`       // Per-robot rest after an autonomous run
+	Running       int                 `json:"running"`                  // Autonomous executions in flight
+	RunningRobots []string            `json:"running_robots,omitempty"` // Member IDs of the running executions
+	Deferred      []SchedulerDeferred `json:"deferred,omitempty"`       // Robots due but held back by the limit
+	CoolingDown   []SchedulerCooldown `json:"cooling_down,omitempty"`   // Robots resting after a run
+	LastTick      *time.Time          `json:"last_tick,omitempty"`      // Last scheduling round
+}
+
+// SchedulerDeferred - a robot waiting for a free autonomous slot
+type SchedulerDeferred struct {
+	MemberID string    `json:"member_id"`
+	Since    time.Time `json:"since"`
+}
+
+// SchedulerCooldown - a robot that may not run autonomously before Until
+type SchedulerCooldown struct {
+	MemberID string    `json:"member_id"`
+	Until    time.Time `json:"until"`
 }
 
 // GetRobot returns the robot associated with this execution
@@ -331,6 +458,7 @@ type Task struct {
 	Messages    []agentcontext.Message `json:"messages"`              // original input (text, images, files)
 	GoalRef     string                 `json:"goal_ref,omitempty"`    // reference to goal (e.g., "Goal 1")
 	Source      TaskSource             `json:"source"`                // auto | human | event
+	Tags        []string               `json:"tags,omitempty"`        // task categories, e.g. "external_email", "payment" (see Config.ApprovalRequiredFor)
 
 	// Executor
 	ExecutorType ExecutorType `json:"executor_type"`
@@ -347,11 +475,18 @@ type Task struct {
 	// ValidationRules are specific checks to perform (can be semantic or structural)
 	ValidationRules []string `json:"validation_rules,omitempty"` // e.g., ["output must be valid JSON", "sales_total > 0"]
 
+	// Condition is checked against earlier task results before the task runs; the task
+	// is skipped when it is false (see standard.EvaluateCondition for the syntax)
+	Condition string `json:"condition,omitempty"` // e.g., "task-001.output.anomalies"
+
 	// Runtime
 	Status    TaskStatus `json:"status"`
 	Order     int        `json:"order"` // execution order (0-based)
 	StartTime *time.Time `json:"start_time,omitempty"`
 	EndTime   *time.Time `json:"end_time,omitempty"`
+
+	// Approval gate (Config.ApprovalRequiredFor): set when a human approved the task
+	ApprovedAt *time.Time `json:"approved_at,omitempty"`
 }
 
 // TaskResult - task execution result
@@ -361,6 +496,7 @@ type TaskResult struct {
 	Output   interface{} `json:"output,omitempty"`
 	Error    string      `json:"error,omitempty"`
 	Duration int64       `json:"duration_ms"`
+	Skipped  bool        `json:"skipped,omitempty"` // Task.Condition was false; the task did not run
 
 	// Validation result (populated by Delivery Agent in P4, not by runner in V2)
 	Validation *ValidationResult `json:"validation,omitempty"`
@@ -399,7 +535,13 @@ type DeliveryResult struct {
 type DeliveryContent struct {
 	Summary     string               `json:"summary"`               // Brief 1-2 sentence summary
 	Body        string               `json:"body"`                  // Full markdown report
+	Format      DeliveryFormat       `json:"format,omitempty"`      // markdown (default) | html | pdf - body rendered and attached
 	Attachments []DeliveryAttachment `json:"attachments,omitempty"` // Output artifacts from P3
+
+	// Per-recipient personalization (email): when RecipientTemplate is set each recipient
+	// gets their own message with the template expanded, e.g. "Hi {{ recipient.name }}"
+	RecipientTemplate string            `json:"recipient_template,omitempty"` // Markdown with {{ recipient.* }} / {{ content.* }} placeholders
+	Sections          map[string]string `json:"sections,omitempty"`           // {{ recipient.section }} by recipient email, member_id or role_id
 }
 
 // DeliveryAttachment - Task output attachment with metadata
@@ -410,6 +552,7 @@ type DeliveryAttachment struct {
 	File        string `json:"file"`                   // Wrapper: __<uploader>://<fileID>, workspace://, or URL
 	Size        int64  `json:"size,omitempty"`         // File size in bytes
 	ContentType string `json:"content_type,omitempty"` // MIME type
+	Thumbnail   string `json:"thumbnail,omitempty"`    // Preview wrapper for images, set by the Delivery Center
 }
 
 // DeliveryRequest - pushed to Delivery Center (no channels - center decides based on preferences)
@@ -424,6 +567,17 @@ type DeliveryContext struct {
 	ExecutionID string      `json:"execution_id"` // Execution ID
 	TriggerType TriggerType `json:"trigger_type"` // clock | human | event
 	TeamID      string      `json:"team_id"`      // Team ID
+	Timezone    string      `json:"timezone"`     // Robot timezone (IANA name) for rendered times
+}
+
+// Location returns the delivery timezone, falling back to the server zone
+func (c *DeliveryContext) Location() *time.Location {
+	if c != nil && c.Timezone != "" {
+		if loc, err := time.LoadLocation(c.Timezone); err == nil {
+			return loc
+		}
+	}
+	return time.Local
 }
 
 // DeliveryPreferences - Robot/User delivery preferences (from Config)
@@ -431,6 +585,23 @@ type DeliveryPreferences struct {
 	Email   *EmailPreference   `json:"email,omitempty"`   // Email delivery settings
 	Webhook *WebhookPreference `json:"webhook,omitempty"` // Webhook delivery settings
 	Process *ProcessPreference `json:"process,omitempty"` // Process delivery settings
+	Slack   *SlackPreference   `json:"slack,omitempty"`   // Slack delivery settings
+
+	Thumbnails *ThumbnailPreference `json:"thumbnails,omitempty"` // Image attachment previews
+}
+
+// ThumbnailPreference - preview generation for image attachments
+type ThumbnailPreference struct {
+	Enabled bool `json:"enabled"`        // Generate a thumbnail for each image attachment
+	Size    int  `json:"size,omitempty"` // Longest side in pixels (default: 256)
+}
+
+// GetSize returns the thumbnail size, defaulting to 256px
+func (t *ThumbnailPreference) GetSize() int {
+	if t == nil || t.Size <= 0 {
+		return 256
+	}
+	return t.Size
 }
 
 // EmailPreference - Email delivery configuration
@@ -458,6 +629,33 @@ type WebhookTarget struct {
 	Method  string            `json:"method,omitempty"`  // HTTP method (default: POST)
 	Headers map[string]string `json:"headers,omitempty"` // Custom headers
 	Secret  string            `json:"secret,omitempty"`  // Signing secret
+	Expect  *WebhookExpect    `json:"expect,omitempty"`  // Response checks on top of a 2xx status
+
+	// Retry overrides for this target; unset uses the global webhook retry policy
+	MaxRetries   int `json:"max_retries,omitempty"`   // retries after the first attempt (negative = never retry)
+	RetryBackoff int `json:"retry_backoff,omitempty"` // seconds before the first retry, doubled per retry up to the policy's max delay
+}
+
+// Retry returns the retry policy of this target: retry with the target's overrides applied
+func (t WebhookTarget) Retry(retry WebhookRetry) WebhookRetry {
+	switch {
+	case t.MaxRetries < 0:
+		retry.MaxAttempts = 1
+	case t.MaxRetries > 0:
+		retry.MaxAttempts = t.MaxRetries + 1
+	}
+	if t.RetryBackoff > 0 {
+		retry.BaseDelay = time.Duration(t.RetryBackoff) * time.Second
+	}
+	return retry
+}
+
+// WebhookExpect - response content a webhook must return for the delivery to count as
+// successful; for receivers that answer 200 with an error body. All set checks must pass.
+type WebhookExpect struct {
+	JSONPath string      `json:"json_path,omitempty"` // dotted path into the JSON body (e.g. "data.ok", "items.0.id")
+	Equals   interface{} `json:"equals,omitempty"`    // value at json_path; without it the path only has to exist
+	Contains string      `json:"contains,omitempty"`  // substring the raw body must contain
 }
 
 // ProcessPreference - Process delivery configuration
@@ -470,17 +668,51 @@ type ProcessPreference struct {
 type ProcessTarget struct {
 	Process string `json:"process"`        // Yao Process name
 	Args    []any  `json:"args,omitempty"` // Process arguments
+
+	// ArgsTemplate replaces the default {content, context} envelope argument.
+	// "{{ content.body }}" as a whole value passes the field as-is; inside a longer
+	// string it is interpolated as text. Maps and lists are resolved recursively.
+	// Args are still appended after the templated arguments.
+	ArgsTemplate []any `json:"args_template,omitempty"`
+}
+
+// SlackPreference - Slack delivery configuration
+type SlackPreference struct {
+	Enabled bool          `json:"enabled"`           // Whether Slack delivery is enabled
+	Targets []SlackTarget `json:"targets,omitempty"` // Multiple Slack targets
+}
+
+// SlackTarget - Single Slack incoming webhook
+type SlackTarget struct {
+	WebhookURL   string   `json:"webhook_url"`             // Incoming webhook URL (https://hooks.slack.com/...)
+	Channel      string   `json:"channel,omitempty"`       // Channel override (e.g. "#reports"); the webhook's own channel if empty
+	MentionUsers []string `json:"mention_users,omitempty"` // Slack user IDs mentioned above the body (e.g. "U024BE7LH")
+}
+
+// DeliveryDiagnostic - Validation result for a single delivery target
+type DeliveryDiagnostic struct {
+	Type     DeliveryType `json:"type"`               // email | webhook | process | slack
+	Index    int          `json:"index"`              // Position in the channel's targets
+	Target   string       `json:"target"`             // Target identifier (recipients, URL, process name)
+	Valid    bool         `json:"valid"`              // No errors found
+	Errors   []string     `json:"errors,omitempty"`   // Problems that would fail delivery
+	Warnings []string     `json:"warnings,omitempty"` // Suspicious but deliverable
 }
 
 // ChannelResult - Result of delivery to a single channel target
 type ChannelResult struct {
-	Type       DeliveryType `json:"type"`                 // email | webhook | process
-	Target     string       `json:"target"`               // Target identifier (email, URL, process name)
-	Success    bool         `json:"success"`              // Whether delivery succeeded
-	Recipients []string     `json:"recipients,omitempty"` // Who received (for email)
-	Details    interface{}  `json:"details,omitempty"`    // Channel-specific response
-	Error      string       `json:"error,omitempty"`      // Error message if failed
-	SentAt     *time.Time   `json:"sent_at,omitempty"`    // When this target was delivered
+	Type       DeliveryType `json:"type"`                  // email | webhook | process
+	Target     string       `json:"target"`                // Target identifier (email, URL, process name)
+	Success    bool         `json:"success"`               // Whether delivery succeeded
+	Recipients []string     `json:"recipients,omitempty"`  // Who received (for email)
+	Details    interface{}  `json:"details,omitempty"`     // Channel-specific response
+	Error      string       `json:"error,omitempty"`       // Error message if failed
+	SentAt     *time.Time   `json:"sent_at,omitempty"`     // When this target was delivered
+	DurationMs int64        `json:"duration_ms,omitempty"` // Latency from dispatch to result
+
+	// Webhook only: requests made, and how the delivery ended after the last one
+	Attempts    int                 `json:"attempts,omitempty"`
+	Disposition DeliveryDisposition `json:"disposition,omitempty"`
 }
 
 // LearningEntry - knowledge to save
@@ -513,6 +745,8 @@ func NewRobotFromMap(m map[string]interface{}) (*Robot, error) {
 		ManagerEmail:   getString(m, "manager_email"),
 		LanguageModel:  getString(m, "language_model"),
 		Workspace:      getString(m, "workspace"),
+		CostLimit:      getFloat(m, "cost_limit"),
+		Timezone:       getString(m, "timezone"),
 	}
 
 	// Parse robot_status
@@ -617,3 +851,26 @@ func getBool(m map[string]interface{}, key string) bool {
 	}
 	return false
 }
+
+// getFloat safely gets a float64 value from map
+func getFloat(m map[string]interface{}, key string) float64 {
+	if m == nil {
+		return 0
+	}
+	if v, ok := m[key]; ok && v != nil {
+		switch n := v.(type) {
+		case float64:
+			return n
+		case float32:
+			return float64(n)
+		case int:
+			return float64(n)
+		case int64:
+			return float64(n)
+		case string:
+			f, _ := strconv.ParseFloat(n, 64)
+			return f
+		}
+	}
+	return 0
+}
@@ -860,3 +860,41 @@ func TestDefaultEmailChannel(t *testing.T) {
 		assert.Equal(t, original, types.DefaultEmailChannel())
 	})
 }
+
+func TestRobotLocation(t *testing.T) {
+	t.Run("clock tz wins over robot timezone", func(t *testing.T) {
+		robot := &types.Robot{
+			Timezone: "Europe/Paris",
+			Config:   &types.Config{Clock: &types.Clock{TZ: "Asia/Tokyo"}},
+		}
+		assert.Equal(t, "Asia/Tokyo", robot.Location().String())
+	})
+
+	t.Run("robot timezone without clock tz", func(t *testing.T) {
+		robot := &types.Robot{Timezone: "Europe/Paris", Config: &types.Config{Clock: &types.Clock{}}}
+		assert.Equal(t, "Europe/Paris", robot.Location().String())
+	})
+
+	t.Run("falls back to server timezone", func(t *testing.T) {
+		assert.Equal(t, time.Local, (&types.Robot{}).Location())
+		assert.Equal(t, time.Local, (&types.Robot{Timezone: "Not/AZone"}).Location())
+	})
+
+	t.Run("delivery context", func(t *testing.T) {
+		assert.Equal(t, "America/New_York", (&types.DeliveryContext{Timezone: "America/New_York"}).Location().String())
+		assert.Equal(t, time.Local, (&types.DeliveryContext{}).Location())
+		var ctx *types.DeliveryContext
+		assert.Equal(t, time.Local, ctx.Location())
+	})
+}
+
+func TestRobotReleaseSlot(t *testing.T) {
+	robot := &types.Robot{Config: &types.Config{Quota: &types.Quota{Max: 2}}}
+	assert.True(t, robot.TryAcquireSlot(&types.Execution{ID: "exec1"}))
+	assert.True(t, robot.TryAcquireSlot(&types.Execution{ID: "exec2"}))
+
+	assert.Equal(t, 1, robot.ReleaseSlot("exec1"))
+	assert.Equal(t, 1, robot.ReleaseSlot("missing"), "releasing an unknown ID changes nothing")
+	assert.Equal(t, 0, robot.ReleaseSlot("exec2"))
+	assert.True(t, robot.TryAcquireSlot(&types.Execution{ID: "exec3"}))
+}
@@ -293,6 +293,17 @@ func TestIsValidEmail(t *testing.T) {
 	assert.False(t, utils.IsValidEmail("test@"))
 }
 
+func TestNormalizeEmail(t *testing.T) {
+	email, err := utils.NormalizeEmail(" Foo@Example.COM ")
+	assert.NoError(t, err)
+	assert.Equal(t, "Foo@example.com", email)
+
+	_, err = utils.NormalizeEmail("foo@")
+	assert.Error(t, err)
+	_, err = utils.NormalizeEmail("foo bar@example.com")
+	assert.Error(t, err)
+}
+
 func TestIsValidTime(t *testing.T) {
 	assert.True(t, utils.IsValidTime("09:00"))
 	assert.True(t, utils.IsValidTime("14:30"))
@@ -3,6 +3,7 @@ package utils
 import (
 	"fmt"
 	"regexp"
+	"strings"
 )
 
 var (
@@ -23,6 +24,19 @@ func IsValidEmail(email string) bool {
 	return emailRegex.MatchString(email)
 }
 
+// NormalizeEmail trims an address and lowercases its domain; the local part is kept
+// as entered. Returns an error when the result is not a valid address.
+func NormalizeEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	if at := strings.LastIndex(email, "@"); at > 0 {
+		email = email[:at] + strings.ToLower(email[at:])
+	}
+	if !IsValidEmail(email) {
+		return "", fmt.Errorf("invalid email address: %q", email)
+	}
+	return email, nil
+}
+
 // IsValidTime validates time format (HH:MM)
 func IsValidTime(timeStr string) bool {
 	return timeRegex.MatchString(timeStr)
@@ -66,6 +66,7 @@ type Uses struct {
 	Learning    string `json:"learning,omitempty" yaml:"learning,omitempty"`       // P5: Learning extraction agent
 	Host        string `json:"host,omitempty" yaml:"host,omitempty"`               // Host: Human interaction agent
 	Validation  string `json:"validation,omitempty" yaml:"validation,omitempty"`   // Validation: Task output validation agent
+	Explain     string `json:"explain,omitempty" yaml:"explain,omitempty"`         // Explain: Plain-language execution summary agent
 }
 
 // GetPhaseAgent returns the globally configured agent ID for a robot pipeline phase.
@@ -89,6 +90,8 @@ func (u *Uses) GetPhaseAgent(phase string) string {
 		return u.Host
 	case "validation":
 		return u.Validation
+	case "explain":
+		return u.Explain
 	default:
 		return ""
 	}
@@ -0,0 +1,64 @@
+package attachment
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/xun/dbal"
+)
+
+// Usage is the storage used by a team across all uploaders
+type Usage struct {
+	Files int64 `json:"files"` // Number of stored files
+	Bytes int64 `json:"bytes"` // Total size in bytes
+}
+
+// TeamUsage returns the number of files and bytes stored for a team (by __yao_team_id)
+func TeamUsage(ctx context.Context, teamID string) (*Usage, error) {
+	if teamID == "" {
+		return nil, fmt.Errorf("team_id is required")
+	}
+
+	m := model.Select("__yao.attachment")
+	if m == nil {
+		return nil, fmt.Errorf("attachment model not found")
+	}
+
+	rows, err := m.Get(model.QueryParam{
+		Select: []interface{}{dbal.Raw("COUNT(*) as files"), dbal.Raw("SUM(bytes) as bytes")},
+		Wheres: []model.QueryWhere{
+			{Column: "__yao_team_id", Value: teamID},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team usage: %w", err)
+	}
+
+	usage := &Usage{}
+	if len(rows) > 0 {
+		usage.Files = usageInt(rows[0]["files"])
+		usage.Bytes = usageInt(rows[0]["bytes"])
+	}
+	return usage, nil
+}
+
+// usageInt converts an aggregate value; SUM comes back as a decimal string on some drivers
+func usageInt(v interface{}) int64 {
+	switch n := v.(type) {
+	case int:
+		return int64(n)
+	case int64:
+		return n
+	case float64:
+		return int64(n)
+	case []byte:
+		f, _ := strconv.ParseFloat(string(n), 64)
+		return int64(f)
+	case string:
+		f, _ := strconv.ParseFloat(n, 64)
+		return int64(f)
+	}
+	return 0
+}
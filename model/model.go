@@ -21,9 +21,11 @@ import (
 // SystemModels system models
 var systemModels = map[string]string{
 	"__yao.agent.assistant":    "yao/models/agent/assistant.mod.yao",
+	"__yao.agent.batch":        "yao/models/agent/batch.mod.yao",
 	"__yao.agent.board":        "yao/models/agent/board.mod.yao",
 	"__yao.agent.board.column": "yao/models/agent/board_column.mod.yao",
 	"__yao.agent.chat":         "yao/models/agent/chat.mod.yao",
+	"__yao.agent.dead_letter":  "yao/models/agent/dead_letter.mod.yao",
 	"__yao.agent.execution":    "yao/models/agent/execution.mod.yao",
 	"__yao.agent.mail":         "yao/models/agent/mail.mod.yao",
 	"__yao.agent.message":      "yao/models/agent/message.mod.yao",
@@ -44,6 +46,7 @@ var systemModels = map[string]string{
 	"__yao.kb.document":        "yao/models/kb/document.mod.yao",
 	"__yao.team":               "yao/models/team.mod.yao",
 	"__yao.member":             "yao/models/member.mod.yao",
+	"__yao.member.audit":       "yao/models/member_audit.mod.yao",
 	"__yao.user":               "yao/models/user.mod.yao",
 	"__yao.role":               "yao/models/role.mod.yao",
 	"__yao.user.type":          "yao/models/user/type.mod.yao",
@@ -1,6 +1,7 @@
 package robot
 
 import (
+	"errors"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -9,8 +10,12 @@ import (
 	robottypes "github.com/yaoapp/yao/agent/robot/types"
 	"github.com/yaoapp/yao/openapi/oauth/authorized"
 	"github.com/yaoapp/yao/openapi/response"
+	"github.com/yaoapp/yao/openapi/utils"
 )
 
+// robotPayloadLimit caps create/update bodies at the configured robot payload size (413 above it)
+var robotPayloadLimit = utils.BodyLimit(func() int64 { return robottypes.GetPayloadLimits().MaxBodyBytes })
+
 // GetRobot retrieves a single robot by ID
 // GET /v1/agent/robots/:id
 func GetRobot(c *gin.Context) {
@@ -139,6 +144,10 @@ func CreateRobot(c *gin.Context) {
 	// Parse request body
 	var req CreateRobotRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
+		if utils.IsBodyTooLarge(err) {
+			utils.RespondBodyTooLarge(c, robottypes.GetPayloadLimits().MaxBodyBytes)
+			return
+		}
 		errorResp := &response.ErrorResponse{
 			Code:             response.ErrInvalidRequest.Code,
 			ErrorDescription: "Invalid request body: " + err.Error(),
@@ -223,6 +232,15 @@ func CreateRobot(c *gin.Context) {
 	if err != nil {
 		log.Error("Failed to create robot: %v", err)
 
+		if errors.Is(err, robottypes.ErrPayloadLimit) {
+			errorResp := &response.ErrorResponse{
+				Code:             response.ErrInvalidRequest.Code,
+				ErrorDescription: err.Error(),
+			}
+			response.RespondWithError(c, response.StatusBadRequest, errorResp)
+			return
+		}
+
 		// Check for duplicate error
 		if strings.Contains(err.Error(), "already exists") {
 			errorResp := &response.ErrorResponse{
@@ -266,6 +284,10 @@ func UpdateRobot(c *gin.Context) {
 	// Parse request body
 	var req UpdateRobotRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
+		if utils.IsBodyTooLarge(err) {
+			utils.RespondBodyTooLarge(c, robottypes.GetPayloadLimits().MaxBodyBytes)
+			return
+		}
 		errorResp := &response.ErrorResponse{
 			Code:             response.ErrInvalidRequest.Code,
 			ErrorDescription: "Invalid request body: " + err.Error(),
@@ -321,6 +343,15 @@ func UpdateRobot(c *gin.Context) {
 	if err != nil {
 		log.Error("Failed to update robot %s: %v", robotID, err)
 
+		if errors.Is(err, robottypes.ErrPayloadLimit) {
+			errorResp := &response.ErrorResponse{
+				Code:             response.ErrInvalidRequest.Code,
+				ErrorDescription: err.Error(),
+			}
+			response.RespondWithError(c, response.StatusBadRequest, errorResp)
+			return
+		}
+
 		if err == robottypes.ErrRobotNotFound {
 			errorResp := &response.ErrorResponse{
 				Code:             response.ErrInvalidRequest.Code,
@@ -3,6 +3,7 @@ package robot
 import (
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/yaoapp/kun/log"
@@ -106,6 +107,13 @@ func ListExecutions(c *gin.Context) {
 	if filter.TriggerType != "" {
 		query.Trigger = robottypes.TriggerType(filter.TriggerType)
 	}
+	var ok bool
+	if query.From, ok = parseTimeParam(c, "from", filter.From); !ok {
+		return
+	}
+	if query.To, ok = parseTimeParam(c, "to", filter.To); !ok {
+		return
+	}
 
 	// Call API layer
 	result, err := robotapi.ListExecutions(ctx, robotID, query)
@@ -134,6 +142,24 @@ func ListExecutions(c *gin.Context) {
 	response.RespondWithSuccess(c, response.StatusOK, resp)
 }
 
+// parseTimeParam parses an optional RFC3339 query parameter; on a bad value it
+// writes a 400 response and returns false
+func parseTimeParam(c *gin.Context, name, value string) (*time.Time, bool) {
+	if value == "" {
+		return nil, true
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Invalid '" + name + "' parameter: must be RFC3339 format",
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return nil, false
+	}
+	return &t, true
+}
+
 // GetExecution gets a single execution by ID
 // GET /v1/agent/robots/:id/executions/:exec_id
 func GetExecution(c *gin.Context) {
@@ -230,6 +256,36 @@ func GetExecution(c *gin.Context) {
 	response.RespondWithSuccess(c, response.StatusOK, resp)
 }
 
+// ExplainExecution returns a plain-language summary of what an execution did and why,
+// in the requester's locale (?locale= or Accept-Language)
+// GET /v1/agent/robots/:id/executions/:exec_id/explain
+func ExplainExecution(c *gin.Context) {
+	ctx, execID, ok := bindExecutionRequest(c, false)
+	if !ok {
+		return
+	}
+
+	locale := GetLocale(c)
+	ctx.Locale = locale
+	explanation, err := robotapi.ExplainExecution(ctx, execID, locale)
+	if err != nil {
+		status, code := response.StatusInternalServerError, response.ErrServerError.Code
+		if strings.Contains(err.Error(), "not found") {
+			status, code = response.StatusNotFound, response.ErrInvalidRequest.Code
+		} else {
+			log.Error("Failed to explain execution %s: %v", execID, err)
+		}
+		errorResp := &response.ErrorResponse{
+			Code:             code,
+			ErrorDescription: err.Error(),
+		}
+		response.RespondWithError(c, status, errorResp)
+		return
+	}
+
+	response.RespondWithSuccess(c, response.StatusOK, explanation)
+}
+
 // PauseExecution pauses a running execution
 // POST /v1/agent/robots/:id/executions/:exec_id/pause
 func PauseExecution(c *gin.Context) {
@@ -242,7 +298,8 @@ func ResumeExecution(c *gin.Context) {
 	handleExecutionControl(c, "resume")
 }
 
-// CancelExecution cancels/stops an execution
+// CancelExecution cancels an execution. Waiting and confirming executions are
+// cancelled as is; a running one needs {"force": true} in the body.
 // POST /v1/agent/robots/:id/executions/:exec_id/cancel
 func CancelExecution(c *gin.Context) {
 	handleExecutionControl(c, "cancel")
@@ -314,7 +371,23 @@ func handleExecutionControl(c *gin.Context, action string) {
 	case "resume":
 		controlErr = robotapi.ResumeExecution(ctx, execID)
 	case "cancel":
-		controlErr = robotapi.StopExecution(ctx, execID)
+		var req ExecutionCancelRequest
+		if c.Request.ContentLength != 0 {
+			if err := c.ShouldBindJSON(&req); err != nil {
+				errorResp := &response.ErrorResponse{
+					Code:             response.ErrInvalidRequest.Code,
+					ErrorDescription: "Invalid request body: " + err.Error(),
+				}
+				response.RespondWithError(c, response.StatusBadRequest, errorResp)
+				return
+			}
+		}
+		cancelCtx := robottypes.NewContext(c.Request.Context(), authInfo)
+		controlErr = robotapi.CancelExecution(cancelCtx, execID, robotapi.CancelOptions{
+			Cascade: req.Cascade,
+			Force:   req.Force,
+			Reason:  req.Reason,
+		})
 	default:
 		errorResp := &response.ErrorResponse{
 			Code:             response.ErrInvalidRequest.Code,
@@ -337,6 +410,14 @@ func handleExecutionControl(c *gin.Context, action string) {
 			response.RespondWithError(c, response.StatusNotFound, errorResp)
 			return
 		}
+		if errors.Is(controlErr, robottypes.ErrCancelRequiresForce) || strings.Contains(errMsg, "can be cancelled") {
+			errorResp := &response.ErrorResponse{
+				Code:             response.ErrInvalidRequest.Code,
+				ErrorDescription: errMsg,
+			}
+			response.RespondWithError(c, response.StatusConflict, errorResp)
+			return
+		}
 
 		errorResp := &response.ErrorResponse{
 			Code:             response.ErrServerError.Code,
@@ -355,3 +436,183 @@ func handleExecutionControl(c *gin.Context, action string) {
 	}
 	response.RespondWithSuccess(c, response.StatusOK, resp)
 }
+
+// AddExecutionNote appends an operator note to an execution
+// POST /v1/agent/robots/:id/executions/:exec_id/notes
+func AddExecutionNote(c *gin.Context) {
+	authInfo := authorized.GetInfo(c)
+
+	robotID := c.Param("id")
+	execID := c.Param("exec_id")
+	if robotID == "" || execID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "robot id and execution id are required",
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	var req ExecutionNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Invalid request body: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	ctx := robottypes.NewContext(c.Request.Context(), authInfo)
+
+	robotResp, err := robotapi.GetRobotResponse(ctx, robotID)
+	if err != nil {
+		if errors.Is(err, robottypes.ErrRobotNotFound) {
+			errorResp := &response.ErrorResponse{
+				Code:             response.ErrInvalidRequest.Code,
+				ErrorDescription: "Robot not found: " + robotID,
+			}
+			response.RespondWithError(c, response.StatusNotFound, errorResp)
+			return
+		}
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrServerError.Code,
+			ErrorDescription: "Failed to get robot: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusInternalServerError, errorResp)
+		return
+	}
+
+	if !CanWrite(c, authInfo, robotResp.YaoTeamID, robotResp.YaoCreatedBy) {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrAccessDenied.Code,
+			ErrorDescription: "Forbidden: No permission to annotate this robot's executions",
+		}
+		response.RespondWithError(c, response.StatusForbidden, errorResp)
+		return
+	}
+
+	// Verify execution belongs to this robot
+	exec, err := robotapi.GetExecution(ctx, execID)
+	if err != nil || exec.MemberID != robotID {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Execution not found: " + execID,
+		}
+		response.RespondWithError(c, response.StatusNotFound, errorResp)
+		return
+	}
+
+	author := ""
+	if authInfo != nil {
+		author = authInfo.UserID
+	}
+	notes, err := robotapi.AddExecutionNote(ctx, execID, author, req.Note)
+	if err != nil {
+		if errors.Is(err, robottypes.ErrInvalidExecutionNote) {
+			errorResp := &response.ErrorResponse{
+				Code:             response.ErrInvalidRequest.Code,
+				ErrorDescription: err.Error(),
+			}
+			response.RespondWithError(c, response.StatusBadRequest, errorResp)
+			return
+		}
+		log.Error("Failed to add note to execution %s: %v", execID, err)
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrServerError.Code,
+			ErrorDescription: "Failed to add execution note: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusInternalServerError, errorResp)
+		return
+	}
+
+	response.RespondWithSuccess(c, response.StatusCreated, &ExecutionNotesResponse{ExecutionID: execID, Notes: notes})
+}
+
+// ResetExecution forces a stuck execution into a final status (owner/admin only)
+// POST /v1/agent/robots/:id/executions/:exec_id/reset
+func ResetExecution(c *gin.Context) {
+	authInfo := authorized.GetInfo(c)
+
+	robotID := c.Param("id")
+	execID := c.Param("exec_id")
+	if robotID == "" || execID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "robot id and execution id are required",
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	var req ExecutionResetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Invalid request body: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	ctx := robottypes.NewContext(c.Request.Context(), authInfo)
+
+	robotResp, err := robotapi.GetRobotResponse(ctx, robotID)
+	if err != nil {
+		if errors.Is(err, robottypes.ErrRobotNotFound) {
+			errorResp := &response.ErrorResponse{
+				Code:             response.ErrInvalidRequest.Code,
+				ErrorDescription: "Robot not found: " + robotID,
+			}
+			response.RespondWithError(c, response.StatusNotFound, errorResp)
+			return
+		}
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrServerError.Code,
+			ErrorDescription: "Failed to get robot: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusInternalServerError, errorResp)
+		return
+	}
+
+	if !CanWrite(c, authInfo, robotResp.YaoTeamID, robotResp.YaoCreatedBy) {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrAccessDenied.Code,
+			ErrorDescription: "Forbidden: Only the robot owner or an admin can reset executions",
+		}
+		response.RespondWithError(c, response.StatusForbidden, errorResp)
+		return
+	}
+
+	// Verify execution belongs to this robot
+	exec, err := robotapi.GetExecution(ctx, execID)
+	if err != nil || exec.MemberID != robotID {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Execution not found: " + execID,
+		}
+		response.RespondWithError(c, response.StatusNotFound, errorResp)
+		return
+	}
+
+	exec, err = robotapi.ResetExecution(ctx, execID, robottypes.ExecStatus(req.Status), req.Reason)
+	if err != nil {
+		if errors.Is(err, robottypes.ErrInvalidReset) {
+			errorResp := &response.ErrorResponse{
+				Code:             response.ErrInvalidRequest.Code,
+				ErrorDescription: err.Error(),
+			}
+			response.RespondWithError(c, response.StatusConflict, errorResp)
+			return
+		}
+		log.Error("Failed to reset execution %s: %v", execID, err)
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrServerError.Code,
+			ErrorDescription: "Failed to reset execution: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusInternalServerError, errorResp)
+		return
+	}
+
+	response.RespondWithSuccess(c, response.StatusOK, NewExecutionResponseFromExecution(exec))
+}
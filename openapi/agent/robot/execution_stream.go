@@ -0,0 +1,48 @@
+package robot
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/kun/log"
+	robotapi "github.com/yaoapp/yao/agent/robot/api"
+)
+
+// StreamExecution streams the live events of an execution over SSE, one `data:` line
+// per event, ending with {"type":"done"} once the execution is terminal
+// GET /v1/agent/robots/:id/executions/:exec_id/stream
+func StreamExecution(c *gin.Context) {
+	ctx, execID, ok := bindExecutionRequest(c, false)
+	if !ok {
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream;charset=utf-8")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	w := c.Writer
+	flusher, ok := w.(interface{ Flush() })
+	if !ok {
+		log.Error("ResponseWriter does not support Flush")
+		return
+	}
+
+	send := func(entry *robotapi.ExecutionStreamEvent) error {
+		raw, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "data: %s\n\n", raw); err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	}
+
+	if err := robotapi.StreamExecution(ctx, execID, send); err != nil {
+		log.Warn("Execution stream %s ended: %v", execID, err)
+	}
+}
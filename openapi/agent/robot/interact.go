@@ -21,6 +21,7 @@ type InteractRequest struct {
 	Source      string `json:"source,omitempty"`
 	Message     string `json:"message" binding:"required"`
 	Action      string `json:"action,omitempty"`
+	Force       bool   `json:"force,omitempty"` // with action "cancel": also cancel a running execution
 	Stream      bool   `json:"stream,omitempty"`
 }
 
@@ -110,6 +111,7 @@ func InteractRobot(c *gin.Context) {
 		Source:      robottypes.InteractSource(req.Source),
 		Message:     req.Message,
 		Action:      req.Action,
+		Force:       req.Force,
 	}
 
 	// Detect SSE mode: request body stream=true or Accept header
@@ -176,6 +178,14 @@ func interactSSE(c *gin.Context, ctx *robottypes.Context, robotID string, apiReq
 	}
 
 	result, err := robotapi.InteractStreamRaw(ctx, robotID, apiReq, onMessage)
+	if errors.Is(err, robottypes.ErrInteractCancelled) {
+		// The client hung up (nginx's 499): there is nobody to write to
+		if !c.Writer.Written() {
+			c.Status(499)
+		}
+		log.Info("Robot %s interaction stream closed by client", robotID)
+		return
+	}
 	if err != nil {
 		writeData(&message.Message{
 			Type: message.TypeError,
@@ -0,0 +1,147 @@
+package robot
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/kun/log"
+	robotapi "github.com/yaoapp/yao/agent/robot/api"
+	robottypes "github.com/yaoapp/yao/agent/robot/types"
+	"github.com/yaoapp/yao/openapi/oauth/authorized"
+	"github.com/yaoapp/yao/openapi/response"
+)
+
+// ==================== Plan Editing Handlers ====================
+// Structured alternative to the Host Agent "adjust" conversation: while an execution
+// is confirming, its goals and tasks can be fetched and replaced directly.
+
+// GetExecutionPlan returns the editable plan of a confirming execution
+// GET /v1/agent/robots/:id/executions/:exec_id/plan
+func GetExecutionPlan(c *gin.Context) {
+	ctx, execID, ok := bindExecutionRequest(c, false)
+	if !ok {
+		return
+	}
+
+	plan, err := robotapi.GetExecutionPlan(ctx, execID)
+	if err != nil {
+		respondPlanError(c, execID, err)
+		return
+	}
+
+	response.RespondWithSuccess(c, response.StatusOK, plan)
+}
+
+// UpdateExecutionPlan replaces the plan of a confirming execution
+// PUT /v1/agent/robots/:id/executions/:exec_id/plan
+func UpdateExecutionPlan(c *gin.Context) {
+	var req ExecutionPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Invalid request body: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	ctx, execID, ok := bindExecutionRequest(c, true)
+	if !ok {
+		return
+	}
+
+	plan, err := robotapi.UpdateExecutionPlan(ctx, execID, &robottypes.ExecutionPlan{
+		ExecutionID: execID,
+		Version:     *req.Version,
+		Goals:       req.Goals,
+		Tasks:       req.Tasks,
+	})
+	if err != nil {
+		respondPlanError(c, execID, err)
+		return
+	}
+
+	response.RespondWithSuccess(c, response.StatusOK, plan)
+}
+
+// bindExecutionRequest checks authentication, robot permission and that the execution belongs to the robot
+func bindExecutionRequest(c *gin.Context, write bool) (*robottypes.Context, string, bool) {
+	authInfo := authorized.GetInfo(c)
+	if authInfo == nil || (authInfo.Subject == "" && authInfo.UserID == "") {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidToken.Code,
+			ErrorDescription: "Authentication required",
+		}
+		response.RespondWithError(c, response.StatusUnauthorized, errorResp)
+		return nil, "", false
+	}
+
+	robotID := c.Param("id")
+	execID := c.Param("exec_id")
+	if robotID == "" || execID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "robot id and execution id are required",
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return nil, "", false
+	}
+
+	ctx := robottypes.NewContext(c.Request.Context(), authInfo)
+	robotResp, err := robotapi.GetRobotResponse(ctx, robotID)
+	if err != nil {
+		handleRobotError(c, robotID, err)
+		return nil, "", false
+	}
+
+	allowed := CanRead(c, authInfo, robotResp.YaoTeamID, robotResp.YaoCreatedBy)
+	if write {
+		allowed = CanWrite(c, authInfo, robotResp.YaoTeamID, robotResp.YaoCreatedBy)
+	}
+	if !allowed {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrAccessDenied.Code,
+			ErrorDescription: "Forbidden: No permission to edit this robot's plans",
+		}
+		if !write {
+			errorResp.ErrorDescription = "Forbidden: No permission to access this robot's executions"
+		}
+		response.RespondWithError(c, response.StatusForbidden, errorResp)
+		return nil, "", false
+	}
+
+	exec, err := robotapi.GetExecution(ctx, execID)
+	if err != nil || exec.MemberID != robotID {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Execution not found: " + execID,
+		}
+		response.RespondWithError(c, response.StatusNotFound, errorResp)
+		return nil, "", false
+	}
+
+	return ctx, execID, true
+}
+
+// respondPlanError maps plan editing errors to HTTP responses
+func respondPlanError(c *gin.Context, execID string, err error) {
+	status := response.StatusInternalServerError
+	code := response.ErrServerError.Code
+	switch {
+	case errors.Is(err, robottypes.ErrPlanVersionConflict), errors.Is(err, robottypes.ErrPlanNotEditable):
+		status, code = response.StatusConflict, response.ErrInvalidRequest.Code
+	case errors.Is(err, robottypes.ErrInvalidPlan):
+		status, code = response.StatusBadRequest, response.ErrInvalidRequest.Code
+	case strings.Contains(err.Error(), "not found"):
+		status, code = response.StatusNotFound, response.ErrInvalidRequest.Code
+	default:
+		log.Error("Failed to edit plan of execution %s: %v", execID, err)
+	}
+
+	errorResp := &response.ErrorResponse{
+		Code:             code,
+		ErrorDescription: err.Error(),
+	}
+	response.RespondWithError(c, status, errorResp)
+}
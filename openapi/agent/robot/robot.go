@@ -16,8 +16,8 @@ func Attach(group *gin.RouterGroup, oauth types.OAuth) {
 	group.Use(oauth.Guard)
 
 	// Robot CRUD - Standard REST endpoints
-	group.GET("", ListAllRobots) // GET /robots - List robots with pagination and filtering
-	group.POST("", CreateRobot)  // POST /robots - Create a new robot
+	group.GET("", ListAllRobots)                   // GET /robots - List robots with pagination and filtering
+	group.POST("", robotPayloadLimit, CreateRobot) // POST /robots - Create a new robot
 
 	// Activities - Cross-robot activity feed for team (must be before /:id to avoid conflict)
 	group.GET("/activities", ListActivities) // GET /robots/activities - List team activities
@@ -29,19 +29,25 @@ func Attach(group *gin.RouterGroup, oauth types.OAuth) {
 	group.POST("/integrations/weixin/qrcode", CreateWeixinQRCode)           // POST /robots/integrations/weixin/qrcode - Create QR session
 	group.GET("/integrations/weixin/qrcode/:session_key", PollWeixinQRCode) // GET  /robots/integrations/weixin/qrcode/:session_key - Poll QR status
 
-	group.GET("/:id", GetRobot)       // GET /robots/:id - Get robot details
-	group.PUT("/:id", UpdateRobot)    // PUT /robots/:id - Update robot
-	group.DELETE("/:id", DeleteRobot) // DELETE /robots/:id - Delete robot
+	group.GET("/:id", GetRobot)                       // GET /robots/:id - Get robot details
+	group.PUT("/:id", robotPayloadLimit, UpdateRobot) // PUT /robots/:id - Update robot
+	group.DELETE("/:id", DeleteRobot)                 // DELETE /robots/:id - Delete robot
 
 	// Robot Status
 	group.GET("/:id/status", GetRobotStatus) // GET /robots/:id/status - Get robot runtime status
 
 	// Execution Management
-	group.GET("/:id/executions", ListExecutions)                   // GET /robots/:id/executions - List robot executions
-	group.GET("/:id/executions/:exec_id", GetExecution)            // GET /robots/:id/executions/:exec_id - Get execution details
-	group.POST("/:id/executions/:exec_id/pause", PauseExecution)   // POST /robots/:id/executions/:exec_id/pause - Pause execution
-	group.POST("/:id/executions/:exec_id/resume", ResumeExecution) // POST /robots/:id/executions/:exec_id/resume - Resume execution
-	group.POST("/:id/executions/:exec_id/cancel", CancelExecution) // POST /robots/:id/executions/:exec_id/cancel - Cancel execution
+	group.GET("/:id/executions", ListExecutions)                    // GET /robots/:id/executions - List robot executions
+	group.GET("/:id/executions/:exec_id", GetExecution)             // GET /robots/:id/executions/:exec_id - Get execution details
+	group.POST("/:id/executions/:exec_id/pause", PauseExecution)    // POST /robots/:id/executions/:exec_id/pause - Pause execution
+	group.POST("/:id/executions/:exec_id/resume", ResumeExecution)  // POST /robots/:id/executions/:exec_id/resume - Resume execution
+	group.POST("/:id/executions/:exec_id/cancel", CancelExecution)  // POST /robots/:id/executions/:exec_id/cancel - Cancel execution
+	group.POST("/:id/executions/:exec_id/notes", AddExecutionNote)  // POST /robots/:id/executions/:exec_id/notes - Add operator note
+	group.POST("/:id/executions/:exec_id/reset", ResetExecution)    // POST /robots/:id/executions/:exec_id/reset - Force a stuck execution into failed/cancelled
+	group.GET("/:id/executions/:exec_id/plan", GetExecutionPlan)    // GET /robots/:id/executions/:exec_id/plan - Get editable plan (confirming only)
+	group.PUT("/:id/executions/:exec_id/plan", UpdateExecutionPlan) // PUT /robots/:id/executions/:exec_id/plan - Replace plan (confirming only)
+	group.GET("/:id/executions/:exec_id/explain", ExplainExecution) // GET /robots/:id/executions/:exec_id/explain - Plain-language summary (?locale=)
+	group.GET("/:id/executions/:exec_id/stream", StreamExecution)   // GET /robots/:id/executions/:exec_id/stream - Live events (SSE) until terminal
 
 	// Results (Deliveries) - Completed executions with delivery content
 	group.GET("/:id/results", ListResults)          // GET /robots/:id/results - List robot results
@@ -20,6 +20,7 @@ type CreateRobotRequest struct {
 	DisplayName string `json:"display_name" binding:"required"` // Display name
 	Bio         string `json:"bio,omitempty"`                   // Robot description
 	Avatar      string `json:"avatar,omitempty"`                // Avatar URL
+	Timezone    string `json:"timezone,omitempty"`              // IANA timezone for schedules and timestamps
 
 	// Identity & Role
 	SystemPrompt string `json:"system_prompt,omitempty"` // System prompt
@@ -53,6 +54,7 @@ type UpdateRobotRequest struct {
 	DisplayName *string `json:"display_name,omitempty"` // Display name
 	Bio         *string `json:"bio,omitempty"`          // Robot description
 	Avatar      *string `json:"avatar,omitempty"`       // Avatar URL
+	Timezone    *string `json:"timezone,omitempty"`     // IANA timezone (""=server timezone)
 
 	// Identity & Role
 	SystemPrompt *string `json:"system_prompt,omitempty"` // System prompt
@@ -101,6 +103,7 @@ type Response struct {
 	DisplayName string `json:"display_name"`
 	Bio         string `json:"bio,omitempty"`
 	Avatar      string `json:"avatar,omitempty"`
+	Timezone    string `json:"timezone,omitempty"`
 
 	// Identity & Role
 	SystemPrompt string `json:"system_prompt,omitempty"`
@@ -179,6 +182,7 @@ func NewResponse(r *robotapi.RobotResponse) *Response {
 		DisplayName:       r.DisplayName,
 		Bio:               r.Bio,
 		Avatar:            r.Avatar,
+		Timezone:          r.Timezone,
 		SystemPrompt:      r.SystemPrompt,
 		RoleID:            r.RoleID,
 		ManagerID:         r.ManagerID,
@@ -206,6 +210,7 @@ func (r *CreateRobotRequest) ToAPICreateRequest() *robotapi.CreateRobotRequest {
 		DisplayName:       r.DisplayName,
 		Bio:               r.Bio,
 		Avatar:            r.Avatar,
+		Timezone:          r.Timezone,
 		SystemPrompt:      r.SystemPrompt,
 		RoleID:            r.RoleID,
 		ManagerID:         r.ManagerID,
@@ -230,6 +235,7 @@ func (r *UpdateRobotRequest) ToAPIUpdateRequest() *robotapi.UpdateRobotRequest {
 		DisplayName:       r.DisplayName,
 		Bio:               r.Bio,
 		Avatar:            r.Avatar,
+		Timezone:          r.Timezone,
 		SystemPrompt:      r.SystemPrompt,
 		RoleID:            r.RoleID,
 		ManagerID:         r.ManagerID,
@@ -276,6 +282,8 @@ type ExecutionFilter struct {
 	ExcludeStatus string `form:"exclude_status"` // comma-separated statuses to exclude, e.g. "confirming,waiting"
 	TriggerType   string `form:"trigger_type"`   // clock | human | event
 	Keyword       string `form:"keyword"`        // search in execution details
+	From          string `form:"from"`           // RFC3339, started at or after
+	To            string `form:"to"`             // RFC3339, started at or before
 	Page          int    `form:"page"`
 	PageSize      int    `form:"pagesize"`
 }
@@ -292,6 +300,9 @@ type ExecutionResponse struct {
 	EndTime     *time.Time `json:"end_time,omitempty"`
 	Error       string     `json:"error,omitempty"`
 
+	// Delivery outcome: pending | success | partial | failed | skipped
+	DeliveryStatus string `json:"delivery_status,omitempty"`
+
 	// UI display fields (updated by executor at each phase)
 	Name            string `json:"name,omitempty"`              // Execution title
 	CurrentTaskName string `json:"current_task_name,omitempty"` // Current task description
@@ -306,6 +317,14 @@ type ExecutionResponse struct {
 
 	// Input (optional, included in detail view)
 	Input interface{} `json:"input,omitempty"`
+
+	// Operator notes and plan edits (detail view)
+	Notes     []robottypes.ExecutionNote `json:"notes,omitempty"`
+	PlanEdits []robottypes.PlanEdit      `json:"plan_edits,omitempty"`
+
+	// Cancellation details (cancelled executions only)
+	CancelReason string `json:"cancel_reason,omitempty"`
+	CancelledBy  string `json:"cancelled_by,omitempty"`
 }
 
 // ExecutionListResponse - paginated list response
@@ -316,6 +335,13 @@ type ExecutionListResponse struct {
 	PageSize int                  `json:"pagesize"`
 }
 
+// ExecutionCancelRequest - optional body of POST .../cancel
+type ExecutionCancelRequest struct {
+	Force   bool   `json:"force,omitempty"`   // required to cancel a running execution
+	Cascade bool   `json:"cascade,omitempty"` // also cancel chained continuations
+	Reason  string `json:"reason,omitempty"`
+}
+
 // ExecutionControlResponse - response for pause/resume/cancel
 type ExecutionControlResponse struct {
 	ExecutionID string `json:"execution_id"`
@@ -324,6 +350,31 @@ type ExecutionControlResponse struct {
 	Message     string `json:"message,omitempty"`
 }
 
+// ExecutionNoteRequest - request body for adding an operator note
+type ExecutionNoteRequest struct {
+	Note string `json:"note" binding:"required"`
+}
+
+// ExecutionNotesResponse - notes of an execution after an append
+type ExecutionNotesResponse struct {
+	ExecutionID string                     `json:"execution_id"`
+	Notes       []robottypes.ExecutionNote `json:"notes"`
+}
+
+// ExecutionResetRequest - request body for forcing a stuck execution into a final status
+type ExecutionResetRequest struct {
+	Status string `json:"status" binding:"required"` // failed | cancelled
+	Reason string `json:"reason,omitempty"`
+}
+
+// ExecutionPlanRequest - request body for replacing the plan of a confirming execution.
+// Version is the plan version the edit is based on; goals may be omitted to keep them.
+type ExecutionPlanRequest struct {
+	Version *int              `json:"version" binding:"required"`
+	Goals   *robottypes.Goals `json:"goals,omitempty"`
+	Tasks   []robottypes.Task `json:"tasks" binding:"required"`
+}
+
 // ==================== Trigger Types ====================
 
 // TriggerRequest - HTTP request to trigger robot execution
@@ -414,6 +465,8 @@ func NewExecutionResponseFromExecution(exec *robottypes.Execution) *ExecutionRes
 		StartTime:   exec.StartTime,
 		EndTime:     exec.EndTime,
 		Error:       exec.Error,
+		// Delivery outcome
+		DeliveryStatus: string(exec.DeliveryStatus),
 		// UI display fields
 		Name:            exec.Name,
 		CurrentTaskName: exec.CurrentTaskName,
@@ -425,6 +478,11 @@ func NewExecutionResponseFromExecution(exec *robottypes.Execution) *ExecutionRes
 		Results:     exec.Results,
 		Delivery:    exec.Delivery,
 		Input:       exec.Input,
+		Notes:       exec.Notes,
+		PlanEdits:   exec.PlanEdits,
+		// Cancellation details
+		CancelReason: exec.CancelReason,
+		CancelledBy:  exec.CancelledBy,
 	}
 }
 
@@ -466,6 +524,8 @@ func NewExecutionResponseBrief(exec *robottypes.Execution) *ExecutionResponse {
 		StartTime:   exec.StartTime,
 		EndTime:     exec.EndTime,
 		Error:       exec.Error,
+		// Delivery outcome, so lists can flag completed runs whose report never went out
+		DeliveryStatus: string(exec.DeliveryStatus),
 		// UI display fields - include in list view for display
 		Name:            exec.Name,
 		CurrentTaskName: exec.CurrentTaskName,
@@ -38,6 +38,8 @@ func (config *Config) MarshalJSON() ([]byte, error) {
 		Store:     config.Store,
 		Cache:     config.Cache,
 		Providers: config.Providers,
+
+		Encryption: config.Encryption,
 	}
 
 	if config.OAuth != nil {
@@ -129,6 +131,7 @@ func (config *Config) UnmarshalJSON(data []byte) error {
 	config.Store = tempConfig.Store
 	config.Cache = tempConfig.Cache
 	config.Providers = tempConfig.Providers
+	config.Encryption = tempConfig.Encryption
 
 	if tempConfig.OAuth != nil {
 		config.OAuth = &OAuth{
@@ -352,11 +355,21 @@ func (config *Config) OAuthConfig(appConfig config.Config) (*oauth.Config, error
 		return nil, err
 	}
 
+	// Optional encryption at rest for member columns
+	var memberCipher *user.FieldCipher
+	if config.Encryption != nil {
+		memberCipher, err = user.NewFieldCipher(config.Encryption.Key, config.Encryption.MemberFields)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Create the User provider
 	userProvider := user.NewDefaultUser(&user.DefaultUserOptions{
-		Prefix: prefix,
-		Model:  string(providers.User),
-		Cache:  cacheStore,
+		Prefix:            prefix,
+		Model:             string(providers.User),
+		Cache:             cacheStore,
+		MemberFieldCipher: memberCipher,
 	})
 
 	// Create the Client provider
@@ -1,6 +1,8 @@
 package user
 
 import (
+	"errors"
+
 	"github.com/yaoapp/gou/store"
 	"github.com/yaoapp/yao/openapi/oauth/types"
 )
@@ -13,6 +15,7 @@ const (
 	ErrOAuthAccountNotFound     = "oauth account not found"
 	ErrTeamNotFound             = "team not found"
 	ErrMemberNotFound           = "member not found"
+	ErrLanguageModelNotAllowed  = "language model %q is not allowed for this team"
 	ErrInvalidIdentifierType    = "invalid identifier type: %s"
 	ErrNoPasswordHash           = "no password hash found"
 	ErrFailedToGenerateUserID   = "failed to generate user_id: %w"
@@ -65,6 +68,14 @@ const (
 	ErrRecoveryCodeNotFound      = "recovery code not found or already used"
 )
 
+// Member errors callers tell apart with errors.Is
+var (
+	ErrInvitationAccepted   = errors.New("invitation already accepted")
+	ErrInvitationNotPending = errors.New("invitation is no longer pending")
+	ErrInvalidNewOwner      = errors.New("invalid new owner")
+	ErrInvalidNewManager    = errors.New("invalid new manager")
+)
+
 // Default field lists - used when not configured
 var (
 	// DefaultPublicUserFields contains fields that can be safely returned to users
@@ -157,17 +168,17 @@ var (
 
 	// DefaultMemberFields contains basic member fields
 	DefaultMemberFields = []interface{}{
-		"member_id", "team_id", "user_id", "member_type", "display_name", "bio", "avatar", "email", "robot_email", "role_id", "is_owner", "status",
+		"member_id", "team_id", "user_id", "member_type", "display_name", "bio", "avatar", "email", "timezone", "external_provider", "external_id", "robot_email", "role_id", "is_owner", "status", "status_reason",
 		"invitation_id", "invited_by", "invited_at", "joined_at", "invitation_token", "invitation_expires_at",
 		"last_active_at", "login_count", "created_at", "updated_at",
 	}
 
 	// DefaultMemberDetailFields contains all member fields including robot config
 	DefaultMemberDetailFields = []interface{}{
-		"member_id", "team_id", "user_id", "member_type", "display_name", "bio", "avatar", "email", "role_id", "is_owner", "status",
+		"member_id", "team_id", "user_id", "member_type", "display_name", "bio", "avatar", "email", "timezone", "external_provider", "external_id", "role_id", "is_owner", "status", "status_reason",
 		"system_prompt", "manager_id", "robot_email", "authorized_senders", "email_filter_rules",
 		"robot_config", "agents", "mcp_servers",
-		"language_model", "workspace", "cost_limit", "autonomous_mode", "last_robot_activity", "robot_status",
+		"language_model", "workspace", "cost_limit", "autonomous_mode", "last_robot_activity", "next_run_at", "cooldown_until", "robot_status",
 		"invitation_id", "invited_by", "invited_at", "joined_at", "invitation_token",
 		"invitation_expires_at", "last_active_at",
 		"login_count", "notes", "metadata", "created_at", "updated_at",
@@ -194,6 +205,7 @@ type DefaultUser struct {
 	oauthAccountModel string
 	teamModel         string
 	memberModel       string
+	memberAuditModel  string
 	invitationModel   string
 	cache             store.Store
 
@@ -250,6 +262,7 @@ type DefaultUserOptions struct {
 	OAuthAccountModel string // bind to a specific oauth account model
 	TeamModel         string // bind to a specific team model
 	MemberModel       string // bind to a specific member model
+	MemberAuditModel  string // bind to a specific member audit model
 	InvitationModel   string // bind to a specific invitation code model
 	Cache             store.Store
 
@@ -282,6 +295,9 @@ type DefaultUserOptions struct {
 	MemberFields       []interface{} // basic member fields
 	MemberDetailFields []interface{} // detailed member fields including robot config and permissions
 
+	// Member column encryption at rest (nil keeps the columns in plaintext)
+	MemberFieldCipher *FieldCipher
+
 	// MFA configuration (use defaults if not specified)
 	MFAOptions *types.MFAOptions // MFA settings
 }
@@ -319,6 +335,11 @@ func NewDefaultUser(options *DefaultUserOptions) *DefaultUser {
 		memberModel = "__yao.member"
 	}
 
+	memberAuditModel := options.MemberAuditModel
+	if memberAuditModel == "" {
+		memberAuditModel = "__yao.member.audit"
+	}
+
 	invitationModel := options.InvitationModel
 	if invitationModel == "" {
 		invitationModel = "__yao.invitation"
@@ -405,6 +426,12 @@ func NewDefaultUser(options *DefaultUserOptions) *DefaultUser {
 		mfaOptions = DefaultMFAOptions
 	}
 
+	// Member columns are also read and written outside the provider (robot store/cache),
+	// so the cipher is registered package-wide
+	if options.MemberFieldCipher != nil {
+		SetMemberFieldCipher(options.MemberFieldCipher)
+	}
+
 	return &DefaultUser{
 		prefix:            options.Prefix,
 		model:             model,
@@ -413,6 +440,7 @@ func NewDefaultUser(options *DefaultUserOptions) *DefaultUser {
 		oauthAccountModel: oauthAccountModel,
 		teamModel:         teamModel,
 		memberModel:       memberModel,
+		memberAuditModel:  memberAuditModel,
 		invitationModel:   invitationModel,
 		cache:             options.Cache,
 		idStrategy:        idStrategy,
@@ -0,0 +1,269 @@
+package user
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/yaoapp/kun/maps"
+)
+
+// encryptedValuePrefix marks a column value encrypted at rest (AES-256-GCM, base64(nonce|ciphertext))
+const encryptedValuePrefix = "enc:v1:"
+
+// memberJSONColumns are the json-typed columns of the member model. Their ciphertext is
+// stored as a JSON string ("\"enc:v1:...\"") so the column still holds valid JSON: strict
+// JSON columns accept the write and the model's json cast yields the ciphertext on read.
+var memberJSONColumns = map[string]bool{
+	"authorized_senders": true,
+	"email_filter_rules": true,
+	"robot_config":       true,
+	"agents":             true,
+	"mcp_servers":        true,
+	"metadata":           true,
+}
+
+// FieldCipher encrypts and decrypts a configured set of member columns.
+// Values are JSON-encoded before encryption so JSON columns (e.g. robot_config)
+// round-trip with their original type.
+type FieldCipher struct {
+	aead   cipher.AEAD
+	fields map[string]bool
+}
+
+var (
+	memberCipher   *FieldCipher
+	memberCipherMu sync.RWMutex
+)
+
+// NewFieldCipher creates a cipher for the given columns. The key may be any
+// non-empty secret; it is stretched to 32 bytes with SHA-256.
+// Returns nil (encryption disabled) when no fields are configured.
+func NewFieldCipher(key string, fields []string) (*FieldCipher, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	if key == "" {
+		return nil, fmt.Errorf("encryption key is required when encrypted member fields are configured")
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	set := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		if f = strings.TrimSpace(f); f != "" {
+			set[f] = true
+		}
+	}
+	return &FieldCipher{aead: aead, fields: set}, nil
+}
+
+// SetMemberFieldCipher sets the cipher used for member columns (nil disables encryption)
+func SetMemberFieldCipher(c *FieldCipher) {
+	memberCipherMu.Lock()
+	defer memberCipherMu.Unlock()
+	memberCipher = c
+}
+
+// getMemberFieldCipher returns the active member cipher, nil if encryption is disabled
+func getMemberFieldCipher() *FieldCipher {
+	memberCipherMu.RLock()
+	defer memberCipherMu.RUnlock()
+	return memberCipher
+}
+
+// IsEncryptedMemberField reports whether the active member cipher encrypts field at rest
+func IsEncryptedMemberField(field string) bool {
+	c := getMemberFieldCipher()
+	return c != nil && c.fields[field]
+}
+
+// EncryptMemberData encrypts the configured member columns of a row about to be written.
+// Values carrying the ciphertext prefix are rejected in every column, whether encryption
+// is enabled or not: written as-is they would be read back as ciphertext.
+func EncryptMemberData(data map[string]interface{}) error {
+	if data == nil {
+		return nil
+	}
+	for field, v := range data {
+		if s, ok := v.(string); ok {
+			if _, encrypted := encryptedValue(s); encrypted {
+				return fmt.Errorf("invalid member field %s: values starting with %q are reserved", field, encryptedValuePrefix)
+			}
+		}
+	}
+
+	c := getMemberFieldCipher()
+	if c == nil {
+		return nil
+	}
+	for field := range c.fields {
+		v, ok := data[field]
+		if !ok || v == nil {
+			continue
+		}
+		enc, err := c.encrypt(v)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt member field %s: %w", field, err)
+		}
+		if memberJSONColumns[field] {
+			quoted, err := json.Marshal(enc)
+			if err != nil {
+				return fmt.Errorf("failed to encrypt member field %s: %w", field, err)
+			}
+			enc = string(quoted)
+		}
+		data[field] = enc
+	}
+	return nil
+}
+
+// DecryptMemberData decrypts the configured member columns of a row read from the database.
+// No-op when encryption is disabled. Plaintext values (written before encryption was enabled)
+// are returned as-is. An encrypted value the active cipher can't open is an error: the row
+// must not be used with ciphertext in place of its data.
+func DecryptMemberData(row map[string]interface{}) error {
+	c := getMemberFieldCipher()
+	if c == nil || row == nil {
+		return nil
+	}
+	for field := range c.fields {
+		s, ok := row[field].(string)
+		if !ok {
+			continue
+		}
+		s, encrypted := encryptedValue(s)
+		if !encrypted {
+			continue
+		}
+		v, err := c.decrypt(s)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt member field %s: %w", field, err)
+		}
+		row[field] = v
+	}
+	return nil
+}
+
+// encryptMemberFields is the provider-side write hook
+func (u *DefaultUser) encryptMemberFields(data maps.MapStrAny) error {
+	return EncryptMemberData(data)
+}
+
+// decryptMemberFields is the provider-side read hook
+func (u *DefaultUser) decryptMemberFields(row maps.MapStrAny) error {
+	return DecryptMemberData(row)
+}
+
+// decryptMemberRows decrypts every row of a query result
+func (u *DefaultUser) decryptMemberRows(rows []maps.MapStr) error {
+	for _, row := range rows {
+		if err := DecryptMemberData(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decryptPaginatedMembers decrypts the rows of a paginated result in place
+func (u *DefaultUser) decryptPaginatedMembers(result maps.MapStr) error {
+	switch rows := result["data"].(type) {
+	case []maps.MapStr:
+		return u.decryptMemberRows(rows)
+	case []maps.MapStrAny:
+		for _, row := range rows {
+			if err := DecryptMemberData(row); err != nil {
+				return err
+			}
+		}
+	case []map[string]interface{}:
+		for _, row := range rows {
+			if err := DecryptMemberData(row); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, item := range rows {
+			var err error
+			switch row := item.(type) {
+			case maps.MapStr:
+				err = DecryptMemberData(row)
+			case maps.MapStrAny:
+				err = DecryptMemberData(row)
+			case map[string]interface{}:
+				err = DecryptMemberData(row)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// encryptedValue reports whether s is an encrypted column value, returning it without the
+// JSON string quoting of json columns read as raw text
+func encryptedValue(s string) (string, bool) {
+	if strings.HasPrefix(s, encryptedValuePrefix) {
+		return s, true
+	}
+	if strings.HasPrefix(s, `"`+encryptedValuePrefix) {
+		var unquoted string
+		if err := json.Unmarshal([]byte(s), &unquoted); err == nil {
+			return unquoted, true
+		}
+	}
+	return s, false
+}
+
+func (c *FieldCipher) encrypt(v interface{}) (string, error) {
+	plain, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := c.aead.Seal(nonce, nonce, plain, nil)
+	return encryptedValuePrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (c *FieldCipher) decrypt(s string) (interface{}, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(s, encryptedValuePrefix))
+	if err != nil {
+		return nil, err
+	}
+
+	size := c.aead.NonceSize()
+	if len(raw) < size {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+
+	plain, err := c.aead.Open(nil, raw[:size], raw[size:], nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(plain, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
@@ -0,0 +1,207 @@
+package user_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/kun/maps"
+	"github.com/yaoapp/xun/capsule"
+	"github.com/yaoapp/yao/openapi/oauth/providers/user"
+)
+
+func TestNewFieldCipher(t *testing.T) {
+	// No fields configured: encryption disabled
+	c, err := user.NewFieldCipher("secret", nil)
+	assert.NoError(t, err)
+	assert.Nil(t, c)
+
+	// Fields without a key are rejected
+	_, err = user.NewFieldCipher("", []string{"robot_config"})
+	assert.Error(t, err)
+
+	c, err = user.NewFieldCipher("secret", []string{"robot_config"})
+	assert.NoError(t, err)
+	assert.NotNil(t, c)
+}
+
+func TestMemberDataEncryption(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		user.SetMemberFieldCipher(nil)
+		data := map[string]interface{}{"robot_config": map[string]interface{}{"api_key": "sk-1"}}
+		assert.NoError(t, user.EncryptMemberData(data))
+		assert.Equal(t, map[string]interface{}{"api_key": "sk-1"}, data["robot_config"])
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		c, err := user.NewFieldCipher("secret", []string{"robot_config", "system_prompt"})
+		require.NoError(t, err)
+		user.SetMemberFieldCipher(c)
+		defer user.SetMemberFieldCipher(nil)
+
+		data := map[string]interface{}{
+			"robot_config":  map[string]interface{}{"api_key": "sk-1"},
+			"system_prompt": "be nice",
+			"display_name":  "Bot",
+		}
+		require.NoError(t, user.EncryptMemberData(data))
+
+		// json columns hold the ciphertext as a JSON string, text columns as is
+		enc, ok := data["robot_config"].(string)
+		require.True(t, ok)
+		assert.True(t, strings.HasPrefix(enc, `"enc:v1:`))
+		assert.True(t, json.Valid([]byte(enc)))
+		assert.NotContains(t, enc, "sk-1")
+		assert.True(t, strings.HasPrefix(data["system_prompt"].(string), "enc:v1:"))
+		assert.Equal(t, "Bot", data["display_name"])
+
+		// Ciphertext is never accepted as input, so it can't be encrypted twice
+		assert.Error(t, user.EncryptMemberData(data))
+
+		require.NoError(t, user.DecryptMemberData(data))
+		assert.Equal(t, map[string]interface{}{"api_key": "sk-1"}, data["robot_config"])
+		assert.Equal(t, "be nice", data["system_prompt"])
+
+		// The json cast of a json column on read unquotes the ciphertext
+		data = map[string]interface{}{"robot_config": map[string]interface{}{"api_key": "sk-1"}}
+		require.NoError(t, user.EncryptMemberData(data))
+		var cast interface{}
+		require.NoError(t, json.Unmarshal([]byte(data["robot_config"].(string)), &cast))
+		row := map[string]interface{}{"robot_config": cast}
+		require.NoError(t, user.DecryptMemberData(row))
+		assert.Equal(t, map[string]interface{}{"api_key": "sk-1"}, row["robot_config"])
+	})
+
+	t.Run("input carrying the prefix is rejected", func(t *testing.T) {
+		user.SetMemberFieldCipher(nil)
+		err := user.EncryptMemberData(map[string]interface{}{"bio": "enc:v1:not-really"})
+		assert.Error(t, err)
+
+		c, err := user.NewFieldCipher("secret", []string{"system_prompt"})
+		require.NoError(t, err)
+		user.SetMemberFieldCipher(c)
+		defer user.SetMemberFieldCipher(nil)
+		assert.Error(t, user.EncryptMemberData(map[string]interface{}{"system_prompt": "enc:v1:forged"}))
+		assert.Error(t, user.EncryptMemberData(map[string]interface{}{"robot_config": `"enc:v1:forged"`}))
+	})
+
+	t.Run("only configured columns are decrypted", func(t *testing.T) {
+		user.SetMemberFieldCipher(nil)
+		row := map[string]interface{}{"bio": "enc:v1:looks like ciphertext"}
+		assert.NoError(t, user.DecryptMemberData(row), "disabled encryption reads rows as stored")
+		assert.Equal(t, "enc:v1:looks like ciphertext", row["bio"])
+
+		c, err := user.NewFieldCipher("secret", []string{"system_prompt"})
+		require.NoError(t, err)
+		user.SetMemberFieldCipher(c)
+		defer user.SetMemberFieldCipher(nil)
+		assert.NoError(t, user.DecryptMemberData(row))
+		assert.Equal(t, "enc:v1:looks like ciphertext", row["bio"])
+	})
+
+	t.Run("plaintext rows pass through", func(t *testing.T) {
+		c, err := user.NewFieldCipher("secret", []string{"system_prompt"})
+		require.NoError(t, err)
+		user.SetMemberFieldCipher(c)
+		defer user.SetMemberFieldCipher(nil)
+
+		row := map[string]interface{}{"system_prompt": "legacy"}
+		assert.NoError(t, user.DecryptMemberData(row))
+		assert.Equal(t, "legacy", row["system_prompt"])
+	})
+
+	t.Run("wrong key fails", func(t *testing.T) {
+		c, err := user.NewFieldCipher("secret", []string{"system_prompt"})
+		require.NoError(t, err)
+		user.SetMemberFieldCipher(c)
+		data := map[string]interface{}{"system_prompt": "hidden"}
+		require.NoError(t, user.EncryptMemberData(data))
+
+		other, err := user.NewFieldCipher("another", []string{"system_prompt"})
+		require.NoError(t, err)
+		user.SetMemberFieldCipher(other)
+		defer user.SetMemberFieldCipher(nil)
+		assert.Error(t, user.DecryptMemberData(data))
+	})
+}
+
+func TestRobotMemberEncryptionAtRest(t *testing.T) {
+	prepare(t)
+	defer clean()
+
+	c, err := user.NewFieldCipher("test-encryption-key", []string{"robot_config", "system_prompt"})
+	require.NoError(t, err)
+	user.SetMemberFieldCipher(c)
+	defer user.SetMemberFieldCipher(nil)
+
+	ctx := context.Background()
+	testUUID := strings.ReplaceAll(uuid.New().String(), "-", "")[:8]
+	ownerUser := createTestUser(ctx, t, "encowner"+testUUID)
+
+	teamID, err := testProvider.CreateTeam(ctx, maps.MapStrAny{
+		"name":     "Encryption Team " + testUUID,
+		"owner_id": ownerUser,
+		"status":   "active",
+		"type":     "corporation",
+		"type_id":  "business",
+	})
+	require.NoError(t, err)
+
+	memberID, err := testProvider.CreateRobotMember(ctx, teamID, maps.MapStrAny{
+		"display_name":  "EncBot" + testUUID,
+		"role_id":       "bot",
+		"system_prompt": "top secret prompt",
+		"robot_config":  map[string]interface{}{"mcp_api_key": "sk-secret"},
+	})
+	require.NoError(t, err)
+
+	// Raw row is encrypted
+	rows, err := model.Select("__yao.member").Get(model.QueryParam{
+		Select: []interface{}{"system_prompt"},
+		Wheres: []model.QueryWhere{{Column: "member_id", Value: memberID}},
+		Limit:  1,
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	raw, _ := rows[0]["system_prompt"].(string)
+	assert.True(t, strings.HasPrefix(raw, "enc:v1:"))
+
+	// Provider reads return plaintext
+	member, err := testProvider.GetMemberDetailByMemberID(ctx, memberID)
+	require.NoError(t, err)
+	assert.Equal(t, "top secret prompt", member["system_prompt"])
+
+	// robot_config round-trips through the json column: the raw column holds valid JSON
+	// without the secret, and provider reads return the original object
+	m := model.Select("__yao.member")
+	rawRow, err := capsule.Query().Table(m.MetaData.Table.Name).
+		Select("robot_config").
+		Where("member_id", memberID).
+		First()
+	require.NoError(t, err)
+	rawConfig := fmt.Sprintf("%s", rawRow["robot_config"])
+	assert.True(t, json.Valid([]byte(rawConfig)), rawConfig)
+	assert.NotContains(t, rawConfig, "sk-secret")
+	assert.Equal(t, map[string]interface{}{"mcp_api_key": "sk-secret"}, member["robot_config"])
+
+	err = testProvider.UpdateRobotMember(ctx, memberID, maps.MapStrAny{
+		"robot_config": map[string]interface{}{"mcp_api_key": "sk-rotated"},
+	})
+	require.NoError(t, err)
+	member, err = testProvider.GetMemberDetailByMemberID(ctx, memberID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"mcp_api_key": "sk-rotated"}, member["robot_config"])
+
+	// Updates are encrypted as well
+	err = testProvider.UpdateRobotMember(ctx, memberID, maps.MapStrAny{"system_prompt": "rotated"})
+	require.NoError(t, err)
+	member, err = testProvider.GetMemberDetailByMemberID(ctx, memberID)
+	require.NoError(t, err)
+	assert.Equal(t, "rotated", member["system_prompt"])
+}
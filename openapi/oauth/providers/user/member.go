@@ -2,17 +2,28 @@ package user
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/yaoapp/gou/model"
 	"github.com/yaoapp/kun/maps"
+	"github.com/yaoapp/xun/capsule"
+	"github.com/yaoapp/yao/openapi/utils"
 )
 
 // Member Resource
 
 // GetMember retrieves member information by team_id and user_id
 func (u *DefaultUser) GetMember(ctx context.Context, teamID string, userID string) (maps.MapStrAny, error) {
+	if err := contextErr(ctx); err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
 	m := model.Select(u.memberModel)
 	members, err := m.Get(model.QueryParam{
 		Select: u.memberFields,
@@ -31,11 +42,19 @@ func (u *DefaultUser) GetMember(ctx context.Context, teamID string, userID strin
 		return nil, fmt.Errorf(ErrMemberNotFound)
 	}
 
+	if err := u.decryptMemberFields(members[0]); err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
 	return members[0], nil
 }
 
 // GetMemberDetail retrieves detailed member information
 func (u *DefaultUser) GetMemberDetail(ctx context.Context, teamID string, userID string) (maps.MapStrAny, error) {
+	if err := contextErr(ctx); err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
 	m := model.Select(u.memberModel)
 	members, err := m.Get(model.QueryParam{
 		Select: u.memberDetailFields,
@@ -54,6 +73,10 @@ func (u *DefaultUser) GetMemberDetail(ctx context.Context, teamID string, userID
 		return nil, fmt.Errorf(ErrMemberNotFound)
 	}
 
+	if err := u.decryptMemberFields(members[0]); err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
 	return members[0], nil
 }
 
@@ -76,6 +99,10 @@ func (u *DefaultUser) GetMemberByID(ctx context.Context, memberID int64) (maps.M
 		return nil, fmt.Errorf(ErrMemberNotFound)
 	}
 
+	if err := u.decryptMemberFields(members[0]); err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
 	return members[0], nil
 }
 
@@ -98,11 +125,19 @@ func (u *DefaultUser) GetMemberByInvitationID(ctx context.Context, invitationID
 		return nil, fmt.Errorf(ErrMemberNotFound)
 	}
 
+	if err := u.decryptMemberFields(members[0]); err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
 	return members[0], nil
 }
 
 // GetMemberByMemberID retrieves member information by member_id (business ID)
 func (u *DefaultUser) GetMemberByMemberID(ctx context.Context, memberID string) (maps.MapStrAny, error) {
+	if err := contextErr(ctx); err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
 	m := model.Select(u.memberModel)
 	members, err := m.Get(model.QueryParam{
 		Select: u.memberFields,
@@ -120,14 +155,38 @@ func (u *DefaultUser) GetMemberByMemberID(ctx context.Context, memberID string)
 		return nil, fmt.Errorf(ErrMemberNotFound)
 	}
 
+	if err := u.decryptMemberFields(members[0]); err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
 	return members[0], nil
 }
 
 // GetMemberDetailByMemberID retrieves detailed member information by member_id (business ID)
 func (u *DefaultUser) GetMemberDetailByMemberID(ctx context.Context, memberID string) (maps.MapStrAny, error) {
+	return u.GetMemberDetailByMemberIDWithFields(ctx, memberID, nil)
+}
+
+// GetMemberDetailByMemberIDWithFields retrieves a member by member_id, selecting only the given
+// fields. Each must be one of the member detail fields; an empty list selects them all.
+func (u *DefaultUser) GetMemberDetailByMemberIDWithFields(ctx context.Context, memberID string, fields []interface{}) (maps.MapStrAny, error) {
+	selectFields := u.memberDetailFields
+	if len(fields) > 0 {
+		for _, field := range fields {
+			if !selectsColumn(u.memberDetailFields, fmt.Sprintf("%v", field)) {
+				return nil, fmt.Errorf("invalid field: %v", field)
+			}
+		}
+		selectFields = fields
+	}
+
+	if err := contextErr(ctx); err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
 	m := model.Select(u.memberModel)
 	members, err := m.Get(model.QueryParam{
-		Select: u.memberDetailFields,
+		Select: selectFields,
 		Wheres: []model.QueryWhere{
 			{Column: "member_id", Value: memberID},
 		},
@@ -142,35 +201,85 @@ func (u *DefaultUser) GetMemberDetailByMemberID(ctx context.Context, memberID st
 		return nil, fmt.Errorf(ErrMemberNotFound)
 	}
 
+	if err := u.decryptMemberFields(members[0]); err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
 	return members[0], nil
 }
 
-// MemberExists checks if a member exists by team_id and user_id
-func (u *DefaultUser) MemberExists(ctx context.Context, teamID string, userID string) (bool, error) {
+// GetMemberByExternalID retrieves the member linked to an external identity (e.g. an SSO subject)
+func (u *DefaultUser) GetMemberByExternalID(ctx context.Context, provider string, externalID string) (maps.MapStrAny, error) {
+	if provider == "" || externalID == "" {
+		return nil, fmt.Errorf("external_provider and external_id are required")
+	}
+
 	m := model.Select(u.memberModel)
 	members, err := m.Get(model.QueryParam{
-		Select: []interface{}{"id"}, // Only select ID for existence check
+		Select: u.memberFields,
 		Wheres: []model.QueryWhere{
-			{Column: "team_id", Value: teamID},
-			{Column: "user_id", Value: userID},
+			{Column: "external_provider", Value: provider},
+			{Column: "external_id", Value: externalID},
 		},
 		Limit: 1,
 	})
 
 	if err != nil {
-		return false, fmt.Errorf(ErrFailedToGetMember, err)
+		return nil, fmt.Errorf(ErrFailedToGetMember, err)
 	}
 
-	return len(members) > 0, nil
+	if len(members) == 0 {
+		return nil, fmt.Errorf(ErrMemberNotFound)
+	}
+
+	if err := u.decryptMemberFields(members[0]); err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
+	return members[0], nil
 }
 
-// MemberExistsByRobotEmail checks if a robot member exists by robot_email (globally unique)
-func (u *DefaultUser) MemberExistsByRobotEmail(ctx context.Context, robotEmail string) (bool, error) {
+// GetMembersByExternalIDs retrieves the members linked to any of the external IDs of one provider.
+// Unknown IDs are skipped, so the result may be shorter than externalIDs.
+func (u *DefaultUser) GetMembersByExternalIDs(ctx context.Context, provider string, externalIDs []string) ([]maps.MapStr, error) {
+	if provider == "" {
+		return nil, fmt.Errorf("external_provider is required")
+	}
+	if len(externalIDs) == 0 {
+		return []maps.MapStr{}, nil
+	}
+
+	m := model.Select(u.memberModel)
+	members, err := m.Get(model.QueryParam{
+		Select: u.memberFields,
+		Wheres: []model.QueryWhere{
+			{Column: "external_provider", Value: provider},
+			{Column: "external_id", Value: externalIDs, OP: "in"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
+	if err := u.decryptMemberRows(members); err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
+	return members, nil
+}
+
+// MemberExists checks if a member exists by team_id and user_id
+func (u *DefaultUser) MemberExists(ctx context.Context, teamID string, userID string) (bool, error) {
+	if err := contextErr(ctx); err != nil {
+		return false, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
 	m := model.Select(u.memberModel)
 	members, err := m.Get(model.QueryParam{
 		Select: []interface{}{"id"}, // Only select ID for existence check
 		Wheres: []model.QueryWhere{
-			{Column: "robot_email", Value: robotEmail},
+			{Column: "team_id", Value: teamID},
+			{Column: "user_id", Value: userID},
 		},
 		Limit: 1,
 	})
@@ -182,6 +291,66 @@ func (u *DefaultUser) MemberExistsByRobotEmail(ctx context.Context, robotEmail s
 	return len(members) > 0, nil
 }
 
+// MemberExistsByRobotEmail checks if a robot member exists by robot_email (globally unique).
+// The comparison ignores letter case, so "Robot@Example.com" finds "robot@example.com".
+func (u *DefaultUser) MemberExistsByRobotEmail(ctx context.Context, robotEmail string) (bool, error) {
+	robotEmail = strings.TrimSpace(robotEmail)
+	if robotEmail == "" {
+		return false, nil
+	}
+
+	if err := contextErr(ctx); err != nil {
+		return false, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
+	// Model wheres can't wrap a column in LOWER(), and LIKE is case-sensitive on Postgres
+	// and reads "%" and "_" as wildcards, so the comparison is spelled out here
+	table := model.Select(u.memberModel).MetaData.Table.Name
+	row, err := capsule.Query().Table(table).
+		Select("id").
+		WhereRaw("LOWER(robot_email) = ?", strings.ToLower(robotEmail)).
+		WhereNull("deleted_at").
+		First()
+	if err != nil {
+		return false, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
+	return row != nil, nil
+}
+
+// GetTeamMemberEmails returns the email values stored on the members of teamID that equal
+// email ignoring letter case, so model queries can filter on them with an exact match
+func (u *DefaultUser) GetTeamMemberEmails(ctx context.Context, teamID string, email string) ([]string, error) {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return []string{}, nil
+	}
+	if err := contextErr(ctx); err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
+	table := model.Select(u.memberModel).MetaData.Table.Name
+	rows, err := capsule.Query().Table(table).
+		Select("email").
+		Where("team_id", teamID).
+		WhereRaw("LOWER(email) = ?", strings.ToLower(email)).
+		WhereNull("deleted_at").
+		Get()
+	if err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
+	emails := []string{}
+	seen := map[string]bool{}
+	for _, row := range rows {
+		if stored, ok := row["email"].(string); ok && !seen[stored] {
+			seen[stored] = true
+			emails = append(emails, stored)
+		}
+	}
+	return emails, nil
+}
+
 // MemberExistsByMemberID checks if a member exists by member_id (business ID)
 func (u *DefaultUser) MemberExistsByMemberID(ctx context.Context, memberID string) (bool, error) {
 	m := model.Select(u.memberModel)
@@ -245,6 +414,15 @@ func (u *DefaultUser) CreateMember(ctx context.Context, memberData maps.MapStrAn
 	if _, exists := memberData["role_id"]; !exists {
 		return "", fmt.Errorf("role_id is required in memberData")
 	}
+	if err := normalizeEmailFields(memberData, "email"); err != nil {
+		return "", err
+	}
+	if err := validateTimezoneField(memberData); err != nil {
+		return "", err
+	}
+	if err := u.checkExternalIdentity(ctx, memberData, nil); err != nil {
+		return "", err
+	}
 
 	// Generate member_id if not provided
 	var generatedMemberID string
@@ -303,11 +481,16 @@ func (u *DefaultUser) CreateMember(ctx context.Context, memberData maps.MapStrAn
 		}
 	}
 
+	if err := u.encryptMemberFields(memberData); err != nil {
+		return "", fmt.Errorf(ErrFailedToCreateMember, err)
+	}
+
 	m := model.Select(u.memberModel)
 	_, err := m.Create(memberData)
 	if err != nil {
 		return "", fmt.Errorf(ErrFailedToCreateMember, err)
 	}
+	notifyMemberWrite(fmt.Sprintf("%v", memberData["team_id"]))
 
 	return generatedMemberID, nil
 }
@@ -321,6 +504,19 @@ func (u *DefaultUser) CreateRobotMember(ctx context.Context, teamID string, robo
 	if _, exists := robotData["role_id"]; !exists {
 		return "", fmt.Errorf("role_id is required for robot members")
 	}
+	if err := normalizeEmailFields(robotData, "email", "robot_email"); err != nil {
+		return "", err
+	}
+	// robot_email is globally unique: a blank one would be stored as "" and collide
+	if robotEmail, ok := robotData["robot_email"].(string); ok && robotEmail == "" {
+		return "", fmt.Errorf("robot_email must not be blank")
+	}
+	if err := validateTimezoneField(robotData); err != nil {
+		return "", err
+	}
+	if err := u.checkRobotLanguageModel(ctx, teamID, robotData); err != nil {
+		return "", err
+	}
 
 	// Check if robot_email already exists globally (robot_email is globally unique)
 	if robotEmail, exists := robotData["robot_email"]; exists && robotEmail != nil && robotEmail != "" {
@@ -350,7 +546,7 @@ func (u *DefaultUser) CreateRobotMember(ctx context.Context, teamID string, robo
 
 	// Copy shared profile fields (used by both users and robots)
 	profileFields := []string{
-		"display_name", "bio", "avatar", "email",
+		"display_name", "bio", "avatar", "email", "timezone",
 	}
 	for _, field := range profileFields {
 		if value, exists := robotData[field]; exists {
@@ -399,6 +595,15 @@ func (u *DefaultUser) UpdateRobotMember(ctx context.Context, memberID string, ro
 	if !exists || memberType != "robot" {
 		return fmt.Errorf("member %s is not a robot member", memberID)
 	}
+	if err := normalizeEmailFields(robotData, "email", "robot_email"); err != nil {
+		return err
+	}
+	if err := validateTimezoneField(robotData); err != nil {
+		return err
+	}
+	if err := u.checkRobotLanguageModel(ctx, fmt.Sprintf("%v", existingMember["team_id"]), robotData); err != nil {
+		return err
+	}
 
 	// Check if robot_email already exists globally (if updating robot_email)
 	if robotEmail, exists := robotData["robot_email"]; exists && robotEmail != nil && robotEmail != "" {
@@ -432,7 +637,7 @@ func (u *DefaultUser) UpdateRobotMember(ctx context.Context, memberID string, ro
 
 	// Copy shared profile fields (used by both users and robots)
 	profileFields := []string{
-		"display_name", "bio", "avatar", "email",
+		"display_name", "bio", "avatar", "email", "timezone",
 	}
 	for _, field := range profileFields {
 		if value, exists := robotData[field]; exists {
@@ -463,6 +668,166 @@ func (u *DefaultUser) UpdateRobotMember(ctx context.Context, memberID string, ro
 	return u.UpdateMemberByMemberID(ctx, memberID, memberData)
 }
 
+// ReassignRobotsFromManager moves every robot of a team managed by oldManagerID to newManagerID.
+// Both are user_ids; the new manager must be an active user member of the team.
+// Returns the number of robots reassigned.
+func (u *DefaultUser) ReassignRobotsFromManager(ctx context.Context, teamID string, oldManagerID string, newManagerID string) (int, error) {
+	if teamID == "" || oldManagerID == "" || newManagerID == "" {
+		return 0, fmt.Errorf("team_id, old manager and new manager are required")
+	}
+	if oldManagerID == newManagerID {
+		return 0, fmt.Errorf("%w: same as the old manager", ErrInvalidNewManager)
+	}
+
+	manager, err := u.GetMember(ctx, teamID, newManagerID)
+	if err != nil {
+		return 0, fmt.Errorf("%w %s: not a member of this team", ErrInvalidNewManager, newManagerID)
+	}
+	if status, _ := manager["status"].(string); status != "active" {
+		return 0, fmt.Errorf("%w %s: member status is %s, not active", ErrInvalidNewManager, newManagerID, status)
+	}
+
+	m := model.Select(u.memberModel)
+	affected, err := m.UpdateWhere(model.QueryParam{
+		Wheres: []model.QueryWhere{
+			{Column: "team_id", Value: teamID},
+			{Column: "member_type", Value: "robot"},
+			{Column: "manager_id", Value: oldManagerID},
+		},
+	}, maps.MapStrAny{
+		"manager_id": newManagerID,
+		"updated_at": time.Now(),
+	})
+	if err != nil {
+		return 0, fmt.Errorf(ErrFailedToUpdateMember, err)
+	}
+	if affected > 0 {
+		notifyMemberWrite(teamID)
+	}
+
+	return affected, nil
+}
+
+// TransferOwnership hands a team over to the member with memberID, which must be an active
+// user member of the team. The new owner gets is_owner and ownerRoleID, every previous owner
+// member is demoted to memberRoleID, and team.owner_id follows. All updates run in one
+// transaction, so the team never ends up with zero or two owners.
+func (u *DefaultUser) TransferOwnership(ctx context.Context, teamID string, memberID string, ownerRoleID string, memberRoleID string) error {
+	if teamID == "" || memberID == "" || ownerRoleID == "" || memberRoleID == "" {
+		return fmt.Errorf("team_id, member_id and both role ids are required")
+	}
+
+	target, err := u.GetMemberByMemberID(ctx, memberID)
+	if err != nil || utils.ToString(target["team_id"]) != teamID {
+		return fmt.Errorf(ErrMemberNotFound)
+	}
+	if memberType := utils.ToString(target["member_type"]); memberType == "robot" {
+		return fmt.Errorf("%w %s: robot members cannot own a team", ErrInvalidNewOwner, memberID)
+	}
+	if status := utils.ToString(target["status"]); status != "active" {
+		return fmt.Errorf("%w %s: member status is %s, not active", ErrInvalidNewOwner, memberID, status)
+	}
+
+	team, err := u.GetTeam(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	newOwnerID := utils.ToString(target["user_id"])
+	oldOwnerID := utils.ToString(team["owner_id"])
+	if newOwnerID == oldOwnerID {
+		return fmt.Errorf("%w %s: already the team owner", ErrInvalidNewOwner, memberID)
+	}
+
+	previous, err := u.teamOwnerMembers(ctx, teamID, oldOwnerID)
+	if err != nil {
+		return err
+	}
+
+	memberTable := model.Select(u.memberModel).MetaData.Table.Name
+	teamTable := model.Select(u.teamModel).MetaData.Table.Name
+
+	tx, err := capsule.Query().DB(true).BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start ownership transfer: %w", err)
+	}
+	defer tx.Rollback() // no-op once committed
+
+	exec := func(query string, args ...interface{}) (int64, error) {
+		result, err := tx.ExecContext(ctx, tx.Rebind(query), args...)
+		if err != nil {
+			return 0, err
+		}
+		return result.RowsAffected()
+	}
+
+	now := time.Now()
+	demote := fmt.Sprintf("UPDATE %s SET is_owner = ?, role_id = ?, updated_at = ? WHERE team_id = ? AND member_id = ? AND deleted_at IS NULL", memberTable)
+	for _, row := range previous {
+		id := utils.ToString(row["member_id"])
+		if id == memberID {
+			continue
+		}
+		if _, err := exec(demote, false, memberRoleID, now, teamID, id); err != nil {
+			return fmt.Errorf("failed to demote previous owner: %w", err)
+		}
+	}
+
+	promote := fmt.Sprintf("UPDATE %s SET is_owner = ?, role_id = ?, updated_at = ? WHERE team_id = ? AND member_id = ? AND deleted_at IS NULL", memberTable)
+	affected, err := exec(promote, true, ownerRoleID, now, teamID, memberID)
+	if err != nil {
+		return fmt.Errorf("failed to promote new owner: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf(ErrMemberNotFound)
+	}
+
+	owner := fmt.Sprintf("UPDATE %s SET owner_id = ?, updated_at = ? WHERE team_id = ? AND deleted_at IS NULL", teamTable)
+	affected, err = exec(owner, newOwnerID, now, teamID)
+	if err != nil {
+		return fmt.Errorf("failed to update team owner: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf(ErrTeamNotFound)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit ownership transfer: %w", err)
+	}
+	notifyMemberWrite(teamID)
+	return nil
+}
+
+// teamOwnerMembers returns the member rows flagged is_owner plus the member row of ownerID,
+// which older teams created before the flag existed may lack
+func (u *DefaultUser) teamOwnerMembers(ctx context.Context, teamID string, ownerID string) ([]maps.MapStr, error) {
+	m := model.Select(u.memberModel)
+	rows, err := m.Get(model.QueryParam{
+		Select: []interface{}{"member_id", "user_id", "is_owner", "role_id"},
+		Wheres: []model.QueryWhere{
+			{Column: "team_id", Value: teamID},
+			{Column: "member_type", Value: "user"},
+			{Column: "is_owner", Value: true},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
+	owners := make([]maps.MapStr, 0, len(rows)+1)
+	for _, row := range rows {
+		owners = append(owners, row)
+		if utils.ToString(row["user_id"]) == ownerID {
+			ownerID = ""
+		}
+	}
+	if ownerID != "" {
+		if row, err := u.GetMember(ctx, teamID, ownerID); err == nil {
+			owners = append(owners, maps.MapStr(row))
+		}
+	}
+	return owners, nil
+}
+
 // AddMember adds a user to a team (invitation-based)
 func (u *DefaultUser) AddMember(ctx context.Context, teamID string, userID string, roleID string, invitedBy string) (string, error) {
 	// Check if member already exists
@@ -517,6 +882,10 @@ func (u *DefaultUser) AcceptInvitation(ctx context.Context, invitationID string,
 	}
 
 	if len(members) == 0 {
+		// A repeated accept by the member who already joined is a no-op, not a failure
+		if u.invitationAcceptedBy(invitationID, userID) {
+			return ErrInvitationAccepted
+		}
 		return fmt.Errorf("invitation not found or already accepted")
 	}
 
@@ -559,9 +928,11 @@ func (u *DefaultUser) AcceptInvitation(ctx context.Context, invitationID string,
 	// copyMemberProfileFromUser will also remove empty fields
 	u.copyMemberProfileFromUser(ctx, finalUserID, updateData)
 
+	// Conditional on pending: of two concurrent accepts only the first updates the row
 	affected, err := m.UpdateWhere(model.QueryParam{
 		Wheres: []model.QueryWhere{
 			{Column: "id", Value: memberID},
+			{Column: "status", Value: "pending"},
 		},
 		Limit: 1,
 	}, updateData)
@@ -571,12 +942,34 @@ func (u *DefaultUser) AcceptInvitation(ctx context.Context, invitationID string,
 	}
 
 	if affected == 0 {
-		return fmt.Errorf(ErrMemberNotFound)
+		if u.invitationAcceptedBy(invitationID, finalUserID) {
+			return ErrInvitationAccepted
+		}
+		return fmt.Errorf("invitation not found or already accepted")
 	}
+	notifyMemberWrite(fmt.Sprintf("%v", member["team_id"]))
 
 	return nil
 }
 
+// invitationAcceptedBy reports whether the invitation is already active for userID
+func (u *DefaultUser) invitationAcceptedBy(invitationID, userID string) bool {
+	if invitationID == "" || userID == "" {
+		return false
+	}
+	m := model.Select(u.memberModel)
+	members, err := m.Get(model.QueryParam{
+		Select: []interface{}{"id"},
+		Wheres: []model.QueryWhere{
+			{Column: "invitation_id", Value: invitationID},
+			{Column: "user_id", Value: userID},
+			{Column: "status", Value: "active"},
+		},
+		Limit: 1,
+	})
+	return err == nil && len(members) > 0
+}
+
 // UpdateMember updates an existing member
 func (u *DefaultUser) UpdateMember(ctx context.Context, teamID string, userID string, memberData maps.MapStrAny) error {
 	// Remove sensitive fields that should not be updated directly
@@ -590,6 +983,16 @@ func (u *DefaultUser) UpdateMember(ctx context.Context, teamID string, userID st
 		return nil
 	}
 
+	if err := u.checkExternalIdentity(ctx, memberData, func(owner maps.MapStr) bool {
+		return owner["team_id"] == teamID && owner["user_id"] == userID
+	}); err != nil {
+		return err
+	}
+
+	if err := u.encryptMemberFields(memberData); err != nil {
+		return fmt.Errorf(ErrFailedToUpdateMember, err)
+	}
+
 	m := model.Select(u.memberModel)
 	affected, err := m.UpdateWhere(model.QueryParam{
 		Wheres: []model.QueryWhere{
@@ -602,6 +1005,7 @@ func (u *DefaultUser) UpdateMember(ctx context.Context, teamID string, userID st
 	if err != nil {
 		return fmt.Errorf(ErrFailedToUpdateMember, err)
 	}
+	notifyMemberWrite(teamID)
 
 	if affected == 0 {
 		// Check if member exists
@@ -631,6 +1035,16 @@ func (u *DefaultUser) UpdateMemberByID(ctx context.Context, id int64, memberData
 		return nil
 	}
 
+	if err := u.checkExternalIdentity(ctx, memberData, func(owner maps.MapStr) bool {
+		return fmt.Sprintf("%v", owner["id"]) == fmt.Sprintf("%d", id)
+	}); err != nil {
+		return err
+	}
+
+	if err := u.encryptMemberFields(memberData); err != nil {
+		return fmt.Errorf(ErrFailedToUpdateMember, err)
+	}
+
 	m := model.Select(u.memberModel)
 	affected, err := m.UpdateWhere(model.QueryParam{
 		Wheres: []model.QueryWhere{
@@ -642,6 +1056,7 @@ func (u *DefaultUser) UpdateMemberByID(ctx context.Context, id int64, memberData
 	if err != nil {
 		return fmt.Errorf(ErrFailedToUpdateMember, err)
 	}
+	notifyMemberWrite("")
 
 	if affected == 0 {
 		// Check if member exists
@@ -671,6 +1086,16 @@ func (u *DefaultUser) UpdateMemberByMemberID(ctx context.Context, memberID strin
 		return nil
 	}
 
+	if err := u.checkExternalIdentity(ctx, memberData, func(owner maps.MapStr) bool {
+		return owner["member_id"] == memberID
+	}); err != nil {
+		return err
+	}
+
+	if err := u.encryptMemberFields(memberData); err != nil {
+		return fmt.Errorf(ErrFailedToUpdateMember, err)
+	}
+
 	m := model.Select(u.memberModel)
 	affected, err := m.UpdateWhere(model.QueryParam{
 		Wheres: []model.QueryWhere{
@@ -682,6 +1107,7 @@ func (u *DefaultUser) UpdateMemberByMemberID(ctx context.Context, memberID strin
 	if err != nil {
 		return fmt.Errorf(ErrFailedToUpdateMember, err)
 	}
+	notifyMemberWrite("")
 
 	// Note: affected=0 can mean either:
 	// 1. No record found with the given member_id
@@ -721,6 +1147,7 @@ func (u *DefaultUser) RemoveMember(ctx context.Context, teamID string, userID st
 	if affected == 0 {
 		return fmt.Errorf(ErrMemberNotFound)
 	}
+	notifyMemberWrite(teamID)
 
 	return nil
 }
@@ -742,6 +1169,58 @@ func (u *DefaultUser) RemoveMemberByMemberID(ctx context.Context, memberID strin
 	if affected == 0 {
 		return fmt.Errorf(ErrMemberNotFound)
 	}
+	notifyMemberWrite("")
+
+	return nil
+}
+
+// RestoreMemberByMemberID brings back a member of teamID removed by RemoveMemberByMemberID by
+// clearing its soft-delete marker. A member of another team is reported as not found and left
+// untouched. A robot whose robot_email has been taken by another active member since the
+// removal can't be restored, robot_email being globally unique.
+func (u *DefaultUser) RestoreMemberByMemberID(ctx context.Context, teamID string, memberID string) error {
+	m := model.Select(u.memberModel)
+	table := m.MetaData.Table.Name
+
+	// Model queries skip soft-deleted rows, so look the removed member up directly
+	row, err := capsule.Query().Table(table).
+		Select("id", "robot_email").
+		Where("member_id", memberID).
+		Where("team_id", teamID).
+		WhereNotNull("deleted_at").
+		First()
+	if err != nil {
+		return fmt.Errorf(ErrFailedToGetMember, err)
+	}
+	if row == nil {
+		return fmt.Errorf(ErrMemberNotFound)
+	}
+
+	if robotEmail, ok := row["robot_email"].(string); ok && robotEmail != "" {
+		taken, err := u.MemberExistsByRobotEmail(ctx, robotEmail)
+		if err != nil {
+			return fmt.Errorf("failed to check robot_email uniqueness: %w", err)
+		}
+		if taken {
+			return fmt.Errorf("robot_email %s already exists", robotEmail)
+		}
+	}
+
+	affected, err := capsule.Query().Table(table).
+		Where("member_id", memberID).
+		Where("team_id", teamID).
+		WhereNotNull("deleted_at").
+		Update(map[string]interface{}{
+			"deleted_at": nil,
+			"updated_at": time.Now(),
+		})
+	if err != nil {
+		return fmt.Errorf(ErrFailedToUpdateMember, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf(ErrMemberNotFound)
+	}
+	notifyMemberWrite(teamID)
 
 	return nil
 }
@@ -758,6 +1237,7 @@ func (u *DefaultUser) RemoveAllTeamMembers(ctx context.Context, teamID string) e
 	if err != nil {
 		return fmt.Errorf("failed to delete all team members: %w", err)
 	}
+	notifyMemberWrite(teamID)
 
 	return nil
 }
@@ -775,12 +1255,20 @@ func (u *DefaultUser) GetTeamMembers(ctx context.Context, teamID string) ([]maps
 		},
 	}
 
+	if err := contextErr(ctx); err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
 	m := model.Select(u.memberModel)
 	members, err := m.Get(param)
 	if err != nil {
 		return nil, fmt.Errorf(ErrFailedToGetMember, err)
 	}
 
+	if err := u.decryptMemberRows(members); err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
 	return members, nil
 }
 
@@ -802,6 +1290,10 @@ func (u *DefaultUser) GetUserTeams(ctx context.Context, userID string) ([]maps.M
 		return nil, fmt.Errorf(ErrFailedToGetMember, err)
 	}
 
+	if err := u.decryptMemberRows(members); err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
 	return members, nil
 }
 
@@ -824,6 +1316,10 @@ func (u *DefaultUser) GetTeamMembersByStatus(ctx context.Context, teamID string,
 		return nil, fmt.Errorf(ErrFailedToGetMember, err)
 	}
 
+	if err := u.decryptMemberRows(members); err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
 	return members, nil
 }
 
@@ -846,6 +1342,10 @@ func (u *DefaultUser) GetTeamRobotMembers(ctx context.Context, teamID string) ([
 		return nil, fmt.Errorf(ErrFailedToGetMember, err)
 	}
 
+	if err := u.decryptMemberRows(members); err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
 	return members, nil
 }
 
@@ -869,9 +1369,86 @@ func (u *DefaultUser) GetActiveRobotMembers(ctx context.Context) ([]maps.MapStr,
 		return nil, fmt.Errorf(ErrFailedToGetMember, err)
 	}
 
+	if err := u.decryptMemberRows(members); err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
+	return members, nil
+}
+
+// GetRobotsDueForExecution retrieves the active autonomous robots that should run at now:
+// next_run_at and cooldown_until are either unset or already passed. The database orders
+// the most overdue first; robots are then ranked by their quota priority (highest first).
+func (u *DefaultUser) GetRobotsDueForExecution(ctx context.Context, now time.Time) ([]maps.MapStr, error) {
+	param := model.QueryParam{
+		Select: u.memberDetailFields,
+		Wheres: []model.QueryWhere{
+			{Column: "member_type", Value: "robot"},
+			{Column: "autonomous_mode", Value: true},
+			{Column: "status", Value: "active"},
+			{Wheres: []model.QueryWhere{
+				{Column: "next_run_at", OP: "null"},
+				{Column: "next_run_at", OP: "le", Value: now, Method: "orwhere"},
+			}},
+			{Wheres: []model.QueryWhere{
+				{Column: "cooldown_until", OP: "null"},
+				{Column: "cooldown_until", OP: "le", Value: now, Method: "orwhere"},
+			}},
+		},
+		Orders: []model.QueryOrder{
+			{Column: "next_run_at", Option: "asc"},
+			{Column: "last_robot_activity", Option: "asc"},
+		},
+	}
+
+	m := model.Select(u.memberModel)
+	members, err := m.Get(param)
+	if err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
+	if err := u.decryptMemberRows(members); err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
+	// Priority sits inside robot_config, so rank in memory; stable keeps the due order
+	sort.SliceStable(members, func(i, j int) bool {
+		return robotQuotaPriority(members[i]) > robotQuotaPriority(members[j])
+	})
 	return members, nil
 }
 
+// robotQuotaPriority reads quota.priority from a robot row's config (default: 5)
+func robotQuotaPriority(row maps.MapStr) int {
+	config := row["robot_config"]
+	if raw, ok := config.(string); ok {
+		var parsed map[string]interface{}
+		if json.Unmarshal([]byte(raw), &parsed) != nil {
+			return 5
+		}
+		config = parsed
+	}
+	cfg, ok := config.(map[string]interface{})
+	if !ok {
+		return 5
+	}
+	quota, ok := cfg["quota"].(map[string]interface{})
+	if !ok {
+		return 5
+	}
+	switch priority := quota["priority"].(type) {
+	case float64:
+		if priority > 0 {
+			return int(priority)
+		}
+	case int:
+		if priority > 0 {
+			return priority
+		}
+	}
+	return 5
+}
+
 // UpdateMemberRole updates a member's role
 func (u *DefaultUser) UpdateMemberRole(ctx context.Context, teamID string, userID string, roleID string) error {
 	updateData := maps.MapStrAny{
@@ -922,10 +1499,16 @@ func (u *DefaultUser) UpdateMemberRoleByMemberID(ctx context.Context, memberID s
 	return u.UpdateMemberByMemberID(ctx, memberID, updateData)
 }
 
-// UpdateMemberStatusByMemberID updates a member's status by member_id
-func (u *DefaultUser) UpdateMemberStatusByMemberID(ctx context.Context, memberID string, status string) error {
+// UpdateMemberStatusByMemberID updates a member's status by member_id.
+// The optional reason is stored as status_reason; without one the previous reason is cleared,
+// since it described the old status.
+func (u *DefaultUser) UpdateMemberStatusByMemberID(ctx context.Context, memberID string, status string, reason ...string) error {
 	updateData := maps.MapStrAny{
-		"status": status,
+		"status":        status,
+		"status_reason": nil,
+	}
+	if len(reason) > 0 && reason[0] != "" {
+		updateData["status_reason"] = reason[0]
 	}
 
 	return u.UpdateMemberByMemberID(ctx, memberID, updateData)
@@ -978,6 +1561,16 @@ func (u *DefaultUser) UpdateMemberByInvitationID(ctx context.Context, invitation
 		return nil
 	}
 
+	if err := u.checkExternalIdentity(ctx, memberData, func(owner maps.MapStr) bool {
+		return owner["invitation_id"] == invitationID
+	}); err != nil {
+		return err
+	}
+
+	if err := u.encryptMemberFields(memberData); err != nil {
+		return fmt.Errorf(ErrFailedToUpdateMember, err)
+	}
+
 	m := model.Select(u.memberModel)
 	affected, err := m.UpdateWhere(model.QueryParam{
 		Wheres: []model.QueryWhere{
@@ -989,6 +1582,7 @@ func (u *DefaultUser) UpdateMemberByInvitationID(ctx context.Context, invitation
 	if err != nil {
 		return fmt.Errorf(ErrFailedToUpdateMember, err)
 	}
+	notifyMemberWrite("")
 
 	if affected == 0 {
 		// Check if member exists
@@ -1022,10 +1616,48 @@ func (u *DefaultUser) RemoveMemberByInvitationID(ctx context.Context, invitation
 	if affected == 0 {
 		return fmt.Errorf(ErrMemberNotFound)
 	}
+	notifyMemberWrite("")
 
 	return nil
 }
 
+// RevokeInvitation withdraws a pending invitation: the member row is deleted, which
+// invalidates its token, and the revoked invitation is returned so the caller can
+// notify the invitee. Fails with ErrInvitationAccepted once the invitee has joined.
+func (u *DefaultUser) RevokeInvitation(ctx context.Context, invitationID string) (maps.MapStrAny, error) {
+	invitation, err := u.GetMemberByInvitationID(ctx, invitationID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch status, _ := invitation["status"].(string); status {
+	case "pending":
+	case "active":
+		return nil, ErrInvitationAccepted
+	default:
+		return nil, fmt.Errorf("%w (status: %s)", ErrInvitationNotPending, status)
+	}
+
+	// Conditional on pending so an accept racing the revoke wins cleanly
+	m := model.Select(u.memberModel)
+	affected, err := m.DeleteWhere(model.QueryParam{
+		Wheres: []model.QueryWhere{
+			{Column: "invitation_id", Value: invitationID},
+			{Column: "status", Value: "pending"},
+		},
+		Limit: 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf(ErrFailedToDeleteMember, err)
+	}
+	if affected == 0 {
+		return nil, ErrInvitationAccepted
+	}
+	notifyMemberWrite(fmt.Sprintf("%v", invitation["team_id"]))
+
+	return invitation, nil
+}
+
 // PaginateMembers retrieves paginated list of members
 func (u *DefaultUser) PaginateMembers(ctx context.Context, param model.QueryParam, page int, pagesize int) (maps.MapStr, error) {
 	// Set default select fields if not provided
@@ -1033,15 +1665,107 @@ func (u *DefaultUser) PaginateMembers(ctx context.Context, param model.QueryPara
 		param.Select = u.memberFields
 	}
 
+	if err := contextErr(ctx); err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
 	m := model.Select(u.memberModel)
 	result, err := m.Paginate(param, page, pagesize)
 	if err != nil {
 		return nil, fmt.Errorf(ErrFailedToGetMember, err)
 	}
 
+	if err := u.decryptPaginatedMembers(result); err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
 	return result, nil
 }
 
+// PaginateMembersCursor retrieves members newest first, keyed off the id column: each page
+// starts after cursor ("" for the first page) and holds at most limit rows. Unlike
+// PaginateMembers, rows inserted while scrolling don't shift later pages.
+// Returns {"data": [...], "pagesize": limit, "next_cursor": ""}, next_cursor empty on the last page.
+func (u *DefaultUser) PaginateMembersCursor(ctx context.Context, param model.QueryParam, cursor string, limit int) (maps.MapStr, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	// Set default select fields if not provided; id carries the cursor either way
+	if param.Select == nil {
+		param.Select = u.memberFields
+	} else if !selectsColumn(param.Select, "id") {
+		param.Select = append([]interface{}{"id"}, param.Select...)
+	}
+
+	if cursor != "" {
+		lastID, err := decodeMemberCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		param.Wheres = append(param.Wheres, model.QueryWhere{Column: "id", OP: "<", Value: lastID})
+	}
+	param.Orders = []model.QueryOrder{{Column: "id", Option: "desc"}}
+	param.Limit = limit + 1 // one extra row tells whether another page follows
+
+	if err := contextErr(ctx); err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
+	m := model.Select(u.memberModel)
+	rows, err := m.Get(param)
+	if err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+	if err := u.decryptMemberRows(rows); err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
+	nextCursor := ""
+	if len(rows) > limit {
+		rows = rows[:limit]
+		nextCursor = encodeMemberCursor(utils.ToInt64(rows[limit-1]["id"]))
+	}
+
+	data := make([]maps.MapStrAny, len(rows))
+	for i, row := range rows {
+		data[i] = maps.MapStrAny(row)
+	}
+	return maps.MapStr{
+		"data":        data,
+		"pagesize":    limit,
+		"next_cursor": nextCursor,
+	}, nil
+}
+
+// encodeMemberCursor returns the opaque cursor of the page after the member with id
+func encodeMemberCursor(id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("member:" + strconv.FormatInt(id, 10)))
+}
+
+// decodeMemberCursor returns the member id a cursor points after
+func decodeMemberCursor(cursor string) (int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil {
+		if value, ok := strings.CutPrefix(string(raw), "member:"); ok {
+			if id, err := strconv.ParseInt(value, 10, 64); err == nil && id > 0 {
+				return id, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("invalid cursor: %q", cursor)
+}
+
+// selectsColumn reports whether a select list includes column
+func selectsColumn(fields []interface{}, column string) bool {
+	for _, field := range fields {
+		if name, ok := field.(string); ok && name == column {
+			return true
+		}
+	}
+	return false
+}
+
 // copyMemberProfileFromUser copies member profile fields from user if not set in updateData
 // Fields: display_name (from user.name), bio (n/a), avatar (from user.picture), email (from user.email)
 // Only copies if the field is nil or empty in updateData
@@ -1098,3 +1822,88 @@ func (u *DefaultUser) copyMemberProfileFromUser(ctx context.Context, userID stri
 		}
 	}
 }
+
+// normalizeEmailFields trims and validates the email fields of data in place so that
+// case or whitespace variants of one address are stored the same way.
+// Blank values are set to "" and left to the caller's own defaults.
+func normalizeEmailFields(data maps.MapStrAny, fields ...string) error {
+	for _, field := range fields {
+		value, ok := data[field].(string)
+		if !ok {
+			continue
+		}
+		if strings.TrimSpace(value) == "" {
+			data[field] = ""
+			continue
+		}
+		email, err := utils.NormalizeEmailAddress(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", field, err)
+		}
+		data[field] = email
+	}
+	return nil
+}
+
+// validateTimezoneField checks that a non-empty "timezone" is a known IANA zone name.
+// An empty value clears the timezone so the server zone is used.
+func validateTimezoneField(data maps.MapStrAny) error {
+	value, ok := data["timezone"].(string)
+	if !ok {
+		return nil
+	}
+	value = strings.TrimSpace(value)
+	data["timezone"] = value
+	if value == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(value); err != nil {
+		return fmt.Errorf("timezone: invalid timezone %q", value)
+	}
+	return nil
+}
+
+// checkExternalIdentity trims external_provider/external_id in data and makes sure the pair
+// is set together and not linked to another member. isSelf recognizes the member being
+// updated; nil means a new member.
+func (u *DefaultUser) checkExternalIdentity(ctx context.Context, data maps.MapStrAny, isSelf func(owner maps.MapStr) bool) error {
+	_, hasProvider := data["external_provider"]
+	_, hasID := data["external_id"]
+	if !hasProvider && !hasID {
+		return nil
+	}
+
+	provider, _ := data["external_provider"].(string)
+	externalID, _ := data["external_id"].(string)
+	provider = strings.TrimSpace(provider)
+	externalID = strings.TrimSpace(externalID)
+
+	// Clearing the link: store NULLs so the unique index ignores the row
+	if provider == "" && externalID == "" {
+		data["external_provider"] = nil
+		data["external_id"] = nil
+		return nil
+	}
+	if provider == "" || externalID == "" {
+		return fmt.Errorf("external_provider and external_id must be set together")
+	}
+	data["external_provider"] = provider
+	data["external_id"] = externalID
+
+	m := model.Select(u.memberModel)
+	owners, err := m.Get(model.QueryParam{
+		Select: []interface{}{"id", "member_id", "team_id", "user_id", "invitation_id"},
+		Wheres: []model.QueryWhere{
+			{Column: "external_provider", Value: provider},
+			{Column: "external_id", Value: externalID},
+		},
+		Limit: 1,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to check external identity uniqueness: %w", err)
+	}
+	if len(owners) > 0 && (isSelf == nil || !isSelf(owners[0])) {
+		return fmt.Errorf("external identity %s:%s is already linked to another member", provider, externalID)
+	}
+	return nil
+}
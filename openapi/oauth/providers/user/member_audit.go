@@ -0,0 +1,52 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/kun/maps"
+)
+
+// Member audit actions
+const (
+	MemberAuditCreate = "create"
+	MemberAuditUpdate = "update"
+	MemberAuditDelete = "delete"
+)
+
+// memberAuditFields are the columns returned by PaginateMemberAudits
+var memberAuditFields = []interface{}{"id", "team_id", "member_id", "actor_id", "action", "changes", "created_at"}
+
+// CreateMemberAudit stores one entry of a member's change trail.
+// auditData carries team_id, member_id, actor_id, action and changes.
+func (u *DefaultUser) CreateMemberAudit(ctx context.Context, auditData maps.MapStrAny) error {
+	for _, field := range []string{"team_id", "member_id", "actor_id", "action"} {
+		if v, _ := auditData[field].(string); v == "" {
+			return fmt.Errorf("failed to create member audit: %s is required", field)
+		}
+	}
+
+	m := model.Select(u.memberAuditModel)
+	if _, err := m.Create(auditData); err != nil {
+		return fmt.Errorf("failed to create member audit: %w", err)
+	}
+	return nil
+}
+
+// PaginateMemberAudits returns the change trail of a team member, newest first
+func (u *DefaultUser) PaginateMemberAudits(ctx context.Context, teamID, memberID string, page int, pagesize int) (maps.MapStr, error) {
+	m := model.Select(u.memberAuditModel)
+	result, err := m.Paginate(model.QueryParam{
+		Select: memberAuditFields,
+		Wheres: []model.QueryWhere{
+			{Column: "team_id", Value: teamID},
+			{Column: "member_id", Value: memberID},
+		},
+		Orders: []model.QueryOrder{{Column: "id", Option: "desc"}},
+	}, page, pagesize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get member audits: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,21 @@
+package user
+
+import (
+	"context"
+	"io"
+
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/yao/seed"
+)
+
+// ExportMembers writes the members matching param to w in the given format, page by
+// page through the seed export machinery. Encrypted columns are written decrypted.
+func (u *DefaultUser) ExportMembers(ctx context.Context, w io.Writer, param model.QueryParam, format seed.ExportFormat) (*seed.ExportResult, error) {
+	if param.Select == nil {
+		param.Select = u.memberFields
+	}
+	return seed.Stream(w, u.memberModel, param, seed.StreamOption{
+		Format:    format,
+		Transform: DecryptMemberData,
+	})
+}
@@ -0,0 +1,30 @@
+package user
+
+import "sync"
+
+// MemberWriteFunc is called after a member row is created, updated, removed or restored.
+// teamID is the team of the changed row, or empty when the write addressed the row by
+// another key (id, member_id, invitation_id) and any team may be affected.
+type MemberWriteFunc func(teamID string)
+
+var (
+	memberWriteFuncs   []MemberWriteFunc
+	memberWriteFuncsMu sync.RWMutex
+)
+
+// OnMemberWrite registers fn to run after every member write made through the provider,
+// e.g. to drop caches of member queries
+func OnMemberWrite(fn MemberWriteFunc) {
+	memberWriteFuncsMu.Lock()
+	defer memberWriteFuncsMu.Unlock()
+	memberWriteFuncs = append(memberWriteFuncs, fn)
+}
+
+// notifyMemberWrite runs the registered member write funcs
+func notifyMemberWrite(teamID string) {
+	memberWriteFuncsMu.RLock()
+	defer memberWriteFuncsMu.RUnlock()
+	for _, fn := range memberWriteFuncs {
+		fn(teamID)
+	}
+}
@@ -3,13 +3,17 @@ package user_test
 import (
 	"context"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/yaoapp/gou/model"
 	"github.com/yaoapp/kun/maps"
+	"github.com/yaoapp/xun/capsule"
+	"github.com/yaoapp/yao/openapi/oauth/providers/user"
 )
 
 func TestMemberBasicOperations(t *testing.T) {
@@ -308,6 +312,66 @@ func TestMemberInvitationFlow(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "invitation not found")
 	})
+
+	// Test a repeated accept by the member who joined
+	t.Run("AcceptInvitation_RepeatedBySameUser", func(t *testing.T) {
+		err := testProvider.AcceptInvitation(ctx, invitationID, invitationToken, inviteeUser)
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, user.ErrInvitationAccepted)
+	})
+}
+
+func TestAcceptInvitationConcurrent(t *testing.T) {
+	prepare(t)
+	defer clean()
+
+	ctx := context.Background()
+	testUUID := strings.ReplaceAll(uuid.New().String(), "-", "")[:8]
+
+	ownerUser := createTestUser(ctx, t, "owner"+testUUID)
+	inviteeUser := createTestUser(ctx, t, "invitee"+testUUID)
+
+	teamID, err := testProvider.CreateTeam(ctx, maps.MapStrAny{
+		"name":     "Concurrent Accept Team " + testUUID,
+		"owner_id": ownerUser,
+		"status":   "active",
+	})
+	assert.NoError(t, err)
+
+	_, err = testProvider.AddMember(ctx, teamID, inviteeUser, "user", ownerUser)
+	assert.NoError(t, err)
+	memberDetail, err := testProvider.GetMemberDetail(ctx, teamID, inviteeUser)
+	assert.NoError(t, err)
+	invitationID := memberDetail["invitation_id"].(string)
+	invitationToken := memberDetail["invitation_token"].(string)
+
+	// Double click: both requests race on the same pending invitation
+	const clicks = 5
+	errs := make(chan error, clicks)
+	var wg sync.WaitGroup
+	for i := 0; i < clicks; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- testProvider.AcceptInvitation(ctx, invitationID, invitationToken, inviteeUser)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	accepted := 0
+	for err := range errs {
+		if err == nil {
+			accepted++
+			continue
+		}
+		assert.ErrorIs(t, err, user.ErrInvitationAccepted)
+	}
+	assert.Equal(t, 1, accepted, "exactly one accept wins")
+
+	member, err := testProvider.GetMember(ctx, teamID, inviteeUser)
+	assert.NoError(t, err)
+	assert.Equal(t, "active", member["status"])
 }
 
 func TestRobotMemberOperations(t *testing.T) {
@@ -610,6 +674,39 @@ func TestMemberQueryOperations(t *testing.T) {
 		total := result["total"]
 		assert.True(t, total == 2 || total == int64(2))
 	})
+
+	// Test PaginateMembersCursor
+	t.Run("PaginateMembersCursor", func(t *testing.T) {
+		param := model.QueryParam{
+			Wheres: []model.QueryWhere{
+				{Column: "team_id", Value: team1ID},
+			},
+		}
+
+		// One member per page: walk until next_cursor runs out
+		seen := map[interface{}]bool{}
+		cursor := ""
+		for pages := 0; pages < 5; pages++ {
+			result, err := testProvider.PaginateMembersCursor(ctx, param, cursor, 1)
+			require.NoError(t, err)
+			data, ok := result["data"].([]maps.MapStrAny)
+			require.True(t, ok)
+			for _, member := range data {
+				assert.False(t, seen[member["id"]], "member returned twice")
+				seen[member["id"]] = true
+			}
+			cursor, _ = result["next_cursor"].(string)
+			if cursor == "" {
+				break
+			}
+		}
+		assert.Empty(t, cursor)
+		assert.Len(t, seen, 2)
+
+		_, err := testProvider.PaginateMembersCursor(ctx, param, "not-a-cursor!", 1)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid cursor")
+	})
 }
 
 func TestMemberErrorHandling(t *testing.T) {
@@ -1104,6 +1201,26 @@ func TestMemberIDOperations(t *testing.T) {
 		assert.Contains(t, member, "updated_at")
 	})
 
+	// Test GetMemberDetailByMemberIDWithFields
+	t.Run("GetMemberDetailByMemberIDWithFields", func(t *testing.T) {
+		member, err := testProvider.GetMemberDetailByMemberIDWithFields(ctx, businessMemberID, []interface{}{"member_id", "display_name", "avatar"})
+		assert.NoError(t, err)
+		assert.Equal(t, businessMemberID, member["member_id"])
+		assert.Contains(t, member, "display_name")
+		assert.NotContains(t, member, "team_id")
+		assert.NotContains(t, member, "robot_config")
+
+		// No selection falls back to the detail fields
+		member, err = testProvider.GetMemberDetailByMemberIDWithFields(ctx, businessMemberID, nil)
+		assert.NoError(t, err)
+		assert.Contains(t, member, "team_id")
+
+		// Columns outside the detail fields are refused
+		_, err = testProvider.GetMemberDetailByMemberIDWithFields(ctx, businessMemberID, []interface{}{"member_id", "password_hash"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid field")
+	})
+
 	// Test UpdateMemberByMemberID
 	t.Run("UpdateMemberByMemberID", func(t *testing.T) {
 		updateData := maps.MapStrAny{
@@ -1146,6 +1263,26 @@ func TestMemberIDOperations(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	// Test UpdateMemberStatusByMemberID with a reason
+	t.Run("UpdateMemberStatusByMemberIDWithReason", func(t *testing.T) {
+		err := testProvider.UpdateMemberStatusByMemberID(ctx, businessMemberID, "suspended", "Repeated policy violations")
+		assert.NoError(t, err)
+
+		member, err := testProvider.GetMemberDetailByMemberID(ctx, businessMemberID)
+		assert.NoError(t, err)
+		assert.Equal(t, "suspended", member["status"])
+		assert.Equal(t, "Repeated policy violations", member["status_reason"])
+
+		// A status change without a reason clears the old one
+		err = testProvider.UpdateMemberStatusByMemberID(ctx, businessMemberID, "active")
+		assert.NoError(t, err)
+
+		member, err = testProvider.GetMemberDetailByMemberID(ctx, businessMemberID)
+		assert.NoError(t, err)
+		assert.Equal(t, "active", member["status"])
+		assert.Nil(t, member["status_reason"])
+	})
+
 	// Test UpdateMemberLastActivityByMemberID
 	t.Run("UpdateMemberLastActivityByMemberID", func(t *testing.T) {
 		err := testProvider.UpdateMemberLastActivityByMemberID(ctx, businessMemberID)
@@ -1184,6 +1321,43 @@ func TestMemberIDOperations(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "member not found")
 	})
+
+	// Test RestoreMemberByMemberID (brings the removed member back)
+	t.Run("RestoreMemberByMemberID", func(t *testing.T) {
+		deletedAt := func() interface{} {
+			m := model.Select("__yao.member")
+			row, err := capsule.Query().Table(m.MetaData.Table.Name).
+				Select("deleted_at").
+				Where("member_id", businessMemberID).
+				First()
+			require.NoError(t, err)
+			return row["deleted_at"]
+		}
+
+		// A member of another team is not found and stays removed
+		before := deletedAt()
+		require.NotNil(t, before)
+		err := testProvider.RestoreMemberByMemberID(ctx, "other-team-"+testUUID, businessMemberID)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "member not found")
+		assert.Equal(t, before, deletedAt())
+
+		err = testProvider.RestoreMemberByMemberID(ctx, teamID, businessMemberID)
+		assert.NoError(t, err)
+
+		member, err := testProvider.GetMemberByMemberID(ctx, businessMemberID)
+		assert.NoError(t, err)
+		assert.Equal(t, businessMemberID, member["member_id"])
+
+		// Only removed members can be restored
+		err = testProvider.RestoreMemberByMemberID(ctx, teamID, businessMemberID)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "member not found")
+
+		err = testProvider.RestoreMemberByMemberID(ctx, teamID, "nonexistent-member-id")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "member not found")
+	})
 }
 
 func TestMemberExistsByRobotEmail(t *testing.T) {
@@ -1238,6 +1412,48 @@ func TestMemberExistsByRobotEmail(t *testing.T) {
 		assert.NoError(t, err)
 		assert.False(t, exists)
 	})
+
+	// Different casing and surrounding spaces still find the same address
+	t.Run("MemberExistsByRobotEmail_CaseInsensitive", func(t *testing.T) {
+		exists, err := testProvider.MemberExistsByRobotEmail(ctx, "  "+strings.ToUpper(testRobotEmail)+" ")
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	// "_" is a LIKE wildcard but must not match another character
+	t.Run("MemberExistsByRobotEmail_NoWildcardMatch", func(t *testing.T) {
+		wildcard := "testrobot" + testUUID[:len(testUUID)-1] + "_@robot.example.com"
+		exists, err := testProvider.MemberExistsByRobotEmail(ctx, wildcard)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+
+		exists, err = testProvider.MemberExistsByRobotEmail(ctx, "testrobot%@robot.example.com")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	// Member emails are looked up in the team ignoring case, never by pattern
+	t.Run("GetTeamMemberEmails", func(t *testing.T) {
+		storedEmail := "Mixed" + testUUID + "@robot.example.com"
+		_, err := testProvider.CreateRobotMember(ctx, teamID, maps.MapStrAny{
+			"display_name": "MailBot" + testUUID,
+			"role_id":      "bot",
+			"email":        storedEmail,
+		})
+		require.NoError(t, err)
+
+		emails, err := testProvider.GetTeamMemberEmails(ctx, teamID, strings.ToLower(storedEmail))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{storedEmail}, emails)
+
+		emails, err = testProvider.GetTeamMemberEmails(ctx, teamID, "mixed%@robot.example.com")
+		assert.NoError(t, err)
+		assert.Empty(t, emails)
+
+		emails, err = testProvider.GetTeamMemberEmails(ctx, "other-team-"+testUUID, storedEmail)
+		assert.NoError(t, err)
+		assert.Empty(t, emails)
+	})
 }
 
 func TestUpdateRobotMember(t *testing.T) {
@@ -1542,6 +1758,64 @@ func TestRobotEmailUniqueness(t *testing.T) {
 		// Note: The exact error message may vary depending on the database driver
 	})
 
+	// Test case and whitespace variants of an existing robot_email are duplicates
+	t.Run("CreateRobotWithEmailVariant_ShouldFail", func(t *testing.T) {
+		at := strings.LastIndex(testEmail, "@")
+		robotData := maps.MapStrAny{
+			"display_name": "Robot2b" + testUUID,
+			"role_id":      "bot",
+			"robot_email":  "  " + testEmail[:at] + strings.ToUpper(testEmail[at:]) + " ",
+		}
+
+		_, err := testProvider.CreateRobotMember(ctx, team2ID, robotData)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "already exists")
+	})
+
+	// Test clearly invalid addresses are rejected
+	t.Run("CreateRobotWithInvalidEmail_ShouldFail", func(t *testing.T) {
+		for _, field := range []string{"robot_email", "email"} {
+			robotData := maps.MapStrAny{
+				"display_name": "Robot2c" + testUUID,
+				"role_id":      "bot",
+				field:          "not-an-email",
+			}
+
+			_, err := testProvider.CreateRobotMember(ctx, team2ID, robotData)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), field)
+		}
+	})
+
+	// Test a blank robot_email is rejected rather than stored as ""
+	t.Run("CreateRobotWithBlankRobotEmail_ShouldFail", func(t *testing.T) {
+		robotData := maps.MapStrAny{
+			"display_name": "Robot2e" + testUUID,
+			"role_id":      "bot",
+			"robot_email":  "   ",
+		}
+
+		_, err := testProvider.CreateRobotMember(ctx, team2ID, robotData)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "robot_email must not be blank")
+	})
+
+	// Test the member email is stored trimmed with a lowercase domain
+	t.Run("CreateRobotNormalizesEmail", func(t *testing.T) {
+		robotData := maps.MapStrAny{
+			"display_name": "Robot2d" + testUUID,
+			"role_id":      "bot",
+			"email":        " Owner" + testUUID + "@Example.COM ",
+		}
+
+		memberID, err := testProvider.CreateRobotMember(ctx, team2ID, robotData)
+		assert.NoError(t, err)
+
+		member, err := testProvider.GetMemberDetailByMemberID(ctx, memberID)
+		assert.NoError(t, err)
+		assert.Equal(t, "Owner"+testUUID+"@example.com", member["email"])
+	})
+
 	// Test creating robot with different robot_email should succeed
 	t.Run("CreateRobotWithDifferentEmail_ShouldSucceed", func(t *testing.T) {
 		differentEmail := "another-robot" + testUUID + "@robot.example.com"
@@ -1633,3 +1907,472 @@ func TestRobotEmailUniqueness(t *testing.T) {
 }
 
 // Helper function createTestUser is defined in team_test.go
+
+func TestMemberExternalIdentity(t *testing.T) {
+	prepare(t)
+	defer clean()
+
+	ctx := context.Background()
+	testUUID := strings.ReplaceAll(uuid.New().String(), "-", "")[:8]
+	provider := "okta" + testUUID
+
+	ownerUser := createTestUser(ctx, t, "owner"+testUUID)
+	user1 := createTestUser(ctx, t, "sso1"+testUUID)
+	user2 := createTestUser(ctx, t, "sso2"+testUUID)
+
+	teamID, err := testProvider.CreateTeam(ctx, maps.MapStrAny{
+		"name":     "SSO Test Team " + testUUID,
+		"owner_id": ownerUser,
+		"status":   "active",
+	})
+	assert.NoError(t, err)
+
+	member1, err := testProvider.CreateMember(ctx, maps.MapStrAny{
+		"team_id":           teamID,
+		"user_id":           user1,
+		"role_id":           "user",
+		"status":            "active",
+		"external_provider": provider,
+		"external_id":       " sub-1 ",
+	})
+	assert.NoError(t, err)
+
+	t.Run("GetMemberByExternalID", func(t *testing.T) {
+		member, err := testProvider.GetMemberByExternalID(ctx, provider, "sub-1")
+		assert.NoError(t, err)
+		assert.Equal(t, member1, member["member_id"])
+		assert.Equal(t, "sub-1", member["external_id"])
+
+		_, err = testProvider.GetMemberByExternalID(ctx, provider, "sub-unknown")
+		assert.Error(t, err)
+	})
+
+	t.Run("DuplicatePair_ShouldFail", func(t *testing.T) {
+		_, err := testProvider.CreateMember(ctx, maps.MapStrAny{
+			"team_id":           teamID,
+			"user_id":           user2,
+			"role_id":           "user",
+			"external_provider": provider,
+			"external_id":       "sub-1",
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "already linked")
+	})
+
+	t.Run("HalfPair_ShouldFail", func(t *testing.T) {
+		_, err := testProvider.CreateMember(ctx, maps.MapStrAny{
+			"team_id":     teamID,
+			"user_id":     user2,
+			"role_id":     "user",
+			"external_id": "sub-2",
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "set together")
+	})
+
+	t.Run("UpdateKeepsOwnPair", func(t *testing.T) {
+		err := testProvider.UpdateMemberByMemberID(ctx, member1, maps.MapStrAny{
+			"external_provider": provider,
+			"external_id":       "sub-1",
+			"display_name":      "SSO User",
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("GetMembersByExternalIDs", func(t *testing.T) {
+		member2, err := testProvider.CreateMember(ctx, maps.MapStrAny{
+			"team_id":           teamID,
+			"user_id":           user2,
+			"role_id":           "user",
+			"external_provider": provider,
+			"external_id":       "sub-2",
+		})
+		assert.NoError(t, err)
+
+		members, err := testProvider.GetMembersByExternalIDs(ctx, provider, []string{"sub-1", "sub-2", "sub-missing"})
+		assert.NoError(t, err)
+		assert.Len(t, members, 2)
+
+		ids := []interface{}{members[0]["member_id"], members[1]["member_id"]}
+		assert.ElementsMatch(t, []interface{}{member1, member2}, ids)
+
+		members, err = testProvider.GetMembersByExternalIDs(ctx, provider, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, members)
+	})
+}
+
+func TestGetRobotsDueForExecution(t *testing.T) {
+	prepare(t)
+	defer clean()
+
+	ctx := context.Background()
+	testUUID := strings.ReplaceAll(uuid.New().String(), "-", "")[:8]
+	ownerUser := createTestUser(ctx, t, "owner"+testUUID)
+
+	teamID, err := testProvider.CreateTeam(ctx, maps.MapStrAny{
+		"name":     "Due Robots Team " + testUUID,
+		"owner_id": ownerUser,
+		"status":   "active",
+	})
+	assert.NoError(t, err)
+
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	createRobot := func(name string, config interface{}, schedule maps.MapStrAny) string {
+		data := maps.MapStrAny{
+			"display_name":    name + testUUID,
+			"role_id":         "bot",
+			"autonomous_mode": true,
+		}
+		if config != nil {
+			data["robot_config"] = config
+		}
+		memberID, err := testProvider.CreateRobotMember(ctx, teamID, data)
+		assert.NoError(t, err)
+		if len(schedule) > 0 {
+			assert.NoError(t, testProvider.UpdateMemberByMemberID(ctx, memberID, schedule))
+		}
+		return memberID
+	}
+
+	neverScheduled := createRobot("Never", nil, nil)
+	overdue := createRobot("Overdue", map[string]interface{}{"quota": map[string]interface{}{"priority": 9}}, maps.MapStrAny{"next_run_at": past})
+	notYet := createRobot("NotYet", nil, maps.MapStrAny{"next_run_at": future})
+	resting := createRobot("Resting", nil, maps.MapStrAny{"next_run_at": past, "cooldown_until": future})
+
+	members, err := testProvider.GetRobotsDueForExecution(ctx, now)
+	assert.NoError(t, err)
+
+	ours := map[string]bool{neverScheduled: true, overdue: true, notYet: true, resting: true}
+	var due []interface{}
+	for _, member := range members {
+		if id, ok := member["member_id"].(string); ok && ours[id] {
+			due = append(due, id)
+		}
+	}
+	assert.Equal(t, []interface{}{overdue, neverScheduled}, due, "only due robots, higher priority first")
+}
+
+func TestReassignRobotsFromManager(t *testing.T) {
+	prepare(t)
+	defer clean()
+
+	ctx := context.Background()
+	testUUID := strings.ReplaceAll(uuid.New().String(), "-", "")[:8]
+	ownerUser := createTestUser(ctx, t, "owner"+testUUID)
+	leavingUser := createTestUser(ctx, t, "leaving"+testUUID)
+	nextUser := createTestUser(ctx, t, "next"+testUUID)
+	suspendedUser := createTestUser(ctx, t, "suspended"+testUUID)
+
+	teamID, err := testProvider.CreateTeam(ctx, maps.MapStrAny{
+		"name":     "Reassign Team " + testUUID,
+		"owner_id": ownerUser,
+		"status":   "active",
+	})
+	require.NoError(t, err)
+
+	for userID, status := range map[string]string{leavingUser: "active", nextUser: "active", suspendedUser: "suspended"} {
+		_, err := testProvider.CreateMember(ctx, maps.MapStrAny{
+			"team_id":     teamID,
+			"user_id":     userID,
+			"member_type": "user",
+			"role_id":     "user",
+			"status":      status,
+		})
+		require.NoError(t, err)
+	}
+
+	createRobot := func(name, managerID string) string {
+		memberID, err := testProvider.CreateRobotMember(ctx, teamID, maps.MapStrAny{
+			"display_name": name + testUUID,
+			"role_id":      "bot",
+			"manager_id":   managerID,
+		})
+		require.NoError(t, err)
+		return memberID
+	}
+	first := createRobot("First", leavingUser)
+	second := createRobot("Second", leavingUser)
+	other := createRobot("Other", ownerUser)
+
+	t.Run("RejectsInvalidManager", func(t *testing.T) {
+		_, err := testProvider.ReassignRobotsFromManager(ctx, teamID, leavingUser, "not-a-member"+testUUID)
+		assert.Error(t, err)
+		_, err = testProvider.ReassignRobotsFromManager(ctx, teamID, leavingUser, suspendedUser)
+		assert.Error(t, err)
+		_, err = testProvider.ReassignRobotsFromManager(ctx, teamID, leavingUser, leavingUser)
+		assert.Error(t, err)
+	})
+
+	t.Run("MovesOnlyTheOldManagersRobots", func(t *testing.T) {
+		count, err := testProvider.ReassignRobotsFromManager(ctx, teamID, leavingUser, nextUser)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		for _, memberID := range []string{first, second} {
+			robot, err := testProvider.GetMemberDetailByMemberID(ctx, memberID)
+			require.NoError(t, err)
+			assert.Equal(t, nextUser, robot["manager_id"])
+		}
+		robot, err := testProvider.GetMemberDetailByMemberID(ctx, other)
+		require.NoError(t, err)
+		assert.Equal(t, ownerUser, robot["manager_id"])
+
+		count, err = testProvider.ReassignRobotsFromManager(ctx, teamID, leavingUser, nextUser)
+		require.NoError(t, err)
+		assert.Equal(t, 0, count, "nothing left to reassign")
+	})
+}
+
+func TestTransferOwnership(t *testing.T) {
+	prepare(t)
+	defer clean()
+
+	ctx := context.Background()
+	testUUID := strings.ReplaceAll(uuid.New().String(), "-", "")[:8]
+	ownerUser := createTestUser(ctx, t, "owner"+testUUID)
+	nextUser := createTestUser(ctx, t, "next"+testUUID)
+	pendingUser := createTestUser(ctx, t, "pending"+testUUID)
+
+	teamID, err := testProvider.CreateTeam(ctx, maps.MapStrAny{
+		"name":     "Ownership Team " + testUUID,
+		"owner_id": ownerUser,
+		"status":   "active",
+	})
+	require.NoError(t, err)
+
+	createMember := func(userID, roleID, status string, isOwner bool) string {
+		memberID, err := testProvider.CreateMember(ctx, maps.MapStrAny{
+			"team_id":     teamID,
+			"user_id":     userID,
+			"member_type": "user",
+			"role_id":     roleID,
+			"is_owner":    isOwner,
+			"status":      status,
+		})
+		require.NoError(t, err)
+		return memberID
+	}
+	ownerMember := createMember(ownerUser, "owner", "active", true)
+	nextMember := createMember(nextUser, "user", "active", false)
+	pendingMember := createMember(pendingUser, "user", "pending", false)
+	robotMember, err := testProvider.CreateRobotMember(ctx, teamID, maps.MapStrAny{
+		"display_name": "Robot" + testUUID,
+		"role_id":      "bot",
+	})
+	require.NoError(t, err)
+
+	ownerFlag := func(memberID string) bool {
+		member, err := testProvider.GetMemberByMemberID(ctx, memberID)
+		require.NoError(t, err)
+		switch v := member["is_owner"].(type) {
+		case bool:
+			return v
+		case int64:
+			return v != 0
+		case int:
+			return v != 0
+		}
+		return false
+	}
+
+	t.Run("RejectsInvalidTargets", func(t *testing.T) {
+		err := testProvider.TransferOwnership(ctx, teamID, robotMember, "owner", "user")
+		assert.ErrorContains(t, err, "robot")
+		err = testProvider.TransferOwnership(ctx, teamID, pendingMember, "owner", "user")
+		assert.ErrorContains(t, err, "not active")
+		err = testProvider.TransferOwnership(ctx, teamID, ownerMember, "owner", "user")
+		assert.ErrorContains(t, err, "already the team owner")
+		err = testProvider.TransferOwnership(ctx, teamID, "missing"+testUUID, "owner", "user")
+		assert.Error(t, err)
+
+		assert.True(t, ownerFlag(ownerMember), "failed transfers leave the owner in place")
+	})
+
+	t.Run("SwapsOwnerFlagsAndTeamOwner", func(t *testing.T) {
+		require.NoError(t, testProvider.TransferOwnership(ctx, teamID, nextMember, "owner", "user"))
+
+		assert.True(t, ownerFlag(nextMember))
+		assert.False(t, ownerFlag(ownerMember))
+
+		previous, err := testProvider.GetMemberByMemberID(ctx, ownerMember)
+		require.NoError(t, err)
+		assert.Equal(t, "user", previous["role_id"])
+		next, err := testProvider.GetMemberByMemberID(ctx, nextMember)
+		require.NoError(t, err)
+		assert.Equal(t, "owner", next["role_id"])
+
+		isOwner, err := testProvider.IsTeamOwner(ctx, teamID, nextUser)
+		require.NoError(t, err)
+		assert.True(t, isOwner)
+		isOwner, err = testProvider.IsTeamOwner(ctx, teamID, ownerUser)
+		require.NoError(t, err)
+		assert.False(t, isOwner)
+	})
+}
+
+func TestRevokeInvitation(t *testing.T) {
+	prepare(t)
+	defer clean()
+
+	ctx := context.Background()
+	testUUID := strings.ReplaceAll(uuid.New().String(), "-", "")[:8]
+	ownerUser := createTestUser(ctx, t, "owner"+testUUID)
+	pendingUser := createTestUser(ctx, t, "pending"+testUUID)
+	joinedUser := createTestUser(ctx, t, "joined"+testUUID)
+
+	teamID, err := testProvider.CreateTeam(ctx, maps.MapStrAny{
+		"name":     "Revoke Team " + testUUID,
+		"owner_id": ownerUser,
+		"status":   "active",
+	})
+	require.NoError(t, err)
+
+	invite := func(userID string) (string, string) {
+		_, err := testProvider.AddMember(ctx, teamID, userID, "user", ownerUser)
+		require.NoError(t, err)
+		detail, err := testProvider.GetMemberDetail(ctx, teamID, userID)
+		require.NoError(t, err)
+		return detail["invitation_id"].(string), detail["invitation_token"].(string)
+	}
+	pendingID, pendingToken := invite(pendingUser)
+	joinedID, joinedToken := invite(joinedUser)
+	require.NoError(t, testProvider.AcceptInvitation(ctx, joinedID, joinedToken, ""))
+
+	t.Run("RevokesPending", func(t *testing.T) {
+		revoked, err := testProvider.RevokeInvitation(ctx, pendingID)
+		require.NoError(t, err)
+		assert.Equal(t, pendingUser, revoked["user_id"])
+		assert.Equal(t, teamID, revoked["team_id"])
+
+		exists, err := testProvider.MemberExists(ctx, teamID, pendingUser)
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		err = testProvider.AcceptInvitation(ctx, pendingID, pendingToken, pendingUser)
+		assert.Error(t, err, "the revoked link no longer works")
+	})
+
+	t.Run("AlreadyAccepted", func(t *testing.T) {
+		_, err := testProvider.RevokeInvitation(ctx, joinedID)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, user.ErrInvitationAccepted)
+
+		member, err := testProvider.GetMember(ctx, teamID, joinedUser)
+		require.NoError(t, err)
+		assert.Equal(t, "active", member["status"])
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		_, err := testProvider.RevokeInvitation(ctx, pendingID)
+		require.Error(t, err)
+		assert.Equal(t, user.ErrMemberNotFound, err.Error())
+	})
+}
+
+func TestMemberQueriesHonorContext(t *testing.T) {
+	prepare(t)
+	defer clean()
+
+	ctx := context.Background()
+	testUUID := strings.ReplaceAll(uuid.New().String(), "-", "")[:8]
+	ownerUser := createTestUser(ctx, t, "ctxowner"+testUUID)
+	memberUser := createTestUser(ctx, t, "ctxmember"+testUUID)
+
+	teamID, err := testProvider.CreateTeam(ctx, maps.MapStrAny{
+		"name":     "Context Team " + testUUID,
+		"owner_id": ownerUser,
+		"status":   "active",
+		"type":     "corporation",
+		"type_id":  "business",
+	})
+	require.NoError(t, err)
+	memberID, err := testProvider.AddMember(ctx, teamID, memberUser, "user", ownerUser)
+	require.NoError(t, err)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	expired, cancelExpired := context.WithTimeout(ctx, time.Nanosecond)
+	defer cancelExpired()
+	<-expired.Done()
+
+	for name, queryCtx := range map[string]context.Context{"Cancelled": cancelled, "Expired": expired} {
+		t.Run(name, func(t *testing.T) {
+			_, err := testProvider.GetMemberByMemberID(queryCtx, memberID)
+			assert.ErrorIs(t, err, queryCtx.Err())
+
+			_, err = testProvider.GetMemberDetailByMemberIDWithFields(queryCtx, memberID, nil)
+			assert.ErrorIs(t, err, queryCtx.Err())
+
+			_, err = testProvider.PaginateMembers(queryCtx, model.QueryParam{
+				Wheres: []model.QueryWhere{{Column: "team_id", Value: teamID}},
+			}, 1, 10)
+			assert.ErrorIs(t, err, queryCtx.Err())
+
+			_, _, err = testProvider.CheckTeamAccess(queryCtx, teamID, memberUser)
+			assert.ErrorIs(t, err, queryCtx.Err())
+		})
+	}
+
+	// A live context still reads
+	member, err := testProvider.GetMemberByMemberID(ctx, memberID)
+	require.NoError(t, err)
+	assert.Equal(t, memberID, member["member_id"])
+}
+
+func TestOnMemberWrite(t *testing.T) {
+	prepare(t)
+	defer clean()
+
+	ctx := context.Background()
+	testUUID := strings.ReplaceAll(uuid.New().String(), "-", "")[:8]
+	ownerUser := createTestUser(ctx, t, "owner"+testUUID)
+
+	teamID, err := testProvider.CreateTeam(ctx, maps.MapStrAny{
+		"name":     "Write Hook Team " + testUUID,
+		"owner_id": ownerUser,
+		"status":   "active",
+	})
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var writes []string
+	user.OnMemberWrite(func(team string) {
+		mu.Lock()
+		defer mu.Unlock()
+		writes = append(writes, team)
+	})
+	taken := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		w := writes
+		writes = nil
+		return w
+	}
+
+	memberID, err := testProvider.CreateRobotMember(ctx, teamID, maps.MapStrAny{
+		"display_name": "Hooked " + testUUID,
+		"role_id":      "bot",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{teamID}, taken(), "create names the team")
+
+	require.NoError(t, testProvider.UpdateMemberByMemberID(ctx, memberID, maps.MapStrAny{"bio": "updated"}))
+	assert.Equal(t, []string{""}, taken(), "a write by member_id may touch any team")
+
+	require.NoError(t, testProvider.RemoveMemberByMemberID(ctx, memberID))
+	assert.Equal(t, []string{""}, taken())
+
+	require.NoError(t, testProvider.RestoreMemberByMemberID(ctx, teamID, memberID))
+	assert.Equal(t, []string{teamID}, taken())
+
+	_, err = testProvider.GetMemberByMemberID(ctx, memberID)
+	require.NoError(t, err)
+	assert.Empty(t, taken(), "reads do not notify")
+
+	assert.Error(t, testProvider.RemoveMemberByMemberID(ctx, "missing"+testUUID))
+	assert.Empty(t, taken(), "failed writes do not notify")
+}
@@ -2,7 +2,9 @@ package user
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/yaoapp/gou/model"
@@ -13,6 +15,10 @@ import (
 
 // GetTeam retrieves team information by team_id
 func (u *DefaultUser) GetTeam(ctx context.Context, teamID string) (maps.MapStrAny, error) {
+	if err := contextErr(ctx); err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetTeam, err)
+	}
+
 	m := model.Select(u.teamModel)
 	teams, err := m.Get(model.QueryParam{
 		Select: u.teamFields,
@@ -563,3 +569,124 @@ func (u *DefaultUser) CheckTeamAccess(ctx context.Context, teamID string, userID
 
 	return isOwner, isMember, nil
 }
+
+// GetTeamAccessInfo describes a user's standing in a team: {is_owner, is_member,
+// member_id, role_id, status}. is_member follows CheckTeamAccess (any member record,
+// pending invitations included); status tells them apart. Without a member record
+// member_id, role_id and status are empty.
+func (u *DefaultUser) GetTeamAccessInfo(ctx context.Context, teamID string, userID string) (maps.MapStrAny, error) {
+	isOwner, err := u.IsTeamOwner(ctx, teamID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	m := model.Select(u.memberModel)
+	members, err := m.Get(model.QueryParam{
+		Select: []interface{}{"member_id", "role_id", "status"},
+		Wheres: []model.QueryWhere{
+			{Column: "team_id", Value: teamID},
+			{Column: "user_id", Value: userID},
+		},
+		Limit: 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetMember, err)
+	}
+
+	info := maps.MapStrAny{
+		"is_owner":  isOwner,
+		"is_member": isOwner || len(members) > 0,
+		"member_id": "",
+		"role_id":   "",
+		"status":    "",
+	}
+	if len(members) > 0 {
+		for _, field := range []string{"member_id", "role_id", "status"} {
+			if v, ok := members[0][field].(string); ok {
+				info[field] = v
+			}
+		}
+	}
+	return info, nil
+}
+
+// Team Language Models
+
+// GetTeamAllowedLanguageModels returns settings.allowed_language_models of a team.
+// An empty list means the team does not restrict robot language models.
+func (u *DefaultUser) GetTeamAllowedLanguageModels(ctx context.Context, teamID string) ([]string, error) {
+	m := model.Select(u.teamModel)
+	teams, err := m.Get(model.QueryParam{
+		Select: []interface{}{"settings"},
+		Wheres: []model.QueryWhere{
+			{Column: "team_id", Value: teamID},
+		},
+		Limit: 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf(ErrFailedToGetTeam, err)
+	}
+	if len(teams) == 0 {
+		return nil, fmt.Errorf(ErrTeamNotFound)
+	}
+	return AllowedLanguageModels(teams[0]["settings"]), nil
+}
+
+// AllowedLanguageModels reads allowed_language_models from a team settings value
+// (a map, or the raw JSON some drivers return)
+func AllowedLanguageModels(settings interface{}) []string {
+	var raw []byte
+	switch v := settings.(type) {
+	case nil:
+		return nil
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	default:
+		raw, _ = json.Marshal(v)
+	}
+
+	var parsed struct {
+		AllowedLanguageModels []string `json:"allowed_language_models"`
+	}
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		return nil
+	}
+
+	allowed := make([]string, 0, len(parsed.AllowedLanguageModels))
+	for _, name := range parsed.AllowedLanguageModels {
+		if name = strings.TrimSpace(name); name != "" {
+			allowed = append(allowed, name)
+		}
+	}
+	return allowed
+}
+
+// CheckLanguageModel returns an error when languageModel is set and not in allowed.
+// An empty allowed list permits every model.
+func CheckLanguageModel(allowed []string, languageModel string) error {
+	languageModel = strings.TrimSpace(languageModel)
+	if languageModel == "" || len(allowed) == 0 {
+		return nil
+	}
+	for _, name := range allowed {
+		if name == languageModel {
+			return nil
+		}
+	}
+	return fmt.Errorf(ErrLanguageModelNotAllowed, languageModel)
+}
+
+// checkRobotLanguageModel validates robotData.language_model against the team allowlist
+func (u *DefaultUser) checkRobotLanguageModel(ctx context.Context, teamID string, robotData maps.MapStrAny) error {
+	languageModel, _ := robotData["language_model"].(string)
+	if strings.TrimSpace(languageModel) == "" || teamID == "" {
+		return nil
+	}
+	allowed, err := u.GetTeamAllowedLanguageModels(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	return CheckLanguageModel(allowed, languageModel)
+}
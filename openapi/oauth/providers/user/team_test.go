@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/yaoapp/gou/model"
 	"github.com/yaoapp/kun/maps"
+	"github.com/yaoapp/yao/openapi/oauth/providers/user"
 )
 
 // TestTeamData represents test team data structure
@@ -573,3 +574,121 @@ func createTestUser(ctx context.Context, t *testing.T, suffix string) string {
 	assert.NoError(t, err)
 	return userMap["user_id"].(string)
 }
+
+func TestTeamAllowedLanguageModels(t *testing.T) {
+	prepare(t)
+	defer clean()
+
+	ctx := context.Background()
+	testUUID := strings.ReplaceAll(uuid.New().String(), "-", "")[:8]
+	ownerUser := createTestUser(ctx, t, "llmowner"+testUUID)
+
+	teamID, err := testProvider.CreateTeam(ctx, maps.MapStrAny{
+		"name":     "LLM Team " + testUUID,
+		"owner_id": ownerUser,
+		"status":   "active",
+		"type":     "corporation",
+		"type_id":  "business",
+		"settings": map[string]interface{}{
+			"allowed_language_models": []string{"gpt-4o-mini", "deepseek-chat"},
+		},
+	})
+	assert.NoError(t, err)
+
+	t.Run("GetTeamAllowedLanguageModels", func(t *testing.T) {
+		allowed, err := testProvider.GetTeamAllowedLanguageModels(ctx, teamID)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"gpt-4o-mini", "deepseek-chat"}, allowed)
+	})
+
+	t.Run("CreateRobotMember rejects a model outside the allowlist", func(t *testing.T) {
+		_, err := testProvider.CreateRobotMember(ctx, teamID, maps.MapStrAny{
+			"display_name":   "Pricey Bot " + testUUID,
+			"role_id":        "bot",
+			"language_model": "gpt-4",
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not allowed")
+	})
+
+	t.Run("CreateRobotMember and UpdateRobotMember with allowed models", func(t *testing.T) {
+		memberID, err := testProvider.CreateRobotMember(ctx, teamID, maps.MapStrAny{
+			"display_name":   "Budget Bot " + testUUID,
+			"role_id":        "bot",
+			"language_model": "gpt-4o-mini",
+		})
+		assert.NoError(t, err)
+
+		err = testProvider.UpdateRobotMember(ctx, memberID, maps.MapStrAny{"language_model": "deepseek-chat"})
+		assert.NoError(t, err)
+
+		err = testProvider.UpdateRobotMember(ctx, memberID, maps.MapStrAny{"language_model": "gpt-4"})
+		assert.Error(t, err)
+	})
+
+	t.Run("CheckLanguageModel", func(t *testing.T) {
+		assert.NoError(t, user.CheckLanguageModel(nil, "anything"))
+		assert.NoError(t, user.CheckLanguageModel([]string{"a"}, ""))
+		assert.NoError(t, user.CheckLanguageModel([]string{"a", "b"}, "b"))
+		assert.Error(t, user.CheckLanguageModel([]string{"a"}, "c"))
+		assert.Equal(t, []string{"x"}, user.AllowedLanguageModels(`{"allowed_language_models":["x"," "]}`))
+	})
+}
+
+func TestTeamAccessInfo(t *testing.T) {
+	prepare(t)
+	defer clean()
+
+	ctx := context.Background()
+	testUUID := strings.ReplaceAll(uuid.New().String(), "-", "")[:8]
+	ownerUser := createTestUser(ctx, t, "accessowner"+testUUID)
+	memberUser := createTestUser(ctx, t, "accessmember"+testUUID)
+	outsider := createTestUser(ctx, t, "accessoutsider"+testUUID)
+
+	teamID, err := testProvider.CreateTeam(ctx, maps.MapStrAny{
+		"name":     "Access Team " + testUUID,
+		"owner_id": ownerUser,
+		"status":   "active",
+		"type":     "corporation",
+		"type_id":  "business",
+	})
+	assert.NoError(t, err)
+
+	memberID, err := testProvider.AddMember(ctx, teamID, memberUser, "user", ownerUser)
+	assert.NoError(t, err)
+
+	t.Run("Owner", func(t *testing.T) {
+		info, err := testProvider.GetTeamAccessInfo(ctx, teamID, ownerUser)
+		assert.NoError(t, err)
+		assert.Equal(t, true, info["is_owner"])
+		assert.Equal(t, true, info["is_member"])
+	})
+
+	t.Run("Member", func(t *testing.T) {
+		info, err := testProvider.GetTeamAccessInfo(ctx, teamID, memberUser)
+		assert.NoError(t, err)
+		assert.Equal(t, false, info["is_owner"])
+		assert.Equal(t, true, info["is_member"])
+		assert.Equal(t, memberID, info["member_id"])
+		assert.Equal(t, "user", info["role_id"])
+		assert.NotEmpty(t, info["status"])
+
+		isOwner, isMember, err := testProvider.CheckTeamAccess(ctx, teamID, memberUser)
+		assert.NoError(t, err)
+		assert.Equal(t, isOwner, info["is_owner"])
+		assert.Equal(t, isMember, info["is_member"])
+	})
+
+	t.Run("Outsider", func(t *testing.T) {
+		info, err := testProvider.GetTeamAccessInfo(ctx, teamID, outsider)
+		assert.NoError(t, err)
+		assert.Equal(t, false, info["is_owner"])
+		assert.Equal(t, false, info["is_member"])
+		assert.Equal(t, "", info["member_id"])
+	})
+
+	t.Run("TeamNotFound", func(t *testing.T) {
+		_, err := testProvider.GetTeamAccessInfo(ctx, "no_such_team_"+testUUID, ownerUser)
+		assert.Error(t, err)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rand"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,6 +14,16 @@ import (
 
 // Utils
 
+// contextErr reports why ctx ended, nil while it is live. gou models take no context,
+// so a query can't be interrupted once issued; read paths check ctx before querying so
+// a cancelled or expired request starts no further database work.
+func contextErr(ctx context.Context) error {
+	if ctx == nil {
+		return nil
+	}
+	return ctx.Err()
+}
+
 // GenerateUserID generates a new unique user_id for user creation
 // safe: optional parameter, if true check for collisions and retry if needed
 //
@@ -270,7 +281,24 @@ func generateRandomPassword(length int) (string, error) {
 	return string(bytes), nil
 }
 
-// parseTimeFromDB parses time values from database fields, handling different formats and types
+// dbTimeZonedFormats carry their own offset and are parsed as written
+var dbTimeZonedFormats = []string{
+	time.RFC3339,                          // 2006-01-02T15:04:05.999Z07:00
+	"2006-01-02 15:04:05Z07:00",           // SQLite driver (mattn) storage format
+	"2006-01-02 15:04:05-07",              // PostgreSQL timestamptz as text
+	"2006-01-02 15:04:05 -0700 MST",       // time.Time.String()
+	"2006-01-02 15:04:05.999999999 -0700", // time.Time.String() without the zone name
+}
+
+// dbTimeNaiveFormats have no offset. Drivers write time.Time values converted to UTC
+// (MySQL's default loc), so these are read as UTC rather than in the server's zone.
+var dbTimeNaiveFormats = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+}
+
+// parseTimeFromDB parses time values from database fields, handling different formats and types.
+// Fractional seconds are accepted in every format; values without an offset are UTC.
 func parseTimeFromDB(value interface{}) (*time.Time, error) {
 	if value == nil {
 		return nil, nil
@@ -278,23 +306,37 @@ func parseTimeFromDB(value interface{}) (*time.Time, error) {
 
 	switch v := value.(type) {
 	case time.Time:
+		if v.IsZero() {
+			return nil, nil
+		}
 		return &v, nil
-	case string:
-		if v == "" {
+	case *time.Time:
+		if v == nil || v.IsZero() {
 			return nil, nil
 		}
-		// Try parsing common time formats - assume local timezone for database timestamps
-		if parsedTime, err := time.ParseInLocation("2006-01-02 15:04:05", v, time.Local); err == nil {
-			return &parsedTime, nil
+		return v, nil
+	case []byte:
+		return parseTimeFromDB(string(v))
+	case int64:
+		if v <= 0 {
+			return nil, nil
 		}
-		if parsedTime, err := time.Parse(time.RFC3339, v); err == nil {
-			return &parsedTime, nil
+		t := time.Unix(v, 0)
+		return &t, nil
+	case string:
+		v = strings.TrimSpace(v)
+		if v == "" {
+			return nil, nil
 		}
-		if parsedTime, err := time.ParseInLocation("2006-01-02T15:04:05", v, time.Local); err == nil {
-			return &parsedTime, nil
+		for _, layout := range dbTimeZonedFormats {
+			if parsedTime, err := time.Parse(layout, v); err == nil {
+				return &parsedTime, nil
+			}
 		}
-		if parsedTime, err := time.ParseInLocation("2006-01-02 15:04:05.000000", v, time.Local); err == nil {
-			return &parsedTime, nil
+		for _, layout := range dbTimeNaiveFormats {
+			if parsedTime, err := time.ParseInLocation(layout, v, time.UTC); err == nil {
+				return &parsedTime, nil
+			}
 		}
 		return nil, fmt.Errorf("unable to parse time format: %s", v)
 	default:
@@ -343,6 +385,12 @@ func parseIntFromDB(value interface{}) (int64, error) {
 
 // checkTimeExpired checks if a time field from database indicates expiration
 func checkTimeExpired(value interface{}) (bool, error) {
+	return checkTimeExpiredAt(value, time.Now())
+}
+
+// checkTimeExpiredAt compares the stored time with now as instants, so the server
+// zone, the stored offset and DST transitions do not shift the result
+func checkTimeExpiredAt(value interface{}, now time.Time) (bool, error) {
 	parsedTime, err := parseTimeFromDB(value)
 	if err != nil {
 		return false, err
@@ -350,5 +398,12 @@ func checkTimeExpired(value interface{}) (bool, error) {
 	if parsedTime == nil {
 		return false, nil // No expiry time set
 	}
-	return time.Now().After(*parsedTime), nil
+	return now.After(*parsedTime), nil
+}
+
+// InvitationExpired reports whether an invitation_expires_at value read from the
+// database lies before now. Unset or unparseable values count as not expired.
+func InvitationExpired(value interface{}, now time.Time) bool {
+	expired, err := checkTimeExpiredAt(value, now)
+	return err == nil && expired
 }
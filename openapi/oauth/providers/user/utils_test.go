@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -354,3 +355,53 @@ func TestIDStrategyConfiguration(t *testing.T) {
 		assert.Len(t, userID, 36)
 	})
 }
+
+func TestInvitationExpired(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// 2025-03-09 02:00 EST -> 03:00 EDT; 06:30 UTC is 01:30 EST, 07:30 UTC is 03:30 EDT
+	expiry := time.Date(2025, 3, 9, 7, 0, 0, 0, time.UTC)
+	before := time.Date(2025, 3, 9, 6, 59, 0, 0, time.UTC)
+	after := time.Date(2025, 3, 9, 7, 1, 0, 0, time.UTC)
+
+	stored := map[string]interface{}{
+		"time.Time utc":        expiry,
+		"time.Time new york":   expiry.In(newYork),
+		"pointer":              &expiry,
+		"naive string is utc":  "2025-03-09 07:00:00",
+		"naive iso":            "2025-03-09T07:00:00",
+		"naive with fraction":  "2025-03-09 07:00:00.000000",
+		"bytes":                []byte("2025-03-09 07:00:00"),
+		"rfc3339 offset":       "2025-03-09T03:00:00-04:00",
+		"sqlite driver format": "2025-03-09 02:00:00.000000000-05:00",
+		"postgres text":        "2025-03-09 07:00:00+00",
+		"time string":          expiry.In(newYork).String(),
+	}
+
+	for name, value := range stored {
+		t.Run(name, func(t *testing.T) {
+			assert.False(t, user.InvitationExpired(value, before), "one minute before expiry")
+			assert.True(t, user.InvitationExpired(value, after), "one minute after expiry")
+			// Where the server runs does not matter
+			assert.False(t, user.InvitationExpired(value, before.In(newYork)))
+			assert.True(t, user.InvitationExpired(value, after.In(newYork)))
+		})
+	}
+
+	t.Run("fall back transition", func(t *testing.T) {
+		// 2025-11-02 01:30 occurs twice in New York; the offset decides which one
+		firstPass := "2025-11-02T01:30:00-04:00"  // 05:30 UTC
+		secondPass := "2025-11-02T01:30:00-05:00" // 06:30 UTC
+		now := time.Date(2025, 11, 2, 6, 0, 0, 0, time.UTC)
+		assert.True(t, user.InvitationExpired(firstPass, now))
+		assert.False(t, user.InvitationExpired(secondPass, now))
+	})
+
+	t.Run("unset or unparseable never expires", func(t *testing.T) {
+		assert.False(t, user.InvitationExpired(nil, after))
+		assert.False(t, user.InvitationExpired("", after))
+		assert.False(t, user.InvitationExpired(time.Time{}, after))
+		assert.False(t, user.InvitationExpired("next tuesday", after))
+	})
+}
@@ -2,6 +2,7 @@ package types
 
 import (
 	"context"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/yaoapp/gou/model"
@@ -287,6 +288,7 @@ type UserProvider interface {
 	IsTeamOwner(ctx context.Context, teamID string, userID string) (bool, error)
 	IsTeamMember(ctx context.Context, teamID string, userID string) (bool, error)
 	CheckTeamAccess(ctx context.Context, teamID string, userID string) (isOwner bool, isMember bool, err error)
+	GetTeamAccessInfo(ctx context.Context, teamID string, userID string) (maps.MapStrAny, error)
 
 	// ============================================================================
 	// Member Resource
@@ -298,7 +300,10 @@ type UserProvider interface {
 	GetMemberByID(ctx context.Context, memberID int64) (maps.MapStrAny, error)
 	GetMemberByMemberID(ctx context.Context, memberID string) (maps.MapStrAny, error)
 	GetMemberDetailByMemberID(ctx context.Context, memberID string) (maps.MapStrAny, error)
+	GetMemberDetailByMemberIDWithFields(ctx context.Context, memberID string, fields []interface{}) (maps.MapStrAny, error)
 	GetMemberByInvitationID(ctx context.Context, invitationID string) (maps.MapStrAny, error)
+	GetMemberByExternalID(ctx context.Context, provider string, externalID string) (maps.MapStrAny, error)
+	GetMembersByExternalIDs(ctx context.Context, provider string, externalIDs []string) ([]maps.MapStr, error)
 	MemberExists(ctx context.Context, teamID string, userID string) (bool, error)
 	MemberExistsByRobotEmail(ctx context.Context, robotEmail string) (bool, error)
 	CreateMember(ctx context.Context, memberData maps.MapStrAny) (string, error)
@@ -308,7 +313,9 @@ type UserProvider interface {
 	UpdateMemberByInvitationID(ctx context.Context, invitationID string, memberData maps.MapStrAny) error
 	RemoveMember(ctx context.Context, teamID string, userID string) error
 	RemoveMemberByMemberID(ctx context.Context, memberID string) error
+	RestoreMemberByMemberID(ctx context.Context, teamID string, memberID string) error
 	RemoveMemberByInvitationID(ctx context.Context, invitationID string) error
+	RevokeInvitation(ctx context.Context, invitationID string) (maps.MapStrAny, error)
 	RemoveAllTeamMembers(ctx context.Context, teamID string) error
 
 	// Member Invitation Management
@@ -318,25 +325,30 @@ type UserProvider interface {
 	// Robot Member Operations
 	CreateRobotMember(ctx context.Context, teamID string, robotData maps.MapStrAny) (string, error)
 	UpdateRobotMember(ctx context.Context, memberID string, robotData maps.MapStrAny) error
+	ReassignRobotsFromManager(ctx context.Context, teamID string, oldManagerID string, newManagerID string) (int, error)
 	UpdateRobotActivity(ctx context.Context, memberID int64, robotStatus string) error
 	GetActiveRobotMembers(ctx context.Context) ([]maps.MapStr, error)
+	GetRobotsDueForExecution(ctx context.Context, now time.Time) ([]maps.MapStr, error)
 
 	// Member Query Methods
 	GetTeamMembers(ctx context.Context, teamID string) ([]maps.MapStr, error)
 	GetUserTeams(ctx context.Context, userID string) ([]maps.MapStr, error)
 	GetTeamMembersByStatus(ctx context.Context, teamID string, status string) ([]maps.MapStr, error)
 	GetTeamRobotMembers(ctx context.Context, teamID string) ([]maps.MapStr, error)
+	GetTeamMemberEmails(ctx context.Context, teamID string, email string) ([]string, error)
 
 	// Member Management
 	UpdateMemberRole(ctx context.Context, teamID string, userID string, roleID string) error
 	UpdateMemberRoleByMemberID(ctx context.Context, memberID string, roleID string) error
 	UpdateMemberStatus(ctx context.Context, teamID string, userID string, status string) error
-	UpdateMemberStatusByMemberID(ctx context.Context, memberID string, status string) error
+	UpdateMemberStatusByMemberID(ctx context.Context, memberID string, status string, reason ...string) error
+	TransferOwnership(ctx context.Context, teamID string, memberID string, ownerRoleID string, memberRoleID string) error
 	UpdateMemberLastActivity(ctx context.Context, teamID string, userID string) error
 	UpdateMemberLastActivityByMemberID(ctx context.Context, memberID string) error
 
 	// Member List and Search
 	PaginateMembers(ctx context.Context, param model.QueryParam, page int, pagesize int) (maps.MapStr, error)
+	PaginateMembersCursor(ctx context.Context, param model.QueryParam, cursor string, limit int) (maps.MapStr, error)
 
 	// ============================================================================
 	// Invitation Code Resource (Official Platform Invitation Codes)
@@ -777,6 +777,208 @@ func TestMultipleInvitationCreation(t *testing.T) {
 	})
 }
 
+// TestMemberBulkInvite tests the POST /user/teams/:team_id/members/bulk-invite endpoint
+func TestMemberBulkInvite(t *testing.T) {
+	serverURL := testutils.Prepare(t)
+	defer testutils.Clean()
+
+	// Get base URL from server config
+	baseURL := ""
+	if openapi.Server != nil && openapi.Server.Config != nil {
+		baseURL = openapi.Server.Config.BaseURL
+	}
+
+	// Register test client
+	client := testutils.RegisterTestClient(t, "Bulk Invite Test Client", []string{"https://localhost/callback"})
+	defer testutils.CleanupTestClient(t, client.ClientID)
+
+	// Get access token with root permissions
+	tokenInfo := testutils.ObtainAccessTokenWithRootPermission(t, serverURL, client.ClientID, client.ClientSecret, "https://localhost/callback", "openid profile")
+
+	// Use UUID to ensure unique identifiers
+	testUUID := strings.ReplaceAll(uuid.New().String(), "-", "")[:8]
+
+	createdTeam := createTestTeam(t, serverURL, baseURL, tokenInfo.AccessToken, "Bulk Invite Test Team "+testUUID)
+	teamID := getTeamID(createdTeam)
+
+	bulkInvite := func(t *testing.T, body map[string]interface{}) (int, map[string]interface{}) {
+		jsonData, err := json.Marshal(body)
+		assert.NoError(t, err)
+
+		url := fmt.Sprintf("%s%s/user/teams/%s/members/bulk-invite", serverURL, baseURL, teamID)
+		req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+		req.Header.Set("Content-Type", "application/json")
+
+		client := &http.Client{Timeout: 10 * time.Second}
+		resp, err := client.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		var result map[string]interface{}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		assert.NoError(t, err)
+		return resp.StatusCode, result
+	}
+
+	first := fmt.Sprintf("first-%s@example.com", testUUID)
+	second := fmt.Sprintf("second-%s@example.com", testUUID)
+
+	t.Run("PartialFailure", func(t *testing.T) {
+		status, result := bulkInvite(t, map[string]interface{}{
+			"send_email": false,
+			"invitations": []map[string]interface{}{
+				{"email": first, "role_id": "user", "display_name": "First"},
+				{"email": "not-an-email", "role_id": "user"},
+				{"email": second, "role_id": "user"},
+				{"email": strings.ToUpper(first), "role_id": "user"},
+				{"email": "norole-" + testUUID + "@example.com"},
+			},
+		})
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, float64(2), result["invited"])
+		assert.Equal(t, float64(3), result["failed"])
+
+		rows, ok := result["results"].([]interface{})
+		if !assert.True(t, ok) || !assert.Len(t, rows, 5) {
+			return
+		}
+		expected := []struct {
+			status string
+			error  string
+		}{
+			{"invited", ""},
+			{"failed", "invalid email"},
+			{"invited", ""},
+			{"failed", "duplicate"},
+			{"failed", "role_id is required"},
+		}
+		for i, want := range expected {
+			row := rows[i].(map[string]interface{})
+			assert.Equal(t, want.status, row["status"], "row %d", i)
+			if want.error == "" {
+				assert.NotEmpty(t, row["member_id"], "row %d", i)
+				assert.True(t, strings.HasPrefix(toString(row["invitation_id"]), "inv_"), "row %d", i)
+			} else {
+				assert.Contains(t, toString(row["error"]), want.error, "row %d", i)
+			}
+		}
+	})
+
+	t.Run("AlreadyInvited", func(t *testing.T) {
+		status, result := bulkInvite(t, map[string]interface{}{
+			"send_email":  false,
+			"invitations": []map[string]interface{}{{"email": second, "role_id": "user"}},
+		})
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, float64(0), result["invited"])
+		rows := result["results"].([]interface{})
+		assert.Contains(t, toString(rows[0].(map[string]interface{})["error"]), "pending invitation")
+	})
+
+	t.Run("EmptyRequest", func(t *testing.T) {
+		status, _ := bulkInvite(t, map[string]interface{}{"invitations": []interface{}{}})
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+}
+
+// TestMemberInvite tests the POST /user/teams/:team_id/members/invitations endpoints
+func TestMemberInvite(t *testing.T) {
+	serverURL := testutils.Prepare(t)
+	defer testutils.Clean()
+
+	// Get base URL from server config
+	baseURL := ""
+	if openapi.Server != nil && openapi.Server.Config != nil {
+		baseURL = openapi.Server.Config.BaseURL
+	}
+
+	// Register test client
+	client := testutils.RegisterTestClient(t, "Member Invite Test Client", []string{"https://localhost/callback"})
+	defer testutils.CleanupTestClient(t, client.ClientID)
+
+	// Get access token with root permissions
+	tokenInfo := testutils.ObtainAccessTokenWithRootPermission(t, serverURL, client.ClientID, client.ClientSecret, "https://localhost/callback", "openid profile")
+
+	// Use UUID to ensure unique identifiers
+	testUUID := strings.ReplaceAll(uuid.New().String(), "-", "")[:8]
+
+	createdTeam := createTestTeam(t, serverURL, baseURL, tokenInfo.AccessToken, "Member Invite Test Team "+testUUID)
+	teamID := getTeamID(createdTeam)
+	invitationsURL := fmt.Sprintf("%s%s/user/teams/%s/members/invitations", serverURL, baseURL, teamID)
+
+	post := func(t *testing.T, url string, body map[string]interface{}) (int, map[string]interface{}) {
+		jsonData, err := json.Marshal(body)
+		assert.NoError(t, err)
+
+		req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+		req.Header.Set("Content-Type", "application/json")
+
+		client := &http.Client{Timeout: 30 * time.Second}
+		resp, err := client.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		var result map[string]interface{}
+		_ = json.NewDecoder(resp.Body).Decode(&result)
+		return resp.StatusCode, result
+	}
+
+	email := fmt.Sprintf("invitee-%s@example.com", testUUID)
+	var invitationID, link string
+
+	t.Run("Invite", func(t *testing.T) {
+		status, result := post(t, invitationsURL, map[string]interface{}{
+			"email":   email,
+			"role_id": "user",
+			"message": "Welcome aboard",
+		})
+		if !assert.Equal(t, http.StatusCreated, status, "%v", result) {
+			return
+		}
+		invitationID = toString(result["invitation_id"])
+		link = toString(result["invitation_link"])
+		assert.NotEmpty(t, result["member_id"])
+		assert.True(t, strings.HasPrefix(invitationID, "inv_"))
+		assert.Contains(t, link, invitationID, "the link can be shared by hand")
+		assert.NotEmpty(t, result["invitation_expires_at"])
+		if result["email_sent"] != true {
+			assert.NotEmpty(t, result["email_error"], "an unsent email says why")
+		}
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		status, _ := post(t, invitationsURL, map[string]interface{}{"email": strings.ToUpper(email), "role_id": "user"})
+		assert.Equal(t, http.StatusConflict, status)
+	})
+
+	t.Run("InvalidEmail", func(t *testing.T) {
+		status, _ := post(t, invitationsURL, map[string]interface{}{"email": "not-an-email", "role_id": "user"})
+		assert.Equal(t, http.StatusBadRequest, status)
+
+		status, _ = post(t, invitationsURL, map[string]interface{}{"email": "norole-" + testUUID + "@example.com"})
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("Resend", func(t *testing.T) {
+		if invitationID == "" {
+			t.Skip("invite failed")
+		}
+		status, result := post(t, invitationsURL+"/"+invitationID+"/resend", nil)
+		if !assert.Equal(t, http.StatusOK, status, "%v", result) {
+			return
+		}
+		assert.Equal(t, invitationID, result["invitation_id"])
+		assert.NotEqual(t, link, result["invitation_link"], "resend issues a new token")
+
+		status, _ = post(t, invitationsURL+"/inv_missing/resend", nil)
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+}
+
 // TestInvitationDelete tests the DELETE /user/teams/:team_id/invitations/:invitation_id endpoint
 func TestInvitationDelete(t *testing.T) {
 	serverURL := testutils.Prepare(t)
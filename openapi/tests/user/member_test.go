@@ -3,18 +3,28 @@ package user_test
 import (
 	"bytes"
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/gou/session"
 	"github.com/yaoapp/kun/maps"
+	robotevents "github.com/yaoapp/yao/agent/robot/events"
+	"github.com/yaoapp/yao/event"
+	eventtypes "github.com/yaoapp/yao/event/types"
 	"github.com/yaoapp/yao/openapi"
+	oauthuser "github.com/yaoapp/yao/openapi/oauth/providers/user"
 	"github.com/yaoapp/yao/openapi/tests/testutils"
+	"github.com/yaoapp/yao/openapi/user"
 )
 
 // TestMemberList tests the GET /user/teams/:team_id/members endpoint
@@ -297,6 +307,46 @@ func TestMemberList(t *testing.T) {
 				}
 			},
 		},
+		{
+			"list members with keyword search",
+			teamID,
+			"?keyword=" + testUUID,
+			map[string]string{
+				"Authorization": "Bearer " + tokenInfo.AccessToken,
+			},
+			200,
+			"should match the robot by display name or email and echo the keyword",
+			func(t *testing.T, response map[string]interface{}) {
+				assert.Equal(t, testUUID, response["keyword"], "Should echo the keyword")
+				data, _ := response["data"].([]interface{})
+				assert.Len(t, data, 1, "Only the robot should match")
+			},
+		},
+		{
+			"list members with keyword combined with member_type",
+			teamID,
+			"?keyword=" + testUUID + "&member_type=user",
+			map[string]string{
+				"Authorization": "Bearer " + tokenInfo.AccessToken,
+			},
+			200,
+			"should AND the keyword with the other filters",
+			func(t *testing.T, response map[string]interface{}) {
+				data, _ := response["data"].([]interface{})
+				assert.Empty(t, data, "The robot should be excluded by member_type")
+			},
+		},
+		{
+			"list members with too short keyword",
+			teamID,
+			"?keyword=a",
+			map[string]string{
+				"Authorization": "Bearer " + tokenInfo.AccessToken,
+			},
+			400,
+			"should reject keywords shorter than 2 characters",
+			nil,
+		},
 		{
 			"list members with invalid status value",
 			teamID,
@@ -421,6 +471,547 @@ func TestMemberList(t *testing.T) {
 	}
 }
 
+// TestMemberListRobotEmailFilter tests the robot_email filter of GET /user/teams/:team_id/members
+func TestMemberListRobotEmailFilter(t *testing.T) {
+	// Initialize test environment
+	serverURL := testutils.Prepare(t)
+	defer testutils.Clean()
+
+	// Get base URL from server config
+	baseURL := ""
+	if openapi.Server != nil && openapi.Server.Config != nil {
+		baseURL = openapi.Server.Config.BaseURL
+	}
+
+	// Register a test client for OAuth authentication
+	testClient := testutils.RegisterTestClient(t, "Member Robot Email Filter Test Client", []string{"https://localhost/callback"})
+	defer testutils.CleanupTestClient(t, testClient.ClientID)
+
+	// Obtain access token for authenticated requests
+	tokenInfo := testutils.ObtainAccessToken(t, serverURL, testClient.ClientID, testClient.ClientSecret, "https://localhost/callback", "openid profile")
+
+	team := createTestTeam(t, serverURL, baseURL, tokenInfo.AccessToken, "Robot Email Filter Test Team")
+	teamID := getTeamID(team)
+	membersURL := serverURL + baseURL + "/user/teams/" + teamID + "/members"
+	client := &http.Client{}
+
+	// Two robots, only the first one should match
+	testUUID := strings.ReplaceAll(uuid.New().String(), "-", "")[:8]
+	robotEmails := []string{
+		fmt.Sprintf("filter-a-%s@robot.test.com", testUUID),
+		fmt.Sprintf("filter-b-%s@robot.test.com", testUUID),
+	}
+	for i, robotEmail := range robotEmails {
+		body, _ := json.Marshal(map[string]interface{}{
+			"name":        fmt.Sprintf("Filter Robot %d %s", i, testUUID),
+			"robot_email": robotEmail,
+			"role":        "member",
+			"prompt":      "You are a test robot",
+		})
+		req, _ := http.NewRequest("POST", membersURL+"/robots", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+		resp, err := client.Do(req)
+		assert.NoError(t, err, "HTTP request should succeed")
+		resp.Body.Close()
+		assert.Equal(t, 201, resp.StatusCode, "Should create robot member")
+	}
+
+	list := func(robotEmail string) (int, []interface{}) {
+		req, _ := http.NewRequest("GET", membersURL+"?robot_email="+url.QueryEscape(robotEmail), nil)
+		req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+		resp, err := client.Do(req)
+		assert.NoError(t, err, "HTTP request should succeed")
+		defer resp.Body.Close()
+
+		var response map[string]interface{}
+		body, _ := io.ReadAll(resp.Body)
+		_ = json.Unmarshal(body, &response)
+		data, _ := response["data"].([]interface{})
+		return resp.StatusCode, data
+	}
+
+	t.Run("only the matching robot is returned", func(t *testing.T) {
+		code, data := list(robotEmails[0])
+		assert.Equal(t, 200, code)
+		if assert.Len(t, data, 1) {
+			member := data[0].(map[string]interface{})
+			assert.Equal(t, robotEmails[0], member["robot_email"])
+		}
+	})
+
+	t.Run("domain case does not matter", func(t *testing.T) {
+		code, data := list(strings.Replace(robotEmails[1], "robot.test.com", "ROBOT.Test.com", 1))
+		assert.Equal(t, 200, code)
+		assert.Len(t, data, 1)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		code, data := list("nobody-" + testUUID + "@robot.test.com")
+		assert.Equal(t, 200, code)
+		assert.Empty(t, data)
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		code, _ := list("not-an-email")
+		assert.Equal(t, 400, code)
+	})
+}
+
+// TestMemberListMultipleRoles tests filtering the member list by several roles at once
+func TestMemberListMultipleRoles(t *testing.T) {
+	// Initialize test environment
+	serverURL := testutils.Prepare(t)
+	defer testutils.Clean()
+
+	// Get base URL from server config
+	baseURL := ""
+	if openapi.Server != nil && openapi.Server.Config != nil {
+		baseURL = openapi.Server.Config.BaseURL
+	}
+
+	// Register a test client for OAuth authentication
+	testClient := testutils.RegisterTestClient(t, "Member Multiple Roles Test Client", []string{"https://localhost/callback"})
+	defer testutils.CleanupTestClient(t, testClient.ClientID)
+
+	// Obtain access token for authenticated requests
+	tokenInfo := testutils.ObtainAccessToken(t, serverURL, testClient.ClientID, testClient.ClientSecret, "https://localhost/callback", "openid profile")
+
+	team := createTestTeam(t, serverURL, baseURL, tokenInfo.AccessToken, "Multiple Roles Test Team")
+	teamID := getTeamID(team)
+	membersURL := serverURL + baseURL + "/user/teams/" + teamID + "/members"
+	client := &http.Client{}
+
+	// The owner plus a robot with the member role
+	testUUID := strings.ReplaceAll(uuid.New().String(), "-", "")[:8]
+	body, _ := json.Marshal(map[string]interface{}{
+		"name":        "Roles Robot " + testUUID,
+		"robot_email": fmt.Sprintf("roles-%s@robot.test.com", testUUID),
+		"role":        "member",
+		"prompt":      "You are a test robot",
+	})
+	req, _ := http.NewRequest("POST", membersURL+"/robots", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+	resp, err := client.Do(req)
+	assert.NoError(t, err, "HTTP request should succeed")
+	resp.Body.Close()
+	assert.Equal(t, 201, resp.StatusCode, "Should create robot member")
+
+	list := func(query string) (int, []interface{}) {
+		req, _ := http.NewRequest("GET", membersURL+"?"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+		resp, err := client.Do(req)
+		assert.NoError(t, err, "HTTP request should succeed")
+		defer resp.Body.Close()
+
+		var response map[string]interface{}
+		body, _ := io.ReadAll(resp.Body)
+		_ = json.Unmarshal(body, &response)
+		data, _ := response["data"].([]interface{})
+		return resp.StatusCode, data
+	}
+
+	// Read the owner's role rather than assuming the team config
+	_, all := list("")
+	ownerRole := ""
+	for _, item := range all {
+		if member, ok := item.(map[string]interface{}); ok && member["member_type"] == "user" {
+			ownerRole, _ = member["role_id"].(string)
+		}
+	}
+	if ownerRole == "" || ownerRole == "member" {
+		t.Skip("owner role not distinguishable from member role")
+	}
+
+	t.Run("single role", func(t *testing.T) {
+		code, data := list("role_id=member")
+		assert.Equal(t, 200, code)
+		assert.Len(t, data, 1)
+	})
+
+	t.Run("comma-separated roles", func(t *testing.T) {
+		code, data := list("role_id=" + url.QueryEscape(ownerRole+", member"))
+		assert.Equal(t, 200, code)
+		assert.Len(t, data, 2)
+	})
+
+	t.Run("repeated role_id parameters", func(t *testing.T) {
+		code, data := list("role_id=" + url.QueryEscape(ownerRole) + "&role_id=member")
+		assert.Equal(t, 200, code)
+		assert.Len(t, data, 2)
+	})
+
+	t.Run("empty role in list", func(t *testing.T) {
+		code, _ := list("role_id=" + url.QueryEscape("member,,"+ownerRole))
+		assert.Equal(t, 400, code)
+	})
+}
+
+// TestMemberListActivityRange tests the active_before / active_after filters
+func TestMemberListActivityRange(t *testing.T) {
+	// Initialize test environment
+	serverURL := testutils.Prepare(t)
+	defer testutils.Clean()
+
+	// Get base URL from server config
+	baseURL := ""
+	if openapi.Server != nil && openapi.Server.Config != nil {
+		baseURL = openapi.Server.Config.BaseURL
+	}
+
+	// Register a test client for OAuth authentication
+	testClient := testutils.RegisterTestClient(t, "Member Activity Range Test Client", []string{"https://localhost/callback"})
+	defer testutils.CleanupTestClient(t, testClient.ClientID)
+
+	// Obtain access token for authenticated requests
+	tokenInfo := testutils.ObtainAccessToken(t, serverURL, testClient.ClientID, testClient.ClientSecret, "https://localhost/callback", "openid profile")
+
+	team := createTestTeam(t, serverURL, baseURL, tokenInfo.AccessToken, "Activity Range Test Team")
+	teamID := getTeamID(team)
+	membersURL := serverURL + baseURL + "/user/teams/" + teamID + "/members"
+	client := &http.Client{}
+
+	// A robot that has never been active, next to the owner
+	testUUID := strings.ReplaceAll(uuid.New().String(), "-", "")[:8]
+	body, _ := json.Marshal(map[string]interface{}{
+		"name":        "Idle Robot " + testUUID,
+		"robot_email": fmt.Sprintf("idle-%s@robot.test.com", testUUID),
+		"prompt":      "You are a test robot",
+	})
+	req, _ := http.NewRequest("POST", membersURL+"/robots", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+	resp, err := client.Do(req)
+	assert.NoError(t, err, "HTTP request should succeed")
+	resp.Body.Close()
+	assert.Equal(t, 201, resp.StatusCode, "Should create robot member")
+
+	list := func(query string) (int, []interface{}) {
+		req, _ := http.NewRequest("GET", membersURL+"?"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+		resp, err := client.Do(req)
+		assert.NoError(t, err, "HTTP request should succeed")
+		defer resp.Body.Close()
+
+		var response map[string]interface{}
+		body, _ := io.ReadAll(resp.Body)
+		_ = json.Unmarshal(body, &response)
+		data, _ := response["data"].([]interface{})
+		return resp.StatusCode, data
+	}
+
+	// Mark the owner active now; the robot keeps a null last_active_at
+	_, all := list("")
+	assert.Len(t, all, 2)
+	ownerMemberID := ""
+	for _, item := range all {
+		if member, ok := item.(map[string]interface{}); ok && member["member_type"] == "user" {
+			ownerMemberID, _ = member["member_id"].(string)
+		}
+	}
+	if ownerMemberID == "" {
+		t.Fatal("owner not found in member list")
+	}
+	provider := testutils.GetUserProvider(t)
+	err = provider.UpdateMemberLastActivityByMemberID(context.Background(), ownerMemberID)
+	assert.NoError(t, err)
+
+	hourAgo := url.QueryEscape(time.Now().Add(-time.Hour).UTC().Format(time.RFC3339))
+	inAnHour := url.QueryEscape(time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+
+	t.Run("active_before excludes members never active", func(t *testing.T) {
+		code, data := list("active_before=" + inAnHour)
+		assert.Equal(t, 200, code)
+		if assert.Len(t, data, 1) {
+			assert.Equal(t, ownerMemberID, data[0].(map[string]interface{})["member_id"])
+		}
+	})
+
+	t.Run("active_after", func(t *testing.T) {
+		code, data := list("active_after=" + hourAgo)
+		assert.Equal(t, 200, code)
+		assert.Len(t, data, 1)
+	})
+
+	t.Run("inactive since", func(t *testing.T) {
+		code, data := list("active_before=" + hourAgo)
+		assert.Equal(t, 200, code)
+		assert.Len(t, data, 0)
+	})
+
+	t.Run("invalid timestamps", func(t *testing.T) {
+		code, _ := list("active_before=yesterday")
+		assert.Equal(t, 400, code)
+		code, _ = list("active_after=" + inAnHour + "&active_before=" + hourAgo)
+		assert.Equal(t, 400, code)
+	})
+}
+
+// TestMemberAudit tests that member changes land in GET /user/teams/:team_id/members/:member_id/audit
+func TestMemberAudit(t *testing.T) {
+	// Initialize test environment
+	serverURL := testutils.Prepare(t)
+	defer testutils.Clean()
+
+	// Get base URL from server config
+	baseURL := ""
+	if openapi.Server != nil && openapi.Server.Config != nil {
+		baseURL = openapi.Server.Config.BaseURL
+	}
+
+	// Register a test client for OAuth authentication
+	testClient := testutils.RegisterTestClient(t, "Member Audit Test Client", []string{"https://localhost/callback"})
+	defer testutils.CleanupTestClient(t, testClient.ClientID)
+
+	// Obtain access token with root permissions (required for creating robot members)
+	tokenInfo := testutils.ObtainAccessTokenWithRootPermission(t, serverURL, testClient.ClientID, testClient.ClientSecret, "https://localhost/callback", "openid profile")
+
+	team := createTestTeam(t, serverURL, baseURL, tokenInfo.AccessToken, "Member Audit Test Team")
+	teamID := getTeamID(team)
+	membersURL := serverURL + baseURL + "/user/teams/" + teamID + "/members"
+	memberID := createTestMember(t, serverURL, baseURL, teamID, tokenInfo.AccessToken, "test-audit-user")
+	client := &http.Client{}
+
+	send := func(method, url string, body map[string]interface{}) int {
+		var reader io.Reader
+		if body != nil {
+			raw, _ := json.Marshal(body)
+			reader = bytes.NewBuffer(raw)
+		}
+		req, _ := http.NewRequest(method, url, reader)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+		resp, err := client.Do(req)
+		assert.NoError(t, err, "HTTP request should succeed")
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	trail := func(memberID string) []map[string]interface{} {
+		req, _ := http.NewRequest("GET", membersURL+"/"+memberID+"/audit", nil)
+		req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+		resp, err := client.Do(req)
+		assert.NoError(t, err, "HTTP request should succeed")
+		defer resp.Body.Close()
+		assert.Equal(t, 200, resp.StatusCode)
+
+		var response map[string]interface{}
+		body, _ := io.ReadAll(resp.Body)
+		_ = json.Unmarshal(body, &response)
+		data, _ := response["data"].([]interface{})
+		entries := make([]map[string]interface{}, 0, len(data))
+		for _, item := range data {
+			if entry, ok := item.(map[string]interface{}); ok {
+				entries = append(entries, entry)
+			}
+		}
+		return entries
+	}
+
+	changes := func(entry map[string]interface{}) map[string]interface{} {
+		switch v := entry["changes"].(type) {
+		case map[string]interface{}:
+			return v
+		case string:
+			var decoded map[string]interface{}
+			_ = json.Unmarshal([]byte(v), &decoded)
+			return decoded
+		}
+		return nil
+	}
+
+	// A role change, a no-op repeat, then a status change
+	assert.Equal(t, 200, send("PUT", membersURL+"/"+memberID, map[string]interface{}{"role_id": "admin"}))
+	assert.Equal(t, 200, send("PUT", membersURL+"/"+memberID, map[string]interface{}{"role_id": "admin"}))
+	assert.Equal(t, 200, send("PUT", membersURL+"/"+memberID, map[string]interface{}{"status": "inactive"}))
+
+	entries := trail(memberID)
+	if assert.Len(t, entries, 2, "unchanged updates leave no entry") {
+		assert.Equal(t, "update", entries[0]["action"])
+		assert.Equal(t, tokenInfo.UserID, entries[0]["actor_id"])
+		statusChanges := changes(entries[0])
+		assert.Contains(t, statusChanges, "status")
+		assert.NotContains(t, statusChanges, "role_id", "only changed fields are recorded")
+
+		roleChange, _ := changes(entries[1])["role_id"].(map[string]interface{})
+		assert.Equal(t, "team:member", roleChange["before"])
+		assert.Equal(t, "admin", roleChange["after"])
+	}
+
+	// Removal is recorded, and the trail outlives the member
+	assert.Equal(t, 200, send("DELETE", membersURL+"/"+memberID, nil))
+	entries = trail(memberID)
+	if assert.Len(t, entries, 3) {
+		assert.Equal(t, "delete", entries[0]["action"])
+	}
+
+	// Robot creation is recorded
+	testUUID := strings.ReplaceAll(uuid.New().String(), "-", "")[:8]
+	req, _ := http.NewRequest("POST", membersURL+"/robots", bytes.NewBufferString(fmt.Sprintf(
+		`{"name":"Audit Robot %s","robot_email":"audit-%s@robot.test.com","role":"member","prompt":"You are a test robot"}`, testUUID, testUUID)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+	resp, err := client.Do(req)
+	assert.NoError(t, err, "HTTP request should succeed")
+	var created map[string]interface{}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	_ = json.Unmarshal(body, &created)
+	robotID, _ := created["member_id"].(string)
+	if assert.NotEmpty(t, robotID, "robot creation should return member_id: %s", string(body)) {
+		entries = trail(robotID)
+		if assert.Len(t, entries, 1) {
+			assert.Equal(t, "create", entries[0]["action"])
+			assert.Contains(t, changes(entries[0]), "robot_email")
+		}
+
+		// Robot updates are compared with the stored member, scope fields left out
+		assert.Equal(t, 200, send("PUT", membersURL+"/robots/"+robotID, map[string]interface{}{"bio": "Audited robot"}))
+		entries = trail(robotID)
+		if assert.Len(t, entries, 2) {
+			assert.Equal(t, "update", entries[0]["action"])
+			bioChange, _ := changes(entries[0])["bio"].(map[string]interface{})
+			assert.Equal(t, "Audited robot", bioChange["after"])
+			for field := range changes(entries[0]) {
+				assert.False(t, strings.HasPrefix(field, "__yao_"), "scope field %s recorded", field)
+			}
+		}
+
+		// Columns encrypted at rest never reach the trail in the clear
+		cipher, err := oauthuser.NewFieldCipher("audit-test-key", []string{"system_prompt"})
+		assert.NoError(t, err)
+		oauthuser.SetMemberFieldCipher(cipher)
+		defer oauthuser.SetMemberFieldCipher(nil)
+
+		assert.Equal(t, 200, send("PUT", membersURL+"/"+robotID, map[string]interface{}{"system_prompt": "classified prompt"}))
+		entries = trail(robotID)
+		if assert.Len(t, entries, 3) {
+			promptChange, _ := changes(entries[0])["system_prompt"].(map[string]interface{})
+			assert.Equal(t, "[encrypted]", promptChange["after"])
+			raw, _ := json.Marshal(entries)
+			assert.NotContains(t, string(raw), "classified prompt")
+			assert.NotContains(t, string(raw), "You are a test robot")
+		}
+
+		// Robot updates compare the robot config too, encrypted columns as markers only
+		assert.Equal(t, 200, send("PUT", membersURL+"/robots/"+robotID, map[string]interface{}{"prompt": "rotated classified prompt"}))
+		entries = trail(robotID)
+		if assert.Len(t, entries, 4) {
+			promptChange, _ := changes(entries[0])["system_prompt"].(map[string]interface{})
+			assert.Equal(t, "[encrypted]", promptChange["before"])
+			assert.Equal(t, "[encrypted]", promptChange["after"])
+			raw, _ := json.Marshal(entries)
+			assert.NotContains(t, string(raw), "rotated classified prompt")
+		}
+	}
+}
+
+// TestMemberExport tests the GET /user/teams/:team_id/members/export endpoint
+func TestMemberExport(t *testing.T) {
+	// Initialize test environment
+	serverURL := testutils.Prepare(t)
+	defer testutils.Clean()
+
+	// Get base URL from server config
+	baseURL := ""
+	if openapi.Server != nil && openapi.Server.Config != nil {
+		baseURL = openapi.Server.Config.BaseURL
+	}
+
+	// Register a test client for OAuth authentication
+	testClient := testutils.RegisterTestClient(t, "Member Export Test Client", []string{"https://localhost/callback"})
+	defer testutils.CleanupTestClient(t, testClient.ClientID)
+
+	// Obtain access token for authenticated requests
+	tokenInfo := testutils.ObtainAccessToken(t, serverURL, testClient.ClientID, testClient.ClientSecret, "https://localhost/callback", "openid profile")
+
+	team := createTestTeam(t, serverURL, baseURL, tokenInfo.AccessToken, "Member Export Test Team")
+	teamID := getTeamID(team)
+	membersURL := serverURL + baseURL + "/user/teams/" + teamID + "/members"
+	client := &http.Client{}
+
+	testUUID := strings.ReplaceAll(uuid.New().String(), "-", "")[:8]
+	robotEmail := fmt.Sprintf("export-%s@robot.test.com", testUUID)
+	body, _ := json.Marshal(map[string]interface{}{
+		"name":        "Export Robot " + testUUID,
+		"robot_email": robotEmail,
+		"role":        "member",
+		"prompt":      "You are a test robot",
+	})
+	req, _ := http.NewRequest("POST", membersURL+"/robots", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+	resp, err := client.Do(req)
+	assert.NoError(t, err, "HTTP request should succeed")
+	resp.Body.Close()
+	assert.Equal(t, 201, resp.StatusCode, "Should create robot member")
+
+	export := func(query string) (*http.Response, []byte) {
+		req, _ := http.NewRequest("GET", membersURL+"/export?"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+		resp, err := client.Do(req)
+		assert.NoError(t, err, "HTTP request should succeed")
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp, body
+	}
+
+	t.Run("robots only as CSV", func(t *testing.T) {
+		resp, body := export("format=csv&member_type=robot")
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Contains(t, resp.Header.Get("Content-Disposition"), "members-"+teamID+".csv")
+
+		records, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+		assert.NoError(t, err)
+		if assert.Len(t, records, 2, "Header and the robot row") {
+			assert.Contains(t, records[0], "robot_email")
+			assert.NotContains(t, records[0], "invitation_token")
+			assert.Contains(t, records[1], robotEmail)
+		}
+	})
+
+	t.Run("users only as JSON", func(t *testing.T) {
+		resp, body := export("format=json&member_type=user")
+		assert.Equal(t, 200, resp.StatusCode)
+
+		var rows []map[string]interface{}
+		assert.NoError(t, json.Unmarshal(body, &rows))
+		for _, row := range rows {
+			assert.Equal(t, "user", row["member_type"])
+		}
+	})
+
+	t.Run("XLSX", func(t *testing.T) {
+		resp, body := export("format=xlsx")
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", resp.Header.Get("Content-Type"))
+		assert.True(t, bytes.HasPrefix(body, []byte("PK")), "XLSX is a zip container")
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		resp, _ := export("format=xml")
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+
+	t.Run("fields outside the allowlist are dropped", func(t *testing.T) {
+		resp, body := export("format=csv&member_type=robot&fields=member_id,invitation_token,invitation_id,system_prompt")
+		assert.Equal(t, 200, resp.StatusCode)
+
+		records, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+		assert.NoError(t, err)
+		if assert.NotEmpty(t, records) {
+			// The test user owns the team, so robot configuration columns are allowed
+			assert.Equal(t, []string{"member_id", "system_prompt"}, records[0])
+		}
+	})
+
+	t.Run("nothing exportable", func(t *testing.T) {
+		resp, _ := export("format=csv&fields=invitation_token")
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+}
+
 // TestMemberGet tests the GET /user/teams/:team_id/members/:member_id endpoint
 func TestMemberGet(t *testing.T) {
 	// Initialize test environment
@@ -543,6 +1134,71 @@ func TestMemberGet(t *testing.T) {
 	}
 }
 
+// TestProcessMemberGet tests that the user.member.get process hides fields like the HTTP endpoint
+func TestProcessMemberGet(t *testing.T) {
+	// Initialize test environment
+	serverURL := testutils.Prepare(t)
+	defer testutils.Clean()
+
+	// Get base URL from server config
+	baseURL := ""
+	if openapi.Server != nil && openapi.Server.Config != nil {
+		baseURL = openapi.Server.Config.BaseURL
+	}
+
+	// Register a test client for OAuth authentication
+	testClient := testutils.RegisterTestClient(t, "Member Get Process Test Client", []string{"https://localhost/callback"})
+	defer testutils.CleanupTestClient(t, testClient.ClientID)
+
+	// Obtain access token for authenticated requests
+	tokenInfo := testutils.ObtainAccessToken(t, serverURL, testClient.ClientID, testClient.ClientSecret, "https://localhost/callback", "openid profile")
+
+	createdTeam := createTestTeam(t, serverURL, baseURL, tokenInfo.AccessToken, "Member Get Process Test Team")
+	teamID := getTeamID(createdTeam)
+	ownerMemberID := getOwnerMemberID(t, serverURL, baseURL, teamID, tokenInfo.AccessToken)
+
+	// Run the processes as the team owner
+	sid := uuid.NewString()
+	err := session.Global().ID(sid).Set("__user_id", tokenInfo.UserID)
+	assert.NoError(t, err, "Should set session user")
+
+	access, err := process.New("user.team.access", teamID).WithSID(sid).Exec()
+	assert.NoError(t, err, "Should resolve team access")
+	roleID := fmt.Sprintf("%v", access.(maps.MapStrAny)["role_id"])
+
+	// Hide fields from the owner's role for the duration of the test
+	teamConfig := user.GetTeamConfig("en")
+	if teamConfig == nil {
+		t.Skip("No team config loaded")
+	}
+	original := teamConfig.FieldVisibility
+	teamConfig.FieldVisibility = &user.FieldVisibilityConfig{
+		Roles: map[string][]string{roleID: {"email", "bio", "member_id"}},
+	}
+	defer func() { teamConfig.FieldVisibility = original }()
+
+	t.Run("hidden fields are stripped", func(t *testing.T) {
+		result, err := process.New("user.member.get", teamID, ownerMemberID).WithSID(sid).Exec()
+		assert.NoError(t, err, "Should get member")
+
+		member, ok := result.(maps.MapStrAny)
+		assert.True(t, ok, "Should return a member map")
+		assert.NotContains(t, member, "email", "email is hidden from the owner's role")
+		assert.NotContains(t, member, "bio", "bio is hidden from the owner's role")
+		assert.Equal(t, ownerMemberID, member["member_id"], "Identity fields are never hidden")
+	})
+
+	t.Run("hidden fields are stripped from a field selection", func(t *testing.T) {
+		result, err := process.New("user.member.get", teamID, ownerMemberID, "member_id,email").WithSID(sid).Exec()
+		assert.NoError(t, err, "Should get member")
+
+		member, ok := result.(maps.MapStrAny)
+		assert.True(t, ok, "Should return a member map")
+		assert.NotContains(t, member, "email", "email is hidden even when selected")
+		assert.Equal(t, ownerMemberID, member["member_id"], "Selected visible fields are returned")
+	})
+}
+
 // TestMemberUpdate tests the PUT /user/teams/:team_id/members/:member_id endpoint
 func TestMemberUpdate(t *testing.T) {
 	// Initialize test environment
@@ -836,6 +1492,98 @@ func TestMemberDelete(t *testing.T) {
 	}
 }
 
+// TestMemberRestore tests the POST /user/teams/:team_id/members/:member_id/restore endpoint
+func TestMemberRestore(t *testing.T) {
+	// Initialize test environment
+	serverURL := testutils.Prepare(t)
+	defer testutils.Clean()
+
+	// Get base URL from server config
+	baseURL := ""
+	if openapi.Server != nil && openapi.Server.Config != nil {
+		baseURL = openapi.Server.Config.BaseURL
+	}
+
+	// Register a test client for OAuth authentication
+	testClient := testutils.RegisterTestClient(t, "Member Restore Test Client", []string{"https://localhost/callback"})
+	defer testutils.CleanupTestClient(t, testClient.ClientID)
+
+	// Obtain access token for authenticated requests
+	tokenInfo := testutils.ObtainAccessToken(t, serverURL, testClient.ClientID, testClient.ClientSecret, "https://localhost/callback", "openid profile")
+
+	team := createTestTeam(t, serverURL, baseURL, tokenInfo.AccessToken, "Restore Test Team")
+	teamID := getTeamID(team)
+	memberID := createTestMember(t, serverURL, baseURL, teamID, tokenInfo.AccessToken, "test-restore-user")
+	membersURL := serverURL + baseURL + "/user/teams/" + teamID + "/members"
+	client := &http.Client{}
+
+	do := func(method, url string, auth bool) *http.Response {
+		req, err := http.NewRequest(method, url, nil)
+		assert.NoError(t, err, "Should create HTTP request")
+		if auth {
+			req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+		}
+		resp, err := client.Do(req)
+		assert.NoError(t, err, "HTTP request should succeed")
+		return resp
+	}
+
+	listed := func() bool {
+		resp := do("GET", membersURL, true)
+		defer resp.Body.Close()
+		assert.Equal(t, 200, resp.StatusCode, "Should list members")
+
+		var response map[string]interface{}
+		body, _ := io.ReadAll(resp.Body)
+		assert.NoError(t, json.Unmarshal(body, &response), "Should parse member list response")
+
+		data, _ := response["data"].([]interface{})
+		for _, item := range data {
+			if member, ok := item.(map[string]interface{}); ok && member["member_id"] == memberID {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Only removed members can be restored
+	resp := do("POST", membersURL+"/"+memberID+"/restore", true)
+	resp.Body.Close()
+	assert.Equal(t, 404, resp.StatusCode, "Should not restore an active member")
+
+	resp = do("DELETE", membersURL+"/"+memberID, true)
+	resp.Body.Close()
+	assert.Equal(t, 200, resp.StatusCode, "Should remove member")
+	assert.False(t, listed(), "Removed member should not be listed")
+
+	t.Run("restore without authentication", func(t *testing.T) {
+		resp := do("POST", membersURL+"/"+memberID+"/restore", false)
+		resp.Body.Close()
+		assert.Equal(t, 401, resp.StatusCode, "Should require authentication")
+	})
+
+	t.Run("restore into another team", func(t *testing.T) {
+		other := createTestTeam(t, serverURL, baseURL, tokenInfo.AccessToken, "Restore Test Other Team")
+		resp := do("POST", serverURL+baseURL+"/user/teams/"+getTeamID(other)+"/members/"+memberID+"/restore", true)
+		resp.Body.Close()
+		assert.Equal(t, 404, resp.StatusCode, "Should not restore a member of another team")
+		assert.False(t, listed(), "Member should stay removed")
+	})
+
+	t.Run("restore removed member", func(t *testing.T) {
+		resp := do("POST", membersURL+"/"+memberID+"/restore", true)
+		defer resp.Body.Close()
+		assert.Equal(t, 200, resp.StatusCode, "Should restore member")
+
+		body, _ := io.ReadAll(resp.Body)
+		var response map[string]interface{}
+		assert.NoError(t, json.Unmarshal(body, &response), "Should parse JSON response")
+		assert.Equal(t, "Member restored successfully", response["message"])
+
+		assert.True(t, listed(), "Restored member should be listed again")
+	})
+}
+
 // TestMemberPermissionVerification tests permission verification for member operations
 func TestMemberPermissionVerification(t *testing.T) {
 	// Initialize test environment
@@ -1086,6 +1834,68 @@ func getOwnerMemberID(t *testing.T, serverURL, baseURL, teamID, accessToken stri
 	return ""
 }
 
+// TestMemberCreateRobotEvent tests that creating a robot member publishes robot.member.created
+func TestMemberCreateRobotEvent(t *testing.T) {
+	// Initialize test environment
+	serverURL := testutils.Prepare(t)
+	defer testutils.Clean()
+
+	// Get base URL from server config
+	baseURL := ""
+	if openapi.Server != nil && openapi.Server.Config != nil {
+		baseURL = openapi.Server.Config.BaseURL
+	}
+
+	// Register a test client for OAuth authentication
+	testClient := testutils.RegisterTestClient(t, "Robot Created Event Test Client", []string{"https://localhost/callback"})
+	defer testutils.CleanupTestClient(t, testClient.ClientID)
+
+	// Obtain access token for authenticated requests
+	tokenInfo := testutils.ObtainAccessToken(t, serverURL, testClient.ClientID, testClient.ClientSecret, "https://localhost/callback", "openid profile")
+
+	team := createTestTeam(t, serverURL, baseURL, tokenInfo.AccessToken, "Robot Created Event Test Team")
+	teamID := getTeamID(team)
+
+	ch := make(chan *eventtypes.Event, 16)
+	subID := event.Subscribe(robotevents.MemberCreated, ch)
+	defer event.Unsubscribe(subID)
+
+	testUUID := strings.ReplaceAll(uuid.New().String(), "-", "")[:8]
+	body, _ := json.Marshal(map[string]interface{}{
+		"name":        "Event Robot " + testUUID,
+		"email":       fmt.Sprintf("event-robot-%s@test.com", testUUID),
+		"robot_email": fmt.Sprintf("event-robot-%s@robot.test.com", testUUID),
+		"role":        "member",
+		"prompt":      "You are a test robot",
+	})
+	req, err := http.NewRequest("POST", serverURL+baseURL+"/user/teams/"+teamID+"/members/robots", bytes.NewBuffer(body))
+	assert.NoError(t, err, "Should create HTTP request")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+
+	resp, err := (&http.Client{}).Do(req)
+	assert.NoError(t, err, "HTTP request should succeed")
+	defer resp.Body.Close()
+	assert.Equal(t, 201, resp.StatusCode, "Should create robot member")
+
+	var created map[string]interface{}
+	respBody, _ := io.ReadAll(resp.Body)
+	assert.NoError(t, json.Unmarshal(respBody, &created), "Should parse JSON response")
+
+	select {
+	case ev := <-ch:
+		assert.Equal(t, robotevents.MemberCreated, ev.Type)
+
+		var payload robotevents.MemberPayload
+		assert.NoError(t, ev.Should(&payload))
+		assert.Equal(t, teamID, payload.TeamID)
+		assert.Equal(t, created["member_id"], payload.MemberID)
+		assert.Equal(t, "member", payload.RoleID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for robot.member.created")
+	}
+}
+
 // TestMemberCreateRobot tests the POST /user/teams/:team_id/members/robots endpoint
 func TestMemberCreateRobot(t *testing.T) {
 	// Initialize test environment
@@ -1388,6 +2198,75 @@ func TestMemberCreateRobot(t *testing.T) {
 	}
 }
 
+// TestMemberCreateRobotEmailValidation tests robot_email is checked and trimmed before a robot member is created
+func TestMemberCreateRobotEmailValidation(t *testing.T) {
+	serverURL := testutils.Prepare(t)
+	defer testutils.Clean()
+
+	baseURL := ""
+	if openapi.Server != nil && openapi.Server.Config != nil {
+		baseURL = openapi.Server.Config.BaseURL
+	}
+
+	testClient := testutils.RegisterTestClient(t, "Robot Email Validation Test Client", []string{"https://localhost/callback"})
+	defer testutils.CleanupTestClient(t, testClient.ClientID)
+
+	tokenInfo := testutils.ObtainAccessTokenWithRootPermission(t, serverURL, testClient.ClientID, testClient.ClientSecret, "https://localhost/callback", "openid profile")
+
+	testUUID := strings.ReplaceAll(uuid.New().String(), "-", "")[:8]
+	createdTeam := createTestTeam(t, serverURL, baseURL, tokenInfo.AccessToken, "Robot Email Validation Team "+testUUID)
+	teamID := getTeamID(createdTeam)
+
+	createRobot := func(t *testing.T, robotEmail string) (int, map[string]interface{}) {
+		bodyBytes, _ := json.Marshal(map[string]interface{}{
+			"name":        "Mail Robot " + testUUID,
+			"robot_email": robotEmail,
+			"role":        "member",
+			"prompt":      "You are an assistant",
+		})
+		req, _ := http.NewRequest("POST", serverURL+baseURL+"/user/teams/"+teamID+"/members/robots", bytes.NewBuffer(bodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+
+		resp, err := http.DefaultClient.Do(req)
+		if !assert.NoError(t, err, "HTTP request should succeed") {
+			return 0, nil
+		}
+		defer resp.Body.Close()
+
+		var result map[string]interface{}
+		body, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(body, &result)
+		return resp.StatusCode, result
+	}
+
+	t.Run("blank robot_email is rejected", func(t *testing.T) {
+		status, result := createRobot(t, "   ")
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Contains(t, toString(result["error_description"]), "robot_email")
+	})
+
+	t.Run("malformed robot_email is rejected", func(t *testing.T) {
+		for _, robotEmail := range []string{"not-an-email", "robot@", "@robot.test.com", "robot@localhost"} {
+			status, result := createRobot(t, robotEmail)
+			assert.Equal(t, http.StatusBadRequest, status, robotEmail)
+			assert.Contains(t, toString(result["error_description"]), "invalid email address", robotEmail)
+		}
+	})
+
+	t.Run("uppercase robot_email is trimmed and its domain lowercased", func(t *testing.T) {
+		status, result := createRobot(t, "  Mail-Robot-"+testUUID+"@Robot.TEST.com ")
+		if !assert.Equal(t, http.StatusCreated, status) {
+			return
+		}
+
+		provider := testutils.GetUserProvider(t)
+		member, err := provider.GetMemberByMemberID(context.Background(), toString(result["member_id"]))
+		assert.NoError(t, err)
+		assert.Equal(t, "Mail-Robot-"+testUUID+"@robot.test.com", member["robot_email"])
+	})
+}
+
 // toString converts interface{} to string for test assertions
 func toString(v interface{}) string {
 	switch val := v.(type) {
@@ -1480,6 +2359,17 @@ func TestMemberCheckRobotEmail(t *testing.T) {
 			true,
 			"should return exists=true for existing robot email",
 		},
+		{
+			"check existing robot email with different casing",
+			teamID,
+			strings.ToUpper(existingRobotEmail),
+			map[string]string{
+				"Authorization": "Bearer " + tokenInfo.AccessToken,
+			},
+			200,
+			true,
+			"should match robot email case-insensitively",
+		},
 		{
 			"check non-existing robot email",
 			teamID,
@@ -2074,6 +2964,91 @@ func TestMemberUpdateRobot(t *testing.T) {
 	}
 }
 
+// TestMemberUpdateRobotFields tests robot config fields on PUT /user/teams/:team_id/members/:member_id
+func TestMemberUpdateRobotFields(t *testing.T) {
+	// Initialize test environment
+	serverURL := testutils.Prepare(t)
+	defer testutils.Clean()
+
+	// Get base URL from server config
+	baseURL := ""
+	if openapi.Server != nil && openapi.Server.Config != nil {
+		baseURL = openapi.Server.Config.BaseURL
+	}
+
+	// Register a test client for OAuth authentication
+	testClient := testutils.RegisterTestClient(t, "Member Update Robot Fields Test Client", []string{"https://localhost/callback"})
+	defer testutils.CleanupTestClient(t, testClient.ClientID)
+
+	// Obtain access token with root permissions (required for creating robot members)
+	tokenInfo := testutils.ObtainAccessTokenWithRootPermission(t, serverURL, testClient.ClientID, testClient.ClientSecret, "https://localhost/callback", "openid profile")
+
+	team := createTestTeam(t, serverURL, baseURL, tokenInfo.AccessToken, "Member Update Robot Fields Test Team")
+	teamID := getTeamID(team)
+	membersURL := serverURL + baseURL + "/user/teams/" + teamID + "/members"
+	userMemberID := createTestMember(t, serverURL, baseURL, teamID, tokenInfo.AccessToken, "test-robot-fields-user")
+	client := &http.Client{}
+
+	send := func(method, url string, body map[string]interface{}) (int, string) {
+		raw, _ := json.Marshal(body)
+		req, _ := http.NewRequest(method, url, bytes.NewBuffer(raw))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+		resp, err := client.Do(req)
+		assert.NoError(t, err, "HTTP request should succeed")
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(respBody)
+	}
+
+	testUUID := strings.ReplaceAll(uuid.New().String(), "-", "")[:8]
+	status, body := send("POST", membersURL+"/robots", map[string]interface{}{
+		"name":        "Fields Robot " + testUUID,
+		"robot_email": "fields-" + testUUID + "@robot.test.com",
+		"role":        "member",
+		"prompt":      "You are a test robot",
+	})
+	var created map[string]interface{}
+	_ = json.Unmarshal([]byte(body), &created)
+	robotID, _ := created["member_id"].(string)
+	if !assert.Equal(t, 201, status, body) || !assert.NotEmpty(t, robotID) {
+		return
+	}
+
+	provider := testutils.GetUserProvider(t)
+
+	t.Run("RobotFieldsPersist", func(t *testing.T) {
+		status, body := send("PUT", membersURL+"/"+robotID, map[string]interface{}{
+			"system_prompt": "You review pull requests",
+			"agents":        []string{"reviewer"},
+			"status":        "active",
+		})
+		assert.Equal(t, 200, status, body)
+
+		member, err := provider.GetMemberByMemberID(context.Background(), robotID)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "You review pull requests", member["system_prompt"])
+			assert.Contains(t, fmt.Sprintf("%v", member["agents"]), "reviewer")
+		}
+	})
+
+	t.Run("InvalidRobotField", func(t *testing.T) {
+		status, _ := send("PUT", membersURL+"/"+robotID, map[string]interface{}{"autonomous_mode": "sometimes"})
+		assert.Equal(t, 400, status)
+	})
+
+	t.Run("UserMemberRejected", func(t *testing.T) {
+		status, body := send("PUT", membersURL+"/"+userMemberID, map[string]interface{}{"system_prompt": "Not for users"})
+		assert.Equal(t, 400, status)
+		assert.Contains(t, body, "not a robot member")
+
+		member, err := provider.GetMemberByMemberID(context.Background(), userMemberID)
+		if assert.NoError(t, err) {
+			assert.Empty(t, member["system_prompt"], "nothing is written for a rejected update")
+		}
+	})
+}
+
 // TestMemberProfileGet tests the GET /user/teams/:team_id/members/:user_id/profile endpoint
 func TestMemberProfileGet(t *testing.T) {
 	// Initialize test environment
@@ -2522,4 +3497,84 @@ func TestMemberProfileUpdate(t *testing.T) {
 	}
 }
 
+// TestMemberPatchRobot tests the PATCH /user/teams/:team_id/members/:member_id/robot endpoint
+func TestMemberPatchRobot(t *testing.T) {
+	serverURL := testutils.Prepare(t)
+	defer testutils.Clean()
+
+	baseURL := ""
+	if openapi.Server != nil && openapi.Server.Config != nil {
+		baseURL = openapi.Server.Config.BaseURL
+	}
+
+	testClient := testutils.RegisterTestClient(t, "Robot Member Patch Test Client", []string{"https://localhost/callback"})
+	defer testutils.CleanupTestClient(t, testClient.ClientID)
+
+	tokenInfo := testutils.ObtainAccessTokenWithRootPermission(t, serverURL, testClient.ClientID, testClient.ClientSecret, "https://localhost/callback", "openid profile")
+
+	testUUID := strings.ReplaceAll(uuid.New().String(), "-", "")[:8]
+	createdTeam := createTestTeam(t, serverURL, baseURL, tokenInfo.AccessToken, "Robot Patch Test Team "+testUUID)
+	teamID := getTeamID(createdTeam)
+	membersURL := serverURL + baseURL + "/user/teams/" + teamID + "/members"
+
+	doRequest := func(method, url string, body interface{}) (int, map[string]interface{}) {
+		var reader io.Reader
+		if body != nil {
+			bodyBytes, _ := json.Marshal(body)
+			reader = bytes.NewBuffer(bodyBytes)
+		}
+		req, _ := http.NewRequest(method, url, reader)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+		resp, err := http.DefaultClient.Do(req)
+		if !assert.NoError(t, err) {
+			return 0, nil
+		}
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		var result map[string]interface{}
+		json.Unmarshal(data, &result)
+		return resp.StatusCode, result
+	}
+
+	code, created := doRequest("POST", membersURL+"/robots", map[string]interface{}{
+		"name":        "Patch Robot",
+		"robot_email": fmt.Sprintf("patch-robot-%s@robot.test.com", testUUID),
+		"role":        "member",
+		"prompt":      "Original prompt",
+		"llm":         "gpt-4",
+		"agents":      []string{"agent1"},
+		"cost_limit":  50.0,
+	})
+	assert.Equal(t, 201, code, "Should create robot member")
+	memberID := toString(created["member_id"])
+	patchURL := membersURL + "/" + memberID + "/robot"
+
+	// An empty string is a value, not an omission
+	code, _ = doRequest("PATCH", patchURL, map[string]interface{}{"prompt": ""})
+	assert.Equal(t, 200, code, "Should patch the prompt")
+
+	code, member := doRequest("GET", membersURL+"/"+memberID, nil)
+	assert.Equal(t, 200, code)
+	assert.Equal(t, "", toString(member["system_prompt"]), "Prompt should be cleared")
+	assert.Equal(t, "gpt-4", member["language_model"], "Omitted fields should be unchanged")
+	assert.EqualValues(t, 50, member["cost_limit"], "Omitted fields should be unchanged")
+
+	// Zero values and empty lists are applied
+	code, _ = doRequest("PATCH", patchURL, map[string]interface{}{"cost_limit": 0, "agents": []string{}})
+	assert.Equal(t, 200, code, "Should patch cost limit and agents")
+	_, member = doRequest("GET", membersURL+"/"+memberID, nil)
+	assert.EqualValues(t, 0, member["cost_limit"], "Cost limit should be zero")
+	assert.Empty(t, member["agents"], "Agents should be cleared")
+
+	code, _ = doRequest("PATCH", patchURL, map[string]interface{}{})
+	assert.Equal(t, 400, code, "Empty patch should be rejected")
+
+	code, _ = doRequest("PATCH", patchURL, map[string]interface{}{"autonomous_mode": "sometimes"})
+	assert.Equal(t, 400, code, "Invalid autonomous_mode should be rejected")
+
+	code, _ = doRequest("PATCH", membersURL+"/nonexistent-member/robot", map[string]interface{}{"prompt": "x"})
+	assert.Equal(t, 404, code, "Unknown member should return 404")
+}
+
 // Note: getTeamID function is already defined in team_test.go
@@ -13,6 +13,15 @@ type Config struct {
 	Providers *Providers `json:"providers,omitempty" yaml:"providers,omitempty"`
 	OAuth     *OAuth     `json:"oauth,omitempty" yaml:"oauth,omitempty"`
 	root      string     `json:"-" yaml:"-"` // Application root path, not serialized to JSON
+
+	// Encryption configures encryption at rest for sensitive columns (disabled when nil)
+	Encryption *Encryption `json:"encryption,omitempty" yaml:"encryption,omitempty"`
+}
+
+// Encryption is the encryption-at-rest configuration for sensitive columns
+type Encryption struct {
+	Key          string   `json:"key,omitempty" yaml:"key,omitempty"`                     // Secret used to derive the AES-256 key
+	MemberFields []string `json:"member_fields,omitempty" yaml:"member_fields,omitempty"` // Member columns to encrypt, e.g. ["robot_config", "mcp_servers"]
 }
 
 // Provider is the provider for the OpenAPI server, and in the future will be refactored into a struct
@@ -129,6 +138,8 @@ type TempConfig struct {
 	Cache     string     `json:"cache,omitempty"`
 	Providers *Providers `json:"providers,omitempty"`
 	OAuth     *TempOAuth `json:"oauth,omitempty"`
+
+	Encryption *Encryption `json:"encryption,omitempty"`
 }
 
 // Model represents a chat model
@@ -2,10 +2,13 @@ package user
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
 	"github.com/yaoapp/gou/model"
@@ -13,12 +16,20 @@ import (
 	"github.com/yaoapp/kun/exception"
 	"github.com/yaoapp/kun/log"
 	"github.com/yaoapp/kun/maps"
+	robotapi "github.com/yaoapp/yao/agent/robot/api"
+	robotevents "github.com/yaoapp/yao/agent/robot/events"
+	robottypes "github.com/yaoapp/yao/agent/robot/types"
+	"github.com/yaoapp/yao/event"
 	"github.com/yaoapp/yao/openapi/oauth"
 	"github.com/yaoapp/yao/openapi/oauth/authorized"
+	"github.com/yaoapp/yao/openapi/oauth/providers/user"
 	"github.com/yaoapp/yao/openapi/response"
 	"github.com/yaoapp/yao/openapi/utils"
 )
 
+// minMemberKeywordLength keeps keyword searches from degenerating into full scans
+const minMemberKeywordLength = 2
+
 // Member Management Handlers
 
 // GinMemberList handles GET /teams/:team_id/members - Get team members with advanced filtering
@@ -73,6 +84,16 @@ func GinMemberList(c *gin.Context) {
 		req.Order = "created_at desc"
 	}
 
+	// Repeated role_id parameters work like a comma-separated list
+	if roleIDs := c.QueryArray("role_id"); len(roleIDs) > 1 {
+		req.RoleID = strings.Join(roleIDs, ",")
+	}
+
+	// A cursor, even empty, switches to cursor pagination
+	if cursor, ok := c.GetQuery("cursor"); ok {
+		req.Cursor = &cursor
+	}
+
 	// Parse fields from comma-separated string if provided
 	if fieldsStr := c.Query("fields"); fieldsStr != "" {
 		req.Fields = strings.Split(fieldsStr, ",")
@@ -92,7 +113,7 @@ func GinMemberList(c *gin.Context) {
 	}
 
 	// Call business logic
-	result, err := memberList(c.Request.Context(), authInfo.UserID, teamID, &req, requestBaseURL, locale)
+	result, err := cachedMemberList(c.Request.Context(), authInfo.UserID, teamID, &req, requestBaseURL, locale)
 	if err != nil {
 		log.Error("Failed to get team members: %v", err)
 		// Check error type for appropriate response
@@ -220,12 +241,28 @@ func GinMemberGet(c *gin.Context) {
 		return
 	}
 
+	// Optional projection: ?fields=member_id,display_name,avatar
+	var fields []string
+	if fieldsStr := c.Query("fields"); fieldsStr != "" {
+		for _, field := range strings.Split(fieldsStr, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				fields = append(fields, field)
+			}
+		}
+	}
+
 	// Call business logic
-	memberData, err := memberGet(c.Request.Context(), authInfo.UserID, teamID, memberID)
+	memberData, err := memberGet(c.Request.Context(), authInfo.UserID, teamID, memberID, fields)
 	if err != nil {
 		log.Error("Failed to get member details: %v", err)
 		// Check error type for appropriate response
-		if strings.Contains(err.Error(), "not found") {
+		if strings.Contains(err.Error(), "invalid field") {
+			errorResp := &response.ErrorResponse{
+				Code:             response.ErrInvalidRequest.Code,
+				ErrorDescription: err.Error(),
+			}
+			response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		} else if strings.Contains(err.Error(), "not found") {
 			errorResp := &response.ErrorResponse{
 				Code:             response.ErrInvalidRequest.Code,
 				ErrorDescription: "Member not found",
@@ -247,11 +284,23 @@ func GinMemberGet(c *gin.Context) {
 		return
 	}
 
-	// Convert to response format
-	member := mapToMemberDetailResponse(memberData)
+	// Convert to response format, hiding the fields the viewer's role may not see
+	locale := c.Query("locale")
+	if locale == "" {
+		locale = "en"
+	}
+	hidden := viewerHiddenFields(c.Request.Context(), teamID, authInfo.UserID, locale)
+	member := mapToMemberDetailResponse(memberData, hidden)
+	if len(fields) > 0 {
+		response.RespondWithSuccess(c, http.StatusOK, projectMemberResponse(member, fields))
+		return
+	}
 	response.RespondWithSuccess(c, http.StatusOK, member)
 }
 
+// robotPayloadLimit caps robot member create/update bodies at the configured robot payload size
+var robotPayloadLimit = utils.BodyLimit(func() int64 { return robottypes.GetPayloadLimits().MaxBodyBytes })
+
 // GinMemberCreateRobot handles POST /teams/:team_id/members/robots - Add robot member to team
 func GinMemberCreateRobot(c *gin.Context) {
 	// Get authorized user info
@@ -278,6 +327,10 @@ func GinMemberCreateRobot(c *gin.Context) {
 	// Parse request body
 	var req CreateRobotMemberRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
+		if utils.IsBodyTooLarge(err) {
+			utils.RespondBodyTooLarge(c, robottypes.GetPayloadLimits().MaxBodyBytes)
+			return
+		}
 		errorResp := &response.ErrorResponse{
 			Code:             response.ErrInvalidRequest.Code,
 			ErrorDescription: "Invalid request body: " + err.Error(),
@@ -286,6 +339,21 @@ func GinMemberCreateRobot(c *gin.Context) {
 		return
 	}
 
+	// robot_email is the robot's mailbox: reject blank and malformed addresses up front
+	robotEmail, err := utils.NormalizeEmailAddress(req.RobotEmail)
+	if strings.TrimSpace(req.RobotEmail) == "" {
+		err = fmt.Errorf("robot_email is required")
+	}
+	if err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Invalid robot_email: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+	req.RobotEmail = robotEmail
+
 	// Prepare base robot member data
 	baseData := maps.MapStrAny{
 		"display_name":    req.Name,
@@ -336,7 +404,13 @@ func GinMemberCreateRobot(c *gin.Context) {
 	if err != nil {
 		log.Error("Failed to create robot member: %v", err)
 		// Check error type for appropriate response
-		if strings.Contains(err.Error(), "not found") {
+		if errors.Is(err, robottypes.ErrPayloadLimit) || strings.Contains(err.Error(), "invalid email address") || strings.Contains(err.Error(), "must not be blank") {
+			errorResp := &response.ErrorResponse{
+				Code:             response.ErrInvalidRequest.Code,
+				ErrorDescription: err.Error(),
+			}
+			response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		} else if strings.Contains(err.Error(), "not found") {
 			errorResp := &response.ErrorResponse{
 				Code:             response.ErrInvalidRequest.Code,
 				ErrorDescription: "Team not found",
@@ -395,6 +469,10 @@ func GinMemberUpdateRobot(c *gin.Context) {
 	// Parse request body
 	var req UpdateRobotMemberRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
+		if utils.IsBodyTooLarge(err) {
+			utils.RespondBodyTooLarge(c, robottypes.GetPayloadLimits().MaxBodyBytes)
+			return
+		}
 		errorResp := &response.ErrorResponse{
 			Code:             response.ErrInvalidRequest.Code,
 			ErrorDescription: "Invalid request body: " + err.Error(),
@@ -471,38 +549,7 @@ func GinMemberUpdateRobot(c *gin.Context) {
 	err := memberUpdateRobot(c.Request.Context(), authInfo.UserID, teamID, memberID, robotData)
 	if err != nil {
 		log.Error("Failed to update robot member: %v", err)
-		// Check error type for appropriate response
-		if strings.Contains(err.Error(), "not found") {
-			errorResp := &response.ErrorResponse{
-				Code:             response.ErrInvalidRequest.Code,
-				ErrorDescription: err.Error(),
-			}
-			response.RespondWithError(c, response.StatusNotFound, errorResp)
-		} else if strings.Contains(err.Error(), "access denied") {
-			errorResp := &response.ErrorResponse{
-				Code:             response.ErrAccessDenied.Code,
-				ErrorDescription: err.Error(),
-			}
-			response.RespondWithError(c, response.StatusForbidden, errorResp)
-		} else if strings.Contains(err.Error(), "not a robot member") {
-			errorResp := &response.ErrorResponse{
-				Code:             response.ErrInvalidRequest.Code,
-				ErrorDescription: err.Error(),
-			}
-			response.RespondWithError(c, response.StatusBadRequest, errorResp)
-		} else if strings.Contains(err.Error(), "already exists") || strings.Contains(err.Error(), "duplicate") {
-			errorResp := &response.ErrorResponse{
-				Code:             response.ErrInvalidRequest.Code,
-				ErrorDescription: err.Error(),
-			}
-			response.RespondWithError(c, response.StatusConflict, errorResp)
-		} else {
-			errorResp := &response.ErrorResponse{
-				Code:             response.ErrServerError.Code,
-				ErrorDescription: "Failed to update robot member",
-			}
-			response.RespondWithError(c, response.StatusInternalServerError, errorResp)
-		}
+		respondRobotUpdateError(c, err)
 		return
 	}
 
@@ -510,6 +557,47 @@ func GinMemberUpdateRobot(c *gin.Context) {
 	response.RespondWithSuccess(c, http.StatusOK, gin.H{"message": "Robot member updated successfully"})
 }
 
+// respondRobotUpdateError maps a robot member update failure to an HTTP response
+func respondRobotUpdateError(c *gin.Context, err error) {
+	if errors.Is(err, robottypes.ErrPayloadLimit) {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: err.Error(),
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+	} else if strings.Contains(err.Error(), "not found") {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: err.Error(),
+		}
+		response.RespondWithError(c, response.StatusNotFound, errorResp)
+	} else if strings.Contains(err.Error(), "access denied") {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrAccessDenied.Code,
+			ErrorDescription: err.Error(),
+		}
+		response.RespondWithError(c, response.StatusForbidden, errorResp)
+	} else if strings.Contains(err.Error(), "not a robot member") {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: err.Error(),
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+	} else if strings.Contains(err.Error(), "already exists") || strings.Contains(err.Error(), "duplicate") {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: err.Error(),
+		}
+		response.RespondWithError(c, response.StatusConflict, errorResp)
+	} else {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrServerError.Code,
+			ErrorDescription: "Failed to update robot member",
+		}
+		response.RespondWithError(c, response.StatusInternalServerError, errorResp)
+	}
+}
+
 // GinMemberUpdate handles PUT /teams/:team_id/members/:member_id - Update team member
 func GinMemberUpdate(c *gin.Context) {
 	// Get authorized user info
@@ -552,7 +640,12 @@ func GinMemberUpdate(c *gin.Context) {
 		updateData["role_id"] = req.RoleID
 	}
 	if req.Status != "" {
+		// The reason belongs to this status change; a change without one clears the old reason
 		updateData["status"] = req.Status
+		updateData["status_reason"] = nil
+		if req.StatusReason != "" {
+			updateData["status_reason"] = req.StatusReason
+		}
 	}
 	if req.Settings != nil {
 		updateData["settings"] = req.Settings
@@ -560,13 +653,33 @@ func GinMemberUpdate(c *gin.Context) {
 	if req.LastActivity != "" {
 		updateData["last_activity"] = req.LastActivity
 	}
+	if patch := req.robotFields(); patch != nil {
+		robotData, err := robotPatchData(patch)
+		if err != nil {
+			errorResp := &response.ErrorResponse{
+				Code:             response.ErrInvalidRequest.Code,
+				ErrorDescription: err.Error(),
+			}
+			response.RespondWithError(c, response.StatusBadRequest, errorResp)
+			return
+		}
+		for k, v := range robotData {
+			updateData[k] = v
+		}
+	}
 
 	// Call business logic
 	err := memberUpdate(c.Request.Context(), authInfo.UserID, teamID, memberID, updateData)
 	if err != nil {
 		log.Error("Failed to update member: %v", err)
 		// Check error type for appropriate response
-		if strings.Contains(err.Error(), "not found") {
+		if errors.Is(err, robottypes.ErrPayloadLimit) || strings.Contains(err.Error(), "not a robot member") {
+			errorResp := &response.ErrorResponse{
+				Code:             response.ErrInvalidRequest.Code,
+				ErrorDescription: err.Error(),
+			}
+			response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		} else if strings.Contains(err.Error(), "not found") {
 			errorResp := &response.ErrorResponse{
 				Code:             response.ErrInvalidRequest.Code,
 				ErrorDescription: "Member not found",
@@ -784,9 +897,9 @@ func GinMemberDelete(c *gin.Context) {
 // Args[1] map: Query parameters with advanced filtering
 //
 //	{
-//	  "page": 1, "pagesize": 20,
-//	  "status": "active", "member_type": "user", "role_id": "admin",
-//	  "email": "test@example.com", "display_name": "John",
+//	  "page": 1, "pagesize": 20, "cursor": "",
+//	  "status": "active", "member_type": "user", "role_id": "admin,owner",
+//	  "email": "test@example.com", "robot_email": "bot@example.com", "display_name": "John",
 //	  "order": "created_at desc",
 //	  "fields": ["id", "user_id", "display_name", "role_id"]
 //	}
@@ -826,6 +939,10 @@ func ProcessMemberList(process *process.Process) interface{} {
 		}
 	}
 
+	if cursor, ok := queryMap["cursor"].(string); ok {
+		req.Cursor = &cursor
+	}
+
 	// Parse filters
 	if status, ok := queryMap["status"].(string); ok {
 		req.Status = status
@@ -835,18 +952,44 @@ func ProcessMemberList(process *process.Process) interface{} {
 		req.MemberType = memberType
 	}
 
-	if roleID, ok := queryMap["role_id"].(string); ok {
+	// One role, a comma-separated list, or an array of roles
+	switch roleID := queryMap["role_id"].(type) {
+	case string:
 		req.RoleID = roleID
+	case []string:
+		req.RoleID = strings.Join(roleID, ",")
+	case []interface{}:
+		roles := make([]string, len(roleID))
+		for i, role := range roleID {
+			roles[i] = utils.ToString(role)
+		}
+		req.RoleID = strings.Join(roles, ",")
 	}
 
 	if email, ok := queryMap["email"].(string); ok {
 		req.Email = email
 	}
 
+	if robotEmail, ok := queryMap["robot_email"].(string); ok {
+		req.RobotEmail = robotEmail
+	}
+
 	if displayName, ok := queryMap["display_name"].(string); ok {
 		req.DisplayName = displayName
 	}
 
+	if keyword, ok := queryMap["keyword"].(string); ok {
+		req.Keyword = keyword
+	}
+
+	if activeBefore, ok := queryMap["active_before"].(string); ok {
+		req.ActiveBefore = activeBefore
+	}
+
+	if activeAfter, ok := queryMap["active_after"].(string); ok {
+		req.ActiveAfter = activeAfter
+	}
+
 	// Parse sorting
 	if order, ok := queryMap["order"].(string); ok {
 		req.Order = order
@@ -879,7 +1022,7 @@ func ProcessMemberList(process *process.Process) interface{} {
 	}
 
 	// Call business logic (no requestBaseURL available in process context, use empty string)
-	result, err := memberList(ctx, userIDStr, teamID, req, "", locale)
+	result, err := cachedMemberList(ctx, userIDStr, teamID, req, "", locale)
 	if err != nil {
 		exception.New("failed to list members: %s", 500, err.Error()).Throw()
 	}
@@ -890,7 +1033,8 @@ func ProcessMemberList(process *process.Process) interface{} {
 // ProcessMemberGet user.member.get Member get processor
 // Args[0] string: team_id
 // Args[1] string: member_id
-// Return: map: Member details
+// Args[2] []string|string: optional fields to select (comma-separated string or list)
+// Return: map: Member details, without the fields hidden from the session user's role
 func ProcessMemberGet(process *process.Process) interface{} {
 	process.ValidateArgNums(2)
 
@@ -910,13 +1054,32 @@ func ProcessMemberGet(process *process.Process) interface{} {
 		ctx = context.Background()
 	}
 
+	// Optional field selection: list or comma-separated string
+	var fields []string
+	if process.NumOfArgs() > 2 {
+		switch v := process.Args[2].(type) {
+		case string:
+			for _, field := range strings.Split(v, ",") {
+				if field = strings.TrimSpace(field); field != "" {
+					fields = append(fields, field)
+				}
+			}
+		case []string:
+			fields = v
+		case []interface{}:
+			for _, field := range v {
+				fields = append(fields, utils.ToString(field))
+			}
+		}
+	}
+
 	// Call business logic
-	result, err := memberGet(ctx, userIDStr, teamID, memberID)
+	result, err := memberGet(ctx, userIDStr, teamID, memberID, fields)
 	if err != nil {
 		exception.New("failed to get member: %s", 500, err.Error()).Throw()
 	}
 
-	return result
+	return viewerHiddenFields(ctx, teamID, userIDStr, "en").strip(result)
 }
 
 // ProcessMemberUpdate user.member.update Member update processor
@@ -947,6 +1110,9 @@ func ProcessMemberUpdate(process *process.Process) interface{} {
 	// Call business logic
 	err := memberUpdate(ctx, userIDStr, teamID, memberID, updateData)
 	if err != nil {
+		if errors.Is(err, robottypes.ErrPayloadLimit) || strings.Contains(err.Error(), "not a robot member") {
+			exception.New("failed to update member: %s", 400, err.Error()).Throw()
+		}
 		exception.New("failed to update member: %s", 500, err.Error()).Throw()
 	}
 
@@ -1097,6 +1263,63 @@ func memberList(ctx context.Context, userID, teamID string, req *MemberListReque
 	// Get team configuration for invitation link generation
 	teamConfig := GetTeamConfig(locale)
 
+	param, keyword, err := memberListParam(ctx, teamID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get paginated members: by cursor when one is given, by page otherwise
+	var result maps.MapStr
+	if req.Cursor != nil {
+		result, err = provider.PaginateMembersCursor(ctx, param, *req.Cursor, req.PageSize)
+	} else {
+		result, err = provider.PaginateMembers(ctx, param, req.Page, req.PageSize)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve members: %w", err)
+	}
+
+	// Add invitation_link for pending members with token
+	if data, ok := result["data"].([]maps.MapStrAny); ok {
+		for i := range data {
+			member := data[i]
+			// Only generate invitation link for pending members with invitation_id and invitation_token
+			status, _ := member["status"].(string)
+			invitationID, _ := member["invitation_id"].(string)
+			invitationToken, _ := member["invitation_token"].(string)
+
+			if status == "pending" && invitationID != "" && invitationToken != "" {
+				// Build invitation link using the centralized helper function
+				invitationLink := buildTeamInvitationLink(invitationID, invitationToken, teamConfig, requestBaseURL)
+				member["invitation_link"] = invitationLink
+			}
+		}
+	}
+
+	// Echo the search term so clients can highlight matches
+	if keyword != "" {
+		result["keyword"] = keyword
+	}
+
+	return result, nil
+}
+
+// parseMemberActivityTime parses an RFC3339 activity bound, nil when value is empty
+func parseMemberActivityTime(name, value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %q is not an RFC3339 timestamp (e.g. 2024-01-02T15:04:05Z)", name, value)
+	}
+	return &t, nil
+}
+
+// memberListParam builds the member query of a list request: its filters, keyword search,
+// ordering and field selection. It returns the trimmed keyword alongside.
+func memberListParam(ctx context.Context, teamID string, req *MemberListRequest) (model.QueryParam, string, error) {
 	// Build query parameters
 	param := model.QueryParam{
 		Wheres: []model.QueryWhere{
@@ -1107,11 +1330,8 @@ func memberList(ctx context.Context, userID, teamID string, req *MemberListReque
 	// Add filters
 	if req.Status != "" {
 		// Validate status values
-		validStatuses := map[string]bool{
-			"pending": true, "active": true, "inactive": true, "suspended": true,
-		}
-		if !validStatuses[req.Status] {
-			return nil, fmt.Errorf("invalid status value: %s (must be one of: pending, active, inactive, suspended)", req.Status)
+		if !memberStatuses[req.Status] {
+			return model.QueryParam{}, "", fmt.Errorf("invalid status value: %s (must be one of: pending, active, inactive, suspended)", req.Status)
 		}
 		param.Wheres = append(param.Wheres, model.QueryWhere{
 			Column: "status",
@@ -1125,7 +1345,7 @@ func memberList(ctx context.Context, userID, teamID string, req *MemberListReque
 			"user": true, "robot": true,
 		}
 		if !validTypes[req.MemberType] {
-			return nil, fmt.Errorf("invalid member_type value: %s (must be one of: user, robot)", req.MemberType)
+			return model.QueryParam{}, "", fmt.Errorf("invalid member_type value: %s (must be one of: user, robot)", req.MemberType)
 		}
 		param.Wheres = append(param.Wheres, model.QueryWhere{
 			Column: "member_type",
@@ -1134,16 +1354,53 @@ func memberList(ctx context.Context, userID, teamID string, req *MemberListReque
 	}
 
 	if req.RoleID != "" {
+		roleIDs, err := splitRoleIDs(req.RoleID)
+		if err != nil {
+			return model.QueryParam{}, "", err
+		}
+		if len(roleIDs) == 1 {
+			param.Wheres = append(param.Wheres, model.QueryWhere{
+				Column: "role_id",
+				Value:  roleIDs[0],
+			})
+		} else {
+			param.Wheres = append(param.Wheres, model.QueryWhere{
+				Column: "role_id",
+				Value:  roleIDs,
+				OP:     "in",
+			})
+		}
+	}
+
+	if email := strings.TrimSpace(req.Email); email != "" {
+		// Case-insensitive: find how the address is spelled in the team, then match exactly
+		provider, err := getUserProvider()
+		if err != nil {
+			return model.QueryParam{}, "", fmt.Errorf("failed to get user provider: %w", err)
+		}
+		emails, err := provider.GetTeamMemberEmails(ctx, teamID, email)
+		if err != nil {
+			return model.QueryParam{}, "", err
+		}
+		if len(emails) == 0 {
+			emails = []string{email}
+		}
 		param.Wheres = append(param.Wheres, model.QueryWhere{
-			Column: "role_id",
-			Value:  req.RoleID,
+			Column: "email",
+			Value:  emails,
+			OP:     "in",
 		})
 	}
 
-	if req.Email != "" {
+	if strings.TrimSpace(req.RobotEmail) != "" {
+		// Normalized the way CreateRobotMember stores it, so the domain's case doesn't matter
+		robotEmail, err := utils.NormalizeEmailAddress(req.RobotEmail)
+		if err != nil {
+			return model.QueryParam{}, "", fmt.Errorf("invalid robot_email: %w", err)
+		}
 		param.Wheres = append(param.Wheres, model.QueryWhere{
-			Column: "email",
-			Value:  req.Email,
+			Column: "robot_email",
+			Value:  robotEmail,
 		})
 	}
 
@@ -1155,6 +1412,40 @@ func memberList(ctx context.Context, userID, teamID string, req *MemberListReque
 		})
 	}
 
+	// Keyword search: any of the text columns may match, ANDed with the filters above
+	keyword := strings.TrimSpace(req.Keyword)
+	if keyword != "" {
+		if utf8.RuneCountInString(keyword) < minMemberKeywordLength {
+			return model.QueryParam{}, "", fmt.Errorf("invalid keyword: must be at least %d characters", minMemberKeywordLength)
+		}
+		match := "%" + keyword + "%"
+		param.Wheres = append(param.Wheres, model.QueryWhere{Wheres: []model.QueryWhere{
+			{Column: "display_name", Value: match, OP: "like"},
+			{Column: "email", Value: match, OP: "like", Method: "orwhere"},
+			{Column: "robot_email", Value: match, OP: "like", Method: "orwhere"},
+			{Column: "bio", Value: match, OP: "like", Method: "orwhere"},
+		}})
+	}
+
+	// Activity range: comparisons on NULL never match, so never-active members drop out
+	activeAfter, err := parseMemberActivityTime("active_after", req.ActiveAfter)
+	if err != nil {
+		return model.QueryParam{}, "", err
+	}
+	activeBefore, err := parseMemberActivityTime("active_before", req.ActiveBefore)
+	if err != nil {
+		return model.QueryParam{}, "", err
+	}
+	if activeAfter != nil && activeBefore != nil && activeAfter.After(*activeBefore) {
+		return model.QueryParam{}, "", fmt.Errorf("invalid activity range: active_after is later than active_before")
+	}
+	if activeAfter != nil {
+		param.Wheres = append(param.Wheres, model.QueryWhere{Column: "last_active_at", OP: "ge", Value: *activeAfter})
+	}
+	if activeBefore != nil {
+		param.Wheres = append(param.Wheres, model.QueryWhere{Column: "last_active_at", OP: "le", Value: *activeBefore})
+	}
+
 	// Parse and validate sorting
 	validOrderFields := map[string]bool{
 		"created_at": true,
@@ -1185,10 +1476,10 @@ func memberList(ctx context.Context, userID, teamID string, req *MemberListReque
 	// Validate and add user-specified order field
 	if orderBy != "" {
 		if !validOrderFields[orderBy] {
-			return nil, fmt.Errorf("invalid order field: %s (must be one of: created_at, joined_at)", orderBy)
+			return model.QueryParam{}, "", fmt.Errorf("invalid order field: %s (must be one of: created_at, joined_at)", orderBy)
 		}
 		if !validOrderDirs[orderDir] {
-			return nil, fmt.Errorf("invalid order direction: %s (must be one of: asc, desc)", orderDir)
+			return model.QueryParam{}, "", fmt.Errorf("invalid order direction: %s (must be one of: asc, desc)", orderDir)
 		}
 		orders = append(orders, model.QueryOrder{
 			Column: orderBy, Option: orderDir,
@@ -1211,34 +1502,29 @@ func memberList(ctx context.Context, userID, teamID string, req *MemberListReque
 		}
 	}
 
-	// Get paginated members
-	result, err := provider.PaginateMembers(ctx, param, req.Page, req.PageSize)
-	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve members: %w", err)
-	}
-
-	// Add invitation_link for pending members with token
-	if data, ok := result["data"].([]maps.MapStrAny); ok {
-		for i := range data {
-			member := data[i]
-			// Only generate invitation link for pending members with invitation_id and invitation_token
-			status, _ := member["status"].(string)
-			invitationID, _ := member["invitation_id"].(string)
-			invitationToken, _ := member["invitation_token"].(string)
+	return param, keyword, nil
+}
 
-			if status == "pending" && invitationID != "" && invitationToken != "" {
-				// Build invitation link using the centralized helper function
-				invitationLink := buildTeamInvitationLink(invitationID, invitationToken, teamConfig, requestBaseURL)
-				member["invitation_link"] = invitationLink
-			}
+// splitRoleIDs parses a role_id filter: one role or a comma-separated list, duplicates dropped
+func splitRoleIDs(roleID string) ([]string, error) {
+	parts := strings.Split(roleID, ",")
+	roleIDs := make([]string, 0, len(parts))
+	seen := make(map[string]bool, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return nil, fmt.Errorf("invalid role_id: %q contains an empty role", roleID)
+		}
+		if !seen[part] {
+			seen[part] = true
+			roleIDs = append(roleIDs, part)
 		}
 	}
-
-	return result, nil
+	return roleIDs, nil
 }
 
 // memberGet handles the business logic for getting a specific team member
-func memberGet(ctx context.Context, userID, teamID, memberID string) (maps.MapStrAny, error) {
+func memberGet(ctx context.Context, userID, teamID, memberID string, fields []string) (maps.MapStrAny, error) {
 	// Check if user has access to the team (read permission: owner or member)
 	isOwner, isMember, err := checkTeamAccess(ctx, teamID, userID)
 	if err != nil {
@@ -1256,9 +1542,16 @@ func memberGet(ctx context.Context, userID, teamID, memberID string) (maps.MapSt
 		return nil, fmt.Errorf("failed to get user provider: %w", err)
 	}
 
-	// Get member details using member_id (with all fields including robot config)
-	memberData, err := provider.GetMemberDetailByMemberID(ctx, memberID)
+	// Get member details using member_id (all fields including robot config, unless a selection is given)
+	var selectFields []interface{}
+	for _, field := range fields {
+		selectFields = append(selectFields, field)
+	}
+	memberData, err := provider.GetMemberDetailByMemberIDWithFields(ctx, memberID, selectFields)
 	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid field") {
+			return nil, err
+		}
 		return nil, fmt.Errorf("member not found: %w", err)
 	}
 
@@ -1295,6 +1588,10 @@ func memberCheckRobotEmail(ctx context.Context, userID, teamID, robotEmail strin
 
 // memberCreateRobot handles the business logic for creating a robot member
 func memberCreateRobot(ctx context.Context, userID, teamID string, robotData maps.MapStrAny) (string, error) {
+	if err := robottypes.CheckRobotPayload(robotData); err != nil {
+		return "", err
+	}
+
 	// Check if user has access to the team (write permission: owner only)
 	isOwner, _, err := checkTeamAccess(ctx, teamID, userID)
 	if err != nil {
@@ -1318,11 +1615,29 @@ func memberCreateRobot(ctx context.Context, userID, teamID string, robotData map
 		return "", fmt.Errorf("failed to create robot member: %w", err)
 	}
 
+	if created, err := provider.GetMemberByMemberID(ctx, memberID); err == nil {
+		recordMemberAudit(ctx, userID, teamID, memberID, user.MemberAuditCreate, nil, created)
+	}
+
+	// Best effort: the member exists, subscribers missing the news must not fail the request
+	_, err = event.Push(context.Background(), robotevents.MemberCreated, robotevents.MemberPayload{
+		TeamID:   teamID,
+		MemberID: memberID,
+		RoleID:   utils.ToString(robotData["role_id"]),
+	})
+	if err != nil {
+		log.Warn("Failed to publish %s for member %s: %v", robotevents.MemberCreated, memberID, err)
+	}
+
 	return memberID, nil
 }
 
 // memberUpdateRobot handles the business logic for updating a robot member
 func memberUpdateRobot(ctx context.Context, userID, teamID, memberID string, robotData maps.MapStrAny) error {
+	if err := robottypes.CheckRobotPayload(robotData); err != nil {
+		return err
+	}
+
 	// Check if user has access to the team (write permission: owner only)
 	isOwner, _, err := checkTeamAccess(ctx, teamID, userID)
 	if err != nil {
@@ -1340,17 +1655,39 @@ func memberUpdateRobot(ctx context.Context, userID, teamID, memberID string, rob
 		return fmt.Errorf("failed to get user provider: %w", err)
 	}
 
+	// The member must be a robot of this team; read with its robot config so the
+	// audit sees config changes
+	member, err := provider.GetMemberDetailByMemberID(ctx, memberID)
+	if err != nil || utils.ToString(member["team_id"]) != teamID {
+		return fmt.Errorf("robot member not found: %s", memberID)
+	}
+	if utils.ToString(member["member_type"]) != "robot" {
+		return fmt.Errorf("member %s is not a robot member", memberID)
+	}
+
 	// Use UpdateRobotMember method which handles robot-specific logic and validation
 	err = provider.UpdateRobotMember(ctx, memberID, robotData)
 	if err != nil {
 		return fmt.Errorf("failed to update robot member: %w", err)
 	}
+	recordMemberAuditSince(ctx, userID, teamID, memberID, member)
+
+	// Running robots read their config from the robot cache
+	robotapi.RefreshRobot(robottypes.NewContext(ctx, nil), memberID, teamID)
 
 	return nil
 }
 
+// memberRobotColumns are the robot config columns memberUpdate accepts for robot members
+var memberRobotColumns = []string{"system_prompt", "language_model", "cost_limit", "autonomous_mode", "agents", "mcp_servers"}
+
 // memberUpdate handles the business logic for updating a team member
 func memberUpdate(ctx context.Context, userID, teamID, memberID string, updateData maps.MapStrAny) error {
+	// Generic updates can carry robot columns too
+	if err := robottypes.CheckRobotPayload(updateData); err != nil {
+		return err
+	}
+
 	// Check if user has access to the team (write permission: owner only)
 	isOwner, _, err := checkTeamAccess(ctx, teamID, userID)
 	if err != nil {
@@ -1369,19 +1706,47 @@ func memberUpdate(ctx context.Context, userID, teamID, memberID string, updateDa
 	}
 
 	// Check if member exists using member_id
-	_, err = provider.GetMemberByMemberID(ctx, memberID)
+	before, err := provider.GetMemberByMemberID(ctx, memberID)
 	if err != nil {
 		return fmt.Errorf("member not found: %w", err)
 	}
 
+	// Robot config only applies to robots, and goes through the robot update so the
+	// language model is checked against the team's models
+	robotData := maps.MapStrAny{}
+	for _, column := range memberRobotColumns {
+		if value, ok := updateData[column]; ok {
+			if utils.ToString(before["member_type"]) != "robot" {
+				return fmt.Errorf("member %s is not a robot member: %s cannot be set", memberID, column)
+			}
+			robotData[column] = value
+		}
+	}
+	if len(robotData) > 0 {
+		if err := provider.UpdateRobotMember(ctx, memberID, robotData); err != nil {
+			return fmt.Errorf("failed to update robot member: %w", err)
+		}
+	}
+
 	// Add updated_at timestamp
-	updateData["updated_at"] = time.Now()
+	memberData := maps.MapStrAny{"updated_at": time.Now()}
+	for k, v := range updateData {
+		if _, ok := robotData[k]; !ok {
+			memberData[k] = v
+		}
+	}
 
 	// Update member using member_id
-	err = provider.UpdateMemberByMemberID(ctx, memberID, updateData)
+	err = provider.UpdateMemberByMemberID(ctx, memberID, memberData)
 	if err != nil {
 		return fmt.Errorf("failed to update member: %w", err)
 	}
+	recordMemberAudit(ctx, userID, teamID, memberID, user.MemberAuditUpdate, before, updateData)
+
+	// Running robots read their config from the robot cache
+	if len(robotData) > 0 {
+		robotapi.RefreshRobot(robottypes.NewContext(ctx, nil), memberID, teamID)
+	}
 
 	return nil
 }
@@ -1502,7 +1867,7 @@ func memberDelete(ctx context.Context, userID, teamID, memberID string) error {
 	}
 
 	// Check if member exists using member_id
-	_, err = provider.GetMemberByMemberID(ctx, memberID)
+	before, err := provider.GetMemberByMemberID(ctx, memberID)
 	if err != nil {
 		return fmt.Errorf("member not found: %w", err)
 	}
@@ -1512,6 +1877,7 @@ func memberDelete(ctx context.Context, userID, teamID, memberID string) error {
 	if err != nil {
 		return fmt.Errorf("failed to delete member: %w", err)
 	}
+	recordMemberAudit(ctx, userID, teamID, memberID, user.MemberAuditDelete, before, nil)
 
 	return nil
 }
@@ -1531,8 +1897,28 @@ func checkTeamAccess(ctx context.Context, teamID, userID string) (bool, bool, er
 	return provider.CheckTeamAccess(ctx, teamID, userID)
 }
 
-// mapToMemberResponse converts a map to MemberResponse
-func mapToMemberResponse(data maps.MapStr) MemberResponse {
+// projectMemberResponse keeps only the requested fields of a member response
+func projectMemberResponse(member interface{}, fields []string) map[string]interface{} {
+	projected := map[string]interface{}{}
+	raw, err := json.Marshal(member)
+	if err != nil {
+		return projected
+	}
+	var all map[string]interface{}
+	if err := json.Unmarshal(raw, &all); err != nil {
+		return projected
+	}
+	for _, field := range fields {
+		if v, ok := all[field]; ok {
+			projected[field] = v
+		}
+	}
+	return projected
+}
+
+// mapToMemberResponse converts a map to MemberResponse, leaving out the fields hidden from the viewer
+func mapToMemberResponse(data maps.MapStr, hidden hiddenFields) MemberResponse {
+	data = hidden.strip(data)
 	member := MemberResponse{
 		ID:                  utils.ToInt64(data["id"]),
 		MemberID:            utils.ToString(data["member_id"]),
@@ -1547,6 +1933,7 @@ func mapToMemberResponse(data maps.MapStr) MemberResponse {
 		RoleID:              utils.ToString(data["role_id"]),
 		IsOwner:             data["is_owner"], // Keep original type (int or bool)
 		Status:              utils.ToString(data["status"]),
+		StatusReason:        utils.ToString(data["status_reason"]),
 		InvitationID:        utils.ToString(data["invitation_id"]),
 		InvitedBy:           utils.ToString(data["invited_by"]),
 		InvitedAt:           utils.ToTimeString(data["invited_at"]),
@@ -1589,10 +1976,11 @@ func mapToMemberResponse(data maps.MapStr) MemberResponse {
 	return member
 }
 
-// mapToMemberDetailResponse converts a map to MemberDetailResponse
-func mapToMemberDetailResponse(data maps.MapStr) MemberDetailResponse {
+// mapToMemberDetailResponse converts a map to MemberDetailResponse, leaving out the fields hidden from the viewer
+func mapToMemberDetailResponse(data maps.MapStr, hidden hiddenFields) MemberDetailResponse {
+	data = hidden.strip(data)
 	member := MemberDetailResponse{
-		MemberResponse: mapToMemberResponse(data),
+		MemberResponse: mapToMemberResponse(data, nil),
 		// Robot-specific fields
 		SystemPrompt:      utils.ToString(data["system_prompt"]),
 		ManagerID:         utils.ToString(data["manager_id"]),
@@ -0,0 +1,308 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/kun/maps"
+	"github.com/yaoapp/yao/openapi/oauth"
+	"github.com/yaoapp/yao/openapi/oauth/providers/user"
+	"github.com/yaoapp/yao/openapi/response"
+)
+
+// memberAuditSnapshotFields are recorded when a member is created or removed
+var memberAuditSnapshotFields = []string{
+	"member_type", "user_id", "display_name", "email", "robot_email", "role_id", "status", "is_owner",
+}
+
+// memberAuditSkipped are bookkeeping columns that never make an audit entry;
+// permission scope fields (__yao_*) are skipped as well
+var memberAuditSkipped = map[string]bool{
+	"id": true, "created_at": true, "updated_at": true, "deleted_at": true,
+}
+
+// memberAuditEncrypted replaces the values of columns encrypted at rest: the trail
+// records that such a column changed, never what it holds
+const memberAuditEncrypted = "[encrypted]"
+
+// Member Audit Handlers
+
+// GinMemberAudit handles GET /teams/:id/members/:member_id/audit - Get a member's change trail (owner only)
+func GinMemberAudit(c *gin.Context) {
+	// Get authorized user info
+	authInfo := oauth.GetAuthorizedInfo(c)
+	if authInfo == nil || authInfo.UserID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidClient.Code,
+			ErrorDescription: "User not authenticated",
+		}
+		response.RespondWithError(c, response.StatusUnauthorized, errorResp)
+		return
+	}
+
+	teamID := c.Param("id")
+	memberID := c.Param("member_id")
+	if teamID == "" || memberID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Team ID and Member ID are required",
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	// Parse pagination parameters
+	page := 1
+	pagesize := 20
+
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+
+	if ps := c.Query("pagesize"); ps != "" {
+		if parsed, err := strconv.Atoi(ps); err == nil && parsed > 0 && parsed <= 100 {
+			pagesize = parsed
+		}
+	}
+
+	// Call business logic
+	result, err := memberAuditList(c.Request.Context(), authInfo.UserID, teamID, memberID, page, pagesize)
+	if err != nil {
+		log.Error("Failed to get member audit trail: %v", err)
+		// Check error type for appropriate response
+		if strings.Contains(err.Error(), "not found") {
+			errorResp := &response.ErrorResponse{
+				Code:             response.ErrInvalidRequest.Code,
+				ErrorDescription: "Team not found",
+			}
+			response.RespondWithError(c, response.StatusNotFound, errorResp)
+		} else if strings.Contains(err.Error(), "access denied") {
+			errorResp := &response.ErrorResponse{
+				Code:             response.ErrAccessDenied.Code,
+				ErrorDescription: err.Error(),
+			}
+			response.RespondWithError(c, response.StatusForbidden, errorResp)
+		} else {
+			errorResp := &response.ErrorResponse{
+				Code:             response.ErrServerError.Code,
+				ErrorDescription: "Failed to retrieve member audit trail",
+			}
+			response.RespondWithError(c, response.StatusInternalServerError, errorResp)
+		}
+		return
+	}
+
+	// Return the paginated result
+	response.RespondWithSuccess(c, http.StatusOK, result)
+}
+
+// memberAuditList returns a member's change trail; only the team owner may read it.
+// Entries of removed members stay readable.
+func memberAuditList(ctx context.Context, userID, teamID, memberID string, page, pagesize int) (maps.MapStr, error) {
+	isOwner, _, err := checkTeamAccess(ctx, teamID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isOwner {
+		return nil, fmt.Errorf("access denied: only team owner can read the member audit trail")
+	}
+
+	provider, err := getUserProvider()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user provider: %w", err)
+	}
+	return provider.PaginateMemberAudits(ctx, teamID, memberID, page, pagesize)
+}
+
+// recordMemberAudit stores who changed what on a member. before is the member as it was
+// (nil on create), after the new values (nil on delete). Nothing is written when no field
+// changed. The trail is best effort: a failed write is logged, never returned.
+func recordMemberAudit(ctx context.Context, actorID, teamID, memberID, action string, before, after maps.MapStrAny) {
+	var changes map[string]interface{}
+	switch action {
+	case user.MemberAuditCreate:
+		changes = memberAuditDiff(nil, memberAuditSnapshot(after))
+	case user.MemberAuditDelete:
+		changes = memberAuditDiff(memberAuditSnapshot(before), nil)
+	default:
+		changes = memberAuditDiff(before, after)
+	}
+	if len(changes) == 0 {
+		return
+	}
+
+	provider, err := getUserProvider()
+	if err == nil {
+		err = provider.CreateMemberAudit(ctx, maps.MapStrAny{
+			"team_id":   teamID,
+			"member_id": memberID,
+			"actor_id":  actorID,
+			"action":    action,
+			"changes":   changes,
+		})
+	}
+	if err != nil {
+		log.Warn("Failed to record %s audit of member %s: %v", action, memberID, err)
+	}
+}
+
+// recordMemberAuditSince records an update of memberID by comparing before with the
+// member as it is now. For changes made by provider calls whose input does not map
+// one-to-one onto member columns (robot config, ownership transfer). Only the fields
+// read into before are compared.
+func recordMemberAuditSince(ctx context.Context, actorID, teamID, memberID string, before maps.MapStrAny) {
+	provider, err := getUserProvider()
+	if err != nil {
+		log.Warn("Failed to record update audit of member %s: %v", memberID, err)
+		return
+	}
+	current, err := provider.GetMemberDetailByMemberID(ctx, memberID)
+	if err != nil {
+		log.Warn("Failed to record update audit of member %s: %v", memberID, err)
+		return
+	}
+	after := maps.MapStrAny{}
+	for field := range before {
+		after[field] = current[field]
+	}
+	recordMemberAudit(ctx, actorID, teamID, memberID, user.MemberAuditUpdate, before, after)
+}
+
+// memberAuditSnapshot keeps the identifying fields of a member
+func memberAuditSnapshot(member maps.MapStrAny) maps.MapStrAny {
+	snapshot := maps.MapStrAny{}
+	for _, field := range memberAuditSnapshotFields {
+		if v, ok := member[field]; ok && v != nil {
+			snapshot[field] = v
+		}
+	}
+	return snapshot
+}
+
+// memberAuditDiff lists the fields whose value differs between before and after as
+// {field: {"before": old, "after": new}}. Only fields present in after are compared,
+// or every field of before when after is nil. Map values (settings, robot_config, ...)
+// are compared per top-level key and reported as "field.key". Columns encrypted at
+// rest are compared whole and recorded as memberAuditEncrypted, keys included.
+func memberAuditDiff(before, after maps.MapStrAny) map[string]interface{} {
+	changes := map[string]interface{}{}
+	fields := after
+	if after == nil {
+		fields = before
+	}
+
+	for field := range fields {
+		if memberAuditSkipped[field] || strings.HasPrefix(field, "__yao_") {
+			continue
+		}
+		encrypted := user.IsEncryptedMemberField(field)
+		oldValue := memberAuditValue(before[field])
+		newValue := memberAuditValue(after[field])
+
+		oldMap, oldIsMap := oldValue.(map[string]interface{})
+		newMap, newIsMap := newValue.(map[string]interface{})
+		if (oldIsMap || newIsMap) && !encrypted {
+			keys := map[string]bool{}
+			for key := range oldMap {
+				keys[key] = true
+			}
+			for key := range newMap {
+				keys[key] = true
+			}
+			for key := range keys {
+				if !reflect.DeepEqual(oldMap[key], newMap[key]) {
+					changes[field+"."+key] = map[string]interface{}{
+						"before": redactAuditValue(key, oldMap[key]),
+						"after":  redactAuditValue(key, newMap[key]),
+					}
+				}
+			}
+			continue
+		}
+
+		if !reflect.DeepEqual(oldValue, newValue) {
+			changes[field] = map[string]interface{}{
+				"before": auditValue(field, oldValue, encrypted),
+				"after":  auditValue(field, newValue, encrypted),
+			}
+		}
+	}
+	return changes
+}
+
+// memberAuditValue normalizes a value through JSON so stored and requested values compare
+// alike: structs become maps, numbers float64, and JSON text columns decoded values
+func memberAuditValue(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	if s, ok := v.(string); ok {
+		trimmed := strings.TrimSpace(s)
+		if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+			var decoded interface{}
+			if json.Unmarshal([]byte(trimmed), &decoded) == nil {
+				return decoded
+			}
+		}
+		return s
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(raw, &normalized); err != nil {
+		return string(raw)
+	}
+	return normalized
+}
+
+// auditValue is the value recorded for a changed field: the encrypted marker for columns
+// encrypted at rest (nil stays nil, so setting and clearing remain visible), else the
+// value with credentials redacted
+func auditValue(key string, v interface{}, encrypted bool) interface{} {
+	if encrypted && v != nil {
+		return memberAuditEncrypted
+	}
+	return redactAuditValue(key, v)
+}
+
+// redactAuditValue masks credentials (secrets, tokens, passwords, keys) wherever they
+// appear in a value, so the trail never holds them
+func redactAuditValue(key string, v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	lower := strings.ToLower(key)
+	for _, word := range []string{"secret", "token", "password", "api_key", "apikey"} {
+		if strings.Contains(lower, word) {
+			return "[redacted]"
+		}
+	}
+
+	switch value := v.(type) {
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(value))
+		for k, item := range value {
+			redacted[k] = redactAuditValue(k, item)
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(value))
+		for i, item := range value {
+			redacted[i] = redactAuditValue("", item)
+		}
+		return redacted
+	}
+	return v
+}
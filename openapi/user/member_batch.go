@@ -0,0 +1,257 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/kun/log"
+	robotapi "github.com/yaoapp/yao/agent/robot/api"
+	robottypes "github.com/yaoapp/yao/agent/robot/types"
+	"github.com/yaoapp/yao/openapi/oauth/authorized"
+	"github.com/yaoapp/yao/openapi/oauth/providers/user"
+	oauthtypes "github.com/yaoapp/yao/openapi/oauth/types"
+	"github.com/yaoapp/yao/openapi/response"
+	"github.com/yaoapp/yao/openapi/utils"
+)
+
+// Robot Member Batch Trigger Handlers
+
+// GinMemberTriggerBatch handles POST /teams/:id/members/:member_id/trigger/batch - Run a robot once per parameter set
+func GinMemberTriggerBatch(c *gin.Context) {
+	authInfo := authorized.GetInfo(c)
+	if authInfo == nil || authInfo.UserID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidClient.Code,
+			ErrorDescription: "User not authenticated",
+		}
+		response.RespondWithError(c, response.StatusUnauthorized, errorResp)
+		return
+	}
+
+	teamID := c.Param("id")
+	memberID := c.Param("member_id")
+	if teamID == "" || memberID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Team ID and Member ID are required",
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	var req BatchTriggerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Invalid request body: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	result, err := memberTriggerBatch(c.Request.Context(), authInfo, teamID, memberID, &req)
+	if err != nil {
+		log.Error("Failed to trigger batch for member %s: %v", memberID, err)
+		respondRobotMemberError(c, err, "Failed to trigger batch")
+		return
+	}
+
+	response.RespondWithSuccess(c, http.StatusCreated, result)
+}
+
+// GinMemberGetBatch handles GET /teams/:id/members/:member_id/batches/:batch_id - Get batch progress
+func GinMemberGetBatch(c *gin.Context) {
+	authInfo, teamID, memberID, batchID, ok := bindBatchParams(c)
+	if !ok {
+		return
+	}
+
+	result, err := memberGetBatch(c.Request.Context(), authInfo, teamID, memberID, batchID)
+	if err != nil {
+		log.Error("Failed to get batch %s: %v", batchID, err)
+		respondRobotMemberError(c, err, "Failed to retrieve batch")
+		return
+	}
+
+	response.RespondWithSuccess(c, http.StatusOK, result)
+}
+
+// GinMemberCancelBatch handles POST /teams/:id/members/:member_id/batches/:batch_id/cancel - Cancel remaining batch items
+func GinMemberCancelBatch(c *gin.Context) {
+	authInfo, teamID, memberID, batchID, ok := bindBatchParams(c)
+	if !ok {
+		return
+	}
+
+	result, err := memberCancelBatch(c.Request.Context(), authInfo, teamID, memberID, batchID)
+	if err != nil {
+		log.Error("Failed to cancel batch %s: %v", batchID, err)
+		respondRobotMemberError(c, err, "Failed to cancel batch")
+		return
+	}
+
+	response.RespondWithSuccess(c, http.StatusOK, result)
+}
+
+// GinMemberBatchSummary handles GET /teams/:id/members/:member_id/batches/:batch_id/summary - Download batch results as CSV
+func GinMemberBatchSummary(c *gin.Context) {
+	authInfo, teamID, memberID, batchID, ok := bindBatchParams(c)
+	if !ok {
+		return
+	}
+
+	result, err := memberGetBatch(c.Request.Context(), authInfo, teamID, memberID, batchID)
+	if err != nil {
+		log.Error("Failed to get batch %s: %v", batchID, err)
+		respondRobotMemberError(c, err, "Failed to retrieve batch")
+		return
+	}
+
+	data, err := robotapi.BatchSummaryCSV(&robottypes.Batch{BatchID: result.BatchID, Items: result.Items})
+	if err != nil {
+		log.Error("Failed to render batch summary %s: %v", batchID, err)
+		respondRobotMemberError(c, err, "Failed to render batch summary")
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"batch-%s.csv\"", batchID))
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", data)
+}
+
+// bindBatchParams validates auth and path parameters shared by the batch handlers
+func bindBatchParams(c *gin.Context) (*oauthtypes.AuthorizedInfo, string, string, string, bool) {
+	authInfo := authorized.GetInfo(c)
+	if authInfo == nil || authInfo.UserID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidClient.Code,
+			ErrorDescription: "User not authenticated",
+		}
+		response.RespondWithError(c, response.StatusUnauthorized, errorResp)
+		return nil, "", "", "", false
+	}
+
+	teamID := c.Param("id")
+	memberID := c.Param("member_id")
+	batchID := c.Param("batch_id")
+	if teamID == "" || memberID == "" || batchID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Team ID, Member ID and Batch ID are required",
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return nil, "", "", "", false
+	}
+
+	return authInfo, teamID, memberID, batchID, true
+}
+
+// Robot member errors, mapped onto HTTP statuses by respondRobotMemberError
+var (
+	errAccessDenied   = errors.New("access denied")
+	errMemberNotFound = errors.New("member not found")
+	errInvalidRequest = errors.New("invalid request")
+)
+
+// respondRobotMemberError maps robot member (batch, capability) errors to HTTP responses.
+// Only known sentinels are told apart; anything else answers with the generic fallback.
+func respondRobotMemberError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, robottypes.ErrBatchNotFound), errors.Is(err, robottypes.ErrRobotNotFound),
+		errors.Is(err, errMemberNotFound), errors.Is(err, errInvitationNotFound):
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: err.Error(),
+		}
+		response.RespondWithError(c, response.StatusNotFound, errorResp)
+	case errors.Is(err, errAccessDenied):
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrAccessDenied.Code,
+			ErrorDescription: err.Error(),
+		}
+		response.RespondWithError(c, response.StatusForbidden, errorResp)
+	case errors.Is(err, robottypes.ErrBatchEmpty), errors.Is(err, robottypes.ErrBatchTooLarge),
+		errors.Is(err, robottypes.ErrTriggerDisabled), errors.Is(err, robottypes.ErrRobotPaused),
+		errors.Is(err, errInvalidRequest), errors.Is(err, user.ErrInvalidNewOwner), errors.Is(err, user.ErrInvalidNewManager):
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: err.Error(),
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+	default:
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrServerError.Code,
+			ErrorDescription: fallback,
+		}
+		response.RespondWithError(c, response.StatusInternalServerError, errorResp)
+	}
+}
+
+// Business Logic Functions
+
+// memberTriggerBatch handles the business logic for creating a robot batch trigger
+func memberTriggerBatch(ctx context.Context, authInfo *oauthtypes.AuthorizedInfo, teamID, memberID string, req *BatchTriggerRequest) (*robotapi.BatchResult, error) {
+	if err := checkRobotMemberAccess(ctx, authInfo.UserID, teamID, memberID); err != nil {
+		return nil, err
+	}
+
+	return robotapi.TriggerBatch(robottypes.NewContext(ctx, authInfo), memberID, &robotapi.BatchTriggerRequest{
+		Items:            req.Items,
+		SuppressDelivery: req.SuppressDelivery,
+	})
+}
+
+// memberGetBatch handles the business logic for getting a robot batch
+func memberGetBatch(ctx context.Context, authInfo *oauthtypes.AuthorizedInfo, teamID, memberID, batchID string) (*robotapi.BatchResult, error) {
+	if err := checkRobotMemberAccess(ctx, authInfo.UserID, teamID, memberID); err != nil {
+		return nil, err
+	}
+
+	result, err := robotapi.GetBatch(robottypes.NewContext(ctx, authInfo), batchID)
+	if err != nil {
+		return nil, err
+	}
+	if result.MemberID != memberID || result.TeamID != teamID {
+		return nil, robottypes.ErrBatchNotFound
+	}
+	return result, nil
+}
+
+// memberCancelBatch handles the business logic for cancelling the remaining items of a batch
+func memberCancelBatch(ctx context.Context, authInfo *oauthtypes.AuthorizedInfo, teamID, memberID, batchID string) (*robotapi.BatchResult, error) {
+	// Ownership check before mutating
+	if _, err := memberGetBatch(ctx, authInfo, teamID, memberID, batchID); err != nil {
+		return nil, err
+	}
+	return robotapi.CancelBatch(robottypes.NewContext(ctx, authInfo), batchID)
+}
+
+// checkRobotMemberAccess verifies the user belongs to the team and the member is a robot of that team
+func checkRobotMemberAccess(ctx context.Context, userID, teamID, memberID string) error {
+	isOwner, isMember, err := checkTeamAccess(ctx, teamID, userID)
+	if err != nil {
+		return err
+	}
+	if !isOwner && !isMember {
+		return fmt.Errorf("%w: user is not a member of this team", errAccessDenied)
+	}
+
+	provider, err := getUserProvider()
+	if err != nil {
+		return fmt.Errorf("failed to get user provider: %w", err)
+	}
+
+	member, err := provider.GetMemberByMemberID(ctx, memberID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errMemberNotFound, err)
+	}
+	if utils.ToString(member["team_id"]) != teamID {
+		return fmt.Errorf("%w: %s", errMemberNotFound, memberID)
+	}
+	if utils.ToString(member["member_type"]) != "robot" {
+		return fmt.Errorf("%w: %s is not a robot member", errInvalidRequest, memberID)
+	}
+	return nil
+}
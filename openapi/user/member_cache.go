@@ -0,0 +1,212 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/kun/maps"
+	"github.com/yaoapp/yao/openapi/oauth/providers/user"
+)
+
+// defaultMemberSearchTTL is used when member_search.cache is on without a ttl
+const defaultMemberSearchTTL = 5 * time.Second
+
+// memberSearchCache holds recent memberList results for type-ahead search.
+// Entries are keyed by team and normalized request and dropped whenever the user
+// provider writes a member of the team, whichever package made the write (this
+// one, the robot API, processes). Only writes bypassing the provider (direct
+// model access) wait for the short TTL to run out.
+type memberSearchCache struct {
+	mu         sync.Mutex
+	entries    map[string]*memberSearchEntry // key -> cached result
+	generation uint64                        // bumped on every invalidation
+}
+
+type memberSearchEntry struct {
+	teamID  string
+	result  maps.MapStr
+	expires time.Time
+}
+
+var memberSearch = &memberSearchCache{entries: map[string]*memberSearchEntry{}}
+
+func init() {
+	user.OnMemberWrite(invalidateMemberSearch)
+}
+
+// memberSearchTTL returns the cache TTL from the team config; 0 when caching is off
+func memberSearchTTL(teamConfig *TeamConfig) time.Duration {
+	if teamConfig == nil || teamConfig.MemberSearch == nil || !teamConfig.MemberSearch.Cache {
+		return 0
+	}
+	if teamConfig.MemberSearch.TTL == "" {
+		return defaultMemberSearchTTL
+	}
+	normalized, err := normalizeDuration(teamConfig.MemberSearch.TTL)
+	if err != nil {
+		log.Warn("invalid member_search.ttl %q, using %s: %v", teamConfig.MemberSearch.TTL, defaultMemberSearchTTL, err)
+		return defaultMemberSearchTTL
+	}
+	ttl, _ := time.ParseDuration(normalized)
+	return ttl
+}
+
+// memberSearchKey builds the cache key from the team, the filters and the page.
+// The base URL is part of it because pending members carry invitation links built from it,
+// the locale because it selects the team config the list is built with.
+func memberSearchKey(teamID string, req *MemberListRequest, requestBaseURL, locale string) string {
+	fields := append([]string{}, req.Fields...)
+	sort.Strings(fields)
+	key, _ := json.Marshal([]interface{}{
+		req.Page,
+		req.PageSize,
+		req.Cursor,
+		req.Status,
+		req.MemberType,
+		req.RoleID,
+		strings.ToLower(strings.TrimSpace(req.Email)),
+		strings.ToLower(strings.TrimSpace(req.RobotEmail)),
+		strings.ToLower(strings.TrimSpace(req.DisplayName)),
+		strings.ToLower(strings.TrimSpace(req.Keyword)),
+		strings.TrimSpace(req.ActiveBefore),
+		strings.TrimSpace(req.ActiveAfter),
+		strings.ToLower(strings.Join(strings.Fields(req.Order), " ")),
+		fields,
+		requestBaseURL,
+		locale,
+	})
+	return teamID + "|" + string(key)
+}
+
+func (c *memberSearchCache) get(key string) (maps.MapStr, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return cloneMemberValue(entry.result).(maps.MapStr), true
+}
+
+// mark returns the current write generation, taken before a member query that may be cached
+func (c *memberSearchCache) mark() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+// set caches a result read at generation gen, unless a member write invalidated the cache
+// while it was being read
+func (c *memberSearchCache) set(key, teamID string, result maps.MapStr, ttl time.Duration, gen uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generation != gen {
+		return
+	}
+
+	// Sweep expired entries so abandoned queries do not pile up
+	now := time.Now()
+	for k, entry := range c.entries {
+		if now.After(entry.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = &memberSearchEntry{teamID: teamID, result: cloneMemberValue(result).(maps.MapStr), expires: now.Add(ttl)}
+}
+
+// cloneMemberValue deep-copies the maps and slices of a member list, so callers can't
+// modify the cached entry or each other's results
+func cloneMemberValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case maps.MapStr:
+		out := make(maps.MapStr, len(v))
+		for key, item := range v {
+			out[key] = cloneMemberValue(item)
+		}
+		return out
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			out[key] = cloneMemberValue(item)
+		}
+		return out
+	case []maps.MapStr:
+		out := make([]maps.MapStr, len(v))
+		for i, item := range v {
+			out[i] = cloneMemberValue(item).(maps.MapStr)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = cloneMemberValue(item)
+		}
+		return out
+	case []string:
+		return append([]string{}, v...)
+	default:
+		return value
+	}
+}
+
+// invalidateMemberSearch drops every cached member list of a team, or of every team
+// when teamID is empty
+func invalidateMemberSearch(teamID string) {
+	memberSearch.mu.Lock()
+	defer memberSearch.mu.Unlock()
+	memberSearch.generation++
+	for key, entry := range memberSearch.entries {
+		if teamID == "" || entry.teamID == teamID {
+			delete(memberSearch.entries, key)
+		}
+	}
+}
+
+// cachedMemberList serves memberList from the search cache when it is enabled.
+// Team access is checked on every call; only the member query itself is cached.
+// Field visibility is applied per viewer after the cache, so entries are shared across roles.
+func cachedMemberList(ctx context.Context, userID, teamID string, req *MemberListRequest, requestBaseURL, locale string) (maps.MapStr, error) {
+	result, err := cachedMemberPage(ctx, userID, teamID, req, requestBaseURL, locale)
+	if err != nil {
+		return nil, err
+	}
+	return viewerHiddenFields(ctx, teamID, userID, locale).stripPage(result), nil
+}
+
+// cachedMemberPage is the cached member query behind cachedMemberList
+func cachedMemberPage(ctx context.Context, userID, teamID string, req *MemberListRequest, requestBaseURL, locale string) (maps.MapStr, error) {
+	ttl := memberSearchTTL(GetTeamConfig(locale))
+	if ttl <= 0 {
+		return memberList(ctx, userID, teamID, req, requestBaseURL, locale)
+	}
+
+	key := memberSearchKey(teamID, req, requestBaseURL, locale)
+	if result, ok := memberSearch.get(key); ok {
+		isOwner, isMember, err := checkTeamAccess(ctx, teamID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !isOwner && !isMember {
+			return nil, fmt.Errorf("access denied: user is not a member of this team")
+		}
+		return result, nil
+	}
+
+	gen := memberSearch.mark()
+	result, err := memberList(ctx, userID, teamID, req, requestBaseURL, locale)
+	if err != nil {
+		return nil, err
+	}
+	memberSearch.set(key, teamID, result, ttl, gen)
+	return result, nil
+}
@@ -0,0 +1,58 @@
+package user
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/kun/log"
+	robotapi "github.com/yaoapp/yao/agent/robot/api"
+	robottypes "github.com/yaoapp/yao/agent/robot/types"
+	"github.com/yaoapp/yao/openapi/oauth/authorized"
+	oauthtypes "github.com/yaoapp/yao/openapi/oauth/types"
+	"github.com/yaoapp/yao/openapi/response"
+)
+
+// Robot Member Capability Handlers
+
+// GinMemberCapabilities handles GET /teams/:id/members/:member_id/capabilities - Get robot capability descriptor
+func GinMemberCapabilities(c *gin.Context) {
+	authInfo := authorized.GetInfo(c)
+	if authInfo == nil || authInfo.UserID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidClient.Code,
+			ErrorDescription: "User not authenticated",
+		}
+		response.RespondWithError(c, response.StatusUnauthorized, errorResp)
+		return
+	}
+
+	teamID := c.Param("id")
+	memberID := c.Param("member_id")
+	if teamID == "" || memberID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Team ID and Member ID are required",
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	result, err := memberGetCapabilities(c.Request.Context(), authInfo, teamID, memberID)
+	if err != nil {
+		log.Error("Failed to get capabilities for member %s: %v", memberID, err)
+		respondRobotMemberError(c, err, "Failed to retrieve capabilities")
+		return
+	}
+
+	response.RespondWithSuccess(c, http.StatusOK, result)
+}
+
+// memberGetCapabilities handles the business logic for building a robot capability descriptor
+func memberGetCapabilities(ctx context.Context, authInfo *oauthtypes.AuthorizedInfo, teamID, memberID string) (*robottypes.CapabilityDescriptor, error) {
+	if err := checkRobotMemberAccess(ctx, authInfo.UserID, teamID, memberID); err != nil {
+		return nil, err
+	}
+
+	return robotapi.GetCapabilities(robottypes.NewContext(ctx, authInfo), memberID)
+}
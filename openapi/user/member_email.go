@@ -0,0 +1,89 @@
+package user
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/agent/robot/ingress"
+	robottypes "github.com/yaoapp/yao/agent/robot/types"
+	"github.com/yaoapp/yao/openapi/oauth/authorized"
+	oauthtypes "github.com/yaoapp/yao/openapi/oauth/types"
+	"github.com/yaoapp/yao/openapi/response"
+)
+
+// Robot Member Email Ingress Handlers
+
+// GinMemberEmailIngress handles POST /teams/:id/members/:member_id/email-ingress - Hand an inbound email to a robot
+func GinMemberEmailIngress(c *gin.Context) {
+	authInfo := authorized.GetInfo(c)
+	if authInfo == nil || authInfo.UserID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidClient.Code,
+			ErrorDescription: "User not authenticated",
+		}
+		response.RespondWithError(c, response.StatusUnauthorized, errorResp)
+		return
+	}
+
+	teamID := c.Param("id")
+	memberID := c.Param("member_id")
+	if teamID == "" || memberID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Team ID and Member ID are required",
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	var req EmailIngressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Invalid request body: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	result, err := memberEmailIngress(c.Request.Context(), authInfo, teamID, memberID, &req)
+	if err != nil {
+		log.Error("Failed to ingest email for member %s: %v", memberID, err)
+		respondRobotMemberError(c, err, "Failed to ingest email")
+		return
+	}
+
+	// A rejected email is a valid answer for the gateway, not a request error
+	status := http.StatusOK
+	if result.Accepted {
+		status = http.StatusAccepted
+	}
+	response.RespondWithSuccess(c, status, result)
+}
+
+// Business Logic Functions
+
+// memberEmailIngress evaluates an inbound email against the robot's authorized senders and
+// filter rules, triggering the robot when it is accepted
+func memberEmailIngress(ctx context.Context, authInfo *oauthtypes.AuthorizedInfo, teamID, memberID string, req *EmailIngressRequest) (*ingress.Result, error) {
+	if err := checkRobotMemberAccess(ctx, authInfo.UserID, teamID, memberID); err != nil {
+		return nil, err
+	}
+
+	result, err := ingress.Ingest(robottypes.NewContext(ctx, authInfo), memberID, &ingress.InboundEmail{
+		From:      req.From,
+		To:        req.To,
+		Subject:   req.Subject,
+		Body:      req.Body,
+		MessageID: req.MessageID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !result.Accepted {
+		log.Info("Email for robot %s rejected: sender=%s reasons=%v", memberID, result.Sender, result.Reasons)
+	}
+	return result, nil
+}
@@ -0,0 +1,123 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/kun/log"
+	robotapi "github.com/yaoapp/yao/agent/robot/api"
+	robottypes "github.com/yaoapp/yao/agent/robot/types"
+	"github.com/yaoapp/yao/openapi/oauth/authorized"
+	oauthtypes "github.com/yaoapp/yao/openapi/oauth/types"
+	"github.com/yaoapp/yao/openapi/response"
+)
+
+// Robot Member Execution History Handlers
+
+// GinMemberExecutions handles GET /teams/:id/members/:member_id/executions - List a robot member's executions
+func GinMemberExecutions(c *gin.Context) {
+	authInfo := authorized.GetInfo(c)
+	if authInfo == nil || authInfo.UserID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidClient.Code,
+			ErrorDescription: "User not authenticated",
+		}
+		response.RespondWithError(c, response.StatusUnauthorized, errorResp)
+		return
+	}
+
+	teamID := c.Param("id")
+	memberID := c.Param("member_id")
+	if teamID == "" || memberID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Team ID and Member ID are required",
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	var req MemberExecutionListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Invalid query parameters: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	query, err := req.executionQuery()
+	if err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: err.Error(),
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	result, err := memberListExecutions(c.Request.Context(), authInfo, teamID, memberID, query)
+	if err != nil {
+		log.Error("Failed to list executions for member %s: %v", memberID, err)
+		respondRobotMemberError(c, err, "Failed to list executions")
+		return
+	}
+
+	response.RespondWithSuccess(c, http.StatusOK, result)
+}
+
+// executionQuery converts the query string into a robot execution query
+func (req *MemberExecutionListRequest) executionQuery() (*robotapi.ExecutionQuery, error) {
+	query := &robotapi.ExecutionQuery{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Trigger:  robottypes.TriggerType(strings.TrimSpace(req.TriggerType)),
+		Detail:   req.Detail,
+	}
+
+	for _, status := range strings.Split(req.Status, ",") {
+		if status = strings.TrimSpace(status); status != "" {
+			query.Statuses = append(query.Statuses, robottypes.ExecStatus(status))
+		}
+	}
+
+	var err error
+	if query.From, err = parseExecutionTime("from", req.From); err != nil {
+		return nil, err
+	}
+	if query.To, err = parseExecutionTime("to", req.To); err != nil {
+		return nil, err
+	}
+	if query.From != nil && query.To != nil && query.To.Before(*query.From) {
+		return nil, fmt.Errorf("%w: time range ends before it starts", errInvalidRequest)
+	}
+	return query, nil
+}
+
+// parseExecutionTime parses an optional RFC3339 bound of the execution time range
+func parseExecutionTime(name, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s must be an RFC3339 time", errInvalidRequest, name)
+	}
+	return &t, nil
+}
+
+// Business Logic Functions
+
+// memberListExecutions handles the business logic for listing a robot member's executions
+func memberListExecutions(ctx context.Context, authInfo *oauthtypes.AuthorizedInfo, teamID, memberID string, query *robotapi.ExecutionQuery) (*robotapi.ExecutionResult, error) {
+	if err := checkRobotMemberAccess(ctx, authInfo.UserID, teamID, memberID); err != nil {
+		return nil, err
+	}
+
+	return robotapi.ListExecutions(robottypes.NewContext(ctx, authInfo), memberID, query)
+}
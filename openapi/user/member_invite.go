@@ -0,0 +1,477 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/kun/exception"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/kun/maps"
+	"github.com/yaoapp/yao/openapi/oauth/authorized"
+	"github.com/yaoapp/yao/openapi/oauth/providers/user"
+	oauthTypes "github.com/yaoapp/yao/openapi/oauth/types"
+	"github.com/yaoapp/yao/openapi/response"
+	"github.com/yaoapp/yao/openapi/utils"
+)
+
+// maxBulkInvites caps the rows of one bulk invite request
+const maxBulkInvites = 200
+
+// Bulk invite row statuses
+const (
+	bulkInviteInvited = "invited"
+	bulkInviteFailed  = "failed"
+)
+
+// Member Bulk Invite Handlers
+
+// GinMemberBulkInvite handles POST /teams/:id/members/bulk-invite - Invite several people at once
+func GinMemberBulkInvite(c *gin.Context) {
+	authInfo := authorized.GetInfo(c)
+	if authInfo == nil || authInfo.UserID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidClient.Code,
+			ErrorDescription: "User not authenticated",
+		}
+		response.RespondWithError(c, response.StatusUnauthorized, errorResp)
+		return
+	}
+
+	teamID := c.Param("id")
+	if teamID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Team ID is required",
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	var req BulkInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Invalid request body: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	results, err := memberBulkInvite(c.Request.Context(), authInfo.UserID, teamID, &req, getRequestBaseURL(c))
+	if err != nil {
+		log.Error("Failed to bulk invite members to team %s: %v", teamID, err)
+		respondRobotMemberError(c, err, "Failed to invite members")
+		return
+	}
+
+	response.RespondWithSuccess(c, http.StatusOK, bulkInviteSummary(results))
+}
+
+// ProcessMemberBulkInvite user.member.bulk.invite Member bulk invite processor
+// Args[0] string: team_id
+// Args[1] []map: invitees [{email, role_id, display_name}]
+// Args[2] map: options {send_email, locale, message} (optional)
+// Return: map: {"results": [...], "invited": n, "failed": n}
+func ProcessMemberBulkInvite(process *process.Process) interface{} {
+	process.ValidateArgNums(2)
+
+	userIDStr := GetUserIDFromSession(process)
+
+	teamID := process.ArgsString(0)
+	if teamID == "" {
+		exception.New("team_id is required", 400).Throw()
+	}
+
+	req := &BulkInviteRequest{}
+	for _, raw := range process.ArgsArray(1) {
+		row, ok := raw.(map[string]interface{})
+		if !ok {
+			exception.New("invitees must be a list of {email, role_id, display_name}", 400).Throw()
+		}
+		req.Invitations = append(req.Invitations, BulkInviteItem{
+			Email:       utils.ToString(row["email"]),
+			RoleID:      utils.ToString(row["role_id"]),
+			DisplayName: utils.ToString(row["display_name"]),
+		})
+	}
+	if process.NumOfArgs() > 2 {
+		options := process.ArgsMap(2)
+		if v, ok := options["send_email"]; ok {
+			sendEmail := utils.ToBool(v)
+			req.SendEmail = &sendEmail
+		}
+		req.Locale = utils.ToString(options["locale"])
+		req.Message = utils.ToString(options["message"])
+	}
+
+	ctx := process.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	results, err := memberBulkInvite(ctx, userIDStr, teamID, req, "")
+	if err != nil {
+		exception.New("failed to invite members: %s", 500, err.Error()).Throw()
+	}
+
+	return bulkInviteSummary(results)
+}
+
+// memberBulkInvite invites each row through the single invitation path. Rows are
+// independent: a bad row is reported as failed and the rest still go out. Only a
+// failed access check or an invalid request fails the call as a whole.
+func memberBulkInvite(ctx context.Context, userID, teamID string, req *BulkInviteRequest, requestBaseURL string) ([]BulkInviteResult, error) {
+	if len(req.Invitations) == 0 {
+		return nil, fmt.Errorf("%w: no invitations", errInvalidRequest)
+	}
+	if len(req.Invitations) > maxBulkInvites {
+		return nil, fmt.Errorf("%w: at most %d invitations per request", errInvalidRequest, maxBulkInvites)
+	}
+
+	// Owner check, team and inviter are the same for every row: load them once
+	team, err := loadInvitationTeam(ctx, userID, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Addresses already in the team, as members or pending invitations
+	existing := map[string]bool{}
+	members, err := team.provider.GetTeamMembers(ctx, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve members: %w", err)
+	}
+	for _, member := range members {
+		if email := strings.ToLower(utils.ToString(member["email"])); email != "" {
+			existing[email] = true
+		}
+	}
+
+	sendEmail := req.SendEmail == nil || *req.SendEmail
+	locale := req.Locale
+	if locale == "" {
+		locale = "en"
+	}
+
+	results := make([]BulkInviteResult, 0, len(req.Invitations))
+	seen := map[string]bool{}
+	for _, item := range req.Invitations {
+		email := strings.TrimSpace(item.Email)
+		result := BulkInviteResult{Email: email, Status: bulkInviteFailed}
+		normalized, emailErr := utils.NormalizeEmailAddress(email)
+		if emailErr == nil {
+			email = normalized
+		}
+		key := strings.ToLower(email)
+
+		switch {
+		case email == "":
+			result.Error = "email is required"
+		case emailErr != nil:
+			result.Error = "invalid email address"
+		case strings.TrimSpace(item.RoleID) == "":
+			result.Error = "role_id is required"
+		case seen[key]:
+			result.Error = "duplicate email in request"
+		case existing[key]:
+			result.Error = "already a member or has a pending invitation"
+		}
+		seen[key] = true
+		if result.Error != "" {
+			results = append(results, result)
+			continue
+		}
+
+		invitationData := maps.MapStrAny{
+			"email":            email,
+			"role_id":          strings.TrimSpace(item.RoleID),
+			"display_name":     strings.TrimSpace(item.DisplayName),
+			"message":          req.Message,
+			"request_base_url": requestBaseURL,
+			"settings":         &InvitationSettings{SendEmail: sendEmail, Locale: locale},
+		}
+		invitationID, memberID, err := createTeamInvitation(ctx, team, userID, teamID, invitationData)
+		if err != nil {
+			log.Warn("Bulk invite to team %s: %s failed: %v", teamID, email, err)
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		result.Status = bulkInviteInvited
+		result.InvitationID = invitationID
+		result.MemberID = memberID
+		existing[key] = true
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// bulkInviteSummary wraps per-row results with their counts
+func bulkInviteSummary(results []BulkInviteResult) map[string]interface{} {
+	invited := 0
+	for _, result := range results {
+		if result.Status == bulkInviteInvited {
+			invited++
+		}
+	}
+	return map[string]interface{}{
+		"results": results,
+		"invited": invited,
+		"failed":  len(results) - invited,
+	}
+}
+
+// Single Member Invite Handlers
+
+// GinMemberInvite handles POST /teams/:id/members/invitations - Invite one person and email them the link
+func GinMemberInvite(c *gin.Context) {
+	authInfo := authorized.GetInfo(c)
+	if authInfo == nil || authInfo.UserID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidClient.Code,
+			ErrorDescription: "User not authenticated",
+		}
+		response.RespondWithError(c, response.StatusUnauthorized, errorResp)
+		return
+	}
+
+	teamID := c.Param("id")
+	if teamID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Team ID is required",
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	var req MemberInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Invalid request body: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	result, err := memberInvite(c.Request.Context(), authInfo.UserID, teamID, &req, getRequestBaseURL(c))
+	if err != nil {
+		log.Error("Failed to invite member to team %s: %v", teamID, err)
+		respondMemberInviteError(c, err, "Failed to send invitation")
+		return
+	}
+
+	response.RespondWithSuccess(c, http.StatusCreated, result)
+}
+
+// GinMemberInviteResend handles POST /teams/:id/members/invitations/:invitation_id/resend -
+// Renew the invitation token and expiry, then email the new link
+func GinMemberInviteResend(c *gin.Context) {
+	authInfo := authorized.GetInfo(c)
+	if authInfo == nil || authInfo.UserID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidClient.Code,
+			ErrorDescription: "User not authenticated",
+		}
+		response.RespondWithError(c, response.StatusUnauthorized, errorResp)
+		return
+	}
+
+	teamID := c.Param("id")
+	invitationID := c.Param("invitation_id")
+	if teamID == "" || invitationID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Team ID and Invitation ID are required",
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	locale := c.Query("locale")
+	if locale == "" {
+		locale = "en"
+	}
+
+	result, err := memberInviteResend(c.Request.Context(), authInfo.UserID, teamID, invitationID, locale, getRequestBaseURL(c))
+	if err != nil {
+		log.Error("Failed to resend invitation %s: %v", invitationID, err)
+		respondMemberInviteError(c, err, "Failed to resend invitation")
+		return
+	}
+
+	response.RespondWithSuccess(c, http.StatusOK, result)
+}
+
+// Invite errors, mapped onto HTTP statuses by respondMemberInviteError
+var (
+	errInvitationNotFound = errors.New("invitation not found")
+	errAlreadyMember      = errors.New("already a member or has a pending invitation")
+)
+
+// respondMemberInviteError maps invite errors onto HTTP statuses
+func respondMemberInviteError(c *gin.Context, err error, fallback string) {
+	if errors.Is(err, errAlreadyMember) || errors.Is(err, user.ErrInvitationAccepted) || errors.Is(err, user.ErrInvitationNotPending) {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: err.Error(),
+		}
+		response.RespondWithError(c, response.StatusConflict, errorResp)
+		return
+	}
+	respondRobotMemberError(c, err, fallback)
+}
+
+// memberInvite creates a pending member for req.Email and emails the invitation link.
+// The invitation stands even when the email cannot be sent (messenger or template not
+// configured, delivery error): the result then carries the link to share by hand.
+func memberInvite(ctx context.Context, userID, teamID string, req *MemberInviteRequest, requestBaseURL string) (*MemberInviteResult, error) {
+	email, err := utils.NormalizeEmailAddress(req.Email)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidRequest, err)
+	}
+	roleID := strings.TrimSpace(req.RoleID)
+	if roleID == "" {
+		return nil, fmt.Errorf("%w: role_id is required", errInvalidRequest)
+	}
+	locale := req.Locale
+	if locale == "" {
+		locale = "en"
+	}
+
+	team, err := loadInvitationTeam(ctx, userID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	provider := team.provider
+	members, err := provider.GetTeamMembers(ctx, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve members: %w", err)
+	}
+	for _, member := range members {
+		if strings.EqualFold(utils.ToString(member["email"]), email) {
+			return nil, fmt.Errorf("%s is %w", email, errAlreadyMember)
+		}
+	}
+
+	// The email is sent below, synchronously, so its outcome can be reported
+	invitationID, _, err := createTeamInvitation(ctx, team, userID, teamID, maps.MapStrAny{
+		"email":            email,
+		"role_id":          roleID,
+		"display_name":     strings.TrimSpace(req.DisplayName),
+		"message":          req.Message,
+		"request_base_url": requestBaseURL,
+		"settings":         &InvitationSettings{SendEmail: false, Locale: locale},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	invitation, err := provider.GetMemberByInvitationID(ctx, invitationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve created invitation: %w", err)
+	}
+	return deliverMemberInvitation(ctx, provider, userID, teamID, invitation, locale, requestBaseURL), nil
+}
+
+// memberInviteResend gives a pending invitation a new token and a fresh expiry (the old
+// link stops working), then emails the new link
+func memberInviteResend(ctx context.Context, userID, teamID, invitationID, locale, requestBaseURL string) (*MemberInviteResult, error) {
+	isOwner, _, err := checkTeamAccess(ctx, teamID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isOwner {
+		return nil, fmt.Errorf("%w: only team owner can resend invitations", errAccessDenied)
+	}
+
+	provider, err := getUserProvider()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user provider: %w", err)
+	}
+
+	invitation, err := provider.GetMemberByInvitationID(ctx, invitationID)
+	if err != nil || utils.ToString(invitation["team_id"]) != teamID {
+		return nil, fmt.Errorf("%w: %s", errInvitationNotFound, invitationID)
+	}
+	switch utils.ToString(invitation["status"]) {
+	case "pending":
+	case "active":
+		return nil, fmt.Errorf("%w: %s", user.ErrInvitationAccepted, invitationID)
+	default:
+		return nil, fmt.Errorf("%w: %s", user.ErrInvitationNotPending, invitationID)
+	}
+
+	token, err := generateTeamInvitationToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate invitation token: %w", err)
+	}
+	expiry, err := getTeamInvitationExpiry(maps.MapStrAny{"settings": &InvitationSettings{Locale: locale}})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	renewed := maps.MapStrAny{
+		"invitation_token":      token,
+		"invitation_expires_at": now.Add(expiry),
+		"invited_at":            now,
+		"updated_at":            now,
+	}
+	if err := provider.UpdateMemberByInvitationID(ctx, invitationID, renewed); err != nil {
+		return nil, fmt.Errorf("failed to update invitation: %w", err)
+	}
+
+	for k, v := range renewed {
+		invitation[k] = v
+	}
+	return deliverMemberInvitation(ctx, provider, userID, teamID, invitation, locale, requestBaseURL), nil
+}
+
+// deliverMemberInvitation emails the invitation link and reports the outcome
+func deliverMemberInvitation(ctx context.Context, provider *user.DefaultUser, userID, teamID string, invitation maps.MapStrAny, locale, requestBaseURL string) *MemberInviteResult {
+	invitationID := utils.ToString(invitation["invitation_id"])
+	token := utils.ToString(invitation["invitation_token"])
+	result := &MemberInviteResult{
+		MemberID:            utils.ToString(invitation["member_id"]),
+		InvitationID:        invitationID,
+		InvitationLink:      buildTeamInvitationLink(invitationID, token, GetTeamConfig(locale), requestBaseURL),
+		InvitationExpiresAt: utils.FormatTimeWithLocale(invitation["invitation_expires_at"], time.RFC3339),
+	}
+
+	email := utils.ToString(invitation["email"])
+	if email == "" {
+		result.EmailError = "invitation has no email address"
+		return result
+	}
+
+	teamName := teamID
+	if team, err := provider.GetTeam(ctx, teamID); err == nil {
+		teamName = utils.ToString(team["name"])
+	}
+
+	emailData := maps.MapStrAny{}
+	for k, v := range invitation {
+		emailData[k] = v
+	}
+	emailData["request_base_url"] = requestBaseURL
+	emailData["settings"] = &InvitationSettings{Locale: locale}
+
+	sendCtx := context.WithValue(ctx, "identity", &oauthTypes.AuthorizedInfo{UserID: userID, TeamID: teamID})
+	inviterName := teamInvitationInviterName(ctx, provider, userID)
+	if err := sendTeamInvitationEmail(sendCtx, email, inviterName, teamName, token, invitationID, emailData); err != nil {
+		log.Warn("Invitation %s created but not emailed to %s: %v", invitationID, email, err)
+		result.EmailError = err.Error()
+		return result
+	}
+	result.EmailSent = true
+	return result
+}
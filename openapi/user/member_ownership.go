@@ -0,0 +1,163 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/kun/exception"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/openapi/oauth/authorized"
+	"github.com/yaoapp/yao/openapi/oauth/providers/user"
+	"github.com/yaoapp/yao/openapi/response"
+	"github.com/yaoapp/yao/openapi/utils"
+)
+
+// Team Ownership Transfer Handlers
+
+// GinMemberTransferOwnership handles POST /teams/:id/ownership - Hand the team over to another member (owner only)
+func GinMemberTransferOwnership(c *gin.Context) {
+	authInfo := authorized.GetInfo(c)
+	if authInfo == nil || authInfo.UserID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidClient.Code,
+			ErrorDescription: "User not authenticated",
+		}
+		response.RespondWithError(c, response.StatusUnauthorized, errorResp)
+		return
+	}
+
+	teamID := c.Param("id")
+	if teamID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Team ID is required",
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	var req TransferOwnershipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Invalid request body: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	if err := memberTransferOwnership(c.Request.Context(), authInfo.UserID, teamID, &req); err != nil {
+		log.Error("Failed to transfer ownership of team %s to member %s: %v", teamID, req.MemberID, err)
+		respondRobotMemberError(c, err, "Failed to transfer team ownership")
+		return
+	}
+
+	response.RespondWithSuccess(c, http.StatusOK, map[string]interface{}{
+		"message":   "Team ownership transferred successfully",
+		"team_id":   teamID,
+		"member_id": req.MemberID,
+	})
+}
+
+// ProcessMemberTransferOwnership user.member.ownership.transfer Team ownership transfer processor
+// Args[0] string: team_id
+// Args[1] string: member_id of the new owner
+// Args[2] string (optional): role_id given to the previous owner
+// Return: map: {"message": "success"}
+func ProcessMemberTransferOwnership(process *process.Process) interface{} {
+	process.ValidateArgNums(2)
+
+	userIDStr := GetUserIDFromSession(process)
+
+	teamID := process.ArgsString(0)
+	req := &TransferOwnershipRequest{MemberID: process.ArgsString(1)}
+	if process.NumOfArgs() > 2 {
+		req.PreviousOwnerRoleID = process.ArgsString(2)
+	}
+	if teamID == "" || req.MemberID == "" {
+		exception.New("team_id and member_id are required", 400).Throw()
+	}
+
+	ctx := process.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if err := memberTransferOwnership(ctx, userIDStr, teamID, req); err != nil {
+		if errors.Is(err, errAccessDenied) {
+			exception.New("failed to transfer team ownership: %s", 403, err.Error()).Throw()
+		}
+		if errors.Is(err, errMemberNotFound) || errors.Is(err, user.ErrInvalidNewOwner) {
+			exception.New("failed to transfer team ownership: %s", 400, err.Error()).Throw()
+		}
+		exception.New("failed to transfer team ownership: %s", 500, err.Error()).Throw()
+	}
+
+	return map[string]interface{}{
+		"message": "success",
+	}
+}
+
+// memberTransferOwnership handles the business logic for handing a team to another member (owner only)
+func memberTransferOwnership(ctx context.Context, userID, teamID string, req *TransferOwnershipRequest) error {
+	isOwner, _, err := checkTeamAccess(ctx, teamID, userID)
+	if err != nil {
+		return err
+	}
+	if !isOwner {
+		return fmt.Errorf("%w: only team owner can transfer ownership", errAccessDenied)
+	}
+
+	provider, err := getUserProvider()
+	if err != nil {
+		return fmt.Errorf("failed to get user provider: %w", err)
+	}
+
+	teamConfig := GetTeamConfig("")
+	memberRoleID := strings.TrimSpace(req.PreviousOwnerRoleID)
+	if memberRoleID == "" {
+		memberRoleID = defaultMemberRole(teamConfig)
+	}
+	// Both sides change role: keep them as they were for the audit trail
+	newOwner, err := provider.GetMemberByMemberID(ctx, req.MemberID)
+	if err != nil || utils.ToString(newOwner["team_id"]) != teamID {
+		return fmt.Errorf("%w: %s", errMemberNotFound, req.MemberID)
+	}
+	oldOwner, _ := provider.GetMember(ctx, teamID, userID)
+
+	if err := provider.TransferOwnership(ctx, teamID, req.MemberID, ownerRole(teamConfig), memberRoleID); err != nil {
+		return err
+	}
+
+	recordMemberAuditSince(ctx, userID, teamID, req.MemberID, newOwner)
+	if oldOwnerID, _ := oldOwner["member_id"].(string); oldOwnerID != "" {
+		recordMemberAuditSince(ctx, userID, teamID, oldOwnerID, oldOwner)
+	}
+	return nil
+}
+
+// ownerRole returns the role_id given to team owners
+func ownerRole(teamConfig *TeamConfig) string {
+	if teamConfig != nil && teamConfig.Role != "" {
+		return teamConfig.Role
+	}
+	return "owner"
+}
+
+// defaultMemberRole returns the configured default role for regular members, "member" if none is marked
+func defaultMemberRole(teamConfig *TeamConfig) string {
+	if teamConfig != nil {
+		owner := ownerRole(teamConfig)
+		for _, role := range teamConfig.Roles {
+			if role.Default && !role.IsOwner && role.RoleID != owner {
+				return role.RoleID
+			}
+		}
+	}
+	return "member"
+}
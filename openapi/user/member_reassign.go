@@ -0,0 +1,116 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/kun/exception"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/openapi/oauth/authorized"
+	"github.com/yaoapp/yao/openapi/response"
+)
+
+// Robot Manager Reassignment Handlers
+
+// GinMemberReassignRobots handles POST /teams/:id/members/robots/reassign - Move all robots of one manager to another
+func GinMemberReassignRobots(c *gin.Context) {
+	authInfo := authorized.GetInfo(c)
+	if authInfo == nil || authInfo.UserID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidClient.Code,
+			ErrorDescription: "User not authenticated",
+		}
+		response.RespondWithError(c, response.StatusUnauthorized, errorResp)
+		return
+	}
+
+	teamID := c.Param("id")
+	if teamID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Team ID is required",
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	var req ReassignRobotsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Invalid request body: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	count, err := memberReassignRobots(c.Request.Context(), authInfo.UserID, teamID, req.OldManagerID, req.NewManagerID)
+	if err != nil {
+		log.Error("Failed to reassign robots of manager %s in team %s: %v", req.OldManagerID, teamID, err)
+		respondRobotMemberError(c, err, "Failed to reassign robots")
+		return
+	}
+
+	response.RespondWithSuccess(c, http.StatusOK, map[string]interface{}{
+		"reassigned":     count,
+		"old_manager_id": req.OldManagerID,
+		"new_manager_id": req.NewManagerID,
+	})
+}
+
+// ProcessMemberReassignRobots user.member.robots.reassign Robot manager reassignment processor
+// Args[0] string: team_id
+// Args[1] string: old manager user_id
+// Args[2] string: new manager user_id
+// Return: map: {"reassigned": count}
+func ProcessMemberReassignRobots(process *process.Process) interface{} {
+	process.ValidateArgNums(3)
+
+	userIDStr := GetUserIDFromSession(process)
+
+	teamID := process.ArgsString(0)
+	oldManagerID := process.ArgsString(1)
+	newManagerID := process.ArgsString(2)
+	if teamID == "" || oldManagerID == "" || newManagerID == "" {
+		exception.New("team_id, old manager and new manager are required", 400).Throw()
+	}
+
+	ctx := process.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	count, err := memberReassignRobots(ctx, userIDStr, teamID, oldManagerID, newManagerID)
+	if err != nil {
+		exception.New("failed to reassign robots: %s", 500, err.Error()).Throw()
+	}
+
+	return map[string]interface{}{
+		"reassigned": count,
+	}
+}
+
+// memberReassignRobots handles the business logic for moving robots to a new manager (owner only)
+func memberReassignRobots(ctx context.Context, userID, teamID, oldManagerID, newManagerID string) (int, error) {
+	isOwner, _, err := checkTeamAccess(ctx, teamID, userID)
+	if err != nil {
+		return 0, err
+	}
+	if !isOwner {
+		return 0, fmt.Errorf("%w: only team owner can reassign robots", errAccessDenied)
+	}
+
+	provider, err := getUserProvider()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get user provider: %w", err)
+	}
+
+	count, err := provider.ReassignRobotsFromManager(ctx, teamID, oldManagerID, newManagerID)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,137 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/kun/exception"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/openapi/oauth/authorized"
+	"github.com/yaoapp/yao/openapi/response"
+)
+
+// Removed Member Recovery Handlers
+
+// GinMemberRestore handles POST /teams/:id/members/:member_id/restore - Bring back a removed member (owner only)
+func GinMemberRestore(c *gin.Context) {
+	authInfo := authorized.GetInfo(c)
+	if authInfo == nil || authInfo.UserID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidClient.Code,
+			ErrorDescription: "User not authenticated",
+		}
+		response.RespondWithError(c, response.StatusUnauthorized, errorResp)
+		return
+	}
+
+	teamID := c.Param("id")
+	memberID := c.Param("member_id")
+	if teamID == "" || memberID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Team ID and Member ID are required",
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	if err := memberRestore(c.Request.Context(), authInfo.UserID, teamID, memberID); err != nil {
+		log.Error("Failed to restore member %s: %v", memberID, err)
+		switch {
+		case strings.Contains(err.Error(), "not found"):
+			errorResp := &response.ErrorResponse{
+				Code:             response.ErrInvalidRequest.Code,
+				ErrorDescription: "Removed member not found",
+			}
+			response.RespondWithError(c, response.StatusNotFound, errorResp)
+		case strings.Contains(err.Error(), "access denied"):
+			errorResp := &response.ErrorResponse{
+				Code:             response.ErrAccessDenied.Code,
+				ErrorDescription: err.Error(),
+			}
+			response.RespondWithError(c, response.StatusForbidden, errorResp)
+		case strings.Contains(err.Error(), "already exists"):
+			errorResp := &response.ErrorResponse{
+				Code:             response.ErrInvalidRequest.Code,
+				ErrorDescription: err.Error(),
+			}
+			response.RespondWithError(c, response.StatusConflict, errorResp)
+		default:
+			errorResp := &response.ErrorResponse{
+				Code:             response.ErrServerError.Code,
+				ErrorDescription: "Failed to restore member",
+			}
+			response.RespondWithError(c, response.StatusInternalServerError, errorResp)
+		}
+		return
+	}
+
+	response.RespondWithSuccess(c, http.StatusOK, gin.H{
+		"message":   "Member restored successfully",
+		"member_id": memberID,
+	})
+}
+
+// ProcessMemberRestore user.member.restore Removed member recovery processor
+// Args[0] string: team_id
+// Args[1] string: member_id
+// Return: map: {"message": "success"}
+func ProcessMemberRestore(process *process.Process) interface{} {
+	process.ValidateArgNums(2)
+
+	userIDStr := GetUserIDFromSession(process)
+
+	teamID := process.ArgsString(0)
+	memberID := process.ArgsString(1)
+	if teamID == "" || memberID == "" {
+		exception.New("team_id and member_id are required", 400).Throw()
+	}
+
+	ctx := process.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if err := memberRestore(ctx, userIDStr, teamID, memberID); err != nil {
+		if strings.Contains(err.Error(), "access denied") {
+			exception.New("failed to restore member: %s", 403, err.Error()).Throw()
+		}
+		if strings.Contains(err.Error(), "not found") {
+			exception.New("failed to restore member: %s", 404, err.Error()).Throw()
+		}
+		if strings.Contains(err.Error(), "already exists") {
+			exception.New("failed to restore member: %s", 409, err.Error()).Throw()
+		}
+		exception.New("failed to restore member: %s", 500, err.Error()).Throw()
+	}
+
+	return map[string]interface{}{
+		"message": "success",
+	}
+}
+
+// memberRestore handles the business logic for restoring a removed team member (owner only)
+func memberRestore(ctx context.Context, userID, teamID, memberID string) error {
+	isOwner, _, err := checkTeamAccess(ctx, teamID, userID)
+	if err != nil {
+		return err
+	}
+	if !isOwner {
+		return fmt.Errorf("access denied: only team owner can restore members")
+	}
+
+	provider, err := getUserProvider()
+	if err != nil {
+		return fmt.Errorf("failed to get user provider: %w", err)
+	}
+
+	if err := provider.RestoreMemberByMemberID(ctx, teamID, memberID); err != nil {
+		return err
+	}
+
+	return nil
+}
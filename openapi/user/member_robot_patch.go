@@ -0,0 +1,171 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/kun/exception"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/kun/maps"
+	robottypes "github.com/yaoapp/yao/agent/robot/types"
+	"github.com/yaoapp/yao/openapi/oauth/authorized"
+	"github.com/yaoapp/yao/openapi/response"
+	"github.com/yaoapp/yao/openapi/utils"
+)
+
+// GinMemberPatchRobot handles PATCH /teams/:id/members/:member_id/robot - Partially update a robot member's config
+func GinMemberPatchRobot(c *gin.Context) {
+	authInfo := authorized.GetInfo(c)
+	if authInfo == nil || authInfo.UserID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidClient.Code,
+			ErrorDescription: "User not authenticated",
+		}
+		response.RespondWithError(c, response.StatusUnauthorized, errorResp)
+		return
+	}
+
+	teamID := c.Param("id")
+	memberID := c.Param("member_id")
+	if teamID == "" || memberID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Team ID and Member ID are required",
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	var req PatchRobotMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if utils.IsBodyTooLarge(err) {
+			utils.RespondBodyTooLarge(c, robottypes.GetPayloadLimits().MaxBodyBytes)
+			return
+		}
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Invalid request body: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	updateData, err := robotPatchData(&req)
+	if err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: err.Error(),
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	err = memberUpdateRobot(c.Request.Context(), authInfo.UserID, teamID, memberID, authInfo.WithUpdateScope(updateData))
+	if err != nil {
+		log.Error("Failed to patch robot member %s: %v", memberID, err)
+		respondRobotUpdateError(c, err)
+		return
+	}
+
+	response.RespondWithSuccess(c, http.StatusOK, gin.H{"message": "Robot member updated successfully"})
+}
+
+// ProcessMemberPatchRobot user.member.robot.patch Robot member partial update processor
+// Args[0] string: team_id
+// Args[1] string: member_id
+// Args[2] map: Fields to change {"prompt": "", "cost_limit": 20, "agents": [...]}
+// Return: map: {"message": "success"}
+func ProcessMemberPatchRobot(process *process.Process) interface{} {
+	process.ValidateArgNums(3)
+
+	userIDStr := GetUserIDFromSession(process)
+
+	teamID := process.ArgsString(0)
+	memberID := process.ArgsString(1)
+	if teamID == "" || memberID == "" {
+		exception.New("team_id and member_id are required", 400).Throw()
+	}
+
+	var req PatchRobotMemberRequest
+	raw, err := json.Marshal(process.ArgsMap(2))
+	if err == nil {
+		err = json.Unmarshal(raw, &req)
+	}
+	if err != nil {
+		exception.New("invalid robot config: %s", 400, err.Error()).Throw()
+	}
+
+	updateData, err := robotPatchData(&req)
+	if err != nil {
+		exception.New(err.Error(), 400).Throw()
+	}
+
+	ctx := process.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if err := memberUpdateRobot(ctx, userIDStr, teamID, memberID, updateData); err != nil {
+		if errors.Is(err, robottypes.ErrPayloadLimit) || strings.Contains(err.Error(), "not a robot member") {
+			exception.New("failed to update robot member: %s", 400, err.Error()).Throw()
+		}
+		exception.New("failed to update robot member: %s", 500, err.Error()).Throw()
+	}
+
+	return map[string]interface{}{
+		"message": "success",
+	}
+}
+
+// robotPatchData maps the fields present in a patch request to member columns
+func robotPatchData(req *PatchRobotMemberRequest) (maps.MapStrAny, error) {
+	data := maps.MapStrAny{}
+
+	if req.SystemPrompt != nil {
+		data["system_prompt"] = *req.SystemPrompt
+	}
+	if req.LanguageModel != nil {
+		data["language_model"] = strings.TrimSpace(*req.LanguageModel)
+	}
+	if req.CostLimit != nil {
+		if *req.CostLimit < 0 {
+			return nil, fmt.Errorf("cost_limit must not be negative")
+		}
+		data["cost_limit"] = *req.CostLimit
+	}
+	if req.AutonomousMode != nil {
+		switch strings.ToLower(strings.TrimSpace(*req.AutonomousMode)) {
+		case "enabled", "true":
+			data["autonomous_mode"] = true
+		case "disabled", "false":
+			data["autonomous_mode"] = false
+		default:
+			return nil, fmt.Errorf("autonomous_mode must be enabled or disabled")
+		}
+	}
+
+	// Present arrays replace the stored list, [] clears it
+	if req.Agents != nil {
+		data["agents"] = req.Agents
+	}
+	if req.MCPServers != nil {
+		data["mcp_servers"] = req.MCPServers
+	}
+	if req.AuthorizedSenders != nil {
+		data["authorized_senders"] = req.AuthorizedSenders
+	}
+	if req.EmailFilterRules != nil {
+		data["email_filter_rules"] = req.EmailFilterRules
+	}
+
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no robot config fields to update")
+	}
+	return data, nil
+}
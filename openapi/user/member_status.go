@@ -0,0 +1,125 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/kun/exception"
+	"github.com/yaoapp/kun/maps"
+	"github.com/yaoapp/yao/openapi/oauth/providers/user"
+	"github.com/yaoapp/yao/openapi/utils"
+)
+
+// maxBulkStatusUpdates caps the members of one bulk status update
+const maxBulkStatusUpdates = 500
+
+// memberStatuses are the statuses a member can be set to
+var memberStatuses = map[string]bool{
+	"pending": true, "active": true, "inactive": true, "suspended": true,
+}
+
+// ProcessMemberBulkStatusUpdate user.member.bulk_update_status Member bulk status processor
+// Args[0] string: team_id
+// Args[1] []string: member_ids
+// Args[2] string: status (active, inactive, suspended, pending)
+// Return: map: {"updated": n, "errors": [{"member_id": "", "error": ""}]}
+func ProcessMemberBulkStatusUpdate(process *process.Process) interface{} {
+	process.ValidateArgNums(3)
+
+	userIDStr := GetUserIDFromSession(process)
+
+	teamID := process.ArgsString(0)
+	if teamID == "" {
+		exception.New("team_id is required", 400).Throw()
+	}
+
+	var memberIDs []string
+	for _, raw := range process.ArgsArray(1) {
+		memberIDs = append(memberIDs, utils.ToString(raw))
+	}
+	status := process.ArgsString(2)
+
+	ctx := process.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	result, err := memberBulkUpdateStatus(ctx, userIDStr, teamID, memberIDs, status)
+	if err != nil {
+		if strings.Contains(err.Error(), "access denied") {
+			exception.New("failed to update member status: %s", 403, err.Error()).Throw()
+		}
+		if strings.Contains(err.Error(), "invalid") {
+			exception.New("failed to update member status: %s", 400, err.Error()).Throw()
+		}
+		exception.New("failed to update member status: %s", 500, err.Error()).Throw()
+	}
+
+	return result
+}
+
+// memberBulkUpdateStatus sets the status of each listed member. Members are independent:
+// one that is missing, outside the team or the owner is reported and the rest are still
+// updated. Only a failed access check or an invalid request fails the call as a whole.
+func memberBulkUpdateStatus(ctx context.Context, userID, teamID string, memberIDs []string, status string) (*BulkStatusUpdateResult, error) {
+	status = strings.TrimSpace(status)
+	if !memberStatuses[status] {
+		return nil, fmt.Errorf("invalid status value: %s (must be one of: pending, active, inactive, suspended)", status)
+	}
+	if len(memberIDs) == 0 {
+		return nil, fmt.Errorf("invalid request: no member_ids")
+	}
+	if len(memberIDs) > maxBulkStatusUpdates {
+		return nil, fmt.Errorf("invalid request: at most %d members per request", maxBulkStatusUpdates)
+	}
+
+	// Same permission as memberUpdate: owner only
+	isOwner, _, err := checkTeamAccess(ctx, teamID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isOwner {
+		return nil, fmt.Errorf("access denied: only team owner can update members")
+	}
+
+	provider, err := getUserProvider()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user provider: %w", err)
+	}
+
+	result := &BulkStatusUpdateResult{Errors: []BulkStatusUpdateError{}}
+	seen := map[string]bool{}
+	for _, memberID := range memberIDs {
+		memberID = strings.TrimSpace(memberID)
+		if memberID == "" || seen[memberID] {
+			continue
+		}
+		seen[memberID] = true
+
+		member, err := provider.GetMemberByMemberID(ctx, memberID)
+		if err != nil || utils.ToString(member["team_id"]) != teamID {
+			result.Errors = append(result.Errors, BulkStatusUpdateError{MemberID: memberID, Error: "member not found"})
+			continue
+		}
+		if utils.ToBool(member["is_owner"]) {
+			result.Errors = append(result.Errors, BulkStatusUpdateError{MemberID: memberID, Error: "team owner status cannot be changed"})
+			continue
+		}
+
+		err = provider.UpdateMemberByMemberID(ctx, memberID, maps.MapStrAny{
+			"status":     status,
+			"updated_at": time.Now(),
+		})
+		if err != nil {
+			result.Errors = append(result.Errors, BulkStatusUpdateError{MemberID: memberID, Error: err.Error()})
+			continue
+		}
+		recordMemberAudit(ctx, userID, teamID, memberID, user.MemberAuditUpdate, member, maps.MapStrAny{"status": status})
+		result.Updated++
+	}
+
+	return result, nil
+}
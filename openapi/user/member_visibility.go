@@ -0,0 +1,140 @@
+package user
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/kun/maps"
+	"github.com/yaoapp/yao/openapi/response"
+	"github.com/yaoapp/yao/openapi/utils"
+)
+
+// memberIdentityFields identify a member row and are never hidden
+var memberIdentityFields = map[string]bool{
+	"id":          true,
+	"member_id":   true,
+	"team_id":     true,
+	"member_type": true,
+	"status":      true,
+}
+
+// memberResponseFields lists the JSON keys of MemberDetailResponse in declaration order
+var memberResponseFields = jsonFieldNames(reflect.TypeOf(MemberDetailResponse{}))
+
+// hiddenFields is the set of member fields a viewer may not see; nil hides nothing
+type hiddenFields map[string]bool
+
+// memberHiddenFields returns the fields hidden from a viewer with roleID.
+// A role listed in the config replaces the default list, so `"owner": []` shows everything.
+func memberHiddenFields(teamConfig *TeamConfig, roleID string) hiddenFields {
+	if teamConfig == nil || teamConfig.FieldVisibility == nil {
+		return nil
+	}
+	fields, ok := teamConfig.FieldVisibility.Roles[roleID]
+	if !ok {
+		fields = teamConfig.FieldVisibility.Default
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	hidden := hiddenFields{}
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if field != "" && !memberIdentityFields[field] {
+			hidden[field] = true
+		}
+	}
+	return hidden
+}
+
+// strip returns a copy of data without the hidden fields
+func (h hiddenFields) strip(data maps.MapStr) maps.MapStr {
+	if len(h) == 0 {
+		return data
+	}
+	out := make(maps.MapStr, len(data))
+	for key, value := range data {
+		if !h[key] {
+			out[key] = value
+		}
+	}
+	return out
+}
+
+// stripPage returns a copy of a member page with the hidden fields removed from every row
+func (h hiddenFields) stripPage(result maps.MapStr) maps.MapStr {
+	rows, ok := result["data"].([]maps.MapStrAny)
+	if len(h) == 0 || !ok {
+		return result
+	}
+	out := make(maps.MapStr, len(result))
+	for key, value := range result {
+		out[key] = value
+	}
+	stripped := make([]maps.MapStrAny, len(rows))
+	for i, row := range rows {
+		stripped[i] = h.strip(row)
+	}
+	out["data"] = stripped
+	return out
+}
+
+// PreviewVisibleFields returns the member fields a viewer with the given role can see,
+// so the frontend can lay out member views before fetching them
+func PreviewVisibleFields(role string) []string {
+	hidden := memberHiddenFields(GetTeamConfig(""), role)
+	visible := make([]string, 0, len(memberResponseFields))
+	for _, field := range memberResponseFields {
+		if !hidden[field] {
+			visible = append(visible, field)
+		}
+	}
+	return visible
+}
+
+// viewerHiddenFields resolves the viewer's role in the team and returns the fields hidden from it.
+// A viewer without a member row (e.g. a legacy owner) is treated as having no role.
+func viewerHiddenFields(ctx context.Context, teamID, userID, locale string) hiddenFields {
+	teamConfig := GetTeamConfig(locale)
+	if teamConfig == nil || teamConfig.FieldVisibility == nil {
+		return nil
+	}
+
+	roleID := ""
+	if provider, err := getUserProvider(); err == nil {
+		if member, err := provider.GetMember(ctx, teamID, userID); err == nil {
+			roleID = utils.ToString(member["role_id"])
+		}
+	}
+	return memberHiddenFields(teamConfig, roleID)
+}
+
+// jsonFieldNames collects the JSON keys of a struct, descending into embedded structs
+func jsonFieldNames(t reflect.Type) []string {
+	names := []string{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			names = append(names, jsonFieldNames(field.Type)...)
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// GinMemberVisibleFields handles GET /teams/config/visible-fields?role_id=xxx - Preview the member fields a role can see
+func GinMemberVisibleFields(c *gin.Context) {
+	roleID := strings.TrimSpace(c.Query("role_id"))
+	response.RespondWithSuccess(c, http.StatusOK, map[string]interface{}{
+		"role_id": roleID,
+		"fields":  PreviewVisibleFields(roleID),
+	})
+}
@@ -591,6 +591,39 @@ func ProcessTeamGet(process *process.Process) interface{} {
 	return result
 }
 
+// ProcessTeamAccess user.team.access Team access processor
+// Args[0] string: team_id
+// Return: map: {"is_owner": bool, "is_member": bool, "member_id": "...", "role_id": "...", "status": "..."}
+// of the session user
+func ProcessTeamAccess(process *process.Process) interface{} {
+	process.ValidateArgNums(1)
+
+	// Get user_id from session
+	userIDStr := GetUserIDFromSession(process)
+
+	teamID := process.ArgsString(0)
+	if teamID == "" {
+		exception.New("team_id is required", 400).Throw()
+	}
+
+	// Get context
+	ctx := process.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// Call business logic
+	result, err := teamAccess(ctx, userIDStr, teamID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			exception.New("team not found: %s", 404, teamID).Throw()
+		}
+		exception.New("failed to get team access: %s", 500, err.Error()).Throw()
+	}
+
+	return result
+}
+
 // ProcessTeamCreate user.team.create Team create processor
 // Args[0] map: Team data {"name": "Team Name", "description": "Description", "settings": {...}}
 // Return: map: {"team_id": "created_team_id"}
@@ -749,6 +782,15 @@ func getCurrentTeamID(ctx context.Context, teamID, userID string) (string, error
 	return "", fmt.Errorf("invalid team_id format")
 }
 
+// teamAccess returns the user's standing in a team
+func teamAccess(ctx context.Context, userID, teamID string) (maps.MapStrAny, error) {
+	provider, err := getUserProvider()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user provider: %w", err)
+	}
+	return provider.GetTeamAccessInfo(ctx, teamID, userID)
+}
+
 // teamGet handles the business logic for getting a specific user team
 func teamGet(ctx context.Context, userID, teamID string) (maps.MapStrAny, error) {
 	// Get user provider instance
@@ -837,10 +879,7 @@ func teamCreate(ctx context.Context, userID string, teamData maps.MapStrAny) (st
 	}
 
 	// Determine owner member role_id from team config
-	ownerRoleID := "owner" // fallback default
-	if teamConfig != nil && teamConfig.Role != "" {
-		ownerRoleID = teamConfig.Role
-	}
+	ownerRoleID := ownerRole(teamConfig)
 
 	// Add the creator as an owner member of the team
 	ownerMemberData := types.CopyCreateScope(teamData, maps.MapStrAny{
@@ -990,8 +1029,9 @@ func mapToTeamDetailResponse(data maps.MapStr) TeamDetailResponse {
 		} else if settingsMap, ok := settings.(map[string]interface{}); ok {
 			// Convert map to TeamSettings (for backward compatibility)
 			teamSettings := &TeamSettings{
-				Theme:      utils.ToString(settingsMap["theme"]),
-				Visibility: utils.ToString(settingsMap["visibility"]),
+				Theme:                 utils.ToString(settingsMap["theme"]),
+				Visibility:            utils.ToString(settingsMap["visibility"]),
+				AllowedLanguageModels: user.AllowedLanguageModels(settingsMap),
 			}
 			team.Settings = teamSettings
 		}
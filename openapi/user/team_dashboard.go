@@ -0,0 +1,192 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/kun/exception"
+	"github.com/yaoapp/kun/log"
+	robotapi "github.com/yaoapp/yao/agent/robot/api"
+	robottypes "github.com/yaoapp/yao/agent/robot/types"
+	"github.com/yaoapp/yao/attachment"
+	"github.com/yaoapp/yao/openapi/oauth/authorized"
+	oauthtypes "github.com/yaoapp/yao/openapi/oauth/types"
+	"github.com/yaoapp/yao/openapi/response"
+	"github.com/yaoapp/yao/openapi/utils"
+)
+
+// dashboardRecentExecutions is how many recent executions the dashboard lists
+const dashboardRecentExecutions = 10
+
+// TeamDashboard is the consolidated team overview: members, invitations, robots,
+// recent executions and storage in one payload
+type TeamDashboard struct {
+	TeamID             string                    `json:"team_id"`
+	Members            TeamDashboardMembers      `json:"members"`
+	PendingInvitations int                       `json:"pending_invitations"`
+	Robots             []*robotapi.RobotOverview `json:"robots"`
+	RecentExecutions   TeamDashboardExecutions   `json:"recent_executions"`
+	Storage            *attachment.Usage         `json:"storage,omitempty"`
+	GeneratedAt        time.Time                 `json:"generated_at"`
+}
+
+// TeamDashboardMembers counts the team's members
+type TeamDashboardMembers struct {
+	Total    int            `json:"total"`
+	ByStatus map[string]int `json:"by_status"` // pending | active | inactive | suspended
+	ByType   map[string]int `json:"by_type"`   // user | robot
+}
+
+// TeamDashboardExecutions summarizes the team's latest robot executions
+type TeamDashboardExecutions struct {
+	Total int                      `json:"total"`
+	Items []TeamDashboardExecution `json:"items"`
+}
+
+// TeamDashboardExecution is one row of the recent executions list
+type TeamDashboardExecution struct {
+	ExecutionID string                 `json:"execution_id"`
+	MemberID    string                 `json:"member_id"`
+	Name        string                 `json:"name,omitempty"`
+	Status      robottypes.ExecStatus  `json:"status"`
+	TriggerType robottypes.TriggerType `json:"trigger_type"`
+	StartTime   time.Time              `json:"start_time"`
+	EndTime     *time.Time             `json:"end_time,omitempty"`
+}
+
+// Team Dashboard Handlers
+
+// GinTeamDashboard handles GET /teams/:id/dashboard - Consolidated team overview
+func GinTeamDashboard(c *gin.Context) {
+	authInfo := authorized.GetInfo(c)
+	if authInfo == nil || authInfo.UserID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidClient.Code,
+			ErrorDescription: "User not authenticated",
+		}
+		response.RespondWithError(c, response.StatusUnauthorized, errorResp)
+		return
+	}
+
+	teamID := c.Param("id")
+	if teamID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Team ID is required",
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	result, err := teamDashboard(c.Request.Context(), authInfo, teamID)
+	if err != nil {
+		log.Error("Failed to get dashboard for team %s: %v", teamID, err)
+		respondRobotMemberError(c, err, "Failed to retrieve team dashboard")
+		return
+	}
+
+	response.RespondWithSuccess(c, http.StatusOK, result)
+}
+
+// ProcessTeamDashboard user.team.dashboard Team dashboard processor
+// Args[0] string: team_id
+// Return: TeamDashboard
+func ProcessTeamDashboard(process *process.Process) interface{} {
+	process.ValidateArgNums(1)
+
+	userIDStr := GetUserIDFromSession(process)
+
+	teamID := process.ArgsString(0)
+	if teamID == "" {
+		exception.New("team_id is required", 400).Throw()
+	}
+
+	ctx := process.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	result, err := teamDashboard(ctx, &oauthtypes.AuthorizedInfo{UserID: userIDStr, TeamID: teamID}, teamID)
+	if err != nil {
+		exception.New("failed to get team dashboard: %s", 500, err.Error()).Throw()
+	}
+
+	return result
+}
+
+// teamDashboard assembles the team overview. Members are required; robots, executions
+// and storage come from optional subsystems and are left empty when unavailable.
+func teamDashboard(ctx context.Context, authInfo *oauthtypes.AuthorizedInfo, teamID string) (*TeamDashboard, error) {
+	isOwner, isMember, err := checkTeamAccess(ctx, teamID, authInfo.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !isOwner && !isMember {
+		return nil, fmt.Errorf("%w: user is not a member of this team", errAccessDenied)
+	}
+
+	provider, err := getUserProvider()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user provider: %w", err)
+	}
+
+	members, err := provider.GetTeamMembers(ctx, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve members: %w", err)
+	}
+
+	dashboard := &TeamDashboard{
+		TeamID: teamID,
+		Members: TeamDashboardMembers{
+			Total:    len(members),
+			ByStatus: map[string]int{},
+			ByType:   map[string]int{},
+		},
+		Robots:           []*robotapi.RobotOverview{},
+		RecentExecutions: TeamDashboardExecutions{Items: []TeamDashboardExecution{}},
+		GeneratedAt:      time.Now(),
+	}
+	for _, member := range members {
+		dashboard.Members.ByStatus[utils.ToString(member["status"])]++
+		dashboard.Members.ByType[utils.ToString(member["member_type"])]++
+	}
+	// Invitations are pending member rows
+	dashboard.PendingInvitations = dashboard.Members.ByStatus["pending"]
+
+	robotCtx := robottypes.NewContext(ctx, authInfo)
+	if overview, err := robotapi.GetRobotsOverview(robotCtx, teamID); err != nil {
+		log.Warn("team dashboard %s: robots unavailable: %v", teamID, err)
+	} else {
+		dashboard.Robots = overview.Robots
+	}
+
+	executions, err := robotapi.ListTeamExecutions(robotCtx, teamID, &robotapi.ExecutionQuery{Page: 1, PageSize: dashboardRecentExecutions})
+	if err != nil {
+		log.Warn("team dashboard %s: executions unavailable: %v", teamID, err)
+	} else {
+		dashboard.RecentExecutions.Total = executions.Total
+		for _, exec := range executions.Data {
+			dashboard.RecentExecutions.Items = append(dashboard.RecentExecutions.Items, TeamDashboardExecution{
+				ExecutionID: exec.ID,
+				MemberID:    exec.MemberID,
+				Name:        exec.Name,
+				Status:      exec.Status,
+				TriggerType: exec.TriggerType,
+				StartTime:   exec.StartTime,
+				EndTime:     exec.EndTime,
+			})
+		}
+	}
+
+	if usage, err := attachment.TeamUsage(ctx, teamID); err != nil {
+		log.Warn("team dashboard %s: storage usage unavailable: %v", teamID, err)
+	} else {
+		dashboard.Storage = usage
+	}
+
+	return dashboard, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -21,6 +22,7 @@ import (
 	messengertypes "github.com/yaoapp/yao/messenger/types"
 	"github.com/yaoapp/yao/openapi/oauth"
 	"github.com/yaoapp/yao/openapi/oauth/authorized"
+	"github.com/yaoapp/yao/openapi/oauth/providers/user"
 	oauthTypes "github.com/yaoapp/yao/openapi/oauth/types"
 	"github.com/yaoapp/yao/openapi/response"
 	"github.com/yaoapp/yao/openapi/utils"
@@ -478,6 +480,80 @@ func GinTeamInvitationDelete(c *gin.Context) {
 	response.RespondWithSuccess(c, http.StatusOK, gin.H{"message": "Invitation cancelled successfully"})
 }
 
+// GinTeamInvitationRevoke handles POST /teams/:team_id/invitations/:invitation_id/revoke - Revoke invitation, optionally notifying the invitee
+func GinTeamInvitationRevoke(c *gin.Context) {
+	// Get authorized user info
+	authInfo := oauth.GetAuthorizedInfo(c)
+	if authInfo == nil || authInfo.UserID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidClient.Code,
+			ErrorDescription: "User not authenticated",
+		}
+		response.RespondWithError(c, response.StatusUnauthorized, errorResp)
+		return
+	}
+
+	teamID := c.Param("id")
+	invitationID := c.Param("invitation_id")
+	if teamID == "" || invitationID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Team ID and Invitation ID are required",
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	// Body is optional: {"notify": true, "locale": "en"}
+	var requestBody struct {
+		Notify bool   `json:"notify"`
+		Locale string `json:"locale"`
+	}
+	_ = c.ShouldBindJSON(&requestBody)
+	locale := requestBody.Locale
+	if locale == "" {
+		locale = c.Query("locale")
+	}
+	if locale == "" {
+		locale = "en"
+	}
+
+	// Call business logic
+	err := teamInvitationRevoke(c.Request.Context(), authInfo.UserID, teamID, invitationID, locale, requestBody.Notify)
+	if err != nil {
+		log.Error("Failed to revoke invitation: %v", err)
+		// Check error type for appropriate response
+		if strings.Contains(err.Error(), "not found") {
+			errorResp := &response.ErrorResponse{
+				Code:             response.ErrInvalidRequest.Code,
+				ErrorDescription: "Invitation not found",
+			}
+			response.RespondWithError(c, response.StatusNotFound, errorResp)
+		} else if strings.Contains(err.Error(), "access denied") {
+			errorResp := &response.ErrorResponse{
+				Code:             response.ErrAccessDenied.Code,
+				ErrorDescription: err.Error(),
+			}
+			response.RespondWithError(c, response.StatusForbidden, errorResp)
+		} else if errors.Is(err, user.ErrInvitationAccepted) || errors.Is(err, user.ErrInvitationNotPending) {
+			errorResp := &response.ErrorResponse{
+				Code:             response.ErrInvalidRequest.Code,
+				ErrorDescription: err.Error(),
+			}
+			response.RespondWithError(c, response.StatusConflict, errorResp)
+		} else {
+			errorResp := &response.ErrorResponse{
+				Code:             response.ErrServerError.Code,
+				ErrorDescription: "Failed to revoke invitation",
+			}
+			response.RespondWithError(c, response.StatusInternalServerError, errorResp)
+		}
+		return
+	}
+
+	response.RespondWithSuccess(c, http.StatusOK, gin.H{"message": "Invitation revoked successfully"})
+}
+
 // GinTeamInvitationAccept handles POST /user/teams/invitations/:invitation_id/accept - Accept invitation and login to team
 func GinTeamInvitationAccept(c *gin.Context) {
 	// Get authorized user info
@@ -557,6 +633,11 @@ func GinTeamInvitationAccept(c *gin.Context) {
 
 	// Accept the invitation (will update user_id if invitation doesn't have one)
 	err = provider.AcceptInvitation(ctx, invitationID, req.Token, userID)
+	if errors.Is(err, user.ErrInvitationAccepted) {
+		// Double submit: the first request joined the team, this one just logs in
+		log.Info("Invitation %s already accepted by user %s", invitationID, userID)
+		err = nil
+	}
 	if err != nil {
 		log.Error("Failed to accept invitation: %v", err)
 		// Check error type for appropriate response
@@ -812,6 +893,51 @@ func ProcessTeamInvitationDelete(process *process.Process) interface{} {
 	}
 }
 
+// ProcessTeamInvitationRevoke user.team.invitation.revoke Team invitation revoke processor
+// Args[0] string: team_id
+// Args[1] string: invitation_id
+// Args[2] bool: notify (optional, default false) - email the invitee that the invitation was withdrawn
+// Args[3] string: locale (optional, default "en")
+// Return: map: {"message": "success"}
+func ProcessTeamInvitationRevoke(process *process.Process) interface{} {
+	process.ValidateArgNums(2)
+
+	// Get user_id from session
+	userIDStr := GetUserIDFromSession(process)
+
+	teamID := process.ArgsString(0)
+	invitationID := process.ArgsString(1)
+
+	if teamID == "" || invitationID == "" {
+		exception.New("team_id and invitation_id are required", 400).Throw()
+	}
+
+	notify := false
+	if process.NumOfArgs() > 2 {
+		notify = process.ArgsBool(2)
+	}
+	locale := "en"
+	if process.NumOfArgs() > 3 && process.ArgsString(3) != "" {
+		locale = process.ArgsString(3)
+	}
+
+	// Get context
+	ctx := process.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// Call business logic
+	err := teamInvitationRevoke(ctx, userIDStr, teamID, invitationID, locale, notify)
+	if err != nil {
+		exception.New("failed to revoke team invitation: %s", 500, err.Error()).Throw()
+	}
+
+	return map[string]interface{}{
+		"message": "success",
+	}
+}
+
 // Private Business Logic Functions (internal use only)
 
 // getAdminRoot returns the admin root path from share.App configuration
@@ -954,21 +1080,8 @@ func teamInvitationGetPublic(ctx context.Context, invitationID, locale string) (
 	}
 
 	// Check if invitation has expired
-	expiresAt := invitationData["invitation_expires_at"]
-	if expiresAt != nil {
-		var expiryTime time.Time
-		switch v := expiresAt.(type) {
-		case time.Time:
-			expiryTime = v
-		case string:
-			parsed, err := time.Parse(time.RFC3339, v)
-			if err == nil {
-				expiryTime = parsed
-			}
-		}
-		if !expiryTime.IsZero() && time.Now().After(expiryTime) {
-			return nil, fmt.Errorf("invitation has expired")
-		}
+	if user.InvitationExpired(invitationData["invitation_expires_at"], time.Now()) {
+		return nil, fmt.Errorf("invitation has expired")
 	}
 
 	// Get team information
@@ -1081,46 +1194,63 @@ func teamInvitationGet(ctx context.Context, userID, teamID, invitationID string)
 // 1. Email invitation: provide email and role, send invitation link via email
 // 2. Link invitation: create invitation link for display in frontend, customizable expiry
 func teamInvitationCreate(ctx context.Context, userID, teamID string, invitationData maps.MapStrAny) (string, error) {
-	// Remove empty string fields (should not be inserted to database)
-	for _, field := range []string{"user_id", "email", "message", "display_name", "bio"} {
-		if invitationData[field] == "" {
-			delete(invitationData, field)
-		}
+	team, err := loadInvitationTeam(ctx, userID, teamID)
+	if err != nil {
+		return "", err
 	}
+	invitationID, _, err := createTeamInvitation(ctx, team, userID, teamID, invitationData)
+	return invitationID, err
+}
+
+// invitationTeam is what every invitation of a team needs, loaded once per request
+type invitationTeam struct {
+	provider    *user.DefaultUser
+	teamName    string // for the email template
+	inviterName string // for the email template
+}
 
+// loadInvitationTeam checks the inviter owns the team and loads the team side of its invitations
+func loadInvitationTeam(ctx context.Context, userID, teamID string) (*invitationTeam, error) {
 	// Check if user has access to the team (write permission: owner only)
 	isOwner, _, err := checkTeamAccess(ctx, teamID, userID)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// Only allow access if user is owner
 	if !isOwner {
-		return "", fmt.Errorf("access denied: only team owner can send invitations")
+		return nil, fmt.Errorf("%w: only team owner can send invitations", errAccessDenied)
 	}
 
 	// Get user provider instance
 	provider, err := getUserProvider()
 	if err != nil {
-		return "", fmt.Errorf("failed to get user provider: %w", err)
+		return nil, fmt.Errorf("failed to get user provider: %w", err)
 	}
 
 	// Get team information for email template
 	team, err := provider.GetTeam(ctx, teamID)
 	if err != nil {
-		return "", fmt.Errorf("failed to get team information: %w", err)
+		return nil, fmt.Errorf("failed to get team information: %w", err)
 	}
-	teamName := utils.ToString(team["name"])
 
-	// Get inviter information for email template
-	inviter, err := provider.GetUser(ctx, userID)
-	if err != nil {
-		log.Warn("Failed to get inviter information: %v", err)
-		inviter = maps.MapStrAny{"name": "Team Admin"}
-	}
-	inviterName := utils.ToString(inviter["name"])
-	if inviterName == "" {
-		inviterName = utils.ToString(inviter["email"])
+	return &invitationTeam{
+		provider:    provider,
+		teamName:    utils.ToString(team["name"]),
+		inviterName: teamInvitationInviterName(ctx, provider, userID),
+	}, nil
+}
+
+// createTeamInvitation creates one invitation for a team loaded by loadInvitationTeam and
+// returns its invitation_id and member_id
+func createTeamInvitation(ctx context.Context, team *invitationTeam, userID, teamID string, invitationData maps.MapStrAny) (string, string, error) {
+	provider, teamName, inviterName := team.provider, team.teamName, team.inviterName
+
+	// Remove empty string fields (should not be inserted to database)
+	for _, field := range []string{"user_id", "email", "message", "display_name", "bio"} {
+		if invitationData[field] == "" {
+			delete(invitationData, field)
+		}
 	}
 
 	// Check if user is already a member or has pending invitation (if user_id is provided)
@@ -1134,17 +1264,17 @@ func teamInvitationCreate(ctx context.Context, userID, teamID string, invitation
 		inviteeUserID = utils.ToString(invitationData["user_id"])
 		exists, err := provider.MemberExists(ctx, teamID, inviteeUserID)
 		if err != nil {
-			return "", fmt.Errorf("failed to check member existence: %w", err)
+			return "", "", fmt.Errorf("failed to check member existence: %w", err)
 		}
 		if exists {
-			return "", fmt.Errorf("user is already a member or has a pending invitation")
+			return "", "", fmt.Errorf("user is already a member or has a pending invitation")
 		}
 
 		// If email not provided, get it from user profile
 		if inviteeEmail == "" {
 			user, err := provider.GetUser(ctx, inviteeUserID)
 			if err != nil {
-				return "", fmt.Errorf("failed to get user information: %w", err)
+				return "", "", fmt.Errorf("failed to get user information: %w", err)
 			}
 			inviteeEmail = utils.ToString(user["email"])
 
@@ -1170,19 +1300,19 @@ func teamInvitationCreate(ctx context.Context, userID, teamID string, invitation
 
 	// If send_email is true, email must be provided
 	if shouldSendEmail && inviteeEmail == "" {
-		return "", fmt.Errorf("email is required when send_email is true")
+		return "", "", fmt.Errorf("email is required when send_email is true")
 	}
 
 	// Generate invitation token
 	token, err := generateTeamInvitationToken()
 	if err != nil {
-		return "", fmt.Errorf("failed to generate invitation token: %w", err)
+		return "", "", fmt.Errorf("failed to generate invitation token: %w", err)
 	}
 
 	// Calculate expiry duration
 	expiryDuration, err := getTeamInvitationExpiry(invitationData)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse expiry duration: %w", err)
+		return "", "", fmt.Errorf("failed to parse expiry duration: %w", err)
 	}
 
 	// Save request_base_url and settings before database operation (they will be lost in DB)
@@ -1205,13 +1335,13 @@ func teamInvitationCreate(ctx context.Context, userID, teamID string, invitation
 	// Create invitation (as a pending member)
 	businessMemberID, err := provider.CreateMember(ctx, invitationData)
 	if err != nil {
-		return "", fmt.Errorf("failed to create invitation: %w", err)
+		return "", "", fmt.Errorf("failed to create invitation: %w", err)
 	}
 
 	// Get the created member to retrieve the generated invitation_id
 	createdMember, err := provider.GetMemberByMemberID(ctx, businessMemberID)
 	if err != nil {
-		return "", fmt.Errorf("failed to retrieve created invitation: %w", err)
+		return "", "", fmt.Errorf("failed to retrieve created invitation: %w", err)
 	}
 
 	// Get the generated invitation_id
@@ -1241,7 +1371,7 @@ func teamInvitationCreate(ctx context.Context, userID, teamID string, invitation
 		}()
 	}
 
-	return invitationID, nil
+	return invitationID, businessMemberID, nil
 }
 
 // teamInvitationResend handles the business logic for resending a team invitation
@@ -1293,15 +1423,7 @@ func teamInvitationResend(ctx context.Context, userID, teamID, invitationID, req
 	teamName := utils.ToString(team["name"])
 
 	// Get inviter information for email template
-	inviter, err := provider.GetUser(ctx, userID)
-	if err != nil {
-		log.Warn("Failed to get inviter information: %v", err)
-		inviter = maps.MapStrAny{"name": "Team Admin"}
-	}
-	inviterName := utils.ToString(inviter["name"])
-	if inviterName == "" {
-		inviterName = utils.ToString(inviter["email"])
-	}
+	inviterName := teamInvitationInviterName(ctx, provider, userID)
 
 	// Generate new invitation token
 	newToken, err := generateTeamInvitationToken()
@@ -1420,8 +1542,83 @@ func teamInvitationDelete(ctx context.Context, userID, teamID, invitationID stri
 	return nil
 }
 
+// teamInvitationRevoke withdraws a pending invitation and, when notify is set, tells the
+// invitee by email. Unlike teamInvitationDelete it refuses accepted invitations with a
+// clear error and leaves an audit log entry.
+func teamInvitationRevoke(ctx context.Context, userID, teamID, invitationID, locale string, notify bool) error {
+	// Check if user has access to the team (write permission: owner only)
+	isOwner, _, err := checkTeamAccess(ctx, teamID, userID)
+	if err != nil {
+		return err
+	}
+
+	// Only allow access if user is owner
+	if !isOwner {
+		return fmt.Errorf("access denied: only team owner can revoke invitations")
+	}
+
+	// Get user provider instance
+	provider, err := getUserProvider()
+	if err != nil {
+		return fmt.Errorf("failed to get user provider: %w", err)
+	}
+
+	// Verify invitation belongs to this team before touching it
+	invitationData, err := provider.GetMemberByInvitationID(ctx, invitationID)
+	if err != nil {
+		return fmt.Errorf("invitation not found: %w", err)
+	}
+	if utils.ToString(invitationData["team_id"]) != teamID {
+		return fmt.Errorf("invitation not found in this team")
+	}
+
+	revoked, err := provider.RevokeInvitation(ctx, invitationID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke invitation: %w", err)
+	}
+	log.Info("Invitation %s for team %s revoked by %s (notify: %t)", invitationID, teamID, userID, notify)
+
+	inviteeEmail := utils.ToString(revoked["email"])
+	if !notify || inviteeEmail == "" {
+		return nil
+	}
+
+	teamName := teamID
+	if team, err := provider.GetTeam(ctx, teamID); err == nil {
+		teamName = utils.ToString(team["name"])
+	}
+
+	// The invitation is gone either way; a failed notice is only logged
+	go func() {
+		bgCtx := context.Background()
+		bgCtx = context.WithValue(bgCtx, "identity", &oauthTypes.AuthorizedInfo{UserID: userID, TeamID: teamID})
+		if err := sendTeamInvitationRevokedEmail(bgCtx, inviteeEmail, teamName, invitationID, locale); err != nil {
+			log.Error("Failed to send invitation revocation email: %v", err)
+		} else {
+			log.Info("Invitation revocation email sent to %s for team %s (invitation_id: %s)", inviteeEmail, teamName, invitationID)
+		}
+	}()
+
+	return nil
+}
+
 // Private Helper Functions (internal use only)
 
+// teamInvitationInviterName returns the name shown as the sender of an invitation:
+// the user's name, their email, or "Team Admin" when the user cannot be read
+func teamInvitationInviterName(ctx context.Context, provider *user.DefaultUser, userID string) string {
+	inviter, err := provider.GetUser(ctx, userID)
+	if err != nil {
+		log.Warn("Failed to get inviter information: %v", err)
+		inviter = maps.MapStrAny{"name": "Team Admin"}
+	}
+	inviterName := utils.ToString(inviter["name"])
+	if inviterName == "" {
+		inviterName = utils.ToString(inviter["email"])
+	}
+	return inviterName
+}
+
 // generateTeamInvitationToken generates a secure random token for invitations
 func generateTeamInvitationToken() (string, error) {
 	bytes := make([]byte, 32) // 32 bytes = 256 bits
@@ -1566,6 +1763,42 @@ func sendTeamInvitationEmail(ctx context.Context, email, inviterName, teamName,
 	return nil
 }
 
+// sendTeamInvitationRevokedEmail tells the invitee that their invitation was withdrawn,
+// using the "revoke" template of the team invite config
+func sendTeamInvitationRevokedEmail(ctx context.Context, email, teamName, invitationID, locale string) error {
+	if messenger.Instance == nil {
+		return fmt.Errorf("messenger service not available")
+	}
+
+	teamConfig := GetTeamConfig(locale)
+	if teamConfig == nil || teamConfig.Invite == nil {
+		return fmt.Errorf("team configuration not found for locale: %s", locale)
+	}
+
+	emailTemplate := teamConfig.Invite.Templates["revoke"]
+	if emailTemplate == "" {
+		return fmt.Errorf("revocation email template not configured in team config")
+	}
+
+	channel := "default"
+	if teamConfig.Invite.Channel != "" {
+		channel = teamConfig.Invite.Channel
+	}
+
+	templateData := messengertypes.TemplateData{
+		"to":            email,
+		"team_name":     teamName,
+		"invitation_id": invitationID,
+	}
+
+	err := messenger.Instance.SendT(ctx, channel, emailTemplate, templateData, messengertypes.MessageTypeEmail)
+	if err != nil {
+		return fmt.Errorf("failed to send invitation revocation email: %w", err)
+	}
+
+	return nil
+}
+
 // convertToTeamInvitationResponse converts a map to InvitationResponse (alias for mapToTeamInvitationResponse)
 func convertToTeamInvitationResponse(data maps.MapStrAny, requestBaseURL string) InvitationResponse {
 	return mapToTeamInvitationResponse(maps.MapStr(data), requestBaseURL)
@@ -0,0 +1,131 @@
+package user
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/kun/log"
+	robotapi "github.com/yaoapp/yao/agent/robot/api"
+	robottypes "github.com/yaoapp/yao/agent/robot/types"
+	"github.com/yaoapp/yao/openapi/oauth/authorized"
+	oauthtypes "github.com/yaoapp/yao/openapi/oauth/types"
+	"github.com/yaoapp/yao/openapi/response"
+)
+
+// Team Robots Overview Handlers
+
+// GinTeamRobotsOverview handles GET /teams/:id/robots/overview - Execution badge counts for every robot in the team.
+// Designed for polling: responds 304 Not Modified when If-None-Match matches the current ETag.
+func GinTeamRobotsOverview(c *gin.Context) {
+	authInfo := authorized.GetInfo(c)
+	if authInfo == nil || authInfo.UserID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidClient.Code,
+			ErrorDescription: "User not authenticated",
+		}
+		response.RespondWithError(c, response.StatusUnauthorized, errorResp)
+		return
+	}
+
+	teamID := c.Param("id")
+	if teamID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Team ID is required",
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	result, err := teamRobotsOverview(c.Request.Context(), authInfo, teamID)
+	if err != nil {
+		log.Error("Failed to get robots overview for team %s: %v", teamID, err)
+		respondRobotMemberError(c, err, "Failed to retrieve robots overview")
+		return
+	}
+
+	if etag := overviewETag(result); etag != "" {
+		c.Header("ETag", etag)
+		c.Header("Cache-Control", "private, no-cache")
+		if c.GetHeader("If-None-Match") == etag {
+			c.Status(http.StatusNotModified)
+			return
+		}
+	}
+
+	response.RespondWithSuccess(c, http.StatusOK, result)
+}
+
+// GinTeamRobotsStatus handles GET /teams/:id/robots/status - Live status of every robot in the team,
+// working robots first
+func GinTeamRobotsStatus(c *gin.Context) {
+	authInfo := authorized.GetInfo(c)
+	if authInfo == nil || authInfo.UserID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidClient.Code,
+			ErrorDescription: "User not authenticated",
+		}
+		response.RespondWithError(c, response.StatusUnauthorized, errorResp)
+		return
+	}
+
+	teamID := c.Param("id")
+	if teamID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Team ID is required",
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	result, err := teamRobotsStatus(c.Request.Context(), authInfo, teamID)
+	if err != nil {
+		log.Error("Failed to get robots status for team %s: %v", teamID, err)
+		respondRobotMemberError(c, err, "Failed to retrieve robots status")
+		return
+	}
+
+	response.RespondWithSuccess(c, http.StatusOK, result)
+}
+
+// teamRobotsStatus handles the business logic for the team robots status board
+func teamRobotsStatus(ctx context.Context, authInfo *oauthtypes.AuthorizedInfo, teamID string) ([]*robotapi.RobotStatusEntry, error) {
+	isOwner, isMember, err := checkTeamAccess(ctx, teamID, authInfo.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !isOwner && !isMember {
+		return nil, fmt.Errorf("%w: user is not a member of this team", errAccessDenied)
+	}
+
+	return robotapi.GetRobotsStatus(robottypes.NewContext(ctx, authInfo), teamID)
+}
+
+// teamRobotsOverview handles the business logic for the team robots overview
+func teamRobotsOverview(ctx context.Context, authInfo *oauthtypes.AuthorizedInfo, teamID string) (*robotapi.RobotsOverview, error) {
+	isOwner, isMember, err := checkTeamAccess(ctx, teamID, authInfo.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !isOwner && !isMember {
+		return nil, fmt.Errorf("%w: user is not a member of this team", errAccessDenied)
+	}
+
+	return robotapi.GetRobotsOverview(robottypes.NewContext(ctx, authInfo), teamID)
+}
+
+// overviewETag derives a weak ETag from the badge data (generated_at excluded so unchanged counts match)
+func overviewETag(overview *robotapi.RobotsOverview) string {
+	raw, err := json.Marshal(overview.Robots)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(raw)
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`
+}
@@ -402,6 +402,9 @@ const (
 type TeamSettings struct {
 	Theme      string `json:"theme,omitempty"`      // Team UI theme (e.g., "light", "dark")
 	Visibility string `json:"visibility,omitempty"` // Team visibility (e.g., "public", "private")
+
+	// Language models the team's robots may use; empty means no restriction
+	AllowedLanguageModels []string `json:"allowed_language_models,omitempty"`
 }
 
 // MemberSettings represents member-specific settings
@@ -479,6 +482,7 @@ type MemberResponse struct {
 	RoleID              string          `json:"role_id"`
 	IsOwner             interface{}     `json:"is_owner,omitempty"` // Can be int or bool
 	Status              string          `json:"status"`
+	StatusReason        string          `json:"status_reason,omitempty"` // Why the status was last changed
 	InvitationID        string          `json:"invitation_id,omitempty"`
 	InvitedBy           string          `json:"invited_by,omitempty"`
 	InvitedAt           string          `json:"invited_at,omitempty"`
@@ -557,18 +561,42 @@ type UpdateRobotMemberRequest struct {
 	RobotStatus       string   `json:"robot_status,omitempty"`       // Robot status: idle, working, error
 }
 
+// PatchRobotMemberRequest represents a partial update of a robot member's config.
+// Only fields present in the body are changed: nil means omitted, while "" or []
+// clears the stored value.
+type PatchRobotMemberRequest struct {
+	SystemPrompt      *string  `json:"prompt"`             // Identity & role prompt
+	LanguageModel     *string  `json:"llm"`                // Language model (e.g., "gpt-4")
+	Agents            []string `json:"agents"`             // Accessible agents
+	MCPServers        []string `json:"mcp_tools"`          // MCP servers/tools
+	CostLimit         *float64 `json:"cost_limit"`         // Monthly cost limit in USD (0 = no limit)
+	AutonomousMode    *string  `json:"autonomous_mode"`    // "enabled" or "disabled"
+	AuthorizedSenders []string `json:"authorized_senders"` // Whitelist of emails authorized to send commands
+	EmailFilterRules  []string `json:"email_filter_rules"` // Email filtering rules (supports regex patterns)
+}
+
 // MemberListRequest represents the request to list team members with advanced filtering
 type MemberListRequest struct {
 	// Pagination
 	Page     int `json:"page" form:"page"`         // Page number (default: 1)
 	PageSize int `json:"pagesize" form:"pagesize"` // Page size (default: 20, max: 100)
 
+	// Cursor pagination, used when present: "" for the first page, then the returned
+	// next_cursor. Pages run newest first; page and order are ignored.
+	Cursor *string `json:"cursor,omitempty" form:"-"`
+
 	// Filters
 	Status      string `json:"status" form:"status"`             // Filter by status: pending, active, inactive, suspended
 	MemberType  string `json:"member_type" form:"member_type"`   // Filter by type: user, robot
-	RoleID      string `json:"role_id" form:"role_id"`           // Filter by role ID
-	Email       string `json:"email" form:"email"`               // Filter by email (exact match)
+	RoleID      string `json:"role_id" form:"role_id"`           // Filter by role ID, or any of several comma-separated
+	Email       string `json:"email" form:"email"`               // Filter by email (exact match, case-insensitive)
+	RobotEmail  string `json:"robot_email" form:"robot_email"`   // Filter by robot email (exact match, domain case-insensitive)
 	DisplayName string `json:"display_name" form:"display_name"` // Filter by display name (like match)
+	Keyword     string `json:"keyword" form:"keyword"`           // Search display_name, email, robot_email and bio (min 2 characters)
+
+	// Activity range (RFC3339): members never active are left out when either is set
+	ActiveBefore string `json:"active_before" form:"active_before"` // last_active_at <= active_before
+	ActiveAfter  string `json:"active_after" form:"active_after"`   // last_active_at >= active_after
 
 	// Sorting
 	Order string `json:"order" form:"order"` // Sort order: "field_name [asc|desc]" (e.g., "created_at desc", "joined_at asc"). Direction is optional, defaults to desc
@@ -581,8 +609,33 @@ type MemberListRequest struct {
 type UpdateMemberRequest struct {
 	RoleID       string          `json:"role_id,omitempty"`
 	Status       string          `json:"status,omitempty"`
+	StatusReason string          `json:"status_reason,omitempty"` // Optional, stored with the new status
 	Settings     *MemberSettings `json:"settings,omitempty"`
 	LastActivity string          `json:"last_activity,omitempty"`
+
+	// Robot members only; setting any of these on a user member is rejected
+	SystemPrompt   *string  `json:"system_prompt,omitempty"`   // Identity & role prompt
+	LanguageModel  *string  `json:"language_model,omitempty"`  // Language model (e.g., "gpt-4")
+	CostLimit      *float64 `json:"cost_limit,omitempty"`      // Monthly cost limit in USD (0 = no limit)
+	AutonomousMode *string  `json:"autonomous_mode,omitempty"` // "enabled" or "disabled"
+	Agents         []string `json:"agents,omitempty"`          // Accessible agents, replaces the stored list
+	MCPServers     []string `json:"mcp_servers,omitempty"`     // MCP servers/tools, replaces the stored list
+}
+
+// robotFields returns the robot fields present in the request as a robot patch, nil if none are
+func (req *UpdateMemberRequest) robotFields() *PatchRobotMemberRequest {
+	if req.SystemPrompt == nil && req.LanguageModel == nil && req.CostLimit == nil &&
+		req.AutonomousMode == nil && req.Agents == nil && req.MCPServers == nil {
+		return nil
+	}
+	return &PatchRobotMemberRequest{
+		SystemPrompt:   req.SystemPrompt,
+		LanguageModel:  req.LanguageModel,
+		CostLimit:      req.CostLimit,
+		AutonomousMode: req.AutonomousMode,
+		Agents:         req.Agents,
+		MCPServers:     req.MCPServers,
+	}
 }
 
 // UpdateMemberProfileRequest represents the request to update member profile information
@@ -594,6 +647,100 @@ type UpdateMemberProfileRequest struct {
 	Email       *string `json:"email,omitempty"`        // Email address (for display only)
 }
 
+// MemberExecutionListRequest represents the query of a robot member's execution history
+type MemberExecutionListRequest struct {
+	Page        int    `form:"page"`         // Page number (default: 1)
+	PageSize    int    `form:"pagesize"`     // Page size (default: 20, max: 100)
+	Status      string `form:"status"`       // Comma-separated statuses, e.g. "completed,failed"
+	TriggerType string `form:"trigger_type"` // clock | human | event
+	From        string `form:"from"`         // RFC3339, started at or after
+	To          string `form:"to"`           // RFC3339, started at or before
+	Detail      bool   `form:"detail"`       // Include tasks, results and resume_context
+}
+
+// BatchTriggerRequest represents the request to run a robot member once per parameter set
+type BatchTriggerRequest struct {
+	Items            []map[string]interface{} `json:"items" binding:"required"`    // Parameter sets, one execution each (max 1000)
+	SuppressDelivery bool                     `json:"suppress_delivery,omitempty"` // Skip per-item delivery, notify once when the batch completes
+}
+
+// EmailIngressRequest is an inbound email handed over by the mail gateway
+type EmailIngressRequest struct {
+	From      string `json:"from" binding:"required"` // From header, "Name <a@b.com>" or "a@b.com"
+	To        string `json:"to,omitempty"`            // Address the email was sent to
+	Subject   string `json:"subject,omitempty"`       // Subject line
+	Body      string `json:"body,omitempty"`          // Plain text body
+	MessageID string `json:"message_id,omitempty"`    // Message-ID header
+}
+
+// ReassignRobotsRequest moves all robots of a departing manager to another team member
+type ReassignRobotsRequest struct {
+	OldManagerID string `json:"old_manager_id" binding:"required"` // user_id of the current manager
+	NewManagerID string `json:"new_manager_id" binding:"required"` // user_id of the new manager (active team member)
+}
+
+// TransferOwnershipRequest hands a team over to another member
+type TransferOwnershipRequest struct {
+	MemberID            string `json:"member_id" binding:"required"`     // member_id of the new owner (active user member)
+	PreviousOwnerRoleID string `json:"previous_owner_role_id,omitempty"` // role for the previous owner (default: the configured default role)
+}
+
+// BulkInviteRequest invites several people to a team in one call
+type BulkInviteRequest struct {
+	Invitations []BulkInviteItem `json:"invitations" binding:"required"`
+	SendEmail   *bool            `json:"send_email,omitempty"` // Whether to email each invitee (defaults to true)
+	Locale      string           `json:"locale,omitempty"`     // Language code for the invitation emails
+	Message     string           `json:"message,omitempty"`    // Custom message included in every invitation
+}
+
+// BulkInviteItem is one invitee of a bulk invite
+type BulkInviteItem struct {
+	Email       string `json:"email"`
+	RoleID      string `json:"role_id"`
+	DisplayName string `json:"display_name,omitempty"`
+}
+
+// BulkInviteResult is the outcome of one bulk invite row
+type BulkInviteResult struct {
+	Email        string `json:"email"`
+	MemberID     string `json:"member_id,omitempty"`
+	InvitationID string `json:"invitation_id,omitempty"`
+	Status       string `json:"status"` // invited | failed
+	Error        string `json:"error,omitempty"`
+}
+
+// MemberInviteRequest invites one person to a team and emails them the invitation
+type MemberInviteRequest struct {
+	Email       string `json:"email" binding:"required"`   // Invitee's email address
+	RoleID      string `json:"role_id" binding:"required"` // Role given on acceptance
+	Message     string `json:"message,omitempty"`          // Custom message included in the email
+	DisplayName string `json:"display_name,omitempty"`     // Name shown until the invitee accepts
+	Locale      string `json:"locale,omitempty"`           // Language code for the email (default: en)
+}
+
+// MemberInviteResult is a pending invitation and whether its email went out. When the
+// email could not be sent the link is still valid and can be shared by hand.
+type MemberInviteResult struct {
+	MemberID            string `json:"member_id"`
+	InvitationID        string `json:"invitation_id"`
+	InvitationLink      string `json:"invitation_link"`
+	InvitationExpiresAt string `json:"invitation_expires_at,omitempty"`
+	EmailSent           bool   `json:"email_sent"`
+	EmailError          string `json:"email_error,omitempty"` // Why the email was not sent
+}
+
+// BulkStatusUpdateResult is the outcome of a bulk member status update
+type BulkStatusUpdateResult struct {
+	Updated int                     `json:"updated"`
+	Errors  []BulkStatusUpdateError `json:"errors"`
+}
+
+// BulkStatusUpdateError is a member a bulk status update skipped
+type BulkStatusUpdateError struct {
+	MemberID string `json:"member_id"`
+	Error    string `json:"error"`
+}
+
 // ==== Profile API Types ====
 
 // ProfileGetRequest represents the request to get user profile with optional expansions
@@ -745,6 +892,22 @@ type TeamConfig struct {
 	Invite *InviteConfig `json:"invite,omitempty"`
 	Type   string        `json:"type,omitempty"` // Default subscription type for new teams
 	Role   string        `json:"role,omitempty"` // Default user role for team creator
+
+	MemberSearch    *MemberSearchConfig    `json:"member_search,omitempty"`
+	FieldVisibility *FieldVisibilityConfig `json:"field_visibility,omitempty"`
+}
+
+// FieldVisibilityConfig hides member response fields from viewers by their team role.
+// Field names are the JSON keys of the member response, e.g. "cost_limit".
+type FieldVisibilityConfig struct {
+	Default []string            `json:"default,omitempty"` // Hidden from viewers whose role has no entry in Roles
+	Roles   map[string][]string `json:"roles,omitempty"`   // role_id -> fields hidden from viewers with that role
+}
+
+// MemberSearchConfig represents the member list/search configuration
+type MemberSearchConfig struct {
+	Cache bool   `json:"cache,omitempty"` // Cache member list results per team and query (default: off)
+	TTL   string `json:"ttl,omitempty"`   // Cache lifetime, e.g. "5s", "1m" (default: 5s)
 }
 
 // TeamRole represents a team role configuration
@@ -69,13 +69,29 @@ func init() {
 		"team.update": ProcessTeamUpdate,
 		"team.delete": ProcessTeamDelete,
 
+		// Team Dashboard
+		"team.dashboard": ProcessTeamDashboard,
+
+		// Team Access
+		"team.access": ProcessTeamAccess,
+
 		// Team Member Management
-		"member.list":           ProcessMemberList,
-		"member.get":            ProcessMemberGet,
-		"member.update":         ProcessMemberUpdate,
-		"member.profile.get":    ProcessMemberGetProfile,
-		"member.profile.update": ProcessMemberUpdateProfile,
-		"member.delete":         ProcessMemberDelete,
+		"member.list":            ProcessMemberList,
+		"member.get":             ProcessMemberGet,
+		"member.update":          ProcessMemberUpdate,
+		"member.profile.get":     ProcessMemberGetProfile,
+		"member.profile.update":  ProcessMemberUpdateProfile,
+		"member.delete":          ProcessMemberDelete,
+		"member.restore":         ProcessMemberRestore,
+		"member.robots.reassign": ProcessMemberReassignRobots,
+		"member.robot.patch":     ProcessMemberPatchRobot,
+		"member.bulk.invite":     ProcessMemberBulkInvite,
+
+		// Team Member Bulk Status
+		"member.bulk_update_status": ProcessMemberBulkStatusUpdate,
+
+		// Team Ownership
+		"member.ownership.transfer": ProcessMemberTransferOwnership,
 
 		// Team Invitation Management
 		"team.invitation.list":   ProcessTeamInvitationList,
@@ -83,6 +99,7 @@ func init() {
 		"team.invitation.create": ProcessTeamInvitationCreate,
 		"team.invitation.resend": ProcessTeamInvitationResend,
 		"team.invitation.delete": ProcessTeamInvitationDelete,
+		"team.invitation.revoke": ProcessTeamInvitationRevoke,
 	})
 }
 
@@ -142,7 +159,8 @@ func attachTeam(group *gin.RouterGroup, oauth types.OAuth) {
 	team.Use(oauth.Guard)
 
 	// Team Configuration
-	team.GET("/config", GinTeamConfig) // Get team configuration (public version, sensitive fields hidden)
+	team.GET("/config", GinTeamConfig)                         // Get team configuration (public version, sensitive fields hidden)
+	team.GET("/config/visible-fields", GinMemberVisibleFields) // GET /teams/config/visible-fields?role_id=xxx - Member fields visible to a role
 
 	// Team Selection
 	team.POST("/select", GinTeamSelection) // POST /teams/select - Select a team and issue tokens with team_id (requires authentication)
@@ -150,26 +168,60 @@ func attachTeam(group *gin.RouterGroup, oauth types.OAuth) {
 	team.PUT("/:id", GinTeamUpdate)        // PUT /teams/:id - Update team
 	team.DELETE("/:id", GinTeamDelete)     // DELETE /teams/:id - Delete team
 
+	// Team Ownership
+	team.POST("/:id/ownership", GinMemberTransferOwnership) // POST /teams/:id/ownership - Transfer ownership to another member (owner only)
+
 	// Get Current Team
 	team.GET("/current", GinTeamCurrent)
 
 	// Team Members - Nested resource endpoints
-	team.GET("/:id/members", GinMemberList)                              // GET /api/user/teams/:id/members - List team members
-	team.GET("/:id/members/check-robot-email", GinMemberCheckRobotEmail) // GET /api/user/teams/:id/members/check-robot-email?robot_email=xxx - Check if robot email exists globally
-	team.POST("/:id/members/robots", GinMemberCreateRobot)               // POST /api/user/teams/:id/members/robots - Add robot member
-	team.PUT("/:id/members/robots/:member_id", GinMemberUpdateRobot)     // PUT /api/user/teams/:id/members/robots/:member_id - Update robot member
-	team.GET("/:id/members/:member_id/profile", GinMemberGetProfile)     // GET /api/user/teams/:id/members/:member_id/profile - Get member profile (display_name, bio, avatar, email)
-	team.PUT("/:id/members/:member_id/profile", GinMemberUpdateProfile)  // PUT /api/user/teams/:id/members/:member_id/profile - Update member profile (display_name, bio, avatar, email)
-	team.GET("/:id/members/:member_id", GinMemberGet)                    // GET /api/user/teams/:id/members/:member_id - Get member details
-	team.PUT("/:id/members/:member_id", GinMemberUpdate)                 // PUT /api/user/teams/:id/members/:member_id - Update member (admin: role, status)
-	team.DELETE("/:id/members/:member_id", GinMemberDelete)              // DELETE /api/user/teams/:id/members/:member_id - Remove member
+	team.GET("/:id/members", GinMemberList)                                             // GET /api/user/teams/:id/members - List team members
+	team.GET("/:id/members/export", GinMemberExport)                                    // GET /api/user/teams/:id/members/export?format=csv - Download the member roster (csv, xlsx, json)
+	team.GET("/:id/members/check-robot-email", GinMemberCheckRobotEmail)                // GET /api/user/teams/:id/members/check-robot-email?robot_email=xxx - Check if robot email exists globally
+	team.POST("/:id/members/robots", robotPayloadLimit, GinMemberCreateRobot)           // POST /api/user/teams/:id/members/robots - Add robot member
+	team.PUT("/:id/members/robots/:member_id", robotPayloadLimit, GinMemberUpdateRobot) // PUT /api/user/teams/:id/members/robots/:member_id - Update robot member
+	team.POST("/:id/members/robots/reassign", GinMemberReassignRobots)                  // POST /api/user/teams/:id/members/robots/reassign - Move all robots of a departing manager
+	team.PATCH("/:id/members/:member_id/robot", robotPayloadLimit, GinMemberPatchRobot) // PATCH /api/user/teams/:id/members/:member_id/robot - Partially update robot config
+	team.POST("/:id/members/bulk-invite", GinMemberBulkInvite)                          // POST /api/user/teams/:id/members/bulk-invite - Invite several people, per-row results
+	team.POST("/:id/members/invitations", GinMemberInvite)                              // POST /api/user/teams/:id/members/invitations - Invite one person, email the link (returned for manual sharing)
+	team.POST("/:id/members/invitations/:invitation_id/resend", GinMemberInviteResend)  // POST /api/user/teams/:id/members/invitations/:invitation_id/resend - New token and expiry, email again
+	team.GET("/:id/members/:member_id/profile", GinMemberGetProfile)                    // GET /api/user/teams/:id/members/:member_id/profile - Get member profile (display_name, bio, avatar, email)
+	team.PUT("/:id/members/:member_id/profile", GinMemberUpdateProfile)                 // PUT /api/user/teams/:id/members/:member_id/profile - Update member profile (display_name, bio, avatar, email)
+	team.GET("/:id/members/:member_id", GinMemberGet)                                   // GET /api/user/teams/:id/members/:member_id - Get member details
+	team.PUT("/:id/members/:member_id", GinMemberUpdate)                                // PUT /api/user/teams/:id/members/:member_id - Update member (admin: role, status)
+	team.DELETE("/:id/members/:member_id", GinMemberDelete)                             // DELETE /api/user/teams/:id/members/:member_id - Remove member
+	team.GET("/:id/members/:member_id/audit", GinMemberAudit)                           // GET /api/user/teams/:id/members/:member_id/audit - Member change trail (owner only)
+	team.POST("/:id/members/:member_id/restore", GinMemberRestore)                      // POST /api/user/teams/:id/members/:member_id/restore - Restore a removed member (owner only)
+
+	// Robot Member Batch Triggers
+	team.POST("/:id/members/:member_id/trigger/batch", GinMemberTriggerBatch)            // POST /api/user/teams/:id/members/:member_id/trigger/batch - Run robot once per parameter set
+	team.GET("/:id/members/:member_id/batches/:batch_id", GinMemberGetBatch)             // GET /api/user/teams/:id/members/:member_id/batches/:batch_id - Get batch progress
+	team.POST("/:id/members/:member_id/batches/:batch_id/cancel", GinMemberCancelBatch)  // POST /api/user/teams/:id/members/:member_id/batches/:batch_id/cancel - Cancel remaining batch items
+	team.GET("/:id/members/:member_id/batches/:batch_id/summary", GinMemberBatchSummary) // GET /api/user/teams/:id/members/:member_id/batches/:batch_id/summary - Download batch results (CSV)
+
+	// Robot Member Email Ingress
+	team.POST("/:id/members/:member_id/email-ingress", robotPayloadLimit, GinMemberEmailIngress) // POST /api/user/teams/:id/members/:member_id/email-ingress - Hand an inbound email to a robot (mail gateway)
+
+	// Robot Member Capabilities
+	team.GET("/:id/members/:member_id/capabilities", GinMemberCapabilities) // GET /api/user/teams/:id/members/:member_id/capabilities - Get robot capability descriptor
+
+	// Robot Member Execution History
+	team.GET("/:id/members/:member_id/executions", GinMemberExecutions) // GET /api/user/teams/:id/members/:member_id/executions - List executions (paged, filtered)
+
+	// Team Robots Overview
+	team.GET("/:id/robots/overview", GinTeamRobotsOverview) // GET /api/user/teams/:id/robots/overview - Execution badge counts per robot (supports If-None-Match)
+	team.GET("/:id/robots/status", GinTeamRobotsStatus)     // GET /api/user/teams/:id/robots/status - Live status per robot, working first
+
+	// Team Dashboard
+	team.GET("/:id/dashboard", GinTeamDashboard) // GET /api/user/teams/:id/dashboard - Members, invitations, robots, recent executions and storage in one call
 
 	// Team Invitations - Nested resource endpoints
-	team.GET("/:id/invitations", GinTeamInvitationList)                         // GET /teams/:id/invitations - List invitations
-	team.POST("/:id/invitations", GinTeamInvitationCreate)                      // POST /teams/:id/invitations - Send invitation
-	team.GET("/:id/invitations/:invitation_id", GinTeamInvitationGet)           // GET /teams/:id/invitations/:invitation_id - Get invitation (admin)
-	team.PUT("/:id/invitations/:invitation_id/resend", GinTeamInvitationResend) // PUT /teams/:id/invitations/:invitation_id/resend - Resend invitation
-	team.DELETE("/:id/invitations/:invitation_id", GinTeamInvitationDelete)     // DELETE /teams/:id/invitations/:invitation_id - Cancel invitation
+	team.GET("/:id/invitations", GinTeamInvitationList)                          // GET /teams/:id/invitations - List invitations
+	team.POST("/:id/invitations", GinTeamInvitationCreate)                       // POST /teams/:id/invitations - Send invitation
+	team.GET("/:id/invitations/:invitation_id", GinTeamInvitationGet)            // GET /teams/:id/invitations/:invitation_id - Get invitation (admin)
+	team.PUT("/:id/invitations/:invitation_id/resend", GinTeamInvitationResend)  // PUT /teams/:id/invitations/:invitation_id/resend - Resend invitation
+	team.DELETE("/:id/invitations/:invitation_id", GinTeamInvitationDelete)      // DELETE /teams/:id/invitations/:invitation_id - Cancel invitation
+	team.POST("/:id/invitations/:invitation_id/revoke", GinTeamInvitationRevoke) // POST /teams/:id/invitations/:invitation_id/revoke - Revoke invitation, optionally notify invitee
 }
 
 // Invitation Response Management (Cross-module invitation handling)
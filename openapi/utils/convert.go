@@ -2,16 +2,32 @@ package utils
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 )
 
+var emailPattern = regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
+
 // NormalizeEmail returns a trimmed, lowercased email for case-insensitive matching.
 func NormalizeEmail(email string) string {
 	return strings.ToLower(strings.TrimSpace(email))
 }
 
+// NormalizeEmailAddress trims an address and lowercases its domain, keeping the
+// local part as entered. Clearly invalid addresses are rejected with an error.
+func NormalizeEmailAddress(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	if at := strings.LastIndex(email, "@"); at > 0 {
+		email = email[:at] + strings.ToLower(email[at:])
+	}
+	if !emailPattern.MatchString(email) {
+		return "", fmt.Errorf("invalid email address: %q", email)
+	}
+	return email, nil
+}
+
 // Type Conversion Utilities
 // These functions provide safe type conversion from interface{} to common types
 
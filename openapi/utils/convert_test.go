@@ -171,3 +171,32 @@ func TestTimeToNano(t *testing.T) {
 
 func strPtr(s string) *string { return &s }
 func int64Ptr(i int64) *int64 { return &i }
+
+func TestNormalizeEmailAddress(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect string
+		valid  bool
+	}{
+		{"Foo@Example.COM ", "Foo@example.com", true},
+		{"  user+tag@Domain.co.UK", "user+tag@domain.co.uk", true},
+		{"a@b.com", "a@b.com", true},
+		{"invalid", "", false},
+		{"@example.com", "", false},
+		{"user@", "", false},
+		{"user@@example.com", "", false},
+		{"us er@example.com", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := NormalizeEmailAddress(tt.input)
+			if !tt.valid {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expect, got)
+		})
+	}
+}
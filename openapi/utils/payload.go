@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/yao/openapi/response"
+)
+
+// BodyLimit rejects request bodies larger than limit() bytes with 413.
+// A declared Content-Length over the limit is rejected before anything is read;
+// otherwise the body is capped so binding stops at the limit (see IsBodyTooLarge).
+func BodyLimit(limit func() int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		max := limit()
+		if max <= 0 || c.Request.Body == nil {
+			c.Next()
+			return
+		}
+
+		if c.Request.ContentLength > max {
+			RespondBodyTooLarge(c, max)
+			c.Abort()
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, max)
+		c.Next()
+	}
+}
+
+// IsBodyTooLarge reports whether a bind error was caused by the BodyLimit cap
+func IsBodyTooLarge(err error) bool {
+	var maxErr *http.MaxBytesError
+	return errors.As(err, &maxErr)
+}
+
+// RespondBodyTooLarge writes the 413 response naming the limit
+func RespondBodyTooLarge(c *gin.Context, max int64) {
+	errorResp := &response.ErrorResponse{
+		Code:             response.ErrInvalidRequest.Code,
+		ErrorDescription: fmt.Sprintf("Request body exceeds max_body_bytes (%d)", max),
+	}
+	response.RespondWithError(c, http.StatusRequestEntityTooLarge, errorResp)
+}
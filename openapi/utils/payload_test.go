@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingReader yields size bytes of JSON without allocating them and records how much was read
+type countingReader struct {
+	size int64
+	read int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	if r.read >= r.size {
+		return 0, io.EOF
+	}
+	n := int64(len(p))
+	if rest := r.size - r.read; n > rest {
+		n = rest
+	}
+	for i := int64(0); i < n; i++ {
+		p[i] = ' '
+	}
+	r.read += n
+	return int(n), nil
+}
+
+func newLimitedRouter(max int64) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/robots", BodyLimit(func() int64 { return max }), func(c *gin.Context) {
+		var body map[string]interface{}
+		if err := c.ShouldBindJSON(&body); err != nil {
+			if IsBodyTooLarge(err) {
+				RespondBodyTooLarge(c, max)
+				return
+			}
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func TestBodyLimit(t *testing.T) {
+	const max = 1 << 20
+
+	t.Run("10MB body rejected before reading", func(t *testing.T) {
+		body := &countingReader{size: 10 << 20}
+		req := httptest.NewRequest(http.MethodPost, "/robots", body)
+		req.ContentLength = body.size
+		w := httptest.NewRecorder()
+
+		newLimitedRouter(max).ServeHTTP(w, req)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Contains(t, w.Body.String(), "max_body_bytes")
+		assert.Equal(t, int64(0), body.read)
+	})
+
+	t.Run("chunked body stops at the limit", func(t *testing.T) {
+		body := &countingReader{size: 10 << 20}
+		req := httptest.NewRequest(http.MethodPost, "/robots", body)
+		req.ContentLength = -1
+		w := httptest.NewRecorder()
+
+		newLimitedRouter(max).ServeHTTP(w, req)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.LessOrEqual(t, body.read, int64(max+64*1024))
+	})
+
+	t.Run("small body passes", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/robots", strings.NewReader(`{"agents":["a"]}`))
+		w := httptest.NewRecorder()
+
+		newLimitedRouter(max).ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
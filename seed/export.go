@@ -0,0 +1,457 @@
+package seed
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+	"github.com/yaoapp/gou/fs"
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/kun/maps"
+)
+
+// Export writes the rows of a model matching options.Query and options.WhereFilters to
+// filename on the seed filesystem, in a format Import reads back. The format defaults to
+// the file extension.
+func Export(modelName string, filename string, options ExportOption) (*ExportResult, error) {
+	format := options.Format
+	if format == "" {
+		format = ExportFormat(strings.TrimPrefix(strings.ToLower(filepath.Ext(filename)), "."))
+	}
+
+	param, err := exportQuery(options)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pages go through a pipe straight into the file instead of piling up in memory
+	type streamed struct {
+		result *ExportResult
+		err    error
+	}
+	reader, writer := io.Pipe()
+	done := make(chan streamed, 1)
+	go func() {
+		result, err := Stream(writer, modelName, param, StreamOption{
+			Format:        format,
+			ChunkSize:     options.ChunkSize,
+			Columns:       options.Columns,
+			MaxRows:       options.MaxRows,
+			IncludeHeader: options.IncludeHeader,
+		})
+		writer.CloseWithError(err)
+		done <- streamed{result: result, err: err}
+	}()
+
+	seedFS := fs.MustGet("seed")
+	_, writeErr := seedFS.Write(filename, reader, 0644)
+	if writeErr != nil {
+		writeErr = fmt.Errorf("failed to write %s: %w", filename, writeErr)
+	}
+	reader.CloseWithError(writeErr) // a failed write stops the export at its next page
+	out := <-done
+
+	err = out.err
+	if err == nil {
+		err = writeErr
+	}
+	if err != nil {
+		seedFS.Remove(filename)
+		return out.result, err
+	}
+	return out.result, nil
+}
+
+// Stream exports the rows of a model matching param to w, page by page.
+// Only one page is held in memory at a time; after every page the output is flushed,
+// and when w is an http.ResponseWriter (e.g. gin's) the flush reaches the client.
+func Stream(w io.Writer, modelName string, param model.QueryParam, options StreamOption) (*ExportResult, error) {
+	if !model.Exists(modelName) {
+		return nil, fmt.Errorf("model %s not found", modelName)
+	}
+	mod := model.Select(modelName)
+
+	if options.ChunkSize <= 0 {
+		options.ChunkSize = ChunkSizeDefault
+	}
+	if options.Format == "" {
+		options.Format = ExportFormatCSV
+	}
+	if options.MaxRows > 0 && options.MaxRows < options.ChunkSize {
+		options.ChunkSize = options.MaxRows
+	}
+	header := options.IncludeHeader == nil || *options.IncludeHeader
+
+	// Offset paging needs a stable order
+	if len(param.Orders) == 0 && mod.PrimaryKey != "" {
+		param.Orders = []model.QueryOrder{{Column: mod.PrimaryKey, Option: "asc"}}
+	}
+
+	columns := streamColumns(mod, param, options.Columns)
+	var out rowWriter
+	switch options.Format {
+	case ExportFormatCSV:
+		out = newCSVRowWriter(w, columns, header)
+	case ExportFormatJSON:
+		out = newJSONRowWriter(w, columns)
+	case ExportFormatXLSX:
+		out = newXLSXRowWriter(w, columns, header)
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", options.Format)
+	}
+
+	result := &ExportResult{}
+	if err := out.begin(); err != nil {
+		return result, err
+	}
+
+	for page := 1; ; page++ {
+		res, err := mod.Paginate(param, page, options.ChunkSize)
+		if err != nil {
+			return result, fmt.Errorf("failed to read page %d: %w", page, err)
+		}
+
+		rows := paginateRows(res)
+		last := len(rows) < options.ChunkSize || toInt(res["next"]) <= 0
+		if options.MaxRows > 0 && result.Total+len(rows) >= options.MaxRows {
+			rows = rows[:options.MaxRows-result.Total]
+			last = true
+		}
+		for _, row := range rows {
+			if options.Transform != nil {
+				if err := options.Transform(row); err != nil {
+					return result, err
+				}
+			}
+			if err := out.write(row); err != nil {
+				return result, err
+			}
+			result.Total++
+		}
+
+		if err := out.flush(); err != nil {
+			return result, err
+		}
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+
+		if last {
+			break
+		}
+	}
+
+	if err := out.end(); err != nil {
+		return result, err
+	}
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return result, nil
+}
+
+// SetStreamHeaders prepares an HTTP response for Stream. No Content-Length is sent, so
+// the server sends the body chunked as pages are flushed (an XLSX body only once complete).
+func SetStreamHeaders(header http.Header, format ExportFormat, filename string) {
+	switch format {
+	case ExportFormatJSON:
+		header.Set("Content-Type", "application/json; charset=utf-8")
+	case ExportFormatXLSX:
+		header.Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	default:
+		header.Set("Content-Type", "text/csv; charset=utf-8")
+	}
+	if filename != "" {
+		header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	}
+	header.Del("Content-Length")
+	header.Set("Cache-Control", "no-store")
+	header.Set("X-Accel-Buffering", "no") // keep reverse proxies from buffering the stream
+}
+
+// exportQuery merges the filter, select and order shortcuts of options into options.Query
+func exportQuery(options ExportOption) (model.QueryParam, error) {
+	param := options.Query
+	if len(options.WhereFilters) > 0 {
+		param.Wheres = append(append([]model.QueryWhere{}, param.Wheres...), options.WhereFilters...)
+	}
+	if len(options.Select) > 0 {
+		param.Select = make([]interface{}, len(options.Select))
+		for i, col := range options.Select {
+			param.Select[i] = col
+		}
+	}
+	if options.Order != "" {
+		orders, err := parseExportOrder(options.Order)
+		if err != nil {
+			return param, err
+		}
+		param.Orders = orders
+	}
+	return param, nil
+}
+
+// parseExportOrder reads "column [asc|desc], ..." into query orders
+func parseExportOrder(order string) ([]model.QueryOrder, error) {
+	orders := []model.QueryOrder{}
+	for _, part := range strings.Split(order, ",") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			continue
+		}
+		option := "asc"
+		if len(fields) > 1 {
+			option = strings.ToLower(fields[1])
+		}
+		if len(fields) > 2 || (option != "asc" && option != "desc") {
+			return nil, fmt.Errorf("invalid export order %q", strings.TrimSpace(part))
+		}
+		orders = append(orders, model.QueryOrder{Column: fields[0], Option: option})
+	}
+	return orders, nil
+}
+
+// streamColumns resolves the exported columns: explicit option, then the query's select, then every model column
+func streamColumns(mod *model.Model, param model.QueryParam, columns []string) []string {
+	if len(columns) > 0 {
+		return columns
+	}
+
+	for _, sel := range param.Select {
+		if name, ok := sel.(string); ok && name != "" && !strings.Contains(name, "*") {
+			columns = append(columns, name)
+		}
+	}
+	if len(columns) > 0 {
+		return columns
+	}
+
+	for name := range mod.Columns {
+		columns = append(columns, name)
+	}
+	sortColumns(columns)
+	return columns
+}
+
+// paginateRows extracts the rows of a Paginate result
+func paginateRows(res map[string]interface{}) []map[string]interface{} {
+	rows := []map[string]interface{}{}
+	switch data := res["data"].(type) {
+	case []maps.MapStrAny:
+		for _, row := range data {
+			rows = append(rows, row)
+		}
+	case []maps.MapStr:
+		for _, row := range data {
+			rows = append(rows, row)
+		}
+	case []map[string]interface{}:
+		rows = data
+	case []interface{}:
+		for _, item := range data {
+			switch row := item.(type) {
+			case maps.MapStrAny:
+				rows = append(rows, row)
+			case maps.MapStr:
+				rows = append(rows, row)
+			case map[string]interface{}:
+				rows = append(rows, row)
+			}
+		}
+	}
+	return rows
+}
+
+// formatExportValue renders a column value as a CSV cell that Import reads back
+func formatExportValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case []byte:
+		return string(val)
+	case time.Time:
+		return val.Format(time.RFC3339)
+	case *time.Time:
+		if val == nil {
+			return ""
+		}
+		return val.Format(time.RFC3339)
+	case map[string]interface{}, maps.MapStrAny, maps.MapStr, []interface{}:
+		raw, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprintf("%v", val)
+		}
+		return string(raw)
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
+
+// formatXLSXValue keeps numbers and booleans as typed cells, everything else is rendered like a CSV cell
+func formatXLSXValue(v interface{}) interface{} {
+	switch v.(type) {
+	case nil:
+		return nil
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return v
+	default:
+		return formatExportValue(v)
+	}
+}
+
+// rowWriter encodes exported rows in one format
+type rowWriter interface {
+	begin() error
+	write(row map[string]interface{}) error
+	flush() error
+	end() error
+}
+
+type csvRowWriter struct {
+	w       *csv.Writer
+	columns []string
+	header  bool
+}
+
+func newCSVRowWriter(w io.Writer, columns []string, header bool) *csvRowWriter {
+	return &csvRowWriter{w: csv.NewWriter(w), columns: columns, header: header}
+}
+
+func (c *csvRowWriter) begin() error {
+	if !c.header {
+		return nil
+	}
+	return c.w.Write(c.columns)
+}
+
+func (c *csvRowWriter) write(row map[string]interface{}) error {
+	record := make([]string, len(c.columns))
+	for i, col := range c.columns {
+		record[i] = formatExportValue(row[col])
+	}
+	return c.w.Write(record)
+}
+
+func (c *csvRowWriter) flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvRowWriter) end() error {
+	return c.flush()
+}
+
+// jsonRowWriter writes a JSON array of objects, the shape Import reads for .json seeds
+type jsonRowWriter struct {
+	w       io.Writer
+	columns []string
+	count   int
+}
+
+func newJSONRowWriter(w io.Writer, columns []string) *jsonRowWriter {
+	return &jsonRowWriter{w: w, columns: columns}
+}
+
+func (j *jsonRowWriter) begin() error {
+	_, err := io.WriteString(j.w, "[")
+	return err
+}
+
+func (j *jsonRowWriter) write(row map[string]interface{}) error {
+	obj := make(map[string]interface{}, len(j.columns))
+	for _, col := range j.columns {
+		if v, ok := row[col]; ok {
+			obj[col] = v
+		}
+	}
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	sep := ",\n"
+	if j.count == 0 {
+		sep = "\n"
+	}
+	j.count++
+	if _, err := io.WriteString(j.w, sep); err != nil {
+		return err
+	}
+	_, err = j.w.Write(raw)
+	return err
+}
+
+func (j *jsonRowWriter) flush() error { return nil }
+
+func (j *jsonRowWriter) end() error {
+	_, err := io.WriteString(j.w, "\n]\n")
+	return err
+}
+
+// xlsxRowWriter builds the workbook with excelize's stream writer and writes it out at
+// the end: the zip container can't be sent before it is complete.
+type xlsxRowWriter struct {
+	w       io.Writer
+	columns []string
+	file    *excelize.File
+	sheet   *excelize.StreamWriter
+	row     int
+	header  bool
+}
+
+func newXLSXRowWriter(w io.Writer, columns []string, header bool) *xlsxRowWriter {
+	return &xlsxRowWriter{w: w, columns: columns, header: header}
+}
+
+func (x *xlsxRowWriter) begin() error {
+	x.file = excelize.NewFile()
+	sheet, err := x.file.NewStreamWriter(x.file.GetSheetName(0))
+	if err != nil {
+		return err
+	}
+	x.sheet = sheet
+	if !x.header {
+		return nil
+	}
+
+	header := make([]interface{}, len(x.columns))
+	for i, col := range x.columns {
+		header[i] = col
+	}
+	return x.setRow(header)
+}
+
+func (x *xlsxRowWriter) write(row map[string]interface{}) error {
+	values := make([]interface{}, len(x.columns))
+	for i, col := range x.columns {
+		values[i] = formatXLSXValue(row[col])
+	}
+	return x.setRow(values)
+}
+
+func (x *xlsxRowWriter) setRow(values []interface{}) error {
+	x.row++
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+	return x.sheet.SetRow(cell, values)
+}
+
+func (x *xlsxRowWriter) flush() error { return nil }
+
+func (x *xlsxRowWriter) end() error {
+	defer x.file.Close()
+	if err := x.sheet.Flush(); err != nil {
+		return err
+	}
+	return x.file.Write(x.w)
+}
@@ -0,0 +1,187 @@
+package seed
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/gou/fs"
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/test"
+)
+
+// TestSeedStreamCSV tests streaming a model to an HTTP response page by page
+func TestSeedStreamCSV(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+
+	if !model.Exists("__yao.role") {
+		t.Skip("__yao.role model not loaded, skipping test")
+	}
+
+	mod := model.Select("__yao.role")
+	_, _ = mod.DestroyWhere(model.QueryParam{})
+	imported, err := Import("roles.csv", "__yao.role", ImportOption{ChunkSize: 100, Duplicate: DuplicateIgnore, Mode: ImportModeBatch})
+	assert.Nil(t, err)
+	assert.Greater(t, imported.Success, 1, "Need at least 2 roles to page")
+
+	rec := httptest.NewRecorder()
+	SetStreamHeaders(rec.Header(), ExportFormatCSV, "roles.csv")
+
+	// One row per page forces several flushes
+	result, err := Stream(rec, "__yao.role", model.QueryParam{}, StreamOption{
+		Format:    ExportFormatCSV,
+		ChunkSize: 1,
+		Columns:   []string{"role_id", "name"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, imported.Success, result.Total)
+	assert.True(t, rec.Flushed, "Response should be flushed while streaming")
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Empty(t, rec.Header().Get("Content-Length"))
+
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"role_id", "name"}, records[0])
+	assert.Len(t, records, result.Total+1)
+}
+
+// TestSeedStreamJSON tests that a JSON stream is a valid seed file
+func TestSeedStreamJSON(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+
+	if !model.Exists("__yao.role") {
+		t.Skip("__yao.role model not loaded, skipping test")
+	}
+
+	mod := model.Select("__yao.role")
+	_, _ = mod.DestroyWhere(model.QueryParam{})
+	_, err := Import("roles.csv", "__yao.role", ImportOption{ChunkSize: 100, Duplicate: DuplicateIgnore, Mode: ImportModeBatch})
+	assert.Nil(t, err)
+
+	var buf bytes.Buffer
+	result, err := Stream(&buf, "__yao.role", model.QueryParam{Select: []interface{}{"role_id", "name"}}, StreamOption{
+		Format:    ExportFormatJSON,
+		ChunkSize: 2,
+	})
+	assert.Nil(t, err)
+
+	var rows []map[string]interface{}
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &rows))
+	assert.Len(t, rows, result.Total)
+	if len(rows) > 0 {
+		assert.Contains(t, rows[0], "role_id")
+		assert.NotContains(t, rows[0], "id")
+	}
+
+	// Empty result is still a valid document
+	buf.Reset()
+	result, err = Stream(&buf, "__yao.role", model.QueryParam{
+		Wheres: []model.QueryWhere{{Column: "role_id", Value: "__no_such_role__"}},
+	}, StreamOption{Format: ExportFormatJSON})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, result.Total)
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &rows))
+	assert.Len(t, rows, 0)
+
+	_, err = Stream(&buf, "__yao.role", model.QueryParam{}, StreamOption{Format: "xml"})
+	assert.NotNil(t, err)
+}
+
+// TestSeedExportXLSX tests that an exported workbook imports back into the model
+func TestSeedExportXLSX(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+
+	if !model.Exists("__yao.role") {
+		t.Skip("__yao.role model not loaded, skipping test")
+	}
+
+	mod := model.Select("__yao.role")
+	_, _ = mod.DestroyWhere(model.QueryParam{})
+	imported, err := Import("roles.csv", "__yao.role", ImportOption{ChunkSize: 100, Duplicate: DuplicateIgnore, Mode: ImportModeBatch})
+	assert.Nil(t, err)
+
+	filename := "roles_export_test.xlsx"
+	defer fs.MustGet("seed").Remove(filename)
+
+	result, err := Export("__yao.role", filename, ExportOption{
+		ChunkSize: 2,
+		Columns:   []string{"role_id", "name", "description"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, imported.Success, result.Total)
+
+	_, _ = mod.DestroyWhere(model.QueryParam{})
+	reimported, err := Import(filename, "__yao.role", ImportOption{ChunkSize: 100, Duplicate: DuplicateIgnore, Mode: ImportModeBatch})
+	assert.Nil(t, err)
+	assert.Equal(t, result.Total, reimported.Success)
+
+	_, err = Export("__yao.role", "roles_export_test.xml", ExportOption{})
+	assert.NotNil(t, err)
+}
+
+// TestSeedExportRoundTrip tests JSON seed -> CSV export -> CSV import, and the export filters
+func TestSeedExportRoundTrip(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+
+	if !model.Exists("__yao.role") {
+		t.Skip("__yao.role model not loaded, skipping test")
+	}
+
+	mod := model.Select("__yao.role")
+	_, _ = mod.DestroyWhere(model.QueryParam{})
+	imported, err := Import("roles.json", "__yao.role", ImportOption{ChunkSize: 100, Duplicate: DuplicateIgnore, Mode: ImportModeBatch})
+	assert.Nil(t, err)
+	assert.Greater(t, imported.Success, 1, "Need at least 2 roles")
+
+	filename := "roles_roundtrip_test.csv"
+	seedFS := fs.MustGet("seed")
+	defer seedFS.Remove(filename)
+
+	result, err := Export("__yao.role", filename, ExportOption{
+		ChunkSize: 1,
+		Select:    []string{"role_id", "name", "description"},
+		Order:     "role_id desc",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, imported.Success, result.Total)
+
+	_, _ = mod.DestroyWhere(model.QueryParam{})
+	reimported, err := Import(filename, "__yao.role", ImportOption{ChunkSize: 100, Duplicate: DuplicateIgnore, Mode: ImportModeBatch})
+	assert.Nil(t, err)
+	assert.Equal(t, imported.Success, reimported.Success)
+
+	// MaxRows and IncludeHeader
+	noHeader := false
+	result, err = Export("__yao.role", filename, ExportOption{
+		ChunkSize:     5,
+		Select:        []string{"role_id"},
+		MaxRows:       1,
+		IncludeHeader: &noHeader,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result.Total)
+	raw, err := seedFS.ReadFile(filename)
+	assert.Nil(t, err)
+	records, err := csv.NewReader(bytes.NewReader(raw)).ReadAll()
+	assert.Nil(t, err)
+	assert.Len(t, records, 1)
+	assert.NotEqual(t, "role_id", records[0][0])
+
+	// WhereFilters
+	result, err = Export("__yao.role", filename, ExportOption{
+		WhereFilters: []model.QueryWhere{{Column: "role_id", Value: records[0][0]}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result.Total)
+
+	_, err = Export("__yao.role", filename, ExportOption{Order: "role_id sideways"})
+	assert.NotNil(t, err)
+}
@@ -1,5 +1,7 @@
 package seed
 
+import "github.com/yaoapp/gou/model"
+
 // DuplicateMode the duplicate mode
 type DuplicateMode string
 
@@ -35,6 +37,52 @@ type ImportOption struct {
 	Mode      ImportMode    `json:"mode,omitempty"`
 }
 
+// ExportFormat the export format
+type ExportFormat string
+
+const (
+	// ExportFormatCSV exports rows as CSV with a header line
+	ExportFormatCSV ExportFormat = "csv"
+	// ExportFormatJSON exports rows as a JSON array of objects
+	ExportFormatJSON ExportFormat = "json"
+	// ExportFormatXLSX exports rows as the first sheet of an Excel workbook with a header row
+	ExportFormatXLSX ExportFormat = "xlsx"
+)
+
+// StreamOption the seed stream export option
+type StreamOption struct {
+	Format    ExportFormat `json:"format,omitempty"`
+	ChunkSize int          `json:"chunk_size,omitempty"` // rows per page, default ChunkSizeDefault
+	Columns   []string     `json:"columns,omitempty"`    // exported columns in order, default the query select or all model columns
+	MaxRows   int          `json:"max_rows,omitempty"`   // stop after this many rows, 0 for all
+
+	// IncludeHeader writes the column names as the first CSV/XLSX row, default true.
+	// Import needs the header to map columns, leave it on for seed files.
+	IncludeHeader *bool `json:"include_header,omitempty"`
+
+	// Transform, when set, is applied to every row before it is written (e.g. to decrypt columns)
+	Transform func(row map[string]interface{}) error `json:"-"`
+}
+
+// ExportOption the seed file export option
+type ExportOption struct {
+	Format    ExportFormat     `json:"format,omitempty"`     // default from the file extension
+	ChunkSize int              `json:"chunk_size,omitempty"` // rows per page, default ChunkSizeDefault
+	Columns   []string         `json:"columns,omitempty"`    // exported columns in order
+	Query     model.QueryParam `json:"query,omitempty"`      // rows to export, default all
+
+	WhereFilters  []model.QueryWhere `json:"wheres,omitempty"`         // added to the conditions of Query
+	Select        []string           `json:"select,omitempty"`         // columns read from the model, replaces Query.Select
+	Order         string             `json:"order,omitempty"`          // e.g. "name asc, id desc", replaces Query.Orders
+	MaxRows       int                `json:"max_rows,omitempty"`       // stop after this many rows, 0 for all
+	IncludeHeader *bool              `json:"include_header,omitempty"` // see StreamOption.IncludeHeader, default true
+}
+
+// ExportResult the seed export result
+type ExportResult struct {
+	Total int `json:"total"`
+}
+
 // ImportHandler the seed import handler
 type ImportHandler func(line int, data [][]interface{}) error
 
@@ -225,6 +225,7 @@ var testSystemModels = map[string]string{
 	"__yao.kb.document":        "yao/models/kb/document.mod.yao",
 	"__yao.team":               "yao/models/team.mod.yao",
 	"__yao.member":             "yao/models/member.mod.yao",
+	"__yao.member.audit":       "yao/models/member_audit.mod.yao",
 	"__yao.user":               "yao/models/user.mod.yao",
 	"__yao.role":               "yao/models/role.mod.yao",
 	"__yao.user.type":          "yao/models/user/type.mod.yao",